@@ -31,6 +31,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/pkg/api"
@@ -43,7 +44,11 @@ import (
 
 // InjectorControllerOptions is a struct having injector controller options values
 type InjectorControllerOptions struct {
-	Namespace               string
+	Namespace string
+	// WatchedNamespaces, if set, limits cainjector to reading CA data from
+	// Certificates and Secrets in these namespaces.
+	WatchedNamespaces []string
+
 	LeaderElect             bool
 	LeaderElectionNamespace string
 	LeaseDuration           time.Duration
@@ -69,6 +74,11 @@ func (o *InjectorControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"If set, this limits the scope of cainjector to a single namespace. "+
 		"If set, cainjector will not update resources with certificates outside of the "+
 		"configured namespace.")
+	fs.StringSliceVar(&o.WatchedNamespaces, "watched-namespaces", nil, ""+
+		"Comma separated list of namespaces to limit the scope of cainjector to. "+
+		"If set, cainjector will only read CA data from Certificates and Secrets in these "+
+		"namespaces, and will not update resources with CA data from any other namespace. "+
+		"Cannot be used together with --namespace.")
 	fs.BoolVar(&o.LeaderElect, "leader-elect", cmdutil.DefaultLeaderElect, ""+
 		"If true, cainjector will perform leader election between instances to ensure no more "+
 		"than one instance of cainjector operates at a time")
@@ -94,6 +104,28 @@ func (o *InjectorControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
 }
 
+// Validate checks that the injector controller options are consistent.
+func (o *InjectorControllerOptions) Validate() error {
+	if o.Namespace != "" && len(o.WatchedNamespaces) > 0 {
+		return fmt.Errorf("only one of --namespace and --watched-namespaces may be specified")
+	}
+	for _, ns := range o.WatchedNamespaces {
+		if ns == "" {
+			return fmt.Errorf("--watched-namespaces must not contain an empty namespace")
+		}
+	}
+	return nil
+}
+
+// watchedNamespaces returns the namespaces cainjector is limited to, or nil
+// if it watches all namespaces.
+func (o *InjectorControllerOptions) watchedNamespaces() []string {
+	if o.Namespace != "" {
+		return []string{o.Namespace}
+	}
+	return o.WatchedNamespaces
+}
+
 // NewInjectorControllerOptions returns a new InjectorControllerOptions
 func NewInjectorControllerOptions(out, errOut io.Writer) *InjectorControllerOptions {
 	o := &InjectorControllerOptions{
@@ -123,6 +155,10 @@ servers and webhook servers.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.log = logf.Log.WithName("ca-injector")
 
+			if err := o.Validate(); err != nil {
+				return fmt.Errorf("error validating options: %s", err)
+			}
+
 			logf.V(logf.InfoLevel).InfoS("starting", "version", util.AppVersion, "revision", util.AppGitCommit)
 			return o.RunInjectorController(ctx)
 		},
@@ -135,9 +171,22 @@ servers and webhook servers.`,
 }
 
 func (o InjectorControllerOptions) RunInjectorController(ctx context.Context) error {
+	namespaces := o.watchedNamespaces()
+	var (
+		namespace string
+		newCache  cache.NewCacheFunc
+	)
+	switch len(namespaces) {
+	case 0:
+	case 1:
+		namespace = namespaces[0]
+	default:
+		newCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                        api.Scheme,
-		Namespace:                     o.Namespace,
+		Namespace:                     namespace,
+		NewCache:                      newCache,
 		LeaderElection:                o.LeaderElect,
 		LeaderElectionNamespace:       o.LeaderElectionNamespace,
 		LeaderElectionID:              "cert-manager-cainjector-leader-election",
@@ -215,7 +264,7 @@ func (o InjectorControllerOptions) RunInjectorController(ctx context.Context) er
 	// Never retry if the controller exits cleanly.
 	g.Go(func() (err error) {
 		for {
-			err = cainjector.RegisterCertificateBased(gctx, mgr)
+			err = cainjector.RegisterCertificateBased(gctx, mgr, namespaces)
 			if err == nil {
 				return
 			}
@@ -234,7 +283,7 @@ func (o InjectorControllerOptions) RunInjectorController(ctx context.Context) er
 	// We do not retry this controller because it only interacts with core APIs
 	// which should always be in a working state.
 	g.Go(func() (err error) {
-		if err = cainjector.RegisterSecretBased(gctx, mgr); err != nil {
+		if err = cainjector.RegisterSecretBased(gctx, mgr, namespaces); err != nil {
 			return fmt.Errorf("error registering secret controller: %v", err)
 		}
 		return
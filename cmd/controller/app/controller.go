@@ -23,9 +23,11 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"text/template"
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 	"k8s.io/apimachinery/pkg/api/resource"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
@@ -38,6 +40,8 @@ import (
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
+	acmehealth "github.com/cert-manager/cert-manager/pkg/acme/health"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
@@ -95,6 +99,41 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		return nil
 	})
 
+	// Start the ACME directory health check server if it is enabled
+	if opts.ACMEDirectoryHealthCheckAddress != "" {
+		healthLn, err := net.Listen("tcp", opts.ACMEDirectoryHealthCheckAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on ACME directory health check address %s: %v", opts.ACMEDirectoryHealthCheckAddress, err)
+		}
+		healthMux := http.NewServeMux()
+		healthMux.Handle(acmehealth.Path, acmehealth.NewChecker(
+			acmehealth.IssuerDirectoryLister(ctx.CMClient, ctx.Namespace),
+			opts.ACMEDirectoryHealthCheckCacheDuration,
+		))
+		healthServer := &http.Server{
+			Handler: healthMux,
+		}
+
+		g.Go(func() error {
+			<-rootCtx.Done()
+			// allow a timeout for graceful shutdown
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := healthServer.Shutdown(ctx); err != nil {
+				return err
+			}
+			return nil
+		})
+		g.Go(func() error {
+			log.V(logf.InfoLevel).Info("starting ACME directory health check server", "address", healthLn.Addr())
+			if err := healthServer.Serve(healthLn); err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		})
+	}
+
 	// Start profiler if it is enabled
 	if opts.EnablePprof {
 		profilerLn, err := net.Listen("tcp", opts.PprofAddress)
@@ -261,8 +300,28 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		return nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceLimitsMemory: %w", err)
 	}
 
+	var certificateRequestNameTemplate *template.Template
+	if opts.CertificateRequestNameTemplate != "" {
+		certificateRequestNameTemplate, err = apiutil.ParseCertificateRequestNameTemplate(opts.CertificateRequestNameTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing CertificateRequestNameTemplate: %w", err)
+		}
+	}
+
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
+	// A single limiter is shared by every signing controller so that the
+	// limit applies to the total number of in-flight signings.
+	var signingLimiter *semaphore.Weighted
+	if opts.MaxConcurrentSignings > 0 {
+		signingLimiter = semaphore.NewWeighted(int64(opts.MaxConcurrentSignings))
+	}
+
+	var metricsOpts []metrics.Option
+	if opts.MetricsIssuerLabels {
+		metricsOpts = append(metricsOpts, metrics.WithIssuerLabels())
+	}
+
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
 		Kubeconfig:         opts.Kubeconfig,
 		KubernetesAPIQPS:   opts.KubernetesAPIQPS,
@@ -271,8 +330,9 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 
 		Namespace: opts.Namespace,
 
-		Clock:   clock.RealClock{},
-		Metrics: metrics.New(log, clock.RealClock{}),
+		Clock:     clock.RealClock{},
+		Metrics:   metrics.New(log, clock.RealClock{}, metricsOpts...),
+		UserAgent: opts.IssuerUserAgent,
 
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
@@ -286,8 +346,16 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			DNS01Nameservers:        nameservers,
 			DNS01CheckRetryPeriod:   opts.DNS01CheckRetryPeriod,
 			DNS01CheckAuthoritative: !opts.DNS01RecursiveNameserversOnly,
+			DNS01NameserverStrategy: dnsutil.NameserverStrategy(opts.DNS01RecursiveNameserversStrategy),
+
+			MaxConcurrentDNS01SelfChecks: opts.MaxConcurrentDNS01SelfChecks,
 
 			AccountRegistry: acmeAccountRegistry,
+			EABKeyDir:       opts.ACMEEABKeyDir,
+
+			ChallengeForceCleanUpOnIssuerDeletion: opts.ACMEChallengeForceCleanUpOnIssuerDeletion,
+
+			OrdersListInterval: opts.ACMEOrdersListInterval,
 		},
 
 		SchedulerOptions: controller.SchedulerOptions{
@@ -298,6 +366,7 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			ClusterIssuerAmbientCredentials: opts.ClusterIssuerAmbientCredentials,
 			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
 			ClusterResourceNamespace:        opts.ClusterResourceNamespace,
+			SigningLimiter:                  signingLimiter,
 		},
 
 		IngressShimOptions: controller.IngressShimOptions{
@@ -308,8 +377,19 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		},
 
 		CertificateOptions: controller.CertificateOptions{
-			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
-			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+			EnableOwnerRef:                    opts.EnableCertificateOwnerRef,
+			CopiedAnnotationPrefixes:          opts.CopiedAnnotationPrefixes,
+			NotBeforeTolerance:                opts.CertificateNotBeforeTolerance,
+			ExpirationImminentWindow:          opts.CertificateExpirationImminentWindow,
+			CertificateRequestMaxRetryBackoff: opts.CertificateRequestMaxRetryBackoff,
+			DisableTemporaryCertificates:      opts.DisableTemporaryCertificates,
+			CertificateRequestNameTemplate:    certificateRequestNameTemplate,
+			MaxIssuanceAttempts:               opts.MaxCertificateIssuanceAttempts,
+			SkipUnchangedSecretWrites:         opts.SkipUnchangedSecretWrites,
+			RenewalJitterPercent:              opts.CertificateRenewalJitterPercent,
+			IssuedWebhookURL:                  opts.CertificateIssuedWebhookURL,
+			IssuedWebhookTimeout:              opts.CertificateIssuedWebhookTimeout,
+			IssuedWebhookMaxRetries:           opts.CertificateIssuedWebhookMaxRetries,
 		},
 	})
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 
 	cmdutil "github.com/cert-manager/cert-manager/cmd/util"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cm "github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	challengescontroller "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
@@ -53,6 +55,7 @@ import (
 	csrvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/venafi"
 	clusterissuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	issuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/issuers"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -82,10 +85,25 @@ type ControllerOptions struct {
 	ACMEHTTP01SolverResourceLimitsMemory  string
 	// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 	ACMEHTTP01SolverNameservers []string
+	// ACMEEABKeyDir is the directory from which ACME external account
+	// binding keys referenced using keyFile are read.
+	ACMEEABKeyDir string
+	// ACMEChallengeForceCleanUpOnIssuerDeletion causes the resources
+	// presented for ACME challenges to be cleaned up if their issuer has
+	// been deleted.
+	ACMEChallengeForceCleanUpOnIssuerDeletion bool
+	// ACMEOrdersListInterval is how often the orders of ACME accounts are
+	// listed to record the number of orders in the issuers' status.
+	// Zero disables listing orders.
+	ACMEOrdersListInterval time.Duration
 
 	ClusterIssuerAmbientCredentials bool
 	IssuerAmbientCredentials        bool
 
+	// IssuerUserAgent is the User-Agent sent in HTTP requests made by
+	// issuers and DNS01 providers.
+	IssuerUserAgent string
+
 	// Default issuer/certificates details consumed by ingress-shim
 	DefaultIssuerName                 string
 	DefaultIssuerKind                 string
@@ -97,21 +115,96 @@ type ControllerOptions struct {
 	// Allows controlling if recursive nameservers are only used for all checks.
 	// Normally authoritative nameservers are used for checking propagation.
 	DNS01RecursiveNameserversOnly bool
+	// DNS01RecursiveNameserversStrategy controls how the answers of the
+	// recursive nameservers are combined when only they are used for checks.
+	DNS01RecursiveNameserversStrategy string
 
 	EnableCertificateOwnerRef bool
 
+	// CertificateNotBeforeTolerance is how far in the future the NotBefore
+	// time of an issued certificate may be before the NotYetValid condition is
+	// set on the Certificate.
+	CertificateNotBeforeTolerance time.Duration
+
+	// CertificateExpirationImminentWindow is how close to its expiry a
+	// certificate whose renewal is failing must be for it to be reported as
+	// about to expire, and for the issuance of a Failed Certificate to be
+	// retried. Zero disables both.
+	CertificateExpirationImminentWindow time.Duration
+
+	// CertificateRequestMaxRetryBackoff is the maximum time to wait before
+	// retrying a CertificateRequest whose signing attempt failed.
+	CertificateRequestMaxRetryBackoff time.Duration
+
+	// DisableTemporaryCertificates prevents temporary self-signed
+	// certificates from being written to Secrets while a Certificate is
+	// being issued, even if it has the issue-temporary-certificate
+	// annotation.
+	DisableTemporaryCertificates bool
+
+	// CertificateRequestNameTemplate is a text/template used to name the
+	// CertificateRequests created for each revision of a Certificate. If
+	// empty, names are generated from the Certificate's name.
+	CertificateRequestNameTemplate string
+
+	// MaxCertificateIssuanceAttempts is the number of consecutive failed
+	// issuances after which a Certificate is marked as Failed and issuance is
+	// no longer retried until its spec changes, or until its certificate is
+	// within CertificateExpirationImminentWindow of expiring. Zero means no
+	// limit.
+	MaxCertificateIssuanceAttempts int
+
+	// SkipUnchangedSecretWrites prevents Certificate Secrets from being
+	// written if the write would not change their content.
+	SkipUnchangedSecretWrites bool
+
+	// CertificateRenewalJitterPercent is the maximum percentage of a
+	// certificate's renew before period by which its renewal is brought
+	// forward, to spread out the renewals of certificates with identical
+	// lifetimes. Zero disables jitter.
+	CertificateRenewalJitterPercent int
+
+	// CertificateIssuedWebhookURL is the URL of a webhook which is notified
+	// whenever a certificate has been issued or renewed. If empty, no
+	// notifications are sent.
+	CertificateIssuedWebhookURL string
+	// CertificateIssuedWebhookTimeout is the timeout of each attempt to
+	// notify the webhook.
+	CertificateIssuedWebhookTimeout time.Duration
+	// CertificateIssuedWebhookMaxRetries is the number of times a failed
+	// notification is retried.
+	CertificateIssuedWebhookMaxRetries int
+
 	MaxConcurrentChallenges int
 
+	// MaxConcurrentSignings is the maximum number of signing operations that
+	// may be in flight at once across all issuers. Zero means no limit.
+	MaxConcurrentSignings int
+
 	// The host and port address, separated by a ':', that the Prometheus server
 	// should expose metrics on.
 	MetricsListenAddress string
+	// MetricsIssuerLabels adds labels identifying the issuer to the
+	// certificate and ACME client metrics.
+	MetricsIssuerLabels bool
 	// PprofAddress is the address on which Go profiler will run. Should be
 	// in form <host>:<port>.
 	PprofAddress string
 	// EnablePprof determines whether pprof should be enabled.
 	EnablePprof bool
 
+	// ACMEDirectoryHealthCheckAddress is the address on which the ACME
+	// directory health check is served. If empty, the check is disabled.
+	ACMEDirectoryHealthCheckAddress string
+	// ACMEDirectoryHealthCheckCacheDuration is how long the result of
+	// checking the ACME directories is reused before they are checked again.
+	ACMEDirectoryHealthCheckCacheDuration time.Duration
+
 	DNS01CheckRetryPeriod time.Duration
+	// MaxConcurrentDNS01SelfChecks is the maximum number of DNS01 propagation
+	// self checks that may run at once. Zero means self checks are run
+	// synchronously by the challenges controller's workers.
+	MaxConcurrentDNS01SelfChecks int
 
 	// Annotations copied Certificate -> CertificateRequest,
 	// CertificateRequest -> Order. Slice of string literals that are
@@ -136,13 +229,43 @@ const (
 	defaultTLSACMEIssuerGroup        = cm.GroupName
 	defaultEnableCertificateOwnerRef = false
 
+	defaultCertificateNotBeforeTolerance = 5 * time.Minute
+
+	defaultCertificateExpirationImminentWindow = 7 * 24 * time.Hour
+
+	defaultCertificateRequestMaxRetryBackoff = 30 * time.Minute
+
+	defaultDisableTemporaryCertificates = false
+
+	defaultCertificateRequestNameTemplate = ""
+
+	defaultMaxCertificateIssuanceAttempts = 0
+
+	defaultSkipUnchangedSecretWrites = false
+
+	defaultCertificateRenewalJitterPercent = 0
+
+	defaultCertificateIssuedWebhookURL        = ""
+	defaultCertificateIssuedWebhookTimeout    = 5 * time.Second
+	defaultCertificateIssuedWebhookMaxRetries = 3
+
 	defaultDNS01RecursiveNameserversOnly = false
 
+	defaultDNS01RecursiveNameserversStrategy = string(dnsutil.NameserverStrategyAll)
+
 	defaultMaxConcurrentChallenges = 60
 
+	defaultMaxConcurrentSignings = 0
+
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
+	defaultMetricsIssuerLabels            = false
+
+	defaultACMEDirectoryHealthCheckAddress       = ""
+	defaultACMEDirectoryHealthCheckCacheDuration = time.Minute
 
 	defaultDNS01CheckRetryPeriod = 10 * time.Second
+
+	defaultMaxConcurrentDNS01SelfChecks = 10
 )
 
 var (
@@ -218,31 +341,47 @@ var (
 
 func NewControllerOptions() *ControllerOptions {
 	return &ControllerOptions{
-		APIServerHost:                     defaultAPIServerHost,
-		ClusterResourceNamespace:          defaultClusterResourceNamespace,
-		KubernetesAPIQPS:                  defaultKubernetesAPIQPS,
-		KubernetesAPIBurst:                defaultKubernetesAPIBurst,
-		Namespace:                         defaultNamespace,
-		LeaderElect:                       cmdutil.DefaultLeaderElect,
-		LeaderElectionNamespace:           cmdutil.DefaultLeaderElectionNamespace,
-		LeaderElectionLeaseDuration:       cmdutil.DefaultLeaderElectionLeaseDuration,
-		LeaderElectionRenewDeadline:       cmdutil.DefaultLeaderElectionRenewDeadline,
-		LeaderElectionRetryPeriod:         cmdutil.DefaultLeaderElectionRetryPeriod,
-		controllers:                       defaultEnabledControllers,
-		ClusterIssuerAmbientCredentials:   defaultClusterIssuerAmbientCredentials,
-		IssuerAmbientCredentials:          defaultIssuerAmbientCredentials,
-		DefaultIssuerName:                 defaultTLSACMEIssuerName,
-		DefaultIssuerKind:                 defaultTLSACMEIssuerKind,
-		DefaultIssuerGroup:                defaultTLSACMEIssuerGroup,
-		DefaultAutoCertificateAnnotations: defaultAutoCertificateAnnotations,
-		ACMEHTTP01SolverNameservers:       []string{},
-		DNS01RecursiveNameservers:         []string{},
-		DNS01RecursiveNameserversOnly:     defaultDNS01RecursiveNameserversOnly,
-		EnableCertificateOwnerRef:         defaultEnableCertificateOwnerRef,
-		MetricsListenAddress:              defaultPrometheusMetricsServerAddress,
-		DNS01CheckRetryPeriod:             defaultDNS01CheckRetryPeriod,
-		EnablePprof:                       cmdutil.DefaultEnableProfiling,
-		PprofAddress:                      cmdutil.DefaultProfilerAddr,
+		APIServerHost:                         defaultAPIServerHost,
+		ClusterResourceNamespace:              defaultClusterResourceNamespace,
+		KubernetesAPIQPS:                      defaultKubernetesAPIQPS,
+		KubernetesAPIBurst:                    defaultKubernetesAPIBurst,
+		Namespace:                             defaultNamespace,
+		LeaderElect:                           cmdutil.DefaultLeaderElect,
+		LeaderElectionNamespace:               cmdutil.DefaultLeaderElectionNamespace,
+		LeaderElectionLeaseDuration:           cmdutil.DefaultLeaderElectionLeaseDuration,
+		LeaderElectionRenewDeadline:           cmdutil.DefaultLeaderElectionRenewDeadline,
+		LeaderElectionRetryPeriod:             cmdutil.DefaultLeaderElectionRetryPeriod,
+		controllers:                           defaultEnabledControllers,
+		ClusterIssuerAmbientCredentials:       defaultClusterIssuerAmbientCredentials,
+		IssuerAmbientCredentials:              defaultIssuerAmbientCredentials,
+		DefaultIssuerName:                     defaultTLSACMEIssuerName,
+		DefaultIssuerKind:                     defaultTLSACMEIssuerKind,
+		DefaultIssuerGroup:                    defaultTLSACMEIssuerGroup,
+		DefaultAutoCertificateAnnotations:     defaultAutoCertificateAnnotations,
+		ACMEHTTP01SolverNameservers:           []string{},
+		DNS01RecursiveNameservers:             []string{},
+		DNS01RecursiveNameserversOnly:         defaultDNS01RecursiveNameserversOnly,
+		DNS01RecursiveNameserversStrategy:     defaultDNS01RecursiveNameserversStrategy,
+		EnableCertificateOwnerRef:             defaultEnableCertificateOwnerRef,
+		CertificateNotBeforeTolerance:         defaultCertificateNotBeforeTolerance,
+		CertificateExpirationImminentWindow:   defaultCertificateExpirationImminentWindow,
+		CertificateRequestMaxRetryBackoff:     defaultCertificateRequestMaxRetryBackoff,
+		DisableTemporaryCertificates:          defaultDisableTemporaryCertificates,
+		CertificateRequestNameTemplate:        defaultCertificateRequestNameTemplate,
+		MaxCertificateIssuanceAttempts:        defaultMaxCertificateIssuanceAttempts,
+		SkipUnchangedSecretWrites:             defaultSkipUnchangedSecretWrites,
+		CertificateRenewalJitterPercent:       defaultCertificateRenewalJitterPercent,
+		CertificateIssuedWebhookURL:           defaultCertificateIssuedWebhookURL,
+		CertificateIssuedWebhookTimeout:       defaultCertificateIssuedWebhookTimeout,
+		CertificateIssuedWebhookMaxRetries:    defaultCertificateIssuedWebhookMaxRetries,
+		MetricsListenAddress:                  defaultPrometheusMetricsServerAddress,
+		MetricsIssuerLabels:                   defaultMetricsIssuerLabels,
+		DNS01CheckRetryPeriod:                 defaultDNS01CheckRetryPeriod,
+		MaxConcurrentDNS01SelfChecks:          defaultMaxConcurrentDNS01SelfChecks,
+		EnablePprof:                           cmdutil.DefaultEnableProfiling,
+		PprofAddress:                          cmdutil.DefaultProfilerAddr,
+		ACMEDirectoryHealthCheckAddress:       defaultACMEDirectoryHealthCheckAddress,
+		ACMEDirectoryHealthCheckCacheDuration: defaultACMEDirectoryHealthCheckCacheDuration,
 	}
 }
 
@@ -283,7 +422,9 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"A list of controllers to enable. '--controllers=*' enables all "+
 		"on-by-default controllers, '--controllers=foo' enables just the controller "+
 		"named 'foo', '--controllers=*,-foo' disables the controller named "+
-		"'foo'.\nAll controllers: %s",
+		"'foo'. A list containing only disabled controllers, such as "+
+		"'--controllers=-foo,-bar', is treated as if '*' had also been given."+
+		"\nAll controllers: %s",
 		strings.Join(allControllers, ", ")))
 
 	fs.StringVar(&s.ACMEHTTP01SolverImage, "acme-http01-solver-image", defaultACMEHTTP01SolverImage, ""+
@@ -307,6 +448,20 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 			"ACME HTTP01 check requests. This should be a list containing host and "+
 			"port, for example 8.8.8.8:53,8.8.4.4:53")
 
+	fs.StringVar(&s.ACMEEABKeyDir, "acme-eab-key-dir", "", ""+
+		"Directory from which ACME external account binding keys referenced using "+
+		"externalAccountBinding.keyFile are read, for example a mounted CSI volume. "+
+		"Keys for Issuers are read from the subdirectory named after their namespace. "+
+		"If not set, external account binding keys can only be read from Secrets.")
+	fs.BoolVar(&s.ACMEChallengeForceCleanUpOnIssuerDeletion, "acme-challenge-force-cleanup-on-issuer-deletion", false, ""+
+		"If true, the DNS records and HTTP01 solver resources presented for ACME challenges are cleaned up "+
+		"if the Issuer or ClusterIssuer of the challenge is deleted, instead of being left until the issuer is recreated.")
+	fs.DurationVar(&s.ACMEOrdersListInterval, "acme-orders-list-interval", 0, ""+
+		"If set, the orders of the ACME account of each ACME issuer are listed at most this often, when the issuer "+
+		"is synced, and the number of orders is recorded in the issuer's status.acme.orders. The orders are "+
+		"only listed and logged, as ACME has no way to delete them. A growing number of orders indicates that "+
+		"orders are being created and abandoned. Not all ACME servers support listing orders. Disabled if 0.")
+
 	fs.BoolVar(&s.ClusterIssuerAmbientCredentials, "cluster-issuer-ambient-credentials", defaultClusterIssuerAmbientCredentials, ""+
 		"Whether a cluster-issuer may make use of ambient credentials for issuers. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the ClusterIssuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
@@ -315,6 +470,10 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Whether an issuer may make use of ambient credentials. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the Issuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
 		"AWS - All sources the Go SDK defaults to, notably including any EC2 IAM roles available via instance metadata.")
+	fs.StringVar(&s.IssuerUserAgent, "issuer-user-agent", "", ""+
+		"The User-Agent sent in HTTP requests made by issuers, including to ACME servers, Vault, Venafi "+
+		"and DNS01 provider APIs. If not set, a User-Agent identifying the cert-manager component "+
+		"and version is used.")
 	fs.StringSliceVar(&s.DefaultAutoCertificateAnnotations, "auto-certificate-annotations", defaultAutoCertificateAnnotations, ""+
 		"The annotation consumed by the ingress-shim controller to indicate a ingress is requesting a certificate")
 
@@ -335,10 +494,57 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 			"environments, where access to authoritative nameservers is restricted. "+
 			"Enabling this option could cause the DNS01 self check to take longer "+
 			"due to caching performed by the recursive nameservers.")
+	fs.StringVar(&s.DNS01RecursiveNameserversStrategy, "dns01-recursive-nameservers-strategy",
+		defaultDNS01RecursiveNameserversStrategy,
+		"How the answers of the configured DNS resolvers are combined when "+
+			"--dns01-recursive-nameservers-only is set. One of 'first' (use the first "+
+			"resolver that answers), 'all' (every resolver must return the record) or "+
+			"'majority' (more than half of the resolvers must return the record).")
 
 	fs.BoolVar(&s.EnableCertificateOwnerRef, "enable-certificate-owner-ref", defaultEnableCertificateOwnerRef, ""+
 		"Whether to set the certificate resource as an owner of secret where the tls certificate is stored. "+
-		"When this flag is enabled, the secret will be automatically removed when the certificate resource is deleted.")
+		"When this flag is enabled, the secret will be automatically removed when the certificate resource is deleted. "+
+		"It can be overridden for a certificate by setting its 'cert-manager.io/secret-owner-reference' annotation to 'true' or 'false'.")
+	fs.DurationVar(&s.CertificateNotBeforeTolerance, "certificate-not-before-tolerance", defaultCertificateNotBeforeTolerance, ""+
+		"How far in the future the NotBefore time of an issued certificate may be before the NotYetValid condition "+
+		"is set on the Certificate. This allows for clock skew between cert-manager and the issuing CA.")
+	fs.DurationVar(&s.CertificateExpirationImminentWindow, "certificate-expiration-imminent-window", defaultCertificateExpirationImminentWindow, ""+
+		"How close to its expiry a certificate whose renewal is failing must be for the ExpirationImminent condition "+
+		"and the certificate_expiration_imminent metric to be set, and for issuance of a Failed Certificate to be "+
+		"retried. Set to 0 to disable.")
+	fs.DurationVar(&s.CertificateRequestMaxRetryBackoff, "certificate-request-max-retry-backoff", defaultCertificateRequestMaxRetryBackoff, ""+
+		"The maximum time to wait before retrying a CertificateRequest whose signing attempt failed. "+
+		"Retries back off exponentially, with jitter, up to this duration.")
+	fs.BoolVar(&s.DisableTemporaryCertificates, "disable-temporary-certificates", defaultDisableTemporaryCertificates, ""+
+		"If true, temporary self-signed certificates are never written to Secrets while a Certificate is being issued, "+
+		"even if it has the 'cert-manager.io/issue-temporary-certificate' annotation. The Secret is left as it is "+
+		"until the signed certificate has been issued.")
+	fs.StringVar(&s.CertificateRequestNameTemplate, "certificate-request-name-template", defaultCertificateRequestNameTemplate, ""+
+		"A Go text/template used to name the CertificateRequests created for each revision of a Certificate, "+
+		"e.g. '{{ .Namespace }}-{{ .Name }}'. The fields .Name and .Namespace refer to the Certificate. "+
+		"The result is shortened to 52 characters and suffixed with '-<revision>'. "+
+		"If empty, a random suffix is appended to the Certificate's name.")
+	fs.IntVar(&s.MaxCertificateIssuanceAttempts, "max-certificate-issuance-attempts", defaultMaxCertificateIssuanceAttempts, ""+
+		"The number of consecutive failed issuances after which a Certificate is given a 'Failed' condition and "+
+		"issuance is no longer retried until the Certificate's spec is changed, or until its certificate is within "+
+		"the certificate-expiration-imminent-window of expiring. If 0, issuance is retried indefinitely.")
+	fs.BoolVar(&s.SkipUnchangedSecretWrites, "skip-unchanged-secret-writes", defaultSkipUnchangedSecretWrites, ""+
+		"If true, a Certificate's Secret is only written if its content, as last written by cert-manager, would change. "+
+		"This avoids redundant writes to the API server, and etcd, when Certificates are reconciled repeatedly.")
+	fs.IntVar(&s.CertificateRenewalJitterPercent, "certificate-renewal-jitter-percent", defaultCertificateRenewalJitterPercent, ""+
+		"The maximum percentage of a certificate's renew before period by which its renewal is brought forward, "+
+		"so that certificates with identical lifetimes are not all renewed at once. The jitter of each Certificate "+
+		"is derived from its namespace and name, so it does not change between reconciles. Must be between 0 and 100; "+
+		"if 0, certificates are renewed exactly at their renewal time.")
+	fs.StringVar(&s.CertificateIssuedWebhookURL, "certificate-issued-webhook-url", defaultCertificateIssuedWebhookURL, ""+
+		"If set, a JSON document describing each issued or renewed certificate (name, namespace, secret name, revision, "+
+		"serial number, validity and SHA-256 fingerprint) is POSTed to this URL once the certificate has been written "+
+		"to its Secret. Failed notifications are logged and reported as events, but do not fail the issuance.")
+	fs.DurationVar(&s.CertificateIssuedWebhookTimeout, "certificate-issued-webhook-timeout", defaultCertificateIssuedWebhookTimeout, ""+
+		"The timeout of each attempt to notify the certificate-issued-webhook-url.")
+	fs.IntVar(&s.CertificateIssuedWebhookMaxRetries, "certificate-issued-webhook-max-retries", defaultCertificateIssuedWebhookMaxRetries, ""+
+		"The number of times a failed notification to the certificate-issued-webhook-url is retried, with an "+
+		"exponential backoff. Responses with a 4xx status code other than 429 are not retried.")
 	fs.StringSliceVar(&s.CopiedAnnotationPrefixes, "copied-annotation-prefixes", defaultCopiedAnnotationPrefixes, "Specify which annotations should/shouldn't be copied"+
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
@@ -346,16 +552,36 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+	fs.IntVar(&s.MaxConcurrentSignings, "max-concurrent-signings", defaultMaxConcurrentSignings, ""+
+		"The maximum number of certificate signing operations that can be in flight at once, shared "+
+		"across all issuers. Use this to protect a CA that cannot handle many simultaneous requests. "+
+		"The default of 0 means signing operations are not limited.")
 	fs.DurationVar(&s.DNS01CheckRetryPeriod, "dns01-check-retry-period", defaultDNS01CheckRetryPeriod, ""+
 		"The duration the controller should wait between checking if a ACME dns entry exists."+
 		"This should be a valid duration string, for example 180s or 1h")
+	fs.IntVar(&s.MaxConcurrentDNS01SelfChecks, "max-concurrent-dns01-self-checks", defaultMaxConcurrentDNS01SelfChecks, ""+
+		"The maximum number of DNS01 propagation self checks that can run at once. Self checks for the "+
+		"different DNS names of a Certificate are run in parallel, up to this limit, so that a slow or "+
+		"failing check for one name does not delay the others. Lower this to reduce the query rate to "+
+		"the DNS01 nameservers. A value of 0 runs self checks one at a time in the challenges controller's workers.")
 
 	fs.StringVar(&s.MetricsListenAddress, "metrics-listen-address", defaultPrometheusMetricsServerAddress, ""+
 		"The host and port that the metrics endpoint should listen on.")
+	fs.BoolVar(&s.MetricsIssuerLabels, "metrics-issuer-labels", defaultMetricsIssuerLabels, ""+
+		"Add labels identifying the issuer to the certificate metrics (issuer_name, issuer_kind and "+
+		"issuer_group) and to the ACME client request metrics (issuer_name, issuer_namespace and issuer_kind). "+
+		"A certificate only has one issuer, so this does not add certificate series, but the number of ACME "+
+		"client request series grows with the number of ACME issuers.")
 	fs.BoolVar(&s.EnablePprof, "enable-profiling", cmdutil.DefaultEnableProfiling, ""+
 		"Enable profiling for controller.")
 	fs.StringVar(&s.PprofAddress, "profiler-address", cmdutil.DefaultProfilerAddr,
 		"The host and port that Go profiler should listen on, i.e localhost:6060. Ensure that profiler is not exposed on a public address. Profiler will be served at /debug/pprof.")
+	fs.StringVar(&s.ACMEDirectoryHealthCheckAddress, "acme-directory-health-check-address", defaultACMEDirectoryHealthCheckAddress, ""+
+		"The host and port on which to serve a health check, at /healthz/acme, that reports whether the ACME "+
+		"directories configured on Issuers and ClusterIssuers are reachable. The check reports a degraded status "+
+		"rather than affecting the liveness of the controller. If empty, the check is disabled.")
+	fs.DurationVar(&s.ACMEDirectoryHealthCheckCacheDuration, "acme-directory-health-check-cache-duration", defaultACMEDirectoryHealthCheckCacheDuration, ""+
+		"How long the result of checking the ACME directories is reused before they are checked again.")
 }
 
 func (o *ControllerOptions) Validate() error {
@@ -375,6 +601,67 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
+	if o.CertificateNotBeforeTolerance < 0 {
+		return fmt.Errorf("invalid value for certificate-not-before-tolerance: %v must not be negative", o.CertificateNotBeforeTolerance)
+	}
+
+	if o.CertificateExpirationImminentWindow < 0 {
+		return fmt.Errorf("invalid value for certificate-expiration-imminent-window: %v must not be negative", o.CertificateExpirationImminentWindow)
+	}
+
+	if o.MaxConcurrentSignings < 0 {
+		return fmt.Errorf("invalid value for max-concurrent-signings: %v must not be negative", o.MaxConcurrentSignings)
+	}
+
+	if o.ACMEOrdersListInterval < 0 {
+		return fmt.Errorf("invalid value for acme-orders-list-interval: %v must not be negative", o.ACMEOrdersListInterval)
+	}
+
+	if o.MaxConcurrentDNS01SelfChecks < 0 {
+		return fmt.Errorf("invalid value for max-concurrent-dns01-self-checks: %v must not be negative", o.MaxConcurrentDNS01SelfChecks)
+	}
+
+	if o.CertificateRequestMaxRetryBackoff <= 0 {
+		return fmt.Errorf("invalid value for certificate-request-max-retry-backoff: %v must be higher than 0", o.CertificateRequestMaxRetryBackoff)
+	}
+
+	if o.CertificateRenewalJitterPercent < 0 || o.CertificateRenewalJitterPercent > 100 {
+		return fmt.Errorf("invalid value for certificate-renewal-jitter-percent: %v must be between 0 and 100", o.CertificateRenewalJitterPercent)
+	}
+
+	if o.CertificateIssuedWebhookURL != "" {
+		u, err := url.Parse(o.CertificateIssuedWebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid value for certificate-issued-webhook-url: %q must be an absolute http or https URL", o.CertificateIssuedWebhookURL)
+		}
+	}
+
+	if o.CertificateIssuedWebhookTimeout <= 0 {
+		return fmt.Errorf("invalid value for certificate-issued-webhook-timeout: %v must be higher than 0", o.CertificateIssuedWebhookTimeout)
+	}
+
+	if o.CertificateIssuedWebhookMaxRetries < 0 {
+		return fmt.Errorf("invalid value for certificate-issued-webhook-max-retries: %v must not be negative", o.CertificateIssuedWebhookMaxRetries)
+	}
+
+	if o.MaxCertificateIssuanceAttempts < 0 {
+		return fmt.Errorf("invalid value for max-certificate-issuance-attempts: %v must not be negative", o.MaxCertificateIssuanceAttempts)
+	}
+
+	if o.CertificateRequestNameTemplate != "" {
+		if _, err := apiutil.ParseCertificateRequestNameTemplate(o.CertificateRequestNameTemplate); err != nil {
+			return fmt.Errorf("invalid value for certificate-request-name-template: %v", err)
+		}
+	}
+
+	if o.ACMEDirectoryHealthCheckAddress != "" && o.ACMEDirectoryHealthCheckCacheDuration <= 0 {
+		return fmt.Errorf("invalid value for acme-directory-health-check-cache-duration: %v must be higher than 0", o.ACMEDirectoryHealthCheckCacheDuration)
+	}
+
+	if !isValidNameserverStrategy(o.DNS01RecursiveNameserversStrategy) {
+		return fmt.Errorf("invalid value for dns01-recursive-nameservers-strategy: %q must be one of %v", o.DNS01RecursiveNameserversStrategy, dnsutil.NameserverStrategies)
+	}
+
 	for _, server := range append(o.DNS01RecursiveNameservers, o.ACMEHTTP01SolverNameservers...) {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -403,6 +690,15 @@ func (o *ControllerOptions) Validate() error {
 	return nil
 }
 
+func isValidNameserverStrategy(strategy string) bool {
+	for _, s := range dnsutil.NameserverStrategies {
+		if strategy == string(s) {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *ControllerOptions) EnabledControllers() sets.String {
 	var disabled []string
 	enabled := sets.NewString()
@@ -418,6 +714,12 @@ func (o *ControllerOptions) EnabledControllers() sets.String {
 		}
 	}
 
+	// If only controllers to disable were given, disable them from the set
+	// of on-by-default controllers.
+	if len(disabled) > 0 && len(disabled) == len(o.controllers) {
+		enabled = enabled.Insert(defaultEnabledControllers...)
+	}
+
 	enabled = enabled.Delete(disabled...)
 
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalCertificateSigningRequestControllers) {
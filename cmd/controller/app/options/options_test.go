@@ -47,6 +47,14 @@ func TestEnabledControllers(t *testing.T) {
 			controllers: []string{"*", "-clusterissuers", "-issuers"},
 			expEnabled:  sets.NewString(defaultEnabledControllers...).Delete("clusterissuers", "issuers"),
 		},
+		"if only disabled controllers given, return all default controllers without disabled": {
+			controllers: []string{"-challenges", "-orders"},
+			expEnabled:  sets.NewString(defaultEnabledControllers...).Delete("challenges", "orders"),
+		},
+		"if only some default controllers enabled, return just those": {
+			controllers: []string{"certificates-trigger", "issuers"},
+			expEnabled:  sets.NewString("certificates-trigger", "issuers"),
+		},
 	}
 
 	for name, test := range tests {
@@ -63,3 +71,37 @@ func TestEnabledControllers(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateControllers(t *testing.T) {
+	tests := map[string]struct {
+		controllers []string
+		expErr      bool
+	}{
+		"all default controllers": {
+			controllers: []string{"*"},
+		},
+		"known controllers enabled and disabled": {
+			controllers: []string{"issuers", "-orders", "-challenges"},
+		},
+		"unknown controller enabled": {
+			controllers: []string{"issuers", "foo"},
+			expErr:      true,
+		},
+		"unknown controller disabled": {
+			controllers: []string{"*", "-foo"},
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewControllerOptions()
+			o.controllers = test.controllers
+
+			err := o.Validate()
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
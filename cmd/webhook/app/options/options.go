@@ -89,6 +89,12 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 	fs.StringVar(&c.TLSConfig.MinTLSVersion, "tls-min-version", c.TLSConfig.MinTLSVersion,
 		"Minimum TLS version supported. "+
 			"Possible values: "+strings.Join(tlsPossibleVersions, ", "))
+	fs.IntVar(&c.KeyPolicy.MinRSAKeySize, "min-rsa-key-size", c.KeyPolicy.MinRSAKeySize, ""+
+		"Minimum size, in bits, of the RSA private keys Certificates may request. "+
+		"If 0, no minimum is enforced beyond the default validation.")
+	fs.IntVar(&c.KeyPolicy.MinECDSAKeySize, "min-ecdsa-key-size", c.KeyPolicy.MinECDSAKeySize, ""+
+		"Minimum size, in bits, of the curve of the ECDSA private keys Certificates may request. "+
+		"Possible values: 0, 256, 384, 521")
 	fs.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/experimental features. "+
 		"Options are:\n"+strings.Join(utilfeature.DefaultFeatureGate.KnownFeatures(), "\n"))
 }
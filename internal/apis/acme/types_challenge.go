@@ -121,6 +121,10 @@ type ChallengeStatus struct {
 	// configured).
 	Presented bool
 
+	// PresentedTime is the time at which the challenge values were last
+	// presented.
+	PresentedTime *metav1.Time
+
 	// Reason contains human readable information on why the Challenge is in the
 	// current state.
 	Reason string
@@ -128,4 +132,34 @@ type ChallengeStatus struct {
 	// State contains the current 'state' of the challenge.
 	// If not set, the state of the challenge is unknown.
 	State State
+
+	// Fallback will be set to true if this challenge could not be presented
+	// and the issuer is configured to fall back to another challenge type.
+	// Any resources created for the challenge have been cleaned up and the
+	// owning Order will replace it with a challenge of the next preferred type.
+	Fallback bool
+
+	// Presentation describes the DNS01 record presented for this challenge.
+	// It is set when a DNS01 challenge has been presented.
+	Presentation *ACMEChallengePresentation
+}
+
+// ACMEChallengePresentation describes how the records for a DNS01 challenge
+// were presented, to help diagnose challenges that do not behave as expected.
+type ACMEChallengePresentation struct {
+	// Solver is the name of the DNS01 provider that was selected to present
+	// the challenge, e.g. 'cloudflare' or 'webhook/<groupName>/<solverName>'.
+	Solver string
+
+	// ResolvedFQDN is the fully qualified domain name of the TXT record that
+	// was presented.
+	ResolvedFQDN string
+
+	// ResolvedZone is the DNS zone that the TXT record was presented in.
+	// It is empty if the zone could not be determined.
+	ResolvedZone string
+
+	// CNAMETarget is the final target of the CNAME records that were followed
+	// from the '_acme-challenge' record of the DNS name, if any were followed.
+	CNAMETarget string
 }
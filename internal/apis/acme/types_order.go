@@ -144,6 +144,15 @@ type ACMEAuthorization struct {
 	// name and an appropriate Challenge resource will be created to perform
 	// the ACME challenge process.
 	Challenges []ACMEChallenge
+
+	// FailedChallengeTypes lists the challenge types that could not be
+	// presented for this authorization and have been abandoned in favour of
+	// the next type in the issuer's challengeTypePreference.
+	FailedChallengeTypes []ACMEChallengeType
+
+	// Presentation describes the DNS01 record presented by the Challenge
+	// for this authorization, as reported in the Challenge's status.
+	Presentation *ACMEChallengePresentation
 }
 
 // Challenge specifies a challenge offered by the ACME server for an Order.
@@ -26,12 +26,12 @@ import (
 
 	acme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
-	metav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
+	apismetav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
-	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	pkgapismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	pkgapismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -44,6 +44,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEAccountOrdersStatus)(nil), (*acme.ACMEAccountOrdersStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(a.(*v1.ACMEAccountOrdersStatus), b.(*acme.ACMEAccountOrdersStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEAccountOrdersStatus)(nil), (*v1.ACMEAccountOrdersStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEAccountOrdersStatus_To_v1_ACMEAccountOrdersStatus(a.(*acme.ACMEAccountOrdersStatus), b.(*v1.ACMEAccountOrdersStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEAuthorization)(nil), (*acme.ACMEAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEAuthorization_To_acme_ACMEAuthorization(a.(*v1.ACMEAuthorization), b.(*acme.ACMEAuthorization), scope)
 	}); err != nil {
@@ -64,6 +74,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengePresentation)(nil), (*acme.ACMEChallengePresentation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(a.(*v1.ACMEChallengePresentation), b.(*acme.ACMEChallengePresentation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengePresentation)(nil), (*v1.ACMEChallengePresentation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengePresentation_To_v1_ACMEChallengePresentation(a.(*acme.ACMEChallengePresentation), b.(*v1.ACMEChallengePresentation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolver)(nil), (*acme.ACMEChallengeSolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(a.(*v1.ACMEChallengeSolver), b.(*acme.ACMEChallengeSolver), scope)
 	}); err != nil {
@@ -104,6 +124,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01HostNetwork)(nil), (*acme.ACMEChallengeSolverHTTP01HostNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(a.(*v1.ACMEChallengeSolverHTTP01HostNetwork), b.(*acme.ACMEChallengeSolverHTTP01HostNetwork), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01HostNetwork)(nil), (*v1.ACMEChallengeSolverHTTP01HostNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1_ACMEChallengeSolverHTTP01HostNetwork(a.(*acme.ACMEChallengeSolverHTTP01HostNetwork), b.(*v1.ACMEChallengeSolverHTTP01HostNetwork), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01Ingress)(nil), (*acme.ACMEChallengeSolverHTTP01Ingress)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(a.(*v1.ACMEChallengeSolverHTTP01Ingress), b.(*acme.ACMEChallengeSolverHTTP01Ingress), scope)
 	}); err != nil {
@@ -204,6 +234,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderBunny)(nil), (*acme.ACMEIssuerDNS01ProviderBunny)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(a.(*v1.ACMEIssuerDNS01ProviderBunny), b.(*acme.ACMEIssuerDNS01ProviderBunny), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderBunny)(nil), (*v1.ACMEIssuerDNS01ProviderBunny)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(a.(*acme.ACMEIssuerDNS01ProviderBunny), b.(*v1.ACMEIssuerDNS01ProviderBunny), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderCloudDNS)(nil), (*acme.ACMEIssuerDNS01ProviderCloudDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(a.(*v1.ACMEIssuerDNS01ProviderCloudDNS), b.(*acme.ACMEIssuerDNS01ProviderCloudDNS), scope)
 	}); err != nil {
@@ -234,6 +274,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderGandi)(nil), (*acme.ACMEIssuerDNS01ProviderGandi)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(a.(*v1.ACMEIssuerDNS01ProviderGandi), b.(*acme.ACMEIssuerDNS01ProviderGandi), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderGandi)(nil), (*v1.ACMEIssuerDNS01ProviderGandi)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1_ACMEIssuerDNS01ProviderGandi(a.(*acme.ACMEIssuerDNS01ProviderGandi), b.(*v1.ACMEIssuerDNS01ProviderGandi), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*v1.ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -387,12 +437,38 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(in *v1.ACMEAccountOrdersStatus, out *acme.ACMEAccountOrdersStatus, s conversion.Scope) error {
+	out.Count = in.Count
+	out.Truncated = in.Truncated
+	out.LastListedTime = (*metav1.Time)(unsafe.Pointer(in.LastListedTime))
+	return nil
+}
+
+// Convert_v1_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus is an autogenerated conversion function.
+func Convert_v1_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(in *v1.ACMEAccountOrdersStatus, out *acme.ACMEAccountOrdersStatus, s conversion.Scope) error {
+	return autoConvert_v1_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMEAccountOrdersStatus_To_v1_ACMEAccountOrdersStatus(in *acme.ACMEAccountOrdersStatus, out *v1.ACMEAccountOrdersStatus, s conversion.Scope) error {
+	out.Count = in.Count
+	out.Truncated = in.Truncated
+	out.LastListedTime = (*metav1.Time)(unsafe.Pointer(in.LastListedTime))
+	return nil
+}
+
+// Convert_acme_ACMEAccountOrdersStatus_To_v1_ACMEAccountOrdersStatus is an autogenerated conversion function.
+func Convert_acme_ACMEAccountOrdersStatus_To_v1_ACMEAccountOrdersStatus(in *acme.ACMEAccountOrdersStatus, out *v1.ACMEAccountOrdersStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMEAccountOrdersStatus_To_v1_ACMEAccountOrdersStatus(in, out, s)
+}
+
 func autoConvert_v1_ACMEAuthorization_To_acme_ACMEAuthorization(in *v1.ACMEAuthorization, out *acme.ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = acme.State(in.InitialState)
 	out.Challenges = *(*[]acme.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.FailedChallengeTypes = *(*[]acme.ACMEChallengeType)(unsafe.Pointer(&in.FailedChallengeTypes))
+	out.Presentation = (*acme.ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -407,6 +483,8 @@ func autoConvert_acme_ACMEAuthorization_To_v1_ACMEAuthorization(in *acme.ACMEAut
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = v1.State(in.InitialState)
 	out.Challenges = *(*[]v1.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.FailedChallengeTypes = *(*[]v1.ACMEChallengeType)(unsafe.Pointer(&in.FailedChallengeTypes))
+	out.Presentation = (*v1.ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -439,6 +517,32 @@ func Convert_acme_ACMEChallenge_To_v1_ACMEChallenge(in *acme.ACMEChallenge, out
 	return autoConvert_acme_ACMEChallenge_To_v1_ACMEChallenge(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(in *v1.ACMEChallengePresentation, out *acme.ACMEChallengePresentation, s conversion.Scope) error {
+	out.Solver = in.Solver
+	out.ResolvedFQDN = in.ResolvedFQDN
+	out.ResolvedZone = in.ResolvedZone
+	out.CNAMETarget = in.CNAMETarget
+	return nil
+}
+
+// Convert_v1_ACMEChallengePresentation_To_acme_ACMEChallengePresentation is an autogenerated conversion function.
+func Convert_v1_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(in *v1.ACMEChallengePresentation, out *acme.ACMEChallengePresentation, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengePresentation_To_v1_ACMEChallengePresentation(in *acme.ACMEChallengePresentation, out *v1.ACMEChallengePresentation, s conversion.Scope) error {
+	out.Solver = in.Solver
+	out.ResolvedFQDN = in.ResolvedFQDN
+	out.ResolvedZone = in.ResolvedZone
+	out.CNAMETarget = in.CNAMETarget
+	return nil
+}
+
+// Convert_acme_ACMEChallengePresentation_To_v1_ACMEChallengePresentation is an autogenerated conversion function.
+func Convert_acme_ACMEChallengePresentation_To_v1_ACMEChallengePresentation(in *acme.ACMEChallengePresentation, out *v1.ACMEChallengePresentation, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengePresentation_To_v1_ACMEChallengePresentation(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1.ACMEChallengeSolver, out *acme.ACMEChallengeSolver, s conversion.Scope) error {
 	out.Selector = (*acme.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
 	out.HTTP01 = (*acme.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
@@ -451,6 +555,7 @@ func autoConvert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1.ACMEC
 	} else {
 		out.DNS01 = nil
 	}
+	out.PreCheckDelay = (*metav1.Duration)(unsafe.Pointer(in.PreCheckDelay))
 	return nil
 }
 
@@ -471,6 +576,7 @@ func autoConvert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACM
 	} else {
 		out.DNS01 = nil
 	}
+	out.PreCheckDelay = (*metav1.Duration)(unsafe.Pointer(in.PreCheckDelay))
 	return nil
 }
 
@@ -481,6 +587,7 @@ func Convert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACMECha
 
 func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1.ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.ChallengeDomain = in.ChallengeDomain
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -535,6 +642,24 @@ func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Bunny != nil {
+		in, out := &in.Bunny, &out.Bunny
+		*out = new(acme.ACMEIssuerDNS01ProviderBunny)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bunny = nil
+	}
+	if in.Gandi != nil {
+		in, out := &in.Gandi, &out.Gandi
+		*out = new(acme.ACMEIssuerDNS01ProviderGandi)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Gandi = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(acme.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -564,6 +689,7 @@ func Convert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *v1.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = v1.CNAMEStrategy(in.CNAMEStrategy)
+	out.ChallengeDomain = in.ChallengeDomain
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(v1.ACMEIssuerDNS01ProviderAkamai)
@@ -618,6 +744,24 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Bunny != nil {
+		in, out := &in.Bunny, &out.Bunny
+		*out = new(v1.ACMEIssuerDNS01ProviderBunny)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bunny = nil
+	}
+	if in.Gandi != nil {
+		in, out := &in.Gandi, &out.Gandi
+		*out = new(v1.ACMEIssuerDNS01ProviderGandi)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1_ACMEIssuerDNS01ProviderGandi(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Gandi = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(v1.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -648,6 +792,7 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *ac
 func autoConvert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *v1.ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.HostNetwork = (*acme.ACMEChallengeSolverHTTP01HostNetwork)(unsafe.Pointer(in.HostNetwork))
 	return nil
 }
 
@@ -659,6 +804,7 @@ func Convert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *v1.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*v1.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.HostNetwork = (*v1.ACMEChallengeSolverHTTP01HostNetwork)(unsafe.Pointer(in.HostNetwork))
 	return nil
 }
 
@@ -669,6 +815,7 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *
 
 func autoConvert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	out.ParentRefs = *(*[]v1alpha2.ParentRef)(unsafe.Pointer(&in.ParentRefs))
 	return nil
@@ -681,6 +828,7 @@ func Convert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeS
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	out.ParentRefs = *(*[]v1alpha2.ParentRef)(unsafe.Pointer(&in.ParentRefs))
 	return nil
@@ -691,8 +839,31 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeS
 	return autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(in *v1.ACMEChallengeSolverHTTP01HostNetwork, out *acme.ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	out.Port = in.Port
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(in *v1.ACMEChallengeSolverHTTP01HostNetwork, out *acme.ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1_ACMEChallengeSolverHTTP01HostNetwork(in *acme.ACMEChallengeSolverHTTP01HostNetwork, out *v1.ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	out.Port = in.Port
+	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1_ACMEChallengeSolverHTTP01HostNetwork is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1_ACMEChallengeSolverHTTP01HostNetwork(in *acme.ACMEChallengeSolverHTTP01HostNetwork, out *v1.ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1_ACMEChallengeSolverHTTP01HostNetwork(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *v1.ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
@@ -707,6 +878,7 @@ func Convert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *v1.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
@@ -847,9 +1019,10 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSo
 
 func autoConvert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *v1.ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
 		return err
 	}
+	out.KeyFile = in.KeyFile
 	out.KeyAlgorithm = acme.HMACKeyAlgorithm(in.KeyAlgorithm)
 	return nil
 }
@@ -861,9 +1034,10 @@ func Convert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in
 
 func autoConvert_acme_ACMEExternalAccountBinding_To_v1_ACMEExternalAccountBinding(in *acme.ACMEExternalAccountBinding, out *v1.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
 		return err
 	}
+	out.KeyFile = in.KeyFile
 	out.KeyAlgorithm = v1.HMACKeyAlgorithm(in.KeyAlgorithm)
 	return nil
 }
@@ -887,7 +1061,7 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	} else {
 		out.ExternalAccountBinding = nil
 	}
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PrivateKey, &out.PrivateKey, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PrivateKey, &out.PrivateKey, s); err != nil {
 		return err
 	}
 	if in.Solvers != nil {
@@ -901,8 +1075,11 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	} else {
 		out.Solvers = nil
 	}
+	out.ChallengeTypePreference = *(*[]acme.ACMEChallengeType)(unsafe.Pointer(&in.ChallengeTypePreference))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.HTTPTimeout = (*metav1.Duration)(unsafe.Pointer(in.HTTPTimeout))
+	out.HTTPMaxRetries = (*int)(unsafe.Pointer(in.HTTPMaxRetries))
 	return nil
 }
 
@@ -920,7 +1097,7 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	} else {
 		out.ExternalAccountBinding = nil
 	}
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PrivateKey, &out.PrivateKey, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PrivateKey, &out.PrivateKey, s); err != nil {
 		return err
 	}
 	if in.Solvers != nil {
@@ -934,14 +1111,17 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	} else {
 		out.Solvers = nil
 	}
+	out.ChallengeTypePreference = *(*[]v1.ACMEChallengeType)(unsafe.Pointer(&in.ChallengeTypePreference))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.HTTPTimeout = (*metav1.Duration)(unsafe.Pointer(in.HTTPTimeout))
+	out.HTTPMaxRetries = (*int)(unsafe.Pointer(in.HTTPMaxRetries))
 	return nil
 }
 
 func autoConvert_v1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01ProviderAcmeDNS(in *v1.ACMEIssuerDNS01ProviderAcmeDNS, out *acme.ACMEIssuerDNS01ProviderAcmeDNS, s conversion.Scope) error {
 	out.Host = in.Host
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
 	return nil
@@ -954,7 +1134,7 @@ func Convert_v1_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01ProviderAc
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderAcmeDNS_To_v1_ACMEIssuerDNS01ProviderAcmeDNS(in *acme.ACMEIssuerDNS01ProviderAcmeDNS, out *v1.ACMEIssuerDNS01ProviderAcmeDNS, s conversion.Scope) error {
 	out.Host = in.Host
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccountSecret, &out.AccountSecret, s); err != nil {
 		return err
 	}
 	return nil
@@ -967,13 +1147,13 @@ func Convert_acme_ACMEIssuerDNS01ProviderAcmeDNS_To_v1_ACMEIssuerDNS01ProviderAc
 
 func autoConvert_v1_ACMEIssuerDNS01ProviderAkamai_To_acme_ACMEIssuerDNS01ProviderAkamai(in *v1.ACMEIssuerDNS01ProviderAkamai, out *acme.ACMEIssuerDNS01ProviderAkamai, s conversion.Scope) error {
 	out.ServiceConsumerDomain = in.ServiceConsumerDomain
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ClientToken, &out.ClientToken, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ClientToken, &out.ClientToken, s); err != nil {
 		return err
 	}
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
 		return err
 	}
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccessToken, &out.AccessToken, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccessToken, &out.AccessToken, s); err != nil {
 		return err
 	}
 	return nil
@@ -986,13 +1166,13 @@ func Convert_v1_ACMEIssuerDNS01ProviderAkamai_To_acme_ACMEIssuerDNS01ProviderAka
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderAkamai_To_v1_ACMEIssuerDNS01ProviderAkamai(in *acme.ACMEIssuerDNS01ProviderAkamai, out *v1.ACMEIssuerDNS01ProviderAkamai, s conversion.Scope) error {
 	out.ServiceConsumerDomain = in.ServiceConsumerDomain
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ClientToken, &out.ClientToken, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ClientToken, &out.ClientToken, s); err != nil {
 		return err
 	}
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.ClientSecret, &out.ClientSecret, s); err != nil {
 		return err
 	}
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccessToken, &out.AccessToken, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccessToken, &out.AccessToken, s); err != nil {
 		return err
 	}
 	return nil
@@ -1008,7 +1188,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01Provi
 	if in.ClientSecret != nil {
 		in, out := &in.ClientSecret, &out.ClientSecret
 		*out = new(meta.SecretKeySelector)
-		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1018,6 +1198,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01Provi
 	out.TenantID = in.TenantID
 	out.ResourceGroupName = in.ResourceGroupName
 	out.HostedZoneName = in.HostedZoneName
+	out.PrivateZone = in.PrivateZone
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
 	return nil
@@ -1032,8 +1213,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01Provi
 	out.ClientID = in.ClientID
 	if in.ClientSecret != nil {
 		in, out := &in.ClientSecret, &out.ClientSecret
-		*out = new(apismetav1.SecretKeySelector)
-		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1043,6 +1224,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01Provi
 	out.TenantID = in.TenantID
 	out.ResourceGroupName = in.ResourceGroupName
 	out.HostedZoneName = in.HostedZoneName
+	out.PrivateZone = in.PrivateZone
 	out.Environment = v1.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*v1.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
 	return nil
@@ -1053,11 +1235,35 @@ func Convert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01ProviderA
 	return autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01ProviderAzureDNS(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in *v1.ACMEIssuerDNS01ProviderBunny, out *acme.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccessKey, &out.AccessKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in *v1.ACMEIssuerDNS01ProviderBunny, out *acme.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(in *acme.ACMEIssuerDNS01ProviderBunny, out *v1.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccessKey, &out.AccessKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(in *acme.ACMEIssuerDNS01ProviderBunny, out *v1.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(in *v1.ACMEIssuerDNS01ProviderCloudDNS, out *acme.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
 		*out = new(meta.SecretKeySelector)
-		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1076,8 +1282,8 @@ func Convert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderC
 func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01ProviderCloudDNS(in *acme.ACMEIssuerDNS01ProviderCloudDNS, out *v1.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(apismetav1.SecretKeySelector)
-		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1098,7 +1304,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01Pro
 	if in.APIKey != nil {
 		in, out := &in.APIKey, &out.APIKey
 		*out = new(meta.SecretKeySelector)
-		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1107,12 +1313,23 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01Pro
 	if in.APIToken != nil {
 		in, out := &in.APIToken, &out.APIToken
 		*out = new(meta.SecretKeySelector)
-		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
 		out.APIToken = nil
 	}
+	if in.APITokens != nil {
+		in, out := &in.APITokens, &out.APITokens
+		*out = make([]meta.SecretKeySelector, len(*in))
+		for i := range *in {
+			if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.APITokens = nil
+	}
 	return nil
 }
 
@@ -1125,8 +1342,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01Pro
 	out.Email = in.Email
 	if in.APIKey != nil {
 		in, out := &in.APIKey, &out.APIKey
-		*out = new(apismetav1.SecretKeySelector)
-		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1134,13 +1351,24 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01Pro
 	}
 	if in.APIToken != nil {
 		in, out := &in.APIToken, &out.APIToken
-		*out = new(apismetav1.SecretKeySelector)
-		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
 		out.APIToken = nil
 	}
+	if in.APITokens != nil {
+		in, out := &in.APITokens, &out.APITokens
+		*out = make([]pkgapismetav1.SecretKeySelector, len(*in))
+		for i := range *in {
+			if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.APITokens = nil
+	}
 	return nil
 }
 
@@ -1150,7 +1378,7 @@ func Convert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01Provide
 }
 
 func autoConvert_v1_ACMEIssuerDNS01ProviderDigitalOcean_To_acme_ACMEIssuerDNS01ProviderDigitalOcean(in *v1.ACMEIssuerDNS01ProviderDigitalOcean, out *acme.ACMEIssuerDNS01ProviderDigitalOcean, s conversion.Scope) error {
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
 		return err
 	}
 	return nil
@@ -1162,7 +1390,7 @@ func Convert_v1_ACMEIssuerDNS01ProviderDigitalOcean_To_acme_ACMEIssuerDNS01Provi
 }
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01ProviderDigitalOcean(in *acme.ACMEIssuerDNS01ProviderDigitalOcean, out *v1.ACMEIssuerDNS01ProviderDigitalOcean, s conversion.Scope) error {
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
 		return err
 	}
 	return nil
@@ -1173,13 +1401,47 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(in *v1.ACMEIssuerDNS01ProviderGandi, out *acme.ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(in *v1.ACMEIssuerDNS01ProviderGandi, out *acme.ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderGandi_To_v1_ACMEIssuerDNS01ProviderGandi(in *acme.ACMEIssuerDNS01ProviderGandi, out *v1.ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1_ACMEIssuerDNS01ProviderGandi is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1_ACMEIssuerDNS01ProviderGandi(in *acme.ACMEIssuerDNS01ProviderGandi, out *v1.ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderGandi_To_v1_ACMEIssuerDNS01ProviderGandi(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *v1.ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
+	out.AdditionalNameservers = *(*[]string)(unsafe.Pointer(&in.AdditionalNameservers))
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
 		return err
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.TSIGKeyFile != nil {
+		in, out := &in.TSIGKeyFile, &out.TSIGKeyFile
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TSIGKeyFile = nil
+	}
 	return nil
 }
 
@@ -1190,11 +1452,21 @@ func Convert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRF
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01ProviderRFC2136(in *acme.ACMEIssuerDNS01ProviderRFC2136, out *v1.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
+	out.AdditionalNameservers = *(*[]string)(unsafe.Pointer(&in.AdditionalNameservers))
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
 		return err
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.TSIGKeyFile != nil {
+		in, out := &in.TSIGKeyFile, &out.TSIGKeyFile
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TSIGKeyFile = nil
+	}
 	return nil
 }
 
@@ -1205,11 +1477,14 @@ func Convert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01ProviderRF
 
 func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(in *v1.ACMEIssuerDNS01ProviderRoute53, out *acme.ACMEIssuerDNS01ProviderRoute53, s conversion.Scope) error {
 	out.AccessKeyID = in.AccessKeyID
-	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretAccessKey, &out.SecretAccessKey, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretAccessKey, &out.SecretAccessKey, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
+	out.RoleSessionName = in.RoleSessionName
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
 	out.HostedZoneID = in.HostedZoneID
+	out.Comment = in.Comment
 	out.Region = in.Region
 	return nil
 }
@@ -1221,11 +1496,14 @@ func Convert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRo
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01ProviderRoute53(in *acme.ACMEIssuerDNS01ProviderRoute53, out *v1.ACMEIssuerDNS01ProviderRoute53, s conversion.Scope) error {
 	out.AccessKeyID = in.AccessKeyID
-	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretAccessKey, &out.SecretAccessKey, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretAccessKey, &out.SecretAccessKey, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
+	out.RoleSessionName = in.RoleSessionName
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
 	out.HostedZoneID = in.HostedZoneID
+	out.Comment = in.Comment
 	out.Region = in.Region
 	return nil
 }
@@ -1262,6 +1540,7 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1_ACMEIssuerDNS01ProviderWe
 func autoConvert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Orders = (*acme.ACMEAccountOrdersStatus)(unsafe.Pointer(in.Orders))
 	return nil
 }
 
@@ -1273,6 +1552,7 @@ func Convert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatu
 func autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *v1.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Orders = (*v1.ACMEAccountOrdersStatus)(unsafe.Pointer(in.Orders))
 	return nil
 }
 
@@ -1412,7 +1692,7 @@ func autoConvert_v1_ChallengeSpec_To_acme_ChallengeSpec(in *v1.ChallengeSpec, ou
 	if err := Convert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(&in.Solver, &out.Solver, s); err != nil {
 		return err
 	}
-	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1434,7 +1714,7 @@ func autoConvert_acme_ChallengeSpec_To_v1_ChallengeSpec(in *acme.ChallengeSpec,
 	if err := Convert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(&in.Solver, &out.Solver, s); err != nil {
 		return err
 	}
-	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1448,8 +1728,11 @@ func Convert_acme_ChallengeSpec_To_v1_ChallengeSpec(in *acme.ChallengeSpec, out
 func autoConvert_v1_ChallengeStatus_To_acme_ChallengeStatus(in *v1.ChallengeStatus, out *acme.ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*metav1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.Fallback = in.Fallback
+	out.Presentation = (*acme.ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -1461,8 +1744,11 @@ func Convert_v1_ChallengeStatus_To_acme_ChallengeStatus(in *v1.ChallengeStatus,
 func autoConvert_acme_ChallengeStatus_To_v1_ChallengeStatus(in *acme.ChallengeStatus, out *v1.ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*metav1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = v1.State(in.State)
+	out.Fallback = in.Fallback
+	out.Presentation = (*v1.ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -1547,13 +1833,13 @@ func Convert_acme_OrderList_To_v1_OrderList(in *acme.OrderList, out *v1.OrderLis
 
 func autoConvert_v1_OrderSpec_To_acme_OrderSpec(in *v1.OrderSpec, out *acme.OrderSpec, s conversion.Scope) error {
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
-	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	return nil
 }
 
@@ -1564,13 +1850,13 @@ func Convert_v1_OrderSpec_To_acme_OrderSpec(in *v1.OrderSpec, out *acme.OrderSpe
 
 func autoConvert_acme_OrderSpec_To_v1_OrderSpec(in *acme.OrderSpec, out *v1.OrderSpec, s conversion.Scope) error {
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
-	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	return nil
 }
 
@@ -1586,7 +1872,7 @@ func autoConvert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acm
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -1602,7 +1888,7 @@ func autoConvert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v
 	out.State = v1.State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -160,6 +160,17 @@ type ACMEAuthorization struct {
 	// the ACME challenge process.
 	// +optional
 	Challenges []ACMEChallenge `json:"challenges,omitempty"`
+
+	// FailedChallengeTypes lists the challenge types that could not be
+	// presented for this authorization and have been abandoned in favour of
+	// the next type in the issuer's challengeTypePreference.
+	// +optional
+	FailedChallengeTypes []ACMEChallengeType `json:"failedChallengeTypes,omitempty"`
+
+	// Presentation describes the DNS01 record presented by the Challenge
+	// for this authorization, as reported in the Challenge's status.
+	// +optional
+	Presentation *ACMEChallengePresentation `json:"presentation,omitempty"`
 }
 
 // Challenge specifies a challenge offered by the ACME server for an Order.
@@ -28,9 +28,9 @@ import (
 	meta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	metav1 "github.com/cert-manager/cert-manager/internal/apis/meta/v1"
 	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	pkgapismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -43,6 +43,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ACMEAccountOrdersStatus)(nil), (*acme.ACMEAccountOrdersStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(a.(*ACMEAccountOrdersStatus), b.(*acme.ACMEAccountOrdersStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEAccountOrdersStatus)(nil), (*ACMEAccountOrdersStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEAccountOrdersStatus_To_v1alpha3_ACMEAccountOrdersStatus(a.(*acme.ACMEAccountOrdersStatus), b.(*ACMEAccountOrdersStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEAuthorization)(nil), (*acme.ACMEAuthorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEAuthorization_To_acme_ACMEAuthorization(a.(*ACMEAuthorization), b.(*acme.ACMEAuthorization), scope)
 	}); err != nil {
@@ -63,6 +73,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengePresentation)(nil), (*acme.ACMEChallengePresentation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(a.(*ACMEChallengePresentation), b.(*acme.ACMEChallengePresentation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengePresentation)(nil), (*ACMEChallengePresentation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengePresentation_To_v1alpha3_ACMEChallengePresentation(a.(*acme.ACMEChallengePresentation), b.(*ACMEChallengePresentation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolver)(nil), (*acme.ACMEChallengeSolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(a.(*ACMEChallengeSolver), b.(*acme.ACMEChallengeSolver), scope)
 	}); err != nil {
@@ -103,6 +123,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01HostNetwork)(nil), (*acme.ACMEChallengeSolverHTTP01HostNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(a.(*ACMEChallengeSolverHTTP01HostNetwork), b.(*acme.ACMEChallengeSolverHTTP01HostNetwork), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01HostNetwork)(nil), (*ACMEChallengeSolverHTTP01HostNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork(a.(*acme.ACMEChallengeSolverHTTP01HostNetwork), b.(*ACMEChallengeSolverHTTP01HostNetwork), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01Ingress)(nil), (*acme.ACMEChallengeSolverHTTP01Ingress)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(a.(*ACMEChallengeSolverHTTP01Ingress), b.(*acme.ACMEChallengeSolverHTTP01Ingress), scope)
 	}); err != nil {
@@ -203,6 +233,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderBunny)(nil), (*acme.ACMEIssuerDNS01ProviderBunny)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(a.(*ACMEIssuerDNS01ProviderBunny), b.(*acme.ACMEIssuerDNS01ProviderBunny), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderBunny)(nil), (*ACMEIssuerDNS01ProviderBunny)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1alpha3_ACMEIssuerDNS01ProviderBunny(a.(*acme.ACMEIssuerDNS01ProviderBunny), b.(*ACMEIssuerDNS01ProviderBunny), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderCloudDNS)(nil), (*acme.ACMEIssuerDNS01ProviderCloudDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(a.(*ACMEIssuerDNS01ProviderCloudDNS), b.(*acme.ACMEIssuerDNS01ProviderCloudDNS), scope)
 	}); err != nil {
@@ -233,6 +273,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderGandi)(nil), (*acme.ACMEIssuerDNS01ProviderGandi)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(a.(*ACMEIssuerDNS01ProviderGandi), b.(*acme.ACMEIssuerDNS01ProviderGandi), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderGandi)(nil), (*ACMEIssuerDNS01ProviderGandi)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1alpha3_ACMEIssuerDNS01ProviderGandi(a.(*acme.ACMEIssuerDNS01ProviderGandi), b.(*ACMEIssuerDNS01ProviderGandi), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -386,12 +436,38 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha3_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(in *ACMEAccountOrdersStatus, out *acme.ACMEAccountOrdersStatus, s conversion.Scope) error {
+	out.Count = in.Count
+	out.Truncated = in.Truncated
+	out.LastListedTime = (*v1.Time)(unsafe.Pointer(in.LastListedTime))
+	return nil
+}
+
+// Convert_v1alpha3_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(in *ACMEAccountOrdersStatus, out *acme.ACMEAccountOrdersStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEAccountOrdersStatus_To_acme_ACMEAccountOrdersStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMEAccountOrdersStatus_To_v1alpha3_ACMEAccountOrdersStatus(in *acme.ACMEAccountOrdersStatus, out *ACMEAccountOrdersStatus, s conversion.Scope) error {
+	out.Count = in.Count
+	out.Truncated = in.Truncated
+	out.LastListedTime = (*v1.Time)(unsafe.Pointer(in.LastListedTime))
+	return nil
+}
+
+// Convert_acme_ACMEAccountOrdersStatus_To_v1alpha3_ACMEAccountOrdersStatus is an autogenerated conversion function.
+func Convert_acme_ACMEAccountOrdersStatus_To_v1alpha3_ACMEAccountOrdersStatus(in *acme.ACMEAccountOrdersStatus, out *ACMEAccountOrdersStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMEAccountOrdersStatus_To_v1alpha3_ACMEAccountOrdersStatus(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEAuthorization_To_acme_ACMEAuthorization(in *ACMEAuthorization, out *acme.ACMEAuthorization, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Identifier = in.Identifier
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = acme.State(in.InitialState)
 	out.Challenges = *(*[]acme.ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.FailedChallengeTypes = *(*[]acme.ACMEChallengeType)(unsafe.Pointer(&in.FailedChallengeTypes))
+	out.Presentation = (*acme.ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -406,6 +482,8 @@ func autoConvert_acme_ACMEAuthorization_To_v1alpha3_ACMEAuthorization(in *acme.A
 	out.Wildcard = (*bool)(unsafe.Pointer(in.Wildcard))
 	out.InitialState = State(in.InitialState)
 	out.Challenges = *(*[]ACMEChallenge)(unsafe.Pointer(&in.Challenges))
+	out.FailedChallengeTypes = *(*[]ACMEChallengeType)(unsafe.Pointer(&in.FailedChallengeTypes))
+	out.Presentation = (*ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -438,6 +516,32 @@ func Convert_acme_ACMEChallenge_To_v1alpha3_ACMEChallenge(in *acme.ACMEChallenge
 	return autoConvert_acme_ACMEChallenge_To_v1alpha3_ACMEChallenge(in, out, s)
 }
 
+func autoConvert_v1alpha3_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(in *ACMEChallengePresentation, out *acme.ACMEChallengePresentation, s conversion.Scope) error {
+	out.Solver = in.Solver
+	out.ResolvedFQDN = in.ResolvedFQDN
+	out.ResolvedZone = in.ResolvedZone
+	out.CNAMETarget = in.CNAMETarget
+	return nil
+}
+
+// Convert_v1alpha3_ACMEChallengePresentation_To_acme_ACMEChallengePresentation is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(in *ACMEChallengePresentation, out *acme.ACMEChallengePresentation, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEChallengePresentation_To_acme_ACMEChallengePresentation(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengePresentation_To_v1alpha3_ACMEChallengePresentation(in *acme.ACMEChallengePresentation, out *ACMEChallengePresentation, s conversion.Scope) error {
+	out.Solver = in.Solver
+	out.ResolvedFQDN = in.ResolvedFQDN
+	out.ResolvedZone = in.ResolvedZone
+	out.CNAMETarget = in.CNAMETarget
+	return nil
+}
+
+// Convert_acme_ACMEChallengePresentation_To_v1alpha3_ACMEChallengePresentation is an autogenerated conversion function.
+func Convert_acme_ACMEChallengePresentation_To_v1alpha3_ACMEChallengePresentation(in *acme.ACMEChallengePresentation, out *ACMEChallengePresentation, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengePresentation_To_v1alpha3_ACMEChallengePresentation(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *ACMEChallengeSolver, out *acme.ACMEChallengeSolver, s conversion.Scope) error {
 	out.Selector = (*acme.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
 	out.HTTP01 = (*acme.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
@@ -450,6 +554,7 @@ func autoConvert_v1alpha3_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *AC
 	} else {
 		out.DNS01 = nil
 	}
+	out.PreCheckDelay = (*v1.Duration)(unsafe.Pointer(in.PreCheckDelay))
 	return nil
 }
 
@@ -470,6 +575,7 @@ func autoConvert_acme_ACMEChallengeSolver_To_v1alpha3_ACMEChallengeSolver(in *ac
 	} else {
 		out.DNS01 = nil
 	}
+	out.PreCheckDelay = (*v1.Duration)(unsafe.Pointer(in.PreCheckDelay))
 	return nil
 }
 
@@ -480,6 +586,7 @@ func Convert_acme_ACMEChallengeSolver_To_v1alpha3_ACMEChallengeSolver(in *acme.A
 
 func autoConvert_v1alpha3_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.ChallengeDomain = in.ChallengeDomain
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(acme.ACMEIssuerDNS01ProviderAkamai)
@@ -534,6 +641,24 @@ func autoConvert_v1alpha3_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDN
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Bunny != nil {
+		in, out := &in.Bunny, &out.Bunny
+		*out = new(acme.ACMEIssuerDNS01ProviderBunny)
+		if err := Convert_v1alpha3_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bunny = nil
+	}
+	if in.Gandi != nil {
+		in, out := &in.Gandi, &out.Gandi
+		*out = new(acme.ACMEIssuerDNS01ProviderGandi)
+		if err := Convert_v1alpha3_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Gandi = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(acme.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -563,6 +688,7 @@ func Convert_v1alpha3_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1alpha3_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = CNAMEStrategy(in.CNAMEStrategy)
+	out.ChallengeDomain = in.ChallengeDomain
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(ACMEIssuerDNS01ProviderAkamai)
@@ -617,6 +743,24 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1alpha3_ACMEChallengeSolverDN
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Bunny != nil {
+		in, out := &in.Bunny, &out.Bunny
+		*out = new(ACMEIssuerDNS01ProviderBunny)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1alpha3_ACMEIssuerDNS01ProviderBunny(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bunny = nil
+	}
+	if in.Gandi != nil {
+		in, out := &in.Gandi, &out.Gandi
+		*out = new(ACMEIssuerDNS01ProviderGandi)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1alpha3_ACMEIssuerDNS01ProviderGandi(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Gandi = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(ACMEIssuerDNS01ProviderAcmeDNS)
@@ -647,6 +791,7 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1alpha3_ACMEChallengeSolverDNS01(
 func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.HostNetwork = (*acme.ACMEChallengeSolverHTTP01HostNetwork)(unsafe.Pointer(in.HostNetwork))
 	return nil
 }
 
@@ -658,6 +803,7 @@ func Convert_v1alpha3_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP0
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1alpha3_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.HostNetwork = (*ACMEChallengeSolverHTTP01HostNetwork)(unsafe.Pointer(in.HostNetwork))
 	return nil
 }
 
@@ -667,7 +813,8 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1alpha3_ACMEChallengeSolverHTTP0
 }
 
 func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
-	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	out.ParentRefs = *(*[]v1alpha2.ParentRef)(unsafe.Pointer(&in.ParentRefs))
 	return nil
@@ -679,7 +826,8 @@ func Convert_v1alpha3_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChal
 }
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha3_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
-	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	out.ParentRefs = *(*[]v1alpha2.ParentRef)(unsafe.Pointer(&in.ParentRefs))
 	return nil
@@ -690,8 +838,31 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha3_ACMEChal
 	return autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha3_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
 }
 
+func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(in *ACMEChallengeSolverHTTP01HostNetwork, out *acme.ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	out.Port = in.Port
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(in *ACMEChallengeSolverHTTP01HostNetwork, out *acme.ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork_To_acme_ACMEChallengeSolverHTTP01HostNetwork(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork(in *acme.ACMEChallengeSolverHTTP01HostNetwork, out *ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	out.Port = in.Port
+	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork(in *acme.ACMEChallengeSolverHTTP01HostNetwork, out *ACMEChallengeSolverHTTP01HostNetwork, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01HostNetwork_To_v1alpha3_ACMEChallengeSolverHTTP01HostNetwork(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
-	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
@@ -705,7 +876,8 @@ func Convert_v1alpha3_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolv
 }
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1alpha3_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
-	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Port = in.Port
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
@@ -764,8 +936,8 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressPodObjectMeta_To_v1alpha3_ACME
 
 func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChallengeSolverHTTP01IngressPodSpec(in *ACMEChallengeSolverHTTP01IngressPodSpec, out *acme.ACMEChallengeSolverHTTP01IngressPodSpec, s conversion.Scope) error {
 	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
-	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
-	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	return nil
@@ -778,8 +950,8 @@ func Convert_v1alpha3_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChalle
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1alpha3_ACMEChallengeSolverHTTP01IngressPodSpec(in *acme.ACMEChallengeSolverHTTP01IngressPodSpec, out *ACMEChallengeSolverHTTP01IngressPodSpec, s conversion.Scope) error {
 	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
-	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
-	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	return nil
@@ -849,6 +1021,7 @@ func autoConvert_v1alpha3_ACMEExternalAccountBinding_To_acme_ACMEExternalAccount
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
 		return err
 	}
+	out.KeyFile = in.KeyFile
 	out.KeyAlgorithm = acme.HMACKeyAlgorithm(in.KeyAlgorithm)
 	return nil
 }
@@ -863,6 +1036,7 @@ func autoConvert_acme_ACMEExternalAccountBinding_To_v1alpha3_ACMEExternalAccount
 	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
 		return err
 	}
+	out.KeyFile = in.KeyFile
 	out.KeyAlgorithm = HMACKeyAlgorithm(in.KeyAlgorithm)
 	return nil
 }
@@ -900,8 +1074,11 @@ func autoConvert_v1alpha3_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acm
 	} else {
 		out.Solvers = nil
 	}
+	out.ChallengeTypePreference = *(*[]acme.ACMEChallengeType)(unsafe.Pointer(&in.ChallengeTypePreference))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.HTTPTimeout = (*v1.Duration)(unsafe.Pointer(in.HTTPTimeout))
+	out.HTTPMaxRetries = (*int)(unsafe.Pointer(in.HTTPMaxRetries))
 	return nil
 }
 
@@ -933,8 +1110,11 @@ func autoConvert_acme_ACMEIssuer_To_v1alpha3_ACMEIssuer(in *acme.ACMEIssuer, out
 	} else {
 		out.Solvers = nil
 	}
+	out.ChallengeTypePreference = *(*[]ACMEChallengeType)(unsafe.Pointer(&in.ChallengeTypePreference))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.HTTPTimeout = (*v1.Duration)(unsafe.Pointer(in.HTTPTimeout))
+	out.HTTPMaxRetries = (*int)(unsafe.Pointer(in.HTTPMaxRetries))
 	return nil
 }
 
@@ -1017,6 +1197,7 @@ func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS0
 	out.TenantID = in.TenantID
 	out.ResourceGroupName = in.ResourceGroupName
 	out.HostedZoneName = in.HostedZoneName
+	out.PrivateZone = in.PrivateZone
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
 	return nil
@@ -1042,6 +1223,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1alpha3_ACMEIssuerDNS0
 	out.TenantID = in.TenantID
 	out.ResourceGroupName = in.ResourceGroupName
 	out.HostedZoneName = in.HostedZoneName
+	out.PrivateZone = in.PrivateZone
 	out.Environment = AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
 	return nil
@@ -1052,6 +1234,30 @@ func Convert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1alpha3_ACMEIssuerDNS01Pro
 	return autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1alpha3_ACMEIssuerDNS01ProviderAzureDNS(in, out, s)
 }
 
+func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in *ACMEIssuerDNS01ProviderBunny, out *acme.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.AccessKey, &out.AccessKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in *ACMEIssuerDNS01ProviderBunny, out *acme.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderBunny_To_v1alpha3_ACMEIssuerDNS01ProviderBunny(in *acme.ACMEIssuerDNS01ProviderBunny, out *ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.AccessKey, &out.AccessKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1alpha3_ACMEIssuerDNS01ProviderBunny is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1alpha3_ACMEIssuerDNS01ProviderBunny(in *acme.ACMEIssuerDNS01ProviderBunny, out *ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderBunny_To_v1alpha3_ACMEIssuerDNS01ProviderBunny(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(in *ACMEIssuerDNS01ProviderCloudDNS, out *acme.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
@@ -1112,6 +1318,17 @@ func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDN
 	} else {
 		out.APIToken = nil
 	}
+	if in.APITokens != nil {
+		in, out := &in.APITokens, &out.APITokens
+		*out = make([]meta.SecretKeySelector, len(*in))
+		for i := range *in {
+			if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.APITokens = nil
+	}
 	return nil
 }
 
@@ -1140,6 +1357,17 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1alpha3_ACMEIssuerDN
 	} else {
 		out.APIToken = nil
 	}
+	if in.APITokens != nil {
+		in, out := &in.APITokens, &out.APITokens
+		*out = make([]apismetav1.SecretKeySelector, len(*in))
+		for i := range *in {
+			if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.APITokens = nil
+	}
 	return nil
 }
 
@@ -1172,13 +1400,47 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1alpha3_ACMEIssuerDNS0
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1alpha3_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(in *ACMEIssuerDNS01ProviderGandi, out *acme.ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(in *ACMEIssuerDNS01ProviderGandi, out *acme.ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEIssuerDNS01ProviderGandi_To_acme_ACMEIssuerDNS01ProviderGandi(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderGandi_To_v1alpha3_ACMEIssuerDNS01ProviderGandi(in *acme.ACMEIssuerDNS01ProviderGandi, out *ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1alpha3_ACMEIssuerDNS01ProviderGandi is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderGandi_To_v1alpha3_ACMEIssuerDNS01ProviderGandi(in *acme.ACMEIssuerDNS01ProviderGandi, out *ACMEIssuerDNS01ProviderGandi, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderGandi_To_v1alpha3_ACMEIssuerDNS01ProviderGandi(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
+	out.AdditionalNameservers = *(*[]string)(unsafe.Pointer(&in.AdditionalNameservers))
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
 		return err
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.TSIGKeyFile != nil {
+		in, out := &in.TSIGKeyFile, &out.TSIGKeyFile
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TSIGKeyFile = nil
+	}
 	return nil
 }
 
@@ -1189,11 +1451,21 @@ func Convert_v1alpha3_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01Prov
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1alpha3_ACMEIssuerDNS01ProviderRFC2136(in *acme.ACMEIssuerDNS01ProviderRFC2136, out *ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
+	out.AdditionalNameservers = *(*[]string)(unsafe.Pointer(&in.AdditionalNameservers))
 	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
 		return err
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	if in.TSIGKeyFile != nil {
+		in, out := &in.TSIGKeyFile, &out.TSIGKeyFile
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TSIGKeyFile = nil
+	}
 	return nil
 }
 
@@ -1208,7 +1480,10 @@ func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01
 		return err
 	}
 	out.Role = in.Role
+	out.RoleSessionName = in.RoleSessionName
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
 	out.HostedZoneID = in.HostedZoneID
+	out.Comment = in.Comment
 	out.Region = in.Region
 	return nil
 }
@@ -1224,7 +1499,10 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1alpha3_ACMEIssuerDNS01
 		return err
 	}
 	out.Role = in.Role
+	out.RoleSessionName = in.RoleSessionName
+	out.SessionTags = *(*map[string]string)(unsafe.Pointer(&in.SessionTags))
 	out.HostedZoneID = in.HostedZoneID
+	out.Comment = in.Comment
 	out.Region = in.Region
 	return nil
 }
@@ -1261,6 +1539,7 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1alpha3_ACMEIssuerDNS01Prov
 func autoConvert_v1alpha3_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Orders = (*acme.ACMEAccountOrdersStatus)(unsafe.Pointer(in.Orders))
 	return nil
 }
 
@@ -1272,6 +1551,7 @@ func Convert_v1alpha3_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *ACMEIssuerSt
 func autoConvert_acme_ACMEIssuerStatus_To_v1alpha3_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.Orders = (*ACMEAccountOrdersStatus)(unsafe.Pointer(in.Orders))
 	return nil
 }
 
@@ -1437,8 +1717,11 @@ func autoConvert_acme_ChallengeSpec_To_v1alpha3_ChallengeSpec(in *acme.Challenge
 func autoConvert_v1alpha3_ChallengeStatus_To_acme_ChallengeStatus(in *ChallengeStatus, out *acme.ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*v1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.Fallback = in.Fallback
+	out.Presentation = (*acme.ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -1450,8 +1733,11 @@ func Convert_v1alpha3_ChallengeStatus_To_acme_ChallengeStatus(in *ChallengeStatu
 func autoConvert_acme_ChallengeStatus_To_v1alpha3_ChallengeStatus(in *acme.ChallengeStatus, out *ChallengeStatus, s conversion.Scope) error {
 	out.Processing = in.Processing
 	out.Presented = in.Presented
+	out.PresentedTime = (*v1.Time)(unsafe.Pointer(in.PresentedTime))
 	out.Reason = in.Reason
 	out.State = State(in.State)
+	out.Fallback = in.Fallback
+	out.Presentation = (*ACMEChallengePresentation)(unsafe.Pointer(in.Presentation))
 	return nil
 }
 
@@ -1542,7 +1828,7 @@ func autoConvert_v1alpha3_OrderSpec_To_acme_OrderSpec(in *OrderSpec, out *acme.O
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
 	return nil
 }
 
@@ -1554,7 +1840,7 @@ func autoConvert_acme_OrderSpec_To_v1alpha3_OrderSpec(in *acme.OrderSpec, out *O
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*pkgapismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
 	return nil
 }
 
@@ -1565,7 +1851,7 @@ func autoConvert_v1alpha3_OrderStatus_To_acme_OrderStatus(in *OrderStatus, out *
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -1581,7 +1867,7 @@ func autoConvert_acme_OrderStatus_To_v1alpha3_OrderStatus(in *acme.OrderStatus,
 	out.State = State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
-	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -23,13 +23,33 @@ package v1alpha3
 
 import (
 	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEAccountOrdersStatus) DeepCopyInto(out *ACMEAccountOrdersStatus) {
+	*out = *in
+	if in.LastListedTime != nil {
+		in, out := &in.LastListedTime, &out.LastListedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEAccountOrdersStatus.
+func (in *ACMEAccountOrdersStatus) DeepCopy() *ACMEAccountOrdersStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEAccountOrdersStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEAuthorization) DeepCopyInto(out *ACMEAuthorization) {
 	*out = *in
@@ -43,6 +63,16 @@ func (in *ACMEAuthorization) DeepCopyInto(out *ACMEAuthorization) {
 		*out = make([]ACMEChallenge, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailedChallengeTypes != nil {
+		in, out := &in.FailedChallengeTypes, &out.FailedChallengeTypes
+		*out = make([]ACMEChallengeType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Presentation != nil {
+		in, out := &in.Presentation, &out.Presentation
+		*out = new(ACMEChallengePresentation)
+		**out = **in
+	}
 	return
 }
 
@@ -72,6 +102,22 @@ func (in *ACMEChallenge) DeepCopy() *ACMEChallenge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengePresentation) DeepCopyInto(out *ACMEChallengePresentation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengePresentation.
+func (in *ACMEChallengePresentation) DeepCopy() *ACMEChallengePresentation {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengePresentation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolver) DeepCopyInto(out *ACMEChallengeSolver) {
 	*out = *in
@@ -90,6 +136,11 @@ func (in *ACMEChallengeSolver) DeepCopyInto(out *ACMEChallengeSolver) {
 		*out = new(ACMEChallengeSolverDNS01)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PreCheckDelay != nil {
+		in, out := &in.PreCheckDelay, &out.PreCheckDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -124,7 +175,7 @@ func (in *ACMEChallengeSolverDNS01) DeepCopyInto(out *ACMEChallengeSolverDNS01)
 	if in.Route53 != nil {
 		in, out := &in.Route53, &out.Route53
 		*out = new(ACMEIssuerDNS01ProviderRoute53)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AzureDNS != nil {
 		in, out := &in.AzureDNS, &out.AzureDNS
@@ -136,6 +187,16 @@ func (in *ACMEChallengeSolverDNS01) DeepCopyInto(out *ACMEChallengeSolverDNS01)
 		*out = new(ACMEIssuerDNS01ProviderDigitalOcean)
 		**out = **in
 	}
+	if in.Bunny != nil {
+		in, out := &in.Bunny, &out.Bunny
+		*out = new(ACMEIssuerDNS01ProviderBunny)
+		**out = **in
+	}
+	if in.Gandi != nil {
+		in, out := &in.Gandi, &out.Gandi
+		*out = new(ACMEIssuerDNS01ProviderGandi)
+		**out = **in
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(ACMEIssuerDNS01ProviderAcmeDNS)
@@ -144,7 +205,7 @@ func (in *ACMEChallengeSolverDNS01) DeepCopyInto(out *ACMEChallengeSolverDNS01)
 	if in.RFC2136 != nil {
 		in, out := &in.RFC2136, &out.RFC2136
 		*out = new(ACMEIssuerDNS01ProviderRFC2136)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Webhook != nil {
 		in, out := &in.Webhook, &out.Webhook
@@ -177,6 +238,11 @@ func (in *ACMEChallengeSolverHTTP01) DeepCopyInto(out *ACMEChallengeSolverHTTP01
 		*out = new(ACMEChallengeSolverHTTP01GatewayHTTPRoute)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(ACMEChallengeSolverHTTP01HostNetwork)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -220,6 +286,27 @@ func (in *ACMEChallengeSolverHTTP01GatewayHTTPRoute) DeepCopy() *ACMEChallengeSo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01HostNetwork) DeepCopyInto(out *ACMEChallengeSolverHTTP01HostNetwork) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(ACMEChallengeSolverHTTP01IngressPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01HostNetwork.
+func (in *ACMEChallengeSolverHTTP01HostNetwork) DeepCopy() *ACMEChallengeSolverHTTP01HostNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01HostNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolverHTTP01Ingress) DeepCopyInto(out *ACMEChallengeSolverHTTP01Ingress) {
 	*out = *in
@@ -323,12 +410,12 @@ func (in *ACMEChallengeSolverHTTP01IngressPodSpec) DeepCopyInto(out *ACMEChallen
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -414,6 +501,21 @@ func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ChallengeTypePreference != nil {
+		in, out := &in.ChallengeTypePreference, &out.ChallengeTypePreference
+		*out = make([]ACMEChallengeType, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPTimeout != nil {
+		in, out := &in.HTTPTimeout, &out.HTTPTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HTTPMaxRetries != nil {
+		in, out := &in.HTTPMaxRetries, &out.HTTPMaxRetries
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -489,6 +591,23 @@ func (in *ACMEIssuerDNS01ProviderAzureDNS) DeepCopy() *ACMEIssuerDNS01ProviderAz
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderBunny) DeepCopyInto(out *ACMEIssuerDNS01ProviderBunny) {
+	*out = *in
+	out.AccessKey = in.AccessKey
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderBunny.
+func (in *ACMEIssuerDNS01ProviderBunny) DeepCopy() *ACMEIssuerDNS01ProviderBunny {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderBunny)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderCloudDNS) DeepCopyInto(out *ACMEIssuerDNS01ProviderCloudDNS) {
 	*out = *in
@@ -523,6 +642,11 @@ func (in *ACMEIssuerDNS01ProviderCloudflare) DeepCopyInto(out *ACMEIssuerDNS01Pr
 		*out = new(metav1.SecretKeySelector)
 		**out = **in
 	}
+	if in.APITokens != nil {
+		in, out := &in.APITokens, &out.APITokens
+		*out = make([]metav1.SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -553,10 +677,37 @@ func (in *ACMEIssuerDNS01ProviderDigitalOcean) DeepCopy() *ACMEIssuerDNS01Provid
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderGandi) DeepCopyInto(out *ACMEIssuerDNS01ProviderGandi) {
+	*out = *in
+	out.Token = in.Token
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderGandi.
+func (in *ACMEIssuerDNS01ProviderGandi) DeepCopy() *ACMEIssuerDNS01ProviderGandi {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderGandi)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderRFC2136) DeepCopyInto(out *ACMEIssuerDNS01ProviderRFC2136) {
 	*out = *in
+	if in.AdditionalNameservers != nil {
+		in, out := &in.AdditionalNameservers, &out.AdditionalNameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.TSIGSecret = in.TSIGSecret
+	if in.TSIGKeyFile != nil {
+		in, out := &in.TSIGKeyFile, &out.TSIGKeyFile
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
 	return
 }
 
@@ -574,6 +725,13 @@ func (in *ACMEIssuerDNS01ProviderRFC2136) DeepCopy() *ACMEIssuerDNS01ProviderRFC
 func (in *ACMEIssuerDNS01ProviderRoute53) DeepCopyInto(out *ACMEIssuerDNS01ProviderRoute53) {
 	*out = *in
 	out.SecretAccessKey = in.SecretAccessKey
+	if in.SessionTags != nil {
+		in, out := &in.SessionTags, &out.SessionTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -611,6 +769,11 @@ func (in *ACMEIssuerDNS01ProviderWebhook) DeepCopy() *ACMEIssuerDNS01ProviderWeb
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerStatus) DeepCopyInto(out *ACMEIssuerStatus) {
 	*out = *in
+	if in.Orders != nil {
+		in, out := &in.Orders, &out.Orders
+		*out = new(ACMEAccountOrdersStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -679,7 +842,7 @@ func (in *Challenge) DeepCopyInto(out *Challenge) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -755,6 +918,15 @@ func (in *ChallengeSpec) DeepCopy() *ChallengeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChallengeStatus) DeepCopyInto(out *ChallengeStatus) {
 	*out = *in
+	if in.PresentedTime != nil {
+		in, out := &in.PresentedTime, &out.PresentedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Presentation != nil {
+		in, out := &in.Presentation, &out.Presentation
+		*out = new(ACMEChallengePresentation)
+		**out = **in
+	}
 	return
 }
 
@@ -850,7 +1022,7 @@ func (in *OrderSpec) DeepCopyInto(out *OrderSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
-		*out = new(apismetav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	return
@@ -133,6 +133,11 @@ type ChallengeStatus struct {
 	// +optional
 	Presented bool `json:"presented"`
 
+	// PresentedTime is the time at which the challenge values were last
+	// presented.
+	// +optional
+	PresentedTime *metav1.Time `json:"presentedTime,omitempty"`
+
 	// Contains human readable information on why the Challenge is in the
 	// current state.
 	// +optional
@@ -142,4 +147,40 @@ type ChallengeStatus struct {
 	// If not set, the state of the challenge is unknown.
 	// +optional
 	State State `json:"state,omitempty"`
+
+	// Fallback will be set to true if this challenge could not be presented
+	// and the issuer is configured to fall back to another challenge type.
+	// Any resources created for the challenge have been cleaned up and the
+	// owning Order will replace it with a challenge of the next preferred type.
+	// +optional
+	Fallback bool `json:"fallback,omitempty"`
+
+	// Presentation describes the DNS01 record presented for this challenge.
+	// It is set when a DNS01 challenge has been presented.
+	// +optional
+	Presentation *ACMEChallengePresentation `json:"presentation,omitempty"`
+}
+
+// ACMEChallengePresentation describes how the records for a DNS01 challenge
+// were presented, to help diagnose challenges that do not behave as expected.
+type ACMEChallengePresentation struct {
+	// Solver is the name of the DNS01 provider that was selected to present
+	// the challenge, e.g. 'cloudflare' or 'webhook/<groupName>/<solverName>'.
+	// +optional
+	Solver string `json:"solver,omitempty"`
+
+	// ResolvedFQDN is the fully qualified domain name of the TXT record that
+	// was presented.
+	// +optional
+	ResolvedFQDN string `json:"resolvedFQDN,omitempty"`
+
+	// ResolvedZone is the DNS zone that the TXT record was presented in.
+	// It is empty if the zone could not be determined.
+	// +optional
+	ResolvedZone string `json:"resolvedZone,omitempty"`
+
+	// CNAMETarget is the final target of the CNAME records that were followed
+	// from the '_acme-challenge' record of the DNS name, if any were followed.
+	// +optional
+	CNAMETarget string `json:"cnameTarget,omitempty"`
 }
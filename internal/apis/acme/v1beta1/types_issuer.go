@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -86,6 +87,19 @@ type ACMEIssuer struct {
 	// +optional
 	Solvers []ACMEChallengeSolver `json:"solvers,omitempty"`
 
+	// ChallengeTypePreference is an ordered list of challenge types to use
+	// when solving an authorization, most preferred first.
+	// For each authorization, a solver of the first listed type that matches
+	// is used. If that challenge cannot be presented because its solver is
+	// unsupported or misconfigured, its resources are cleaned up and the next
+	// listed type with a matching solver is attempted instead. Any other
+	// error is retried using the same challenge type.
+	// Solvers for challenge types that are not listed are not used.
+	// If not set, the most specific matching solver is used and no fallback is
+	// attempted.
+	// +optional
+	ChallengeTypePreference []ACMEChallengeType `json:"challengeTypePreference,omitempty"`
+
 	// Enables or disables generating a new ACME account key.
 	// If true, the Issuer resource will *not* request a new account but will expect
 	// the account key to be supplied via an existing secret.
@@ -102,6 +116,21 @@ type ACMEIssuer struct {
 	// Defaults to false.
 	// +optional
 	EnableDurationFeature bool `json:"enableDurationFeature,omitempty"`
+
+	// HTTPTimeout is the maximum amount of time that a single request to the
+	// ACME server may take, including reading the response body.
+	// Requests which are retried are given the full timeout on each attempt.
+	// Defaults to 30 seconds.
+	// +optional
+	HTTPTimeout *metav1.Duration `json:"httpTimeout,omitempty"`
+
+	// HTTPMaxRetries is the number of times that an idempotent request to the
+	// ACME server, such as fetching the directory or a new nonce, is retried
+	// after failing with a network error, a timeout or a 5xx response.
+	// Requests which may change state on the ACME server are never retried.
+	// Must be between 0 and 10. Defaults to 0, which disables retries.
+	// +optional
+	HTTPMaxRetries *int `json:"httpMaxRetries,omitempty"`
 }
 
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
@@ -117,7 +146,20 @@ type ACMEExternalAccountBinding struct {
 	// the External Account Binding keyID above.
 	// The secret key stored in the Secret **must** be un-padded, base64 URL
 	// encoded data.
-	Key cmmeta.SecretKeySelector `json:"keySecretRef"`
+	// Exactly one of keySecretRef or keyFile must be specified.
+	// +optional
+	Key cmmeta.SecretKeySelector `json:"keySecretRef,omitempty"`
+
+	// keyFile is the name of a file holding the symmetric MAC key of the
+	// External Account Binding, as an alternative to keySecretRef. The file is
+	// read from the directory configured with the controller's
+	// --acme-eab-key-dir flag, for example a mounted CSI volume, when the ACME
+	// account is registered. ClusterIssuers read the file from the directory
+	// itself, whereas Issuers read it from the subdirectory named after their
+	// namespace. The key stored in the file **must** be un-padded, base64 URL
+	// encoded data.
+	// +optional
+	KeyFile string `json:"keyFile,omitempty"`
 
 	// Deprecated: keyAlgorithm field exists for historical compatibility
 	// reasons and should not be used. The algorithm is now hardcoded to HS256
@@ -158,6 +200,14 @@ type ACMEChallengeSolver struct {
 	// performing the DNS01 challenge flow.
 	// +optional
 	DNS01 *ACMEChallengeSolverDNS01 `json:"dns01,omitempty"`
+
+	// PreCheckDelay is the time to wait after presenting a challenge before
+	// the first check that it has propagated. Use this if the records or
+	// resources presented by this solver are not served until some time
+	// after they have been created, so that the first check would always
+	// fail. If not set, the first check is performed straight away.
+	// +optional
+	PreCheckDelay *metav1.Duration `json:"preCheckDelay,omitempty"`
 }
 
 // CertificateDomainSelector selects certificates using a label selector, and
@@ -212,6 +262,19 @@ type ACMEChallengeSolverHTTP01 struct {
 	// This solver is experimental, and fields / behaviour may change in the future.
 	// +optional
 	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute `json:"gatewayHTTPRoute,omitempty"`
+
+	// The HostNetwork solver runs the challenge solver pods on the host network
+	// of the nodes they are scheduled to, listening on the configured port.
+	// This is intended for bare-metal clusters without a LoadBalancer, where an
+	// external load balancer or DNS record routes requests for the challenge
+	// to those nodes. No Ingress or HTTPRoute is created.
+	// Unlike the Ingress solver, this solver is never used unless it is
+	// configured explicitly, and it can only be used when the
+	// ExperimentalHTTP01HostNetworkSolver feature gate is enabled on the
+	// cert-manager controller.
+	// This solver is experimental, and fields / behaviour may change in the future.
+	// +optional
+	HostNetwork *ACMEChallengeSolverHTTP01HostNetwork `json:"hostNetwork,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -220,6 +283,15 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	// +optional
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 
+	// Optional port that the challenge solver pods listen on, which is also
+	// the port of the solver Service that the created Ingress routes to.
+	// This may be used when the ingress controller expects backends on a
+	// specific port. If unset, defaults to 8089.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
 	// The ingress class to use when creating Ingress resources to solve ACME
 	// challenges that use this challenge solver.
 	// Only one of 'class' or 'name' may be specified.
@@ -251,6 +323,15 @@ type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
 	// +optional
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 
+	// Optional port that the challenge solver pods listen on, which is also
+	// the port of the solver Service that the created HTTPRoute routes to.
+	// This may be used when the Gateway implementation expects backends on a
+	// specific port. If unset, defaults to 8089.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
 	// Custom labels that will be applied to HTTPRoutes created by cert-manager
 	// while solving HTTP-01 challenges.
 	// +optional
@@ -263,6 +344,25 @@ type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
 	ParentRefs []gwapi.ParentRef `json:"parentRefs,omitempty"`
 }
 
+// The ACMEChallengeSolverHTTP01HostNetwork solver will create challenge solver
+// pods using the host network, and a headless Service selecting them.
+type ACMEChallengeSolverHTTP01HostNetwork struct {
+	// Port on the host network that the challenge solver pods listen on.
+	// Ports below 1024 can only be used if the nodes allow unprivileged
+	// processes to bind to them, as the solver does not run as root.
+	// Only one challenge solver pod can run on each node at a time. If the
+	// port is already in use on every node the pod may be scheduled to, the
+	// challenge is not presented and the conflict is reported in its reason.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Optional pod template used to configure the ACME challenge solver pods,
+	// for example to select the nodes they may run on using a nodeSelector.
+	// +optional
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
+}
+
 type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 	// ObjectMeta overrides for the pod used to solve HTTP01 challenges.
 	// Only the 'labels' and 'annotations' fields may be set.
@@ -341,6 +441,16 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	CNAMEStrategy CNAMEStrategy `json:"cnameStrategy,omitempty"`
 
+	// ChallengeDomain delegates solving challenges to another DNS domain.
+	// If set, the TXT record for a challenge for `example.com` is created as
+	// `_acme-challenge.example.com.<challengeDomain>` in the zone of the
+	// challenge domain, and is checked there, regardless of any CNAME
+	// records. `_acme-challenge.example.com` must be a CNAME to that record
+	// for the ACME server to validate it. May not be used together with the
+	// `Follow` CNAME strategy.
+	// +optional
+	ChallengeDomain string `json:"challengeDomain,omitempty"`
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	// +optional
 	Akamai *ACMEIssuerDNS01ProviderAkamai `json:"akamai,omitempty"`
@@ -365,6 +475,14 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean `json:"digitalocean,omitempty"`
 
+	// Use the Bunny.net DNS API to manage DNS01 challenge records.
+	// +optional
+	Bunny *ACMEIssuerDNS01ProviderBunny `json:"bunny,omitempty"`
+
+	// Use the Gandi LiveDNS API to manage DNS01 challenge records.
+	// +optional
+	Gandi *ACMEIssuerDNS01ProviderGandi `json:"gandi,omitempty"`
+
 	// Use the 'ACME DNS' (https://github.com/joohoi/acme-dns) API to manage
 	// DNS01 challenge records.
 	// +optional
@@ -440,6 +558,13 @@ type ACMEIssuerDNS01ProviderCloudflare struct {
 	// API token used to authenticate with Cloudflare.
 	// +optional
 	APIToken *cmmeta.SecretKeySelector `json:"apiTokenSecretRef,omitempty"`
+
+	// Ordered list of API tokens used to authenticate with Cloudflare.
+	// If Cloudflare rejects a token, or rate limits requests made with it,
+	// the next token in the list is tried.
+	// Cannot be used together with apiKeySecretRef or apiTokenSecretRef.
+	// +optional
+	APITokens []cmmeta.SecretKeySelector `json:"apiTokenSecretRefs,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderDigitalOcean is a structure containing the DNS
@@ -448,6 +573,18 @@ type ACMEIssuerDNS01ProviderDigitalOcean struct {
 	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
 }
 
+// ACMEIssuerDNS01ProviderBunny is a structure containing the DNS
+// configuration for Bunny.net DNS
+type ACMEIssuerDNS01ProviderBunny struct {
+	AccessKey cmmeta.SecretKeySelector `json:"accessKeySecretRef"`
+}
+
+// ACMEIssuerDNS01ProviderGandi is a structure containing the DNS
+// configuration for Gandi LiveDNS
+type ACMEIssuerDNS01ProviderGandi struct {
+	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
+}
+
 // ACMEIssuerDNS01ProviderRoute53 is a structure containing the Route 53
 // configuration for AWS
 type ACMEIssuerDNS01ProviderRoute53 struct {
@@ -466,10 +603,29 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 	// +optional
 	Role string `json:"role,omitempty"`
 
+	// RoleSessionName is the name of the session created when assuming Role.
+	// Defaults to "cert-manager".
+	// +optional
+	RoleSessionName string `json:"roleSessionName,omitempty"`
+
+	// SessionTags are passed as session tags when assuming Role, e.g. for use
+	// in the conditions of service control policies. They are applied in
+	// addition to any transitive session tags of the credentials used to
+	// assume Role, such as those of an IAM role assumed using IRSA.
+	// +optional
+	SessionTags map[string]string `json:"sessionTags,omitempty"`
+
 	// If set, the provider will manage only this zone in Route53 and will not do an lookup using the route53:ListHostedZonesByName api call.
 	// +optional
 	HostedZoneID string `json:"hostedZoneID,omitempty"`
 
+	// Comment is set on the Route53 change batches used to create and delete
+	// challenge records, e.g. to identify the owner of the records when
+	// auditing. The Order the challenge belongs to is appended to it. If not
+	// set, "Managed by cert-manager" is used.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
 	// Always set the region when using AccessKeyID and SecretAccessKey
 	Region string `json:"region"`
 }
@@ -499,6 +655,13 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 	// +optional
 	HostedZoneName string `json:"hostedZoneName,omitempty"`
 
+	// if true, the DNS zone is an Azure Private DNS zone, whose records are
+	// managed using the Azure Private DNS API. The records only resolve in the
+	// virtual networks linked to the zone, so the ACME server and the
+	// nameservers used for the DNS01 self check must be able to query them.
+	// +optional
+	PrivateZone bool `json:"privateZone,omitempty"`
+
 	// name of the Azure environment (default AzurePublicCloud)
 	// +optional
 	Environment AzureDNSEnvironment `json:"environment,omitempty"`
@@ -545,6 +708,13 @@ type ACMEIssuerDNS01ProviderRFC2136 struct {
 	// This field is required.
 	Nameserver string `json:"nameserver"`
 
+	// Additional IP addresses or hostnames of authoritative DNS servers
+	// supporting RFC2136, in the same form as ``nameserver``. If an update is
+	// not accepted by ``nameserver``, these servers are tried in order until
+	// one accepts it.
+	// +optional
+	AdditionalNameservers []string `json:"additionalNameservers,omitempty"`
+
 	// The name of the secret containing the TSIG value.
 	// If ``tsigKeyName`` is defined, this field is required.
 	// +optional
@@ -561,6 +731,13 @@ type ACMEIssuerDNS01ProviderRFC2136 struct {
 	// ``HMACSHA1``, ``HMACSHA256`` or ``HMACSHA512``.
 	// +optional
 	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+
+	// A reference to a key in a Secret containing a BIND style TSIG keyfile,
+	// as generated by `tsig-keygen`, that defines a single key with its name,
+	// algorithm and secret. May not be set together with ``tsigKeyName``,
+	// ``tsigSecretSecretRef`` or ``tsigAlgorithm``.
+	// +optional
+	TSIGKeyFile *cmmeta.SecretKeySelector `json:"tsigKeyFileSecretRef,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderWebhook specifies configuration for a webhook DNS01
@@ -600,4 +777,30 @@ type ACMEIssuerStatus struct {
 	// associated with the  Issuer
 	// +optional
 	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
+
+	// Orders is the result of the last listing of the orders of the ACME
+	// account, which is only done if enabled in the cert-manager controller.
+	// +optional
+	Orders *ACMEAccountOrdersStatus `json:"orders,omitempty"`
+}
+
+// ACMEAccountOrdersStatus is the result of listing the orders of an ACME
+// account.
+type ACMEAccountOrdersStatus struct {
+	// Count is the number of orders returned by the ACME server when the
+	// orders were last listed. ACME servers list pending orders and may also
+	// list ready, processing and valid orders, but not invalid ones, so this
+	// is not only the number of pending orders. Orders cannot be deleted, so
+	// an increasing number indicates that orders are being created and
+	// abandoned.
+	Count int `json:"count"`
+
+	// Truncated is true if the ACME server returned more pages of orders than
+	// cert-manager lists, in which case Count is a lower bound.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+
+	// LastListedTime is the time at which the orders were last listed.
+	// +optional
+	LastListedTime *metav1.Time `json:"lastListedTime,omitempty"`
 }
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
+	cmvalidation "github.com/cert-manager/cert-manager/internal/apis/certmanager/validation"
 )
 
 func ValidateChallengeUpdate(a *admissionv1.AdmissionRequest, oldObj, newObj runtime.Object) (field.ErrorList, []string) {
@@ -42,5 +43,6 @@ func ValidateChallengeUpdate(a *admissionv1.AdmissionRequest, oldObj, newObj run
 }
 
 func ValidateChallenge(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
-	return nil, nil
+	ch := obj.(*cmacme.Challenge)
+	return cmvalidation.ValidateIssuerRef(ch.Spec.IssuerRef, field.NewPath("spec", "issuerRef")), nil
 }
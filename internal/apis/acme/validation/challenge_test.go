@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 )
 
 func TestValidateChallengeUpdate(t *testing.T) {
@@ -106,7 +107,31 @@ func TestValidateChallenge(t *testing.T) {
 		a        *admissionv1.AdmissionRequest
 		errs     []*field.Error
 		warnings []string
-	}{}
+	}{
+		"challenge referencing a cert-manager Issuer": {
+			chal: &cmacme.Challenge{Spec: cmacme.ChallengeSpec{IssuerRef: cmmeta.ObjectReference{Name: "acme", Kind: "Issuer", Group: "cert-manager.io"}}},
+			a:    someAdmissionRequest,
+		},
+		"challenge referencing an external issuer": {
+			chal: &cmacme.Challenge{Spec: cmacme.ChallengeSpec{IssuerRef: cmmeta.ObjectReference{Name: "acme", Kind: "ExternalACMEIssuer", Group: "acme.example.com"}}},
+			a:    someAdmissionRequest,
+		},
+		"challenge referencing an unknown cert-manager issuer kind": {
+			chal: &cmacme.Challenge{Spec: cmacme.ChallengeSpec{IssuerRef: cmmeta.ObjectReference{Name: "acme", Kind: "ExternalACMEIssuer"}}},
+			a:    someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec", "issuerRef", "kind"), "ExternalACMEIssuer", "must be one of Issuer or ClusterIssuer, or issuerRef.group must be set to the API group of the external issuer providing the ExternalACMEIssuer kind"),
+			},
+		},
+		"challenge referencing an invalid external issuer group": {
+			chal: &cmacme.Challenge{Spec: cmacme.ChallengeSpec{IssuerRef: cmmeta.ObjectReference{Kind: "ExternalACMEIssuer", Group: "acme_example"}}},
+			a:    someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(field.NewPath("spec", "issuerRef", "name"), "must be specified"),
+				field.Invalid(field.NewPath("spec", "issuerRef", "group"), "acme_example", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
 			errs, warnings := ValidateChallenge(s.a, s.chal)
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
+	cmvalidation "github.com/cert-manager/cert-manager/internal/apis/certmanager/validation"
 )
 
 func ValidateOrderUpdate(a *admissionv1.AdmissionRequest, oldObj, newObj runtime.Object) (field.ErrorList, []string) {
@@ -41,7 +42,8 @@ func ValidateOrderUpdate(a *admissionv1.AdmissionRequest, oldObj, newObj runtime
 }
 
 func ValidateOrder(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
-	return nil, nil
+	order := obj.(*cmacme.Order)
+	return cmvalidation.ValidateIssuerRef(order.Spec.IssuerRef, field.NewPath("spec", "issuerRef")), nil
 }
 
 func ValidateOrderSpecUpdate(old, new cmacme.OrderSpec, fldPath *field.Path) field.ErrorList {
@@ -26,6 +26,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 )
 
 type testValue string
@@ -257,7 +258,31 @@ func TestValidateOrder(t *testing.T) {
 		a        *admissionv1.AdmissionRequest
 		errs     []*field.Error
 		warnings []string
-	}{}
+	}{
+		"order referencing a cert-manager Issuer": {
+			order: &cmacme.Order{Spec: cmacme.OrderSpec{IssuerRef: cmmeta.ObjectReference{Name: "acme", Kind: "Issuer", Group: "cert-manager.io"}}},
+			a:     someAdmissionRequest,
+		},
+		"order referencing an external issuer": {
+			order: &cmacme.Order{Spec: cmacme.OrderSpec{IssuerRef: cmmeta.ObjectReference{Name: "acme", Kind: "ExternalACMEIssuer", Group: "acme.example.com"}}},
+			a:     someAdmissionRequest,
+		},
+		"order referencing an unknown cert-manager issuer kind": {
+			order: &cmacme.Order{Spec: cmacme.OrderSpec{IssuerRef: cmmeta.ObjectReference{Name: "acme", Kind: "ExternalACMEIssuer"}}},
+			a:     someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec", "issuerRef", "kind"), "ExternalACMEIssuer", "must be one of Issuer or ClusterIssuer, or issuerRef.group must be set to the API group of the external issuer providing the ExternalACMEIssuer kind"),
+			},
+		},
+		"order referencing an invalid external issuer group": {
+			order: &cmacme.Order{Spec: cmacme.OrderSpec{IssuerRef: cmmeta.ObjectReference{Kind: "ExternalACMEIssuer", Group: "acme_example"}}},
+			a:     someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(field.NewPath("spec", "issuerRef", "name"), "must be specified"),
+				field.Invalid(field.NewPath("spec", "issuerRef", "group"), "acme_example", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
 			errs, warnings := ValidateOrder(s.a, s.order)
@@ -132,6 +132,11 @@ type CertificateRequestStatus struct {
 	// FailureTime stores the time that this CertificateRequest failed. This is
 	// used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// NextRetryTime is the earliest time at which the controller will retry
+	// signing this CertificateRequest after a failed attempt. It is unset once
+	// the request has been signed successfully.
+	NextRetryTime *metav1.Time
 }
 
 // CertificateRequestCondition contains condition information for a CertificateRequest.
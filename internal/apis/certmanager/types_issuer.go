@@ -82,6 +82,44 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig
+
+	// DefaultPrivateKey is the private key parameters used for Certificates
+	// referencing this issuer which do not set them in spec.privateKey.
+	// Parameters set on a Certificate always take precedence.
+	// +optional
+	DefaultPrivateKey *IssuerPrivateKeyDefaults
+
+	// TrustBundle is a PEM encoded bundle of CA certificates which are trusted,
+	// in addition to the system roots, when this issuer connects to external
+	// services over TLS. It is used by the ACME client, the Vault and Venafi
+	// clients and the ACMEDNS, AzureDNS, Bunny, CloudDNS, Cloudflare,
+	// DigitalOcean, Gandi and Route53 DNS01 providers, for example when egress
+	// is through a TLS-inspecting proxy. It is not used by the Akamai DNS01
+	// provider or by webhook solvers. Where a service also has its own caBundle,
+	// which replaces the system roots, these certificates are trusted in
+	// addition to it.
+	// +optional
+	TrustBundle []byte
+}
+
+// IssuerPrivateKeyDefaults are the default private key parameters of
+// Certificates referencing an issuer.
+// The algorithm and size are only applied to a Certificate which sets
+// neither of them, or which sets the same algorithm but no size.
+type IssuerPrivateKeyDefaults struct {
+	// Algorithm is the default private key algorithm.
+	// +optional
+	Algorithm PrivateKeyAlgorithm
+
+	// Size is the default key bit size of the private key, interpreted as
+	// for spec.privateKey.size of a Certificate.
+	// +optional
+	Size int
+
+	// Encoding is the default private key cryptography standard (PKCS) the
+	// private key is encoded with.
+	// +optional
+	Encoding PrivateKeyEncoding
 }
 
 // IssuerConfig is a generic wrapper around custom issuer types
@@ -114,6 +152,8 @@ type VenafiIssuer struct {
 	// Zone is the Venafi Policy Zone to use for this issuer.
 	// All requests made to the Venafi platform will be restricted by the named
 	// zone policy.
+	// For Venafi Cloud, the zone must be in the format
+	// "<application>\<issuing template alias>".
 	// This field is required.
 	Zone string
 
@@ -144,6 +184,14 @@ type VenafiTPP struct {
 	// If not specified, the connection will be verified using the cert-manager
 	// system root certificates.
 	CABundle []byte
+
+	// CABundleSecretRef is a reference to a key in a Secret containing a PEM
+	// encoded TLS certificate to use to verify connections to the TPP
+	// instance. It can be used instead of CABundle when the CA is managed
+	// separately, for example by another Certificate. If the key is not
+	// specified, 'ca.crt' is used.
+	// Only one of CABundle or CABundleSecretRef may be specified.
+	CABundleSecretRef *cmmeta.SecretKeySelector
 }
 
 // VenafiCloud defines connection configuration details for Venafi Cloud
@@ -163,6 +211,15 @@ type SelfSignedIssuer struct {
 	// the location of the CRL from which the revocation of this certificate can be checked.
 	// If not set certificate will be issued without CDP. Values are strings.
 	CRLDistributionPoints []string
+
+	// PathLen is the maximum number of intermediate CA certificates that may
+	// follow a CA certificate issued by this issuer in a certificate chain.
+	// It is only applied to certificates requested with isCA set. A value of
+	// 0 means that only end-entity certificates may be issued by the CA. If
+	// not set, CA certificates will be issued without a path length
+	// constraint.
+	// +optional
+	PathLen *int
 }
 
 // VaultIssuer configures an issuer to sign certificates using a HashiCorp Vault
@@ -178,6 +235,14 @@ type VaultIssuer struct {
 	// "my_pki_mount/sign/my-role-name".
 	Path string
 
+	// Mounts is an optional list of additional Vault PKI backend `sign`
+	// endpoints, each selected by the domain names being requested. When
+	// signing, the first entry whose domain suffixes match the common name
+	// and every DNS name of the request is used. If no entry matches, or the
+	// request contains no domain names, Path is used.
+	// +optional
+	Mounts []VaultPKIMount
+
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
 	// More about namespaces can be found here https://www.vaultproject.io/docs/enterprise/namespaces
 	Namespace string
@@ -189,6 +254,19 @@ type VaultIssuer struct {
 	CABundle []byte
 }
 
+// VaultPKIMount is a Vault PKI backend `sign` endpoint which is used for
+// requests whose domain names all fall under one of its domain suffixes.
+type VaultPKIMount struct {
+	// Path is the mount path of the Vault PKI backend's `sign` endpoint, e.g:
+	// "my_pki_mount/sign/my-role-name".
+	Path string
+
+	// DomainSuffixes is the list of DNS domains served by this mount, e.g:
+	// "staging.example.com". A name matches a suffix if it is equal to it or
+	// is a subdomain of it.
+	DomainSuffixes []string
+}
+
 // VaultAuth is configuration used to authenticate with a Vault server.
 // Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
 type VaultAuth struct {
@@ -249,8 +327,16 @@ type VaultKubernetesAuth struct {
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
 	// by this Issuer.
+	// Exactly one of SecretName or CertificateName must be set.
 	SecretName string
 
+	// CertificateName is the name of a Certificate resource, in the same
+	// namespace as the secret would be read from, whose Secret holds the
+	// signing CA keypair. The Secret is resolved from the Certificate on each
+	// use so a renewed CA is picked up as soon as it is issued.
+	// Exactly one of SecretName or CertificateName must be set.
+	CertificateName string
+
 	// The CRL distribution points is an X.509 v3 certificate extension which identifies
 	// the location of the CRL from which the revocation of this certificate can be checked.
 	// If not set, certificates will be issued without distribution points set.
@@ -262,6 +348,23 @@ type CAIssuer struct {
 	// certificate will be issued with no OCSP servers set. For example, an
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	OCSPServers []string
+
+	// IssuingCertificateURLs are the URLs from which the certificate of this
+	// issuer can be downloaded. They are set as the CA Issuers access method
+	// of the Authority Information Access X.509 v3 extension of issued
+	// certificates, allowing clients to build the certificate chain. If not
+	// set, certificates will be issued without CA Issuers URLs set.
+	// +optional
+	IssuingCertificateURLs []string
+
+	// PathLen is the maximum number of intermediate CA certificates that may
+	// follow a CA certificate issued by this issuer in a certificate chain.
+	// It is only applied to certificates requested with isCA set. A value of
+	// 0 means that only end-entity certificates may be issued by the CA. If
+	// not set, CA certificates will be issued without a path length
+	// constraint.
+	// +optional
+	PathLen *int
 }
 
 // IssuerStatus contains status information about an Issuer
@@ -313,4 +416,15 @@ const (
 	// If the `status` of this condition is `False`, CertificateRequest controllers
 	// should prevent attempts to sign certificates.
 	IssuerConditionReady IssuerConditionType = "Ready"
+
+	// IssuerConditionDNS01Validated represents whether the credentials and
+	// zone access of an ACME Issuer's DNS01 solvers were validated by a dry
+	// run. It is only set for DNS01 providers that support validation.
+	IssuerConditionDNS01Validated IssuerConditionType = "DNS01Validated"
+
+	// IssuerConditionSolversConfigured represents whether the resources
+	// referenced by an ACME Issuer's solvers, such as Secrets and DNS01
+	// webhooks, are available. Misconfigured solvers do not affect the
+	// Ready condition.
+	IssuerConditionSolversConfigured IssuerConditionType = "SolversConfigured"
 )
@@ -17,9 +17,37 @@ limitations under the License.
 package v1
 
 import (
+	"net/url"
+
 	"k8s.io/apimachinery/pkg/runtime"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_Certificate lower cases the scheme and host of the URI SANs of a
+// Certificate, as they are case-insensitive. URIs which are not absolute are
+// left untouched so that validation can report them.
+// If a usage profile is set and no usages have been explicitly specified,
+// the usages are populated from the profile. Unknown profiles are left for
+// validation to report.
+func SetDefaults_Certificate(obj *cmapi.Certificate) {
+	if len(obj.Spec.Usages) == 0 {
+		if usages, ok := apiutil.UsagesForProfile(obj.Spec.UsageProfile); ok {
+			obj.Spec.Usages = usages
+		}
+	}
+	for i, uri := range obj.Spec.URIs {
+		if u, err := url.Parse(uri); err != nil || !u.IsAbs() {
+			continue
+		}
+		if normalized, err := util.NormalizeURI(uri); err == nil {
+			obj.Spec.URIs[i] = normalized
+		}
+	}
+}
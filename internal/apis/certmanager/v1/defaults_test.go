@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"reflect"
+	"testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestSetDefaults_CertificateUsageProfile(t *testing.T) {
+	tests := map[string]struct {
+		profile   cmapi.UsageProfile
+		usages    []cmapi.KeyUsage
+		expUsages []cmapi.KeyUsage
+	}{
+		"no profile leaves usages unset": {},
+		"ServerAuth expands to server usages": {
+			profile:   cmapi.ServerAuthUsageProfile,
+			expUsages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth},
+		},
+		"ClientAuth expands to client usages": {
+			profile:   cmapi.ClientAuthUsageProfile,
+			expUsages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageClientAuth},
+		},
+		"MutualTLS expands to server and client usages": {
+			profile:   cmapi.MutualTLSUsageProfile,
+			expUsages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+		},
+		"CodeSigning expands to code signing usages": {
+			profile:   cmapi.CodeSigningUsageProfile,
+			expUsages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageCodeSigning},
+		},
+		"explicit usages override the profile": {
+			profile:   cmapi.MutualTLSUsageProfile,
+			usages:    []cmapi.KeyUsage{cmapi.UsageServerAuth},
+			expUsages: []cmapi.KeyUsage{cmapi.UsageServerAuth},
+		},
+		"unknown profile leaves usages unset": {
+			profile: cmapi.UsageProfile("Unknown"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				UsageProfile: test.profile,
+				Usages:       test.usages,
+			}}
+			SetDefaults_Certificate(crt)
+			if !reflect.DeepEqual(test.expUsages, crt.Spec.Usages) {
+				t.Errorf("unexpected usages, exp=%v got=%v", test.expUsages, crt.Spec.Usages)
+			}
+		})
+	}
+}
+
+func TestSetDefaults_CertificateUsageProfileDoesNotShareSlices(t *testing.T) {
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{UsageProfile: cmapi.ServerAuthUsageProfile}}
+	SetDefaults_Certificate(crt)
+	crt.Spec.Usages[0] = cmapi.UsageAny
+
+	if usages, _ := apiutil.UsagesForProfile(cmapi.ServerAuthUsageProfile); usages[0] != cmapi.UsageDigitalSignature {
+		t.Errorf("mutating the defaulted usages modified the ServerAuth profile")
+	}
+}
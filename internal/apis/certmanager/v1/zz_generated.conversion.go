@@ -164,6 +164,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretKeys)(nil), (*certmanager.CertificateSecretKeys)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(a.(*v1.CertificateSecretKeys), b.(*certmanager.CertificateSecretKeys), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretKeys)(nil), (*v1.CertificateSecretKeys)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretKeys_To_v1_CertificateSecretKeys(a.(*certmanager.CertificateSecretKeys), b.(*v1.CertificateSecretKeys), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -244,6 +254,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerPrivateKeyDefaults)(nil), (*certmanager.IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(a.(*v1.IssuerPrivateKeyDefaults), b.(*certmanager.IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerPrivateKeyDefaults)(nil), (*v1.IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(a.(*certmanager.IssuerPrivateKeyDefaults), b.(*v1.IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(a.(*v1.IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -284,6 +304,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.SPIFFEID)(nil), (*certmanager.SPIFFEID)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SPIFFEID_To_certmanager_SPIFFEID(a.(*v1.SPIFFEID), b.(*certmanager.SPIFFEID), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SPIFFEID)(nil), (*v1.SPIFFEID)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SPIFFEID_To_v1_SPIFFEID(a.(*certmanager.SPIFFEID), b.(*v1.SPIFFEID), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -334,6 +364,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultPKIMount)(nil), (*certmanager.VaultPKIMount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultPKIMount_To_certmanager_VaultPKIMount(a.(*v1.VaultPKIMount), b.(*certmanager.VaultPKIMount), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultPKIMount)(nil), (*v1.VaultPKIMount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultPKIMount_To_v1_VaultPKIMount(a.(*certmanager.VaultPKIMount), b.(*v1.VaultPKIMount), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -389,8 +429,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 
 func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.CertificateName = in.CertificateName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -401,8 +444,11 @@ func Convert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanag
 
 func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *v1.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.CertificateName = in.CertificateName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -445,6 +491,7 @@ func Convert_certmanager_Certificate_To_v1_Certificate(in *certmanager.Certifica
 
 func autoConvert_v1_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(in *v1.CertificateAdditionalOutputFormat, out *certmanager.CertificateAdditionalOutputFormat, s conversion.Scope) error {
 	out.Type = certmanager.CertificateOutputFormatType(in.Type)
+	out.Key = in.Key
 	return nil
 }
 
@@ -455,6 +502,7 @@ func Convert_v1_CertificateAdditionalOutputFormat_To_certmanager_CertificateAddi
 
 func autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAdditionalOutputFormat(in *certmanager.CertificateAdditionalOutputFormat, out *v1.CertificateAdditionalOutputFormat, s conversion.Scope) error {
 	out.Type = v1.CertificateOutputFormatType(in.Type)
+	out.Key = in.Key
 	return nil
 }
 
@@ -591,6 +639,7 @@ func Convert_certmanager_CertificateList_To_v1_CertificateList(in *certmanager.C
 
 func autoConvert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.Managed = (*bool)(unsafe.Pointer(in.Managed))
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -604,6 +653,7 @@ func Convert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v
 
 func autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *v1.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = v1.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.Managed = (*bool)(unsafe.Pointer(in.Managed))
 	out.Encoding = v1.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -762,6 +812,7 @@ func autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestSt
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.NextRetryTime = (*metav1.Time)(unsafe.Pointer(in.NextRetryTime))
 	return nil
 }
 
@@ -775,6 +826,7 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestSt
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.NextRetryTime = (*metav1.Time)(unsafe.Pointer(in.NextRetryTime))
 	return nil
 }
 
@@ -783,6 +835,30 @@ func Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(in *v1.CertificateSecretKeys, out *certmanager.CertificateSecretKeys, s conversion.Scope) error {
+	out.Certificate = in.Certificate
+	out.PrivateKey = in.PrivateKey
+	out.CA = in.CA
+	return nil
+}
+
+// Convert_v1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys is an autogenerated conversion function.
+func Convert_v1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(in *v1.CertificateSecretKeys, out *certmanager.CertificateSecretKeys, s conversion.Scope) error {
+	return autoConvert_v1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretKeys_To_v1_CertificateSecretKeys(in *certmanager.CertificateSecretKeys, out *v1.CertificateSecretKeys, s conversion.Scope) error {
+	out.Certificate = in.Certificate
+	out.PrivateKey = in.PrivateKey
+	out.CA = in.CA
+	return nil
+}
+
+// Convert_certmanager_CertificateSecretKeys_To_v1_CertificateSecretKeys is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretKeys_To_v1_CertificateSecretKeys(in *certmanager.CertificateSecretKeys, out *v1.CertificateSecretKeys, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretKeys_To_v1_CertificateSecretKeys(in, out, s)
+}
+
 func autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -810,12 +886,25 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.CommonName = in.CommonName
 	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.MinRemainingValidity = (*metav1.Duration)(unsafe.Pointer(in.MinRemainingValidity))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	if in.DNSNamesConfigMapRef != nil {
+		in, out := &in.DNSNamesConfigMapRef, &out.DNSNamesConfigMapRef
+		*out = new(meta.ConfigMapKeySelector)
+		if err := internalapismetav1.Convert_v1_ConfigMapKeySelector_To_meta_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSNamesConfigMapRef = nil
+	}
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URIs requires manual conversion: does not exist in peer-type
 	// WARNING: in.EmailAddresses requires manual conversion: does not exist in peer-type
+	out.SPIFFE = (*certmanager.SPIFFEID)(unsafe.Pointer(in.SPIFFE))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*certmanager.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.SecretType = certmanager.CertificateSecretType(in.SecretType)
+	out.SecretKeys = (*certmanager.CertificateSecretKeys)(unsafe.Pointer(in.SecretKeys))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(certmanager.CertificateKeystores)
@@ -825,12 +914,42 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	} else {
 		out.Keystores = nil
 	}
+	if in.AdditionalCACertificates != nil {
+		in, out := &in.AdditionalCACertificates, &out.AdditionalCACertificates
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalCACertificates = nil
+	}
 	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	if in.FallbackIssuerRefs != nil {
+		in, out := &in.FallbackIssuerRefs, &out.FallbackIssuerRefs
+		*out = make([]meta.ObjectReference, len(*in))
+		for i := range *in {
+			if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.FallbackIssuerRefs = nil
+	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsageProfile = certmanager.UsageProfile(in.UsageProfile)
 	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	if in.CSRSecretRef != nil {
+		in, out := &in.CSRSecretRef, &out.CSRSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CSRSecretRef = nil
+	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
@@ -842,12 +961,25 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.CommonName = in.CommonName
 	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.MinRemainingValidity = (*metav1.Duration)(unsafe.Pointer(in.MinRemainingValidity))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	if in.DNSNamesConfigMapRef != nil {
+		in, out := &in.DNSNamesConfigMapRef, &out.DNSNamesConfigMapRef
+		*out = new(apismetav1.ConfigMapKeySelector)
+		if err := internalapismetav1.Convert_meta_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSNamesConfigMapRef = nil
+	}
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URISANs requires manual conversion: does not exist in peer-type
 	// WARNING: in.EmailSANs requires manual conversion: does not exist in peer-type
+	out.SPIFFE = (*v1.SPIFFEID)(unsafe.Pointer(in.SPIFFE))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*v1.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.SecretType = v1.CertificateSecretType(in.SecretType)
+	out.SecretKeys = (*v1.CertificateSecretKeys)(unsafe.Pointer(in.SecretKeys))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(v1.CertificateKeystores)
@@ -857,12 +989,42 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	} else {
 		out.Keystores = nil
 	}
+	if in.AdditionalCACertificates != nil {
+		in, out := &in.AdditionalCACertificates, &out.AdditionalCACertificates
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalCACertificates = nil
+	}
 	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	if in.FallbackIssuerRefs != nil {
+		in, out := &in.FallbackIssuerRefs, &out.FallbackIssuerRefs
+		*out = make([]apismetav1.ObjectReference, len(*in))
+		for i := range *in {
+			if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.FallbackIssuerRefs = nil
+	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsageProfile = v1.UsageProfile(in.UsageProfile)
 	out.PrivateKey = (*v1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	if in.CSRSecretRef != nil {
+		in, out := &in.CSRSecretRef, &out.CSRSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CSRSecretRef = nil
+	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
@@ -875,9 +1037,23 @@ func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Ce
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTCount = (*int)(unsafe.Pointer(in.EmbeddedSCTCount))
+	out.Fingerprint = in.Fingerprint
+	out.ChainFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainFingerprints))
+	out.IssuerSubject = in.IssuerSubject
+	out.IssuerSerialNumber = in.IssuerSerialNumber
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	if in.FallbackIssuerRef != nil {
+		in, out := &in.FallbackIssuerRef, &out.FallbackIssuerRef
+		*out = new(meta.ObjectReference)
+		if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FallbackIssuerRef = nil
+	}
 	return nil
 }
 
@@ -892,9 +1068,23 @@ func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certm
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTCount = (*int)(unsafe.Pointer(in.EmbeddedSCTCount))
+	out.Fingerprint = in.Fingerprint
+	out.ChainFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainFingerprints))
+	out.IssuerSubject = in.IssuerSubject
+	out.IssuerSerialNumber = in.IssuerSerialNumber
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	if in.FallbackIssuerRef != nil {
+		in, out := &in.FallbackIssuerRef, &out.FallbackIssuerRef
+		*out = new(apismetav1.ObjectReference)
+		if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FallbackIssuerRef = nil
+	}
 	return nil
 }
 
@@ -1157,10 +1347,36 @@ func Convert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerList,
 	return autoConvert_certmanager_IssuerList_To_v1_IssuerList(in, out, s)
 }
 
+func autoConvert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *v1.IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *v1.IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *v1.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = v1.PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *v1.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(in, out, s)
+}
+
 func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.DefaultPrivateKey = (*certmanager.IssuerPrivateKeyDefaults)(unsafe.Pointer(in.DefaultPrivateKey))
+	out.TrustBundle = *(*[]byte)(unsafe.Pointer(&in.TrustBundle))
 	return nil
 }
 
@@ -1173,6 +1389,8 @@ func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerS
 	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.DefaultPrivateKey = (*v1.IssuerPrivateKeyDefaults)(unsafe.Pointer(in.DefaultPrivateKey))
+	out.TrustBundle = *(*[]byte)(unsafe.Pointer(&in.TrustBundle))
 	return nil
 }
 
@@ -1255,8 +1473,31 @@ func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKC
 	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1_SPIFFEID_To_certmanager_SPIFFEID(in *v1.SPIFFEID, out *certmanager.SPIFFEID, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_v1_SPIFFEID_To_certmanager_SPIFFEID is an autogenerated conversion function.
+func Convert_v1_SPIFFEID_To_certmanager_SPIFFEID(in *v1.SPIFFEID, out *certmanager.SPIFFEID, s conversion.Scope) error {
+	return autoConvert_v1_SPIFFEID_To_certmanager_SPIFFEID(in, out, s)
+}
+
+func autoConvert_certmanager_SPIFFEID_To_v1_SPIFFEID(in *certmanager.SPIFFEID, out *v1.SPIFFEID, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_certmanager_SPIFFEID_To_v1_SPIFFEID is an autogenerated conversion function.
+func Convert_certmanager_SPIFFEID_To_v1_SPIFFEID(in *certmanager.SPIFFEID, out *v1.SPIFFEID, s conversion.Scope) error {
+	return autoConvert_certmanager_SPIFFEID_To_v1_SPIFFEID(in, out, s)
+}
+
 func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -1267,6 +1508,7 @@ func Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSign
 
 func autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *v1.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -1381,6 +1623,7 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.Mounts = *(*[]certmanager.VaultPKIMount)(unsafe.Pointer(&in.Mounts))
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1397,6 +1640,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.Mounts = *(*[]v1.VaultPKIMount)(unsafe.Pointer(&in.Mounts))
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1435,6 +1679,28 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1_VaultPKIMount_To_certmanager_VaultPKIMount(in *v1.VaultPKIMount, out *certmanager.VaultPKIMount, s conversion.Scope) error {
+	out.Path = in.Path
+	out.DomainSuffixes = *(*[]string)(unsafe.Pointer(&in.DomainSuffixes))
+	return nil
+}
+
+// Convert_v1_VaultPKIMount_To_certmanager_VaultPKIMount is an autogenerated conversion function.
+func Convert_v1_VaultPKIMount_To_certmanager_VaultPKIMount(in *v1.VaultPKIMount, out *certmanager.VaultPKIMount, s conversion.Scope) error {
+	return autoConvert_v1_VaultPKIMount_To_certmanager_VaultPKIMount(in, out, s)
+}
+
+func autoConvert_certmanager_VaultPKIMount_To_v1_VaultPKIMount(in *certmanager.VaultPKIMount, out *v1.VaultPKIMount, s conversion.Scope) error {
+	out.Path = in.Path
+	out.DomainSuffixes = *(*[]string)(unsafe.Pointer(&in.DomainSuffixes))
+	return nil
+}
+
+// Convert_certmanager_VaultPKIMount_To_v1_VaultPKIMount is an autogenerated conversion function.
+func Convert_certmanager_VaultPKIMount_To_v1_VaultPKIMount(in *certmanager.VaultPKIMount, out *v1.VaultPKIMount, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultPKIMount_To_v1_VaultPKIMount(in, out, s)
+}
+
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
@@ -1523,6 +1789,15 @@ func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *ce
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
@@ -1537,6 +1812,15 @@ func autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
@@ -119,6 +119,17 @@ const (
 	// as namespace/name.
 	WantInjectFromSecretAnnotation = "cert-manager.io/inject-ca-from-secret"
 
+	// WantInjectWebhooksAnnotation limits the injection of the CA into a
+	// MutatingWebhookConfiguration or ValidatingWebhookConfiguration to the
+	// webhooks named in it, as a comma separated list. The CA bundles of all
+	// other webhooks in the configuration are left unchanged.
+	// Only a single CA is injected per configuration, from the source named
+	// by the inject-ca-from, inject-ca-from-secret or inject-apiserver-ca
+	// annotation; this annotation does not allow a different CA to be
+	// injected into each webhook. The CA bundles of webhooks that are not
+	// named must be managed separately.
+	WantInjectWebhooksAnnotation = "cert-manager.io/inject-ca-into-webhooks"
+
 	// AllowsInjectionFromSecretAnnotation is an annotation that must be added
 	// to Secret resource that want to denote that they can be directly
 	// injected into injectables that have a `inject-ca-from-secret` annotation.
@@ -78,6 +78,28 @@ const (
 	PKCS8 KeyEncoding = "pkcs8"
 )
 
+// UsageProfile is a named preset of key usages for a common scenario.
+// +kubebuilder:validation:Enum=ServerAuth;ClientAuth;MutualTLS;CodeSigning
+type UsageProfile string
+
+const (
+	// ServerAuthUsageProfile expands to the `digital signature`,
+	// `key encipherment` and `server auth` usages.
+	ServerAuthUsageProfile UsageProfile = "ServerAuth"
+
+	// ClientAuthUsageProfile expands to the `digital signature`,
+	// `key encipherment` and `client auth` usages.
+	ClientAuthUsageProfile UsageProfile = "ClientAuth"
+
+	// MutualTLSUsageProfile expands to the `digital signature`,
+	// `key encipherment`, `server auth` and `client auth` usages.
+	MutualTLSUsageProfile UsageProfile = "MutualTLS"
+
+	// CodeSigningUsageProfile expands to the `digital signature` and
+	// `code signing` usages.
+	CodeSigningUsageProfile UsageProfile = "CodeSigning"
+)
+
 // CertificateSpec defines the desired state of Certificate.
 // A valid Certificate requires at least one of a CommonName, DNSName, or
 // URISAN to be valid.
@@ -111,10 +133,33 @@ type CertificateSpec struct {
 	// +optional
 	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 
+	// The minimum time that must remain before the currently issued
+	// certificate's expiry for the Certificate to be Ready. A certificate
+	// issued with less remaining validity, for example by a misbehaving CA,
+	// leaves the Certificate not Ready. If unset, the certificate is Ready
+	// until it expires. It must be less than renewBefore, or 1/3 of the
+	// duration if renewBefore is unset, so that the certificate is renewed
+	// before it stops being Ready.
+	// Value must be in units accepted by Go time.ParseDuration
+	// https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	MinRemainingValidity *metav1.Duration `json:"minRemainingValidity,omitempty"`
+
 	// DNSNames is a list of DNS subjectAltNames to be set on the Certificate.
 	// +optional
 	DNSNames []string `json:"dnsNames,omitempty"`
 
+	// DNSNamesConfigMapRef is a reference to a key in a ConfigMap resource, in
+	// the same namespace as the Certificate, containing a list of additional
+	// DNS subjectAltNames to be set on the Certificate. Names may be separated
+	// by newlines or commas and are merged with `dnsNames` when the Certificate
+	// is issued. The Certificate is re-issued whenever the list changes. The
+	// key defaults to `dnsNames` if not specified.
+	// The ConfigMap must have the `cert-manager.io/dns-names: "true"` label,
+	// as only ConfigMaps with this label are watched by cert-manager.
+	// +optional
+	DNSNamesConfigMapRef *cmmeta.ConfigMapKeySelector `json:"dnsNamesConfigMapRef,omitempty"`
+
 	// IPAddresses is a list of IP address subjectAltNames to be set on the Certificate.
 	// +optional
 	IPAddresses []string `json:"ipAddresses,omitempty"`
@@ -127,6 +172,12 @@ type CertificateSpec struct {
 	// +optional
 	EmailSANs []string `json:"emailSANs,omitempty"`
 
+	// SPIFFE configures a SPIFFE ID to be derived from the namespace of the
+	// Certificate and the given service account, and set on the Certificate
+	// as a URI subjectAltName in addition to any URIs.
+	// +optional
+	SPIFFE *SPIFFEID `json:"spiffe,omitempty"`
+
 	// SecretName is the name of the secret resource that will be automatically
 	// created and managed by this Certificate resource.
 	// It will be populated with a private key and certificate, signed by the
@@ -141,11 +192,33 @@ type CertificateSpec struct {
 	// +optional
 	SecretTemplate *CertificateSecretTemplate `json:"secretTemplate,omitempty"`
 
+	// SecretType is the type of the Secret resource created for this
+	// Certificate, either `kubernetes.io/tls` or `Opaque`. Defaults to
+	// `kubernetes.io/tls`. The type of an existing Secret cannot be changed,
+	// so it must be deleted for a change of this field to take effect.
+	// +optional
+	SecretType CertificateSecretType `json:"secretType,omitempty"`
+
+	// SecretKeys configures the keys of the Secret the certificate, private
+	// key and CA certificate are stored in. Keys other than the defaults may
+	// only be used with a `secretType` of `Opaque`, since Secrets of type
+	// `kubernetes.io/tls` must store them in `tls.crt` and `tls.key`.
+	// +optional
+	SecretKeys *CertificateSecretKeys `json:"secretKeys,omitempty"`
+
 	// Keystores configures additional keystore output formats stored in the
 	// `secretName` Secret resource.
 	// +optional
 	Keystores *CertificateKeystores `json:"keystores,omitempty"`
 
+	// AdditionalCACertificates is a reference to a key in a Secret resource, in
+	// the same namespace as the Certificate, containing one or more PEM encoded
+	// CA certificates to be appended to the `ca.crt` entry of the Certificate's
+	// Secret when it is issued. Certificates already present in `ca.crt` are not
+	// appended again. The key defaults to `ca.crt` if not specified.
+	// +optional
+	AdditionalCACertificates *cmmeta.SecretKeySelector `json:"additionalCACertificates,omitempty"`
+
 	// IssuerRef is a reference to the issuer for this certificate.
 	// If the `kind` field is not set, or set to `Issuer`, an Issuer resource
 	// with the given name in the same namespace as the Certificate will be used.
@@ -154,6 +227,15 @@ type CertificateSpec struct {
 	// The `name` field in this stanza is required at all times.
 	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
 
+	// FallbackIssuerRefs is an ordered list of references to issuers to
+	// request the certificate from if the issuer referenced by `issuerRef`
+	// fails to issue it. When a CertificateRequest fails, issuance is retried
+	// straight away with the next issuer in the list. If every issuer fails,
+	// issuance is retried from `issuerRef` after the usual backoff.
+	// Each reference follows the same rules as `issuerRef`.
+	// +optional
+	FallbackIssuerRefs []cmmeta.ObjectReference `json:"fallbackIssuerRefs,omitempty"`
+
 	// IsCA will mark this Certificate as valid for certificate signing.
 	// This will automatically add the `cert sign` usage to the list of `usages`.
 	// +optional
@@ -164,6 +246,18 @@ type CertificateSpec struct {
 	// +optional
 	Usages []KeyUsage `json:"usages,omitempty"`
 
+	// UsageProfile is a named preset of usages for a common scenario, one of
+	// `ServerAuth`, `ClientAuth`, `MutualTLS` or `CodeSigning`. If set and
+	// `usages` is empty, `usages` is populated with the preset's usages at
+	// admission. Explicitly set `usages` take precedence over the preset.
+	// As the preset is only expanded while `usages` is empty, the expanded
+	// usages are stored in `usages` and the preset is ignored from then on:
+	// changing `usageProfile` later has no effect, and does not cause the
+	// certificate to be reissued, unless `usages` is cleared at the same time.
+	// A warning is returned at admission if `usages` differ from the preset.
+	// +optional
+	UsageProfile UsageProfile `json:"usageProfile,omitempty"`
+
 	// KeySize is the key bit size of the corresponding private key for this certificate.
 	// If `keyAlgorithm` is set to `rsa`, valid values are `2048`, `4096` or `8192`,
 	// and will default to `2048` if not specified.
@@ -192,6 +286,14 @@ type CertificateSpec struct {
 	// +optional
 	PrivateKey *CertificatePrivateKey `json:"privateKey,omitempty"`
 
+	// CSRSecretRef is a reference to a key in a Secret resource, in the same
+	// namespace as the Certificate, containing a PEM encoded certificate signing
+	// request to be signed by the issuer.
+	// It must be set when `privateKey.managed` is false, and may not be set
+	// otherwise. The key defaults to `tls.csr` if not specified.
+	// +optional
+	CSRSecretRef *cmmeta.SecretKeySelector `json:"csrSecretRef,omitempty"`
+
 	// EncodeUsagesInRequest controls whether key usages should be present
 	// in the CertificateRequest
 	// +optional
@@ -232,6 +334,16 @@ type CertificatePrivateKey struct {
 	// Default is 'Never' for backward compatibility.
 	// +optional
 	RotationPolicy PrivateKeyRotationPolicy `json:"rotationPolicy,omitempty"`
+
+	// Managed controls whether cert-manager generates and stores the private
+	// key for this Certificate.
+	// If set to false, no private key is generated or stored. Instead the
+	// certificate signing request referenced by `spec.csrSecretRef` is signed,
+	// and the same request is signed again whenever the Certificate is renewed.
+	// The target Secret will then only contain the signed certificate and CA.
+	// Defaults to `true`.
+	// +optional
+	Managed *bool `json:"managed,omitempty"`
 }
 
 // Denotes how private keys should be generated or sourced when a Certificate
@@ -278,6 +390,19 @@ type X509Subject struct {
 	SerialNumber string `json:"serialNumber,omitempty"`
 }
 
+// SPIFFEID configures the SPIFFE ID of a Certificate. The SPIFFE ID is
+// derived as spiffe://<trustDomain>/ns/<namespace>/sa/<serviceAccountName>,
+// where namespace is the namespace of the Certificate.
+type SPIFFEID struct {
+	// TrustDomain is the SPIFFE trust domain of the SPIFFE ID, e.g.
+	// `cluster.local`.
+	TrustDomain string `json:"trustDomain"`
+
+	// ServiceAccountName is the name of the service account, in the namespace
+	// of the Certificate, identified by the SPIFFE ID.
+	ServiceAccountName string `json:"serviceAccountName"`
+}
+
 // CertificateKeystores configures additional keystore output formats to be
 // created in the Certificate's output Secret.
 type CertificateKeystores struct {
@@ -359,6 +484,41 @@ type CertificateStatus struct {
 	// +optional
 	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 
+	// The number of Signed Certificate Timestamps (SCTs) embedded in the
+	// certificate stored in the secret named by this resource in
+	// `spec.secretName`, as found in its SCT list extension (RFC 6962).
+	// Zero if the certificate does not embed any SCTs.
+	// If not set, the certificate has not been inspected yet.
+	// +optional
+	EmbeddedSCTCount *int `json:"embeddedSCTCount,omitempty"`
+
+	// The SHA-256 fingerprint of the certificate stored in the secret named by
+	// this resource in `spec.secretName`, formatted as colon separated pairs
+	// of upper case hexadecimal digits.
+	// If not set, the certificate has not been inspected yet.
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// The SHA-256 fingerprints of the certificates following the leaf
+	// certificate in the secret named by this resource in `spec.secretName`,
+	// in the order they appear in the chain, formatted as `fingerprint`.
+	// +optional
+	ChainFingerprints []string `json:"chainFingerprints,omitempty"`
+
+	// The subject distinguished name of the CA certificate which signed the
+	// certificate stored in the secret named by this resource in
+	// `spec.secretName`, formatted as described in RFC 2253. The CA
+	// certificate is looked up in the certificate chain and the CA data of
+	// the secret. A self-signed certificate is its own issuing CA.
+	// If not set, the issuing CA certificate is not stored in the secret.
+	// +optional
+	IssuerSubject string `json:"issuerSubject,omitempty"`
+
+	// The serial number of the CA certificate reported in `issuerSubject`,
+	// formatted as colon separated pairs of upper case hexadecimal digits.
+	// +optional
+	IssuerSerialNumber string `json:"issuerSerialNumber,omitempty"`
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -392,6 +552,14 @@ type CertificateStatus struct {
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	// +optional
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// FallbackIssuerRef is the entry of `spec.fallbackIssuerRefs` that
+	// CertificateRequests for the ongoing issuance are sent to, after the
+	// issuers before it failed to issue the certificate. It is unset when
+	// requests are sent to `spec.issuerRef`, and is removed once the
+	// certificate has been issued or every issuer has failed.
+	// +optional
+	FallbackIssuerRef *cmmeta.ObjectReference `json:"fallbackIssuerRef,omitempty"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
@@ -455,8 +623,66 @@ const (
 	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// A condition added to Certificate resources when the certificate is
+	// within the configured window of its expiry and the most recent attempt
+	// to renew it failed.
+	//
+	// It will be removed by the 'readiness' controller once the certificate
+	// has been renewed.
+	CertificateConditionExpirationImminent CertificateConditionType = "ExpirationImminent"
+
+	// A condition added to Certificate resources when the NotBefore time of
+	// the issued certificate is further in the future than the configured
+	// tolerance. The Ready condition is not affected.
+	//
+	// It will be removed by the 'readiness' controller once the certificate
+	// is valid.
+	CertificateConditionNotYetValid CertificateConditionType = "NotYetValid"
+
+	// A condition added to Certificate resources when issuance has failed
+	// more consecutive times than the controller's configured maximum.
+	// Issuance will not be retried until the Certificate's spec is changed
+	// or an issuance is triggered manually, or until the current certificate
+	// is about to expire, in which case issuance is retried with back off.
+	//
+	// It will be removed by the 'issuing' controller once the certificate
+	// has been issued, or when issuance fails again after the spec changed.
+	CertificateConditionFailed CertificateConditionType = "Failed"
 )
 
+// CertificateSecretType is the type of a Certificate's Secret.
+// +kubebuilder:validation:Enum=kubernetes.io/tls;Opaque
+type CertificateSecretType string
+
+const (
+	// CertificateSecretTypeTLS stores the Certificate in a Secret of type
+	// `kubernetes.io/tls`.
+	CertificateSecretTypeTLS CertificateSecretType = "kubernetes.io/tls"
+
+	// CertificateSecretTypeOpaque stores the Certificate in a Secret of type
+	// `Opaque`.
+	CertificateSecretTypeOpaque CertificateSecretType = "Opaque"
+)
+
+// CertificateSecretKeys configures the keys of a Certificate's Secret which
+// the certificate, private key and CA certificate are stored in.
+type CertificateSecretKeys struct {
+	// Certificate is the key the signed certificate chain is stored in.
+	// Defaults to `tls.crt`.
+	// +optional
+	Certificate string `json:"certificate,omitempty"`
+
+	// PrivateKey is the key the private key is stored in. Defaults to
+	// `tls.key`.
+	// +optional
+	PrivateKey string `json:"privateKey,omitempty"`
+
+	// CA is the key the CA certificate is stored in. Defaults to `ca.crt`.
+	// +optional
+	CA string `json:"ca,omitempty"`
+}
+
 // CertificateSecretTemplate defines the default labels and annotations
 // to be copied to the Kubernetes Secret resource named in `CertificateSpec.secretName`.
 type CertificateSecretTemplate struct {
@@ -471,13 +697,17 @@ type CertificateSecretTemplate struct {
 
 // CertificateOutputFormatType specifies which additional output formats should
 // be written to the Certificate's target Secret.
-// Allowed values are `DER` or `CombinedPEM`.
+// Allowed values are `DER`, `CombinedPEM` or `AzureKeyVaultPEM`.
 // When Type is set to `DER` an additional entry `key.der` will be written to
 // the Secret, containing the binary format of the private key.
 // When Type is set to `CombinedPEM` an additional entry `tls-combined.pem`
 // will be written to the Secret, containing the PEM formatted private key and
 // signed certificate chain (tls.key + tls.crt concatenated).
-// +kubebuilder:validation:Enum=DER;CombinedPEM
+// When Type is set to `AzureKeyVaultPEM` an additional entry `tls-keyvault.pem`,
+// or the entry named by `key`, will be written to the Secret, containing the
+// signed certificate chain followed by the PKCS#8 PEM formatted private key,
+// as expected when importing a certificate into Azure Key Vault.
+// +kubebuilder:validation:Enum=DER;CombinedPEM;AzureKeyVaultPEM
 type CertificateOutputFormatType string
 
 const (
@@ -492,6 +722,13 @@ const (
 	// character, followed by the chain of signed certificate PEM documents
 	// (`<private key> + \n + <signed certificate chain>`).
 	CertificateOutputFormatCombinedPEM CertificateOutputFormatType = "CombinedPEM"
+
+	// CertificateOutputFormatAzureKeyVaultPEM writes the Certificate's signed
+	// certificate chain followed by its private key, PKCS#8 encoded, in PEM
+	// format to the `tls-keyvault.pem` target Secret Data key, or the key set
+	// on the output format. This is the order and encoding Azure Key Vault
+	// expects when importing a PEM certificate.
+	CertificateOutputFormatAzureKeyVaultPEM CertificateOutputFormatType = "AzureKeyVaultPEM"
 )
 
 // CertificateAdditionalOutputFormat defines an additional output format of a
@@ -501,4 +738,10 @@ type CertificateAdditionalOutputFormat struct {
 	// Type is the name of the format type that should be written to the
 	// Certificate's target Secret.
 	Type CertificateOutputFormatType `json:"type"`
+
+	// Key is the name of the Secret Data entry the format is written to. It
+	// may only be set for the `AzureKeyVaultPEM` type, for which it defaults
+	// to `tls-keyvault.pem`.
+	// +optional
+	Key string `json:"key,omitempty"`
 }
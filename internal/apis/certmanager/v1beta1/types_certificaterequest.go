@@ -38,6 +38,16 @@ const (
 	// CertificateRequest has been denied, and the CertificateRequest will never
 	// be issued.
 	CertificateRequestReasonDenied = "Denied"
+
+	// RateLimited is a Ready condition reason that indicates that the issuer
+	// rejected the CertificateRequest because too many requests have been
+	// made. The CertificateRequest will be retried later.
+	CertificateRequestReasonRateLimited = "RateLimited"
+
+	// AuthFailed is a Ready condition reason that indicates that the issuer
+	// could not authenticate with, or was not authorized by, the signing
+	// service. The CertificateRequest will be retried.
+	CertificateRequestReasonAuthFailed = "AuthFailed"
 )
 
 // +genclient
@@ -154,6 +164,12 @@ type CertificateRequestStatus struct {
 	// used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// NextRetryTime is the earliest time at which the controller will retry
+	// signing this CertificateRequest after a failed attempt. It is unset once
+	// the request has been signed successfully.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
 }
 
 // CertificateRequestCondition contains condition information for a CertificateRequest.
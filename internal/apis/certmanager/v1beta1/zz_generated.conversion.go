@@ -162,6 +162,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateSecretKeys)(nil), (*certmanager.CertificateSecretKeys)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(a.(*CertificateSecretKeys), b.(*certmanager.CertificateSecretKeys), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretKeys)(nil), (*CertificateSecretKeys)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretKeys_To_v1beta1_CertificateSecretKeys(a.(*certmanager.CertificateSecretKeys), b.(*CertificateSecretKeys), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -252,6 +262,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerPrivateKeyDefaults)(nil), (*certmanager.IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(a.(*IssuerPrivateKeyDefaults), b.(*certmanager.IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerPrivateKeyDefaults)(nil), (*IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(a.(*certmanager.IssuerPrivateKeyDefaults), b.(*IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(a.(*IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -292,6 +312,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SPIFFEID)(nil), (*certmanager.SPIFFEID)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SPIFFEID_To_certmanager_SPIFFEID(a.(*SPIFFEID), b.(*certmanager.SPIFFEID), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SPIFFEID)(nil), (*SPIFFEID)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SPIFFEID_To_v1beta1_SPIFFEID(a.(*certmanager.SPIFFEID), b.(*SPIFFEID), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -342,6 +372,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VaultPKIMount)(nil), (*certmanager.VaultPKIMount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VaultPKIMount_To_certmanager_VaultPKIMount(a.(*VaultPKIMount), b.(*certmanager.VaultPKIMount), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultPKIMount)(nil), (*VaultPKIMount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultPKIMount_To_v1beta1_VaultPKIMount(a.(*certmanager.VaultPKIMount), b.(*VaultPKIMount), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(a.(*VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -387,8 +427,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 
 func autoConvert_v1beta1_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.CertificateName = in.CertificateName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -399,8 +442,11 @@ func Convert_v1beta1_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certman
 
 func autoConvert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in *certmanager.CAIssuer, out *CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	out.CertificateName = in.CertificateName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -443,6 +489,7 @@ func Convert_certmanager_Certificate_To_v1beta1_Certificate(in *certmanager.Cert
 
 func autoConvert_v1beta1_CertificateAdditionalOutputFormat_To_certmanager_CertificateAdditionalOutputFormat(in *CertificateAdditionalOutputFormat, out *certmanager.CertificateAdditionalOutputFormat, s conversion.Scope) error {
 	out.Type = certmanager.CertificateOutputFormatType(in.Type)
+	out.Key = in.Key
 	return nil
 }
 
@@ -453,6 +500,7 @@ func Convert_v1beta1_CertificateAdditionalOutputFormat_To_certmanager_Certificat
 
 func autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1beta1_CertificateAdditionalOutputFormat(in *certmanager.CertificateAdditionalOutputFormat, out *CertificateAdditionalOutputFormat, s conversion.Scope) error {
 	out.Type = CertificateOutputFormatType(in.Type)
+	out.Key = in.Key
 	return nil
 }
 
@@ -589,6 +637,7 @@ func Convert_certmanager_CertificateList_To_v1beta1_CertificateList(in *certmana
 
 func autoConvert_v1beta1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.Managed = (*bool)(unsafe.Pointer(in.Managed))
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -602,6 +651,7 @@ func Convert_v1beta1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(
 
 func autoConvert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(in *certmanager.CertificatePrivateKey, out *CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.Managed = (*bool)(unsafe.Pointer(in.Managed))
 	out.Encoding = PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
@@ -760,6 +810,7 @@ func autoConvert_v1beta1_CertificateRequestStatus_To_certmanager_CertificateRequ
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.NextRetryTime = (*v1.Time)(unsafe.Pointer(in.NextRetryTime))
 	return nil
 }
 
@@ -773,6 +824,7 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1beta1_CertificateRequ
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.NextRetryTime = (*v1.Time)(unsafe.Pointer(in.NextRetryTime))
 	return nil
 }
 
@@ -781,6 +833,30 @@ func Convert_certmanager_CertificateRequestStatus_To_v1beta1_CertificateRequestS
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1beta1_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(in *CertificateSecretKeys, out *certmanager.CertificateSecretKeys, s conversion.Scope) error {
+	out.Certificate = in.Certificate
+	out.PrivateKey = in.PrivateKey
+	out.CA = in.CA
+	return nil
+}
+
+// Convert_v1beta1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys is an autogenerated conversion function.
+func Convert_v1beta1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(in *CertificateSecretKeys, out *certmanager.CertificateSecretKeys, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateSecretKeys_To_certmanager_CertificateSecretKeys(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretKeys_To_v1beta1_CertificateSecretKeys(in *certmanager.CertificateSecretKeys, out *CertificateSecretKeys, s conversion.Scope) error {
+	out.Certificate = in.Certificate
+	out.PrivateKey = in.PrivateKey
+	out.CA = in.CA
+	return nil
+}
+
+// Convert_certmanager_CertificateSecretKeys_To_v1beta1_CertificateSecretKeys is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretKeys_To_v1beta1_CertificateSecretKeys(in *certmanager.CertificateSecretKeys, out *CertificateSecretKeys, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretKeys_To_v1beta1_CertificateSecretKeys(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -808,12 +884,25 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *Cert
 	out.CommonName = in.CommonName
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.MinRemainingValidity = (*v1.Duration)(unsafe.Pointer(in.MinRemainingValidity))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	if in.DNSNamesConfigMapRef != nil {
+		in, out := &in.DNSNamesConfigMapRef, &out.DNSNamesConfigMapRef
+		*out = new(meta.ConfigMapKeySelector)
+		if err := apismetav1.Convert_v1_ConfigMapKeySelector_To_meta_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSNamesConfigMapRef = nil
+	}
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
+	out.SPIFFE = (*certmanager.SPIFFEID)(unsafe.Pointer(in.SPIFFE))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*certmanager.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.SecretType = certmanager.CertificateSecretType(in.SecretType)
+	out.SecretKeys = (*certmanager.CertificateSecretKeys)(unsafe.Pointer(in.SecretKeys))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(certmanager.CertificateKeystores)
@@ -823,12 +912,42 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *Cert
 	} else {
 		out.Keystores = nil
 	}
+	if in.AdditionalCACertificates != nil {
+		in, out := &in.AdditionalCACertificates, &out.AdditionalCACertificates
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalCACertificates = nil
+	}
 	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	if in.FallbackIssuerRefs != nil {
+		in, out := &in.FallbackIssuerRefs, &out.FallbackIssuerRefs
+		*out = make([]meta.ObjectReference, len(*in))
+		for i := range *in {
+			if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.FallbackIssuerRefs = nil
+	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsageProfile = certmanager.UsageProfile(in.UsageProfile)
 	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	if in.CSRSecretRef != nil {
+		in, out := &in.CSRSecretRef, &out.CSRSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CSRSecretRef = nil
+	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
@@ -845,12 +964,25 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	out.CommonName = in.CommonName
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
 	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.MinRemainingValidity = (*v1.Duration)(unsafe.Pointer(in.MinRemainingValidity))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	if in.DNSNamesConfigMapRef != nil {
+		in, out := &in.DNSNamesConfigMapRef, &out.DNSNamesConfigMapRef
+		*out = new(metav1.ConfigMapKeySelector)
+		if err := apismetav1.Convert_meta_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSNamesConfigMapRef = nil
+	}
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
+	out.SPIFFE = (*SPIFFEID)(unsafe.Pointer(in.SPIFFE))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.SecretType = CertificateSecretType(in.SecretType)
+	out.SecretKeys = (*CertificateSecretKeys)(unsafe.Pointer(in.SecretKeys))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(CertificateKeystores)
@@ -860,12 +992,42 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	} else {
 		out.Keystores = nil
 	}
+	if in.AdditionalCACertificates != nil {
+		in, out := &in.AdditionalCACertificates, &out.AdditionalCACertificates
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalCACertificates = nil
+	}
 	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	if in.FallbackIssuerRefs != nil {
+		in, out := &in.FallbackIssuerRefs, &out.FallbackIssuerRefs
+		*out = make([]metav1.ObjectReference, len(*in))
+		for i := range *in {
+			if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.FallbackIssuerRefs = nil
+	}
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
+	out.UsageProfile = UsageProfile(in.UsageProfile)
 	out.PrivateKey = (*CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	if in.CSRSecretRef != nil {
+		in, out := &in.CSRSecretRef, &out.CSRSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CSRSecretRef = nil
+	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
@@ -883,9 +1045,23 @@ func autoConvert_v1beta1_CertificateStatus_To_certmanager_CertificateStatus(in *
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTCount = (*int)(unsafe.Pointer(in.EmbeddedSCTCount))
+	out.Fingerprint = in.Fingerprint
+	out.ChainFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainFingerprints))
+	out.IssuerSubject = in.IssuerSubject
+	out.IssuerSerialNumber = in.IssuerSerialNumber
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	if in.FallbackIssuerRef != nil {
+		in, out := &in.FallbackIssuerRef, &out.FallbackIssuerRef
+		*out = new(meta.ObjectReference)
+		if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FallbackIssuerRef = nil
+	}
 	return nil
 }
 
@@ -900,9 +1076,23 @@ func autoConvert_certmanager_CertificateStatus_To_v1beta1_CertificateStatus(in *
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTCount = (*int)(unsafe.Pointer(in.EmbeddedSCTCount))
+	out.Fingerprint = in.Fingerprint
+	out.ChainFingerprints = *(*[]string)(unsafe.Pointer(&in.ChainFingerprints))
+	out.IssuerSubject = in.IssuerSubject
+	out.IssuerSerialNumber = in.IssuerSerialNumber
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	if in.FallbackIssuerRef != nil {
+		in, out := &in.FallbackIssuerRef, &out.FallbackIssuerRef
+		*out = new(metav1.ObjectReference)
+		if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FallbackIssuerRef = nil
+	}
 	return nil
 }
 
@@ -1165,10 +1355,36 @@ func Convert_certmanager_IssuerList_To_v1beta1_IssuerList(in *certmanager.Issuer
 	return autoConvert_certmanager_IssuerList_To_v1beta1_IssuerList(in, out, s)
 }
 
+func autoConvert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(in, out, s)
+}
+
 func autoConvert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.DefaultPrivateKey = (*certmanager.IssuerPrivateKeyDefaults)(unsafe.Pointer(in.DefaultPrivateKey))
+	out.TrustBundle = *(*[]byte)(unsafe.Pointer(&in.TrustBundle))
 	return nil
 }
 
@@ -1181,6 +1397,8 @@ func autoConvert_certmanager_IssuerSpec_To_v1beta1_IssuerSpec(in *certmanager.Is
 	if err := Convert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.DefaultPrivateKey = (*IssuerPrivateKeyDefaults)(unsafe.Pointer(in.DefaultPrivateKey))
+	out.TrustBundle = *(*[]byte)(unsafe.Pointer(&in.TrustBundle))
 	return nil
 }
 
@@ -1263,8 +1481,31 @@ func Convert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in *certmanage
 	return autoConvert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1beta1_SPIFFEID_To_certmanager_SPIFFEID(in *SPIFFEID, out *certmanager.SPIFFEID, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_v1beta1_SPIFFEID_To_certmanager_SPIFFEID is an autogenerated conversion function.
+func Convert_v1beta1_SPIFFEID_To_certmanager_SPIFFEID(in *SPIFFEID, out *certmanager.SPIFFEID, s conversion.Scope) error {
+	return autoConvert_v1beta1_SPIFFEID_To_certmanager_SPIFFEID(in, out, s)
+}
+
+func autoConvert_certmanager_SPIFFEID_To_v1beta1_SPIFFEID(in *certmanager.SPIFFEID, out *SPIFFEID, s conversion.Scope) error {
+	out.TrustDomain = in.TrustDomain
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_certmanager_SPIFFEID_To_v1beta1_SPIFFEID is an autogenerated conversion function.
+func Convert_certmanager_SPIFFEID_To_v1beta1_SPIFFEID(in *certmanager.SPIFFEID, out *SPIFFEID, s conversion.Scope) error {
+	return autoConvert_certmanager_SPIFFEID_To_v1beta1_SPIFFEID(in, out, s)
+}
+
 func autoConvert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -1275,6 +1516,7 @@ func Convert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfSi
 
 func autoConvert_certmanager_SelfSignedIssuer_To_v1beta1_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.PathLen = (*int)(unsafe.Pointer(in.PathLen))
 	return nil
 }
 
@@ -1389,6 +1631,7 @@ func autoConvert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer,
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.Mounts = *(*[]certmanager.VaultPKIMount)(unsafe.Pointer(&in.Mounts))
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1405,6 +1648,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.Mounts = *(*[]VaultPKIMount)(unsafe.Pointer(&in.Mounts))
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1443,6 +1687,28 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in *
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1beta1_VaultPKIMount_To_certmanager_VaultPKIMount(in *VaultPKIMount, out *certmanager.VaultPKIMount, s conversion.Scope) error {
+	out.Path = in.Path
+	out.DomainSuffixes = *(*[]string)(unsafe.Pointer(&in.DomainSuffixes))
+	return nil
+}
+
+// Convert_v1beta1_VaultPKIMount_To_certmanager_VaultPKIMount is an autogenerated conversion function.
+func Convert_v1beta1_VaultPKIMount_To_certmanager_VaultPKIMount(in *VaultPKIMount, out *certmanager.VaultPKIMount, s conversion.Scope) error {
+	return autoConvert_v1beta1_VaultPKIMount_To_certmanager_VaultPKIMount(in, out, s)
+}
+
+func autoConvert_certmanager_VaultPKIMount_To_v1beta1_VaultPKIMount(in *certmanager.VaultPKIMount, out *VaultPKIMount, s conversion.Scope) error {
+	out.Path = in.Path
+	out.DomainSuffixes = *(*[]string)(unsafe.Pointer(&in.DomainSuffixes))
+	return nil
+}
+
+// Convert_certmanager_VaultPKIMount_To_v1beta1_VaultPKIMount is an autogenerated conversion function.
+func Convert_certmanager_VaultPKIMount_To_v1beta1_VaultPKIMount(in *certmanager.VaultPKIMount, out *VaultPKIMount, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultPKIMount_To_v1beta1_VaultPKIMount(in, out, s)
+}
+
 func autoConvert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
@@ -1531,6 +1797,15 @@ func autoConvert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
@@ -1545,6 +1820,15 @@ func autoConvert_certmanager_VenafiTPP_To_v1beta1_VenafiTPP(in *certmanager.Vena
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
@@ -20,13 +20,18 @@ import (
 	"fmt"
 	"net"
 	"net/mail"
+	"net/url"
+	"reflect"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
@@ -35,6 +40,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // Validation functions for cert-manager Certificate types
@@ -45,10 +51,11 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, field.Required(fldPath.Child("secretName"), "must be specified"))
 	}
 
-	el = append(el, validateIssuerRef(crt.IssuerRef, fldPath)...)
+	el = append(el, ValidateIssuerRef(crt.IssuerRef, fldPath.Child("issuerRef"))...)
+	el = append(el, validateFallbackIssuerRefs(crt, fldPath.Child("fallbackIssuerRefs"))...)
 
-	if len(crt.CommonName) == 0 && len(crt.DNSNames) == 0 && len(crt.URISANs) == 0 && len(crt.EmailSANs) == 0 && len(crt.IPAddresses) == 0 {
-		el = append(el, field.Invalid(fldPath, "", "at least one of commonName, dnsNames, uris ipAddresses, or emailAddresses must be set"))
+	if len(crt.CommonName) == 0 && len(crt.DNSNames) == 0 && len(crt.URISANs) == 0 && len(crt.EmailSANs) == 0 && len(crt.IPAddresses) == 0 && crt.SPIFFE == nil && crt.DNSNamesConfigMapRef == nil {
+		el = append(el, field.Invalid(fldPath, "", "at least one of commonName, dnsNames, dnsNamesConfigMapRef, uris ipAddresses, emailAddresses or spiffe must be set"))
 	}
 
 	// if a common name has been specified, ensure it is no longer than 64 chars
@@ -56,10 +63,18 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, field.TooLong(fldPath.Child("commonName"), crt.CommonName, 64))
 	}
 
+	if crt.Subject != nil {
+		el = append(el, validateSubject(crt.Subject, fldPath.Child("subject"))...)
+	}
+
 	if len(crt.IPAddresses) > 0 {
 		el = append(el, validateIPAddresses(crt, fldPath)...)
 	}
 
+	if crt.SPIFFE != nil {
+		el = append(el, validateSPIFFE(crt.SPIFFE, fldPath.Child("spiffe"))...)
+	}
+
 	if len(crt.EmailSANs) > 0 {
 		el = append(el, validateEmailAddresses(crt, fldPath)...)
 	}
@@ -81,12 +96,21 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		}
 	}
 
-	if crt.Duration != nil || crt.RenewBefore != nil {
+	if crt.Duration != nil || crt.RenewBefore != nil || crt.MinRemainingValidity != nil {
 		el = append(el, ValidateDuration(crt, fldPath)...)
 	}
 	if len(crt.Usages) > 0 {
 		el = append(el, validateUsages(crt, fldPath)...)
 	}
+	switch crt.UsageProfile {
+	case "", internalcmapi.ServerAuthUsageProfile, internalcmapi.ClientAuthUsageProfile,
+		internalcmapi.MutualTLSUsageProfile, internalcmapi.CodeSigningUsageProfile:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("usageProfile"), crt.UsageProfile, []string{
+			string(internalcmapi.ServerAuthUsageProfile), string(internalcmapi.ClientAuthUsageProfile),
+			string(internalcmapi.MutualTLSUsageProfile), string(internalcmapi.CodeSigningUsageProfile),
+		}))
+	}
 	if crt.RevisionHistoryLimit != nil && *crt.RevisionHistoryLimit < 1 {
 		el = append(el, field.Invalid(fldPath.Child("revisionHistoryLimit"), *crt.RevisionHistoryLimit, "must not be less than 1"))
 	}
@@ -100,7 +124,49 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		}
 	}
 
+	if crt.DNSNamesConfigMapRef != nil && len(crt.DNSNamesConfigMapRef.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("dnsNamesConfigMapRef", "name"), "configmap name is required"))
+	}
+
+	if crt.AdditionalCACertificates != nil && len(crt.AdditionalCACertificates.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("additionalCACertificates", "name"), "secret name is required"))
+	}
+
+	el = append(el, validateSecretTypeAndKeys(crt, fldPath)...)
 	el = append(el, validateAdditionalOutputFormats(crt, fldPath)...)
+	el = append(el, validateUnmanagedPrivateKey(crt, fldPath)...)
+
+	return el
+}
+
+// validateUnmanagedPrivateKey validates the fields used when cert-manager does
+// not manage the private key, and so instead signs a CSR supplied by the user.
+func validateUnmanagedPrivateKey(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	unmanaged := crt.PrivateKey != nil && crt.PrivateKey.Managed != nil && !*crt.PrivateKey.Managed
+	if !unmanaged {
+		if crt.CSRSecretRef != nil {
+			el = append(el, field.Forbidden(fldPath.Child("csrSecretRef"), "may only be set when privateKey.managed is false"))
+		}
+		return el
+	}
+
+	if crt.CSRSecretRef == nil {
+		el = append(el, field.Required(fldPath.Child("csrSecretRef"), "must be specified when privateKey.managed is false"))
+	} else if len(crt.CSRSecretRef.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("csrSecretRef", "name"), "secret name is required"))
+	}
+
+	if crt.DNSNamesConfigMapRef != nil {
+		el = append(el, field.Forbidden(fldPath.Child("dnsNamesConfigMapRef"), "additional DNS names cannot be requested when privateKey.managed is false"))
+	}
+	if crt.Keystores != nil {
+		el = append(el, field.Forbidden(fldPath.Child("keystores"), "keystores cannot be written when privateKey.managed is false"))
+	}
+	if len(crt.AdditionalOutputFormats) > 0 {
+		el = append(el, field.Forbidden(fldPath.Child("additionalOutputFormats"), "additional output formats cannot be written when privateKey.managed is false"))
+	}
 
 	return el
 }
@@ -108,33 +174,186 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 func ValidateCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	crt := obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
-	return allErrs, nil
+	allErrs = append(allErrs, validateExternalIssuerRefKinds(crt.Annotations, &crt.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateURIs(&crt.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateRenewAtAnnotation(crt.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateBooleanAnnotation(crt.Annotations, internalcmapi.SecretOwnerReferenceAnnotation, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateBooleanAnnotation(crt.Annotations, internalcmapi.AdoptSecretAnnotation, field.NewPath("metadata", "annotations"))...)
+	return allErrs, append(renewBeforeWarnings(&crt.Spec, field.NewPath("spec")), usageProfileWarnings(&crt.Spec, field.NewPath("spec"))...)
 }
 
 func ValidateUpdateCertificate(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
-	crt := obj.(*internalcmapi.Certificate)
+	oldCrt, crt := oldObj.(*internalcmapi.Certificate), obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
-	return allErrs, nil
+	// Only check the issuer kinds when the issuer references change, so that
+	// Certificates created before this check existed can still be updated,
+	// including by cert-manager writing their status.
+	if issuerRefsChanged(&oldCrt.Spec, &crt.Spec) {
+		allErrs = append(allErrs, validateExternalIssuerRefKinds(crt.Annotations, &crt.Spec, field.NewPath("spec"))...)
+	}
+	// Likewise, only check the URIs when they change.
+	if !reflect.DeepEqual(oldCrt.Spec.URISANs, crt.Spec.URISANs) {
+		allErrs = append(allErrs, validateURIs(&crt.Spec, field.NewPath("spec"))...)
+	}
+	allErrs = append(allErrs, validateRenewAtAnnotation(crt.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateBooleanAnnotation(crt.Annotations, internalcmapi.SecretOwnerReferenceAnnotation, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateBooleanAnnotation(crt.Annotations, internalcmapi.AdoptSecretAnnotation, field.NewPath("metadata", "annotations"))...)
+	return allErrs, append(renewBeforeWarnings(&crt.Spec, field.NewPath("spec")), usageProfileWarnings(&crt.Spec, field.NewPath("spec"))...)
 }
 
-func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) field.ErrorList {
+// ValidateIssuerRef validates a reference to an issuer. The kind is only
+// validated for references to cert-manager's own issuers, since external
+// issuers may use any kind within their API group.
+func ValidateIssuerRef(issuerRef cmmeta.ObjectReference, issuerRefPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
-	issuerRefPath := fldPath.Child("issuerRef")
 	if issuerRef.Name == "" {
 		el = append(el, field.Required(issuerRefPath.Child("name"), "must be specified"))
 	}
 	if issuerRef.Group == "" || issuerRef.Group == internalcmapi.SchemeGroupVersion.Group {
 		switch issuerRef.Kind {
 		case "":
-		case "Issuer", "ClusterIssuer":
+		case internalcmapi.IssuerKind, internalcmapi.ClusterIssuerKind:
 		default:
-			el = append(el, field.Invalid(issuerRefPath.Child("kind"), issuerRef.Kind, "must be one of Issuer or ClusterIssuer"))
+			el = append(el, field.Invalid(issuerRefPath.Child("kind"), issuerRef.Kind,
+				fmt.Sprintf("must be one of Issuer or ClusterIssuer, or issuerRef.group must be set to the API group of the external issuer providing the %s kind", issuerRef.Kind)))
+		}
+		return el
+	}
+
+	for _, msg := range utilvalidation.IsDNS1123Subdomain(issuerRef.Group) {
+		el = append(el, field.Invalid(issuerRefPath.Child("group"), issuerRef.Group, msg))
+	}
+	return el
+}
+
+// validateFallbackIssuerRefs validates each of the Certificate's fallback
+// issuers in the same way as its issuerRef. An issuer may only be listed once
+// across issuerRef and fallbackIssuerRefs, as failing over to the same issuer
+// again would not help.
+func validateFallbackIssuerRefs(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	seen := []cmmeta.ObjectReference{defaultedIssuerRef(crt.IssuerRef)}
+	for i, ref := range crt.FallbackIssuerRefs {
+		el = append(el, ValidateIssuerRef(ref, fldPath.Index(i))...)
+
+		ref = defaultedIssuerRef(ref)
+		for _, s := range seen {
+			if s == ref {
+				el = append(el, field.Duplicate(fldPath.Index(i), crt.FallbackIssuerRefs[i]))
+				break
+			}
+		}
+		seen = append(seen, ref)
+	}
+
+	return el
+}
+
+// defaultedIssuerRef returns the issuer reference with an empty kind or group
+// set to its default value.
+func defaultedIssuerRef(ref cmmeta.ObjectReference) cmmeta.ObjectReference {
+	if ref.Kind == "" {
+		ref.Kind = internalcmapi.IssuerKind
+	}
+	if ref.Group == "" {
+		ref.Group = internalcmapi.SchemeGroupVersion.Group
+	}
+	return ref
+}
+
+// validateExternalIssuerRefKinds applies validateExternalIssuerRefKind to the
+// Certificate's issuerRef and to each of its fallbackIssuerRefs.
+func validateExternalIssuerRefKinds(annotations map[string]string, crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := validateExternalIssuerRefKind(annotations, crt.IssuerRef, fldPath.Child("issuerRef"))
+	for i, ref := range crt.FallbackIssuerRefs {
+		el = append(el, validateExternalIssuerRefKind(annotations, ref, fldPath.Child("fallbackIssuerRefs").Index(i))...)
+	}
+	return el
+}
+
+// issuerRefsChanged returns true if the issuerRef or any of the
+// fallbackIssuerRefs differ between the two Certificate specs.
+func issuerRefsChanged(oldCrt, crt *internalcmapi.CertificateSpec) bool {
+	return oldCrt.IssuerRef != crt.IssuerRef || !reflect.DeepEqual(oldCrt.FallbackIssuerRefs, crt.FallbackIssuerRefs)
+}
+
+// validateExternalIssuerRefKind rejects issuerRefs which combine one of
+// cert-manager's own issuer kinds with the group of an external issuer. This
+// is almost always a typo in the group, which otherwise results in the
+// request never being picked up by any issuer. External issuers which define
+// their own Issuer or ClusterIssuer kinds can opt out using the
+// AllowExternalIssuerKindAnnotation.
+func validateExternalIssuerRefKind(annotations map[string]string, issuerRef cmmeta.ObjectReference, issuerRefPath *field.Path) field.ErrorList {
+	if issuerRef.Group == "" || issuerRef.Group == internalcmapi.SchemeGroupVersion.Group {
+		return nil
+	}
+	if issuerRef.Kind != internalcmapi.IssuerKind && issuerRef.Kind != internalcmapi.ClusterIssuerKind {
+		return nil
+	}
+	if annotations[internalcmapi.AllowExternalIssuerKindAnnotation] == "true" {
+		return nil
+	}
+	return field.ErrorList{
+		field.Invalid(issuerRefPath.Child("group"), issuerRef.Group,
+			fmt.Sprintf("kind %s is a cert-manager kind and must be used with group %s or no group; if %s is an external issuer which defines its own %s kind, set the %q annotation to \"true\"",
+				issuerRef.Kind, internalcmapi.SchemeGroupVersion.Group, issuerRef.Group, issuerRef.Kind, internalcmapi.AllowExternalIssuerKindAnnotation)),
+	}
+}
+
+// validateRenewAtAnnotation ensures that the RenewAtAnnotation, if set, is a
+// valid RFC3339 timestamp.
+func validateRenewAtAnnotation(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	renewAt, ok := annotations[internalcmapi.RenewAtAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, renewAt); err != nil {
+		return field.ErrorList{
+			field.Invalid(fldPath.Key(internalcmapi.RenewAtAnnotation), renewAt, "must be a timestamp in RFC3339 format"),
 		}
 	}
+	return nil
+}
+
+// validateBooleanAnnotation ensures that the annotation with the given key,
+// if set, is either "true" or "false".
+func validateBooleanAnnotation(annotations map[string]string, key string, fldPath *field.Path) field.ErrorList {
+	value, ok := annotations[key]
+	if !ok || value == "true" || value == "false" {
+		return nil
+	}
+	return field.ErrorList{
+		field.NotSupported(fldPath.Key(key), value, []string{"true", "false"}),
+	}
+}
+
+func validateSubject(subject *internalcmapi.X509Subject, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(subject.SerialNumber) > 0 && !isPrintableString(subject.SerialNumber) {
+		el = append(el, field.Invalid(fldPath.Child("serialNumber"), subject.SerialNumber, "must only contain characters allowed in an ASN.1 PrintableString"))
+	}
 	return el
 }
 
+// isPrintableString returns true if s only contains characters allowed in an
+// ASN.1 PrintableString, as required for the serialNumber attribute by RFC
+// 5280 appendix A.1.
+func isPrintableString(s string) bool {
+	for _, r := range s {
+		switch {
+		case 'a' <= r && r <= 'z',
+			'A' <= r && r <= 'Z',
+			'0' <= r && r <= '9':
+		case strings.ContainsRune(" '()+,-./:=?", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.IPAddresses) <= 0 {
 		return nil
@@ -149,6 +368,40 @@ func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path)
 	return el
 }
 
+func validateURIs(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, d := range a.URISANs {
+		u, err := url.Parse(d)
+		switch {
+		case err != nil:
+			el = append(el, field.Invalid(fldPath.Child("uris").Index(i), d, fmt.Sprintf("invalid URI: %s", err)))
+		case !u.IsAbs():
+			el = append(el, field.Invalid(fldPath.Child("uris").Index(i), d, "invalid URI: must be an absolute URI including a scheme"))
+		case len(u.Host) == 0 && len(u.Opaque) == 0 && len(u.Path) == 0:
+			el = append(el, field.Invalid(fldPath.Child("uris").Index(i), d, "invalid URI: must not be empty after the scheme"))
+		case u.Scheme == pki.SPIFFEScheme:
+			if err := pki.ValidateSPIFFEID(u); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("uris").Index(i), d, fmt.Sprintf("invalid SPIFFE ID: %s", err)))
+			}
+		}
+	}
+	return el
+}
+
+func validateSPIFFE(spiffe *internalcmapi.SPIFFEID, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if err := pki.ValidateSPIFFETrustDomain(spiffe.TrustDomain); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("trustDomain"), spiffe.TrustDomain, err.Error()))
+	}
+	if len(spiffe.ServiceAccountName) == 0 {
+		el = append(el, field.Required(fldPath.Child("serviceAccountName"), "must be specified"))
+	}
+	for _, msg := range utilvalidation.IsDNS1123Subdomain(spiffe.ServiceAccountName) {
+		el = append(el, field.Invalid(fldPath.Child("serviceAccountName"), spiffe.ServiceAccountName, msg))
+	}
+	return el
+}
+
 func validateEmailAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.EmailSANs) <= 0 {
 		return nil
@@ -212,9 +465,68 @@ func ValidateDuration(crt *internalcmapi.CertificateSpec, fldPath *field.Path) f
 	if crt.RenewBefore != nil && crt.RenewBefore.Duration >= duration {
 		el = append(el, field.Invalid(fldPath.Child("renewBefore"), crt.RenewBefore.Duration, fmt.Sprintf("certificate duration %s must be greater than renewBefore %s", duration, crt.RenewBefore.Duration)))
 	}
+	// If spec.minRemainingValidity is set, it must be positive and less than
+	// the duration, otherwise the Certificate could never be ready. It must
+	// also be less than the effective renewBefore, otherwise the Certificate
+	// would stop being Ready before it is renewed.
+	if crt.MinRemainingValidity != nil {
+		renewBefore := duration / 3
+		if crt.RenewBefore != nil && crt.RenewBefore.Duration < duration {
+			renewBefore = crt.RenewBefore.Duration
+		}
+		if crt.MinRemainingValidity.Duration <= 0 {
+			el = append(el, field.Invalid(fldPath.Child("minRemainingValidity"), crt.MinRemainingValidity.Duration, "certificate minRemainingValidity must be greater than 0"))
+		} else if crt.MinRemainingValidity.Duration >= duration {
+			el = append(el, field.Invalid(fldPath.Child("minRemainingValidity"), crt.MinRemainingValidity.Duration, fmt.Sprintf("certificate duration %s must be greater than minRemainingValidity %s", duration, crt.MinRemainingValidity.Duration)))
+		} else if crt.MinRemainingValidity.Duration >= renewBefore {
+			el = append(el, field.Invalid(fldPath.Child("minRemainingValidity"), crt.MinRemainingValidity.Duration, fmt.Sprintf("certificate renewBefore %s must be greater than minRemainingValidity %s", renewBefore, crt.MinRemainingValidity.Duration)))
+		}
+	}
 	return el
 }
 
+// renewBeforeWarnings returns a warning if the certificate would be renewed
+// within the first tenth of its lifetime, as this causes it to be reissued
+// almost immediately after every issuance. A renewBefore that is not less
+// than the duration is rejected by ValidateDuration instead.
+func renewBeforeWarnings(crt *internalcmapi.CertificateSpec, fldPath *field.Path) []string {
+	if crt.RenewBefore == nil {
+		return nil
+	}
+	duration := util.DefaultCertDuration(crt.Duration)
+	renewBefore := crt.RenewBefore.Duration
+	if renewBefore >= duration || duration-renewBefore >= duration/10 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s %s is close to the certificate duration %s: the certificate will be renewed %s after it is issued, which may cause it to be reissued continuously",
+		fldPath.Child("renewBefore"), renewBefore, duration, duration-renewBefore)}
+}
+
+// usageProfileWarnings warns that the usage profile is ignored if the usages
+// are set to anything other than the usages of the profile, as the profile is
+// only expanded into usages while they are empty.
+func usageProfileWarnings(crt *internalcmapi.CertificateSpec, fldPath *field.Path) []string {
+	if crt.UsageProfile == "" || len(crt.Usages) == 0 {
+		return nil
+	}
+	profileUsages, ok := util.UsagesForProfile(cmapi.UsageProfile(crt.UsageProfile))
+	if !ok {
+		return nil
+	}
+	want, got := sets.NewString(), sets.NewString()
+	for _, u := range profileUsages {
+		want.Insert(string(u))
+	}
+	for _, u := range crt.Usages {
+		got.Insert(string(u))
+	}
+	if want.Equal(got) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s %s is ignored as %s is set: clear %s to use the usages of the profile",
+		fldPath.Child("usageProfile"), crt.UsageProfile, fldPath.Child("usages"), fldPath.Child("usages"))}
+}
+
 func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	var el field.ErrorList
 
@@ -235,5 +547,93 @@ func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath
 		aofSet.Insert(string(val.Type))
 	}
 
+	for i, val := range crt.AdditionalOutputFormats {
+		if len(val.Key) == 0 {
+			continue
+		}
+		keyPath := fldPath.Child("additionalOutputFormats").Index(i).Child("key")
+		if val.Type != internalcmapi.AdditionalCertificateOutputFormatAzureKeyVaultPEM {
+			el = append(el, field.Forbidden(keyPath, fmt.Sprintf("key may only be set for the %s output format", internalcmapi.AdditionalCertificateOutputFormatAzureKeyVaultPEM)))
+			continue
+		}
+		for _, msg := range utilvalidation.IsConfigMapKey(val.Key) {
+			el = append(el, field.Invalid(keyPath, val.Key, msg))
+		}
+		if reservedSecretKeys.Has(val.Key) || (crt.SecretKeys != nil &&
+			sets.NewString(crt.SecretKeys.Certificate, crt.SecretKeys.PrivateKey, crt.SecretKeys.CA).Has(val.Key)) {
+			el = append(el, field.Invalid(keyPath, val.Key, "must not be a key already written by cert-manager"))
+		}
+	}
+
+	return el
+}
+
+func validateSecretTypeAndKeys(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	switch crt.SecretType {
+	case "", internalcmapi.CertificateSecretTypeTLS, internalcmapi.CertificateSecretTypeOpaque:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("secretType"), crt.SecretType, []string{
+			string(internalcmapi.CertificateSecretTypeTLS), string(internalcmapi.CertificateSecretTypeOpaque),
+		}))
+	}
+
+	if crt.SecretKeys == nil {
+		return el
+	}
+
+	keysPath := fldPath.Child("secretKeys")
+	seen := sets.NewString()
+	for _, key := range []struct {
+		name, value, defaultValue string
+	}{
+		{name: "certificate", value: crt.SecretKeys.Certificate, defaultValue: corev1.TLSCertKey},
+		{name: "privateKey", value: crt.SecretKeys.PrivateKey, defaultValue: corev1.TLSPrivateKeyKey},
+		{name: "ca", value: crt.SecretKeys.CA, defaultValue: cmmeta.TLSCAKey},
+	} {
+		keyPath := keysPath.Child(key.name)
+		value := key.value
+		if len(value) == 0 {
+			value = key.defaultValue
+		} else if value != key.defaultValue {
+			if crt.SecretType != internalcmapi.CertificateSecretTypeOpaque {
+				el = append(el, field.Forbidden(keyPath, fmt.Sprintf("custom keys may only be used with a secretType of %s", internalcmapi.CertificateSecretTypeOpaque)))
+			}
+			for _, msg := range utilvalidation.IsConfigMapKey(value) {
+				el = append(el, field.Invalid(keyPath, value, msg))
+			}
+			if reservedSecretKeys.Has(value) && !defaultSecretKeys.Has(value) {
+				el = append(el, field.Invalid(keyPath, value, "must not be a key already written by cert-manager"))
+			}
+		}
+		if seen.Has(value) {
+			el = append(el, field.Duplicate(keyPath, value))
+		}
+		seen.Insert(value)
+	}
+
 	return el
 }
+
+// defaultSecretKeys are the Secret Data keys the certificate, private key and
+// CA certificate are stored in by default.
+var defaultSecretKeys = sets.NewString(
+	corev1.TLSCertKey,
+	corev1.TLSPrivateKeyKey,
+	cmmeta.TLSCAKey,
+)
+
+// reservedSecretKeys are the Secret Data keys cert-manager writes for the
+// Certificate itself, keystores and the other additional output formats.
+var reservedSecretKeys = sets.NewString(
+	corev1.TLSCertKey,
+	corev1.TLSPrivateKeyKey,
+	cmmeta.TLSCAKey,
+	"keystore.jks",
+	"truststore.jks",
+	"keystore.p12",
+	"truststore.p12",
+	cmapi.CertificateOutputFormatDERKey,
+	cmapi.CertificateOutputFormatCombinedPEMKey,
+)
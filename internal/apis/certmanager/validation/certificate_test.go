@@ -58,6 +58,14 @@ func int32Ptr(i int32) *int32 {
 	return &i
 }
 
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func TestValidateCertificate(t *testing.T) {
 	fldPath := field.NewPath("spec")
 	scenarios := map[string]struct {
@@ -127,7 +135,127 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 			errs: []*field.Error{
-				field.Invalid(fldPath.Child("issuerRef", "kind"), "invalid", "must be one of Issuer or ClusterIssuer"),
+				field.Invalid(fldPath.Child("issuerRef", "kind"), "invalid", "must be one of Issuer or ClusterIssuer, or issuerRef.group must be set to the API group of the external issuer providing the invalid kind"),
+			},
+		},
+		"valid with external issuerRef group and kind": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "valid",
+						Kind:  "AWSPCAIssuer",
+						Group: "awspca.cert-manager.io",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid issuerRef with cert-manager kind and external group": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "valid",
+						Kind:  "Issuer",
+						Group: "certmanager.io",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef", "group"), "certmanager.io", `kind Issuer is a cert-manager kind and must be used with group cert-manager.io or no group; if certmanager.io is an external issuer which defines its own Issuer kind, set the "cert-manager.io/allow-external-issuer-kind" annotation to "true"`),
+			},
+		},
+		"valid issuerRef with cert-manager kind and external group when explicitly allowed": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						internalcmapi.AllowExternalIssuerKindAnnotation: "true",
+					},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "valid",
+						Kind:  "ClusterIssuer",
+						Group: "sample-issuer.example.com",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid issuerRef group which is not a valid API group": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "valid",
+						Kind:  "AWSPCAIssuer",
+						Group: "awspca.cert-manager.io/v1beta1",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef", "group"), "awspca.cert-manager.io/v1beta1", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+		"valid with fallback issuerRefs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					FallbackIssuerRefs: []cmmeta.ObjectReference{
+						{Name: "valid", Kind: "ClusterIssuer"},
+						{Name: "valid", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid fallback issuerRefs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					FallbackIssuerRefs: []cmmeta.ObjectReference{
+						{Kind: "ClusterIssuer"},
+						{Name: "valid", Kind: "invalid"},
+						{Name: "valid", Kind: "Issuer", Group: "certmanager.io"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("fallbackIssuerRefs").Index(0).Child("name"), "must be specified"),
+				field.Invalid(fldPath.Child("fallbackIssuerRefs").Index(1).Child("kind"), "invalid", "must be one of Issuer or ClusterIssuer, or issuerRef.group must be set to the API group of the external issuer providing the invalid kind"),
+				field.Invalid(fldPath.Child("fallbackIssuerRefs").Index(2).Child("group"), "certmanager.io", `kind Issuer is a cert-manager kind and must be used with group cert-manager.io or no group; if certmanager.io is an external issuer which defines its own Issuer kind, set the "cert-manager.io/allow-external-issuer-kind" annotation to "true"`),
+			},
+		},
+		"fallback issuerRefs must not repeat an issuer": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  cmmeta.ObjectReference{Name: "valid"},
+					FallbackIssuerRefs: []cmmeta.ObjectReference{
+						{Name: "other", Kind: "ClusterIssuer"},
+						{Name: "valid", Kind: "Issuer", Group: "cert-manager.io"},
+						{Name: "other", Kind: "ClusterIssuer"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Duplicate(fldPath.Child("fallbackIssuerRefs").Index(1), cmmeta.ObjectReference{Name: "valid", Kind: "Issuer", Group: "cert-manager.io"}),
+				field.Duplicate(fldPath.Child("fallbackIssuerRefs").Index(2), cmmeta.ObjectReference{Name: "other", Kind: "ClusterIssuer"}),
 			},
 		},
 		"certificate missing secretName": {
@@ -151,7 +279,7 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 			errs: []*field.Error{
-				field.Invalid(fldPath, "", "at least one of commonName, dnsNames, uris ipAddresses, or emailAddresses must be set"),
+				field.Invalid(fldPath, "", "at least one of commonName, dnsNames, dnsNamesConfigMapRef, uris ipAddresses, emailAddresses or spiffe must be set"),
 			},
 		},
 		"certificate with no issuerRef": {
@@ -478,13 +606,65 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("usages").Index(0), internalcmapi.KeyUsage("nonexistent"), "unknown keyusage"),
 			},
 		},
+		"valid certificate with usage profile": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					UsageProfile: internalcmapi.MutualTLSUsageProfile,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid certificate with usage profile expanded into usages": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					UsageProfile: internalcmapi.ServerAuthUsageProfile,
+					Usages:       []internalcmapi.KeyUsage{"server auth", "digital signature", "key encipherment"},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid certificate with usage profile and different usages emits a warning": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					UsageProfile: internalcmapi.MutualTLSUsageProfile,
+					Usages:       []internalcmapi.KeyUsage{"digital signature", "key encipherment", "server auth"},
+				},
+			},
+			a: someAdmissionRequest,
+			warnings: []string{
+				"spec.usageProfile MutualTLS is ignored as spec.usages is set: clear spec.usages to use the usages of the profile",
+			},
+		},
+		"invalid certificate with unknown usage profile": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:   "testcn",
+					SecretName:   "abc",
+					IssuerRef:    validIssuerRef,
+					UsageProfile: "WebServer",
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("usageProfile"), internalcmapi.UsageProfile("WebServer"), []string{"ServerAuth", "ClientAuth", "MutualTLS", "CodeSigning"}),
+			},
+		},
 		"valid certificate with only URI SAN name": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
 					SecretName: "abc",
 					IssuerRef:  validIssuerRef,
 					URISANs: []string{
-						"foo.bar",
+						"spiffe://foo.bar/ns/sandbox",
 					},
 				},
 			},
@@ -564,6 +744,133 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("revisionHistoryLimit"), int32(0), "must not be less than 1"),
 			},
 		},
+		"valid certificate with additionalCACertificates": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					AdditionalCACertificates: &cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "extra-ca"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with additionalCACertificates missing a secret name": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:               "abc",
+					SecretName:               "abc",
+					IssuerRef:                validIssuerRef,
+					AdditionalCACertificates: &cmmeta.SecretKeySelector{Key: "ca.crt"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("additionalCACertificates", "name"), "secret name is required"),
+			},
+		},
+		"valid certificate with only dnsNamesConfigMapRef": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					DNSNamesConfigMapRef: &cmmeta.ConfigMapKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid certificate with renew-at annotation": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.RenewAtAnnotation: "2022-06-01T10:00:00Z"},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with renew-at annotation which is not an RFC3339 timestamp": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.RenewAtAnnotation: "tomorrow"},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(internalcmapi.RenewAtAnnotation), "tomorrow", "must be a timestamp in RFC3339 format"),
+			},
+		},
+		"valid certificate with secret-owner-reference annotation": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.SecretOwnerReferenceAnnotation: "false"},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with secret-owner-reference annotation which is not a boolean": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.SecretOwnerReferenceAnnotation: "yes"},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(field.NewPath("metadata", "annotations").Key(internalcmapi.SecretOwnerReferenceAnnotation), "yes", []string{"true", "false"}),
+			},
+		},
+		"invalid certificate with adopt-secret annotation which is not a boolean": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.AdoptSecretAnnotation: "yes"},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(field.NewPath("metadata", "annotations").Key(internalcmapi.AdoptSecretAnnotation), "yes", []string{"true", "false"}),
+			},
+		},
+		"invalid certificate with dnsNamesConfigMapRef missing a configmap name": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:           "abc",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+					DNSNamesConfigMapRef: &cmmeta.ConfigMapKeySelector{Key: "dnsNames"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("dnsNamesConfigMapRef", "name"), "configmap name is required"),
+			},
+		},
 		"valid with empty secretTemplate": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -623,6 +930,169 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("secretTemplate", "annotations"), "cert-manager.io/certificate-name", "cert-manager.io/* annotations are not allowed"),
 			},
 		},
+		"valid with renewBefore well below duration emits no warning": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:  "testcn",
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 8 * time.Hour},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid with renewBefore close to duration emits a warning": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:  "testcn",
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 23 * time.Hour},
+				},
+			},
+			a: someAdmissionRequest,
+			warnings: []string{
+				"spec.renewBefore 23h0m0s is close to the certificate duration 24h0m0s: the certificate will be renewed 1h0m0s after it is issued, which may cause it to be reissued continuously",
+			},
+		},
+		"valid with renewBefore close to the default duration emits a warning": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:  "testcn",
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					RenewBefore: &metav1.Duration{Duration: 89 * 24 * time.Hour},
+				},
+			},
+			a: someAdmissionRequest,
+			warnings: []string{
+				"spec.renewBefore 2136h0m0s is close to the certificate duration 2160h0m0s: the certificate will be renewed 24h0m0s after it is issued, which may cause it to be reissued continuously",
+			},
+		},
+		"invalid with renewBefore equal to duration is an error and not a warning": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:  "testcn",
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 24 * time.Hour},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("renewBefore"), 24*time.Hour, "certificate duration 24h0m0s must be greater than renewBefore 24h0m0s"),
+			},
+		},
+		"valid with absolute uris": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					URISANs: []string{
+						"spiffe://cluster.local/ns/sandbox/sa/default",
+						"urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+					},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with uris that are not absolute or cannot be parsed": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					URISANs: []string{
+						"spiffe://cluster.local/ns/sandbox/sa/default",
+						"cluster.local/ns/sandbox",
+						"https://example.com/%zz",
+						"https:",
+					},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("uris").Index(1), "cluster.local/ns/sandbox", "invalid URI: must be an absolute URI including a scheme"),
+				field.Invalid(fldPath.Child("uris").Index(2), "https://example.com/%zz", `invalid URI: parse "https://example.com/%zz": invalid URL escape "%zz"`),
+				field.Invalid(fldPath.Child("uris").Index(3), "https:", "invalid URI: must not be empty after the scheme"),
+			},
+		},
+		"valid with only spiffe set": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SPIFFE: &internalcmapi.SPIFFEID{
+						TrustDomain:        "cluster.local",
+						ServiceAccountName: "default",
+					},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid spiffe trust domain and service account name": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					SPIFFE: &internalcmapi.SPIFFEID{
+						TrustDomain:        "Cluster.Local",
+						ServiceAccountName: "Default",
+					},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("spiffe", "trustDomain"), "Cluster.Local", `trust domain contains invalid character 'C': only lowercase letters, digits, '.', '-' and '_' are allowed`),
+				field.Invalid(fldPath.Child("spiffe", "serviceAccountName"), "Default", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+		"invalid spiffe URI in uris": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					URISANs: []string{
+						"spiffe://cluster.local",
+					},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("uris").Index(0), "spiffe://cluster.local", "invalid SPIFFE ID: path must not be empty"),
+			},
+		},
+		"valid with subject serialNumber set": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					Subject: &internalcmapi.X509Subject{
+						SerialNumber: "DEVICE-1234 (rev. 2)",
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with subject serialNumber containing non-printable characters": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					Subject: &internalcmapi.X509Subject{
+						SerialNumber: "device_1234@example",
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("subject", "serialNumber"), "device_1234@example", "must only contain characters allowed in an ASN.1 PrintableString"),
+			},
+		},
 		"invalid due to too long 'CertificateSecretTemplate' annotations": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -676,6 +1146,69 @@ func TestValidateCertificate(t *testing.T) {
 	}
 }
 
+func TestValidateUpdateCertificate(t *testing.T) {
+	externalGroupIssuerRef := cmmeta.ObjectReference{
+		Name:  "valid",
+		Kind:  "Issuer",
+		Group: "certmanager.io",
+	}
+	baseCert := &internalcmapi.Certificate{
+		Spec: internalcmapi.CertificateSpec{
+			CommonName: "testcn",
+			SecretName: "abc",
+			IssuerRef:  externalGroupIssuerRef,
+		},
+	}
+	uriCert := func(uris ...string) *internalcmapi.Certificate {
+		return &internalcmapi.Certificate{
+			Spec: internalcmapi.CertificateSpec{
+				URISANs:    uris,
+				SecretName: "abc",
+				IssuerRef:  validIssuerRef,
+			},
+		}
+	}
+	scenarios := map[string]struct {
+		old, new *internalcmapi.Certificate
+		errs     []*field.Error
+	}{
+		"an unchanged issuerRef is not checked against the external issuer kinds": {
+			old: baseCert,
+			new: baseCert,
+		},
+		"a changed issuerRef is checked against the external issuer kinds": {
+			old: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			new: baseCert,
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec", "issuerRef", "group"), "certmanager.io", `kind Issuer is a cert-manager kind and must be used with group cert-manager.io or no group; if certmanager.io is an external issuer which defines its own Issuer kind, set the "cert-manager.io/allow-external-issuer-kind" annotation to "true"`),
+			},
+		},
+		"unchanged uris are not validated": {
+			old: uriCert("cluster.local/ns/sandbox"),
+			new: uriCert("cluster.local/ns/sandbox"),
+		},
+		"changed uris are validated": {
+			old: uriCert("spiffe://cluster.local/ns/sandbox"),
+			new: uriCert("cluster.local/ns/sandbox"),
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec", "uris").Index(0), "cluster.local/ns/sandbox", "invalid URI: must be an absolute URI including a scheme"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs, _ := ValidateUpdateCertificate(someAdmissionRequest, s.old, s.new)
+			assert.ElementsMatch(t, errs, s.errs)
+		})
+	}
+}
+
 func TestValidateDuration(t *testing.T) {
 	usefulDurations := map[string]*metav1.Duration{
 		"one second":  {Duration: time.Second},
@@ -767,6 +1300,65 @@ func TestValidateDuration(t *testing.T) {
 			},
 			errs: []*field.Error{field.Invalid(fldPath.Child("renewBefore"), usefulDurations["one second"].Duration, fmt.Sprintf("certificate renewBefore must be greater than %s", cmapi.MinimumRenewBefore))},
 		},
+		"valid minRemainingValidity": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					Duration:             usefulDurations["one year"],
+					MinRemainingValidity: usefulDurations["one month"],
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+				},
+			},
+		},
+		"minRemainingValidity is bigger than the duration": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					Duration:             usefulDurations["one month"],
+					MinRemainingValidity: usefulDurations["one year"],
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("minRemainingValidity"), usefulDurations["one year"].Duration, fmt.Sprintf("certificate duration %s must be greater than minRemainingValidity %s", usefulDurations["one month"].Duration, usefulDurations["one year"].Duration))},
+		},
+		"minRemainingValidity is not less than renewBefore": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					Duration:             usefulDurations["one year"],
+					RenewBefore:          usefulDurations["one month"],
+					MinRemainingValidity: usefulDurations["one month"],
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("minRemainingValidity"), usefulDurations["one month"].Duration, fmt.Sprintf("certificate renewBefore %s must be greater than minRemainingValidity %s", usefulDurations["one month"].Duration, usefulDurations["one month"].Duration))},
+		},
+		"minRemainingValidity is not less than the default renewBefore": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					Duration:             usefulDurations["one year"],
+					MinRemainingValidity: usefulDurations["half year"],
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("minRemainingValidity"), usefulDurations["half year"].Duration, fmt.Sprintf("certificate renewBefore %s must be greater than minRemainingValidity %s", usefulDurations["one year"].Duration/3, usefulDurations["half year"].Duration))},
+		},
+		"minRemainingValidity is not positive": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					MinRemainingValidity: &metav1.Duration{},
+					CommonName:           "testcn",
+					SecretName:           "abc",
+					IssuerRef:            validIssuerRef,
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("minRemainingValidity"), time.Duration(0), "certificate minRemainingValidity must be greater than 0")},
+		},
 		"duration is less than the minimum permitted value": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -888,6 +1480,53 @@ func Test_validateAdditionalOutputFormats(t *testing.T) {
 				field.Duplicate(field.NewPath("spec", "additionalOutputFormats").Key("type"), "bar"),
 			},
 		},
+		"if feature enabled and a key is set for the AzureKeyVaultPEM format, expect no error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				AdditionalOutputFormats: []internalcmapi.CertificateAdditionalOutputFormat{
+					{Type: internalcmapi.AdditionalCertificateOutputFormatAzureKeyVaultPEM, Key: "keyvault-import.pem"},
+				},
+			},
+			expErr: nil,
+		},
+		"if feature enabled and a key is set for another format, expect error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				AdditionalOutputFormats: []internalcmapi.CertificateAdditionalOutputFormat{
+					{Type: internalcmapi.AdditionalCertificateOutputFormatDER, Key: "private.der"},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "additionalOutputFormats").Index(0).Child("key"), "key may only be set for the AzureKeyVaultPEM output format"),
+			},
+		},
+		"if feature enabled and the AzureKeyVaultPEM key is invalid or reserved, expect error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				AdditionalOutputFormats: []internalcmapi.CertificateAdditionalOutputFormat{
+					{Type: internalcmapi.AdditionalCertificateOutputFormatAzureKeyVaultPEM, Key: "tls.crt"},
+					{Type: internalcmapi.AdditionalCertificateOutputFormatAzureKeyVaultPEM, Key: "not/valid"},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "additionalOutputFormats").Key("type"), "AzureKeyVaultPEM"),
+				field.Invalid(field.NewPath("spec", "additionalOutputFormats").Index(0).Child("key"), "tls.crt", "must not be a key already written by cert-manager"),
+				field.Invalid(field.NewPath("spec", "additionalOutputFormats").Index(1).Child("key"), "not/valid", "a valid config key must consist of alphanumeric characters, '-', '_' or '.' (e.g. 'key.name',  or 'KEY_NAME',  or 'key-name', regex used for validation is '[-._a-zA-Z0-9]+')"),
+			},
+		},
+		"if feature enabled and the AzureKeyVaultPEM key is a custom Secret key, expect error": {
+			featureEnabled: true,
+			spec: &internalcmapi.CertificateSpec{
+				SecretType: internalcmapi.CertificateSecretTypeOpaque,
+				SecretKeys: &internalcmapi.CertificateSecretKeys{Certificate: "cert.pem"},
+				AdditionalOutputFormats: []internalcmapi.CertificateAdditionalOutputFormat{
+					{Type: internalcmapi.AdditionalCertificateOutputFormatAzureKeyVaultPEM, Key: "cert.pem"},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "additionalOutputFormats").Index(0).Child("key"), "cert.pem", "must not be a key already written by cert-manager"),
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -898,3 +1537,143 @@ func Test_validateAdditionalOutputFormats(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateSecretTypeAndKeys(t *testing.T) {
+	keysPath := field.NewPath("spec", "secretKeys")
+
+	tests := map[string]struct {
+		spec   *internalcmapi.CertificateSpec
+		expErr field.ErrorList
+	}{
+		"no secret type or keys is valid": {
+			spec: &internalcmapi.CertificateSpec{},
+		},
+		"kubernetes.io/tls secret type is valid": {
+			spec: &internalcmapi.CertificateSpec{SecretType: internalcmapi.CertificateSecretTypeTLS},
+		},
+		"unknown secret type is invalid": {
+			spec: &internalcmapi.CertificateSpec{SecretType: "kubernetes.io/basic-auth"},
+			expErr: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "secretType"), internalcmapi.CertificateSecretType("kubernetes.io/basic-auth"), []string{"kubernetes.io/tls", "Opaque"}),
+			},
+		},
+		"custom keys with an Opaque secret type are valid": {
+			spec: &internalcmapi.CertificateSpec{
+				SecretType: internalcmapi.CertificateSecretTypeOpaque,
+				SecretKeys: &internalcmapi.CertificateSecretKeys{Certificate: "cert.pem", PrivateKey: "key.pem", CA: "chain.pem"},
+			},
+		},
+		"default keys with a kubernetes.io/tls secret type are valid": {
+			spec: &internalcmapi.CertificateSpec{
+				SecretKeys: &internalcmapi.CertificateSecretKeys{Certificate: "tls.crt", PrivateKey: "tls.key", CA: "ca.crt"},
+			},
+		},
+		"custom keys with a kubernetes.io/tls secret type are forbidden": {
+			spec: &internalcmapi.CertificateSpec{
+				SecretType: internalcmapi.CertificateSecretTypeTLS,
+				SecretKeys: &internalcmapi.CertificateSecretKeys{CA: "chain.pem"},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(keysPath.Child("ca"), "custom keys may only be used with a secretType of Opaque"),
+			},
+		},
+		"invalid, reserved and duplicate keys are invalid": {
+			spec: &internalcmapi.CertificateSpec{
+				SecretType: internalcmapi.CertificateSecretTypeOpaque,
+				SecretKeys: &internalcmapi.CertificateSecretKeys{Certificate: "not/valid", PrivateKey: "key.der", CA: "not/valid"},
+			},
+			expErr: field.ErrorList{
+				field.Invalid(keysPath.Child("certificate"), "not/valid", "a valid config key must consist of alphanumeric characters, '-', '_' or '.' (e.g. 'key.name',  or 'KEY_NAME',  or 'key-name', regex used for validation is '[-._a-zA-Z0-9]+')"),
+				field.Invalid(keysPath.Child("privateKey"), "key.der", "must not be a key already written by cert-manager"),
+				field.Invalid(keysPath.Child("ca"), "not/valid", "a valid config key must consist of alphanumeric characters, '-', '_' or '.' (e.g. 'key.name',  or 'KEY_NAME',  or 'key-name', regex used for validation is '[-._a-zA-Z0-9]+')"),
+				field.Duplicate(keysPath.Child("ca"), "not/valid"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateSecretTypeAndKeys(test.spec, field.NewPath("spec"))
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
+
+func Test_validateUnmanagedPrivateKey(t *testing.T) {
+	unmanaged := &internalcmapi.CertificatePrivateKey{Managed: boolPtr(false)}
+	csrRef := &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"}}
+
+	tests := map[string]struct {
+		spec   *internalcmapi.CertificateSpec
+		expErr field.ErrorList
+	}{
+		"managed private key without a csrSecretRef is valid": {
+			spec: &internalcmapi.CertificateSpec{},
+		},
+		"explicitly managed private key with a csrSecretRef is forbidden": {
+			spec: &internalcmapi.CertificateSpec{
+				PrivateKey:   &internalcmapi.CertificatePrivateKey{Managed: boolPtr(true)},
+				CSRSecretRef: csrRef,
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "csrSecretRef"), "may only be set when privateKey.managed is false"),
+			},
+		},
+		"unmanaged private key with a csrSecretRef is valid": {
+			spec: &internalcmapi.CertificateSpec{
+				PrivateKey:   unmanaged,
+				CSRSecretRef: csrRef,
+			},
+		},
+		"unmanaged private key without a csrSecretRef is invalid": {
+			spec: &internalcmapi.CertificateSpec{
+				PrivateKey: unmanaged,
+			},
+			expErr: field.ErrorList{
+				field.Required(field.NewPath("spec", "csrSecretRef"), "must be specified when privateKey.managed is false"),
+			},
+		},
+		"unmanaged private key with a csrSecretRef missing a name is invalid": {
+			spec: &internalcmapi.CertificateSpec{
+				PrivateKey:   unmanaged,
+				CSRSecretRef: &cmmeta.SecretKeySelector{Key: "tls.csr"},
+			},
+			expErr: field.ErrorList{
+				field.Required(field.NewPath("spec", "csrSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"unmanaged private key with keystores and additional output formats is invalid": {
+			spec: &internalcmapi.CertificateSpec{
+				PrivateKey:   unmanaged,
+				CSRSecretRef: csrRef,
+				Keystores:    &internalcmapi.CertificateKeystores{},
+				AdditionalOutputFormats: []internalcmapi.CertificateAdditionalOutputFormat{
+					{Type: internalcmapi.AdditionalCertificateOutputFormatDER},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "keystores"), "keystores cannot be written when privateKey.managed is false"),
+				field.Forbidden(field.NewPath("spec", "additionalOutputFormats"), "additional output formats cannot be written when privateKey.managed is false"),
+			},
+		},
+		"unmanaged private key with a dnsNamesConfigMapRef is invalid": {
+			spec: &internalcmapi.CertificateSpec{
+				PrivateKey:   unmanaged,
+				CSRSecretRef: csrRef,
+				DNSNamesConfigMapRef: &cmmeta.ConfigMapKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"},
+				},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "dnsNamesConfigMapRef"), "additional DNS names cannot be requested when privateKey.managed is false"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateUnmanagedPrivateKey(test.spec, field.NewPath("spec"))
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
@@ -41,6 +41,7 @@ var defaultInternalKeyUsages = []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cma
 func ValidateCertificateRequest(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	cr := obj.(*cmapi.CertificateRequest)
 	allErrs := ValidateCertificateRequestSpec(&cr.Spec, field.NewPath("spec"), true)
+	allErrs = append(allErrs, validateExternalIssuerRefKind(cr.Annotations, cr.Spec.IssuerRef, field.NewPath("spec", "issuerRef"))...)
 	allErrs = append(allErrs,
 		ValidateCertificateRequestApprovalCondition(cr.Status.Conditions, field.NewPath("status", "conditions"))...)
 
@@ -86,7 +87,7 @@ func validateCertificateRequestAnnotations(objA, objB *cmapi.CertificateRequest,
 func ValidateCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPath *field.Path, validateCSRContent bool) field.ErrorList {
 	el := field.ErrorList{}
 
-	el = append(el, validateIssuerRef(crSpec.IssuerRef, fldPath)...)
+	el = append(el, ValidateIssuerRef(crSpec.IssuerRef, fldPath.Child("issuerRef"))...)
 
 	if len(crSpec.Request) == 0 {
 		el = append(el, field.Required(fldPath.Child("request"), "must be specified"))
@@ -483,6 +483,41 @@ func TestValidateCertificateRequest(t *testing.T) {
 			a:     someAdmissionRequest,
 			wantE: []*field.Error{},
 		},
+		"Test issuerRef with cert-manager kind and external group": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					Request: mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					IssuerRef: cminternalmeta.ObjectReference{
+						Name:  "valid",
+						Kind:  "ClusterIssuer",
+						Group: "cert-manager.io.example.com",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef", "group"), nil, `kind ClusterIssuer is a cert-manager kind and must be used with group cert-manager.io or no group; if cert-manager.io.example.com is an external issuer which defines its own ClusterIssuer kind, set the "cert-manager.io/allow-external-issuer-kind" annotation to "true"`),
+			},
+		},
+		"Test issuerRef with cert-manager kind and external group when explicitly allowed": {
+			cr: &cminternal.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cminternal.AllowExternalIssuerKindAnnotation: "true",
+					},
+				},
+				Spec: cminternal.CertificateRequestSpec{
+					Request: mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					IssuerRef: cminternalmeta.ObjectReference{
+						Name:  "valid",
+						Kind:  "ClusterIssuer",
+						Group: "sample-issuer.example.com",
+					},
+				},
+			},
+			a:     someAdmissionRequest,
+			wantE: []*field.Error{},
+		},
 		"Test csr with double signature usages": {
 			cr: &cminternal.CertificateRequest{
 				Spec: cminternal.CertificateRequestSpec{
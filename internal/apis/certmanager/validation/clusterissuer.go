@@ -29,11 +29,13 @@ import (
 func ValidateClusterIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*cmapi.ClusterIssuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, ValidateIssuerSpecChanges(nil, &iss.Spec, field.NewPath("spec"))...)
 	return allErrs, warnings
 }
 
 func ValidateUpdateClusterIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
-	iss := obj.(*cmapi.ClusterIssuer)
+	oldIss, iss := oldObj.(*cmapi.ClusterIssuer), obj.(*cmapi.ClusterIssuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, ValidateIssuerSpecChanges(&oldIss.Spec, &iss.Spec, field.NewPath("spec"))...)
 	return allErrs, warnings
 }
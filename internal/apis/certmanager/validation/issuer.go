@@ -19,11 +19,15 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
@@ -34,21 +38,102 @@ import (
 
 // Validation functions for cert-manager Issuer types.
 
+// maxACMEHTTPRetries is the largest number of retries of a request to the
+// ACME server that may be configured on an ACME issuer.
+const maxACMEHTTPRetries = 10
+
 func ValidateIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, ValidateIssuerSpecChanges(nil, &iss.Spec, field.NewPath("spec"))...)
 	return allErrs, warnings
 }
 
 func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
-	iss := obj.(*certmanager.Issuer)
+	oldIss, iss := oldObj.(*certmanager.Issuer), obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, ValidateIssuerSpecChanges(&oldIss.Spec, &iss.Spec, field.NewPath("spec"))...)
 	// Admission request should never be nil
 	return allErrs, warnings
 }
 
+// ValidateIssuerSpecChanges applies the checks which are stricter than those
+// in place when the fields they validate were introduced. They are only
+// applied to fields which are set when an issuer is created, or which are
+// changed when it is updated, so that issuers created before the checks
+// existed can still be updated. oldIss is nil when the issuer is created.
+func ValidateIssuerSpecChanges(oldIss, iss *certmanager.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	var old certmanager.IssuerSpec
+	if oldIss != nil {
+		old = *oldIss
+	}
+
+	el := field.ErrorList{}
+	if iss.Venafi != nil && iss.Venafi.Cloud != nil && iss.Venafi.TPP == nil &&
+		(old.Venafi == nil || old.Venafi.Cloud == nil || old.Venafi.Zone != iss.Venafi.Zone) {
+		el = append(el, validateVenafiCloudZone(iss.Venafi.Zone, fldPath.Child("venafi", "zone"))...)
+	}
+	if iss.CA != nil && (old.CA == nil || !reflect.DeepEqual(old.CA.OCSPServers, iss.CA.OCSPServers)) {
+		el = append(el, validateOCSPServers(iss.CA.OCSPServers, fldPath.Child("ca", "ocspServer"))...)
+	}
+	if iss.CA != nil && (old.CA == nil || !reflect.DeepEqual(old.CA.IssuingCertificateURLs, iss.CA.IssuingCertificateURLs)) {
+		el = append(el, validateIssuingCertificateURLs(iss.CA.IssuingCertificateURLs, fldPath.Child("ca", "issuingCertificateURLs"))...)
+	}
+	if iss.CA != nil && (old.CA == nil || !reflect.DeepEqual(old.CA.CRLDistributionPoints, iss.CA.CRLDistributionPoints)) {
+		el = append(el, validateCRLDistributionPoints(iss.CA.CRLDistributionPoints, fldPath.Child("ca", "crlDistributionPoints"))...)
+	}
+	return el
+}
+
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	if iss.DefaultPrivateKey != nil {
+		el = append(el, ValidateIssuerPrivateKeyDefaults(iss.DefaultPrivateKey, fldPath.Child("defaultPrivateKey"))...)
+	}
+	if len(iss.TrustBundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(iss.TrustBundle) {
+		el = append(el, field.Invalid(fldPath.Child("trustBundle"), "", "must contain at least one PEM encoded CA certificate"))
+	}
+	if len(iss.TrustBundle) > 0 && iss.ACME != nil {
+		for _, sol := range iss.ACME.Solvers {
+			if sol.DNS01 != nil && sol.DNS01.Akamai != nil {
+				warnings = append(warnings, trustBundleNotUsedByAkamaiSolver)
+				break
+			}
+		}
+	}
+	return el, warnings
+}
+
+// ValidateIssuerPrivateKeyDefaults validates the default private key
+// parameters of an issuer in the same way as those of a Certificate.
+// A default size must be accompanied by the algorithm it applies to.
+func ValidateIssuerPrivateKeyDefaults(defaults *certmanager.IssuerPrivateKeyDefaults, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch defaults.Algorithm {
+	case "":
+		if defaults.Size > 0 {
+			el = append(el, field.Required(fldPath.Child("algorithm"), "must be specified when a default size is specified"))
+		}
+	case certmanager.RSAKeyAlgorithm:
+		if defaults.Size > 0 && (defaults.Size < 2048 || defaults.Size > 8192) {
+			el = append(el, field.Invalid(fldPath.Child("size"), defaults.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
+		}
+	case certmanager.ECDSAKeyAlgorithm:
+		if defaults.Size > 0 && defaults.Size != 256 && defaults.Size != 384 && defaults.Size != 521 {
+			el = append(el, field.NotSupported(fldPath.Child("size"), defaults.Size, []string{"256", "384", "521"}))
+		}
+	case certmanager.Ed25519KeyAlgorithm:
+		break
+	default:
+		el = append(el, field.Invalid(fldPath.Child("algorithm"), defaults.Algorithm, "must be either empty or one of rsa, ecdsa or ed25519"))
+	}
+
+	switch defaults.Encoding {
+	case "", certmanager.PKCS1, certmanager.PKCS8:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("encoding"), defaults.Encoding, []string{string(certmanager.PKCS1), string(certmanager.PKCS8)}))
+	}
+	return el
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, []string) {
@@ -119,7 +204,18 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 			el = append(el, field.Required(eabFldPath.Child("keyID"), "the keyID field is required when using externalAccountBinding"))
 		}
 
-		el = append(el, ValidateSecretKeySelector(&eab.Key, eabFldPath.Child("keySecretRef"))...)
+		if len(eab.KeyFile) > 0 {
+			if len(eab.Key.Name) > 0 || len(eab.Key.Key) > 0 {
+				el = append(el, field.Forbidden(eabFldPath.Child("keySecretRef"), "only one of keySecretRef or keyFile may be specified"))
+			}
+			// The file is looked up in the directory configured on the
+			// controller, so it must not be able to reference other files.
+			if strings.ContainsAny(eab.KeyFile, `/\`) || eab.KeyFile == "." || eab.KeyFile == ".." {
+				el = append(el, field.Invalid(eabFldPath.Child("keyFile"), eab.KeyFile, "must be a file name without a directory"))
+			}
+		} else {
+			el = append(el, ValidateSecretKeySelector(&eab.Key, eabFldPath.Child("keySecretRef"))...)
+		}
 
 		if len(eab.KeyAlgorithm) != 0 {
 			warnings = append(warnings, deprecatedACMEEABKeyAlgorithmField)
@@ -130,9 +226,36 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		el = append(el, ValidateACMEIssuerChallengeSolverConfig(&sol, fldPath.Child("solvers").Index(i))...)
 	}
 
+	el = append(el, ValidateACMEChallengeTypePreference(iss.ChallengeTypePreference, fldPath.Child("challengeTypePreference"))...)
+
+	if iss.HTTPTimeout != nil && iss.HTTPTimeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("httpTimeout"), iss.HTTPTimeout.Duration, "must be greater than zero"))
+	}
+	if iss.HTTPMaxRetries != nil && (*iss.HTTPMaxRetries < 0 || *iss.HTTPMaxRetries > maxACMEHTTPRetries) {
+		el = append(el, field.Invalid(fldPath.Child("httpMaxRetries"), *iss.HTTPMaxRetries, fmt.Sprintf("must be between 0 and %d", maxACMEHTTPRetries)))
+	}
+
 	return el, warnings
 }
 
+func ValidateACMEChallengeTypePreference(pref []cmacme.ACMEChallengeType, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	seen := make(map[cmacme.ACMEChallengeType]bool)
+	for i, t := range pref {
+		switch t {
+		case cmacme.ACMEChallengeTypeHTTP01, cmacme.ACMEChallengeTypeDNS01:
+		default:
+			el = append(el, field.NotSupported(fldPath.Index(i), t, []string{string(cmacme.ACMEChallengeTypeHTTP01), string(cmacme.ACMEChallengeTypeDNS01)}))
+			continue
+		}
+		if seen[t] {
+			el = append(el, field.Duplicate(fldPath.Index(i), t))
+		}
+		seen[t] = true
+	}
+	return el
+}
+
 func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -152,6 +275,9 @@ func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fl
 	if numProviders == 0 {
 		el = append(el, field.Required(fldPath, "no solver type configured"))
 	}
+	if sol.PreCheckDelay != nil && sol.PreCheckDelay.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("preCheckDelay"), sol.PreCheckDelay.Duration, "must not be negative"))
+	}
 
 	return el
 }
@@ -168,6 +294,10 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 		numDefined++
 		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(http01.GatewayHTTPRoute, fldPath.Child("gateway"))...)
 	}
+	if http01.HostNetwork != nil {
+		numDefined++
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01HostNetworkConfig(http01.HostNetwork, fldPath.Child("hostNetwork"))...)
+	}
 	if numDefined == 0 {
 		el = append(el, field.Required(fldPath, "no HTTP01 solver type configured"))
 	}
@@ -189,6 +319,7 @@ func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMECh
 	default:
 		el = append(el, field.Invalid(fldPath.Child("serviceType"), ingress.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
 	}
+	el = append(el, validateACMEIssuerChallengeSolverHTTP01Port(ingress.Port, fldPath.Child("port"))...)
 
 	return el
 }
@@ -201,15 +332,45 @@ func ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(gateway *cmacme.ACMECh
 	default:
 		el = append(el, field.Invalid(fldPath.Child("serviceType"), gateway.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
 	}
+	el = append(el, validateACMEIssuerChallengeSolverHTTP01Port(gateway.Port, fldPath.Child("port"))...)
 	if len(gateway.ParentRefs) == 0 {
 		el = append(el, field.Required(fldPath.Child("parentRefs"), `at least 1 parentRef is required`))
 	}
 	return el
 }
 
+// validateACMEIssuerChallengeSolverHTTP01Port validates the optional solver
+// port of the ingress and gatewayHTTPRoute solvers, where 0 means unset.
+func validateACMEIssuerChallengeSolverHTTP01Port(port int32, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if port == 0 {
+		return el
+	}
+	for _, msg := range utilvalidation.IsValidPortNum(int(port)) {
+		el = append(el, field.Invalid(fldPath, port, msg))
+	}
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverHTTP01HostNetworkConfig(hostNetwork *cmacme.ACMEChallengeSolverHTTP01HostNetwork, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if hostNetwork.Port == 0 {
+		el = append(el, field.Required(fldPath.Child("port"), ""))
+	} else {
+		for _, msg := range utilvalidation.IsValidPortNum(int(hostNetwork.Port)) {
+			el = append(el, field.Invalid(fldPath.Child("port"), hostNetwork.Port, msg))
+		}
+	}
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
-	if len(iss.SecretName) == 0 {
+	switch {
+	case len(iss.SecretName) > 0 && len(iss.CertificateName) > 0:
+		el = append(el, field.Forbidden(fldPath, "only one of 'secretName' or 'certificateName' should be specified"))
+	case len(iss.SecretName) == 0 && len(iss.CertificateName) == 0:
 		el = append(el, field.Required(fldPath.Child("secretName"), ""))
 	}
 	for i, ocspURL := range iss.OCSPServers {
@@ -217,10 +378,69 @@ func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) fiel
 			el = append(el, field.Invalid(fldPath.Child("ocspServer").Index(i), ocspURL, "must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org"))
 		}
 	}
+	el = append(el, validatePathLen(iss.PathLen, fldPath.Child("pathLen"))...)
+	return el
+}
+
+// validateOCSPServers checks that each OCSP server is an absolute http or
+// https URL. Empty URLs are reported by ValidateCAIssuerConfig.
+func validateOCSPServers(ocspServers []string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, ocspURL := range ocspServers {
+		if ocspURL != "" && !isAbsoluteHTTPURL(ocspURL) {
+			el = append(el, field.Invalid(fldPath.Index(i), ocspURL, "must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org"))
+		}
+	}
+	return el
+}
+
+// validateIssuingCertificateURLs checks that each issuing certificate URL is
+// an absolute http or https URL.
+func validateIssuingCertificateURLs(issuingCertificateURLs []string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, issuingCertURL := range issuingCertificateURLs {
+		if !isAbsoluteHTTPURL(issuingCertURL) {
+			el = append(el, field.Invalid(fldPath.Index(i), issuingCertURL, "must be a valid http or https URL, e.g., http://ca.example.com/ca.crt"))
+		}
+	}
+	return el
+}
+
+// isAbsoluteHTTPURL returns true if the given string is an absolute http or
+// https URL, as required for the OCSP and CA Issuers access methods of the
+// Authority Information Access extension (RFC 5280 section 4.2.2.1).
+func isAbsoluteHTTPURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validateCRLDistributionPoints checks that each CRL distribution point is an
+// absolute URL, such as an http or ldap URL, as they are embedded in issued
+// certificates as uniform resource identifiers.
+func validateCRLDistributionPoints(crlDistributionPoints []string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, crlDP := range crlDistributionPoints {
+		u, err := url.Parse(crlDP)
+		if err != nil || !u.IsAbs() || (u.Host == "" && u.Path == "" && u.Opaque == "") {
+			el = append(el, field.Invalid(fldPath.Index(i), crlDP, "must be a valid absolute URL, e.g., http://crl.example.com/ca.crl"))
+		}
+	}
 	return el
 }
 
 func ValidateSelfSignedIssuerConfig(iss *certmanager.SelfSignedIssuer, fldPath *field.Path) field.ErrorList {
+	return validatePathLen(iss.PathLen, fldPath.Child("pathLen"))
+}
+
+// validatePathLen checks that a CA path length constraint, if set, is not
+// negative.
+func validatePathLen(pathLen *int, fldPath *field.Path) field.ErrorList {
+	if pathLen != nil && *pathLen < 0 {
+		return field.ErrorList{field.Invalid(fldPath, *pathLen, "must not be negative")}
+	}
 	return nil
 }
 
@@ -232,6 +452,20 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 	if len(iss.Path) == 0 {
 		el = append(el, field.Required(fldPath.Child("path"), ""))
 	}
+	for i, mount := range iss.Mounts {
+		mountPath := fldPath.Child("mounts").Index(i)
+		if len(mount.Path) == 0 {
+			el = append(el, field.Required(mountPath.Child("path"), ""))
+		}
+		if len(mount.DomainSuffixes) == 0 {
+			el = append(el, field.Required(mountPath.Child("domainSuffixes"), "at least one domain suffix must be specified"))
+		}
+		for j, suffix := range mount.DomainSuffixes {
+			if len(strings.Trim(suffix, ".")) == 0 {
+				el = append(el, field.Invalid(mountPath.Child("domainSuffixes").Index(j), suffix, "domain suffix must not be empty"))
+			}
+		}
+	}
 
 	// check if caBundle is valid
 	certs := iss.CABundle
@@ -251,6 +485,14 @@ func ValidateVenafiTPP(tpp *certmanager.VenafiTPP, fldPath *field.Path) (el fiel
 	if tpp.URL == "" {
 		el = append(el, field.Required(fldPath.Child("url"), ""))
 	}
+	if tpp.CABundleSecretRef != nil {
+		if len(tpp.CABundle) > 0 {
+			el = append(el, field.Forbidden(fldPath, "only one of 'caBundle' or 'caBundleSecretRef' may be specified"))
+		}
+		if tpp.CABundleSecretRef.Name == "" {
+			el = append(el, field.Required(fldPath.Child("caBundleSecretRef", "name"), "secret name is required"))
+		}
+	}
 	return el
 }
 
@@ -258,6 +500,20 @@ func ValidateVenafiCloud(c *certmanager.VenafiCloud, fldPath *field.Path) (el fi
 	return el
 }
 
+// validateVenafiCloudZone checks that a Venafi Cloud zone is made up of a
+// non-empty application name and issuing template alias separated by a single
+// backslash. An empty zone is reported by ValidateVenafiIssuerConfig.
+func validateVenafiCloudZone(zone string, fldPath *field.Path) field.ErrorList {
+	if zone == "" {
+		return nil
+	}
+	segments := strings.Split(zone, "\\")
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return field.ErrorList{field.Invalid(fldPath, zone, `Venafi Cloud zones must be in the format "<application>\<issuing template alias>"`)}
+	}
+	return nil
+}
+
 func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Path) (el field.ErrorList) {
 	if iss.Zone == "" {
 		el = append(el, field.Required(fldPath.Child("zone"), ""))
@@ -303,6 +559,14 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, field.Invalid(fldPath.Child("cnameStrategy"), p.CNAMEStrategy, fmt.Sprintf("must be one of %q or %q", cmacme.NoneStrategy, cmacme.FollowStrategy)))
 		}
 	}
+	if len(p.ChallengeDomain) > 0 {
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(strings.TrimSuffix(p.ChallengeDomain, ".")) {
+			el = append(el, field.Invalid(fldPath.Child("challengeDomain"), p.ChallengeDomain, msg))
+		}
+		if p.CNAMEStrategy == cmacme.FollowStrategy {
+			el = append(el, field.Forbidden(fldPath.Child("challengeDomain"), fmt.Sprintf("may not be used together with the %q CNAME strategy", cmacme.FollowStrategy)))
+		}
+	}
 	numProviders := 0
 	if p.Akamai != nil {
 		numProviders++
@@ -384,11 +648,17 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if p.Cloudflare.APIToken != nil {
 				el = append(el, ValidateSecretKeySelector(p.Cloudflare.APIToken, fldPath.Child("cloudflare", "apiTokenSecretRef"))...)
 			}
+			for i := range p.Cloudflare.APITokens {
+				el = append(el, ValidateSecretKeySelector(&p.Cloudflare.APITokens[i], fldPath.Child("cloudflare", "apiTokenSecretRefs").Index(i))...)
+			}
 			if p.Cloudflare.APIKey != nil && p.Cloudflare.APIToken != nil {
 				el = append(el, field.Forbidden(fldPath.Child("cloudflare"), "apiKeySecretRef and apiTokenSecretRef cannot both be specified"))
 			}
-			if p.Cloudflare.APIKey == nil && p.Cloudflare.APIToken == nil {
-				el = append(el, field.Required(fldPath.Child("cloudflare"), "apiKeySecretRef or apiTokenSecretRef is required"))
+			if len(p.Cloudflare.APITokens) > 0 && (p.Cloudflare.APIKey != nil || p.Cloudflare.APIToken != nil) {
+				el = append(el, field.Forbidden(fldPath.Child("cloudflare", "apiTokenSecretRefs"), "apiTokenSecretRefs cannot be specified together with apiKeySecretRef or apiTokenSecretRef"))
+			}
+			if p.Cloudflare.APIKey == nil && p.Cloudflare.APIToken == nil && len(p.Cloudflare.APITokens) == 0 {
+				el = append(el, field.Required(fldPath.Child("cloudflare"), "apiKeySecretRef, apiTokenSecretRef or apiTokenSecretRefs is required"))
 			}
 			if len(p.Cloudflare.Email) == 0 && p.Cloudflare.APIKey != nil {
 				el = append(el, field.Required(fldPath.Child("cloudflare", "email"), ""))
@@ -404,6 +674,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if len(p.Route53.Region) == 0 {
 				el = append(el, field.Required(fldPath.Child("route53", "region"), ""))
 			}
+			el = append(el, validateRoute53AssumeRole(p.Route53, fldPath.Child("route53"))...)
 		}
 	}
 	if p.AcmeDNS != nil {
@@ -422,6 +693,22 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, ValidateSecretKeySelector(&p.DigitalOcean.Token, fldPath.Child("digitalocean", "tokenSecretRef"))...)
 		}
 	}
+	if p.Bunny != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("bunny"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.Bunny.AccessKey, fldPath.Child("bunny", "accessKeySecretRef"))...)
+		}
+	}
+	if p.Gandi != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("gandi"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.Gandi.Token, fldPath.Child("gandi", "tokenSecretRef"))...)
+		}
+	}
 	if p.RFC2136 != nil {
 		if numProviders > 0 {
 			el = append(el, field.Forbidden(fldPath.Child("rfc2136"), "may not specify more than one provider type"))
@@ -435,6 +722,11 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 					el = append(el, field.Invalid(fldPath.Child("rfc2136", "nameserver"), p.RFC2136.Nameserver, "nameserver must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."))
 				}
 			}
+			for i, nameserver := range p.RFC2136.AdditionalNameservers {
+				if _, err := util.ValidNameserver(nameserver); err != nil {
+					el = append(el, field.Invalid(fldPath.Child("rfc2136", "additionalNameservers").Index(i), nameserver, "nameserver must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."))
+				}
+			}
 			if len(p.RFC2136.TSIGAlgorithm) > 0 {
 				present := false
 				for _, b := range supportedTSIGAlgorithms {
@@ -456,6 +748,12 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 				}
 
 			}
+			if p.RFC2136.TSIGKeyFile != nil {
+				el = append(el, ValidateSecretKeySelector(p.RFC2136.TSIGKeyFile, fldPath.Child("rfc2136", "tsigKeyFileSecretRef"))...)
+				if len(p.RFC2136.TSIGKeyName) > 0 || len(p.RFC2136.TSIGSecret.Name) > 0 || len(p.RFC2136.TSIGAlgorithm) > 0 {
+					el = append(el, field.Forbidden(fldPath.Child("rfc2136", "tsigKeyFileSecretRef"), "may not be specified together with tsigKeyName, tsigSecretSecretRef or tsigAlgorithm"))
+				}
+			}
 		}
 	}
 	if p.Webhook != nil {
@@ -475,6 +773,30 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 	return el
 }
 
+// route53RoleSessionNameRegexp matches the role session names accepted by
+// the AWS STS AssumeRole API.
+var route53RoleSessionNameRegexp = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+
+// validateRoute53AssumeRole validates the options used when the Route53
+// provider assumes a role, which may only be set if a role is.
+func validateRoute53AssumeRole(p *cmacme.ACMEIssuerDNS01ProviderRoute53, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+	if len(p.Role) == 0 {
+		if len(p.RoleSessionName) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("roleSessionName"), "may only be set if role is set"))
+		}
+		if len(p.SessionTags) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("sessionTags"), "may only be set if role is set"))
+		}
+		return el
+	}
+	if len(p.RoleSessionName) > 0 && !route53RoleSessionNameRegexp.MatchString(p.RoleSessionName) {
+		el = append(el, field.Invalid(fldPath.Child("roleSessionName"), p.RoleSessionName,
+			"must be between 2 and 64 characters long and only contain alphanumeric characters or any of '+=,.@-_'"))
+	}
+	return el
+}
+
 func ValidateSecretKeySelector(sks *cmmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if sks.Name == "" {
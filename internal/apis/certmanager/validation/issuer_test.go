@@ -19,16 +19,21 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
 	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 var (
@@ -87,6 +92,30 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
 			},
 		},
+		"vault issuer with valid mounts": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Mounts: []cmapi.VaultPKIMount{
+					{Path: "d/e/f", DomainSuffixes: []string{"example.com"}},
+				},
+			},
+		},
+		"vault issuer with invalid mounts": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Mounts: []cmapi.VaultPKIMount{
+					{},
+					{Path: "d/e/f", DomainSuffixes: []string{"example.com", "."}},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("mounts").Index(0).Child("path"), ""),
+				field.Required(fldPath.Child("mounts").Index(0).Child("domainSuffixes"), "at least one domain suffix must be specified"),
+				field.Invalid(fldPath.Child("mounts").Index(1).Child("domainSuffixes").Index(1), ".", "domain suffix must not be empty"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -122,6 +151,88 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				field.Required(fldPath.Child("server"), "acme server URL is a required field"),
 			},
 		},
+		"acme issuer with valid challenge type preference": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                   "valid-email",
+				Server:                  "valid-server",
+				PrivateKey:              validSecretKeyRef,
+				ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01, cmacme.ACMEChallengeTypeHTTP01},
+			},
+		},
+		"acme issuer with invalid and duplicate challenge type preference": {
+			spec: &cmacme.ACMEIssuer{
+				Email:                   "valid-email",
+				Server:                  "valid-server",
+				PrivateKey:              validSecretKeyRef,
+				ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01, "TLS-ALPN-01", cmacme.ACMEChallengeTypeDNS01},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("challengeTypePreference").Index(1), cmacme.ACMEChallengeType("TLS-ALPN-01"), []string{"HTTP-01", "DNS-01"}),
+				field.Duplicate(fldPath.Child("challengeTypePreference").Index(2), cmacme.ACMEChallengeTypeDNS01),
+			},
+		},
+		"acme issuer with valid HTTP timeout and retries": {
+			spec: &cmacme.ACMEIssuer{
+				Email:          "valid-email",
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				HTTPTimeout:    &metav1.Duration{Duration: time.Minute},
+				HTTPMaxRetries: pointer.Int(3),
+			},
+		},
+		"acme issuer with invalid HTTP timeout and retries": {
+			spec: &cmacme.ACMEIssuer{
+				Email:          "valid-email",
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				HTTPTimeout:    &metav1.Duration{Duration: 0},
+				HTTPMaxRetries: pointer.Int(11),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("httpTimeout"), time.Duration(0), "must be greater than zero"),
+				field.Invalid(fldPath.Child("httpMaxRetries"), 11, "must be between 0 and 10"),
+			},
+		},
+		"acme issuer with negative HTTP retries": {
+			spec: &cmacme.ACMEIssuer{
+				Email:          "valid-email",
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				HTTPMaxRetries: pointer.Int(-1),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("httpMaxRetries"), -1, "must be between 0 and 10"),
+			},
+		},
+		"acme solver with a pre-check delay": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01:        &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}},
+						PreCheckDelay: &metav1.Duration{Duration: 30 * time.Second},
+					},
+				},
+			},
+		},
+		"acme solver with a negative pre-check delay": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01:        &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}},
+						PreCheckDelay: &metav1.Duration{Duration: -time.Second},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("solvers").Index(0).Child("preCheckDelay"), -time.Second, "must not be negative"),
+			},
+		},
 		"acme solver without any config": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -207,6 +318,46 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 			},
 			warnings: []string{deprecatedACMEEABKeyAlgorithmField},
 		},
+		"acme solver with an external account binding key file": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{
+					KeyID:   "test",
+					KeyFile: "eab.key",
+				},
+			},
+		},
+		"acme solver with an external account binding key file and keySecretRef": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{
+					KeyID:   "test",
+					Key:     validSecretKeyRef,
+					KeyFile: "eab.key",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("externalAccountBinding.keySecretRef"), "only one of keySecretRef or keyFile may be specified"),
+			},
+		},
+		"acme solver with an external account binding key file outside of the key directory": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{
+					KeyID:   "test",
+					KeyFile: "../token",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("externalAccountBinding.keyFile"), "../token", "must be a file name without a directory"),
+			},
+		},
 		"acme solver with missing http01 config type": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -408,6 +559,8 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 
 func TestValidateIssuerSpec(t *testing.T) {
 	fldPath := field.NewPath("")
+	caPK := testcrypto.MustCreatePEMPrivateKey(t)
+	trustBundle := testcrypto.MustCreateCert(t, caPK, gen.Certificate("ca", gen.SetCertificateCommonName("ca"), gen.SetCertificateIsCA(true)))
 	scenarios := map[string]struct {
 		spec     *cmapi.IssuerSpec
 		errs     field.ErrorList
@@ -431,6 +584,27 @@ func TestValidateIssuerSpec(t *testing.T) {
 			},
 			errs: []*field.Error{field.Required(fldPath.Child("ca", "secretName"), "")},
 		},
+		"valid ca issuer referencing a certificate": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						CertificateName: "ca",
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with both secret name and certificate name specified": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:      "valid",
+						CertificateName: "ca",
+					},
+				},
+			},
+			errs: []*field.Error{field.Forbidden(fldPath.Child("ca"), "only one of 'secretName' or 'certificateName' should be specified")},
+		},
 		"valid self signed issuer": {
 			spec: &cmapi.IssuerSpec{
 				IssuerConfig: cmapi.IssuerConfig{
@@ -500,6 +674,155 @@ func TestValidateIssuerSpec(t *testing.T) {
 				field.Invalid(fldPath.Child("ca", "ocspServer").Index(0), "", `must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org`),
 			},
 		},
+		"valid authority information access urls": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:             "valid",
+						OCSPServers:            []string{"http://ocsp.example.com"},
+						IssuingCertificateURLs: []string{"http://ca.example.com/ca.crt", "https://ca-backup.example.com/ca.crt"},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"valid path length constraints": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PathLen:    intPtr(0),
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"negative ca path length constraint": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PathLen:    intPtr(-1),
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "pathLen"), -1, "must not be negative"),
+			},
+		},
+		"valid selfsigned path length constraint": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{
+						PathLen: intPtr(2),
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"negative selfsigned path length constraint": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{
+						PathLen: intPtr(-1),
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("selfSigned", "pathLen"), -1, "must not be negative"),
+			},
+		},
+		"valid default private key": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				DefaultPrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+					Algorithm: cmapi.ECDSAKeyAlgorithm,
+					Size:      384,
+					Encoding:  cmapi.PKCS8,
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"default private key with an invalid rsa size": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				DefaultPrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+					Algorithm: cmapi.RSAKeyAlgorithm,
+					Size:      1024,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("defaultPrivateKey", "size"), 1024, "must be between 2048 & 8192 for rsa keyAlgorithm"),
+			},
+		},
+		"default private key with an invalid ecdsa size": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				DefaultPrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+					Algorithm: cmapi.ECDSAKeyAlgorithm,
+					Size:      2048,
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("defaultPrivateKey", "size"), 2048, []string{"256", "384", "521"}),
+			},
+		},
+		"default private key size without an algorithm": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				DefaultPrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+					Size: 4096,
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("defaultPrivateKey", "algorithm"), "must be specified when a default size is specified"),
+			},
+		},
+		"trust bundle without any certificates": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					SelfSigned: &cmapi.SelfSignedIssuer{},
+				},
+				TrustBundle: []byte("not a certificate"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("trustBundle"), "", "must contain at least one PEM encoded CA certificate"),
+			},
+		},
+		"trust bundle with an akamai solver": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					ACME: &cmacme.ACMEIssuer{
+						Email:      "valid-email",
+						Server:     "valid-server",
+						PrivateKey: validSecretKeyRef,
+						Solvers: []cmacme.ACMEChallengeSolver{
+							{
+								DNS01: &cmacme.ACMEChallengeSolverDNS01{
+									Akamai: &cmacme.ACMEIssuerDNS01ProviderAkamai{
+										ServiceConsumerDomain: "test.example.com",
+										ClientToken:           validSecretKeyRef,
+										ClientSecret:          validSecretKeyRef,
+										AccessToken:           validSecretKeyRef,
+									},
+								},
+							},
+						},
+					},
+				},
+				TrustBundle: trustBundle,
+			},
+			errs:     []*field.Error{},
+			warnings: []string{trustBundleNotUsedByAkamaiSolver},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -580,6 +903,67 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				field.Invalid(fldPath.Child("ingress", "serviceType"), corev1.ServiceType("InvalidServiceType"), `must be empty, "ClusterIP" or "NodePort"`),
 			},
 		},
+		"acme issuer with valid http01 ingress port": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Port: 8080},
+			},
+		},
+		"acme issuer with invalid http01 ingress port": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Port: -1},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ingress", "port"), int32(-1), "must be between 1 and 65535, inclusive"),
+			},
+		},
+		"acme issuer with invalid http01 gateway port": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					Port:       65536,
+					ParentRefs: []gwapi.ParentRef{{Name: "gateway"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("gateway", "port"), int32(65536), "must be between 1 and 65535, inclusive"),
+			},
+		},
+		"acme issuer with valid http01 hostNetwork config": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				HostNetwork: &cmacme.ACMEChallengeSolverHTTP01HostNetwork{
+					Port: 8080,
+					PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+						Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+							NodeSelector: map[string]string{"edge": "true"},
+						},
+					},
+				},
+			},
+		},
+		"acme issuer with http01 hostNetwork config without a port": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				HostNetwork: &cmacme.ACMEChallengeSolverHTTP01HostNetwork{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("hostNetwork", "port"), ""),
+			},
+		},
+		"acme issuer with http01 hostNetwork config with an invalid port": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				HostNetwork: &cmacme.ACMEChallengeSolverHTTP01HostNetwork{Port: 70000},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("hostNetwork", "port"), int32(70000), "must be between 1 and 65535, inclusive"),
+			},
+		},
+		"acme issuer with both http01 ingress and hostNetwork config": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress:     &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				HostNetwork: &cmacme.ACMEChallengeSolverHTTP01HostNetwork{Port: 8080},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath, "only 1 HTTP01 solver type may be configured"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -680,7 +1064,7 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				},
 			},
 			errs: []*field.Error{
-				field.Required(fldPath.Child("cloudflare"), "apiKeySecretRef or apiTokenSecretRef is required"),
+				field.Required(fldPath.Child("cloudflare"), "apiKeySecretRef, apiTokenSecretRef or apiTokenSecretRefs is required"),
 			},
 		},
 		"both cloudflare api token and key specified": {
@@ -695,6 +1079,35 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Forbidden(fldPath.Child("cloudflare"), "apiKeySecretRef and apiTokenSecretRef cannot both be specified"),
 			},
 		},
+		"multiple cloudflare api tokens": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+					APITokens: []cmmeta.SecretKeySelector{validSecretKeyRef, validSecretKeyRef},
+				},
+			},
+		},
+		"missing cloudflare api tokens fields": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+					APITokens: []cmmeta.SecretKeySelector{validSecretKeyRef, {}},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("cloudflare", "apiTokenSecretRefs").Index(1).Child("name"), "secret name is required"),
+				field.Required(fldPath.Child("cloudflare", "apiTokenSecretRefs").Index(1).Child("key"), "secret key is required"),
+			},
+		},
+		"cloudflare api tokens specified with api token": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+					APIToken:  &validSecretKeyRef,
+					APITokens: []cmmeta.SecretKeySelector{validSecretKeyRef},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("cloudflare", "apiTokenSecretRefs"), "apiTokenSecretRefs cannot be specified together with apiKeySecretRef or apiTokenSecretRef"),
+			},
+		},
 		"missing cloudflare email": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
@@ -713,12 +1126,75 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("route53", "region"), ""),
 			},
 		},
+		"valid route53 role session name and session tags": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:          "us-east-1",
+					Role:            "arn:aws:iam::123456789012:role/cert-manager",
+					RoleSessionName: "cert-manager@team-a",
+					SessionTags:     map[string]string{"team": "a"},
+				},
+			},
+		},
+		"route53 role session name and session tags without a role": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:          "us-east-1",
+					RoleSessionName: "cert-manager",
+					SessionTags:     map[string]string{"team": "a"},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("route53", "roleSessionName"), "may only be set if role is set"),
+				field.Forbidden(fldPath.Child("route53", "sessionTags"), "may only be set if role is set"),
+			},
+		},
+		"invalid route53 role session name": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:          "us-east-1",
+					Role:            "arn:aws:iam::123456789012:role/cert-manager",
+					RoleSessionName: "cert manager",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("route53", "roleSessionName"), "cert manager",
+					"must be between 2 and 64 characters long and only contain alphanumeric characters or any of '+=,.@-_'"),
+			},
+		},
 		"missing provider config": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{},
 			errs: []*field.Error{
 				field.Required(fldPath, "no DNS01 provider configured"),
 			},
 		},
+		"missing bunny access key": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("bunny", "accessKeySecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("bunny", "accessKeySecretRef", "key"), "secret key is required"),
+			},
+		},
+		"bunny and digitalocean both specified": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				DigitalOcean: &cmacme.ACMEIssuerDNS01ProviderDigitalOcean{Token: validSecretKeyRef},
+				Bunny:        &cmacme.ACMEIssuerDNS01ProviderBunny{AccessKey: validSecretKeyRef},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("bunny"), "may not specify more than one provider type"),
+			},
+		},
+		"missing gandi token": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Gandi: &cmacme.ACMEIssuerDNS01ProviderGandi{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("gandi", "tokenSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("gandi", "tokenSecretRef", "key"), "secret key is required"),
+			},
+		},
 		"missing azuredns config": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{},
@@ -1067,6 +1543,26 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Invalid(fldPath.Child("rfc2136", "nameserver"), ":53", "nameserver must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."),
 			},
 		},
+		"rfc2136 provider with additional nameservers": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:            "127.0.0.1",
+					AdditionalNameservers: []string{"127.0.0.2:53", "[2001:db8::1]"},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"rfc2136 provider with invalid additional nameserver": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:            "127.0.0.1",
+					AdditionalNameservers: []string{"127.0.0.2", ":53"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("rfc2136", "additionalNameservers").Index(1), ":53", "nameserver must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."),
+			},
+		},
 		"rfc2136 provider using case-camel in algorithm": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
@@ -1110,6 +1606,71 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("rfc2136", "tsigKeyName"), ""),
 			},
 		},
+		"rfc2136 provider with TSIG keyfile": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:  "127.0.0.1",
+					TSIGKeyFile: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"rfc2136 provider with TSIG keyfile missing key": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:  "127.0.0.1",
+					TSIGKeyFile: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "keyfile"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("rfc2136", "tsigKeyFileSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"rfc2136 provider with TSIG keyfile and discrete TSIG fields": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:  "127.0.0.1",
+					TSIGKeyName: "some-name",
+					TSIGSecret:  validSecretKeyRef,
+					TSIGKeyFile: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("rfc2136", "tsigKeyFileSecretRef"), "may not be specified together with tsigKeyName, tsigSecretSecretRef or tsigAlgorithm"),
+			},
+		},
+		"challenge domain set": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ChallengeDomain: "acme.delegated.net",
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid challenge domain": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ChallengeDomain: "acme_delegated.net",
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("challengeDomain"), "acme_delegated.net", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+			},
+		},
+		"challenge domain with Follow CNAME strategy": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				ChallengeDomain: "acme.delegated.net",
+				CNAMEStrategy:   cmacme.FollowStrategy,
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("challengeDomain"), `may not be used together with the "Follow" CNAME strategy`),
+			},
+		},
 		"multiple providers configured": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
@@ -1284,6 +1845,35 @@ func TestValidateVenafiTPP(t *testing.T) {
 				field.Required(fldPath.Child("url"), ""),
 			},
 		},
+		"valid caBundleSecretRef": {
+			cfg: &cmapi.VenafiTPP{
+				URL: "https://tpp.example.com/vedsdk",
+				CABundleSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "tpp-ca"},
+				},
+			},
+		},
+		"caBundleSecretRef missing name": {
+			cfg: &cmapi.VenafiTPP{
+				URL:               "https://tpp.example.com/vedsdk",
+				CABundleSecretRef: &cmmeta.SecretKeySelector{Key: "ca.crt"},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("caBundleSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"both caBundle and caBundleSecretRef": {
+			cfg: &cmapi.VenafiTPP{
+				URL:      "https://tpp.example.com/vedsdk",
+				CABundle: []byte("ca"),
+				CABundleSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "tpp-ca"},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only one of 'caBundle' or 'caBundleSecretRef' may be specified"),
+			},
+		},
 	}
 
 	for n, s := range scenarios {
@@ -1302,6 +1892,123 @@ func TestValidateVenafiTPP(t *testing.T) {
 	}
 }
 
+func TestValidateIssuerSpecChanges(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	venafiCloudSpec := func(zone string) *cmapi.IssuerSpec {
+		return &cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+			Venafi: &cmapi.VenafiIssuer{Zone: zone, Cloud: &cmapi.VenafiCloud{}},
+		}}
+	}
+	caSpec := func(ocspServers, issuingCertificateURLs []string) *cmapi.IssuerSpec {
+		return &cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+			CA: &cmapi.CAIssuer{SecretName: "valid", OCSPServers: ocspServers, IssuingCertificateURLs: issuingCertificateURLs},
+		}}
+	}
+	crlSpec := func(crlDistributionPoints ...string) *cmapi.IssuerSpec {
+		return &cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+			CA: &cmapi.CAIssuer{SecretName: "valid", CRLDistributionPoints: crlDistributionPoints},
+		}}
+	}
+	scenarios := map[string]struct {
+		old, new *cmapi.IssuerSpec
+		errs     []*field.Error
+	}{
+		"valid cloud zone": {
+			new: venafiCloudSpec("My Application\\Default"),
+		},
+		"cloud zone without an issuing template": {
+			new: venafiCloudSpec("My Application"),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("venafi", "zone"), "My Application", `Venafi Cloud zones must be in the format "<application>\<issuing template alias>"`),
+			},
+		},
+		"cloud zone with an empty application": {
+			new: venafiCloudSpec("\\Default"),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("venafi", "zone"), "\\Default", `Venafi Cloud zones must be in the format "<application>\<issuing template alias>"`),
+			},
+		},
+		"cloud zone with too many segments": {
+			new: venafiCloudSpec("My Application\\Default\\Extra"),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("venafi", "zone"), "My Application\\Default\\Extra", `Venafi Cloud zones must be in the format "<application>\<issuing template alias>"`),
+			},
+		},
+		"tpp zones are not required to contain a backslash": {
+			new: &cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+				Venafi: &cmapi.VenafiIssuer{Zone: "devops", TPP: &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk"}},
+			}},
+		},
+		"invalid authority information access urls": {
+			new: caSpec([]string{"ocsp.example.com"}, []string{"http://ca.example.com/ca.crt", "ldap://ca.example.com", ""}),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "ocspServer").Index(0), "ocsp.example.com", `must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org`),
+				field.Invalid(fldPath.Child("ca", "issuingCertificateURLs").Index(1), "ldap://ca.example.com", "must be a valid http or https URL, e.g., http://ca.example.com/ca.crt"),
+				field.Invalid(fldPath.Child("ca", "issuingCertificateURLs").Index(2), "", "must be a valid http or https URL, e.g., http://ca.example.com/ca.crt"),
+			},
+		},
+		"unchanged authority information access urls are not checked on update": {
+			old: caSpec([]string{"ocsp.example.com"}, []string{"ldap://ca.example.com"}),
+			new: caSpec([]string{"ocsp.example.com"}, []string{"ldap://ca.example.com"}),
+		},
+		"changed authority information access urls are checked on update": {
+			old: caSpec([]string{"http://ocsp.example.com"}, nil),
+			new: caSpec([]string{"ocsp.example.com"}, nil),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "ocspServer").Index(0), "ocsp.example.com", `must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org`),
+			},
+		},
+		"valid crl distribution points": {
+			new: crlSpec("http://crl.example.com/ca.crl", "ldap://ldap.example.com/cn=ca?certificateRevocationList"),
+		},
+		"invalid crl distribution points": {
+			new: crlSpec("http://crl.example.com/ca.crl", "", "crl.example.com/ca.crl", "http://%zz"),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "crlDistributionPoints").Index(1), "", "must be a valid absolute URL, e.g., http://crl.example.com/ca.crl"),
+				field.Invalid(fldPath.Child("ca", "crlDistributionPoints").Index(2), "crl.example.com/ca.crl", "must be a valid absolute URL, e.g., http://crl.example.com/ca.crl"),
+				field.Invalid(fldPath.Child("ca", "crlDistributionPoints").Index(3), "http://%zz", "must be a valid absolute URL, e.g., http://crl.example.com/ca.crl"),
+			},
+		},
+		"unchanged crl distribution points are not checked on update": {
+			old: crlSpec("crl.example.com/ca.crl"),
+			new: crlSpec("crl.example.com/ca.crl"),
+		},
+		"changed crl distribution points are checked on update": {
+			old: crlSpec("http://crl.example.com/ca.crl"),
+			new: crlSpec("crl.example.com/ca.crl"),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "crlDistributionPoints").Index(0), "crl.example.com/ca.crl", "must be a valid absolute URL, e.g., http://crl.example.com/ca.crl"),
+			},
+		},
+		"an unchanged cloud zone is not checked on update": {
+			old: venafiCloudSpec("My Application"),
+			new: venafiCloudSpec("My Application"),
+		},
+		"a changed cloud zone is checked on update": {
+			old: venafiCloudSpec("My Application\\Default"),
+			new: venafiCloudSpec("My Application"),
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("venafi", "zone"), "My Application", `Venafi Cloud zones must be in the format "<application>\<issuing template alias>"`),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateIssuerSpecChanges(s.old, s.new, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateIssuer(t *testing.T) {
 	scenarios := map[string]struct {
 		cfg       *cmapi.Issuer
@@ -21,4 +21,6 @@ package validation
 const (
 	// deprecatedACMEEABKeyAlgorithmField is raised when the deprecated keyAlgorithm field for an ACME issuer's external account binding (EAB) is set.
 	deprecatedACMEEABKeyAlgorithmField = "ACME issuer spec field 'externalAccount.keyAlgorithm' is deprecated. The value of this field will be ignored."
+	// trustBundleNotUsedByAkamaiSolver is raised when an issuer with a trust bundle uses the Akamai DNS01 provider, whose client cannot be configured with custom CA certificates.
+	trustBundleNotUsedByAkamaiSolver = "Issuer spec field 'trustBundle' is not used by the Akamai DNS01 provider. The system CA certificates will be used to connect to Akamai."
 )
@@ -56,6 +56,10 @@ type WebhookConfiguration struct {
 	// Default: nil
 	// +optional
 	FeatureGates map[string]bool
+
+	// keyPolicy sets the weakest private key parameters that Certificates
+	// may request. Certificates requesting weaker keys are rejected.
+	KeyPolicy KeyPolicy
 }
 
 // TLSConfig configures how TLS certificates are sourced for serving.
@@ -122,3 +126,46 @@ type FilesystemServingConfig struct {
 	// Path to a file containing a TLS private key to server with
 	KeyFile string
 }
+
+// KeyPolicy configures the minimum strength of the private keys Certificates
+// may request. A minimum of 0 means that no minimum is enforced beyond the
+// validation of the Certificate resource.
+type KeyPolicy struct {
+	// minRSAKeySize is the minimum size, in bits, of RSA private keys.
+	// Defaults to 0.
+	MinRSAKeySize int
+
+	// minECDSAKeySize is the minimum size, in bits, of the curve of ECDSA
+	// private keys. Must be one of 0, 256, 384 or 521.
+	// Defaults to 0.
+	MinECDSAKeySize int
+
+	// issuerOverrides replace the minimum key sizes above for Certificates
+	// referencing the given issuers.
+	// +optional
+	IssuerOverrides []IssuerKeyPolicy
+}
+
+// IssuerKeyPolicy overrides the KeyPolicy minimums for an issuer.
+// Certificates referencing more than one issuer, for example using
+// fallbackIssuerRefs, must satisfy the policy of every issuer they reference.
+type IssuerKeyPolicy struct {
+	// name of the issuer.
+	Name string
+
+	// kind of the issuer. Defaults to 'Issuer'.
+	// An override for an Issuer applies to Issuers of this name in every
+	// namespace.
+	Kind string
+
+	// group of the issuer. Defaults to 'cert-manager.io'.
+	Group string
+
+	// minRSAKeySize is the minimum size, in bits, of RSA private keys for
+	// Certificates referencing this issuer.
+	MinRSAKeySize int
+
+	// minECDSAKeySize is the minimum size, in bits, of the curve of ECDSA
+	// private keys for Certificates referencing this issuer.
+	MinECDSAKeySize int
+}
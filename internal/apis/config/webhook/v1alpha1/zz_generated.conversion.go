@@ -57,6 +57,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.IssuerKeyPolicy)(nil), (*webhook.IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_IssuerKeyPolicy_To_webhook_IssuerKeyPolicy(a.(*v1alpha1.IssuerKeyPolicy), b.(*webhook.IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*webhook.IssuerKeyPolicy)(nil), (*v1alpha1.IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_webhook_IssuerKeyPolicy_To_v1alpha1_IssuerKeyPolicy(a.(*webhook.IssuerKeyPolicy), b.(*v1alpha1.IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.KeyPolicy)(nil), (*webhook.KeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KeyPolicy_To_webhook_KeyPolicy(a.(*v1alpha1.KeyPolicy), b.(*webhook.KeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*webhook.KeyPolicy)(nil), (*v1alpha1.KeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_webhook_KeyPolicy_To_v1alpha1_KeyPolicy(a.(*webhook.KeyPolicy), b.(*v1alpha1.KeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.TLSConfig)(nil), (*webhook.TLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_TLSConfig_To_webhook_TLSConfig(a.(*v1alpha1.TLSConfig), b.(*webhook.TLSConfig), scope)
 	}); err != nil {
@@ -126,6 +146,58 @@ func Convert_webhook_FilesystemServingConfig_To_v1alpha1_FilesystemServingConfig
 	return autoConvert_webhook_FilesystemServingConfig_To_v1alpha1_FilesystemServingConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_IssuerKeyPolicy_To_webhook_IssuerKeyPolicy(in *v1alpha1.IssuerKeyPolicy, out *webhook.IssuerKeyPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.MinECDSAKeySize = in.MinECDSAKeySize
+	return nil
+}
+
+// Convert_v1alpha1_IssuerKeyPolicy_To_webhook_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_IssuerKeyPolicy_To_webhook_IssuerKeyPolicy(in *v1alpha1.IssuerKeyPolicy, out *webhook.IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_IssuerKeyPolicy_To_webhook_IssuerKeyPolicy(in, out, s)
+}
+
+func autoConvert_webhook_IssuerKeyPolicy_To_v1alpha1_IssuerKeyPolicy(in *webhook.IssuerKeyPolicy, out *v1alpha1.IssuerKeyPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.MinECDSAKeySize = in.MinECDSAKeySize
+	return nil
+}
+
+// Convert_webhook_IssuerKeyPolicy_To_v1alpha1_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_webhook_IssuerKeyPolicy_To_v1alpha1_IssuerKeyPolicy(in *webhook.IssuerKeyPolicy, out *v1alpha1.IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_webhook_IssuerKeyPolicy_To_v1alpha1_IssuerKeyPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_KeyPolicy_To_webhook_KeyPolicy(in *v1alpha1.KeyPolicy, out *webhook.KeyPolicy, s conversion.Scope) error {
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.MinECDSAKeySize = in.MinECDSAKeySize
+	out.IssuerOverrides = *(*[]webhook.IssuerKeyPolicy)(unsafe.Pointer(&in.IssuerOverrides))
+	return nil
+}
+
+// Convert_v1alpha1_KeyPolicy_To_webhook_KeyPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_KeyPolicy_To_webhook_KeyPolicy(in *v1alpha1.KeyPolicy, out *webhook.KeyPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KeyPolicy_To_webhook_KeyPolicy(in, out, s)
+}
+
+func autoConvert_webhook_KeyPolicy_To_v1alpha1_KeyPolicy(in *webhook.KeyPolicy, out *v1alpha1.KeyPolicy, s conversion.Scope) error {
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.MinECDSAKeySize = in.MinECDSAKeySize
+	out.IssuerOverrides = *(*[]v1alpha1.IssuerKeyPolicy)(unsafe.Pointer(&in.IssuerOverrides))
+	return nil
+}
+
+// Convert_webhook_KeyPolicy_To_v1alpha1_KeyPolicy is an autogenerated conversion function.
+func Convert_webhook_KeyPolicy_To_v1alpha1_KeyPolicy(in *webhook.KeyPolicy, out *v1alpha1.KeyPolicy, s conversion.Scope) error {
+	return autoConvert_webhook_KeyPolicy_To_v1alpha1_KeyPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha1_TLSConfig_To_webhook_TLSConfig(in *v1alpha1.TLSConfig, out *webhook.TLSConfig, s conversion.Scope) error {
 	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
 	out.MinTLSVersion = in.MinTLSVersion
@@ -171,6 +243,9 @@ func autoConvert_v1alpha1_WebhookConfiguration_To_webhook_WebhookConfiguration(i
 	out.EnablePprof = in.EnablePprof
 	out.PprofAddress = in.PprofAddress
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	if err := Convert_v1alpha1_KeyPolicy_To_webhook_KeyPolicy(&in.KeyPolicy, &out.KeyPolicy, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -190,6 +265,9 @@ func autoConvert_webhook_WebhookConfiguration_To_v1alpha1_WebhookConfiguration(i
 	out.EnablePprof = in.EnablePprof
 	out.PprofAddress = in.PprofAddress
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	if err := Convert_webhook_KeyPolicy_To_v1alpha1_KeyPolicy(&in.KeyPolicy, &out.KeyPolicy, s); err != nil {
+		return err
+	}
 	return nil
 }
 
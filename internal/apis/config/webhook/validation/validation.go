@@ -54,5 +54,32 @@ func ValidateWebhookConfiguration(cfg *config.WebhookConfiguration) error {
 	if cfg.SecurePort == nil {
 		allErrors = append(allErrors, fmt.Errorf("invalid configuration: securePort must be specified"))
 	}
+	allErrors = append(allErrors, validateKeyPolicy(cfg.KeyPolicy)...)
 	return utilerrors.NewAggregate(allErrors)
 }
+
+func validateKeyPolicy(policy config.KeyPolicy) []error {
+	var allErrors []error
+	allErrors = append(allErrors, validateMinKeySizes("keyPolicy", policy.MinRSAKeySize, policy.MinECDSAKeySize)...)
+	for i, override := range policy.IssuerOverrides {
+		path := fmt.Sprintf("keyPolicy.issuerOverrides[%d]", i)
+		if override.Name == "" {
+			allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.name must be specified", path))
+		}
+		allErrors = append(allErrors, validateMinKeySizes(path, override.MinRSAKeySize, override.MinECDSAKeySize)...)
+	}
+	return allErrors
+}
+
+func validateMinKeySizes(path string, minRSAKeySize, minECDSAKeySize int) []error {
+	var allErrors []error
+	if minRSAKeySize < 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.minRSAKeySize must not be negative", path))
+	}
+	switch minECDSAKeySize {
+	case 0, 256, 384, 521:
+	default:
+		allErrors = append(allErrors, fmt.Errorf("invalid configuration: %s.minECDSAKeySize must be one of 0, 256, 384 or 521", path))
+	}
+	return allErrors
+}
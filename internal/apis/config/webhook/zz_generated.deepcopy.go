@@ -62,6 +62,43 @@ func (in *FilesystemServingConfig) DeepCopy() *FilesystemServingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerKeyPolicy) DeepCopyInto(out *IssuerKeyPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerKeyPolicy.
+func (in *IssuerKeyPolicy) DeepCopy() *IssuerKeyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerKeyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyPolicy) DeepCopyInto(out *KeyPolicy) {
+	*out = *in
+	if in.IssuerOverrides != nil {
+		in, out := &in.IssuerOverrides, &out.IssuerOverrides
+		*out = make([]IssuerKeyPolicy, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyPolicy.
+func (in *KeyPolicy) DeepCopy() *KeyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -107,6 +144,7 @@ func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
 			(*out)[key] = val
 		}
 	}
+	in.KeyPolicy.DeepCopyInto(&out.KeyPolicy)
 	return
 }
 
@@ -24,7 +24,6 @@ import (
 	"strings"
 	"time"
 
-	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -39,6 +38,12 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
+// secretKeys returns the keys of the Certificate's Secret which its
+// certificate, private key and CA certificate are stored in.
+func secretKeys(input Input) internalcertificates.SecretKeys {
+	return internalcertificates.SecretKeysForCertificate(input.Certificate)
+}
+
 func SecretDoesNotExist(input Input) (string, string, bool) {
 	if input.Secret == nil {
 		return DoesNotExist, "Issuing certificate as Secret does not exist", true
@@ -50,9 +55,9 @@ func SecretIsMissingData(input Input) (string, string, bool) {
 	if input.Secret.Data == nil {
 		return MissingData, "Issuing certificate as Secret does not contain any data", true
 	}
-	pkData := input.Secret.Data[corev1.TLSPrivateKeyKey]
-	certData := input.Secret.Data[corev1.TLSCertKey]
-	if len(pkData) == 0 {
+	pkData := input.Secret.Data[secretKeys(input).PrivateKey]
+	certData := input.Secret.Data[secretKeys(input).Certificate]
+	if len(pkData) == 0 && !privateKeyIsUnmanaged(input) {
 		return MissingData, "Issuing certificate as Secret does not contain a private key", true
 	}
 	if len(certData) == 0 {
@@ -62,8 +67,12 @@ func SecretIsMissingData(input Input) (string, string, bool) {
 }
 
 func SecretPublicKeysDiffer(input Input) (string, string, bool) {
-	pkData := input.Secret.Data[corev1.TLSPrivateKeyKey]
-	certData := input.Secret.Data[corev1.TLSCertKey]
+	if privateKeyIsUnmanaged(input) {
+		return secretPublicKeyDiffersFromRequest(input)
+	}
+
+	pkData := input.Secret.Data[secretKeys(input).PrivateKey]
+	certData := input.Secret.Data[secretKeys(input).Certificate]
 	// TODO: replace this with a generic decoder that can handle different
 	//  formats such as JKS, P12 etc (i.e. add proper support for keystores)
 	_, err := tls.X509KeyPair(certData, pkData)
@@ -73,12 +82,42 @@ func SecretPublicKeysDiffer(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// secretPublicKeyDiffersFromRequest is used in place of SecretPublicKeysDiffer
+// for Certificates whose private key is not managed by cert-manager. As no
+// private key is stored, the public key of the certificate is instead compared
+// with the CSR of the CertificateRequest that issued it, if still available.
+func secretPublicKeyDiffersFromRequest(input Input) (string, string, bool) {
+	cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[secretKeys(input).Certificate])
+	if err != nil {
+		return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+	}
+	if input.CurrentRevisionRequest == nil {
+		return "", "", false
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(input.CurrentRevisionRequest.Spec.Request)
+	if err != nil {
+		// An invalid request is handled by CurrentCertificateRequestNotValidForSpec.
+		return "", "", false
+	}
+	matches, err := pki.PublicKeyMatchesCSR(cert.PublicKey, csr)
+	if err != nil || !matches {
+		return InvalidKeyPair, "Issuing certificate as Secret contains a certificate which does not match the public key of the certificate signing request", true
+	}
+	return "", "", false
+}
+
 func SecretPrivateKeyMatchesSpec(input Input) (string, string, bool) {
-	if input.Secret.Data == nil || len(input.Secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+	if privateKeyIsUnmanaged(input) {
+		// There is no stored private key to compare with the spec.
+		return "", "", false
+	}
+
+	if input.Secret.Data == nil || len(input.Secret.Data[secretKeys(input).PrivateKey]) == 0 {
 		return SecretMismatch, "Existing issued Secret does not contain private key data", true
 	}
 
-	pkBytes := input.Secret.Data[corev1.TLSPrivateKeyKey]
+	pkBytes := input.Secret.Data[secretKeys(input).PrivateKey]
 	pk, err := pki.DecodePrivateKeyBytes(pkBytes)
 	if err != nil {
 		return SecretMismatch, fmt.Sprintf("Existing issued Secret contains invalid private key data: %v", err), true
@@ -94,10 +133,23 @@ func SecretPrivateKeyMatchesSpec(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// privateKeyIsUnmanaged returns true if the input Certificate does not have
+// its private key generated and stored by cert-manager.
+func privateKeyIsUnmanaged(input Input) bool {
+	return input.Certificate != nil && certificates.PrivateKeyIsUnmanaged(input.Certificate)
+}
+
+// SecretIssuerAnnotationsNotUpToDate will check whether the issuer
+// annotations on the Secret match the Certificate's issuerRef. Missing
+// annotations are treated as a mismatch, since it is then not known which
+// issuer the certificate in the Secret came from.
 func SecretIssuerAnnotationsNotUpToDate(input Input) (string, string, bool) {
 	name := input.Secret.Annotations[cmapi.IssuerNameAnnotationKey]
 	kind := input.Secret.Annotations[cmapi.IssuerKindAnnotationKey]
 	group := input.Secret.Annotations[cmapi.IssuerGroupAnnotationKey]
+	if name == "" {
+		return IncorrectIssuer, "Issuing certificate as Secret does not record the issuer it was issued by", true
+	}
 	if name != input.Certificate.Spec.IssuerRef.Name ||
 		!issuerKindsEqual(kind, input.Certificate.Spec.IssuerRef.Kind) ||
 		!issuerGroupsEqual(group, input.Certificate.Spec.IssuerRef.Group) {
@@ -106,6 +158,29 @@ func SecretIssuerAnnotationsNotUpToDate(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// SecretIssuerCANotUpToDate triggers reissuance if the CA certificate stored
+// in the Secret is not the one which the Certificate's issuer currently issues
+// certificates with, as happens after the issuer's CA has been rotated.
+// Secrets which do not store a CA certificate, and Certificates whose issuer's
+// CA is not known, are never reissued by this check.
+func SecretIssuerCANotUpToDate(input Input) (string, string, bool) {
+	if input.IssuerCA == nil {
+		return "", "", false
+	}
+	caData := input.Secret.Data[secretKeys(input).CA]
+	if len(caData) == 0 {
+		return "", "", false
+	}
+	ca, err := pki.DecodeX509CertificateBytes(caData)
+	if err != nil {
+		return IssuerCAChanged, fmt.Sprintf("Issuing certificate as Secret contains an invalid CA certificate: %v", err), true
+	}
+	if !ca.Equal(input.IssuerCA) {
+		return IssuerCAChanged, "Issuing certificate as the CA certificate of its issuer has changed", true
+	}
+	return "", "", false
+}
+
 func CurrentCertificateRequestNotValidForSpec(input Input) (string, string, bool) {
 	if input.CurrentRevisionRequest == nil {
 		// Fallback to comparing the Certificate spec with the issued certificate.
@@ -152,7 +227,7 @@ func currentSecretValidForSpec(input Input) (string, string, bool) {
 // CurrentCertificateNearingExpiry returns a policy function that can be used to
 // check whether an X.509 cert currently issued for a Certificate should be
 // renewed.
-func CurrentCertificateNearingExpiry(c clock.Clock) Func {
+func CurrentCertificateNearingExpiry(c clock.Clock, renewalJitterPercent int) Func {
 
 	return func(input Input) (string, string, bool) {
 
@@ -160,7 +235,7 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 		// the actual cert, if it exists. We assume that at this point we have
 		// called policy functions that check that input.Secret and
 		// input.Secret.Data exists (SecretDoesNotExist and SecretIsMissingData).
-		x509cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+		x509cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[secretKeys(input).Certificate])
 		if err != nil {
 			// This case should never happen as it should always be caught by the
 			// secretPublicKeysMatch function beforehand, but handle it just in case.
@@ -170,7 +245,7 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 		notBefore := metav1.NewTime(x509cert.NotBefore)
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		crt := input.Certificate
-		renewalTime := certificates.RenewalTime(notBefore.Time, notAfter.Time, crt.Spec.RenewBefore)
+		renewalTime := certificates.RenewalTimeWithJitter(crt, notBefore.Time, notAfter.Time, renewalJitterPercent)
 
 		renewIn := renewalTime.Time.Sub(c.Now())
 		if renewIn > 0 {
@@ -182,11 +257,48 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 	}
 }
 
+// RenewAtAnnotationPassed returns a policy function that triggers a
+// re-issuance once the time in the Certificate's renew-at annotation has
+// passed. The annotation is ignored once the current certificate has been
+// requested after that time, so that each timestamp triggers at most one
+// re-issuance.
+func RenewAtAnnotationPassed(c clock.Clock) Func {
+	return func(input Input) (string, string, bool) {
+		renewAt, err := certificates.RenewAtTime(input.Certificate)
+		if err != nil || renewAt == nil {
+			// Invalid timestamps are rejected by the webhook, so there is
+			// nothing sensible to do here other than ignore them.
+			return "", "", false
+		}
+		if renewAt.After(c.Now()) {
+			return "", "", false
+		}
+
+		// Prefer the creation time of the request which issued the current
+		// certificate, as issuers may backdate the certificate's NotBefore.
+		var requestedAt time.Time
+		if input.CurrentRevisionRequest != nil {
+			requestedAt = input.CurrentRevisionRequest.CreationTimestamp.Time
+		} else {
+			x509cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[secretKeys(input).Certificate])
+			if err != nil {
+				return InvalidCertificate, fmt.Sprintf("Failed to decode stored certificate: %v", err), true
+			}
+			requestedAt = x509cert.NotBefore
+		}
+		if !requestedAt.Before(*renewAt) {
+			return "", "", false
+		}
+
+		return RenewalRequested, fmt.Sprintf("Renewing certificate as renewal was requested at %s", renewAt.UTC().Format(time.RFC3339)), true
+	}
+}
+
 // CurrentCertificateHasExpired is used exclusively to check if the current
 // issued certificate has actually expired rather than just nearing expiry.
 func CurrentCertificateHasExpired(c clock.Clock) Func {
 	return func(input Input) (string, string, bool) {
-		certData, ok := input.Secret.Data[corev1.TLSCertKey]
+		certData, ok := input.Secret.Data[secretKeys(input).Certificate]
 		if !ok {
 			return MissingData, "Missing Certificate data", true
 		}
@@ -206,6 +318,34 @@ func CurrentCertificateHasExpired(c clock.Clock) Func {
 	}
 }
 
+// CurrentCertificateValidityTooShort is used to check if less time remains
+// before the current issued certificate expires than the Certificate's
+// spec.minRemainingValidity.
+func CurrentCertificateValidityTooShort(c clock.Clock) Func {
+	return func(input Input) (string, string, bool) {
+		minRemainingValidity := input.Certificate.Spec.MinRemainingValidity
+		if minRemainingValidity == nil {
+			return "", "", false
+		}
+		certData, ok := input.Secret.Data[secretKeys(input).Certificate]
+		if !ok {
+			return MissingData, "Missing Certificate data", true
+		}
+		cert, err := pki.DecodeX509CertificateBytes(certData)
+		if err != nil {
+			// In the readiness policy chain, CurrentCertificateHasExpired
+			// has already reported a certificate which cannot be decoded.
+			return InvalidCertificate, fmt.Sprintf("Failed to decode stored certificate: %v", err), true
+		}
+
+		if cert.NotAfter.Sub(c.Now()) < minRemainingValidity.Duration {
+			return InsufficientValidity, fmt.Sprintf("Certificate expires on %s, which is sooner than its minimum remaining validity of %s",
+				cert.NotAfter.Format(time.RFC1123), minRemainingValidity.Duration), true
+		}
+		return "", "", false
+	}
+}
+
 func formatIssuerRef(name, kind, group string) string {
 	if group == "" {
 		group = "cert-manager.io"
@@ -277,9 +417,9 @@ func SecretTemplateMismatchesSecretManagedFields(fieldManager string) Func {
 	return func(input Input) (string, string, bool) {
 		// Only attempt to decode the signed certificate, if one is available.
 		var x509cert *x509.Certificate
-		if len(input.Secret.Data[corev1.TLSCertKey]) > 0 {
+		if len(input.Secret.Data[secretKeys(input).Certificate]) > 0 {
 			var err error
-			x509cert, err = pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+			x509cert, err = pki.DecodeX509CertificateBytes(input.Secret.Data[secretKeys(input).Certificate])
 			if err != nil {
 				// This case should never happen as it should always be caught by the
 				// secretPublicKeysMatch function beforehand, but handle it just in case.
@@ -384,15 +524,28 @@ func SecretAdditionalOutputFormatsDataMismatch(input Input) (string, string, boo
 		case cmapi.CertificateOutputFormatCombinedPEM:
 			v, ok := input.Secret.Data[cmapi.CertificateOutputFormatCombinedPEMKey]
 			if !ok || !bytes.Equal(v, internalcertificates.OutputFormatCombinedPEM(
-				input.Secret.Data[corev1.TLSPrivateKeyKey],
-				input.Secret.Data[corev1.TLSCertKey],
+				input.Secret.Data[secretKeys(input).PrivateKey],
+				input.Secret.Data[secretKeys(input).Certificate],
 			)) {
 				return AdditionalOutputFormatsMismatch, message, true
 			}
 
 		case cmapi.CertificateOutputFormatDER:
 			v, ok := input.Secret.Data[cmapi.CertificateOutputFormatDERKey]
-			if !ok || !bytes.Equal(v, internalcertificates.OutputFormatDER(input.Secret.Data[corev1.TLSPrivateKeyKey])) {
+			if !ok || !bytes.Equal(v, internalcertificates.OutputFormatDER(input.Secret.Data[secretKeys(input).PrivateKey])) {
+				return AdditionalOutputFormatsMismatch, message, true
+			}
+
+		case cmapi.CertificateOutputFormatAzureKeyVaultPEM:
+			v, ok := input.Secret.Data[internalcertificates.OutputFormatSecretKey(format)]
+			if !ok {
+				return AdditionalOutputFormatsMismatch, message, true
+			}
+			bundle, err := internalcertificates.OutputFormatAzureKeyVaultPEM(
+				input.Secret.Data[secretKeys(input).PrivateKey],
+				input.Secret.Data[secretKeys(input).Certificate],
+			)
+			if err != nil || !bytes.Equal(v, bundle) {
 				return AdditionalOutputFormatsMismatch, message, true
 			}
 		}
@@ -411,24 +564,27 @@ func SecretAdditionalOutputFormatsDataMismatch(input Input) (string, string, boo
 func SecretAdditionalOutputFormatsOwnerMismatch(fieldManager string) Func {
 	const message = "Certificate's AdditionalOutputFormats doesn't match Secret ManagedFields"
 	return func(input Input) (string, string, bool) {
-		var (
-			crtHasCombinedPEM, crtHasDER       bool
-			secretHasCombinedPEM, secretHasDER bool
-		)
-
-		// Gather which additional output formats have been defined on the
-		// Certificate.
+		// Gather the Secret keys of the additional output formats which have
+		// been defined on the Certificate.
+		crtKeys := sets.NewString()
 		for _, format := range input.Certificate.Spec.AdditionalOutputFormats {
-			switch format.Type {
-			case cmapi.CertificateOutputFormatCombinedPEM:
-				crtHasCombinedPEM = true
-			case cmapi.CertificateOutputFormatDER:
-				crtHasDER = true
+			if key := internalcertificates.OutputFormatSecretKey(format); len(key) > 0 {
+				crtKeys.Insert(key)
 			}
 		}
 
-		// Determine whether an output format key exists on the Secret which is
-		// owned my the field manager.
+		// The keys which may hold an output format. An Azure Key Vault PEM
+		// bundle written to a custom key which is no longer configured cannot be
+		// detected, and is removed the next time the Secret is applied.
+		candidateKeys := crtKeys.Union(sets.NewString(
+			cmapi.CertificateOutputFormatDERKey,
+			cmapi.CertificateOutputFormatCombinedPEMKey,
+			cmapi.CertificateOutputFormatAzureKeyVaultPEMKey,
+		))
+
+		// Determine which output format keys exist on the Secret and are owned
+		// by the field manager.
+		ownedKeys := sets.NewString()
 		for _, managedField := range input.Secret.ManagedFields {
 			if managedField.Manager != fieldManager || managedField.FieldsV1 == nil {
 				continue
@@ -439,24 +595,19 @@ func SecretAdditionalOutputFormatsOwnerMismatch(fieldManager string) Func {
 				return ManagedFieldsParseError, fmt.Sprintf("failed to decode managed fields on Secret: %s", err), true
 			}
 
-			if fieldset.Has(fieldpath.Path{
-				{FieldName: pointer.String("data")},
-				{FieldName: pointer.String(cmapi.CertificateOutputFormatCombinedPEMKey)},
-			}) {
-				secretHasCombinedPEM = true
-			}
-
-			if fieldset.Has(fieldpath.Path{
-				{FieldName: pointer.String("data")},
-				{FieldName: pointer.String(cmapi.CertificateOutputFormatDERKey)},
-			}) {
-				secretHasDER = true
+			for _, key := range candidateKeys.List() {
+				if fieldset.Has(fieldpath.Path{
+					{FieldName: pointer.String("data")},
+					{FieldName: pointer.String(key)},
+				}) {
+					ownedKeys.Insert(key)
+				}
 			}
 		}
 
 		// Format present or missing on the Certificate should be reflected on the
 		// Secret.
-		if crtHasCombinedPEM != secretHasCombinedPEM || crtHasDER != secretHasDER {
+		if !crtKeys.Equal(ownedKeys) {
 			return AdditionalOutputFormatsMismatch, message, true
 		}
 
@@ -496,9 +647,9 @@ func SecretOwnerReferenceManagedFieldMismatch(ownerRefEnabled bool, fieldManager
 
 		// The presence of the Certificate owner reference should match owner
 		// reference being enabled.
-		if ownerRefEnabled != hasOwnerRefManagedField {
+		if enabled := internalcertificates.SecretOwnerReferenceEnabled(input.Certificate, ownerRefEnabled); enabled != hasOwnerRefManagedField {
 			return SecretOwnerRefMismatch,
-				fmt.Sprintf("unexpected managed Secret Owner Reference field on Secret --enable-certificate-owner-ref=%t", ownerRefEnabled), true
+				fmt.Sprintf("unexpected managed Secret Owner Reference field on Secret %s", ownerRefSetting(input.Certificate, ownerRefEnabled)), true
 		}
 
 		return "", "", false
@@ -512,7 +663,7 @@ func SecretOwnerReferenceValueMismatch(ownerRefEnabled bool) Func {
 	return func(input Input) (string, string, bool) {
 		// If the Owner Reference is not enabled, we don't need to check the value
 		// and can exit early.
-		if !ownerRefEnabled {
+		if !internalcertificates.SecretOwnerReferenceEnabled(input.Certificate, ownerRefEnabled) {
 			return "", "", false
 		}
 
@@ -537,9 +688,18 @@ func SecretOwnerReferenceValueMismatch(ownerRefEnabled bool) Func {
 		// doesn't match the expected value, return violation.
 		if !hasOwnerRefMatchingCertificate {
 			return SecretOwnerRefMismatch,
-				fmt.Sprintf("unexpected Secret Owner Reference value on Secret --enable-certificate-owner-ref=%t", ownerRefEnabled), true
+				fmt.Sprintf("unexpected Secret Owner Reference value on Secret %s", ownerRefSetting(input.Certificate, ownerRefEnabled)), true
 		}
 
 		return "", "", false
 	}
 }
+
+// ownerRefSetting describes the setting which determines whether the
+// Certificate should own its Secret, for use in messages.
+func ownerRefSetting(crt *cmapi.Certificate, ownerRefEnabled bool) string {
+	if value, ok := crt.Annotations[cmapi.SecretOwnerReferenceAnnotation]; ok && (value == "true" || value == "false") {
+		return fmt.Sprintf("%s=%s", cmapi.SecretOwnerReferenceAnnotation, value)
+	}
+	return fmt.Sprintf("--enable-certificate-owner-ref=%t", ownerRefEnabled)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package policies
 
 import (
+	"crypto/x509"
 	"encoding/pem"
 	"testing"
 	"time"
@@ -27,8 +28,10 @@ import (
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
@@ -42,11 +45,33 @@ import (
 func Test_NewTriggerPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
 	staticFixedPrivateKey := testcrypto.MustCreatePEMPrivateKey(t)
+	unmanagedCertificate := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		CommonName: "example.com",
+		IssuerRef:  cmmeta.ObjectReference{Name: "testissuer", Kind: "IssuerKind", Group: "group.example.com"},
+		PrivateKey: &cmapi.CertificatePrivateKey{Managed: pointer.Bool(false)},
+	}}
+	unmanagedSecretAnnotations := map[string]string{
+		cmapi.IssuerNameAnnotationKey:  "testissuer",
+		cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+		cmapi.IssuerGroupAnnotationKey: "group.example.com",
+	}
+	unmanagedRequest := &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+		IssuerRef: unmanagedCertificate.Spec.IssuerRef,
+		Request:   testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, unmanagedCertificate),
+	}}
+	issuedCAPEM := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "ca", IsCA: true}})
+	rotatedCA, err := pki.DecodeX509CertificateBytes(testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "rotated-ca", IsCA: true}}))
+	if err != nil {
+		t.Fatal(err)
+	}
 	tests := map[string]struct {
 		// policy inputs
 		certificate *cmapi.Certificate
 		request     *cmapi.CertificateRequest
 		secret      *corev1.Secret
+		issuerCA    *x509.Certificate
 
 		// expected outputs
 		reason, message string
@@ -83,6 +108,22 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Issuing certificate as Secret does not contain a certificate",
 			reissue: true,
 		},
+		"trigger issuance as Secret is missing the certificate at its configured key": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				SecretType: cmapi.CertificateSecretTypeOpaque,
+				SecretKeys: &cmapi.CertificateSecretKeys{Certificate: "cert.pem"},
+			}},
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: []byte("test"),
+					corev1.TLSCertKey:       []byte("test"),
+				},
+			},
+			reason:  MissingData,
+			message: "Issuing certificate as Secret does not contain a certificate",
+			reissue: true,
+		},
 		"trigger issuance as Secret contains corrupt private key and certificate data": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}},
 			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
@@ -159,6 +200,52 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Issuing certificate as Secret was previously issued by Issuer.cert-manager.io/oldissuer",
 			reissue: true,
 		},
+		"trigger issuance as Secret is missing the issuer annotations": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				IssuerRef: cmmeta.ObjectReference{
+					Name: "testissuer",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+			reason:  IncorrectIssuer,
+			message: "Issuing certificate as Secret does not record the issuer it was issued by",
+			reissue: true,
+		},
+		"trigger issuance as the CA of the issuer has been rotated since the Secret was issued": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				IssuerRef: cmmeta.ObjectReference{
+					Name: "testissuer",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey: "testissuer",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+					cmmeta.TLSCAKey: issuedCAPEM,
+				},
+			},
+			issuerCA: rotatedCA,
+			reason:   IssuerCAChanged,
+			message:  "Issuing certificate as the CA certificate of its issuer has changed",
+			reissue:  true,
+		},
 		"trigger issuance as Secret has old/incorrect 'issuer kind' annotation": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				SecretName: "something",
@@ -256,6 +343,44 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Fields on existing CertificateRequest resource not up to date: [spec.commonName]",
 			reissue: true,
 		},
+		"trigger issuance when CertificateRequest emailAddresses do not match certificate spec": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				EmailAddresses: []string{"alice@example.com", "bob@example.com"},
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{EmailAddresses: []string{"alice@example.com"}}},
+					),
+				},
+			},
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					EmailAddresses: []string{"alice@example.com"},
+				}}),
+			}},
+			reason:  RequestChanged,
+			message: "Fields on existing CertificateRequest resource not up to date: [spec.emailAddresses]",
+			reissue: true,
+		},
 		"do nothing if CertificateRequest matches spec": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				CommonName: "example.com",
@@ -489,14 +614,73 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				},
 			},
 		},
+		"do nothing if the renew-at time has passed but there is no current request and the certificate was issued since": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					cmapi.RenewAtAnnotation: clock.Now().Add(-time.Minute).Format(time.RFC3339),
+				}},
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef:  cmmeta.ObjectReference{Name: "testissuer", Kind: "IssuerKind", Group: "group.example.com"},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something", Annotations: unmanagedSecretAnnotations},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					// A freshly issued certificate, whose NotBefore time is
+					// the only indication of when it was issued.
+					corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+						clock.Now(), clock.Now().Add(time.Hour*24*90),
+					),
+				},
+			},
+		},
+		"do nothing if private key is not managed and Secret contains a certificate matching the CSR": {
+			certificate: unmanagedCertificate,
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something", Annotations: unmanagedSecretAnnotations},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: nil,
+					corev1.TLSCertKey:       testcrypto.MustCreateCert(t, staticFixedPrivateKey, unmanagedCertificate),
+				},
+			},
+			request: unmanagedRequest,
+		},
+		"trigger issuance if private key is not managed and Secret is missing certificate": {
+			certificate: unmanagedCertificate,
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something", Annotations: unmanagedSecretAnnotations},
+				Data:       map[string][]byte{corev1.TLSPrivateKeyKey: nil},
+			},
+			request: unmanagedRequest,
+			reason:  MissingData,
+			message: "Issuing certificate as Secret does not contain a certificate",
+			reissue: true,
+		},
+		"trigger issuance if private key is not managed and certificate does not match the CSR public key": {
+			certificate: unmanagedCertificate,
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something", Annotations: unmanagedSecretAnnotations},
+				Data: map[string][]byte{
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t), unmanagedCertificate),
+				},
+			},
+			request: unmanagedRequest,
+			reason:  InvalidKeyPair,
+			message: "Issuing certificate as Secret contains a certificate which does not match the public key of the certificate signing request",
+			reissue: true,
+		},
 	}
-	policyChain := NewTriggerPolicyChain(clock)
+	policyChain := NewTriggerPolicyChain(clock, 0)
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			reason, message, reissue := policyChain.Evaluate(Input{
 				Certificate:            test.certificate,
 				CurrentRevisionRequest: test.request,
 				Secret:                 test.secret,
+				IssuerCA:               test.issuerCA,
 			})
 
 			if test.reason != reason {
@@ -512,6 +696,191 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 	}
 }
 
+// Test_NewTriggerPolicyChain_NonMaterialChanges ensures that changes to fields
+// which do not affect the issued certificate or its private key never cause
+// a Certificate to be reissued.
+func Test_NewTriggerPolicyChain_NonMaterialChanges(t *testing.T) {
+	clock := &fakeclock.FakeClock{}
+	staticFixedPrivateKey := testcrypto.MustCreatePEMPrivateKey(t)
+	baseCertificate := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		CommonName: "example.com",
+		SecretName: "something",
+		IssuerRef:  cmmeta.ObjectReference{Name: "testissuer", Kind: "Issuer", Group: "cert-manager.io"},
+	}}
+	baseSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "something",
+			Annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "testissuer",
+				cmapi.IssuerKindAnnotationKey:  "Issuer",
+				cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+			corev1.TLSCertKey:       testcrypto.MustCreateCert(t, staticFixedPrivateKey, baseCertificate),
+		},
+	}
+	baseRequest := &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+		IssuerRef: baseCertificate.Spec.IssuerRef,
+		Request:   testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, baseCertificate),
+	}}
+
+	tests := map[string]struct {
+		mutateCertificate func(*cmapi.Certificate)
+		mutateSecret      func(*corev1.Secret)
+	}{
+		"no changes": {},
+		"Certificate labels and annotations changed": {
+			mutateCertificate: func(crt *cmapi.Certificate) {
+				crt.Labels = map[string]string{"app.kubernetes.io/managed-by": "gitops"}
+				crt.Annotations = map[string]string{"gitops.example.com/sync-wave": "1"}
+			},
+		},
+		"secretTemplate set": {
+			mutateCertificate: func(crt *cmapi.Certificate) {
+				crt.Spec.SecretTemplate = &cmapi.CertificateSecretTemplate{
+					Annotations: map[string]string{"foo": "bar"},
+					Labels:      map[string]string{"abc": "123"},
+				}
+			},
+		},
+		"additionalOutputFormats set": {
+			mutateCertificate: func(crt *cmapi.Certificate) {
+				crt.Spec.AdditionalOutputFormats = []cmapi.CertificateAdditionalOutputFormat{{Type: "CombinedPEM"}}
+			},
+		},
+		"revisionHistoryLimit set": {
+			mutateCertificate: func(crt *cmapi.Certificate) {
+				crt.Spec.RevisionHistoryLimit = pointer.Int32(2)
+			},
+		},
+		"issuerRef kind and group unset but defaulted to the same values": {
+			mutateCertificate: func(crt *cmapi.Certificate) {
+				crt.Spec.IssuerRef.Kind = ""
+				crt.Spec.IssuerRef.Group = ""
+			},
+		},
+		"issued by a fallback issuer": {
+			mutateCertificate: func(crt *cmapi.Certificate) {
+				crt.Spec.FallbackIssuerRefs = []cmmeta.ObjectReference{crt.Spec.IssuerRef}
+				crt.Spec.IssuerRef = cmmeta.ObjectReference{Name: "primary", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+			},
+			mutateSecret: func(secret *corev1.Secret) {
+				secret.Annotations[cmapi.IssuerNameAnnotationKey] = "primary"
+				secret.Annotations[cmapi.IssuerKindAnnotationKey] = "ClusterIssuer"
+			},
+		},
+		"extra labels and annotations added to the Secret": {
+			mutateSecret: func(secret *corev1.Secret) {
+				secret.Labels = map[string]string{"app.kubernetes.io/managed-by": "gitops"}
+				secret.Annotations["gitops.example.com/sync-wave"] = "1"
+			},
+		},
+	}
+	policyChain := NewTriggerPolicyChain(clock, 0)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := baseCertificate.DeepCopy()
+			if test.mutateCertificate != nil {
+				test.mutateCertificate(crt)
+			}
+			secret := baseSecret.DeepCopy()
+			if test.mutateSecret != nil {
+				test.mutateSecret(secret)
+			}
+
+			reason, message, reissue := policyChain.Evaluate(Input{
+				Certificate:            crt,
+				CurrentRevisionRequest: baseRequest,
+				Secret:                 secret,
+			})
+			if reissue {
+				t.Errorf("unexpected reissue with reason=%s, message=%s", reason, message)
+			}
+		})
+	}
+}
+
+func Test_SecretIssuerCANotUpToDate(t *testing.T) {
+	caPEM := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "ca", IsCA: true}})
+	ca, err := pki.DecodeX509CertificateBytes(caPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotatedCA, err := pki.DecodeX509CertificateBytes(testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "rotated-ca", IsCA: true}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		secretData  map[string][]byte
+		issuerCA    *x509.Certificate
+
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"no violation if the CA of the issuer is not known": {
+			secretData: map[string][]byte{cmmeta.TLSCAKey: caPEM},
+		},
+		"no violation if the Secret does not store a CA certificate": {
+			secretData: map[string][]byte{},
+			issuerCA:   rotatedCA,
+		},
+		"no violation if the Secret stores the CA of the issuer": {
+			secretData: map[string][]byte{cmmeta.TLSCAKey: caPEM},
+			issuerCA:   ca,
+		},
+		"violation if the Secret stores another CA than the CA of the issuer": {
+			secretData:   map[string][]byte{cmmeta.TLSCAKey: caPEM},
+			issuerCA:     rotatedCA,
+			expReason:    IssuerCAChanged,
+			expMessage:   "Issuing certificate as the CA certificate of its issuer has changed",
+			expViolation: true,
+		},
+		"violation if the Secret stores an invalid CA certificate": {
+			secretData:   map[string][]byte{cmmeta.TLSCAKey: []byte("invalid")},
+			issuerCA:     rotatedCA,
+			expReason:    IssuerCAChanged,
+			expMessage:   "Issuing certificate as Secret contains an invalid CA certificate: error decoding certificate PEM block",
+			expViolation: true,
+		},
+		"uses the CA certificate key configured on the Certificate": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				SecretKeys: &cmapi.CertificateSecretKeys{CA: "root.pem"},
+			}},
+			secretData: map[string][]byte{
+				cmmeta.TLSCAKey: caPEM,
+				"root.pem":      caPEM,
+			},
+			issuerCA:     rotatedCA,
+			expReason:    IssuerCAChanged,
+			expMessage:   "Issuing certificate as the CA certificate of its issuer has changed",
+			expViolation: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := test.certificate
+			if crt == nil {
+				crt = &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}}
+			}
+			gotReason, gotMessage, gotViolation := SecretIssuerCANotUpToDate(Input{
+				Certificate: crt,
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"}, Data: test.secretData},
+				IssuerCA:    test.issuerCA,
+			})
+			assert.Equal(t, test.expReason, gotReason, "unexpected reason")
+			assert.Equal(t, test.expMessage, gotMessage, "unexpected message")
+			assert.Equal(t, test.expViolation, gotViolation, "unexpected violation")
+		})
+	}
+}
+
 func Test_SecretTemplateMismatchesSecret(t *testing.T) {
 	tests := map[string]struct {
 		tmpl         *cmapi.CertificateSecretTemplate
@@ -1038,6 +1407,10 @@ func Test_SecretAdditionalOutputFormatsDataMismatch(t *testing.T) {
 	block, _ := pem.Decode(pk)
 	pkDER := block.Bytes
 	combinedPEM := append(append(pk, '\n'), cert...)
+	keyVaultPEM, err := internalcertificates.OutputFormatAzureKeyVaultPEM(pk, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := map[string]struct {
 		input        Input
@@ -1285,6 +1658,63 @@ func Test_SecretAdditionalOutputFormatsDataMismatch(t *testing.T) {
 			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret Data",
 			expViolation: true,
 		},
+		"if additional output has azure key vault pem and Secret has the correct bundle, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "AzureKeyVaultPEM", Key: "keyvault.pem"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":      cert,
+						"tls.key":      pk,
+						"keyvault.pem": keyVaultPEM,
+					},
+				},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
+		"if additional output has azure key vault pem and Secret has the bundle under another key, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "AzureKeyVaultPEM", Key: "keyvault.pem"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":          cert,
+						"tls.key":          pk,
+						"tls-keyvault.pem": keyVaultPEM,
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret Data",
+			expViolation: true,
+		},
+		"if additional output has azure key vault pem and Secret has a wrong bundle, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "AzureKeyVaultPEM"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":          cert,
+						"tls.key":          pk,
+						"tls-keyvault.pem": combinedPEM,
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret Data",
+			expViolation: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -1717,6 +2147,67 @@ func Test_SecretAdditionalOutputFormatsOwnerMismatch(t *testing.T) {
 			expMessage:   "",
 			expViolation: false,
 		},
+		"if additional output formats has azure key vault pem with a custom key and secret has managed fields for it, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "AzureKeyVaultPEM", Key: "keyvault.pem"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+								Raw: []byte(`{"f:data": {".": {}, "f:keyvault.pem": {}}}`),
+							}},
+						},
+					},
+				},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
+		"if additional output formats has azure key vault pem with a custom key and secret has managed fields for the default key, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "AzureKeyVaultPEM", Key: "keyvault.pem"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+								Raw: []byte(`{"f:data": {".": {}, "f:tls-keyvault.pem": {}}}`),
+							}},
+						},
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret ManagedFields",
+			expViolation: true,
+		},
+		"if additional output formats is empty and secret has managed fields for azure key vault pem, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{}},
+				},
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+								Raw: []byte(`{"f:data": {".": {}, "f:tls-keyvault.pem": {}}}`),
+							}},
+						},
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret ManagedFields",
+			expViolation: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -1744,6 +2235,44 @@ func Test_SecretOwnerReferenceManagedFieldMismatch(t *testing.T) {
 		expMessage   string
 		expViolation bool
 	}{
+		"ownerReferenceEnabled=false overridden by annotation, no secret managed field owner reference should return true": {
+			input: Input{
+				Certificate: gen.CertificateFrom(crt, gen.SetCertificateAnnotations(map[string]string{cmapi.SecretOwnerReferenceAnnotation: "true"})),
+				Secret:      &corev1.Secret{},
+			},
+			ownerRefEnabled: false,
+			expReason:       "SecretOwnerRefMismatch",
+			expMessage:      "unexpected managed Secret Owner Reference field on Secret cert-manager.io/secret-owner-reference=true",
+			expViolation:    true,
+		},
+		"ownerReferenceEnabled=true overridden by annotation, secret managed field owner reference for same UID should return true": {
+			input: Input{
+				Certificate: gen.CertificateFrom(crt, gen.SetCertificateAnnotations(map[string]string{cmapi.SecretOwnerReferenceAnnotation: "false"})),
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: "cert-manager-test", FieldsV1: &metav1.FieldsV1{
+								Raw: []byte(`{"f:metadata": {"f:ownerReferences": {"k:{\"uid\":\"uid-123\"}": {}}}}`),
+							}},
+						},
+					},
+				},
+			},
+			ownerRefEnabled: true,
+			expReason:       "SecretOwnerRefMismatch",
+			expMessage:      "unexpected managed Secret Owner Reference field on Secret cert-manager.io/secret-owner-reference=false",
+			expViolation:    true,
+		},
+		"ownerReferenceEnabled=true overridden by annotation, no secret managed field owner reference should return false": {
+			input: Input{
+				Certificate: gen.CertificateFrom(crt, gen.SetCertificateAnnotations(map[string]string{cmapi.SecretOwnerReferenceAnnotation: "false"})),
+				Secret:      &corev1.Secret{},
+			},
+			ownerRefEnabled: true,
+			expReason:       "",
+			expMessage:      "",
+			expViolation:    false,
+		},
 		"ownerReferenceEnabled=false no secret managed field owner reference should return false": {
 			input: Input{
 				Certificate: crt,
@@ -1922,6 +2451,32 @@ func Test_SecretOwnerReferenceValueMismatch(t *testing.T) {
 		expMessage   string
 		expViolation bool
 	}{
+		"ownerReferenceEnabled=false overridden by annotation, secret has random owner reference should return true": {
+			input: Input{
+				Certificate: gen.CertificateFrom(crt, gen.SetCertificateAnnotations(map[string]string{cmapi.SecretOwnerReferenceAnnotation: "true"})),
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						OwnerReferences: []metav1.OwnerReference{
+							{APIVersion: "foo.bar/v1", Kind: "Foo", Name: "foo", UID: types.UID("abc"), Controller: pointer.Bool(false), BlockOwnerDeletion: pointer.Bool(false)},
+						},
+					},
+				},
+			},
+			ownerRefEnabled: false,
+			expReason:       "SecretOwnerRefMismatch",
+			expMessage:      "unexpected Secret Owner Reference value on Secret cert-manager.io/secret-owner-reference=true",
+			expViolation:    true,
+		},
+		"ownerReferenceEnabled=true overridden by annotation, no secret owner reference should return false": {
+			input: Input{
+				Certificate: gen.CertificateFrom(crt, gen.SetCertificateAnnotations(map[string]string{cmapi.SecretOwnerReferenceAnnotation: "false"})),
+				Secret:      &corev1.Secret{},
+			},
+			ownerRefEnabled: true,
+			expReason:       "",
+			expMessage:      "",
+			expViolation:    false,
+		},
 		"ownerReferenceEnabled=false no secret owner reference should return false": {
 			input: Input{
 				Certificate: crt,
@@ -2164,3 +2719,92 @@ func Test_SecretOwnerReferenceValueMismatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_RenewAtAnnotationPassed(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC))
+	privateKey := testcrypto.MustCreatePEMPrivateKey(t)
+	certificate := func(renewAt string) *cmapi.Certificate {
+		crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}}
+		if len(renewAt) > 0 {
+			crt.Annotations = map[string]string{cmapi.RenewAtAnnotation: renewAt}
+		}
+		return crt
+	}
+	secret := func(notBefore time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: privateKey,
+				corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, privateKey,
+					&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					notBefore, notBefore.Add(time.Hour*24*90),
+				),
+			},
+		}
+	}
+	request := func(created time.Time) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		request     *cmapi.CertificateRequest
+		secret      *corev1.Secret
+
+		reason  string
+		message string
+		reissue bool
+	}{
+		"does not trigger if the annotation is not set": {
+			certificate: certificate(""),
+			secret:      secret(clock.Now().Add(-time.Hour)),
+		},
+		"does not trigger if the annotation is not a valid timestamp": {
+			certificate: certificate("tomorrow"),
+			secret:      secret(clock.Now().Add(-time.Hour)),
+		},
+		"does not trigger if the requested time is in the future": {
+			certificate: certificate(clock.Now().Add(time.Minute).Format(time.RFC3339)),
+			request:     request(clock.Now().Add(-time.Hour)),
+			secret:      secret(clock.Now().Add(-time.Hour)),
+		},
+		"triggers if the requested time has passed and the current request was created before it": {
+			certificate: certificate(clock.Now().Add(-time.Minute).Format(time.RFC3339)),
+			request:     request(clock.Now().Add(-time.Hour)),
+			secret:      secret(clock.Now().Add(-time.Hour)),
+			reason:      RenewalRequested,
+			message:     "Renewing certificate as renewal was requested at 2022-03-01T11:59:00Z",
+			reissue:     true,
+		},
+		"does not trigger if the current request was created after the requested time": {
+			certificate: certificate(clock.Now().Add(-time.Minute).Format(time.RFC3339)),
+			request:     request(clock.Now().Add(-time.Second)),
+			// issuers may backdate the certificate, so only the request's
+			// creation time is considered
+			secret: secret(clock.Now().Add(-time.Hour)),
+		},
+		"triggers if there is no current request and the certificate was issued before the requested time": {
+			certificate: certificate(clock.Now().Add(-time.Minute).Format(time.RFC3339)),
+			secret:      secret(clock.Now().Add(-time.Hour)),
+			reason:      RenewalRequested,
+			message:     "Renewing certificate as renewal was requested at 2022-03-01T11:59:00Z",
+			reissue:     true,
+		},
+		"does not trigger if there is no current request and the certificate was issued after the requested time": {
+			certificate: certificate(clock.Now().Add(-time.Minute).Format(time.RFC3339)),
+			secret:      secret(clock.Now().Add(-time.Second)),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reason, message, reissue := RenewAtAnnotationPassed(clock)(Input{
+				Certificate:            test.certificate,
+				CurrentRevisionRequest: test.request,
+				Secret:                 test.secret,
+			})
+
+			assert.Equal(t, test.reason, reason)
+			assert.Equal(t, test.message, message)
+			assert.Equal(t, test.reissue, reissue)
+		})
+	}
+}
@@ -35,15 +35,30 @@ const (
 	// IncorrectIssuer is a policy violation reason for a scenario where
 	// Certificate has been issued by incorrect Issuer.
 	IncorrectIssuer string = "IncorrectIssuer"
+	// IssuerCAChanged is a policy violation reason for a scenario where the
+	// CA certificate stored in the Certificate's Secret is not the CA that
+	// its issuer currently issues certificates with.
+	IssuerCAChanged string = "IssuerCAChanged"
 	// RequestChanged is a policy violation reason for a scenario where
 	// CertificateRequest not valid for Certificate's spec.
 	RequestChanged string = "RequestChanged"
 	// Renewing is a policy violation reason for a scenario where
 	// Certificate's renewal time is now or in past.
 	Renewing string = "Renewing"
+	// RenewalRequested is a policy violation reason for a scenario where the
+	// time in the Certificate's renew-at annotation has passed.
+	RenewalRequested string = "RenewalRequested"
 	// Expired is a policy violation reason for a scenario where Certificate has
 	// expired.
 	Expired string = "Expired"
+	// NotYetValid is the reason of the NotYetValid condition of a Certificate
+	// whose NotBefore time is further in the future than the configured
+	// tolerance.
+	NotYetValid string = "NotYetValid"
+	// InsufficientValidity is a policy violation reason for a scenario where
+	// less time remains before the Certificate's expiry than its
+	// spec.minRemainingValidity.
+	InsufficientValidity string = "InsufficientValidity"
 	// SecretTemplateMisMatch is a policy violation whereby the Certificate's
 	// SecretTemplate is not reflected on the target Secret, either by having
 	// extra, missing, or wrong Annotations or Labels.
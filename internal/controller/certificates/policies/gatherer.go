@@ -227,6 +227,11 @@ import (
 type Gatherer struct {
 	CertificateRequestLister cmlisters.CertificateRequestLister
 	SecretLister             corelisters.SecretLister
+	ConfigMapLister          corelisters.ConfigMapLister
+
+	// IssuerCAResolver, if set, is used to find the CA certificate which the
+	// Certificate's issuer currently issues certificates with.
+	IssuerCAResolver *certificates.IssuerCAResolver
 }
 
 // DataForCertificate returns the secret as well as the "current" and "next"
@@ -241,6 +246,15 @@ type Gatherer struct {
 // or secret) is not found, then the returned value of this object is left nil.
 func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificate) (Input, error) {
 	log := logf.FromContext(ctx)
+
+	// Merge any DNS names listed in the ConfigMap referenced by the
+	// Certificate into its spec, so that the policies compare the issued
+	// certificate against the full set of names it should contain.
+	crt, err := certificates.CertificateWithConfigMapDNSNames(g.ConfigMapLister, crt)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to read the DNS names referenced by spec.dnsNamesConfigMapRef: %w", err)
+	}
+
 	// Attempt to fetch the Secret being managed but tolerate NotFound errors.
 	secret, err := g.SecretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -312,10 +326,18 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 		log.V(logf.DebugLevel).Info("Found no CertificateRequest resources owned by this Certificate for the next revision", "revision", nextCRRevision)
 	}
 
+	issuerCA, err := g.IssuerCAResolver.IssuerCA(crt)
+	if err != nil {
+		// A CA which cannot be read does not prevent checking the rest of the
+		// Certificate's state.
+		log.V(logf.DebugLevel).Info("Failed to read the CA certificate of the issuer", "error", err.Error())
+	}
+
 	return Input{
 		Certificate:            crt,
 		Secret:                 secret,
 		CurrentRevisionRequest: curCR,
 		NextRevisionRequest:    nextCR,
+		IssuerCA:               issuerCA,
 	}, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
@@ -34,6 +35,8 @@ import (
 
 	cmscheme "github.com/cert-manager/cert-manager/pkg/api"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -46,7 +49,9 @@ func TestDataForCertificate(t *testing.T) {
 		wantCurCR  *cmapi.CertificateRequest
 		wantNextCR *cmapi.CertificateRequest
 		wantSecret *corev1.Secret
-		wantErr    string
+		// wantCert defaults to givenCert when nil.
+		wantCert *cmapi.Certificate
+		wantErr  string
 	}{
 		"when no secret is found, the returned secret is nil": {
 			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("default-unit-test-ns"),
@@ -136,6 +141,39 @@ func TestDataForCertificate(t *testing.T) {
 			}},
 			wantErr: `multiple CertificateRequests were found for the 'next' revision 2, issuance is skipped until there are no more duplicates`,
 		},
+		"when the cert references a configmap, the returned cert should contain the merged DNS names": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateDNSNamesConfigMapRef(cmmeta.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"}}),
+			),
+			builder: &testpkg.Builder{KubeObjects: []runtime.Object{
+				configMap("dns-names", "ns-1", map[string]string{"dnsNames": "example.com\nfoo.example.com, *.bar.example.com\n"}),
+			}},
+			wantCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateDNSNames("example.com", "foo.example.com", "*.bar.example.com"),
+				gen.SetCertificateDNSNamesConfigMapRef(cmmeta.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"}}),
+			),
+		},
+		"should error when the referenced configmap does not exist": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateDNSNamesConfigMapRef(cmmeta.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"}}),
+			),
+			builder: &testpkg.Builder{},
+			wantErr: `failed to read the DNS names referenced by spec.dnsNamesConfigMapRef: configmap "dns-names" not found`,
+		},
+		"should error when the referenced configmap contains an invalid DNS name": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateDNSNamesConfigMapRef(cmmeta.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"}, Key: "names"}),
+			),
+			builder: &testpkg.Builder{KubeObjects: []runtime.Object{
+				configMap("dns-names", "ns-1", map[string]string{"names": "foo.example.com,not_valid"}),
+			}},
+			wantErr: `failed to read the DNS names referenced by spec.dnsNamesConfigMapRef: invalid DNS names in configmap 'ns-1/dns-names': "not_valid" is not a valid DNS name: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -169,6 +207,7 @@ func TestDataForCertificate(t *testing.T) {
 			noop := cache.ResourceEventHandlerFuncs{AddFunc: func(obj interface{}) {}}
 			test.builder.SharedInformerFactory.Certmanager().V1().CertificateRequests().Informer().AddEventHandler(noop)
 			test.builder.KubeSharedInformerFactory.Core().V1().Secrets().Informer().AddEventHandler(noop)
+			certificates.DNSNamesConfigMapsInformer(test.builder.KubeSharedInformerFactory, metav1.NamespaceAll).Informer().AddEventHandler(noop)
 
 			// Even though we are only relying on listers in this unit test
 			// and do not use the informer event handlers, we still need to
@@ -213,6 +252,7 @@ func TestDataForCertificate(t *testing.T) {
 			g := &Gatherer{
 				CertificateRequestLister: test.builder.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
 				SecretLister:             test.builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+				ConfigMapLister:          certificates.DNSNamesConfigMapsInformer(test.builder.KubeSharedInformerFactory, metav1.NamespaceAll).Lister(),
 			}
 
 			ctx := logf.NewContext(context.Background(), logf.WithResource(log, test.givenCert))
@@ -223,7 +263,11 @@ func TestDataForCertificate(t *testing.T) {
 			} else {
 				require.NoError(t, gotErr)
 
-				assert.Equal(t, test.givenCert, got.Certificate, "input cert should always be equal to returned cert")
+				wantCert := test.wantCert
+				if wantCert == nil {
+					wantCert = test.givenCert
+				}
+				assert.Equal(t, wantCert, got.Certificate, "returned cert should be the input cert with any referenced DNS names merged")
 				assert.Equal(t, test.wantCurCR, got.CurrentRevisionRequest)
 				assert.Equal(t, test.wantNextCR, got.NextRevisionRequest)
 				assert.Equal(t, test.wantSecret, got.Secret)
@@ -252,3 +296,14 @@ func cr(crName, crNamespace, ownerCertUID string, annot map[string]string) *cmap
 		gen.AddCertificateRequestAnnotations(annot),
 	)
 }
+
+func configMap(name, namespace string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{cmapi.CertificateDNSNamesConfigMapLabelKey: "true"},
+		},
+		Data: data,
+	}
+}
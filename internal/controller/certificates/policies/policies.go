@@ -18,6 +18,8 @@ limitations under the License.
 package policies
 
 import (
+	"crypto/x509"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/clock"
 
@@ -40,6 +42,10 @@ type Input struct {
 	// Take a look at the gatherer package's documentation to see more about why
 	// we care about the "next" certificate request.
 	NextRevisionRequest *cmapi.CertificateRequest
+
+	// IssuerCA is the CA certificate which the Certificate's issuer currently
+	// issues certificates with, or nil if it is not known.
+	IssuerCA *x509.Certificate
 }
 
 // A Func evaluates the given input data and decides whether a check has passed
@@ -65,20 +71,42 @@ func (c Chain) Evaluate(input Input) (string, string, bool) {
 
 // NewTriggerPolicyChain includes trigger policy checks, which if return true,
 // should cause a Certificate to be marked for issuance.
-func NewTriggerPolicyChain(c clock.Clock) Chain {
+//
+// Only changes that affect the issued certificate or its private key cause a
+// Certificate to be reissued. These are:
+//   - the Secret or its private key and certificate data being missing or
+//     not matching each other
+//   - spec.privateKey algorithm, size and encoding (for managed private keys)
+//   - spec.commonName, spec.dnsNames, spec.ipAddresses, spec.uris,
+//     spec.emailAddresses and spec.subject
+//   - spec.isCA, spec.usages and spec.duration
+//   - spec.issuerRef, or the issuer annotations on the Secret being missing
+//   - the certificate nearing expiry, as controlled by spec.renewBefore
+//   - the renew-at annotation having passed
+//
+// Changes to any other fields, such as the Certificate's labels and
+// annotations, spec.secretTemplate, spec.additionalOutputFormats,
+// spec.keystores or spec.revisionHistoryLimit, or other labels and annotations
+// on the Secret, never cause reissuance. Secret metadata and additional
+// output formats are instead reconciled by NewSecretPostIssuancePolicyChain.
+func NewTriggerPolicyChain(c clock.Clock, renewalJitterPercent int) Chain {
 	return Chain{
 		SecretDoesNotExist,
 		SecretIsMissingData,
 		SecretPublicKeysDiffer,
 		SecretPrivateKeyMatchesSpec,
 		SecretIssuerAnnotationsNotUpToDate,
+		SecretIssuerCANotUpToDate,
 		CurrentCertificateRequestNotValidForSpec,
-		CurrentCertificateNearingExpiry(c),
+		CurrentCertificateNearingExpiry(c, renewalJitterPercent),
+		RenewAtAnnotationPassed(c),
 	}
 }
 
 // NewReadinessPolicyChain includes readiness policy checks, which if return
 // true, would cause a Certificate to be marked as not ready.
+// Certificates which expire sooner than their spec.minRemainingValidity are
+// not ready.
 func NewReadinessPolicyChain(c clock.Clock) Chain {
 	return Chain{
 		SecretDoesNotExist,
@@ -86,6 +114,7 @@ func NewReadinessPolicyChain(c clock.Clock) Chain {
 		SecretPublicKeysDiffer,
 		CurrentCertificateRequestNotValidForSpec,
 		CurrentCertificateHasExpired(c),
+		CurrentCertificateValidityTooShort(c),
 	}
 }
 
@@ -20,10 +20,14 @@ import (
 	"bytes"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -64,3 +68,154 @@ func OutputFormatDER(privateKey []byte) []byte {
 func OutputFormatCombinedPEM(privateKey, certificate []byte) []byte {
 	return bytes.Join([][]byte{privateKey, certificate}, []byte("\n"))
 }
+
+// OutputFormatAzureKeyVaultPEM returns the byte slice of the PEM encoded
+// signed certificate chain followed by the PEM encoded private key, which is
+// re-encoded as PKCS#8. This is the layout Azure Key Vault expects when
+// importing a PEM certificate. To be used for Certificate's Additional Output
+// Format Azure Key Vault PEM.
+func OutputFormatAzureKeyVaultPEM(privateKey, certificate []byte) ([]byte, error) {
+	pk, err := utilpki.DecodePrivateKeyBytes(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	pkcs8, err := utilpki.EncodePKCS8PrivateKey(pk)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join([][]byte{certificate, pkcs8}, []byte("\n")), nil
+}
+
+// OutputFormatSecretKey returns the name of the Secret Data entry the given
+// Additional Output Format is written to.
+func OutputFormatSecretKey(format cmapi.CertificateAdditionalOutputFormat) string {
+	switch format.Type {
+	case cmapi.CertificateOutputFormatDER:
+		return cmapi.CertificateOutputFormatDERKey
+	case cmapi.CertificateOutputFormatCombinedPEM:
+		return cmapi.CertificateOutputFormatCombinedPEMKey
+	case cmapi.CertificateOutputFormatAzureKeyVaultPEM:
+		if len(format.Key) > 0 {
+			return format.Key
+		}
+		return cmapi.CertificateOutputFormatAzureKeyVaultPEMKey
+	}
+	return ""
+}
+
+// SecretKeys are the keys of a Certificate's Secret which the signed
+// certificate chain, private key and CA certificate are stored in.
+type SecretKeys struct {
+	Certificate string
+	PrivateKey  string
+	CA          string
+}
+
+// SecretKeysForCertificate returns the keys of the Certificate's Secret which
+// its signed certificate chain, private key and CA certificate are stored in.
+// Keys which are not configured on the Certificate default to `tls.crt`,
+// `tls.key` and `ca.crt`.
+func SecretKeysForCertificate(crt *cmapi.Certificate) SecretKeys {
+	keys := SecretKeys{
+		Certificate: corev1.TLSCertKey,
+		PrivateKey:  corev1.TLSPrivateKeyKey,
+		CA:          cmmeta.TLSCAKey,
+	}
+	if crt.Spec.SecretKeys == nil {
+		return keys
+	}
+	if len(crt.Spec.SecretKeys.Certificate) > 0 {
+		keys.Certificate = crt.Spec.SecretKeys.Certificate
+	}
+	if len(crt.Spec.SecretKeys.PrivateKey) > 0 {
+		keys.PrivateKey = crt.Spec.SecretKeys.PrivateKey
+	}
+	if len(crt.Spec.SecretKeys.CA) > 0 {
+		keys.CA = crt.Spec.SecretKeys.CA
+	}
+	return keys
+}
+
+// SecretTypeForCertificate returns the type of the Certificate's Secret,
+// which defaults to `kubernetes.io/tls`.
+func SecretTypeForCertificate(crt *cmapi.Certificate) corev1.SecretType {
+	if len(crt.Spec.SecretType) > 0 {
+		return corev1.SecretType(crt.Spec.SecretType)
+	}
+	return corev1.SecretTypeTLS
+}
+
+// SecretOwnerReferenceEnabled returns whether the Certificate should be set
+// as the owner of its Secret. The Certificate's
+// `cert-manager.io/secret-owner-reference` annotation, if set to "true" or
+// "false", takes precedence over the default of the controller.
+func SecretOwnerReferenceEnabled(crt *cmapi.Certificate, defaultEnabled bool) bool {
+	switch crt.Annotations[cmapi.SecretOwnerReferenceAnnotation] {
+	case "true":
+		return true
+	case "false":
+		return false
+	default:
+		return defaultEnabled
+	}
+}
+
+// SecretIsForeign returns true if the Secret holds data, but has neither the
+// `cert-manager.io/certificate-name` annotation, an owner reference to the
+// Certificate, nor fields managed by the given field manager, meaning its
+// data was not written by cert-manager and would be lost if the Certificate
+// were issued into it. Empty Secrets, for example those created beforehand
+// to set their metadata, are not foreign.
+func SecretIsForeign(crt *cmapi.Certificate, secret *corev1.Secret, fieldManager string) bool {
+	if len(secret.Data) == 0 {
+		return false
+	}
+	if _, ok := secret.Annotations[cmapi.CertificateNameKey]; ok {
+		return false
+	}
+	for _, ref := range secret.OwnerReferences {
+		if ref.Kind == cmapi.CertificateKind && ref.UID == crt.UID {
+			return false
+		}
+	}
+	for _, entry := range secret.ManagedFields {
+		if entry.Manager == fieldManager {
+			return false
+		}
+	}
+	return true
+}
+
+// SecretAdoptionAllowed returns whether a foreign Secret may be overwritten
+// when the Certificate is issued, which is only the case if the Certificate's
+// `cert-manager.io/adopt-secret` annotation is "true".
+func SecretAdoptionAllowed(crt *cmapi.Certificate) bool {
+	return crt.Annotations[cmapi.AdoptSecretAnnotation] == "true"
+}
+
+// SecretNotOwnedMessage returns the message explaining that the named Secret
+// will not be issued into, as it holds data which was not written by
+// cert-manager.
+func SecretNotOwnedMessage(secretName string) string {
+	return fmt.Sprintf("Secret %q already exists and contains data which was not written by cert-manager, "+
+		"set the %q annotation on the Certificate to \"true\" to allow it to be overwritten",
+		secretName, cmapi.AdoptSecretAnnotation)
+}
+
+// ReasonSecretNotOwned is the reason of the Issuing condition of a
+// Certificate whose issuance was not triggered, or failed, because its Secret
+// holds data which was not written by cert-manager.
+const ReasonSecretNotOwned = "SecretNotOwned"
+
+// SecretAdoptionPending returns whether the last issuance of the Certificate
+// failed because its Secret was not owned by cert-manager, and the
+// Certificate has since been allowed to adopt the Secret. Adding the
+// annotation does not change the Certificate's generation, so such a
+// Certificate is retried without waiting for the failure back off.
+func SecretAdoptionPending(crt *cmapi.Certificate) bool {
+	if !SecretAdoptionAllowed(crt) {
+		return false
+	}
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	return cond != nil && cond.Status == cmmeta.ConditionFalse && cond.Reason == ReasonSecretNotOwned
+}
@@ -17,16 +17,26 @@ limitations under the License.
 package certificates
 
 import (
+	"bytes"
+	"crypto"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
 	"net"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
@@ -161,3 +171,350 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 		})
 	}
 }
+
+// parseKeyVaultImport parses a PEM bundle the way Azure Key Vault imports
+// one: one or more certificates, the first being the leaf, followed by a
+// single unencrypted PKCS#8 private key which must be the last PEM document.
+func parseKeyVaultImport(bundle []byte) ([]*x509.Certificate, crypto.PrivateKey, error) {
+	var certs []*x509.Certificate
+	var key crypto.PrivateKey
+	rest := bytes.TrimSpace(bundle)
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, nil, fmt.Errorf("invalid PEM data")
+		}
+		rest = bytes.TrimSpace(rest)
+		if key != nil {
+			return nil, nil, fmt.Errorf("unexpected %q block after the private key", block.Type)
+		}
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			certs = append(certs, cert)
+		case "PRIVATE KEY":
+			if len(certs) == 0 {
+				return nil, nil, fmt.Errorf("private key must follow the certificates")
+			}
+			var err error
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+		}
+	}
+	if key == nil {
+		return nil, nil, fmt.Errorf("no private key found")
+	}
+	return certs, key, nil
+}
+
+func Test_OutputFormatAzureKeyVaultPEM(t *testing.T) {
+	caKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caPEM, caCert, err := pki.SignCertificate(caTmpl, caTmpl, caKey.Public(), caKey)
+	require.NoError(t, err)
+
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	ecKey, err := pki.GenerateECPrivateKey(pki.ECCurve384)
+	require.NoError(t, err)
+	edKey, err := pki.GenerateEd25519PrivateKey()
+	require.NoError(t, err)
+
+	ecKeyPEM, err := pki.EncodeECPrivateKey(ecKey)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		key crypto.Signer
+		// keyPEM is the encoded private key, PKCS#8 if not set.
+		keyPEM []byte
+	}{
+		"PKCS#1 RSA key": {
+			key:    rsaKey,
+			keyPEM: pki.EncodePKCS1PrivateKey(rsaKey),
+		},
+		"PKCS#8 RSA key": {
+			key: rsaKey,
+		},
+		"SEC 1 ECDSA key": {
+			key:    ecKey,
+			keyPEM: ecKeyPEM,
+		},
+		"PKCS#8 Ed25519 key": {
+			key: edKey,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			keyPEM := test.keyPEM
+			if keyPEM == nil {
+				var err error
+				keyPEM, err = pki.EncodePKCS8PrivateKey(test.key)
+				require.NoError(t, err)
+			}
+
+			leafTmpl := &x509.Certificate{
+				SerialNumber: big.NewInt(2),
+				Subject:      pkix.Name{CommonName: "leaf"},
+				DNSNames:     []string{"example.com"},
+				NotBefore:    time.Now(),
+				NotAfter:     time.Now().Add(time.Hour),
+			}
+			leafPEM, leafCert, err := pki.SignCertificate(leafTmpl, caCert, test.key.Public(), caKey)
+			require.NoError(t, err)
+			chainPEM := append(leafPEM, caPEM...)
+
+			bundle, err := OutputFormatAzureKeyVaultPEM(keyPEM, chainPEM)
+			require.NoError(t, err)
+
+			certs, key, err := parseKeyVaultImport(bundle)
+			require.NoError(t, err)
+			require.Len(t, certs, 2)
+			assert.True(t, leafCert.Equal(certs[0]), "expected the leaf certificate first")
+			assert.True(t, caCert.Equal(certs[1]), "expected the CA certificate second")
+
+			signer, ok := key.(crypto.Signer)
+			require.True(t, ok, "expected the private key to be a crypto.Signer, got %T", key)
+			matches, err := pki.PublicKeyMatchesCertificate(signer.Public(), certs[0])
+			require.NoError(t, err)
+			assert.True(t, matches, "private key does not match the leaf certificate")
+		})
+	}
+
+	_, err = OutputFormatAzureKeyVaultPEM([]byte("not a key"), caPEM)
+	assert.Error(t, err)
+}
+
+func Test_OutputFormatSecretKey(t *testing.T) {
+	tests := map[string]struct {
+		format cmapi.CertificateAdditionalOutputFormat
+		expKey string
+	}{
+		"DER": {
+			format: cmapi.CertificateAdditionalOutputFormat{Type: cmapi.CertificateOutputFormatDER},
+			expKey: "key.der",
+		},
+		"CombinedPEM": {
+			format: cmapi.CertificateAdditionalOutputFormat{Type: cmapi.CertificateOutputFormatCombinedPEM},
+			expKey: "tls-combined.pem",
+		},
+		"AzureKeyVaultPEM with the default key": {
+			format: cmapi.CertificateAdditionalOutputFormat{Type: cmapi.CertificateOutputFormatAzureKeyVaultPEM},
+			expKey: "tls-keyvault.pem",
+		},
+		"AzureKeyVaultPEM with a custom key": {
+			format: cmapi.CertificateAdditionalOutputFormat{Type: cmapi.CertificateOutputFormatAzureKeyVaultPEM, Key: "keyvault-import.pem"},
+			expKey: "keyvault-import.pem",
+		},
+		"unknown format": {
+			format: cmapi.CertificateAdditionalOutputFormat{Type: "foo"},
+			expKey: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expKey, OutputFormatSecretKey(test.format))
+		})
+	}
+}
+
+func Test_SecretKeysForCertificate(t *testing.T) {
+	tests := map[string]struct {
+		keys    *cmapi.CertificateSecretKeys
+		expKeys SecretKeys
+	}{
+		"no keys configured": {
+			keys:    nil,
+			expKeys: SecretKeys{Certificate: "tls.crt", PrivateKey: "tls.key", CA: "ca.crt"},
+		},
+		"some keys configured": {
+			keys:    &cmapi.CertificateSecretKeys{PrivateKey: "key.pem"},
+			expKeys: SecretKeys{Certificate: "tls.crt", PrivateKey: "key.pem", CA: "ca.crt"},
+		},
+		"all keys configured": {
+			keys:    &cmapi.CertificateSecretKeys{Certificate: "cert.pem", PrivateKey: "key.pem", CA: "chain.pem"},
+			expKeys: SecretKeys{Certificate: "cert.pem", PrivateKey: "key.pem", CA: "chain.pem"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretKeys: test.keys}}
+			assert.Equal(t, test.expKeys, SecretKeysForCertificate(crt))
+		})
+	}
+}
+
+func Test_SecretTypeForCertificate(t *testing.T) {
+	tests := map[cmapi.CertificateSecretType]corev1.SecretType{
+		"":                                corev1.SecretTypeTLS,
+		cmapi.CertificateSecretTypeTLS:    corev1.SecretTypeTLS,
+		cmapi.CertificateSecretTypeOpaque: corev1.SecretTypeOpaque,
+	}
+
+	for secretType, expType := range tests {
+		crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretType: secretType}}
+		assert.Equal(t, expType, SecretTypeForCertificate(crt), "unexpected type for secretType %q", secretType)
+	}
+}
+
+func Test_SecretOwnerReferenceEnabled(t *testing.T) {
+	tests := map[string]struct {
+		annotations    map[string]string
+		defaultEnabled bool
+		expEnabled     bool
+	}{
+		"no annotation uses the default of false": {
+			expEnabled: false,
+		},
+		"no annotation uses the default of true": {
+			defaultEnabled: true,
+			expEnabled:     true,
+		},
+		"annotation of true overrides the default": {
+			annotations: map[string]string{cmapi.SecretOwnerReferenceAnnotation: "true"},
+			expEnabled:  true,
+		},
+		"annotation of false overrides the default": {
+			annotations:    map[string]string{cmapi.SecretOwnerReferenceAnnotation: "false"},
+			defaultEnabled: true,
+			expEnabled:     false,
+		},
+		"invalid annotation uses the default": {
+			annotations:    map[string]string{cmapi.SecretOwnerReferenceAnnotation: "yes"},
+			defaultEnabled: true,
+			expEnabled:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+			assert.Equal(t, test.expEnabled, SecretOwnerReferenceEnabled(crt, test.defaultEnabled))
+		})
+	}
+}
+
+func Test_SecretIsForeign(t *testing.T) {
+	crt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-uid"}}
+
+	tests := map[string]struct {
+		secret     *corev1.Secret
+		expForeign bool
+	}{
+		"secret without data is not foreign": {
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}}},
+		},
+		"secret with data and the certificate name annotation is not foreign": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{cmapi.CertificateNameKey: "test"}},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert")},
+			},
+		},
+		"secret with data and an owner reference to the certificate is not foreign": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "test", UID: "test-uid"},
+				}},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("cert")},
+			},
+		},
+		"secret with data and an owner reference to another certificate is foreign": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "other", UID: "other-uid"},
+				}},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("cert")},
+			},
+			expForeign: true,
+		},
+		"secret with data managed by the field manager is not foreign": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "cert-manager-test"}}},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert")},
+			},
+		},
+		"secret with data managed by another field manager is foreign": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert")},
+			},
+			expForeign: true,
+		},
+		"secret with data and no cert-manager metadata is foreign": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"foo": "bar"}},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+			},
+			expForeign: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expForeign, SecretIsForeign(crt, test.secret, "cert-manager-test"))
+		})
+	}
+}
+
+func Test_SecretAdoptionAllowed(t *testing.T) {
+	for value, expAllowed := range map[string]bool{"": false, "true": true, "false": false, "yes": false} {
+		crt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+		if value != "" {
+			crt.Annotations[cmapi.AdoptSecretAnnotation] = value
+		}
+		assert.Equal(t, expAllowed, SecretAdoptionAllowed(crt), "unexpected result for annotation value %q", value)
+	}
+}
+
+func Test_SecretAdoptionPending(t *testing.T) {
+	notOwnedCond := cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: ReasonSecretNotOwned}
+	adopt := map[string]string{cmapi.AdoptSecretAnnotation: "true"}
+
+	tests := map[string]struct {
+		crt        *cmapi.Certificate
+		expPending bool
+	}{
+		"issuance failed as the Secret was not owned and the Certificate now adopts it": {
+			crt:        gen.Certificate("test", gen.AddCertificateAnnotations(adopt), gen.SetCertificateStatusCondition(notOwnedCond)),
+			expPending: true,
+		},
+		"issuance failed as the Secret was not owned but the Certificate does not adopt it": {
+			crt:        gen.Certificate("test", gen.SetCertificateStatusCondition(notOwnedCond)),
+			expPending: false,
+		},
+		"issuance failed for another reason": {
+			crt: gen.Certificate("test", gen.AddCertificateAnnotations(adopt), gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed",
+			})),
+			expPending: false,
+		},
+		"issuance has not failed": {
+			crt:        gen.Certificate("test", gen.AddCertificateAnnotations(adopt)),
+			expPending: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expPending, SecretAdoptionPending(test.crt))
+		})
+	}
+}
@@ -51,6 +51,22 @@ const (
 	//
 	// ServerSideApply enables the use of ServerSideApply in all API calls.
 	ServerSideApply featuregate.Feature = "ServerSideApply"
+
+	// alpha: v1.8.0
+	//
+	// ExperimentalDNS01KeyOverride enables the key presented for DNS01
+	// challenges to be overridden using the
+	// `acme.cert-manager.io/dns01-key-override` annotation on the Challenge.
+	// This is only intended for use in tests, which can then present a
+	// known value instead of the key authorization of the ACME challenge.
+	ExperimentalDNS01KeyOverride featuregate.Feature = "ExperimentalDNS01KeyOverride"
+
+	// alpha: v1.8.0
+	//
+	// ExperimentalHTTP01HostNetworkSolver enables the hostNetwork HTTP01
+	// challenge solver, which runs challenge solver pods on the host network
+	// of the nodes they are scheduled to.
+	ExperimentalHTTP01HostNetworkSolver featuregate.Feature = "ExperimentalHTTP01HostNetworkSolver"
 )
 
 func init() {
@@ -66,4 +82,6 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	ExperimentalGatewayAPISupport:                    {Default: false, PreRelease: featuregate.Alpha},
 	AdditionalCertificateOutputFormats:               {Default: false, PreRelease: featuregate.Alpha},
 	ServerSideApply:                                  {Default: false, PreRelease: featuregate.Alpha},
+	ExperimentalDNS01KeyOverride:                     {Default: false, PreRelease: featuregate.Alpha},
+	ExperimentalHTTP01HostNetworkSolver:              {Default: false, PreRelease: featuregate.Alpha},
 }
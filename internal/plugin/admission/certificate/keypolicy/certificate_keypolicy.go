@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keypolicy
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+const PluginName = "CertificateKeyPolicy"
+
+// WantsKeyPolicy is implemented by admission plugins which need the KeyPolicy
+// configured for the webhook.
+type WantsKeyPolicy interface {
+	SetKeyPolicy(config.KeyPolicy)
+}
+
+type keyPolicyInitializer struct {
+	policy config.KeyPolicy
+}
+
+// NewInitializer returns a plugin initializer which passes the given
+// KeyPolicy to plugins implementing WantsKeyPolicy.
+func NewInitializer(policy config.KeyPolicy) admission.PluginInitializer {
+	return keyPolicyInitializer{policy: policy}
+}
+
+func (i keyPolicyInitializer) Initialize(plugin admission.Interface) {
+	if wants, ok := plugin.(WantsKeyPolicy); ok {
+		wants.SetKeyPolicy(i.policy)
+	}
+}
+
+// certificateKeyPolicy rejects Certificates requesting private keys weaker
+// than the minimums configured for the webhook, or for the issuers the
+// Certificate references.
+type certificateKeyPolicy struct {
+	*admission.Handler
+
+	policy config.KeyPolicy
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+var _ admission.ValidationInterface = &certificateKeyPolicy{}
+var _ WantsKeyPolicy = &certificateKeyPolicy{}
+
+func NewPlugin() admission.Interface {
+	return &certificateKeyPolicy{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func (p *certificateKeyPolicy) SetKeyPolicy(policy config.KeyPolicy) {
+	p.policy = policy
+}
+
+func (p *certificateKeyPolicy) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	// Only run this admission plugin for the certificates resource
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		len(request.SubResource) > 0 {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	if request.Operation == admissionv1.Update {
+		oldCrt, ok := oldObj.(*certmanager.Certificate)
+		if !ok {
+			return nil, fmt.Errorf("internal error: oldObject in admission request is not of type *certmanager.Certificate")
+		}
+		// Certificates created before the policy was configured can still be
+		// updated as long as their key parameters and issuers do not change.
+		if apiequality.Semantic.DeepEqual(oldCrt.Spec.PrivateKey, crt.Spec.PrivateKey) &&
+			apiequality.Semantic.DeepEqual(issuerRefs(oldCrt), issuerRefs(crt)) {
+			return nil, nil
+		}
+	}
+
+	return nil, validateKeyPolicy(p.policy, crt).ToAggregate()
+}
+
+func validateKeyPolicy(policy config.KeyPolicy, crt *certmanager.Certificate) field.ErrorList {
+	minRSAKeySize, minECDSAKeySize := minKeySizes(policy, issuerRefs(crt))
+
+	algorithm := certmanager.RSAKeyAlgorithm
+	size := 0
+	if crt.Spec.PrivateKey != nil {
+		if len(crt.Spec.PrivateKey.Algorithm) > 0 {
+			algorithm = crt.Spec.PrivateKey.Algorithm
+		}
+		size = crt.Spec.PrivateKey.Size
+	}
+
+	fldPath := field.NewPath("spec", "privateKey")
+	var el field.ErrorList
+	switch algorithm {
+	case certmanager.RSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.MinRSAKeySize
+		}
+		if size < minRSAKeySize {
+			el = append(el, field.Invalid(fldPath.Child("size"), size, fmt.Sprintf("RSA private keys must be at least %d bits", minRSAKeySize)))
+		}
+	case certmanager.ECDSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.ECCurve256
+		}
+		if size < minECDSAKeySize {
+			el = append(el, field.Invalid(fldPath.Child("size"), size, fmt.Sprintf("ECDSA private keys must use a curve of at least %d bits", minECDSAKeySize)))
+		}
+	}
+	return el
+}
+
+// minKeySizes returns the minimum RSA and ECDSA key sizes for a Certificate
+// referencing the given issuers. Each issuer uses its override if one is
+// configured and the global minimums otherwise, and the strictest minimums of
+// all the issuers apply, since the Certificate may be issued by any of them.
+func minKeySizes(policy config.KeyPolicy, refs []cmmeta.ObjectReference) (int, int) {
+	var minRSAKeySize, minECDSAKeySize int
+	for _, ref := range refs {
+		rsaKeySize, ecdsaKeySize := policy.MinRSAKeySize, policy.MinECDSAKeySize
+		if override := issuerOverride(policy, ref); override != nil {
+			rsaKeySize, ecdsaKeySize = override.MinRSAKeySize, override.MinECDSAKeySize
+		}
+		if rsaKeySize > minRSAKeySize {
+			minRSAKeySize = rsaKeySize
+		}
+		if ecdsaKeySize > minECDSAKeySize {
+			minECDSAKeySize = ecdsaKeySize
+		}
+	}
+	return minRSAKeySize, minECDSAKeySize
+}
+
+func issuerOverride(policy config.KeyPolicy, ref cmmeta.ObjectReference) *config.IssuerKeyPolicy {
+	for i, override := range policy.IssuerOverrides {
+		if override.Name == ref.Name &&
+			defaultString(override.Kind, "Issuer") == defaultString(ref.Kind, "Issuer") &&
+			defaultString(override.Group, "cert-manager.io") == defaultString(ref.Group, "cert-manager.io") {
+			return &policy.IssuerOverrides[i]
+		}
+	}
+	return nil
+}
+
+func issuerRefs(crt *certmanager.Certificate) []cmmeta.ObjectReference {
+	return append([]cmmeta.ObjectReference{crt.Spec.IssuerRef}, crt.Spec.FallbackIssuerRefs...)
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keypolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+var testPolicy = config.KeyPolicy{
+	MinRSAKeySize:   3072,
+	MinECDSAKeySize: 384,
+	IssuerOverrides: []config.IssuerKeyPolicy{
+		{Name: "legacy-ca", MinRSAKeySize: 2048, MinECDSAKeySize: 256},
+		{Name: "strict-ca", Kind: "ClusterIssuer", MinRSAKeySize: 4096, MinECDSAKeySize: 521},
+	},
+}
+
+func testCertificate(issuerName, issuerKind string, privateKey *certmanager.CertificatePrivateKey) *certmanager.Certificate {
+	return &certmanager.Certificate{
+		Spec: certmanager.CertificateSpec{
+			IssuerRef:  cmmeta.ObjectReference{Name: issuerName, Kind: issuerKind},
+			PrivateKey: privateKey,
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		op          admissionv1.Operation
+		gvr         *metav1.GroupVersionResource
+		subResource string
+		policy      config.KeyPolicy
+		oldCrt      *certmanager.Certificate
+		crt         *certmanager.Certificate
+		expErr      string
+	}{
+		"accepts an RSA key of the minimum size": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 3072}),
+		},
+		"rejects an RSA key smaller than the minimum size": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048}),
+			expErr: "spec.privateKey.size: Invalid value: 2048: RSA private keys must be at least 3072 bits",
+		},
+		"rejects the default RSA key size if it is smaller than the minimum size": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("ca", "", nil),
+			expErr: "spec.privateKey.size: Invalid value: 2048: RSA private keys must be at least 3072 bits",
+		},
+		"accepts an ECDSA key of the minimum size": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm, Size: 384}),
+		},
+		"rejects the default ECDSA curve if it is weaker than the minimum": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm}),
+			expErr: "spec.privateKey.size: Invalid value: 256: ECDSA private keys must use a curve of at least 384 bits",
+		},
+		"accepts Ed25519 keys": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.Ed25519KeyAlgorithm}),
+		},
+		"accepts any key size if no policy is configured": {
+			op:  admissionv1.Create,
+			gvr: certificatesResource,
+			crt: testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm}),
+		},
+		"uses the override of the referenced Issuer": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("legacy-ca", "Issuer", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048}),
+		},
+		"does not use an Issuer override for a ClusterIssuer of the same name": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("legacy-ca", "ClusterIssuer", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048}),
+			expErr: "spec.privateKey.size: Invalid value: 2048: RSA private keys must be at least 3072 bits",
+		},
+		"rejects a key weaker than the override of the referenced ClusterIssuer": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt:    testCertificate("strict-ca", "ClusterIssuer", &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm, Size: 384}),
+			expErr: "spec.privateKey.size: Invalid value: 384: ECDSA private keys must use a curve of at least 521 bits",
+		},
+		"applies the strictest policy of the fallback issuers": {
+			op:     admissionv1.Create,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			crt: func() *certmanager.Certificate {
+				crt := testCertificate("legacy-ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 3072})
+				crt.Spec.FallbackIssuerRefs = []cmmeta.ObjectReference{{Name: "strict-ca", Kind: "ClusterIssuer"}}
+				return crt
+			}(),
+			expErr: "spec.privateKey.size: Invalid value: 3072: RSA private keys must be at least 4096 bits",
+		},
+		"rejects an update weakening the private key": {
+			op:     admissionv1.Update,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			oldCrt: testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 4096}),
+			crt:    testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048}),
+			expErr: "spec.privateKey.size: Invalid value: 2048: RSA private keys must be at least 3072 bits",
+		},
+		"rejects an update changing to an issuer with a stricter policy": {
+			op:     admissionv1.Update,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			oldCrt: testCertificate("legacy-ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048}),
+			crt:    testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048}),
+			expErr: "spec.privateKey.size: Invalid value: 2048: RSA private keys must be at least 3072 bits",
+		},
+		"accepts an update to an existing Certificate not changing its key parameters or issuers": {
+			op:     admissionv1.Update,
+			gvr:    certificatesResource,
+			policy: testPolicy,
+			oldCrt: testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048}),
+			crt: func() *certmanager.Certificate {
+				crt := testCertificate("ca", "", &certmanager.CertificatePrivateKey{Algorithm: certmanager.RSAKeyAlgorithm, Size: 2048})
+				crt.Spec.DNSNames = []string{"example.com"}
+				return crt
+			}(),
+		},
+		"does not validate the status sub-resource": {
+			op:          admissionv1.Update,
+			gvr:         certificatesResource,
+			subResource: "status",
+			policy:      testPolicy,
+			oldCrt:      testCertificate("ca", "", nil),
+			crt:         testCertificate("ca", "", &certmanager.CertificatePrivateKey{Size: 1024}),
+		},
+		"does not validate other resources": {
+			op: admissionv1.Create,
+			gvr: &metav1.GroupVersionResource{
+				Group:    "cert-manager.io",
+				Version:  "v1",
+				Resource: "certificaterequests",
+			},
+			policy: testPolicy,
+			crt:    testCertificate("ca", "", nil),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin := NewPlugin().(*certificateKeyPolicy)
+			NewInitializer(test.policy).Initialize(plugin)
+
+			warnings, err := plugin.Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       test.op,
+				RequestResource: test.gvr,
+				SubResource:     test.subResource,
+			}, test.oldCrt, test.crt)
+			assert.Empty(t, warnings)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
+
+func TestHandles(t *testing.T) {
+	plugin := NewPlugin()
+	for op, exp := range map[admissionv1.Operation]bool{
+		admissionv1.Create:  true,
+		admissionv1.Update:  true,
+		admissionv1.Delete:  false,
+		admissionv1.Connect: false,
+	} {
+		if got := plugin.Handles(op); got != exp {
+			t.Errorf("unexpected Handles result for %q: got=%t, exp=%t", op, got, exp)
+		}
+	}
+}
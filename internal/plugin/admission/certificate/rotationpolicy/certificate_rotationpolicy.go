@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotationpolicy
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+const PluginName = "CertificateRotationPolicyDefault"
+
+// certificateRotationPolicyDefault defaults the private key rotation policy of
+// newly created Certificates to Always when the
+// DefaultPrivateKeyRotationPolicyAlways feature gate is enabled.
+type certificateRotationPolicyDefault struct {
+	*admission.Handler
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+var _ admission.MutationInterface = &certificateRotationPolicyDefault{}
+
+func NewPlugin() admission.Interface {
+	return &certificateRotationPolicyDefault{
+		// Only Create requests are handled so that enabling the feature gate
+		// never changes the behaviour of existing Certificates.
+		Handler: admission.NewHandler(admissionv1.Create),
+	}
+}
+
+func (p *certificateRotationPolicyDefault) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj runtime.Object) error {
+	// Only run this admission plugin for the certificates resource
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		len(request.SubResource) > 0 ||
+		request.Operation != admissionv1.Create {
+		return nil
+	}
+
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.DefaultPrivateKeyRotationPolicyAlways) {
+		return nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	// Rotation has no meaning for private keys that are not managed by
+	// cert-manager.
+	if crt.Spec.PrivateKey != nil && crt.Spec.PrivateKey.Managed != nil && !*crt.Spec.PrivateKey.Managed {
+		return nil
+	}
+
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &certmanager.CertificatePrivateKey{}
+	}
+	if len(crt.Spec.PrivateKey.RotationPolicy) == 0 {
+		crt.Spec.PrivateKey.RotationPolicy = certmanager.RotationPolicyAlways
+	}
+
+	return nil
+}
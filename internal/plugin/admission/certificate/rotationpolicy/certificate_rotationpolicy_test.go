@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotationpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestMutate(t *testing.T) {
+	tests := map[string]struct {
+		featureEnabled bool
+		op             admissionv1.Operation
+		gvr            *metav1.GroupVersionResource
+		subResource    string
+		privateKey     *certmanager.CertificatePrivateKey
+		exp            *certmanager.CertificatePrivateKey
+	}{
+		"defaults the rotation policy on create when privateKey is unset": {
+			featureEnabled: true,
+			op:             admissionv1.Create,
+			gvr:            certificatesResource,
+			exp:            &certmanager.CertificatePrivateKey{RotationPolicy: certmanager.RotationPolicyAlways},
+		},
+		"defaults the rotation policy on create when only other privateKey fields are set": {
+			featureEnabled: true,
+			op:             admissionv1.Create,
+			gvr:            certificatesResource,
+			privateKey:     &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm},
+			exp:            &certmanager.CertificatePrivateKey{Algorithm: certmanager.ECDSAKeyAlgorithm, RotationPolicy: certmanager.RotationPolicyAlways},
+		},
+		"does not override an explicitly set rotation policy": {
+			featureEnabled: true,
+			op:             admissionv1.Create,
+			gvr:            certificatesResource,
+			privateKey:     &certmanager.CertificatePrivateKey{RotationPolicy: certmanager.RotationPolicyNever},
+			exp:            &certmanager.CertificatePrivateKey{RotationPolicy: certmanager.RotationPolicyNever},
+		},
+		"does not mutate on update": {
+			featureEnabled: true,
+			op:             admissionv1.Update,
+			gvr:            certificatesResource,
+			exp:            nil,
+		},
+		"does not mutate when the feature gate is disabled": {
+			featureEnabled: false,
+			op:             admissionv1.Create,
+			gvr:            certificatesResource,
+			exp:            nil,
+		},
+		"does not mutate the status sub-resource": {
+			featureEnabled: true,
+			op:             admissionv1.Create,
+			gvr:            certificatesResource,
+			subResource:    "status",
+			exp:            nil,
+		},
+		"does not mutate other resources": {
+			featureEnabled: true,
+			op:             admissionv1.Create,
+			gvr: &metav1.GroupVersionResource{
+				Group:    "cert-manager.io",
+				Version:  "v1",
+				Resource: "certificaterequests",
+			},
+			exp: nil,
+		},
+		"does not mutate Certificates with an unmanaged private key": {
+			featureEnabled: true,
+			op:             admissionv1.Create,
+			gvr:            certificatesResource,
+			privateKey:     &certmanager.CertificatePrivateKey{Managed: pointer.Bool(false)},
+			exp:            &certmanager.CertificatePrivateKey{Managed: pointer.Bool(false)},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.DefaultPrivateKeyRotationPolicyAlways, test.featureEnabled)()
+
+			plugin := NewPlugin().(*certificateRotationPolicyDefault)
+			crt := &certmanager.Certificate{
+				Spec: certmanager.CertificateSpec{PrivateKey: test.privateKey},
+			}
+			err := plugin.Mutate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       test.op,
+				RequestResource: test.gvr,
+				SubResource:     test.subResource,
+			}, crt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, test.exp, crt.Spec.PrivateKey)
+		})
+	}
+}
+
+func TestHandles(t *testing.T) {
+	plugin := NewPlugin()
+	for op, exp := range map[admissionv1.Operation]bool{
+		admissionv1.Create:  true,
+		admissionv1.Update:  false,
+		admissionv1.Delete:  false,
+		admissionv1.Connect: false,
+	} {
+		if got := plugin.Handles(op); got != exp {
+			t.Errorf("unexpected Handles result for %q: got=%t, exp=%t", op, got, exp)
+		}
+	}
+}
@@ -18,6 +18,8 @@ package plugin
 
 import (
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/apideprecation"
+	certificatekeypolicy "github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/keypolicy"
+	certificaterotationpolicy "github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/rotationpolicy"
 	certificaterequestapproval "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/approval"
 	certificaterequestidentity "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/identity"
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/resourcevalidation"
@@ -27,13 +29,17 @@ import (
 
 var AllOrderedPlugins = []string{
 	apideprecation.PluginName,
+	certificaterotationpolicy.PluginName,
 	resourcevalidation.PluginName,
+	certificatekeypolicy.PluginName,
 	certificaterequestidentity.PluginName,
 	certificaterequestapproval.PluginName,
 }
 
 func RegisterAllPlugins(plugins *admission.Plugins) {
 	apideprecation.Register(plugins)
+	certificaterotationpolicy.Register(plugins)
+	certificatekeypolicy.Register(plugins)
 	certificaterequestidentity.Register(plugins)
 	certificaterequestapproval.Register(plugins)
 	resourcevalidation.Register(plugins)
@@ -42,7 +48,9 @@ func RegisterAllPlugins(plugins *admission.Plugins) {
 func DefaultOnAdmissionPlugins() sets.String {
 	return sets.NewString(
 		apideprecation.PluginName,
+		certificaterotationpolicy.PluginName,
 		resourcevalidation.PluginName,
+		certificatekeypolicy.PluginName,
 		certificaterequestidentity.PluginName,
 		certificaterequestapproval.PluginName,
 	)
@@ -39,7 +39,7 @@ var _ Interface = &Vault{}
 // ClientBuilder is a function type that returns a new Interface.
 // Can be used in tests to create a mock signer of Vault certificate requests.
 type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
-	issuer v1.GenericIssuer) (Interface, error)
+	issuer v1.GenericIssuer, userAgent string) (Interface, error)
 
 // Interface implements various high level functionality related to connecting
 // with a Vault server, verifying its status and signing certificate request for
@@ -74,7 +74,7 @@ type Vault struct {
 // secrets lister.
 // Returned errors may be network failures and should be considered for
 // retrying.
-func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer, userAgent string) (Interface, error) {
 	v := &Vault{
 		secretsLister: secretsLister,
 		namespace:     namespace,
@@ -90,6 +90,9 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.Gen
 	if err != nil {
 		return nil, fmt.Errorf("error initializing Vault client: %s", err.Error())
 	}
+	if userAgent != "" {
+		client.AddHeader("User-Agent", userAgent)
+	}
 
 	if err := v.setToken(client); err != nil {
 		return nil, err
@@ -118,8 +121,7 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 		"exclude_cn_from_sans": "true",
 	}
 
-	vaultIssuer := v.issuer.GetSpec().Vault
-	url := path.Join("/v1", vaultIssuer.Path)
+	url := path.Join("/v1", signPath(v.issuer.GetSpec().Vault, csr))
 
 	request := v.client.NewRequest("POST", url)
 
@@ -131,7 +133,7 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 
 	resp, err := v.client.RawRequest(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
+		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %w", err)
 	}
 
 	defer resp.Body.Close()
@@ -145,6 +147,52 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 	return extractCertificatesFromVaultCertificateSecret(&vaultResult)
 }
 
+// signPath returns the path of the Vault PKI `sign` endpoint that should be
+// used for the given request. The first of the issuer's mounts whose domain
+// suffixes match the request's common name and all of its DNS names is used,
+// otherwise the issuer's Path is returned.
+func signPath(vaultIssuer *v1.VaultIssuer, csr *x509.CertificateRequest) string {
+	var names []string
+	if csr.Subject.CommonName != "" {
+		names = append(names, csr.Subject.CommonName)
+	}
+	names = append(names, csr.DNSNames...)
+	if len(names) == 0 {
+		return vaultIssuer.Path
+	}
+
+	for _, mount := range vaultIssuer.Mounts {
+		matched := true
+		for _, name := range names {
+			if !matchesDomainSuffix(name, mount.DomainSuffixes) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return mount.Path
+		}
+	}
+
+	return vaultIssuer.Path
+}
+
+// matchesDomainSuffix returns true if name is equal to, or is a subdomain of,
+// one of the given suffixes. The comparison is case insensitive.
+func matchesDomainSuffix(name string, suffixes []string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	for _, suffix := range suffixes {
+		suffix = strings.ToLower(strings.Trim(suffix, "."))
+		if suffix == "" {
+			continue
+		}
+		if name == suffix || strings.HasSuffix(name, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *Vault) setToken(client Client) error {
 	tokenRef := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	if tokenRef != nil {
@@ -185,15 +233,21 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
 
-	certs := v.issuer.GetSpec().Vault.CABundle
-	if len(certs) == 0 {
-		return cfg, nil
+	var caCertPool *x509.CertPool
+	if certs := v.issuer.GetSpec().Vault.CABundle; len(certs) > 0 {
+		caCertPool = x509.NewCertPool()
+		ok := caCertPool.AppendCertsFromPEM(certs)
+		if !ok {
+			return nil, fmt.Errorf("error loading Vault CA bundle")
+		}
 	}
 
-	caCertPool := x509.NewCertPool()
-	ok := caCertPool.AppendCertsFromPEM(certs)
-	if !ok {
-		return nil, fmt.Errorf("error loading Vault CA bundle")
+	caCertPool, err := pki.AddTrustBundle(caCertPool, v.issuer.GetSpec().TrustBundle)
+	if err != nil {
+		return nil, fmt.Errorf("error loading issuer trust bundle: %v", err)
+	}
+	if caCertPool == nil {
+		return cfg, nil
 	}
 
 	cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = caCertPool
@@ -331,6 +331,77 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSignPath(t *testing.T) {
+	vaultIssuer := &cmapi.VaultIssuer{
+		Path: "pki/sign/default",
+		Mounts: []cmapi.VaultPKIMount{
+			{
+				Path:           "pki_staging/sign/staging",
+				DomainSuffixes: []string{"staging.example.com"},
+			},
+			{
+				Path:           "pki_prod/sign/prod",
+				DomainSuffixes: []string{"example.com", ".example.org"},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		commonName string
+		dnsNames   []string
+		expected   string
+	}{
+		"a request with no domain names should use the default path": {
+			expected: "pki/sign/default",
+		},
+		"a common name matching a suffix exactly should use that mount": {
+			commonName: "staging.example.com",
+			expected:   "pki_staging/sign/staging",
+		},
+		"a subdomain should use the first matching mount": {
+			dnsNames: []string{"app.staging.example.com"},
+			expected: "pki_staging/sign/staging",
+		},
+		"a subdomain of a later mount should use that mount": {
+			commonName: "app.example.com",
+			dnsNames:   []string{"app.example.com", "www.example.org"},
+			expected:   "pki_prod/sign/prod",
+		},
+		"names spanning mounts should use the mount matching all of them": {
+			dnsNames: []string{"app.staging.example.com", "app.example.com"},
+			expected: "pki_prod/sign/prod",
+		},
+		"names are matched case insensitively": {
+			dnsNames: []string{"APP.Staging.Example.COM"},
+			expected: "pki_staging/sign/staging",
+		},
+		"a name which only shares a string suffix should not match": {
+			dnsNames: []string{"notexample.com"},
+			expected: "pki/sign/default",
+		},
+		"a name not matching any mount should use the default path": {
+			commonName: "example.net",
+			expected:   "pki/sign/default",
+		},
+		"a single unmatched name should use the default path": {
+			dnsNames: []string{"app.example.com", "example.net"},
+			expected: "pki/sign/default",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			csr := &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: test.commonName},
+				DNSNames: test.dnsNames,
+			}
+			if got := signPath(vaultIssuer, csr); got != test.expected {
+				t.Errorf("unexpected sign path, exp=%s got=%s", test.expected, got)
+			}
+		})
+	}
+}
+
 type testExtractCertificatesFromVaultCertT struct {
 	secret       *certutil.Secret
 	expectedCert string
@@ -927,6 +998,43 @@ func TestNewConfig(t *testing.T) {
 				return nil
 			},
 		},
+
+		"the issuer trust bundle should be added to the CA bundle": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					CABundle: []byte(testLeafCertificate),
+				}),
+				gen.SetIssuerTrustBundle([]byte(testRootCa)),
+			),
+			expectedErr: nil,
+			checkFunc: func(cfg *vault.Config) error {
+				testCA := x509.NewCertPool()
+				testCA.AppendCertsFromPEM([]byte(testLeafCertificate))
+				testCA.AppendCertsFromPEM([]byte(testRootCa))
+				subs := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs.Subjects()
+
+				err := fmt.Errorf("got unexpected root CAs in config, exp=%s got=%s",
+					testCA.Subjects(), subs)
+				if len(subs) != len(testCA.Subjects()) {
+					return err
+				}
+				for i := range subs {
+					if !bytes.Equal(subs[i], testCA.Subjects()[i]) {
+						return err
+					}
+				}
+
+				return nil
+			},
+		},
+
+		"a bad issuer trust bundle should error": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{}),
+				gen.SetIssuerTrustBundle([]byte("a bad trust bundle")),
+			),
+			expectedErr: errors.New("error loading issuer trust bundle: no valid PEM encoded CA certificates found in the trust bundle"),
+		},
 	}
 
 	for name, test := range tests {
@@ -36,6 +36,13 @@ const (
 	//
 	// AdditionalCertificateOutputFormats enable output additional format
 	AdditionalCertificateOutputFormats featuregate.Feature = "AdditionalCertificateOutputFormats"
+
+	// alpha: v1.8.0
+	//
+	// DefaultPrivateKeyRotationPolicyAlways sets `spec.privateKey.rotationPolicy`
+	// to `Always` on newly created Certificates that do not specify a rotation
+	// policy. Existing Certificates are never modified.
+	DefaultPrivateKeyRotationPolicyAlways featuregate.Feature = "DefaultPrivateKeyRotationPolicyAlways"
 )
 
 func init() {
@@ -48,5 +55,6 @@ func init() {
 //   utilfeature.DefaultFeatureGate.Enabled(feature.FeatureName)
 // Where utilfeature is github.com/cert-manager/cert-manager/pkg/util/feature.
 var webhookFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	AdditionalCertificateOutputFormats: {Default: false, PreRelease: featuregate.Alpha},
+	AdditionalCertificateOutputFormats:    {Default: false, PreRelease: featuregate.Alpha},
+	DefaultPrivateKeyRotationPolicyAlways: {Default: false, PreRelease: featuregate.Alpha},
 }
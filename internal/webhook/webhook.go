@@ -33,6 +33,7 @@ import (
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
 	"github.com/cert-manager/cert-manager/internal/plugin"
+	"github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/keypolicy"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
@@ -66,7 +67,7 @@ func NewCertManagerWebhookServer(log logr.Logger, _ options.WebhookFlags, opts c
 	}
 
 	// Set up the admission chain
-	admissionHandler, err := buildAdmissionChain(cl)
+	admissionHandler, err := buildAdmissionChain(cl, opts.KeyPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +90,7 @@ func NewCertManagerWebhookServer(log logr.Logger, _ options.WebhookFlags, opts c
 	return s, nil
 }
 
-func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler, error) {
+func buildAdmissionChain(client kubernetes.Interface, keyPolicy config.KeyPolicy) (*admission.RequestHandler, error) {
 	// Set up the admission chain
 	pluginHandler := admission.NewPlugins(Scheme)
 	plugin.RegisterAllPlugins(pluginHandler)
@@ -109,7 +110,10 @@ func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler
 	if err != nil {
 		return nil, fmt.Errorf("error creating authorization handler: %v", err)
 	}
-	pluginInitializer := initializer.New(client, nil, authorizer, nil)
+	pluginInitializer := admission.PluginInitializers{
+		initializer.New(client, nil, authorizer, nil),
+		keypolicy.NewInitializer(keyPolicy),
+	}
 	pluginChain, err := pluginHandler.NewFromPlugins(plugin.DefaultOnAdmissionPlugins().List(), pluginInitializer)
 	if err != nil {
 		return nil, fmt.Errorf("error building admission chain: %v", err)
@@ -18,18 +18,23 @@ package accounts
 
 import (
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
 	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
+	"k8s.io/utils/clock"
 
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	"github.com/cert-manager/cert-manager/pkg/acme/client/middleware"
 	acmeutil "github.com/cert-manager/cert-manager/pkg/acme/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // NewClientFunc is a function type for building a new ACME client.
@@ -41,22 +46,86 @@ var _ NewClientFunc = NewClient
 func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string) acmecl.Interface {
 	return middleware.NewLogger(&acmeapi.Client{
 		Key:          privateKey,
-		HTTPClient:   client,
+		HTTPClient:   acmecl.WithCertificateChainAccept(client),
 		DirectoryURL: config.Server,
 		UserAgent:    userAgent,
 		RetryBackoff: acmeutil.RetryBackoff,
 	})
 }
 
+// defaultHTTPTimeout is the timeout of requests to the ACME server if the
+// issuer does not configure one.
+const defaultHTTPTimeout = 30 * time.Second
+
+const (
+	// directoryCacheTTL is how long the directory of an ACME server is
+	// reused before it is revalidated with the server.
+	directoryCacheTTL = time.Hour
+
+	// nonceMaxAge is how long a nonce returned by an ACME server is kept
+	// for use by a later request.
+	nonceMaxAge = time.Minute
+)
+
+var (
+	// directoryCache and noncePool are shared by the HTTP clients of all
+	// issuers, which are rebuilt every time an issuer is synced.
+	directoryCache = acmecl.NewDirectoryCache(directoryCacheTTL, clock.RealClock{})
+	noncePool      = acmecl.NewNoncePool(nonceMaxAge, clock.RealClock{})
+)
+
+// cacheKey returns the key under which the directory of the issuer's ACME
+// server and the nonces it returned are cached. They are only reused by HTTP
+// clients of the same issuer which verify the ACME server's certificate in
+// the same way.
+func cacheKey(issuer cmapi.GenericIssuer, skipTLSVerify bool, trustBundle []byte) string {
+	return fmt.Sprintf("%s/%s/%t/%x", issuer.GetUID(), issuer.GetSpec().ACME.Server,
+		skipTLSVerify, sha256.Sum256(trustBundle))
+}
+
 // BuildHTTPClient returns a instrumented HTTP client to be used by the ACME
-// client.
+// client of the given issuer. The timeout and retries of its requests are
+// configured by the issuer's ACME spec. Only the system roots are trusted;
+// use BuildHTTPClientWithTrustBundle to also trust the issuer's trust bundle.
+// Requests for the issuer's ACME directory and for new nonces are served
+// from caches which are shared between the HTTP clients built for the issuer.
 // For the time being, we construct a new HTTP client on each invocation.
 // This is because we need to set the 'skipTLSVerify' flag on the HTTP client
 // itself.
 // In future, we may change to having two global HTTP clients - one that ignores
 // TLS connection errors, and the other that does not.
-func BuildHTTPClient(metrics *metrics.Metrics, skipTLSVerify bool) *http.Client {
-	return acmecl.NewInstrumentedClient(metrics,
+func BuildHTTPClient(metrics *metrics.Metrics, issuer cmapi.GenericIssuer, skipTLSVerify bool) *http.Client {
+	// building a client without a trust bundle cannot fail
+	client, _ := buildHTTPClient(metrics, issuer, skipTLSVerify, nil)
+	return client
+}
+
+// BuildHTTPClientWithTrustBundle returns a HTTP client like BuildHTTPClient,
+// which also trusts the CA certificates of the issuer's trust bundle in
+// addition to the system roots. An error is returned if the trust bundle does
+// not contain any valid CA certificates.
+func BuildHTTPClientWithTrustBundle(metrics *metrics.Metrics, issuer cmapi.GenericIssuer, skipTLSVerify bool) (*http.Client, error) {
+	return buildHTTPClient(metrics, issuer, skipTLSVerify, issuer.GetSpec().TrustBundle)
+}
+
+func buildHTTPClient(metrics *metrics.Metrics, issuer cmapi.GenericIssuer, skipTLSVerify bool, trustBundle []byte) (*http.Client, error) {
+	rootCAs, err := pki.AddTrustBundle(nil, trustBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, maxRetries := defaultHTTPTimeout, 0
+	if config := issuer.GetSpec().ACME; config != nil {
+		if config.HTTPTimeout != nil {
+			timeout = config.HTTPTimeout.Duration
+		}
+		if config.HTTPMaxRetries != nil {
+			maxRetries = *config.HTTPMaxRetries
+		}
+	}
+
+	// Each retry of a request is instrumented separately.
+	client := acmecl.NewInstrumentedClient(metrics, issuer,
 		&http.Client{
 			Transport: &http.Transport{
 				Proxy: http.ProxyFromEnvironment,
@@ -64,12 +133,19 @@ func BuildHTTPClient(metrics *metrics.Metrics, skipTLSVerify bool) *http.Client
 					Timeout:   30 * time.Second,
 					KeepAlive: 30 * time.Second,
 				}).DialContext,
-				TLSClientConfig:       &tls.Config{InsecureSkipVerify: skipTLSVerify},
+				TLSClientConfig:       &tls.Config{InsecureSkipVerify: skipTLSVerify, RootCAs: rootCAs},
 				MaxIdleConns:          100,
 				IdleConnTimeout:       90 * time.Second,
 				TLSHandshakeTimeout:   10 * time.Second,
 				ExpectContinueTimeout: 1 * time.Second,
 			},
-			Timeout: time.Second * 30,
 		})
+	client = acmecl.WithTimeoutAndRetries(client, timeout, maxRetries)
+
+	if config := issuer.GetSpec().ACME; config != nil {
+		key := cacheKey(issuer, skipTLSVerify, trustBundle)
+		client = acmecl.WithDirectoryCache(client, directoryCache, config.Server, key)
+		client = acmecl.WithNoncePool(client, noncePool, key)
+	}
+	return client, nil
 }
@@ -0,0 +1,452 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	acmeapi "golang.org/x/crypto/acme"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestNewClientUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"newNonce":"https://example.com/nonce","newAccount":"https://example.com/account","newOrder":"https://example.com/order"}`))
+	}))
+	defer server.Close()
+
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := NewClient(server.Client(), cmacme.ACMEIssuer{Server: server.URL}, pk, "cert-manager-test/v1.2.3")
+	if _, err := cl.Discover(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The ACME library appends its own identifier to the configured value.
+	if !strings.HasPrefix(userAgent, "cert-manager-test/v1.2.3 ") {
+		t.Errorf("expected the request User-Agent to start with the configured value, got %q", userAgent)
+	}
+}
+
+// strictACMEServer is a fake ACME server which, like some strict
+// implementations of RFC 8555, rejects plain GET requests for anything but
+// the directory, and requires POST-as-GET requests with an empty payload for
+// all resource fetches.
+// Certificate chains are only returned as PEM when requested with an Accept
+// header, and are otherwise returned as a DER encoded leaf certificate.
+type strictACMEServer struct {
+	*httptest.Server
+
+	// alwaysDER causes certificates to be returned DER encoded even when a
+	// PEM encoded chain is requested.
+	alwaysDER bool
+
+	lock       sync.Mutex
+	violations []string
+	// orderIdentifiers are the identifiers of the most recently created
+	// order.
+	orderIdentifiers []acmeapi.AuthzID
+}
+
+func newStrictACMEServer(t *testing.T) *strictACMEServer {
+	s := &strictACMEServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *strictACMEServer) reject(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	detail := fmt.Sprintf(format, args...)
+	s.lock.Lock()
+	s.violations = append(s.violations, detail)
+	s.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `{"type":"urn:ietf:params:acme:error:malformed","detail":%q}`, detail)
+}
+
+func (s *strictACMEServer) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Replay-Nonce", "nonce")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/directory":
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"newNonce":"%[1]s/new-nonce","newAccount":"%[1]s/new-account","newOrder":"%[1]s/new-order"}`, s.URL)
+		return
+	case r.Method == http.MethodHead && r.URL.Path == "/new-nonce":
+		return
+	case r.Method != http.MethodPost:
+		s.reject(w, http.StatusMethodNotAllowed, "%s %s: only POST-as-GET is supported", r.Method, r.URL.Path)
+		return
+	}
+
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		s.reject(w, http.StatusBadRequest, "POST %s: invalid JWS: %v", r.URL.Path, err)
+		return
+	}
+	if r.URL.Path == "/new-order" {
+		s.newOrder(w, jws.Payload)
+		return
+	}
+	// Looking up the account by its key and creating orders are the only
+	// requests made by the client with a payload.
+	if r.URL.Path != "/new-account" && jws.Payload != "" {
+		s.reject(w, http.StatusBadRequest, "POST %s: expected a POST-as-GET request with an empty payload", r.URL.Path)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/new-account":
+		w.Header().Set("Location", s.URL+"/account/1")
+		_, _ = fmt.Fprint(w, `{"status":"valid"}`)
+	case "/order/1":
+		_, _ = fmt.Fprintf(w, `{"status":"valid","identifiers":[{"type":"dns","value":"example.com"}],"authorizations":["%[1]s/authz/1"],"finalize":"%[1]s/order/1/finalize","certificate":"%[1]s/cert/1"}`, s.URL)
+	case "/authz/ip":
+		_, _ = fmt.Fprintf(w, `{"status":"pending","identifier":{"type":"ip","value":"192.0.2.1"},"challenges":[{"type":"http-01","url":"%s/chall/ip","token":"token","status":"pending"}]}`, s.URL)
+	case "/authz/1":
+		_, _ = fmt.Fprintf(w, `{"status":"valid","identifier":{"type":"dns","value":"example.com"},"challenges":[{"type":"http-01","url":"%s/chall/1","token":"token","status":"valid"}]}`, s.URL)
+	case "/chall/1":
+		_, _ = fmt.Fprintf(w, `{"type":"http-01","url":"%s/chall/1","token":"token","status":"valid"}`, s.URL)
+	case "/cert/1":
+		w.Header().Add("Link", fmt.Sprintf(`<%s/cert/1/1>;rel="alternate"`, s.URL))
+		if s.alwaysDER || r.Header.Get("Accept") != acmecl.PEMCertificateChainContentType {
+			w.Header().Set("Content-Type", "application/pkix-cert")
+			_, _ = w.Write([]byte("leaf"))
+			return
+		}
+		w.Header().Set("Content-Type", acmecl.PEMCertificateChainContentType)
+		_ = pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: []byte("leaf")})
+		_ = pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: []byte("intermediate")})
+	default:
+		s.reject(w, http.StatusNotFound, "POST %s: unknown resource", r.URL.Path)
+	}
+}
+
+// newOrder creates an order for the identifiers in the JWS payload, which
+// may be DNS names or, as described in RFC 8738, IP addresses.
+func (s *strictACMEServer) newOrder(w http.ResponseWriter, payload string) {
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		s.reject(w, http.StatusBadRequest, "POST /new-order: invalid payload: %v", err)
+		return
+	}
+	var req struct {
+		Identifiers []acmeapi.AuthzID `json:"identifiers"`
+	}
+	if err := json.Unmarshal(raw, &req); err != nil {
+		s.reject(w, http.StatusBadRequest, "POST /new-order: invalid payload: %v", err)
+		return
+	}
+	s.lock.Lock()
+	s.orderIdentifiers = req.Identifiers
+	s.lock.Unlock()
+
+	identifiers, _ := json.Marshal(req.Identifiers)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", s.URL+"/order/ip")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = fmt.Fprintf(w, `{"status":"pending","identifiers":%s,"authorizations":["%[2]s/authz/ip"],"finalize":"%[2]s/order/ip/finalize"}`, identifiers, s.URL)
+}
+
+// TestNewClientFetchesResourcesWithPostAsGet ensures that every ACME
+// resource fetched by cert-manager is retrieved with a POST-as-GET request,
+// as required by RFC 8555 section 6.3, so that servers which reject plain
+// GET requests are supported.
+func TestNewClientFetchesResourcesWithPostAsGet(t *testing.T) {
+	server := newStrictACMEServer(t)
+	ctx := context.Background()
+
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := NewClient(server.Client(), cmacme.ACMEIssuer{Server: server.URL + "/directory"}, pk, "cert-manager-test")
+
+	fetches := map[string]func() error{
+		"account": func() error {
+			_, err := cl.GetReg(ctx, server.URL+"/account/1")
+			return err
+		},
+		"order": func() error {
+			_, err := cl.GetOrder(ctx, server.URL+"/order/1")
+			return err
+		},
+		"wait for order": func() error {
+			_, err := cl.WaitOrder(ctx, server.URL+"/order/1")
+			return err
+		},
+		"authorization": func() error {
+			_, err := cl.GetAuthorization(ctx, server.URL+"/authz/1")
+			return err
+		},
+		"wait for authorization": func() error {
+			_, err := cl.WaitAuthorization(ctx, server.URL+"/authz/1")
+			return err
+		},
+		"challenge": func() error {
+			_, err := cl.GetChallenge(ctx, server.URL+"/chall/1")
+			return err
+		},
+		"certificate": func() error {
+			chain, err := cl.FetchCert(ctx, server.URL+"/cert/1", true)
+			if err == nil && len(chain) != 2 {
+				err = fmt.Errorf("expected a chain of 2 certificates, got %d", len(chain))
+			}
+			return err
+		},
+		"alternate certificate chains": func() error {
+			alternates, err := cl.ListCertAlternates(ctx, server.URL+"/cert/1")
+			if err == nil && len(alternates) != 1 {
+				err = fmt.Errorf("expected 1 alternate chain, got %d", len(alternates))
+			}
+			return err
+		},
+	}
+
+	for name, fetch := range fetches {
+		if err := fetch(); err != nil {
+			t.Errorf("failed to fetch %s: %v", name, err)
+		}
+	}
+
+	if len(server.violations) > 0 {
+		t.Errorf("expected all resources to be fetched with POST-as-GET, got violations:\n%s", strings.Join(server.violations, "\n"))
+	}
+}
+
+func TestNewClientFetchCertRequestsPEMChain(t *testing.T) {
+	ctx := context.Background()
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("without an Accept header the server returns DER, which cannot be decoded", func(t *testing.T) {
+		server := newStrictACMEServer(t)
+		cl := &acmeapi.Client{Key: pk, HTTPClient: server.Client(), DirectoryURL: server.URL + "/directory"}
+		if _, err := cl.FetchCert(ctx, server.URL+"/cert/1", true); err == nil {
+			t.Error("expected an error decoding a DER encoded certificate")
+		}
+	})
+
+	t.Run("the client requests and decodes a PEM chain", func(t *testing.T) {
+		server := newStrictACMEServer(t)
+		cl := NewClient(server.Client(), cmacme.ACMEIssuer{Server: server.URL + "/directory"}, pk, "cert-manager-test")
+		chain, err := cl.FetchCert(ctx, server.URL+"/cert/1", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(chain) != 2 || string(chain[0]) != "leaf" || string(chain[1]) != "intermediate" {
+			t.Errorf("unexpected certificate chain: %q", chain)
+		}
+	})
+
+	t.Run("an unexpected content type is reported clearly", func(t *testing.T) {
+		server := newStrictACMEServer(t)
+		server.alwaysDER = true
+		cl := NewClient(server.Client(), cmacme.ACMEIssuer{Server: server.URL + "/directory"}, pk, "cert-manager-test")
+		_, err := cl.FetchCert(ctx, server.URL+"/cert/1", true)
+		if err == nil || !strings.Contains(err.Error(), `unexpected content type "application/pkix-cert"`) {
+			t.Errorf("expected an error naming the unexpected content type, got: %v", err)
+		}
+	})
+}
+
+// TestNewClientAuthorizeOrderWithIPIdentifier ensures that orders for IP
+// addresses are submitted with identifiers of type ip, as described in
+// RFC 8738, and that their authorizations can be solved.
+func TestNewClientAuthorizeOrderWithIPIdentifier(t *testing.T) {
+	server := newStrictACMEServer(t)
+	ctx := context.Background()
+
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := NewClient(server.Client(), cmacme.ACMEIssuer{Server: server.URL + "/directory"}, pk, "cert-manager-test")
+
+	order, err := cl.AuthorizeOrder(ctx, acmeapi.IPIDs("192.0.2.1"))
+	if err != nil {
+		t.Fatalf("unexpected error creating order: %v", err)
+	}
+	if len(server.violations) > 0 {
+		t.Errorf("unexpected requests: %v", server.violations)
+	}
+
+	expected := []acmeapi.AuthzID{{Type: "ip", Value: "192.0.2.1"}}
+	if !reflect.DeepEqual(server.orderIdentifiers, expected) {
+		t.Errorf("expected the order to be created with identifiers %v, got %v", expected, server.orderIdentifiers)
+	}
+	if !reflect.DeepEqual(order.Identifiers, expected) {
+		t.Errorf("expected the created order to have identifiers %v, got %v", expected, order.Identifiers)
+	}
+	if len(order.AuthzURLs) != 1 {
+		t.Fatalf("expected the order to have one authorization, got %v", order.AuthzURLs)
+	}
+
+	authz, err := cl.GetAuthorization(ctx, order.AuthzURLs[0])
+	if err != nil {
+		t.Fatalf("unexpected error fetching authorization: %v", err)
+	}
+	if authz.Identifier != expected[0] {
+		t.Errorf("expected the authorization to be for %v, got %v", expected[0], authz.Identifier)
+	}
+	if len(authz.Challenges) != 1 || authz.Challenges[0].Type != "http-01" {
+		t.Errorf("expected the authorization to offer an http-01 challenge, got %+v", authz.Challenges)
+	}
+}
+
+func TestBuildHTTPClientTrustBundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"newNonce":"https://example.com/nonce","newAccount":"https://example.com/account","newOrder":"https://example.com/order"}`))
+	}))
+	defer server.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		trustBundle []byte
+		expectErr   bool
+	}{
+		"the ACME server is not trusted without a trust bundle": {
+			expectErr: true,
+		},
+		"the ACME server is trusted if its CA is in the trust bundle": {
+			trustBundle: serverCA,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := cmacme.ACMEIssuer{Server: server.URL}
+			issuer := &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{ACME: &config},
+					TrustBundle:  test.trustBundle,
+				},
+			}
+
+			httpClient, err := BuildHTTPClientWithTrustBundle(metrics.New(logr.Discard(), clock.RealClock{}), issuer, false)
+			if err != nil {
+				t.Fatalf("unexpected error building the HTTP client: %v", err)
+			}
+
+			_, err = NewClient(httpClient, config, pk, "cert-manager-test").Discover(context.Background())
+			if test.expectErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPClientInvalidTrustBundle(t *testing.T) {
+	issuer := &cmapi.Issuer{
+		Spec: cmapi.IssuerSpec{
+			IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{}},
+			TrustBundle:  []byte("not a certificate"),
+		},
+	}
+
+	if _, err := BuildHTTPClientWithTrustBundle(metrics.New(logr.Discard(), clock.RealClock{}), issuer, false); err == nil {
+		t.Errorf("expected an error for a trust bundle without any certificates")
+	}
+}
+
+func TestBuildHTTPClientCachesDirectory(t *testing.T) {
+	var lock sync.Mutex
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		fetches++
+		lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"newNonce":"https://example.com/nonce","newAccount":"https://example.com/account","newOrder":"https://example.com/order"}`))
+	}))
+	defer server.Close()
+
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := cmacme.ACMEIssuer{Server: server.URL}
+	newIssuer := func(uid string) *cmapi.Issuer {
+		issuer := &cmapi.Issuer{Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &config}}}
+		issuer.UID = types.UID(uid)
+		return issuer
+	}
+	discover := func(issuer *cmapi.Issuer, skipTLSVerify bool) {
+		httpClient := BuildHTTPClient(metrics.New(logr.Discard(), clock.RealClock{}), issuer, skipTLSVerify)
+		if _, err := NewClient(httpClient, config, pk, "cert-manager-test").Discover(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	expectFetches := func(expected int) {
+		t.Helper()
+		lock.Lock()
+		defer lock.Unlock()
+		if fetches != expected {
+			t.Errorf("expected the directory to have been fetched %d times, got %d", expected, fetches)
+		}
+	}
+
+	// the HTTP client is rebuilt every time the issuer is synced
+	discover(newIssuer("issuer-a"), false)
+	discover(newIssuer("issuer-a"), false)
+	expectFetches(1)
+
+	// the directory is not shared with other issuers, or with a client
+	// which verifies the ACME server's certificate differently
+	discover(newIssuer("issuer-b"), false)
+	expectFetches(2)
+	discover(newIssuer("issuer-a"), true)
+	expectFetches(3)
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"net/http"
 	"sync"
+	"time"
 
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
@@ -77,11 +78,13 @@ type registry struct {
 // for 'equality' between two clients. This is used to determine whether any
 // options that should trigger a re-initialisation of a client have changed.
 type stableOptions struct {
-	serverURL     string
-	skipVerifyTLS bool
-	issuerUID     string
-	publicKey     string
-	exponent      int
+	serverURL      string
+	skipVerifyTLS  bool
+	httpTimeout    time.Duration
+	httpMaxRetries int
+	issuerUID      string
+	publicKey      string
+	exponent       int
 }
 
 func (c stableOptions) equalTo(c2 stableOptions) bool {
@@ -91,13 +94,20 @@ func (c stableOptions) equalTo(c2 stableOptions) bool {
 func newStableOptions(uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) stableOptions {
 	// Encoding a big.Int cannot fail
 	publicNBytes, _ := privateKey.PublicKey.N.GobEncode()
-	return stableOptions{
+	opts := stableOptions{
 		serverURL:     config.Server,
 		skipVerifyTLS: config.SkipTLSVerify,
 		issuerUID:     uid,
 		publicKey:     string(publicNBytes),
 		exponent:      privateKey.PublicKey.E,
 	}
+	if config.HTTPTimeout != nil {
+		opts.httpTimeout = config.HTTPTimeout.Duration
+	}
+	if config.HTTPMaxRetries != nil {
+		opts.httpMaxRetries = *config.HTTPMaxRetries
+	}
+	return opts
 }
 
 // clientWithMeta wraps an ACME client with additional metadata used to
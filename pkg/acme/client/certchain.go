@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// PEMCertificateChainContentType is the media type of the PEM encoded
+// certificate chains downloaded from ACME servers, as defined in RFC 8555
+// section 9.1.
+const PEMCertificateChainContentType = "application/pem-certificate-chain"
+
+type certificateChainURLKey struct{}
+
+// ContextWithCertificateChainURL returns a context which marks requests made
+// to the given URL as certificate chain downloads. HTTP clients returned by
+// WithCertificateChainAccept request a PEM encoded certificate chain for
+// such requests.
+func ContextWithCertificateChainURL(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, certificateChainURLKey{}, url)
+}
+
+// WithCertificateChainAccept returns a copy of the given HTTP client which
+// sets `Accept: application/pem-certificate-chain` on certificate chain
+// downloads marked using ContextWithCertificateChainURL, and returns an error
+// if the ACME server responds with any other content type.
+// Some ACME servers respond with a DER encoded certificate if no Accept
+// header is set, which the ACME client cannot decode.
+func WithCertificateChainAccept(client *http.Client) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	wrapped := client.Transport
+	if wrapped == nil {
+		wrapped = http.DefaultTransport
+	}
+
+	withAccept := *client
+	withAccept.Transport = &certificateChainTransport{wrappedRT: wrapped}
+	return &withAccept
+}
+
+// certificateChainTransport is a http.RoundTripper which negotiates the
+// content type of certificate chain downloads.
+type certificateChainTransport struct {
+	wrappedRT http.RoundTripper
+}
+
+func (t *certificateChainTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	url, ok := req.Context().Value(certificateChainURLKey{}).(string)
+	if !ok || url != req.URL.String() {
+		return t.wrappedRT.RoundTrip(req)
+	}
+
+	// a RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept", PEMCertificateChainContentType)
+
+	resp, err := t.wrappedRT.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// error responses are problem documents, which are handled by the
+		// ACME client
+		return resp, err
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != PEMCertificateChainContentType {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected content type %q in certificate chain downloaded from %s, expected %q",
+			contentType, url, PEMCertificateChainContentType)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// DirectoryCache caches the directories of ACME servers.
+// The ACME client fetches the directory each time it is constructed, which
+// happens every time an Issuer is synced. A cached directory is reused for
+// the TTL, after which it is revalidated with a conditional GET if the ACME
+// server returned an ETag, so that an unchanged directory is not downloaded
+// again.
+// A DirectoryCache is safe for concurrent use and may be shared by the HTTP
+// clients of all issuers. Each client is given its own key, so that a
+// directory fetched by a client which does not verify the ACME server's
+// certificate is never served to another client.
+type DirectoryCache struct {
+	ttl   time.Duration
+	clock clock.Clock
+
+	lock    sync.Mutex
+	entries map[string]*directoryEntry
+}
+
+// directoryEntry is a cached response to a request for an ACME directory.
+type directoryEntry struct {
+	header  http.Header
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// NewDirectoryCache returns a DirectoryCache which reuses a directory for the
+// given TTL before revalidating it.
+func NewDirectoryCache(ttl time.Duration, clock clock.Clock) *DirectoryCache {
+	return &DirectoryCache{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[string]*directoryEntry),
+	}
+}
+
+// Invalidate removes the directory cached with the given key, so that it is
+// fetched again by the next request for it.
+func (d *DirectoryCache) Invalidate(key string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.entries, key)
+}
+
+// get returns the directory cached with the given key, if any, and whether
+// it is still within its TTL.
+func (d *DirectoryCache) get(key string) (entry *directoryEntry, fresh bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	entry = d.entries[key]
+	return entry, entry != nil && d.clock.Now().Before(entry.expires)
+}
+
+func (d *DirectoryCache) store(key string, header http.Header, body []byte) {
+	// Nonces are single use, so one returned with the directory must not be
+	// handed out again with the cached response.
+	header = header.Clone()
+	header.Del("Replay-Nonce")
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.entries[key] = &directoryEntry{
+		header:  header,
+		body:    body,
+		etag:    header.Get("ETag"),
+		expires: d.clock.Now().Add(d.ttl),
+	}
+}
+
+// refresh marks the cached directory as valid for another TTL, after the ACME
+// server confirmed that it has not changed.
+func (d *DirectoryCache) refresh(key string, entry *directoryEntry) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.entries[key] == entry {
+		entry.expires = d.clock.Now().Add(d.ttl)
+	}
+}
+
+// WithDirectoryCache returns a copy of the given HTTP client which serves
+// requests for the ACME directory at directoryURL from the given cache, under
+// the given key. The directory is invalidated if the ACME server rejects a
+// nonce, as the server may have been reconfigured since the directory was
+// fetched.
+func WithDirectoryCache(client *http.Client, cache *DirectoryCache, directoryURL, key string) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	wrapped := client.Transport
+	if wrapped == nil {
+		wrapped = http.DefaultTransport
+	}
+
+	withCache := *client
+	withCache.Transport = &directoryCacheTransport{
+		wrappedRT:    wrapped,
+		cache:        cache,
+		directoryURL: directoryURL,
+		key:          key,
+	}
+	return &withCache
+}
+
+// directoryCacheTransport is a http.RoundTripper which caches the responses
+// to requests for an ACME directory.
+type directoryCacheTransport struct {
+	wrappedRT    http.RoundTripper
+	cache        *DirectoryCache
+	directoryURL string
+	key          string
+}
+
+func (t *directoryCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.URL.String() != t.directoryURL {
+		resp, err := t.wrappedRT.RoundTrip(req)
+		if err == nil && isBadNonceResponse(resp) {
+			t.cache.Invalidate(t.key)
+		}
+		return resp, err
+	}
+
+	entry, fresh := t.cache.get(t.key)
+	if fresh {
+		return entry.response(req), nil
+	}
+
+	if entry != nil && entry.etag != "" {
+		// a RoundTripper must not modify the request it is given
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := t.wrappedRT.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil && entry.etag != "":
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		t.cache.refresh(t.key, entry)
+		return entry.response(req), nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.cache.store(t.key, resp.Header, body)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	default:
+		return resp, nil
+	}
+}
+
+// response returns a response to the given request built from the cached
+// directory.
+func (e *directoryEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// isBadNonceResponse returns true if the response is an ACME problem document
+// rejecting the nonce of the request. The body of the response is preserved.
+func isBadNonceResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	var problem struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return false
+	}
+	// ACME servers in the wild use their own namespaces for the error type,
+	// so only its suffix is compared, as the ACME client does.
+	return strings.HasSuffix(strings.ToLower(problem.Type), ":badnonce")
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+const testDirectory = `{"newNonce":"https://example.com/nonce","newAccount":"https://example.com/account","newOrder":"https://example.com/order"}`
+
+// directoryServer is an ACME server which counts the requests made for its
+// directory.
+type directoryServer struct {
+	*httptest.Server
+
+	lock sync.Mutex
+	// etag is returned with the directory, if set
+	etag string
+	// fetches and revalidations count the directory requests that were
+	// answered with the directory and with 304 Not Modified respectively
+	fetches, revalidations int
+}
+
+func newDirectoryServer(etag string) *directoryServer {
+	s := &directoryServer{etag: etag}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		switch r.URL.Path {
+		case "/directory":
+			if s.etag != "" && r.Header.Get("If-None-Match") == s.etag {
+				s.revalidations++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			s.fetches++
+			if s.etag != "" {
+				w.Header().Set("ETag", s.etag)
+			}
+			w.Header().Set("Replay-Nonce", "directory-nonce")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(testDirectory))
+		case "/bad-nonce":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"type":"urn:ietf:params:acme:error:badNonce","detail":"JWS has an invalid anti-replay nonce"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func (s *directoryServer) counts() (fetches, revalidations int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.fetches, s.revalidations
+}
+
+// getDirectory requests the directory and checks that it is returned.
+func getDirectory(t *testing.T, cl *http.Client, url string) *http.Response {
+	t.Helper()
+	resp, err := cl.Get(url)
+	if err != nil {
+		t.Fatalf("unexpected error fetching the directory: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading the directory: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || string(body) != testDirectory {
+		t.Fatalf("unexpected response for the directory: %d %q", resp.StatusCode, body)
+	}
+	return resp
+}
+
+func expectCounts(t *testing.T, s *directoryServer, expectedFetches, expectedRevalidations int) {
+	t.Helper()
+	if fetches, revalidations := s.counts(); fetches != expectedFetches || revalidations != expectedRevalidations {
+		t.Errorf("expected %d fetches and %d revalidations of the directory, got %d and %d",
+			expectedFetches, expectedRevalidations, fetches, revalidations)
+	}
+}
+
+func TestDirectoryCache(t *testing.T) {
+	tests := map[string]struct {
+		etag string
+		// expected counts of requests for the directory after the TTL
+		// has passed and the directory has been requested again
+		expectedFetches, expectedRevalidations int
+	}{
+		"a directory with an ETag is revalidated once the TTL has passed": {
+			etag:                  `"v1"`,
+			expectedFetches:       1,
+			expectedRevalidations: 1,
+		},
+		"a directory without an ETag is fetched again once the TTL has passed": {
+			expectedFetches: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newDirectoryServer(test.etag)
+			defer server.Close()
+
+			clock := fakeclock.NewFakeClock(time.Now())
+			cache := NewDirectoryCache(time.Hour, clock)
+			url := server.URL + "/directory"
+
+			getDirectory(t, WithDirectoryCache(server.Client(), cache, url, "issuer"), url)
+			expectCounts(t, server, 1, 0)
+
+			// A new client for the same key is served from the cache
+			// within the TTL.
+			clock.Step(time.Hour - time.Second)
+			resp := getDirectory(t, WithDirectoryCache(server.Client(), cache, url, "issuer"), url)
+			expectCounts(t, server, 1, 0)
+			if nonce := resp.Header.Get("Replay-Nonce"); nonce != "" {
+				t.Errorf("expected the nonce not to be returned from the cache, got %q", nonce)
+			}
+
+			clock.Step(time.Second)
+			getDirectory(t, WithDirectoryCache(server.Client(), cache, url, "issuer"), url)
+			expectCounts(t, server, test.expectedFetches, test.expectedRevalidations)
+
+			// The revalidated directory is reused for another TTL.
+			clock.Step(time.Hour - time.Second)
+			getDirectory(t, WithDirectoryCache(server.Client(), cache, url, "issuer"), url)
+			expectCounts(t, server, test.expectedFetches, test.expectedRevalidations)
+		})
+	}
+}
+
+func TestDirectoryCacheKeys(t *testing.T) {
+	server := newDirectoryServer(`"v1"`)
+	defer server.Close()
+
+	cache := NewDirectoryCache(time.Hour, fakeclock.NewFakeClock(time.Now()))
+	url := server.URL + "/directory"
+
+	getDirectory(t, WithDirectoryCache(server.Client(), cache, url, "issuer-a"), url)
+	getDirectory(t, WithDirectoryCache(server.Client(), cache, url, "issuer-b"), url)
+	expectCounts(t, server, 2, 0)
+}
+
+func TestDirectoryCacheInvalidatedOnBadNonce(t *testing.T) {
+	server := newDirectoryServer(`"v1"`)
+	defer server.Close()
+
+	cache := NewDirectoryCache(time.Hour, fakeclock.NewFakeClock(time.Now()))
+	url := server.URL + "/directory"
+	cl := WithDirectoryCache(server.Client(), cache, url, "issuer")
+
+	getDirectory(t, cl, url)
+	expectCounts(t, server, 1, 0)
+
+	resp, err := cl.Post(server.URL+"/bad-nonce", "application/jose+json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "badNonce") {
+		t.Errorf("expected the problem document to be passed to the caller, got %q", body)
+	}
+
+	getDirectory(t, cl, url)
+	expectCounts(t, server, 2, 0)
+}
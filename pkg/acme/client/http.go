@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
@@ -38,12 +39,18 @@ import (
 type Transport struct {
 	metrics *metrics.Metrics
 
+	// issuerLabels identify the Issuer or ClusterIssuer that the client
+	// belongs to, so that rate limited responses, and all requests if the
+	// metrics have issuer labels, can be attributed to it.
+	issuerLabels []string
+
 	wrappedRT http.RoundTripper
 }
 
 // NewInstrumentedClient takes a *http.Client and returns a *http.Client that
-// has its RoundTripper wrapped with instrumentation.
-func NewInstrumentedClient(metrics *metrics.Metrics, client *http.Client) *http.Client {
+// has its RoundTripper wrapped with instrumentation. The given issuer is the
+// Issuer or ClusterIssuer that the client makes requests on behalf of.
+func NewInstrumentedClient(metrics *metrics.Metrics, issuer cmapi.GenericIssuer, client *http.Client) *http.Client {
 	// If next client is not defined we'll use http.DefaultClient.
 	if client == nil {
 		client = http.DefaultClient
@@ -54,8 +61,9 @@ func NewInstrumentedClient(metrics *metrics.Metrics, client *http.Client) *http.
 	}
 
 	client.Transport = &Transport{
-		wrappedRT: client.Transport,
-		metrics:   metrics,
+		wrappedRT:    client.Transport,
+		metrics:      metrics,
+		issuerLabels: issuerLabels(issuer),
 	}
 
 	return client
@@ -82,14 +90,31 @@ func (it *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Method,
 		fmt.Sprintf("%d", statusCode),
 	}
+	if it.metrics.IssuerLabels() {
+		labels = append(labels, it.issuerLabels...)
+	}
 	// Observe the time it took to make the request.
 	it.metrics.ObserveACMERequestDuration(time.Since(start), labels...)
 	it.metrics.IncrementACMERequestCount(labels...)
+	if statusCode == http.StatusTooManyRequests {
+		rateLimitedLabels := append(append([]string{}, it.issuerLabels...), req.URL.Host)
+		it.metrics.IncrementACMERateLimitedCount(rateLimitedLabels...)
+	}
 
 	// return the response and error reported from the next RoundTripper.
 	return resp, err
 }
 
+// issuerLabels returns the name, namespace and kind label values of the given
+// issuer.
+func issuerLabels(issuer cmapi.GenericIssuer) []string {
+	kind := cmapi.IssuerKind
+	if issuer.GetNamespace() == "" {
+		kind = cmapi.ClusterIssuerKind
+	}
+	return []string{issuer.GetName(), issuer.GetNamespace(), kind}
+}
+
 // pathProcessor will trim the provided path to only include the first 2
 // segments in order to reduce the number of prometheus labels generated
 func pathProcessor(path string) string {
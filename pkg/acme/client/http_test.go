@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestTransportRateLimitedCount(t *testing.T) {
+	tests := map[string]struct {
+		issuer     cmapi.GenericIssuer
+		statusCode int
+		expected   string
+	}{
+		"a 429 response from an Issuer's client increments the counter": {
+			issuer:     &cmapi.Issuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt", Namespace: "default"}},
+			statusCode: http.StatusTooManyRequests,
+			expected:   `certmanager_http_acme_client_rate_limited_count{host="%s",issuer_kind="Issuer",issuer_name="letsencrypt",issuer_namespace="default"} 2`,
+		},
+		"a 429 response from a ClusterIssuer's client increments the counter": {
+			issuer:     &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt"}},
+			statusCode: http.StatusTooManyRequests,
+			expected:   `certmanager_http_acme_client_rate_limited_count{host="%s",issuer_kind="ClusterIssuer",issuer_name="letsencrypt",issuer_namespace=""} 2`,
+		},
+		"a successful response does not increment the counter": {
+			issuer:     &cmapi.Issuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt", Namespace: "default"}},
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			acmeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+			}))
+			defer acmeServer.Close()
+
+			m := metrics.New(logtesting.NewTestLogger(t), clock.RealClock{})
+			client := NewInstrumentedClient(m, test.issuer, &http.Client{})
+
+			for i := 0; i < 2; i++ {
+				resp, err := client.Get(acmeServer.URL + "/acme/new-order")
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+			}
+
+			body := scrapeMetrics(t, m)
+			if test.expected == "" {
+				if strings.Contains(body, "certmanager_http_acme_client_rate_limited_count{") {
+					t.Errorf("expected rate limited counter to not be set, got:\n%s", body)
+				}
+				return
+			}
+
+			serverURL, err := url.Parse(acmeServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := fmt.Sprintf(test.expected, serverURL.Host)
+			if !strings.Contains(body, expected) {
+				t.Errorf("expected metrics to contain %q, got:\n%s", expected, body)
+			}
+		})
+	}
+}
+
+func TestTransportRequestCountIssuerLabels(t *testing.T) {
+	tests := map[string]struct {
+		opts     []metrics.Option
+		expected string
+	}{
+		"requests are not labelled with the issuer by default": {
+			expected: `certmanager_http_acme_client_request_count{host="%s",method="GET",path="/acme/new-order",scheme="http",status="200"} 1`,
+		},
+		"requests are labelled with the issuer when enabled": {
+			opts:     []metrics.Option{metrics.WithIssuerLabels()},
+			expected: `certmanager_http_acme_client_request_count{host="%s",issuer_kind="Issuer",issuer_name="letsencrypt",issuer_namespace="default",method="GET",path="/acme/new-order",scheme="http",status="200"} 1`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			acmeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer acmeServer.Close()
+
+			m := metrics.New(logtesting.NewTestLogger(t), clock.RealClock{}, test.opts...)
+			issuer := &cmapi.Issuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt", Namespace: "default"}}
+			client := NewInstrumentedClient(m, issuer, &http.Client{})
+
+			resp, err := client.Get(acmeServer.URL + "/acme/new-order")
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			serverURL, err := url.Parse(acmeServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := fmt.Sprintf(test.expected, serverURL.Host)
+			if body := scrapeMetrics(t, m); !strings.Contains(body, expected) {
+				t.Errorf("expected metrics to contain %q, got:\n%s", expected, body)
+			}
+		})
+	}
+}
+
+// scrapeMetrics returns the text exposition of all metrics registered by m.
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	rec := httptest.NewRecorder()
+	m.NewServer(ln).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
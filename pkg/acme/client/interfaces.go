@@ -29,6 +29,11 @@ import (
 //
 // For more information see https://pkg.go.dev/golang.org/x/crypto/acme#Client
 // and RFC 8555 (https://tools.ietf.org/html/rfc8555).
+//
+// Implementations must fetch orders, authorizations, challenges, accounts and
+// certificates using POST-as-GET requests (RFC 8555 section 6.3), as some ACME
+// servers reject plain GET requests. Only the directory may be fetched with a
+// plain GET.
 type Interface interface {
 	AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error)
 	GetOrder(ctx context.Context, url string) (*acme.Order, error)
@@ -70,6 +70,10 @@ func (l *Logger) FetchCert(ctx context.Context, url string, bundle bool) ([][]by
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	// request the certificate chain as PEM, the only format the ACME client
+	// can decode
+	ctx = client.ContextWithCertificateChainURL(ctx, url)
+
 	return l.baseCl.FetchCert(ctx, url, bundle)
 }
 
@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+const (
+	// maxPooledNonces is the maximum number of nonces kept for each key of a
+	// NoncePool.
+	maxPooledNonces = 100
+
+	// replayNonceHeader is the header in which ACME servers return a fresh
+	// nonce with every response, as defined in RFC 8555 section 6.5.1.
+	replayNonceHeader = "Replay-Nonce"
+)
+
+// NoncePool keeps the nonces returned by ACME servers with their responses,
+// so that they can be used for subsequent requests.
+// The ACME client only reuses the nonces of the responses to its own signed
+// requests, and otherwise fetches a fresh nonce with a HEAD request to the
+// server's newNonce endpoint. The ACME client is constructed every time an
+// Issuer is synced, so in practice most signed requests were preceded by an
+// extra round-trip to fetch a nonce.
+// A NoncePool is safe for concurrent use and may be shared by the HTTP clients
+// of all issuers. The nonces of each client are kept under its own key.
+type NoncePool struct {
+	// maxAge is the time after which a nonce is no longer used, as ACME
+	// servers only accept nonces for a limited time.
+	maxAge time.Duration
+	clock  clock.Clock
+
+	lock   sync.Mutex
+	nonces map[string][]pooledNonce
+}
+
+type pooledNonce struct {
+	value   string
+	expires time.Time
+}
+
+// NewNoncePool returns a NoncePool which keeps nonces for at most maxAge.
+func NewNoncePool(maxAge time.Duration, clock clock.Clock) *NoncePool {
+	return &NoncePool{
+		maxAge: maxAge,
+		clock:  clock,
+		nonces: make(map[string][]pooledNonce),
+	}
+}
+
+// add stores a nonce under the given key, unless the pool for the key is full.
+func (p *NoncePool) add(key, nonce string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.nonces[key]) >= maxPooledNonces {
+		return
+	}
+	p.nonces[key] = append(p.nonces[key], pooledNonce{value: nonce, expires: p.clock.Now().Add(p.maxAge)})
+}
+
+// pop removes and returns the most recently stored nonce under the given
+// key which has not expired. It returns false if there is none.
+func (p *NoncePool) pop(key string) (string, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := p.clock.Now()
+	nonces := p.nonces[key]
+	for len(nonces) > 0 {
+		n := nonces[len(nonces)-1]
+		nonces = nonces[:len(nonces)-1]
+		if now.Before(n.expires) {
+			p.nonces[key] = nonces
+			return n.value, true
+		}
+	}
+	delete(p.nonces, key)
+	return "", false
+}
+
+// Clear removes all nonces stored under the given key.
+func (p *NoncePool) Clear(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.nonces, key)
+}
+
+// WithNoncePool returns a copy of the given HTTP client which stores the
+// nonces returned by the ACME server in the given pool, under the given key,
+// and answers the ACME client's requests for a new nonce from the pool.
+// The nonces are removed from the responses, so that the ACME client cannot
+// use them a second time. The pool is cleared if the ACME server rejects a
+// nonce.
+func WithNoncePool(client *http.Client, pool *NoncePool, key string) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	wrapped := client.Transport
+	if wrapped == nil {
+		wrapped = http.DefaultTransport
+	}
+
+	withPool := *client
+	withPool.Transport = &noncePoolTransport{
+		wrappedRT: wrapped,
+		pool:      pool,
+		key:       key,
+	}
+	return &withPool
+}
+
+// noncePoolTransport is a http.RoundTripper which pools the nonces returned
+// by an ACME server.
+type noncePoolTransport struct {
+	wrappedRT http.RoundTripper
+	pool      *NoncePool
+	key       string
+}
+
+func (t *noncePoolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The ACME client only sends HEAD requests to fetch a new nonce.
+	if req.Method == http.MethodHead {
+		if nonce, ok := t.pool.pop(t.key); ok {
+			return nonceResponse(req, nonce), nil
+		}
+		// the fetched nonce is returned to the ACME client to be used
+		// straight away
+		return t.wrappedRT.RoundTrip(req)
+	}
+
+	resp, err := t.wrappedRT.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if isBadNonceResponse(resp) {
+		// Consider all the pooled nonces to be invalid, as the ACME client
+		// does with the nonces it stores itself.
+		t.pool.Clear(t.key)
+	}
+	if nonce := resp.Header.Get(replayNonceHeader); nonce != "" {
+		t.pool.add(t.key, nonce)
+		resp.Header.Del(replayNonceHeader)
+	}
+	return resp, nil
+}
+
+// nonceResponse returns a response to a request for a new nonce carrying
+// the given nonce.
+func nonceResponse(req *http.Request, nonce string) *http.Response {
+	header := make(http.Header)
+	header.Set(replayNonceHeader, nonce)
+	header.Set("Cache-Control", "no-store")
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}
+}
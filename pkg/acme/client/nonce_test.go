@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+// nonceServer is an ACME server which checks that every nonce is only used
+// once, and counts the requests made to its newNonce endpoint.
+type nonceServer struct {
+	*httptest.Server
+
+	lock sync.Mutex
+	// issued is the set of nonces that have been issued and not yet used
+	issued    map[string]bool
+	next      int
+	newNonces int
+	badNonces int
+}
+
+func newNonceServer() *nonceServer {
+	s := &nonceServer{issued: make(map[string]bool)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *nonceServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch r.URL.Path {
+	case "/directory":
+		// like Let's Encrypt, the directory is returned without a nonce
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"newNonce":"%[1]s/nonce","newAccount":"%[1]s/account","newOrder":"%[1]s/order"}`, s.URL)
+	case "/nonce":
+		s.newNonces++
+		w.Header().Set(replayNonceHeader, s.issueNonce())
+	case "/account":
+		w.Header().Set(replayNonceHeader, s.issueNonce())
+		nonce := jwsNonce(r)
+		if !s.issued[nonce] {
+			s.badNonces++
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"type":"urn:ietf:params:acme:error:badNonce","detail":"invalid nonce %s"}`, nonce)
+			return
+		}
+		delete(s.issued, nonce)
+		w.Header().Set("Location", s.URL+"/account/1")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"valid"}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *nonceServer) issueNonce() string {
+	s.next++
+	nonce := fmt.Sprintf("nonce-%d", s.next)
+	s.issued[nonce] = true
+	return nonce
+}
+
+// forgetNonces invalidates all the nonces issued so far, as happens when an
+// ACME server is restarted.
+func (s *nonceServer) forgetNonces() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.issued = make(map[string]bool)
+}
+
+func (s *nonceServer) counts() (newNonces, badNonces int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.newNonces, s.badNonces
+}
+
+// jwsNonce returns the nonce in the protected header of the JWS in the body
+// of the request.
+func jwsNonce(r *http.Request) string {
+	var jws struct {
+		Protected string `json:"protected"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return ""
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return ""
+	}
+	var header struct {
+		Nonce string `json:"nonce"`
+	}
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return ""
+	}
+	return header.Nonce
+}
+
+// syncAccount builds a new ACME client, as is done every time an Issuer is
+// synced, and looks up the ACME account twice.
+func syncAccount(t *testing.T, cl *http.Client, directoryURL string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acmeClient := &acme.Client{
+		Key:          key,
+		HTTPClient:   cl,
+		DirectoryURL: directoryURL,
+		RetryBackoff: func(int, *http.Request, *http.Response) time.Duration { return time.Millisecond },
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := acmeClient.GetReg(context.Background(), ""); err != nil {
+			t.Fatalf("unexpected error looking up the ACME account: %v", err)
+		}
+	}
+}
+
+func TestNoncePoolReducesNonceRequests(t *testing.T) {
+	const syncs = 5
+
+	tests := map[string]struct {
+		withPool          bool
+		expectedNewNonces int
+	}{
+		"without a pool, every ACME client fetches a new nonce": {
+			expectedNewNonces: syncs,
+		},
+		"with a pool, a new nonce is only fetched by the first ACME client": {
+			withPool:          true,
+			expectedNewNonces: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newNonceServer()
+			defer server.Close()
+
+			pool := NewNoncePool(time.Minute, clock.RealClock{})
+			for i := 0; i < syncs; i++ {
+				cl := server.Client()
+				if test.withPool {
+					cl = WithNoncePool(cl, pool, "issuer")
+				}
+				syncAccount(t, cl, server.URL+"/directory")
+			}
+
+			newNonces, badNonces := server.counts()
+			if newNonces != test.expectedNewNonces {
+				t.Errorf("expected %d requests for a new nonce, got %d", test.expectedNewNonces, newNonces)
+			}
+			if badNonces != 0 {
+				t.Errorf("expected no nonce to be rejected, got %d", badNonces)
+			}
+		})
+	}
+}
+
+func TestNoncePoolClearedOnBadNonce(t *testing.T) {
+	server := newNonceServer()
+	defer server.Close()
+
+	pool := NewNoncePool(time.Minute, clock.RealClock{})
+	cl := WithNoncePool(server.Client(), pool, "issuer")
+
+	syncAccount(t, cl, server.URL+"/directory")
+	server.forgetNonces()
+	// the pooled nonce is rejected, after which the ACME client retries
+	// with the nonce returned with the rejection
+	syncAccount(t, cl, server.URL+"/directory")
+
+	newNonces, badNonces := server.counts()
+	if badNonces != 1 {
+		t.Errorf("expected 1 nonce to be rejected, got %d", badNonces)
+	}
+	if newNonces != 1 {
+		t.Errorf("expected 1 request for a new nonce, got %d", newNonces)
+	}
+}
+
+func TestNoncePool(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	pool := NewNoncePool(time.Minute, clock)
+
+	pool.add("issuer-a", "nonce-1")
+	clock.Step(30 * time.Second)
+	pool.add("issuer-a", "nonce-2")
+
+	if _, ok := pool.pop("issuer-b"); ok {
+		t.Errorf("expected no nonce to be shared between keys")
+	}
+	if nonce, ok := pool.pop("issuer-a"); !ok || nonce != "nonce-2" {
+		t.Errorf("expected the most recent nonce, got %q", nonce)
+	}
+
+	clock.Step(30 * time.Second)
+	if nonce, ok := pool.pop("issuer-a"); ok {
+		t.Errorf("expected an expired nonce not to be used, got %q", nonce)
+	}
+
+	pool.add("issuer-a", "nonce-3")
+	pool.Clear("issuer-a")
+	if nonce, ok := pool.pop("issuer-a"); ok {
+		t.Errorf("expected no nonce after the pool was cleared, got %q", nonce)
+	}
+
+	for i := 0; i < maxPooledNonces+1; i++ {
+		pool.add("issuer-a", fmt.Sprintf("nonce-%d", i))
+	}
+	if n := len(pool.nonces["issuer-a"]); n != maxPooledNonces {
+		t.Errorf("expected at most %d nonces to be pooled, got %d", maxPooledNonces, n)
+	}
+}
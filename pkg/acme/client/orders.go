@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+)
+
+// maxOrderListBodySize is the maximum size of a page of an orders list that is
+// read from the ACME server.
+const maxOrderListBodySize = 1 << 20
+
+// OrderLister lists the orders of an ACME account, as described in RFC 8555
+// section 7.1.2.1. The ACME client does not support this, so the POST-as-GET
+// requests for the pages of the list are signed by the OrderLister itself.
+type OrderLister struct {
+	// HTTPClient is used to make the requests to the ACME server.
+	HTTPClient *http.Client
+	// Key is the private key of the ACME account. Only RSA keys are
+	// supported, as they are the only keys cert-manager uses for accounts.
+	Key crypto.Signer
+	// UserAgent is sent with every request, if set.
+	UserAgent string
+	// NonceURL is the newNonce URL from the ACME server's directory.
+	NonceURL string
+	// AccountURL is the URL of the ACME account, which is used as the key ID
+	// of the signed requests.
+	AccountURL string
+}
+
+// ListOrders returns the URLs of the orders listed at the given orders URL of
+// the account, following the "next" links of a paginated list for at most
+// maxPages pages. complete is false if there were more pages to list.
+// ACME servers are only expected to list orders which have not become invalid.
+func (l *OrderLister) ListOrders(ctx context.Context, ordersURL string, maxPages int) (orders []string, complete bool, err error) {
+	if _, ok := l.Key.Public().(*rsa.PublicKey); !ok {
+		return nil, false, errors.New("only RSA ACME account keys are supported")
+	}
+
+	nonce, err := l.fetchNonce(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	next := ordersURL
+	for page := 0; page < maxPages; page++ {
+		var pageOrders []string
+		pageOrders, next, nonce, err = l.listPage(ctx, next, nonce)
+		if err != nil {
+			return nil, false, err
+		}
+		orders = append(orders, pageOrders...)
+		if next == "" {
+			return orders, true, nil
+		}
+	}
+	return orders, false, nil
+}
+
+// listPage returns the orders in the page of the list at the given URL, the
+// URL of the next page, if any, and the nonce to use for the next request.
+// The request is retried once with a fresh nonce if the nonce is rejected, as
+// the ACME client does.
+func (l *OrderLister) listPage(ctx context.Context, pageURL, nonce string) (orders []string, next, nextNonce string, err error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := l.postAsGet(ctx, pageURL, nonce)
+		if err != nil {
+			return nil, "", "", err
+		}
+		nonce = resp.Header.Get(replayNonceHeader)
+
+		if resp.StatusCode == http.StatusOK {
+			defer resp.Body.Close()
+			var list struct {
+				Orders []string `json:"orders"`
+			}
+			if err := json.NewDecoder(io.LimitReader(resp.Body, maxOrderListBodySize)).Decode(&list); err != nil {
+				return nil, "", "", fmt.Errorf("failed to decode the orders list: %w", err)
+			}
+			next, err := nextLink(resp)
+			if err != nil {
+				return nil, "", "", err
+			}
+			if nonce == "" {
+				nonce, err = l.fetchNonce(ctx)
+				if err != nil {
+					return nil, "", "", err
+				}
+			}
+			return list.Orders, next, nonce, nil
+		}
+
+		acmeErr := responseError(resp)
+		if attempt == 0 && strings.HasSuffix(strings.ToLower(acmeErr.ProblemType), ":badnonce") {
+			if nonce == "" {
+				nonce, err = l.fetchNonce(ctx)
+				if err != nil {
+					return nil, "", "", err
+				}
+			}
+			continue
+		}
+		return nil, "", "", acmeErr
+	}
+}
+
+// postAsGet makes a POST-as-GET request to the given URL, signed with the
+// account's key.
+func (l *OrderLister) postAsGet(ctx context.Context, url, nonce string) (*http.Response, error) {
+	body, err := l.signEmptyPayload(url, nonce)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+	if l.UserAgent != "" {
+		req.Header.Set("User-Agent", l.UserAgent)
+	}
+	return l.httpClient().Do(req)
+}
+
+// signEmptyPayload returns a JWS with an empty payload, in the flattened JSON
+// serialization, as required for POST-as-GET requests by RFC 8555 section 6.3.
+func (l *OrderLister) signEmptyPayload(url, nonce string) ([]byte, error) {
+	protected, err := json.Marshal(struct {
+		Alg   string `json:"alg"`
+		KID   string `json:"kid"`
+		Nonce string `json:"nonce"`
+		URL   string `json:"url"`
+	}{Alg: "RS256", KID: l.AccountURL, Nonce: nonce, URL: url})
+	if err != nil {
+		return nil, err
+	}
+	encodedProtected := base64.RawURLEncoding.EncodeToString(protected)
+
+	digest := sha256.Sum256([]byte(encodedProtected + "."))
+	sig, err := l.Key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return json.Marshal(struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}{
+		Protected: encodedProtected,
+		Payload:   "",
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+// fetchNonce fetches a fresh nonce from the ACME server's newNonce endpoint.
+func (l *OrderLister) fetchNonce(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, l.NonceURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if l.UserAgent != "" {
+		req.Header.Set("User-Agent", l.UserAgent)
+	}
+	resp, err := l.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	nonce := resp.Header.Get(replayNonceHeader)
+	if nonce == "" {
+		if resp.StatusCode > 299 {
+			return "", responseError(resp)
+		}
+		return "", errors.New("the ACME server did not return a nonce")
+	}
+	return nonce, nil
+}
+
+func (l *OrderLister) httpClient() *http.Client {
+	if l.HTTPClient != nil {
+		return l.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// nextLink returns the absolute URL of the "next" Link header of the given
+// response, or an empty string if there is none.
+func nextLink(resp *http.Response) (string, error) {
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				if !strings.EqualFold(strings.ReplaceAll(strings.TrimSpace(param), `"`, ""), "rel=next") {
+					continue
+				}
+				next, err := url.Parse(strings.Trim(target, "<>"))
+				if err != nil {
+					return "", fmt.Errorf("invalid next link %q: %w", target, err)
+				}
+				return resp.Request.URL.ResolveReference(next).String(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// responseError returns an *acme.Error built from the problem document in the
+// body of an unsuccessful response, and closes the body.
+func responseError(resp *http.Response) *acme.Error {
+	defer resp.Body.Close()
+	acmeErr := &acme.Error{StatusCode: resp.StatusCode, Header: resp.Header}
+	var problem struct {
+		Type   string `json:"type"`
+		Detail string `json:"detail"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOrderListBodySize)).Decode(&problem); err == nil {
+		acmeErr.ProblemType = problem.Type
+		acmeErr.Detail = problem.Detail
+	}
+	return acmeErr
+}
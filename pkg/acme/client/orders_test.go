@@ -0,0 +1,286 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/acme"
+)
+
+const testAccountPath = "/acme/acct/1"
+
+// ordersServer is an ACME server which returns the orders of an account in a
+// paginated list, and checks that the requests for the list are POST-as-GET
+// requests signed by the account's key with a fresh nonce.
+type ordersServer struct {
+	*httptest.Server
+	t   *testing.T
+	key *rsa.PublicKey
+	// pages are the orders in each page of the list
+	pages [][]string
+
+	lock sync.Mutex
+	// issued is the set of nonces that have been issued and not yet used
+	issued map[string]bool
+	next   int
+	// rejectNonces is the number of requests for which a valid nonce will be
+	// rejected
+	rejectNonces int
+	requests     int
+}
+
+func newOrdersServer(t *testing.T, key *rsa.PublicKey, pages [][]string) *ordersServer {
+	s := &ordersServer{t: t, key: key, pages: pages, issued: make(map[string]bool)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *ordersServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if r.URL.Path == "/acme/new-nonce" {
+		if r.Method != http.MethodHead {
+			s.t.Errorf("expected a HEAD request for a new nonce, got %s", r.Method)
+		}
+		w.Header().Set(replayNonceHeader, s.issueNonce())
+		return
+	}
+	if r.URL.Path != testAccountPath+"/orders" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.requests++
+	w.Header().Set(replayNonceHeader, s.issueNonce())
+	if err := s.verifyRequest(r); err != nil {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"type":"urn:ietf:params:acme:error:%s","detail":"%s"}`, err.Error(), err.Error())
+		return
+	}
+
+	page := 0
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		page, _ = strconv.Atoi(cursor)
+	}
+	if page+1 < len(s.pages) {
+		// a relative link, which must be resolved against the request URL
+		w.Header().Add("Link", fmt.Sprintf(`<%s/orders?cursor=%d>;rel="next"`, testAccountPath, page+1))
+	}
+	w.Header().Add("Link", fmt.Sprintf(`<%s/directory>;rel="index"`, s.URL))
+	w.Header().Set("Content-Type", "application/json")
+	orders := s.pages[page]
+	if orders == nil {
+		orders = []string{}
+	}
+	_ = json.NewEncoder(w).Encode(map[string][]string{"orders": orders})
+}
+
+// verifyRequest returns an error with the type of the ACME problem to return
+// if the request is not a valid POST-as-GET request.
+func (s *ordersServer) verifyRequest(r *http.Request) error {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/jose+json" {
+		return errors.New("malformed")
+	}
+	var jws struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil || jws.Payload != "" {
+		return errors.New("malformed")
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return errors.New("malformed")
+	}
+	var header struct {
+		Alg   string `json:"alg"`
+		KID   string `json:"kid"`
+		Nonce string `json:"nonce"`
+		URL   string `json:"url"`
+	}
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return errors.New("malformed")
+	}
+	if header.Alg != "RS256" || header.KID != s.URL+testAccountPath || header.URL != s.URL+r.URL.String() {
+		return errors.New("malformed")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+	if err != nil {
+		return errors.New("malformed")
+	}
+	digest := sha256.Sum256([]byte(jws.Protected + "."))
+	if err := rsa.VerifyPKCS1v15(s.key, crypto.SHA256, digest[:], sig); err != nil {
+		return errors.New("unauthorized")
+	}
+
+	if !s.issued[header.Nonce] || s.rejectNonces > 0 {
+		s.rejectNonces--
+		return errors.New("badNonce")
+	}
+	delete(s.issued, header.Nonce)
+	return nil
+}
+
+func (s *ordersServer) issueNonce() string {
+	s.next++
+	nonce := fmt.Sprintf("nonce-%d", s.next)
+	s.issued[nonce] = true
+	return nonce
+}
+
+func (s *ordersServer) lister(key crypto.Signer) *OrderLister {
+	return &OrderLister{
+		HTTPClient: s.Client(),
+		Key:        key,
+		NonceURL:   s.URL + "/acme/new-nonce",
+		AccountURL: s.URL + testAccountPath,
+	}
+}
+
+func TestOrderListerListOrders(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		pages        [][]string
+		maxPages     int
+		rejectNonces int
+
+		expectedOrders   []string
+		expectedComplete bool
+		expectedRequests int
+		expectedErr      string
+	}{
+		"lists a single page of orders": {
+			pages:            [][]string{{"order-1", "order-2"}},
+			maxPages:         10,
+			expectedOrders:   []string{"order-1", "order-2"},
+			expectedComplete: true,
+			expectedRequests: 1,
+		},
+		"lists an empty list of orders": {
+			pages:            [][]string{nil},
+			maxPages:         10,
+			expectedComplete: true,
+			expectedRequests: 1,
+		},
+		"follows the next links of a paginated list": {
+			pages:            [][]string{{"order-1", "order-2"}, {"order-3"}, {"order-4"}},
+			maxPages:         10,
+			expectedOrders:   []string{"order-1", "order-2", "order-3", "order-4"},
+			expectedComplete: true,
+			expectedRequests: 3,
+		},
+		"stops after the maximum number of pages": {
+			pages:            [][]string{{"order-1", "order-2"}, {"order-3"}, {"order-4"}},
+			maxPages:         2,
+			expectedOrders:   []string{"order-1", "order-2", "order-3"},
+			expectedRequests: 2,
+		},
+		"retries once with a fresh nonce if the nonce is rejected": {
+			pages:            [][]string{{"order-1"}, {"order-2"}},
+			maxPages:         10,
+			rejectNonces:     1,
+			expectedOrders:   []string{"order-1", "order-2"},
+			expectedComplete: true,
+			expectedRequests: 3,
+		},
+		"returns the error if the nonce is rejected again": {
+			pages:            [][]string{{"order-1"}},
+			maxPages:         10,
+			rejectNonces:     2,
+			expectedRequests: 2,
+			expectedErr:      "400 urn:ietf:params:acme:error:badNonce: badNonce",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newOrdersServer(t, &key.PublicKey, test.pages)
+			defer server.Close()
+			server.rejectNonces = test.rejectNonces
+
+			orders, complete, err := server.lister(key).ListOrders(context.Background(), server.URL+testAccountPath+"/orders", test.maxPages)
+			if test.expectedErr != "" {
+				var acmeErr *acme.Error
+				if !errors.As(err, &acmeErr) || err.Error() != test.expectedErr {
+					t.Errorf("expected ACME error %q, got %v", test.expectedErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(orders, test.expectedOrders) {
+				t.Errorf("expected orders %v, got %v", test.expectedOrders, orders)
+			}
+			if complete != test.expectedComplete {
+				t.Errorf("expected complete to be %t, got %t", test.expectedComplete, complete)
+			}
+			if server.requests != test.expectedRequests {
+				t.Errorf("expected %d requests for the orders list, got %d", test.expectedRequests, server.requests)
+			}
+		})
+	}
+}
+
+func TestOrderListerRejectsWrongKey(t *testing.T) {
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newOrdersServer(t, &accountKey.PublicKey, [][]string{{"order-1"}})
+	defer server.Close()
+
+	_, _, err = server.lister(otherKey).ListOrders(context.Background(), server.URL+testAccountPath+"/orders", 10)
+	var acmeErr *acme.Error
+	if !errors.As(err, &acmeErr) || acmeErr.ProblemType != "urn:ietf:params:acme:error:unauthorized" {
+		t.Errorf("expected the request signed with the wrong key to be rejected, got %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := server.lister(ecKey).ListOrders(context.Background(), server.URL+testAccountPath+"/orders", 10); err == nil {
+		t.Errorf("expected an error for an unsupported key type")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxRetryDelay is the longest time waited before retrying a request.
+const maxRetryDelay = 10 * time.Second
+
+// WithTimeoutAndRetries returns a copy of the given client in which every
+// request to the ACME server is given the timeout to complete, including
+// reading the response body. Idempotent requests (GET and HEAD) which fail
+// with a network error, a timeout or a 5xx response are retried up to
+// maxRetries times, each attempt being given the full timeout.
+// Other requests are never retried by this client, as the JWS they carry
+// consumes a nonce and may change state on the ACME server. The ACME library
+// retries those by itself, with a fresh nonce, where it is safe to do so.
+// A timeout of zero or less disables the timeout.
+func WithTimeoutAndRetries(client *http.Client, timeout time.Duration, maxRetries int) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	wrapped := client.Transport
+	if wrapped == nil {
+		wrapped = http.DefaultTransport
+	}
+
+	withRetries := *client
+	// The timeout is enforced on each attempt by the transport, rather than
+	// on the request as a whole.
+	withRetries.Timeout = 0
+	withRetries.Transport = &retryTransport{
+		wrappedRT:  wrapped,
+		timeout:    timeout,
+		maxRetries: maxRetries,
+		backoff:    retryBackoff,
+	}
+	return &withRetries
+}
+
+// retryTransport is a http.RoundTripper which bounds the time each request
+// may take and retries failed idempotent requests.
+type retryTransport struct {
+	wrappedRT  http.RoundTripper
+	timeout    time.Duration
+	maxRetries int
+
+	// backoff returns the time to wait before the nth retry of a request.
+	backoff func(n int) time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryable := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	for n := 0; ; n++ {
+		resp, err := t.roundTripOnce(req)
+		if !retryable || n >= t.maxRetries || req.Context().Err() != nil {
+			return resp, err
+		}
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if err == nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(t.backoff(n + 1)):
+		}
+	}
+}
+
+// roundTripOnce makes a single attempt at the request, bounded by the
+// timeout. The timeout continues to apply until the response body is closed.
+func (t *retryTransport) roundTripOnce(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.wrappedRT.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.wrappedRT.RoundTrip(req.Clone(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryBackoff doubles the time waited before each retry, starting at 1
+// second and up to maxRetryDelay.
+func retryBackoff(n int) time.Duration {
+	// avoid overflowing the shift below
+	if n > 8 {
+		return maxRetryDelay
+	}
+	d := time.Duration(1<<uint(n-1)) * time.Second
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
+
+// cancelOnClose releases the context of a request once its response body has
+// been closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
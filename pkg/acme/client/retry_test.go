@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestRetryClient returns a client for the server which does not wait
+// between retries.
+func newTestRetryClient(server *httptest.Server, timeout time.Duration, maxRetries int) *http.Client {
+	cl := WithTimeoutAndRetries(server.Client(), timeout, maxRetries)
+	cl.Transport.(*retryTransport).backoff = func(int) time.Duration { return 0 }
+	return cl
+}
+
+func TestWithTimeoutAndRetries(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		maxRetries int
+		// failures is the number of requests the server fails before
+		// responding successfully.
+		failures        int32
+		expectedStatus  int
+		expectedAttempt int32
+	}{
+		"a failing GET request is retried until it succeeds": {
+			method:          http.MethodGet,
+			maxRetries:      3,
+			failures:        2,
+			expectedStatus:  http.StatusOK,
+			expectedAttempt: 3,
+		},
+		"a failing HEAD request is retried until it succeeds": {
+			method:          http.MethodHead,
+			maxRetries:      3,
+			failures:        1,
+			expectedStatus:  http.StatusOK,
+			expectedAttempt: 2,
+		},
+		"a failing GET request is retried at most maxRetries times": {
+			method:          http.MethodGet,
+			maxRetries:      2,
+			failures:        5,
+			expectedStatus:  http.StatusServiceUnavailable,
+			expectedAttempt: 3,
+		},
+		"a failing GET request is not retried if retries are disabled": {
+			method:          http.MethodGet,
+			failures:        1,
+			expectedStatus:  http.StatusServiceUnavailable,
+			expectedAttempt: 1,
+		},
+		"a failing POST request is never retried": {
+			method:          http.MethodPost,
+			maxRetries:      3,
+			failures:        1,
+			expectedStatus:  http.StatusServiceUnavailable,
+			expectedAttempt: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			cl := newTestRetryClient(server, time.Second, test.maxRetries)
+			req, err := http.NewRequest(test.method, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := cl.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != test.expectedStatus {
+				t.Errorf("expected status %d, got %d", test.expectedStatus, resp.StatusCode)
+			}
+			if got := atomic.LoadInt32(&attempts); got != test.expectedAttempt {
+				t.Errorf("expected %d attempts, got %d", test.expectedAttempt, got)
+			}
+		})
+	}
+}
+
+func TestWithTimeoutAndRetriesTimeout(t *testing.T) {
+	// slow is closed at the end of the test to release the handlers of
+	// requests which timed out.
+	slow := make(chan struct{})
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// only the first two attempts are slow
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			select {
+			case <-slow:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	defer close(slow)
+
+	t.Run("a slow request times out", func(t *testing.T) {
+		atomic.StoreInt32(&attempts, 0)
+		cl := newTestRetryClient(server, 50*time.Millisecond, 0)
+
+		start := time.Now()
+		_, err := cl.Get(server.URL)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("expected the request to time out promptly, took %s", elapsed)
+		}
+	})
+
+	t.Run("a slow idempotent request is retried with a new timeout", func(t *testing.T) {
+		atomic.StoreInt32(&attempts, 0)
+		cl := newTestRetryClient(server, 50*time.Millisecond, 2)
+
+		resp, err := cl.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unexpected error reading the body: %v", err)
+		}
+		if string(body) != "ok" {
+			t.Errorf("unexpected body %q", body)
+		}
+		if got := atomic.LoadInt32(&attempts); got != 3 {
+			t.Errorf("expected 3 attempts, got %d", got)
+		}
+	})
+}
+
+func TestWithTimeoutAndRetriesBodyTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cl := newTestRetryClient(server, 100*time.Millisecond, 0)
+	resp, err := cl.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// the timeout continues to apply while the body is read
+	_, err = io.ReadAll(resp.Body)
+	if err == nil || !strings.Contains(err.Error(), "context deadline exceeded") {
+		t.Errorf("expected reading the body to time out, got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health implements a health check that reports whether the ACME
+// directories configured on Issuers and ClusterIssuers can be reached.
+package health
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// Path is the path on which the ACME directory health check is served.
+	Path = "/healthz/acme"
+
+	// defaultRequestTimeout is the maximum time allowed for a single
+	// directory to respond before it is considered unreachable.
+	defaultRequestTimeout = 10 * time.Second
+)
+
+// Directory is an ACME directory whose reachability should be checked.
+type Directory struct {
+	// URL is the ACME directory URL, as configured in `spec.acme.server`.
+	URL string
+
+	// SkipTLSVerify disables verification of the directory's TLS certificate,
+	// matching `spec.acme.skipTLSVerify` on the issuer.
+	SkipTLSVerify bool
+}
+
+// DirectoryLister returns the ACME directories that should be checked.
+type DirectoryLister func(ctx context.Context) ([]Directory, error)
+
+// Result is the outcome of checking a single ACME directory.
+type Result struct {
+	URL string
+	// Err is nil if the directory was reachable.
+	Err error
+}
+
+// Report is the outcome of checking all configured ACME directories.
+type Report struct {
+	// Results holds one entry per unique directory URL, sorted by URL.
+	Results []Result
+	// Err is set if the list of directories could not be determined.
+	Err error
+	// CheckedAt is the time the directories were last checked.
+	CheckedAt time.Time
+}
+
+// Healthy returns true if every configured ACME directory was reachable.
+func (r *Report) Healthy() bool {
+	if r.Err != nil {
+		return false
+	}
+	for _, res := range r.Results {
+		if res.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Checker checks the reachability of ACME directories. Results are cached so
+// that frequent probes do not translate into frequent requests to the ACME
+// servers or the Kubernetes API server.
+type Checker struct {
+	listDirectories DirectoryLister
+	cacheDuration   time.Duration
+
+	client         *http.Client
+	insecureClient *http.Client
+
+	clock clock.Clock
+
+	// lock is held for the duration of a check so that concurrent probes
+	// share a single check rather than each querying the ACME servers.
+	lock   sync.Mutex
+	report *Report
+}
+
+// NewChecker returns a Checker that checks the directories returned by
+// listDirectories at most once per cacheDuration.
+func NewChecker(listDirectories DirectoryLister, cacheDuration time.Duration) *Checker {
+	return &Checker{
+		listDirectories: listDirectories,
+		cacheDuration:   cacheDuration,
+		client:          newHTTPClient(false),
+		insecureClient:  newHTTPClient(true),
+		clock:           clock.RealClock{},
+	}
+}
+
+func newHTTPClient(skipTLSVerify bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: skipTLSVerify}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   defaultRequestTimeout,
+	}
+}
+
+// Check returns the reachability of the configured ACME directories, only
+// querying them if the cached report is older than the cache duration.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Now()
+	if c.report != nil && now.Sub(c.report.CheckedAt) < c.cacheDuration {
+		return c.report
+	}
+
+	c.report = c.check(ctx)
+	c.report.CheckedAt = now
+	return c.report
+}
+
+func (c *Checker) check(ctx context.Context) *Report {
+	directories, err := c.listDirectories(ctx)
+	if err != nil {
+		return &Report{Err: fmt.Errorf("failed to list ACME directories: %w", err)}
+	}
+
+	// Several issuers commonly share the same ACME server, so each directory
+	// URL is only checked once.
+	unique := make(map[string]Directory)
+	for _, dir := range directories {
+		if existing, ok := unique[dir.URL]; ok && !existing.SkipTLSVerify {
+			continue
+		}
+		unique[dir.URL] = dir
+	}
+
+	results := make([]Result, 0, len(unique))
+	var wg sync.WaitGroup
+	var resultsLock sync.Mutex
+	for _, dir := range unique {
+		wg.Add(1)
+		go func(dir Directory) {
+			defer wg.Done()
+			err := c.checkDirectory(ctx, dir)
+
+			resultsLock.Lock()
+			defer resultsLock.Unlock()
+			results = append(results, Result{URL: dir.URL, Err: err})
+		}(dir)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].URL < results[j].URL
+	})
+
+	return &Report{Results: results}
+}
+
+func (c *Checker) checkDirectory(ctx context.Context, dir Directory) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dir.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	client := c.client
+	if dir.SkipTLSVerify {
+		client = c.insecureClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ServeHTTP responds with 200 if all configured ACME directories are
+// reachable and with 503 if any of them are not. The body lists the status of
+// each directory.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	// The check is not tied to the probe request so that a probe which times
+	// out does not cause a failed result to be cached.
+	report := c.Check(context.Background())
+
+	var body bytes.Buffer
+	if report.Err != nil {
+		fmt.Fprintf(&body, "[-]%v\n", report.Err)
+	}
+	for _, res := range report.Results {
+		if res.Err != nil {
+			fmt.Fprintf(&body, "[-]%s unreachable: %v\n", res.URL, res.Err)
+		} else {
+			fmt.Fprintf(&body, "[+]%s ok\n", res.URL)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if !report.Healthy() {
+		logf.FromContext(req.Context()).V(logf.WarnLevel).Info("ACME directory health check failed", "report", body.String())
+		w.WriteHeader(http.StatusServiceUnavailable)
+		body.WriteString("acme directory check degraded\n")
+	} else {
+		w.WriteHeader(http.StatusOK)
+		body.WriteString("acme directory check passed\n")
+	}
+
+	_, _ = body.WriteTo(w)
+}
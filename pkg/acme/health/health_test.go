@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func newFakeDirectory(t *testing.T, status int, requests *int32) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			atomic.AddInt32(requests, 1)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL + "/directory"
+}
+
+func newUnreachableDirectory() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL + "/directory"
+	server.Close()
+	return url
+}
+
+func staticLister(urls ...string) DirectoryLister {
+	return func(context.Context) ([]Directory, error) {
+		var dirs []Directory
+		for _, url := range urls {
+			dirs = append(dirs, Directory{URL: url})
+		}
+		return dirs, nil
+	}
+}
+
+func TestCheckerServeHTTP(t *testing.T) {
+	reachable := newFakeDirectory(t, http.StatusOK, nil)
+	erroring := newFakeDirectory(t, http.StatusInternalServerError, nil)
+	unreachable := newUnreachableDirectory()
+
+	tests := map[string]struct {
+		lister DirectoryLister
+
+		expectedStatus int
+		expectedBody   []string
+	}{
+		"no configured directories is healthy": {
+			lister:         staticLister(),
+			expectedStatus: http.StatusOK,
+		},
+		"all directories reachable is healthy": {
+			lister:         staticLister(reachable, reachable),
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"[+]" + reachable + " ok"},
+		},
+		"an unreachable directory is degraded": {
+			lister:         staticLister(reachable, unreachable),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: []string{
+				"[+]" + reachable + " ok",
+				"[-]" + unreachable + " unreachable",
+			},
+		},
+		"a directory returning an error status is degraded": {
+			lister:         staticLister(erroring),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   []string{"[-]" + erroring + " unreachable: unexpected status code 500"},
+		},
+		"failing to list directories is degraded": {
+			lister: func(context.Context) ([]Directory, error) {
+				return nil, errors.New("api server down")
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   []string{"[-]failed to list ACME directories: api server down"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewChecker(test.lister, time.Minute)
+
+			rec := httptest.NewRecorder()
+			c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+
+			if rec.Code != test.expectedStatus {
+				t.Errorf("unexpected status code, exp=%d got=%d", test.expectedStatus, rec.Code)
+			}
+			body := rec.Body.String()
+			for _, exp := range test.expectedBody {
+				if !strings.Contains(body, exp) {
+					t.Errorf("expected body to contain %q, got:\n%s", exp, body)
+				}
+			}
+			if strings.Count(body, reachable) > 1 {
+				t.Errorf("expected each directory to be reported once, got:\n%s", body)
+			}
+		})
+	}
+}
+
+func TestCheckerCachesResults(t *testing.T) {
+	var requests int32
+	url := newFakeDirectory(t, http.StatusOK, &requests)
+
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	c := NewChecker(staticLister(url), time.Minute)
+	c.clock = fakeClock
+
+	c.Check(context.Background())
+	c.Check(context.Background())
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected cached result to be used, got %d requests", got)
+	}
+
+	fakeClock.Step(time.Minute)
+	if report := c.Check(context.Background()); !report.Healthy() {
+		t.Errorf("expected healthy report, got %+v", report)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected directory to be checked again after the cache expired, got %d requests", got)
+	}
+}
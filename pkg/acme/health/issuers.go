@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+// IssuerDirectoryLister returns a DirectoryLister that lists the ACME
+// directories configured on Issuers in the given namespace, or in all
+// namespaces if namespace is empty. ClusterIssuers are only included when
+// namespace is empty, matching the resources the controller reconciles.
+func IssuerDirectoryLister(cl clientset.Interface, namespace string) DirectoryLister {
+	return func(ctx context.Context) ([]Directory, error) {
+		var issuers []cmapi.GenericIssuer
+
+		issuerList, err := cl.CertmanagerV1().Issuers(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range issuerList.Items {
+			issuers = append(issuers, &issuerList.Items[i])
+		}
+
+		if namespace == "" {
+			clusterIssuerList, err := cl.CertmanagerV1().ClusterIssuers().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			for i := range clusterIssuerList.Items {
+				issuers = append(issuers, &clusterIssuerList.Items[i])
+			}
+		}
+
+		var directories []Directory
+		for _, issuer := range issuers {
+			acme := issuer.GetSpec().ACME
+			if acme == nil || len(acme.Server) == 0 {
+				continue
+			}
+			directories = append(directories, Directory{
+				URL:           acme.Server,
+				SkipTLSVerify: acme.SkipTLSVerify,
+			})
+		}
+
+		return directories, nil
+	}
+}
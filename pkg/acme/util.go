@@ -51,3 +51,34 @@ func PrivateKeySelector(sel cmmeta.SecretKeySelector) cmmeta.SecretKeySelector {
 	}
 	return sel
 }
+
+// SolverChallengeType returns the type of challenge the given solver is able
+// to solve, or an empty string if it does not configure a supported type.
+func SolverChallengeType(s cmacme.ACMEChallengeSolver) cmacme.ACMEChallengeType {
+	switch {
+	case s.HTTP01 != nil:
+		return cmacme.ACMEChallengeTypeHTTP01
+	case s.DNS01 != nil:
+		return cmacme.ACMEChallengeTypeDNS01
+	}
+	return ""
+}
+
+// HasFallbackChallengeType returns true if the issuer's challenge type
+// preference lists the challenge type t and at least one later challenge
+// type for which a solver is configured.
+func HasFallbackChallengeType(iss *cmacme.ACMEIssuer, t cmacme.ACMEChallengeType) bool {
+	found := false
+	for _, pt := range iss.ChallengeTypePreference {
+		if !found {
+			found = pt == t
+			continue
+		}
+		for _, s := range iss.Solvers {
+			if SolverChallengeType(s) == pt {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -95,6 +95,22 @@ func SetIssuerCondition(i cmapi.GenericIssuer, observedGeneration int64, conditi
 	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Issuer %q condition %q to %v", i.GetObjectMeta().Name, conditionType, nowTime.Time)
 }
 
+// RemoveIssuerCondition will remove any condition with this condition type.
+// This function works with both Issuer and ClusterIssuer resources.
+func RemoveIssuerCondition(i cmapi.GenericIssuer, conditionType cmapi.IssuerConditionType) {
+	var updatedConditions []cmapi.IssuerCondition
+
+	// Search through existing conditions
+	for _, cond := range i.GetStatus().Conditions {
+		// Only add unrelated conditions
+		if cond.Type != conditionType {
+			updatedConditions = append(updatedConditions, cond)
+		}
+	}
+
+	i.GetStatus().Conditions = updatedConditions
+}
+
 // CertificateHasCondition will return true if the given Certificate has a
 // condition matching the provided CertificateCondition.
 // Only the Type and Status field will be used in the comparison, meaning that
@@ -283,12 +299,14 @@ func CertificateRequestHasCondition(cr *cmapi.CertificateRequest, c cmapi.Certif
 }
 
 // This returns the status reason of a CertificateRequest. The order of reason
-// hierarchy is 'Failed' -> 'Ready' -> 'Pending' -> ''
+// hierarchy is 'Failed' -> 'Ready' -> 'Pending' -> 'RateLimited' -> 'AuthFailed' -> ''
 func CertificateRequestReadyReason(cr *cmapi.CertificateRequest) string {
 	for _, reason := range []string{
 		cmapi.CertificateRequestReasonFailed,
 		cmapi.CertificateRequestReasonIssued,
 		cmapi.CertificateRequestReasonPending,
+		cmapi.CertificateRequestReasonRateLimited,
+		cmapi.CertificateRequestReasonAuthFailed,
 		cmapi.CertificateRequestReasonDenied,
 	} {
 		for _, con := range cr.Status.Conditions {
@@ -17,11 +17,17 @@ limitations under the License.
 package util
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"strconv"
+	"strings"
+	"text/template"
 
 	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // ComputeName hashes the given object and prefixes it with prefix.
@@ -56,3 +62,52 @@ func DNSSafeShortenTo52Characters(in string) string {
 
 	return in
 }
+
+// CertificateRequestNameData is the data a CertificateRequest naming template
+// is executed with.
+type CertificateRequestNameData struct {
+	// Name is the name of the Certificate the request is created for.
+	Name string
+	// Namespace is the namespace of the Certificate.
+	Namespace string
+}
+
+// ParseCertificateRequestNameTemplate parses a text/template used to name the
+// CertificateRequests created for a Certificate, and checks that it produces
+// a valid name for an example Certificate.
+func ParseCertificateRequestNameTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("certificate-request-name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ComputeCertificateRequestName(tmpl, CertificateRequestNameData{Name: "example", Namespace: "default"}, 1); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// ComputeCertificateRequestName executes the naming template for the given
+// Certificate and suffixes the result with the revision, so that the
+// requests for each revision have a different name. The executed template is
+// shortened so that the name stays within the same length limits as names
+// generated from the Certificate's name.
+func ComputeCertificateRequestName(tmpl *template.Template, data CertificateRequestNameData, revision int) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute CertificateRequest name template: %w", err)
+	}
+
+	prefix := strings.TrimSpace(buf.String())
+	if len(prefix) == 0 {
+		return "", fmt.Errorf("CertificateRequest name template produced an empty name")
+	}
+
+	name := DNSSafeShortenTo52Characters(prefix) + "-" + strconv.Itoa(revision)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("CertificateRequest name template produced an invalid name %q: %s", name, strings.Join(errs, ", "))
+	}
+
+	return name, nil
+}
@@ -18,6 +18,7 @@ package util
 
 import (
 	"testing"
+	"text/template"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -111,3 +112,136 @@ func TestComputeName(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeCertificateRequestName(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     CertificateRequestNameData
+		revision int
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Name follows the template",
+			template: "{{ .Namespace }}-{{ .Name }}",
+			data:     CertificateRequestNameData{Name: "unit-test", Namespace: "team-a"},
+			revision: 1,
+			want:     "team-a-unit-test-1",
+		},
+		{
+			name:     "Name includes the revision",
+			template: "{{ .Name }}-request",
+			data:     CertificateRequestNameData{Name: "unit-test", Namespace: "team-a"},
+			revision: 42,
+			want:     "unit-test-request-42",
+		},
+		{
+			name:     "Surrounding whitespace is ignored",
+			template: "  {{ .Name }}\n",
+			data:     CertificateRequestNameData{Name: "unit-test", Namespace: "team-a"},
+			revision: 3,
+			want:     "unit-test-3",
+		},
+		{
+			name:     "Too long names are shortened",
+			template: "{{ .Namespace }}-{{ .Name }}",
+			data:     CertificateRequestNameData{Name: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Namespace: "team-a"},
+			revision: 1000,
+			want:     "team-a-aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa-1000",
+		},
+		{
+			name:     "Template producing an empty name",
+			template: `{{ if false }}{{ .Name }}{{ end }}`,
+			data:     CertificateRequestNameData{Name: "unit-test", Namespace: "team-a"},
+			revision: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "Template producing an invalid name",
+			template: "{{ .Name }}_request",
+			data:     CertificateRequestNameData{Name: "unit-test", Namespace: "team-a"},
+			revision: 1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Option("missingkey=error").Parse(tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ComputeCertificateRequestName(tmpl, tt.data, tt.revision)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ComputeCertificateRequestName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ComputeCertificateRequestName() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && len(validation.IsDNS1123Subdomain(got)) != 0 {
+				t.Errorf("ComputeCertificateRequestName() = %v is not DNS-1123 valid", got)
+			}
+		})
+	}
+}
+
+func TestComputeCertificateRequestNameUniqueAcrossRevisions(t *testing.T) {
+	tmpl, err := ParseCertificateRequestNameTemplate("{{ .Namespace }}-{{ .Name }}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := CertificateRequestNameData{
+		Name:      "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		Namespace: "team-a",
+	}
+	seen := make(map[string]int)
+	for revision := 1; revision <= 1000; revision++ {
+		name, err := ComputeCertificateRequestName(tmpl, data, revision)
+		if err != nil {
+			t.Fatalf("unexpected error for revision %d: %v", revision, err)
+		}
+		if previous, ok := seen[name]; ok {
+			t.Fatalf("revisions %d and %d have the same name %q", previous, revision, name)
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+			t.Fatalf("name %q for revision %d is not valid: %v", name, revision, errs)
+		}
+		seen[name] = revision
+	}
+}
+
+func TestParseCertificateRequestNameTemplate(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		wantErr  bool
+	}{
+		"valid template": {
+			template: "{{ .Namespace }}-{{ .Name }}",
+		},
+		"template without fields": {
+			template: "static-name",
+		},
+		"malformed template": {
+			template: "{{ .Name ",
+			wantErr:  true,
+		},
+		"unknown field": {
+			template: "{{ .Issuer }}",
+			wantErr:  true,
+		},
+		"template producing an invalid name": {
+			template: "{{ .Name }}.-",
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseCertificateRequestNameTemplate(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCertificateRequestNameTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -52,6 +52,23 @@ var extKeyUsages = map[cmapi.KeyUsage]x509.ExtKeyUsage{
 	cmapi.UsageNetscapeSGC:     x509.ExtKeyUsageNetscapeServerGatedCrypto,
 }
 
+var usageProfiles = map[cmapi.UsageProfile][]cmapi.KeyUsage{
+	cmapi.ServerAuthUsageProfile:  {cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth},
+	cmapi.ClientAuthUsageProfile:  {cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageClientAuth},
+	cmapi.MutualTLSUsageProfile:   {cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+	cmapi.CodeSigningUsageProfile: {cmapi.UsageDigitalSignature, cmapi.UsageCodeSigning},
+}
+
+// UsagesForProfile returns a copy of the usages the given usage profile
+// expands to, or false if the profile is not known.
+func UsagesForProfile(profile cmapi.UsageProfile) ([]cmapi.KeyUsage, bool) {
+	usages, ok := usageProfiles[profile]
+	if !ok {
+		return nil, false
+	}
+	return append([]cmapi.KeyUsage(nil), usages...), true
+}
+
 // KeyUsageType returns the relevant x509.KeyUsage or false if not found
 func KeyUsageType(usage cmapi.KeyUsage) (x509.KeyUsage, bool) {
 	u, ok := keyUsages[usage]
@@ -34,10 +34,32 @@ const (
 	// solver for each ingress class.
 	ACMECertificateHTTP01IngressClassOverride = "acme.cert-manager.io/http01-override-ingress-class"
 
+	// ACMECertificateHTTP01PortOverride is annotation to override the solver port.
+	// If this annotation is specified on a Certificate or Order resource when
+	// using the HTTP01 ingress or gatewayHTTPRoute solver types, the port
+	// field of the HTTP01 solver's configuration will be set to the value
+	// given here.
+	// This is useful for ingress controllers which expect backends to be
+	// reachable on a specific port.
+	ACMECertificateHTTP01PortOverride = "acme.cert-manager.io/http01-override-port"
+
 	// IngressEditInPlaceAnnotationKey is used to toggle the use of ingressClass instead
 	// of ingress on the created Certificate resource
 	IngressEditInPlaceAnnotationKey = "acme.cert-manager.io/http01-edit-in-place"
 
+	// CaptureACMEStateAnnotationKey can be set to "true" on an Order to record
+	// the state of the ACME order and its authorizations, as last returned by
+	// the ACME server, as events on the Order. Challenge tokens are not
+	// recorded. This is intended for debugging Orders which do not complete.
+	CaptureACMEStateAnnotationKey = "acme.cert-manager.io/capture-acme-state"
+
+	// DNS01KeyOverrideAnnotationKey can be set on a Challenge to present the
+	// given value in the DNS01 challenge record, instead of the key
+	// authorization of the ACME challenge. It is only honoured if the
+	// ExperimentalDNS01KeyOverride feature gate is enabled, and is intended
+	// for tests which need the presented record to have a known value.
+	DNS01KeyOverrideAnnotationKey = "acme.cert-manager.io/dns01-key-override"
+
 	// DomainLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the hash of the domain name that is being verified.
 	DomainLabelKey = "acme.cert-manager.io/http-domain"
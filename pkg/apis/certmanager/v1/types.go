@@ -94,6 +94,34 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// RenewAtAnnotation is an annotation that can be added to Certificate
+	// resources to declaratively request a renewal. Its value is an RFC3339
+	// timestamp: once that time has passed, the Certificate is re-issued
+	// unless its current certificate was already requested after that time.
+	RenewAtAnnotation = "cert-manager.io/renew-at"
+
+	// AllowExternalIssuerKindAnnotation is an annotation that can be added to
+	// Certificate and CertificateRequest resources.
+	// If set to "true", the webhook will accept an issuerRef which uses the
+	// Issuer or ClusterIssuer kind together with a group other than
+	// cert-manager.io. This is only needed for external issuers which define
+	// their own Issuer or ClusterIssuer kinds.
+	AllowExternalIssuerKindAnnotation = "cert-manager.io/allow-external-issuer-kind"
+
+	// SecretOwnerReferenceAnnotation is an annotation that can be added to
+	// Certificate resources to override whether the Certificate is set as the
+	// owner of its Secret, which would cause the Secret to be garbage
+	// collected when the Certificate is deleted.
+	// If set to "true" or "false", it takes precedence over the controller's
+	// --enable-certificate-owner-ref flag.
+	SecretOwnerReferenceAnnotation = "cert-manager.io/secret-owner-reference"
+
+	// AdoptSecretAnnotation is an annotation that can be added to Certificate
+	// resources to allow cert-manager to overwrite an existing Secret which
+	// already holds data but was not written by cert-manager.
+	// If not set to "true", issuance fails rather than overwriting the data.
+	AdoptSecretAnnotation = "cert-manager.io/adopt-secret"
 )
 
 // Common/known resource kinds.
@@ -121,6 +149,17 @@ const (
 	// as namespace/name.
 	WantInjectFromSecretAnnotation = "cert-manager.io/inject-ca-from-secret"
 
+	// WantInjectWebhooksAnnotation limits the injection of the CA into a
+	// MutatingWebhookConfiguration or ValidatingWebhookConfiguration to the
+	// webhooks named in it, as a comma separated list. The CA bundles of all
+	// other webhooks in the configuration are left unchanged.
+	// Only a single CA is injected per configuration, from the source named
+	// by the inject-ca-from, inject-ca-from-secret or inject-apiserver-ca
+	// annotation; this annotation does not allow a different CA to be
+	// injected into each webhook. The CA bundles of webhooks that are not
+	// named must be managed separately.
+	WantInjectWebhooksAnnotation = "cert-manager.io/inject-ca-into-webhooks"
+
 	// AllowsInjectionFromSecretAnnotation is an annotation that must be added
 	// to Secret resource that want to denote that they can be directly
 	// injected into injectables that have a `inject-ca-from-secret` annotation.
@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/internal/ingress"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -73,10 +74,22 @@ type controller struct {
 	// logger to be used by this controller
 	log logr.Logger
 
+	// used to record when challenges are presented
+	clock clock.Clock
+
 	dns01Nameservers []string
 
 	DNS01CheckRetryPeriod time.Duration
 
+	// selfChecker runs DNS01 propagation self checks in a bounded pool, so
+	// that the self checks of the different challenges of an Order run in
+	// parallel. If nil, self checks are run synchronously in Sync.
+	selfChecker *selfChecker
+
+	// forceCleanUpOnIssuerDeletion causes the resources presented for
+	// challenges to be cleaned up if their issuer has been deleted.
+	forceCleanUpOnIssuerDeletion bool
+
 	// objectUpdater implements the updateObject function which is used to save
 	// changes to the Challenge.Status and Challenge.Finalizers
 	objectUpdater
@@ -138,6 +151,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
 	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
 	c.recorder = ctx.Recorder
+	c.clock = ctx.Clock
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
 
 	c.httpSolver, err = http.NewSolver(ctx)
@@ -152,6 +166,12 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// read options from context
 	c.dns01Nameservers = ctx.ACMEOptions.DNS01Nameservers
 	c.DNS01CheckRetryPeriod = ctx.ACMEOptions.DNS01CheckRetryPeriod
+	c.forceCleanUpOnIssuerDeletion = ctx.ACMEOptions.ChallengeForceCleanUpOnIssuerDeletion
+	if ctx.ACMEOptions.MaxConcurrentDNS01SelfChecks > 0 {
+		c.selfChecker = newSelfChecker(ctx.ACMEOptions.MaxConcurrentDNS01SelfChecks, func(key string) {
+			c.queue.Add(key)
+		})
+	}
 
 	// Construct an objectUpdater which is used to save changes to the Challenge
 	// object, either using Update or using Patch + Server Side Apply.
@@ -210,6 +230,9 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "challenge in work queue no longer exists")
+			if c.selfChecker != nil {
+				c.selfChecker.Forget(key)
+			}
 			return nil
 		}
 
@@ -165,10 +165,11 @@ func processingChallenges(chs []*cmacme.Challenge) []*cmacme.Challenge {
 }
 
 // incompleteChallenges will filter out challenges from the given slice
-// that are in a 'final' state
+// that are in a 'final' state or that have fallen back to another challenge
+// type
 func incompleteChallenges(chs []*cmacme.Challenge) []*cmacme.Challenge {
 	return filterChallenges(chs, func(ch *cmacme.Challenge) bool {
-		return !acme.IsFinalState(ch.Status.State)
+		return !acme.IsFinalState(ch.Status.State) && !ch.Status.Fallback
 	})
 }
 
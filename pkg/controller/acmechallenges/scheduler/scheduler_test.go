@@ -166,6 +166,24 @@ func TestScheduleN(t *testing.T) {
 					gen.SetChallengeDNSName("example.com")),
 			},
 		},
+		{
+			name: "do not schedule a challenge that has fallen back to another challenge type",
+			n:    5,
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("test",
+					gen.SetChallengeDNSName("example.com"),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					gen.SetChallengeFallback(true)),
+				gen.Challenge("test2",
+					gen.SetChallengeDNSName("example.com"),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01)),
+			},
+			expected: []*cmacme.Challenge{
+				gen.Challenge("test2",
+					gen.SetChallengeDNSName("example.com"),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01)),
+			},
+		},
 		{
 			name: "schedule a single duplicate in CreationTimestamp order",
 			n:    5,
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+	"sync"
+)
+
+// selfChecker runs the propagation self checks of challenges in a bounded
+// pool of goroutines.
+// A self check can take a long time when nameservers are slow to respond, and
+// running it in a controller worker would block the other challenges of an
+// Order from being checked. Instead, Sync starts the check in the pool and
+// returns; once the check completes the challenge is re-queued and the next
+// call to Sync consumes its result.
+// The result of each check is stored per challenge, so a failing check only
+// affects the challenge it was run for.
+type selfChecker struct {
+	// workers holds a token for each self check that is running, bounding
+	// the number of checks that may run at once.
+	workers chan struct{}
+
+	// enqueue is called with the key of a challenge once its check completes.
+	enqueue func(key string)
+
+	lock   sync.Mutex
+	checks map[string]*selfCheck
+}
+
+// selfCheck is a self check that is running or has completed.
+type selfCheck struct {
+	// id identifies the state of the challenge that was checked, so that the
+	// result of a check is not used for a challenge that has since changed.
+	id string
+
+	done bool
+	err  error
+}
+
+// newSelfChecker returns a selfChecker which runs at most maxConcurrent self
+// checks at once.
+func newSelfChecker(maxConcurrent int, enqueue func(key string)) *selfChecker {
+	return &selfChecker{
+		workers: make(chan struct{}, maxConcurrent),
+		enqueue: enqueue,
+		checks:  make(map[string]*selfCheck),
+	}
+}
+
+// Check returns the result of the self check of the challenge with the given
+// key and id, if it has completed. The result is returned only once.
+// If no check is running for the challenge, check is started in the pool and
+// done is false. Checks that are still waiting for a free slot in the pool
+// when ctx is cancelled complete with the error of ctx.
+func (s *selfChecker) Check(ctx context.Context, key, id string, check func(context.Context) error) (done bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if sc, ok := s.checks[key]; ok && sc.id == id {
+		if !sc.done {
+			return false, nil
+		}
+		delete(s.checks, key)
+		return true, sc.err
+	}
+
+	// Any check that is still running for a previous state of the challenge
+	// is replaced, and its result discarded when it completes.
+	sc := &selfCheck{id: id}
+	s.checks[key] = sc
+	go s.run(ctx, key, sc, check)
+
+	return false, nil
+}
+
+func (s *selfChecker) run(ctx context.Context, key string, sc *selfCheck, check func(context.Context) error) {
+	var err error
+	select {
+	case s.workers <- struct{}{}:
+		err = check(ctx)
+		<-s.workers
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	s.lock.Lock()
+	sc.done = true
+	sc.err = err
+	s.lock.Unlock()
+
+	s.enqueue(key)
+}
+
+// Forget discards any self check of the challenge with the given key.
+func (s *selfChecker) Forget(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.checks, key)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// waitForEnqueued waits for n keys to be received from enqueued.
+func waitForEnqueued(t *testing.T, enqueued <-chan string, n int) []string {
+	var keys []string
+	for len(keys) < n {
+		select {
+		case key := <-enqueued:
+			keys = append(keys, key)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for self checks to complete, %d of %d completed", len(keys), n)
+		}
+	}
+	return keys
+}
+
+func TestSelfCheckerRunsChecksConcurrently(t *testing.T) {
+	const maxConcurrent = 3
+	const numChecks = 7
+
+	enqueued := make(chan string, numChecks)
+	s := newSelfChecker(maxConcurrent, func(key string) { enqueued <- key })
+
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	started := make(chan struct{}, numChecks)
+	release := make(chan struct{})
+	check := func(ctx context.Context) error {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	}
+
+	for i := 0; i < numChecks; i++ {
+		done, err := s.Check(context.Background(), fmt.Sprintf("ns/ch-%d", i), "id", check)
+		if done || err != nil {
+			t.Fatalf("expected check %d to be started, got done=%t err=%v", i, done, err)
+		}
+	}
+
+	// All the checks that fit in the pool must be running at the same time.
+	for i := 0; i < maxConcurrent; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %d checks to run concurrently, %d started", maxConcurrent, i)
+		}
+	}
+	// No more than maxConcurrent checks may run at once.
+	select {
+	case <-started:
+		t.Fatalf("expected at most %d checks to run concurrently", maxConcurrent)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	waitForEnqueued(t, enqueued, numChecks)
+
+	if maxRunning != maxConcurrent {
+		t.Errorf("expected %d checks to run concurrently, got %d", maxConcurrent, maxRunning)
+	}
+	for i := 0; i < numChecks; i++ {
+		done, err := s.Check(context.Background(), fmt.Sprintf("ns/ch-%d", i), "id", check)
+		if !done || err != nil {
+			t.Errorf("expected check %d to have succeeded, got done=%t err=%v", i, done, err)
+		}
+	}
+}
+
+func TestSelfCheckerIsolatesFailures(t *testing.T) {
+	enqueued := make(chan string, 3)
+	s := newSelfChecker(2, func(key string) { enqueued <- key })
+
+	checkErr := errors.New("DNS record for \"b.example.com\" not yet propagated")
+	checks := map[string]func(context.Context) error{
+		"ns/a": func(context.Context) error { return nil },
+		"ns/b": func(context.Context) error { return checkErr },
+		"ns/c": func(context.Context) error { return nil },
+	}
+	for key, check := range checks {
+		if done, _ := s.Check(context.Background(), key, "id", check); done {
+			t.Fatalf("expected check for %q to be started", key)
+		}
+	}
+	waitForEnqueued(t, enqueued, len(checks))
+
+	for key, expectedErr := range map[string]error{"ns/a": nil, "ns/b": checkErr, "ns/c": nil} {
+		done, err := s.Check(context.Background(), key, "id", checks[key])
+		if !done {
+			t.Errorf("expected check for %q to have completed", key)
+		}
+		if err != expectedErr {
+			t.Errorf("expected check for %q to return %v, got %v", key, expectedErr, err)
+		}
+	}
+
+	// The result of a check is only returned once, after which a new check
+	// is started.
+	if done, _ := s.Check(context.Background(), "ns/b", "id", checks["ns/b"]); done {
+		t.Errorf("expected a new check to be started once the result has been returned")
+	}
+	waitForEnqueued(t, enqueued, 1)
+}
+
+func TestSelfCheckerDiscardsStaleResults(t *testing.T) {
+	enqueued := make(chan string, 2)
+	s := newSelfChecker(2, func(key string) { enqueued <- key })
+
+	release := make(chan struct{})
+	s.Check(context.Background(), "ns/a", "old", func(context.Context) error {
+		<-release
+		return nil
+	})
+	// The challenge changed while its check was running.
+	s.Check(context.Background(), "ns/a", "new", func(context.Context) error {
+		<-release
+		return errors.New("not yet propagated")
+	})
+	close(release)
+	waitForEnqueued(t, enqueued, 2)
+
+	done, err := s.Check(context.Background(), "ns/a", "new", nil)
+	if !done || err == nil {
+		t.Errorf("expected the result of the check of the current challenge, got done=%t err=%v", done, err)
+	}
+}
+
+func TestSelfCheckerCancelledWhileWaiting(t *testing.T) {
+	enqueued := make(chan string, 2)
+	s := newSelfChecker(1, func(key string) { enqueued <- key })
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	s.Check(context.Background(), "ns/a", "id", func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	// wait for the check to occupy the only slot in the pool
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Check(ctx, "ns/b", "id", func(context.Context) error {
+		t.Error("expected check not to be run once its context is cancelled")
+		return nil
+	})
+	cancel()
+	waitForEnqueued(t, enqueued, 1)
+
+	done, err := s.Check(ctx, "ns/b", "id", nil)
+	if !done || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected check to complete with a cancellation error, got done=%t err=%v", done, err)
+	}
+}
+
+func TestCheckPropagationUsesSelfCheckerForDNS01(t *testing.T) {
+	enqueued := make(chan string, 1)
+	c := &controller{selfChecker: newSelfChecker(1, func(key string) { enqueued <- key })}
+
+	checkErr := errors.New("not yet propagated")
+	s := &fakeSolver{
+		fakeCheck: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+			return checkErr
+		},
+	}
+	issuer := gen.Issuer("test")
+
+	http01 := gen.Challenge("http01", gen.SetChallengeNamespace("ns"), gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01))
+	if done, err := c.checkPropagation(context.Background(), s, issuer, http01); !done || err != checkErr {
+		t.Errorf("expected HTTP01 self check to run synchronously, got done=%t err=%v", done, err)
+	}
+
+	dns01 := gen.Challenge("dns01", gen.SetChallengeNamespace("ns"), gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01))
+	if done, _ := c.checkPropagation(context.Background(), s, issuer, dns01); done {
+		t.Errorf("expected DNS01 self check to be run in the background")
+	}
+	if keys := waitForEnqueued(t, enqueued, 1); keys[0] != "ns/dns01" {
+		t.Errorf("expected challenge %q to be re-queued, got %q", "ns/dns01", keys[0])
+	}
+	if done, err := c.checkPropagation(context.Background(), s, issuer, dns01); !done || err != checkErr {
+		t.Errorf("expected result of DNS01 self check, got done=%t err=%v", done, err)
+	}
+}
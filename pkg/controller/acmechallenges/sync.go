@@ -20,19 +20,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
@@ -42,6 +47,7 @@ const (
 	reasonPresentError   = "PresentError"
 	reasonPresented      = "Presented"
 	reasonFailed         = "Failed"
+	reasonFallback       = "Fallback"
 )
 
 // solver solves ACME challenges by presenting the given token and key in an
@@ -60,8 +66,16 @@ type solver interface {
 // Sync will process this ACME Challenge.
 // It is the core control function for ACME challenges.
 func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (err error) {
-	log := logf.FromContext(ctx).WithValues("dnsName", chOriginal.Spec.DNSName, "type", chOriginal.Spec.Type)
+	log := logf.WithChallenge(logf.FromContext(ctx), chOriginal).WithValues("type", chOriginal.Spec.Type)
 	ctx = logf.NewContext(ctx, log)
+
+	// Challenges referencing an external issuer are processed by that issuer,
+	// which may not share the assumptions cert-manager's ACME issuer makes.
+	if !(chOriginal.Spec.IssuerRef.Group == "" || chOriginal.Spec.IssuerRef.Group == certmanager.GroupName) {
+		log.V(logf.DebugLevel).Info("challenge issuerRef group does not match certmanager group so skipping processing")
+		return nil
+	}
+
 	ch := chOriginal.DeepCopy()
 
 	defer func() {
@@ -94,28 +108,17 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 
 	genericIssuer, err := c.helper.GetGenericIssuer(ch.Spec.IssuerRef, ch.Namespace)
 	if err != nil {
+		if apierrors.IsNotFound(err) && c.forceCleanUpOnIssuerDeletion {
+			return c.forceCleanUp(ctx, ch, err)
+		}
 		return fmt.Errorf("error reading (cluster)issuer %q: %v", ch.Spec.IssuerRef.Name, err)
 	}
 
 	// if a challenge is in a final state, we bail out early as there is nothing
 	// left for us to do here.
 	if acme.IsFinalState(ch.Status.State) {
-		if ch.Status.Presented {
-			solver, err := c.solverFor(ch.Spec.Type)
-			if err != nil {
-				log.Error(err, "error getting solver for challenge")
-				return err
-			}
-
-			err = solver.CleanUp(ctx, genericIssuer, ch)
-			if err != nil {
-				c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCleanUpError, "Error cleaning up challenge: %v", err)
-				ch.Status.Reason = err.Error()
-				log.Error(err, "error cleaning up challenge")
-				return err
-			}
-
-			ch.Status.Presented = false
+		if err := c.cleanUp(ctx, genericIssuer, ch); err != nil {
+			return err
 		}
 
 		ch.Status.Processing = false
@@ -176,16 +179,44 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 	if !ch.Status.Presented {
 		err := solver.Present(ctx, genericIssuer, ch)
 		if err != nil {
-			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonPresentError, "Error presenting challenge: %v", err)
+			c.recorder.Event(ch, corev1.EventTypeWarning, reasonPresentError, cmerrors.WithRemediationHint(fmt.Sprintf("Error presenting challenge: %v", err), err))
 			ch.Status.Reason = err.Error()
+			// only errors caused by an unsupported or invalid solver
+			// configuration are permanent; any other error may be transient
+			// so the challenge is retried with the same type
+			if cmerrors.IsInvalidData(err) && genericIssuer.GetSpec().ACME != nil &&
+				acme.HasFallbackChallengeType(genericIssuer.GetSpec().ACME, ch.Spec.Type) {
+				return c.fallback(ctx, solver, genericIssuer, ch, err)
+			}
 			return err
 		}
 
 		ch.Status.Presented = true
+		presentedTime := metav1.NewTime(c.clock.Now())
+		ch.Status.PresentedTime = &presentedTime
 		c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonPresented, "Presented challenge using %s challenge mechanism", ch.Spec.Type)
 	}
 
-	err = solver.Check(ctx, genericIssuer, ch)
+	if wait := c.preCheckWait(ch); wait > 0 {
+		ch.Status.Reason = fmt.Sprintf("Waiting %s after presenting the %s challenge before checking its propagation",
+			ch.Spec.Solver.PreCheckDelay.Duration, ch.Spec.Type)
+
+		key, err := controllerpkg.KeyFunc(ch)
+		// This is an unexpected edge case and should never occur
+		if err != nil {
+			return err
+		}
+
+		c.queue.AddAfter(key, wait)
+
+		return nil
+	}
+
+	done, err := c.checkPropagation(ctx, solver, genericIssuer, ch)
+	if !done {
+		// the challenge will be re-queued once the self check completes
+		return nil
+	}
 	if err != nil {
 		log.Error(err, "propagation check failed")
 		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
@@ -209,6 +240,124 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 	return nil
 }
 
+// preCheckWait returns how much longer to wait before the first propagation
+// check of the challenge, as configured by the preCheckDelay of its solver.
+func (c *controller) preCheckWait(ch *cmacme.Challenge) time.Duration {
+	if ch.Spec.Solver.PreCheckDelay == nil || ch.Status.PresentedTime == nil {
+		return 0
+	}
+	return ch.Status.PresentedTime.Add(ch.Spec.Solver.PreCheckDelay.Duration).Sub(c.clock.Now())
+}
+
+// checkPropagation runs the propagation self check of the challenge.
+// DNS01 self checks are run in the controller's selfChecker, if it has one, in
+// which case done is false until the check has completed.
+func (c *controller) checkPropagation(ctx context.Context, solver solver, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) (done bool, err error) {
+	if c.selfChecker == nil || ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 {
+		return true, solver.Check(ctx, issuer, ch)
+	}
+
+	key, err := controllerpkg.KeyFunc(ch)
+	// This is an unexpected edge case and should never occur
+	if err != nil {
+		return true, err
+	}
+
+	// The check runs after Sync has returned, so it is given its own copy of
+	// the challenge.
+	checked := ch.DeepCopy()
+	return c.selfChecker.Check(ctx, key, string(ch.UID)+"/"+ch.Spec.Key, func(ctx context.Context) error {
+		return solver.Check(ctx, issuer, checked)
+	})
+}
+
+// fallback cleans up any resources created for a challenge that could not be
+// presented and marks it as having fallen back, so that the owning Order will
+// replace it with a challenge of the next preferred type.
+func (c *controller) fallback(ctx context.Context, solver solver, issuer cmapi.GenericIssuer, ch *cmacme.Challenge, presentErr error) error {
+	if err := solver.CleanUp(ctx, issuer, ch); err != nil {
+		c.recorder.Event(ch, corev1.EventTypeWarning, reasonCleanUpError, cmerrors.WithRemediationHint(fmt.Sprintf("Error cleaning up challenge: %v", err), err))
+		ch.Status.Reason = err.Error()
+		return err
+	}
+
+	ch.Status.Fallback = true
+	ch.Status.Processing = false
+	ch.Status.Reason = fmt.Sprintf("Failed to present %s challenge, falling back to the next preferred challenge type: %v", ch.Spec.Type, presentErr)
+	c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonFallback, "Falling back to the next preferred challenge type as the %s challenge could not be presented", ch.Spec.Type)
+
+	return nil
+}
+
+// cleanUp removes the resources presented for a challenge.
+// Challenges that failed are cleaned up even if they were never marked as
+// presented, as presenting them may have created some of their resources
+// before failing. Such challenges are only cleaned up while they are still
+// processing, i.e. on the sync that observes their transition into a failed
+// state and stops processing them, so that CleanUp is not called for them on
+// every resync. Errors cleaning up such a challenge are recorded but not
+// returned, as it may have nothing to clean up and retrying is likely to fail
+// for the same reason presenting it did.
+func (c *controller) cleanUp(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanUp")
+
+	if !ch.Status.Presented && !(ch.Status.Processing && acme.IsFailureState(ch.Status.State)) {
+		return nil
+	}
+
+	solver, err := c.solverFor(ch.Spec.Type)
+	if err != nil {
+		log.Error(err, "error getting solver for challenge")
+		return err
+	}
+
+	err = solver.CleanUp(ctx, issuer, ch)
+	if err != nil {
+		c.recorder.Event(ch, corev1.EventTypeWarning, reasonCleanUpError, cmerrors.WithRemediationHint(fmt.Sprintf("Error cleaning up challenge: %v", err), err))
+		log.Error(err, "error cleaning up challenge")
+		if ch.Status.Presented {
+			ch.Status.Reason = err.Error()
+			return err
+		}
+	}
+
+	ch.Status.Presented = false
+	ch.Status.PresentedTime = nil
+
+	return nil
+}
+
+// forceCleanUp removes the resources presented for a challenge whose issuer
+// has been deleted, so that they do not linger until the issuer is
+// recreated. The solver configuration is copied to the challenge when it is
+// created, so the resources can be cleaned up without the issuer's spec.
+// Challenges that are not in a final state are still retried with the error
+// returned for the missing issuer, so that they resume if the issuer is
+// recreated.
+func (c *controller) forceCleanUp(ctx context.Context, ch *cmacme.Challenge, issuerErr error) error {
+	if err := c.cleanUp(ctx, deletedIssuer(ch), ch); err != nil {
+		return err
+	}
+
+	if acme.IsFinalState(ch.Status.State) {
+		ch.Status.Processing = false
+		return nil
+	}
+
+	return fmt.Errorf("error reading (cluster)issuer %q: %v", ch.Spec.IssuerRef.Name, issuerErr)
+}
+
+// deletedIssuer returns a placeholder for the deleted issuer of a challenge,
+// which has the name, namespace and kind of the issuer but an empty spec.
+// The namespace that solvers read the credentials referenced by the
+// challenge's solver configuration from only depends on these.
+func deletedIssuer(ch *cmacme.Challenge) cmapi.GenericIssuer {
+	if ch.Spec.IssuerRef.Kind == cmapi.ClusterIssuerKind {
+		return &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: ch.Spec.IssuerRef.Name}}
+	}
+	return &cmapi.Issuer{ObjectMeta: metav1.ObjectMeta{Name: ch.Spec.IssuerRef.Name, Namespace: ch.Namespace}}
+}
+
 // handleError will handle ACME error types, updating the challenge resource
 // with any new information found whilst inspecting the error response.
 // This may include marking the challenge as expired.
@@ -265,6 +414,9 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 	}
 
 	genericIssuer, err := c.helper.GetGenericIssuer(ch.Spec.IssuerRef, ch.Namespace)
+	if apierrors.IsNotFound(err) && c.forceCleanUpOnIssuerDeletion {
+		genericIssuer, err = deletedIssuer(ch), nil
+	}
 	if err != nil {
 		return fmt.Errorf("error reading (cluster)issuer %q: %v", ch.Spec.IssuerRef.Name, err)
 	}
@@ -277,7 +429,7 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 
 	err = solver.CleanUp(ctx, genericIssuer, ch)
 	if err != nil {
-		c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCleanUpError, "Error cleaning up challenge: %v", err)
+		c.recorder.Event(ch, corev1.EventTypeWarning, reasonCleanUpError, cmerrors.WithRemediationHint(fmt.Sprintf("Error cleaning up challenge: %v", err), err))
 		ch.Status.Reason = err.Error()
 		log.Error(err, "error cleaning up challenge")
 		return nil
@@ -401,13 +553,37 @@ func (c *controller) handleAuthorizationError(ch *cmacme.Challenge, err error) e
 	//   if the returned state is 'invalid'
 	ch.Status.State = cmacme.Invalid
 	ch.Status.Reason = fmt.Sprintf("Error accepting authorization: %v", authErr)
-	c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonFailed, "Accepting challenge authorization failed: %v", authErr)
+	c.recorder.Event(ch, corev1.EventTypeWarning, reasonFailed, cmerrors.WithRemediationHint(
+		fmt.Sprintf("Accepting challenge authorization failed: %v", authErr), withAuthorizationErrorHint(authErr)))
 
 	// return nil here, as accepting the challenge did not error, the challenge
 	// simply failed
 	return nil
 }
 
+// authorizationProblemHints are remediation hints for the ACME problem types
+// that commonly cause a challenge authorization to fail.
+var authorizationProblemHints = map[string]string{
+	"urn:ietf:params:acme:error:caa":        "check that the CAA records of the domain permit the ACME server's certificate authority to issue for it",
+	"urn:ietf:params:acme:error:dns":        "check that the DNS records of the domain can be resolved from the internet",
+	"urn:ietf:params:acme:error:connection": "check that the ACME server can connect to the challenge solver from the internet, and that no firewall or redirect prevents it",
+}
+
+// withAuthorizationErrorHint adds a remediation hint to a failed
+// authorization based on the ACME problem types of its errors.
+func withAuthorizationErrorHint(authErr *acmeapi.AuthorizationError) error {
+	for _, err := range authErr.Errors {
+		acmeErr, ok := err.(*acmeapi.Error)
+		if !ok {
+			continue
+		}
+		if hint, ok := authorizationProblemHints[acmeErr.ProblemType]; ok {
+			return cmerrors.NewWithRemediationHint(authErr, hint)
+		}
+	}
+	return authErr
+}
+
 func (c *controller) solverFor(challengeType cmacme.ACMEChallengeType) (solver, error) {
 	switch challengeType {
 	case cmacme.ACMEChallengeTypeHTTP01:
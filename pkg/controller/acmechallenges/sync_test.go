@@ -21,11 +21,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -34,6 +36,7 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
@@ -53,6 +56,7 @@ func (f *fakeSolver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cma
 // This may involve deleting resources in the Kubernetes API Server, or
 // communicating with other external components (e.g. DNS providers).
 func (f *fakeSolver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	f.cleanUpCalls++
 	return f.fakeCleanUp(ctx, issuer, ch)
 }
 
@@ -60,6 +64,8 @@ type fakeSolver struct {
 	fakePresent func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
 	fakeCheck   func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
 	fakeCleanUp func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
+
+	cleanUpCalls int
 }
 
 type testT struct {
@@ -69,6 +75,12 @@ type testT struct {
 	dnsSolver  *fakeSolver
 	expectErr  bool
 	acmeClient *acmecl.FakeACME
+
+	// expectCleanUp is true if the challenge's solver is expected to have
+	// been called to clean up the challenge.
+	expectCleanUp bool
+
+	forceCleanUpOnIssuerDeletion bool
 }
 
 func TestSyncHappyPath(t *testing.T) {
@@ -81,6 +93,7 @@ func TestSyncHappyPath(t *testing.T) {
 			},
 		},
 	}))
+	fixedClock := fakeclock.NewFakeClock(time.Now())
 	baseChallenge := gen.Challenge("testchal",
 		gen.SetChallengeIssuer(cmmeta.ObjectReference{
 			Name: "testissuer",
@@ -90,8 +103,44 @@ func TestSyncHappyPath(t *testing.T) {
 	deletedChallenge := gen.ChallengeFrom(baseChallenge,
 		gen.SetChallengeDeletionTimestamp(metav1.Now()))
 
+	testIssuerDNS01Preferred := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01, cmacme.ACMEChallengeTypeHTTP01},
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{},
+				},
+			},
+			{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}))
+
 	simulatedCleanupError := errors.New("simulated-cleanup-error")
+	simulatedPresentError := errors.New("simulated-present-error")
+	simulatedConfigError := cmerrors.NewInvalidData("simulated-config-error")
+	simulatedPermissionError := cmerrors.NewWithRemediationHint(errors.New("simulated-permission-error"), "grant the simulated permission")
+	externalIssuerChallenge := gen.Challenge("testchal",
+		gen.SetChallengeIssuer(cmmeta.ObjectReference{
+			Name: "testissuer", Kind: "ExternalACMEIssuer", Group: "acme.example.com",
+		}),
+		gen.SetChallengeProcessing(true),
+		gen.SetChallengeURL("testurl"),
+		gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+	)
+
 	tests := map[string]testT{
+		"do nothing if the challenge references an external issuer": {
+			challenge:  externalIssuerChallenge,
+			httpSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{externalIssuerChallenge},
+				ExpectedActions:    []testpkg.Action{},
+			},
+		},
 		"cleanup if the challenge is deleted and remove the finalizer": {
 			challenge: gen.ChallengeFrom(deletedChallenge,
 				gen.SetChallengeProcessing(true),
@@ -296,6 +345,7 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 			builder: &testpkg.Builder{
+				Clock: fixedClock,
 				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
 					gen.SetChallengeProcessing(true),
 					gen.SetChallengeURL("testurl"),
@@ -311,6 +361,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeURL("testurl"),
 							gen.SetChallengeState(cmacme.Pending),
 							gen.SetChallengePresented(true),
+							gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now())),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
 							gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation: some error"),
 						))),
@@ -320,6 +371,94 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"wait for the solver's pre-check delay after presenting before checking propagation": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePreCheckDelay(30*time.Second),
+			),
+			httpSolver: &fakeSolver{
+				fakePresent: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return fmt.Errorf("propagation should not be checked before the pre-check delay")
+				},
+			},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePreCheckDelay(30*time.Second),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengePresented(true),
+							gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now())),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePreCheckDelay(30*time.Second),
+							gen.SetChallengeReason("Waiting 30s after presenting the HTTP-01 challenge before checking its propagation"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Normal Presented Presented challenge using HTTP-01 challenge mechanism",
+				},
+			},
+		},
+		"check propagation once the solver's pre-check delay has passed": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePreCheckDelay(30*time.Second),
+				gen.SetChallengePresented(true),
+				gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-30*time.Second))),
+			),
+			httpSolver: &fakeSolver{
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return fmt.Errorf("some error")
+				},
+			},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePreCheckDelay(30*time.Second),
+					gen.SetChallengePresented(true),
+					gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-30*time.Second))),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePreCheckDelay(30*time.Second),
+							gen.SetChallengePresented(true),
+							gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-30*time.Second))),
+							gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation: some error"),
+						))),
+				},
+			},
+		},
 		"accept the challenge if the self check is passing": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -498,6 +637,67 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"include a remediation hint for known ACME problem types in the authorization failure event": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			httpSolver: &fakeSolver{
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Invalid),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(true),
+							gen.SetChallengeReason("Error accepting authorization: acme: authorization error for example.com: 403 urn:ietf:params:acme:error:caa: CAA record for example.com prevents issuance"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed Accepting challenge authorization failed: acme: authorization error for example.com: 403 urn:ietf:params:acme:error:caa: CAA record for example.com prevents issuance" +
+						" (hint: check that the CAA records of the domain permit the ACME server's certificate authority to issue for it)",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
+					return &acmeapi.Challenge{Status: acmeapi.StatusPending}, nil
+				},
+				FakeWaitAuthorization: func(context.Context, string) (*acmeapi.Authorization, error) {
+					return nil, &acmeapi.AuthorizationError{
+						URI:        "http://testerroruri",
+						Identifier: "example.com",
+						Errors: []error{
+							&acmeapi.Error{
+								StatusCode:  403,
+								ProblemType: "urn:ietf:params:acme:error:caa",
+								Detail:      "CAA record for example.com prevents issuance",
+							},
+						},
+					}
+				},
+			},
+		},
 		"mark the challenge as not processing if it is already valid": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -568,42 +768,537 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
-			runTest(t, test)
-		})
-	}
-}
-
-func runTest(t *testing.T, test testT) {
-	test.builder.T = t
-	test.builder.Init()
-	defer test.builder.Stop()
-
-	c := &controller{}
-	c.Register(test.builder.Context)
-	c.helper = issuer.NewHelper(
-		test.builder.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
-		test.builder.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
-	)
-	c.accountRegistry = &accountstest.FakeRegistry{
-		GetClientFunc: func(_ string) (acmecl.Interface, error) {
-			return test.acmeClient, nil
+		"clean up a failed challenge even if it was not marked as presented": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Invalid),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(false),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			dnsSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Invalid),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(false),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(false),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Invalid),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(false),
+						))),
+				},
+			},
+			expectCleanUp: true,
 		},
-	}
-	c.httpSolver = test.httpSolver
-	c.dnsSolver = test.dnsSolver
-	test.builder.Start()
-
-	err := c.Sync(context.Background(), test.challenge)
-	if err != nil && !test.expectErr {
-		t.Errorf("Expected function to not error, but got: %v", err)
-	}
-	if err == nil && test.expectErr {
-		t.Errorf("Expected function to get an error, but got: %v", err)
-	}
-
-	test.builder.CheckAndFinish(err)
+		"do not retry cleaning up a failed challenge that was not marked as presented": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Errored),
+				gen.SetChallengeReason("simulated-failure"),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(false),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return simulatedCleanupError
+				},
+			},
+			dnsSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Errored),
+					gen.SetChallengeReason("simulated-failure"),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(false),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(false),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Errored),
+							gen.SetChallengeReason("simulated-failure"),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(false),
+						))),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf("Warning CleanUpError Error cleaning up challenge: %s", simulatedCleanupError),
+				},
+			},
+			expectCleanUp: true,
+		},
+		"retry cleaning up a failed challenge that was presented if cleanup fails": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Invalid),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return simulatedCleanupError
+				},
+			},
+			dnsSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Invalid),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Invalid),
+							gen.SetChallengeReason(simulatedCleanupError.Error()),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(true),
+						))),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf("Warning CleanUpError Error cleaning up challenge: %s", simulatedCleanupError),
+				},
+			},
+			expectCleanUp: true,
+			expectErr:     true,
+		},
+		"do not clean up a challenge if its issuer has been deleted and cleanup is not forced": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Invalid),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			httpSolver: &fakeSolver{},
+			dnsSolver:  &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Invalid),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				)},
+				ExpectedActions: []testpkg.Action{},
+			},
+			expectErr: true,
+		},
+		"clean up a failed challenge if its issuer has been deleted and cleanup is forced": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Invalid),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					if issuer.GetObjectMeta().Name != "testissuer" || issuer.GetObjectMeta().Namespace != gen.DefaultTestNamespace {
+						return fmt.Errorf("unexpected issuer %s/%s", issuer.GetObjectMeta().Namespace, issuer.GetObjectMeta().Name)
+					}
+					return nil
+				},
+			},
+			dnsSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Invalid),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				)},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(false),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Invalid),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(false),
+						))),
+				},
+			},
+			forceCleanUpOnIssuerDeletion: true,
+			expectCleanUp:                true,
+		},
+		"clean up a pending challenge if its issuer has been deleted and cleanup is forced, and retry": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			dnsSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				)},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(false),
+						))),
+				},
+			},
+			forceCleanUpOnIssuerDeletion: true,
+			expectCleanUp:                true,
+			expectErr:                    true,
+		},
+		"cleanup a deleted challenge whose issuer has been deleted if cleanup is forced": {
+			challenge: gen.ChallengeFrom(deletedChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+			),
+			httpSolver: &fakeSolver{
+				fakeCleanUp: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			dnsSolver: &fakeSolver{},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ChallengeFrom(deletedChallenge,
+						gen.SetChallengeProcessing(true),
+						gen.SetChallengeURL("testurl"),
+						gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(deletedChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeFinalizers([]string{}),
+						))),
+				},
+			},
+			forceCleanUpOnIssuerDeletion: true,
+			expectCleanUp:                true,
+		},
+		"clean up and fall back to the next preferred challenge type if presenting fails due to invalid configuration": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			),
+			dnsSolver: &fakeSolver{
+				fakePresent: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return simulatedConfigError
+				},
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+				), testIssuerDNS01Preferred},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(false),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+							gen.SetChallengeFallback(true),
+							gen.SetChallengeReason("Failed to present DNS-01 challenge, falling back to the next preferred challenge type: simulated-config-error"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning PresentError Error presenting challenge: simulated-config-error",
+					"Normal Fallback Falling back to the next preferred challenge type as the DNS-01 challenge could not be presented",
+				},
+			},
+		},
+		"do not fall back if presenting fails with a transient error": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			),
+			dnsSolver: &fakeSolver{
+				fakePresent: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return simulatedPresentError
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+				), testIssuerDNS01Preferred},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+							gen.SetChallengeReason("simulated-present-error"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning PresentError Error presenting challenge: simulated-present-error",
+				},
+			},
+			expectErr: true,
+		},
+		"do not fall back if presenting the least preferred challenge type fails": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+			),
+			httpSolver: &fakeSolver{
+				fakePresent: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return simulatedConfigError
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				), testIssuerDNS01Preferred},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeReason("simulated-config-error"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning PresentError Error presenting challenge: simulated-config-error",
+				},
+			},
+			expectErr: true,
+		},
+		"include the remediation hint of the error in the event if presenting fails": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+			),
+			httpSolver: &fakeSolver{
+				fakePresent: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return simulatedPermissionError
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeReason("simulated-permission-error"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning PresentError Error presenting challenge: simulated-permission-error (hint: grant the simulated permission)",
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			runTest(t, test)
+		})
+	}
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	c := &controller{}
+	c.Register(test.builder.Context)
+	c.helper = issuer.NewHelper(
+		test.builder.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+		test.builder.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+	)
+	c.accountRegistry = &accountstest.FakeRegistry{
+		GetClientFunc: func(_ string) (acmecl.Interface, error) {
+			return test.acmeClient, nil
+		},
+	}
+	c.httpSolver = test.httpSolver
+	c.dnsSolver = test.dnsSolver
+	c.forceCleanUpOnIssuerDeletion = test.forceCleanUpOnIssuerDeletion
+	test.builder.Start()
+
+	err := c.Sync(context.Background(), test.challenge)
+	if err != nil && !test.expectErr {
+		t.Errorf("Expected function to not error, but got: %v", err)
+	}
+	if err == nil && test.expectErr {
+		t.Errorf("Expected function to get an error, but got: %v", err)
+	}
+	if test.expectCleanUp && test.httpSolver.cleanUpCalls+test.dnsSolver.cleanUpCalls == 0 {
+		t.Errorf("Expected the challenge to be cleaned up, but CleanUp was not called")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
+
+func TestPreCheckWait(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	presentedAt := func(ago time.Duration) gen.ChallengeModifier {
+		return gen.SetChallengePresentedTime(metav1.NewTime(fixedClock.Now().Add(-ago)))
+	}
+
+	tests := map[string]struct {
+		challenge    *cmacme.Challenge
+		expectedWait time.Duration
+	}{
+		"no wait if the solver has no pre-check delay": {
+			challenge: gen.Challenge("test", presentedAt(0)),
+		},
+		"no wait if the presented time is not known": {
+			challenge: gen.Challenge("test", gen.SetChallengePreCheckDelay(time.Minute)),
+		},
+		"wait the full delay just after presenting": {
+			challenge:    gen.Challenge("test", gen.SetChallengePreCheckDelay(time.Minute), presentedAt(0)),
+			expectedWait: time.Minute,
+		},
+		"wait the rest of the delay": {
+			challenge:    gen.Challenge("test", gen.SetChallengePreCheckDelay(time.Minute), presentedAt(40*time.Second)),
+			expectedWait: 20 * time.Second,
+		},
+		"no wait once the delay has passed": {
+			challenge:    gen.Challenge("test", gen.SetChallengePreCheckDelay(time.Minute), presentedAt(2*time.Minute)),
+			expectedWait: -time.Minute,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{clock: fixedClock}
+			if wait := c.preCheckWait(test.challenge); wait != test.expectedWait {
+				t.Errorf("expected to wait %s, got %s", test.expectedWait, wait)
+			}
+		})
+	}
+}
+
+func TestCleanUp(t *testing.T) {
+	tests := map[string]struct {
+		challenge     *cmacme.Challenge
+		expectCleanUp bool
+	}{
+		"clean up a presented challenge": {
+			challenge:     gen.Challenge("test", gen.SetChallengeProcessing(true), gen.SetChallengeState(cmacme.Valid), gen.SetChallengePresented(true)),
+			expectCleanUp: true,
+		},
+		"do not clean up a challenge that was not presented and has not failed": {
+			challenge: gen.Challenge("test", gen.SetChallengeProcessing(true), gen.SetChallengeState(cmacme.Pending)),
+		},
+		"clean up a failed challenge that was not presented when it stops processing": {
+			challenge:     gen.Challenge("test", gen.SetChallengeProcessing(true), gen.SetChallengeState(cmacme.Invalid)),
+			expectCleanUp: true,
+		},
+		"do not clean up a failed challenge that was not presented once it has stopped processing": {
+			challenge: gen.Challenge("test", gen.SetChallengeProcessing(false), gen.SetChallengeState(cmacme.Invalid)),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			solver := &fakeSolver{
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return nil
+				},
+			}
+			test.challenge.Spec.Type = cmacme.ACMEChallengeTypeHTTP01
+			c := &controller{httpSolver: solver}
+
+			if err := c.cleanUp(context.Background(), gen.Issuer("testissuer"), test.challenge); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cleanedUp := solver.cleanUpCalls > 0; cleanedUp != test.expectCleanUp {
+				t.Errorf("expected clean up to be called: %t, got: %t", test.expectCleanUp, cleanedUp)
+			}
+		})
+	}
 }
@@ -0,0 +1,215 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	reasonACMEOrderState         = "ACMEOrderState"
+	reasonACMEAuthorizationState = "ACMEAuthorizationState"
+
+	// maxCapturedACMEStateSize is the maximum size of the captured state in an
+	// event message. Longer states are truncated.
+	maxCapturedACMEStateSize = 1024
+)
+
+// captureACMEStateEnabled returns true if the state returned by the ACME
+// server should be recorded as events on the Order.
+func captureACMEStateEnabled(o *cmacme.Order) bool {
+	return o.Annotations[cmacme.CaptureACMEStateAnnotationKey] == "true"
+}
+
+// captureAuthorizations fetches each of the authorizations of the ACME order
+// so that their latest state is captured by the stateCapturingClient. Errors
+// are only logged as capturing state must not affect the Order.
+func captureAuthorizations(ctx context.Context, cl acmecl.Interface, acmeOrder *acmeapi.Order) {
+	for _, url := range acmeOrder.AuthzURLs {
+		if _, err := cl.GetAuthorization(ctx, url); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to fetch authorization to capture its state", "url", url)
+		}
+	}
+}
+
+// stateCapturingClient records the orders and authorizations returned by the
+// ACME server as events on an Order.
+type stateCapturingClient struct {
+	acmecl.Interface
+
+	recorder record.EventRecorder
+	order    *cmacme.Order
+}
+
+func (c *stateCapturingClient) AuthorizeOrder(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+	acmeOrder, err := c.Interface.AuthorizeOrder(ctx, id, opt...)
+	if err == nil {
+		c.captureOrder(ctx, acmeOrder)
+	}
+	return acmeOrder, err
+}
+
+func (c *stateCapturingClient) GetOrder(ctx context.Context, url string) (*acmeapi.Order, error) {
+	acmeOrder, err := c.Interface.GetOrder(ctx, url)
+	if err == nil {
+		c.captureOrder(ctx, acmeOrder)
+	}
+	return acmeOrder, err
+}
+
+func (c *stateCapturingClient) WaitOrder(ctx context.Context, url string) (*acmeapi.Order, error) {
+	acmeOrder, err := c.Interface.WaitOrder(ctx, url)
+	if err == nil {
+		c.captureOrder(ctx, acmeOrder)
+	}
+	return acmeOrder, err
+}
+
+func (c *stateCapturingClient) GetAuthorization(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+	acmeAuthz, err := c.Interface.GetAuthorization(ctx, url)
+	if err == nil {
+		c.captureAuthorization(ctx, acmeAuthz)
+	}
+	return acmeAuthz, err
+}
+
+func (c *stateCapturingClient) captureOrder(ctx context.Context, acmeOrder *acmeapi.Order) {
+	state := capturedACMEOrder{
+		URL:            acmeOrder.URI,
+		Status:         acmeOrder.Status,
+		Expires:        formatCapturedTime(acmeOrder.Expires),
+		Authorizations: acmeOrder.AuthzURLs,
+		FinalizeURL:    acmeOrder.FinalizeURL,
+		CertificateURL: acmeOrder.CertURL,
+		Error:          capturedError(acmeOrder.Error),
+	}
+	for _, id := range acmeOrder.Identifiers {
+		state.Identifiers = append(state.Identifiers, formatCapturedIdentifier(id))
+	}
+	c.capture(ctx, reasonACMEOrderState, "ACME order", state)
+}
+
+func (c *stateCapturingClient) captureAuthorization(ctx context.Context, acmeAuthz *acmeapi.Authorization) {
+	state := capturedACMEAuthorization{
+		URL:        acmeAuthz.URI,
+		Status:     acmeAuthz.Status,
+		Identifier: formatCapturedIdentifier(acmeAuthz.Identifier),
+		Wildcard:   acmeAuthz.Wildcard,
+		Expires:    formatCapturedTime(acmeAuthz.Expires),
+	}
+	for _, ch := range acmeAuthz.Challenges {
+		// The token is deliberately not captured.
+		state.Challenges = append(state.Challenges, capturedACMEChallenge{
+			Type:      ch.Type,
+			URL:       ch.URI,
+			Status:    ch.Status,
+			Validated: formatCapturedTime(ch.Validated),
+			Error:     capturedError(ch.Error),
+		})
+	}
+	c.capture(ctx, reasonACMEAuthorizationState, "ACME authorization", state)
+}
+
+// capture records the given state as an event on the Order, truncating it if
+// it is longer than maxCapturedACMEStateSize.
+func (c *stateCapturingClient) capture(ctx context.Context, reason, kind string, state interface{}) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to encode captured state", "kind", kind)
+		return
+	}
+	message := string(data)
+	if len(message) > maxCapturedACMEStateSize {
+		message = message[:maxCapturedACMEStateSize] + "...(truncated)"
+	}
+	c.recorder.Event(c.order, corev1.EventTypeNormal, reason, fmt.Sprintf("%s state: %s", kind, message))
+}
+
+type capturedACMEOrder struct {
+	URL            string             `json:"url,omitempty"`
+	Status         string             `json:"status"`
+	Expires        string             `json:"expires,omitempty"`
+	Identifiers    []string           `json:"identifiers,omitempty"`
+	Authorizations []string           `json:"authorizations,omitempty"`
+	FinalizeURL    string             `json:"finalize,omitempty"`
+	CertificateURL string             `json:"certificate,omitempty"`
+	Error          *capturedACMEError `json:"error,omitempty"`
+}
+
+type capturedACMEAuthorization struct {
+	URL        string                  `json:"url,omitempty"`
+	Status     string                  `json:"status"`
+	Identifier string                  `json:"identifier"`
+	Wildcard   bool                    `json:"wildcard,omitempty"`
+	Expires    string                  `json:"expires,omitempty"`
+	Challenges []capturedACMEChallenge `json:"challenges,omitempty"`
+}
+
+type capturedACMEChallenge struct {
+	Type      string             `json:"type"`
+	URL       string             `json:"url,omitempty"`
+	Status    string             `json:"status"`
+	Validated string             `json:"validated,omitempty"`
+	Error     *capturedACMEError `json:"error,omitempty"`
+}
+
+// capturedACMEError only contains the problem type and detail of an ACME
+// error, and not for example the HTTP headers of the response.
+type capturedACMEError struct {
+	Type   string `json:"type,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func capturedError(err error) *capturedACMEError {
+	if err == nil {
+		return nil
+	}
+	var acmeErr *acmeapi.Error
+	if errors.As(err, &acmeErr) {
+		if acmeErr == nil {
+			return nil
+		}
+		return &capturedACMEError{Type: acmeErr.ProblemType, Detail: acmeErr.Detail}
+	}
+	return &capturedACMEError{Detail: err.Error()}
+}
+
+func formatCapturedIdentifier(id acmeapi.AuthzID) string {
+	if id.Type == "" {
+		return id.Value
+	}
+	return id.Type + ":" + id.Value
+}
+
+func formatCapturedTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+	"k8s.io/client-go/tools/record"
+
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestStateCapturingClient(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	cl := &stateCapturingClient{
+		Interface: &acmecl.FakeACME{
+			FakeGetAuthorization: func(_ context.Context, url string) (*acmeapi.Authorization, error) {
+				return &acmeapi.Authorization{
+					URI:        url,
+					Status:     acmeapi.StatusInvalid,
+					Identifier: acmeapi.AuthzID{Type: "dns", Value: "example.com"},
+					Challenges: []*acmeapi.Challenge{
+						{
+							Type:   "http-01",
+							URI:    "http://chalurl",
+							Token:  "secret-token",
+							Status: acmeapi.StatusInvalid,
+							Error: &acmeapi.Error{
+								ProblemType: "urn:ietf:params:acme:error:connection",
+								Detail:      strings.Repeat("a", 2*maxCapturedACMEStateSize),
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		recorder: recorder,
+		order:    gen.Order("testorder"),
+	}
+
+	if _, err := cl.GetAuthorization(context.Background(), "http://authzurl"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event := <-recorder.Events
+	if !strings.HasPrefix(event, `Normal ACMEAuthorizationState ACME authorization state: {"url":"http://authzurl","status":"invalid","identifier":"dns:example.com"`) {
+		t.Errorf("unexpected event: %s", event)
+	}
+	if strings.Contains(event, "secret-token") {
+		t.Errorf("expected challenge token to not be captured, got event: %s", event)
+	}
+	if !strings.HasSuffix(event, "...(truncated)") {
+		t.Errorf("expected captured state to be truncated, got event: %s", event)
+	}
+	if max := len("Normal ACMEAuthorizationState ACME authorization state: ...(truncated)") + maxCapturedACMEStateSize; len(event) > max {
+		t.Errorf("expected event to be at most %d bytes, got %d", max, len(event))
+	}
+}
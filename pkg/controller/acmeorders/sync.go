@@ -39,14 +39,17 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 const (
 	reasonSolver  = "Solver"
 	reasonCreated = "Created"
+	reasonFailed  = "Failed"
 )
 
 var (
@@ -56,9 +59,17 @@ var (
 )
 
 func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
-	log := logf.FromContext(ctx)
+	log := logf.WithOrder(logf.FromContext(ctx), o)
+	ctx = logf.NewContext(ctx, log)
 	dbg := log.V(logf.DebugLevel)
 
+	// Orders referencing an external issuer are processed by that issuer,
+	// which may not share the assumptions cert-manager's ACME issuer makes.
+	if !(o.Spec.IssuerRef.Group == "" || o.Spec.IssuerRef.Group == certmanager.GroupName) {
+		dbg.Info("order issuerRef group does not match certmanager group so skipping processing")
+		return nil
+	}
+
 	oldOrder := o
 	o = o.DeepCopy()
 
@@ -85,6 +96,9 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	if err != nil {
 		return err
 	}
+	if captureACMEStateEnabled(o) {
+		cl = &stateCapturingClient{Interface: cl, recorder: c.recorder, order: o}
+	}
 
 	switch {
 	case acme.IsFailureState(o.Status.State):
@@ -120,11 +134,28 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return c.deleteAllChallenges(ctx, o)
 	}
 
+	dbg.Info("Recording the challenge types of any Challenge resources that have fallen back")
+	if err := c.recordFailedChallengeTypes(o); err != nil {
+		return err
+	}
+
+	dbg.Info("Recording how any Challenge resources have been presented")
+	if err := c.recordChallengePresentations(o); err != nil {
+		return err
+	}
+
 	dbg.Info("Computing list of Challenge resources that need to exist to complete this Order")
 	requiredChallenges, err := buildRequiredChallenges(ctx, cl, genericIssuer, o)
 	if err != nil {
 		log.Error(err, "Failed to determine the list of Challenge resources needed for the Order")
-		c.recorder.Eventf(o, corev1.EventTypeWarning, reasonSolver, "Failed to determine a valid solver configuration for the set of domains on the Order: %v", err)
+		c.recorder.Event(o, corev1.EventTypeWarning, reasonSolver, cmerrors.WithRemediationHint(
+			fmt.Sprintf("Failed to determine a valid solver configuration for the set of domains on the Order: %v", err), err))
+		if anyChallengeTypesFailed(o) {
+			// every preferred challenge type has been attempted for at
+			// least one authorization, so the Order cannot be completed
+			c.setOrderState(&o.Status, string(cmacme.Errored))
+			o.Status.Reason = fmt.Sprintf("Failed to present any of the preferred challenge types: %v", err)
+		}
 		return nil
 	}
 
@@ -179,6 +210,11 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return err
 	}
 
+	if captureACMEStateEnabled(o) {
+		dbg.Info("Fetching Authorizations from ACME server to capture their state")
+		captureAuthorizations(ctx, cl, acmeOrder)
+	}
+
 	switch {
 	case anyChallengesFailed(challenges):
 		// TODO (@munnerz): instead of waiting for the ACME server to mark this
@@ -235,6 +271,16 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 			}
 		}
 		return err
+
+	// The ACME server may consider every authorization on the Order to be
+	// valid before all of our Challenges have completed, for example if it
+	// reuses an authorization that was validated for another Order in the
+	// meantime. There is no need to wait for the remaining Challenges, which
+	// will be cleaned up once the Order is valid.
+	case !anyChallengesFailed(challenges) && (acmeOrder.Status == acmeapi.StatusReady || acmeOrder.Status == acmeapi.StatusValid):
+		log.V(logf.DebugLevel).Info("ACME server reports all authorizations as valid, updating order state", "state", acmeOrder.Status)
+		_, err := c.updateOrderStatusFromACMEOrder(ctx, cl, o, acmeOrder)
+		return err
 	}
 
 	log.V(logf.DebugLevel).Info("No action taken")
@@ -257,7 +303,7 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	log.V(logf.DebugLevel).Info("build set of domains for Order", "domains", dnsIdentifierSet.List())
 
 	ipIdentifierSet := sets.NewString(o.Spec.IPAddresses...)
-	log.V(logf.DebugLevel).Info("build set of IPs for Order", "domains", dnsIdentifierSet.List())
+	log.V(logf.DebugLevel).Info("build set of IPs for Order", "ips", ipIdentifierSet.List())
 
 	authzIDs := acmeapi.DomainIDs(dnsIdentifierSet.List()...)
 	authzIDs = append(authzIDs, acmeapi.IPIDs(ipIdentifierSet.List()...)...)
@@ -273,6 +319,10 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to create Order: %v", err)
+			if ipIdentifierSet.Len() > 0 && isUnsupportedIdentifierError(acmeErr) {
+				o.Status.Reason = fmt.Sprintf("Failed to create Order: the ACME server does not support IP address identifiers, which are requested for %v: %v", ipIdentifierSet.List(), err)
+			}
+			c.recorder.Event(o, corev1.EventTypeWarning, reasonFailed, cmerrors.WithRemediationHint(o.Status.Reason, withOrderErrorHint(acmeErr)))
 			return nil
 		}
 	}
@@ -289,6 +339,33 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	return nil
 }
 
+// orderProblemHints are remediation hints for the ACME problem types that
+// commonly cause creating an Order to fail.
+var orderProblemHints = map[string]string{
+	"urn:ietf:params:acme:error:rateLimited":         "the ACME server is rate limiting requests for this account or these identifiers, wait for the limit to reset and avoid repeatedly recreating Certificates",
+	"urn:ietf:params:acme:error:rejectedIdentifier":  "the ACME server will not issue for one of the requested identifiers, check the dnsNames and ipAddresses of the Certificate",
+	"urn:ietf:params:acme:error:accountDoesNotExist": "the ACME account of the issuer is not registered with the ACME server, delete the issuer's private key Secret to register a new account",
+}
+
+// withOrderErrorHint adds a remediation hint to an error creating an Order
+// based on its ACME problem type.
+func withOrderErrorHint(acmeErr *acmeapi.Error) error {
+	return cmerrors.NewWithRemediationHint(acmeErr, orderProblemHints[acmeErr.ProblemType])
+}
+
+// isUnsupportedIdentifierError returns true if the ACME server rejected an
+// order because it does not issue certificates for one of its identifiers.
+// This is how servers which do not support IP address identifiers (RFC 8738)
+// respond to orders containing them, as support for them is not advertised in
+// the ACME directory.
+func isUnsupportedIdentifierError(err *acmeapi.Error) bool {
+	switch err.ProblemType {
+	case "urn:ietf:params:acme:error:unsupportedIdentifier", "urn:ietf:params:acme:error:rejectedIdentifier":
+		return true
+	}
+	return false
+}
+
 func (c *controller) updateOrderStatus(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
 	acmeOrder, err := getACMEOrder(ctx, cl, o)
 	if err != nil {
@@ -386,6 +463,59 @@ func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmac
 	return nil
 }
 
+// recordFailedChallengeTypes adds the type of each owned Challenge that has
+// fallen back to the failed challenge types of the authorization it was
+// created for, so that a challenge of the next preferred type is used instead.
+func (c *controller) recordFailedChallengeTypes(o *cmacme.Order) error {
+	challenges, err := c.listOwnedChallenges(o)
+	if err != nil {
+		return err
+	}
+	for _, ch := range challenges {
+		if !ch.Status.Fallback {
+			continue
+		}
+		for i, authz := range o.Status.Authorizations {
+			if authz.URL != ch.Spec.AuthorizationURL || challengeTypeFailed(authz, ch.Spec.Type) {
+				continue
+			}
+			o.Status.Authorizations[i].FailedChallengeTypes = append(o.Status.Authorizations[i].FailedChallengeTypes, ch.Spec.Type)
+		}
+	}
+	return nil
+}
+
+// recordChallengePresentations copies the details of how each owned Challenge
+// has been presented to the authorization it was created for, so that the
+// resolved DNS01 record can be inspected on the Order.
+func (c *controller) recordChallengePresentations(o *cmacme.Order) error {
+	challenges, err := c.listOwnedChallenges(o)
+	if err != nil {
+		return err
+	}
+	for _, ch := range challenges {
+		if ch.Status.Presentation == nil {
+			continue
+		}
+		for i, authz := range o.Status.Authorizations {
+			if authz.URL != ch.Spec.AuthorizationURL {
+				continue
+			}
+			o.Status.Authorizations[i].Presentation = ch.Status.Presentation.DeepCopy()
+		}
+	}
+	return nil
+}
+
+func anyChallengeTypesFailed(o *cmacme.Order) bool {
+	for _, a := range o.Status.Authorizations {
+		if len(a.FailedChallengeTypes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *controller) anyRequiredChallengesDoNotExist(requiredChallenges []cmacme.Challenge) (bool, error) {
 	for _, ch := range requiredChallenges {
 		_, err := c.challengeLister.Challenges(ch.Namespace).Get(ch.Name)
@@ -510,7 +640,10 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	}
 
 	// Call to CreateOrderCert finalizes the ACME order. This call can only be made once.
-	certSlice, certURL, err := cl.CreateOrderCert(ctx, o.Status.FinalizeURL, derBytes, true)
+	// The certificate is not downloaded by CreateOrderCert, but fetched below
+	// using FetchCert, which requests and checks for a PEM encoded
+	// certificate chain.
+	_, certURL, err := cl.CreateOrderCert(ctx, o.Status.FinalizeURL, derBytes, false)
 
 	acmeErr, ok := err.(*acmeapi.Error)
 
@@ -567,21 +700,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 		return fmt.Errorf("error finalizing order: %v", err)
 	}
 
-	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
-		preferredChain := issuer.GetSpec().ACME.PreferredChain
-		found, altChain, err := getAltCertChain(ctx, cl, certURL, preferredChain)
-		if err != nil {
-			return fmt.Errorf("error retrieving alternate chain: %w", err)
-		}
-		if found {
-			return c.storeCertificateOnStatus(ctx, o, altChain)
-		}
-		// if no match is found we return to the actual cert
-		// it is a *preferred* chain after all
-		log.V(logf.DebugLevel).Info(fmt.Sprintf("Preferred chain %s not found, fall back to the default cert", preferredChain))
-	}
-
-	return c.storeCertificateOnStatus(ctx, o, certSlice)
+	return c.fetchCertificateData(ctx, cl, certURL, o, issuer)
 }
 
 func (c *controller) storeCertificateOnStatus(ctx context.Context, o *cmacme.Order, certs [][]byte) error {
@@ -629,23 +748,34 @@ func (c *controller) syncCertificateData(ctx context.Context, cl acmecl.Interfac
 }
 
 func (c *controller) syncCertificateDataWithOrder(ctx context.Context, cl acmecl.Interface, acmeOrder acmeapi.Order, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
-	log := logf.FromContext(ctx)
 	// Certificate data can only be fetched for a valid order
 	if acmeOrder.Status != acmeapi.StatusValid {
 		return nil
 	}
 
+	return c.fetchCertificateData(ctx, cl, acmeOrder.CertURL, o, issuer)
+}
+
+// fetchCertificateData downloads the certificate chain of a valid order from
+// the given URL, or the issuer's preferred chain if one is available, and
+// stores it on the Order's status.
+func (c *controller) fetchCertificateData(ctx context.Context, cl acmecl.Interface, certURL string, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
+	log := logf.FromContext(ctx)
 	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
-		found, altCerts, err := getAltCertChain(ctx, cl, acmeOrder.CertURL, issuer.GetSpec().ACME.PreferredChain)
+		preferredChain := issuer.GetSpec().ACME.PreferredChain
+		found, altCerts, err := getAltCertChain(ctx, cl, certURL, preferredChain)
 		if err != nil {
-			return err
+			return fmt.Errorf("error retrieving alternate chain: %w", err)
 		}
 		if found {
 			return c.storeCertificateOnStatus(ctx, o, altCerts)
 		}
-
+		// if no match is found we return to the actual cert
+		// it is a *preferred* chain after all
+		log.V(logf.DebugLevel).Info(fmt.Sprintf("Preferred chain %s not found, fall back to the default cert", preferredChain))
 	}
-	certs, err := cl.FetchCert(ctx, acmeOrder.CertURL, true)
+
+	certs, err := cl.FetchCert(ctx, certURL, true)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to retrieve issued certificate from ACME server")
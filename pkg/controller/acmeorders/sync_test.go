@@ -145,6 +145,8 @@ func TestSync(t *testing.T) {
 	}
 
 	testOrderPending := gen.OrderFrom(testOrder, gen.SetOrderStatus(pendingStatus))
+	testOrderCaptureState := gen.OrderFrom(testOrder, gen.SetOrderAnnotations(map[string]string{cmacme.CaptureACMEStateAnnotationKey: "true"}))
+	testOrderPendingCaptureState := gen.OrderFrom(testOrderPending, gen.SetOrderAnnotations(map[string]string{cmacme.CaptureACMEStateAnnotationKey: "true"}))
 	testOrderInvalid := testOrderPending.DeepCopy()
 	testOrderInvalid.Status.State = cmacme.Invalid
 	testOrderInvalid.Status.FailureTime = &nowMetaTime
@@ -200,6 +202,11 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	testOrderValidAltCert.Status.State = cmacme.Valid
 	testOrderValidAltCert.Status.Certificate = testCert
 
+	testOrderCertificateFetchFailed := gen.OrderFrom(testOrder, gen.SetOrderStatus(pendingStatus))
+	testOrderCertificateFetchFailed.Status.State = cmacme.Errored
+	testOrderCertificateFetchFailed.Status.FailureTime = &nowMetaTime
+	testOrderCertificateFetchFailed.Status.Reason = fmt.Sprintf("Failed to retrieve signed certificate: %v", &acmeError429)
+
 	fakeHTTP01ACMECl := &acmecl.FakeACME{
 		FakeHTTP01ChallengeResponse: func(s string) (string, error) {
 			// TODO: assert s = "token"
@@ -214,6 +221,73 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	testAuthorizationChallengeValid.Status.State = cmacme.Valid
 	testAuthorizationChallengeInvalid := testAuthorizationChallenge.DeepCopy()
 	testAuthorizationChallengeInvalid.Status.State = cmacme.Invalid
+	testAuthorizationChallengePresented := testAuthorizationChallenge.DeepCopy()
+	testAuthorizationChallengePresented.Status.Presentation = &cmacme.ACMEChallengePresentation{
+		Solver:       "cloudflare",
+		ResolvedFQDN: "_acme-challenge.test.com.delegated.net.",
+		ResolvedZone: "delegated.net.",
+		CNAMETarget:  "_acme-challenge.test.com.delegated.net.",
+	}
+	testOrderPendingPresented := testOrderPending.DeepCopy()
+	testOrderPendingPresented.Status.Authorizations[0].Presentation = testAuthorizationChallengePresented.Status.Presentation
+
+	testIssuerDNS01Preferred := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01, cmacme.ACMEChallengeTypeHTTP01},
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{},
+				},
+			},
+			{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}))
+	fakeACMEClBothTypes := &acmecl.FakeACME{
+		FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+			return "key", nil
+		},
+		FakeDNS01ChallengeRecord: func(s string) (string, error) {
+			return "dnskey", nil
+		},
+	}
+	testOrderPendingBothTypes := gen.OrderFrom(testOrderPending, gen.SetOrderStatus(cmacme.OrderStatus{
+		State:       cmacme.Pending,
+		URL:         "http://testurl.com/abcde",
+		FinalizeURL: "http://testurl.com/abcde/finalize",
+		Authorizations: []cmacme.ACMEAuthorization{
+			{
+				URL:        "http://authzurl",
+				Identifier: "test.com",
+				Challenges: []cmacme.ACMEChallenge{
+					{
+						URL:   "http://chalurl",
+						Token: "token",
+						Type:  "http-01",
+					},
+					{
+						URL:   "http://dnschalurl",
+						Token: "dnstoken",
+						Type:  "dns-01",
+					},
+				},
+			},
+		},
+	}))
+	testDNS01ChallengeFallback, err := buildChallenge(context.TODO(), fakeACMEClBothTypes, testIssuerDNS01Preferred, testOrderPendingBothTypes, testOrderPendingBothTypes.Status.Authorizations[0])
+	if err != nil {
+		t.Fatalf("error building Challenge resource test fixture: %v", err)
+	}
+	testDNS01ChallengeFallback.Status.Fallback = true
+	testOrderPendingDNS01Failed := testOrderPendingBothTypes.DeepCopy()
+	testOrderPendingDNS01Failed.Status.Authorizations[0].FailedChallengeTypes = []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01}
+	testHTTP01ChallengeFallback, err := buildChallenge(context.TODO(), fakeACMEClBothTypes, testIssuerDNS01Preferred, testOrderPendingDNS01Failed, testOrderPendingDNS01Failed.Status.Authorizations[0])
+	if err != nil {
+		t.Fatalf("error building Challenge resource test fixture: %v", err)
+	}
 
 	testACMEAuthorizationPending := &acmeapi.Authorization{
 		URI:    "http://authzurl",
@@ -229,6 +303,47 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 		},
 	}
 
+	// an authorization that the ACME server has reused from an earlier Order
+	testACMEAuthorizationValid := &acmeapi.Authorization{}
+	*testACMEAuthorizationValid = *testACMEAuthorizationPending
+	testACMEAuthorizationValid.Status = acmeapi.StatusValid
+
+	testOrderMissingAuthzMetadata := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		State:       cmacme.Ready,
+		URL:         "http://testurl.com/abcde",
+		FinalizeURL: "http://testurl.com/abcde/finalize",
+		Authorizations: []cmacme.ACMEAuthorization{
+			{
+				URL: "http://authzurl",
+			},
+		},
+	}))
+
+	testOrderMixedAuthorizations := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		State:       cmacme.Pending,
+		URL:         "http://testurl.com/abcde",
+		FinalizeURL: "http://testurl.com/abcde/finalize",
+		Authorizations: []cmacme.ACMEAuthorization{
+			pendingStatus.Authorizations[0],
+			{
+				URL:          "http://authzurl-www",
+				Identifier:   "www.test.com",
+				InitialState: cmacme.Valid,
+				Challenges: []cmacme.ACMEChallenge{
+					{
+						URL:   "http://chalurl-www",
+						Token: "token-www",
+						Type:  "http-01",
+					},
+				},
+			},
+		},
+	}))
+	testMixedAuthorizationsChallenge, err := buildChallenge(context.TODO(), fakeHTTP01ACMECl, testIssuerHTTP01TestCom, testOrderMixedAuthorizations, testOrderMixedAuthorizations.Status.Authorizations[0])
+	if err != nil {
+		t.Fatalf("error building Challenge resource test fixture: %v", err)
+	}
+
 	testACMEOrderPending := &acmeapi.Order{
 		URI: testOrderPending.Status.URL,
 		Identifiers: []acmeapi.AuthzID{
@@ -254,7 +369,19 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	*testACMEOrderInvalid = *testACMEOrderPending
 	testACMEOrderInvalid.Status = acmeapi.StatusInvalid
 
+	testOrderExternalIssuer := gen.OrderFrom(testOrder, gen.SetOrderIssuer(cmmeta.ObjectReference{
+		Name: "testissuer", Kind: "ExternalACMEIssuer", Group: "acme.example.com",
+	}))
+
 	tests := map[string]testT{
+		"do nothing if the order references an external issuer": {
+			order: testOrderExternalIssuer,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testOrderExternalIssuer},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			acmeClient: &acmecl.FakeACME{},
+		},
 		"create a new order with the acme server, set the order url on the status resource and return nil to avoid cache timing issues": {
 			order: testOrder,
 			builder: &testpkg.Builder{
@@ -291,6 +418,35 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"record the state of the new ACME order as an event if the order has the capture annotation": {
+			order: testOrderCaptureState,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderCaptureState},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderCaptureState, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+				ExpectedEvents: []string{
+					`Normal ACMEOrderState ACME order state: {"url":"http://testurl.com/abcde","status":"pending","identifiers":["dns:test.com"],"authorizations":["http://authzurl"],"finalize":"http://testurl.com/abcde/finalize"}`,
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+			},
+		},
 		"create a new order with the acme server with an IP address": {
 			order: testOrderIP,
 			builder: &testpkg.Builder{
@@ -330,6 +486,63 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"fail the order if the acme server does not support IP address identifiers": {
+			order: testOrderIP,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01, testOrderIP},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderIP.Namespace,
+						gen.OrderFrom(testOrderIP, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Errored,
+							FailureTime: &nowMetaTime,
+							Reason:      "Failed to create Order: the ACME server does not support IP address identifiers, which are requested for [10.0.0.1]: 400 urn:ietf:params:acme:error:unsupportedIdentifier: IP address identifiers are not supported",
+						})))),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed Failed to create Order: the ACME server does not support IP address identifiers, which are requested for [10.0.0.1]: 400 urn:ietf:params:acme:error:unsupportedIdentifier: IP address identifiers are not supported",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, &acmeapi.Error{
+						StatusCode:  400,
+						ProblemType: "urn:ietf:params:acme:error:unsupportedIdentifier",
+						Detail:      "IP address identifiers are not supported",
+					}
+				},
+			},
+		},
+		"fail the order and include a remediation hint in the event if the acme server is rate limiting": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrder.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Errored,
+							FailureTime: &nowMetaTime,
+							Reason:      "Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates already issued",
+						})))),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates already issued" +
+						" (hint: the ACME server is rate limiting requests for this account or these identifiers, wait for the limit to reset and avoid repeatedly recreating Certificates)",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, &acmeapi.Error{
+						StatusCode:  429,
+						ProblemType: "urn:ietf:params:acme:error:rateLimited",
+						Detail:      "too many certificates already issued",
+					}
+				},
+			},
+		},
 		"create a challenge resource for the test.com dnsName on the order": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -348,6 +561,32 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"record the failed challenge type and create a challenge of the next preferred type if a challenge has fallen back": {
+			order: testOrderPendingBothTypes,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerDNS01Preferred, testOrderPendingBothTypes, testDNS01ChallengeFallback},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(cmacme.SchemeGroupVersion.WithResource("challenges"), testHTTP01ChallengeFallback.Namespace, testHTTP01ChallengeFallback)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPendingDNS01Failed.Namespace, testOrderPendingDNS01Failed)),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf(`Normal Created Created Challenge resource %q for domain "test.com"`, testHTTP01ChallengeFallback.Name),
+				},
+			},
+			acmeClient: fakeACMEClBothTypes,
+		},
+		"delete the challenge that has fallen back once the next preferred challenge has been created": {
+			order: testOrderPendingDNS01Failed,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerDNS01Preferred, testOrderPendingDNS01Failed, testDNS01ChallengeFallback, testHTTP01ChallengeFallback},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(cmacme.SchemeGroupVersion.WithResource("challenges"), testDNS01ChallengeFallback.Namespace, testDNS01ChallengeFallback.Name)),
+				},
+			},
+			acmeClient: fakeACMEClBothTypes,
+		},
 		"should refuse to create a challenge if only an unknown challenge type is offered": {
 			order: gen.OrderFrom(testOrderPending, gen.SetOrderStatus(cmacme.OrderStatus{
 				State:       cmacme.Pending,
@@ -483,6 +722,78 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"record an authorization the ACME server reports as already valid when fetching authorization metadata": {
+			order: testOrderMissingAuthzMetadata,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderMissingAuthzMetadata},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrder.Namespace, gen.OrderFrom(testOrderMissingAuthzMetadata, gen.SetOrderStatus(
+							cmacme.OrderStatus{
+								State:       cmacme.Ready,
+								URL:         "http://testurl.com/abcde",
+								FinalizeURL: "http://testurl.com/abcde/finalize",
+								Authorizations: []cmacme.ACMEAuthorization{
+									{
+										URL:          "http://authzurl",
+										Identifier:   "test.com",
+										InitialState: cmacme.Valid,
+										Wildcard:     func(b bool) *bool { return &b }(false),
+										Challenges: []cmacme.ACMEChallenge{
+											{
+												Token: "token",
+												Type:  "http-01",
+											},
+										},
+									},
+								},
+							},
+						)),
+					)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					if url != "http://authzurl" {
+						return nil, fmt.Errorf("Invalid URL: expected http://authzurl got %q", url)
+					}
+					return testACMEAuthorizationValid, nil
+				},
+			},
+		},
+		"only create a Challenge for the pending authorization if the Order has both valid and pending authorizations": {
+			order: testOrderMixedAuthorizations,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderMixedAuthorizations},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(cmacme.SchemeGroupVersion.WithResource("challenges"), testMixedAuthorizationsChallenge.Namespace, testMixedAuthorizationsChallenge)),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf(`Normal Created Created Challenge resource %q for domain "test.com"`, testMixedAuthorizationsChallenge.Name),
+				},
+			},
+			acmeClient: fakeHTTP01ACMECl,
+		},
+		"update the order state to 'ready' if the ACME server has reused a valid authorization while the challenge is still pending": {
+			order: testOrderPending,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending, testAuthorizationChallenge},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderReady.Namespace, testOrderReady)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"do nothing if the challenge for test.com is still pending": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -499,6 +810,51 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"record how the challenge for test.com was presented on the order": {
+			order: testOrderPending,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending, testAuthorizationChallengePresented},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPendingPresented.Namespace, testOrderPendingPresented)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+		},
+		"record the state of the ACME order and authorizations as events if the order has the capture annotation": {
+			order: testOrderPendingCaptureState,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPendingCaptureState, testAuthorizationChallenge},
+				ExpectedActions:    []testpkg.Action{},
+				ExpectedEvents: []string{
+					`Normal ACMEOrderState ACME order state: {"url":"http://testurl.com/abcde","status":"pending","identifiers":["dns:test.com"],"authorizations":["http://authzurl"],"finalize":"http://testurl.com/abcde/finalize"}`,
+					`Normal ACMEAuthorizationState ACME authorization state: {"url":"http://authzurl","status":"pending","identifier":"test.com","challenges":[{"type":"http-01","status":""}]}`,
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					if url != "http://authzurl" {
+						return nil, fmt.Errorf("Invalid URL: expected http://authzurl got %q", url)
+					}
+					return testACMEAuthorizationPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"call GetOrder and update the order state to 'ready' if all challenges are 'valid'": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -536,9 +892,43 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
 					return testACMEOrderValid, nil
 				},
-				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
-					testData := []byte("test")
-					return [][]byte{testData}, "http://testurl", nil
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, wantCert bool) ([][]byte, string, error) {
+					if wantCert {
+						return nil, "", errors.New("Expecting the certificate to be fetched using FetchCert")
+					}
+					return nil, "http://testurl", nil
+				},
+				FakeFetchCert: func(_ context.Context, url string, bundle bool) ([][]byte, error) {
+					if url != "http://testurl" {
+						return nil, errors.New("Cert URL is incorrect")
+					}
+					return [][]byte{[]byte("test")}, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					// TODO: assert s = "token"
+					return "key", nil
+				},
+			},
+		},
+		"call FinalizeOrder and update the order state to 'errored' if the certificate cannot be fetched with a 4xx ACME error": {
+			order: testOrderReady,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderValid.Namespace, testOrderCertificateFetchFailed)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderValid, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, wantCert bool) ([][]byte, string, error) {
+					return nil, "http://testurl", nil
+				},
+				FakeFetchCert: func(_ context.Context, url string, bundle bool) ([][]byte, error) {
+					return nil, &acmeError429
 				},
 				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
 					// TODO: assert s = "token"
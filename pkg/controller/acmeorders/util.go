@@ -19,6 +19,8 @@ package acmeorders
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -82,8 +84,101 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 	log := logf.FromContext(ctx, "challengeSpecForAuthorization")
 	dbg := log.V(logf.DebugLevel)
 
-	// 1. fetch solvers from issuer
-	solvers := issuer.GetSpec().ACME.Solvers
+	wc := false
+	if authz.Wildcard != nil {
+		wc = *authz.Wildcard
+	}
+
+	// 1. fetch solvers from issuer, grouped by the preferred challenge type
+	var selectedSolver *cmacme.ACMEChallengeSolver
+	var selectedChallenge *cmacme.ACMEChallenge
+	for _, solvers := range solversByPreference(issuer.GetSpec().ACME, authz) {
+		selectedSolver, selectedChallenge = selectSolver(ctx, solvers, o, authz)
+		if selectedSolver != nil && selectedChallenge != nil {
+			break
+		}
+		dbg.Info("no solver of the preferred challenge type can be used, trying the next preferred type")
+	}
+
+	if selectedSolver == nil || selectedChallenge == nil {
+		return nil, fmt.Errorf("no configured challenge solvers can be used for this challenge")
+	}
+
+	// It should never be possible for this case to be hit as earlier in this
+	// method we already assert that the challenge type is one of 'http-01'
+	// or 'dns-01'.
+	chType, err := challengeType(selectedChallenge.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keyForChallenge(cl, selectedChallenge.Token, chType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. handle overriding the HTTP01 ingress class, name and port fields using
+	//    the ACMECertificateHTTP01IngressNameOverride, Class & Port annotations
+	if err := applyIngressParameterAnnotationOverrides(o, selectedSolver); err != nil {
+		return nil, err
+	}
+
+	// 5. construct Challenge resource with spec.solver field set
+	return &cmacme.ChallengeSpec{
+		AuthorizationURL: authz.URL,
+		Type:             chType,
+		URL:              selectedChallenge.URL,
+		DNSName:          authz.Identifier,
+		Token:            selectedChallenge.Token,
+		Key:              key,
+		// selectedSolver cannot be nil due to the check above.
+		Solver:    *selectedSolver,
+		Wildcard:  wc,
+		IssuerRef: o.Spec.IssuerRef,
+	}, nil
+}
+
+// solversByPreference groups the issuer's solvers by challenge type in the
+// order given by the issuer's challengeTypePreference, skipping any type that
+// has already failed for the authorization. If no preference is configured,
+// all solvers are returned as a single group.
+func solversByPreference(iss *cmacme.ACMEIssuer, authz cmacme.ACMEAuthorization) [][]cmacme.ACMEChallengeSolver {
+	if len(iss.ChallengeTypePreference) == 0 {
+		return [][]cmacme.ACMEChallengeSolver{iss.Solvers}
+	}
+
+	var groups [][]cmacme.ACMEChallengeSolver
+	for _, t := range iss.ChallengeTypePreference {
+		if challengeTypeFailed(authz, t) {
+			continue
+		}
+		var solvers []cmacme.ACMEChallengeSolver
+		for _, s := range iss.Solvers {
+			if acme.SolverChallengeType(s) == t {
+				solvers = append(solvers, s)
+			}
+		}
+		groups = append(groups, solvers)
+	}
+	return groups
+}
+
+// challengeTypeFailed returns true if the given challenge type has already
+// been abandoned for the authorization.
+func challengeTypeFailed(authz cmacme.ACMEAuthorization, t cmacme.ACMEChallengeType) bool {
+	for _, ft := range authz.FailedChallengeTypes {
+		if ft == t {
+			return true
+		}
+	}
+	return false
+}
+
+// selectSolver returns the most specific of the given solvers that matches
+// the authorization, along with the ACME challenge it should be used to solve.
+// If more than one solver is equally specific, the first in the list is used.
+func selectSolver(ctx context.Context, solvers []cmacme.ACMEChallengeSolver, o *cmacme.Order, authz cmacme.ACMEAuthorization) (*cmacme.ACMEChallengeSolver, *cmacme.ACMEChallenge) {
+	dbg := logf.FromContext(ctx, "selectSolver").V(logf.DebugLevel)
 
 	wc := false
 	if authz.Wildcard != nil {
@@ -100,12 +195,16 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 	selectedNumDNSNamesMatch := 0
 	selectedNumDNSZonesMatch := 0
 
+	// IP address identifiers cannot be validated using DNS01 challenges, as
+	// required by RFC 8738 section 7.
+	isIP := net.ParseIP(authz.Identifier) != nil
+
 	challengeForSolver := func(solver *cmacme.ACMEChallengeSolver) *cmacme.ACMEChallenge {
 		for _, ch := range authz.Challenges {
 			switch {
 			case ch.Type == "http-01" && solver.HTTP01 != nil:
 				return &ch
-			case ch.Type == "dns-01" && solver.DNS01 != nil:
+			case ch.Type == "dns-01" && solver.DNS01 != nil && !isIP:
 				return &ch
 			}
 		}
@@ -246,42 +345,7 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 		// fallback to choosing the first in the list
 	}
 
-	if selectedSolver == nil || selectedChallenge == nil {
-		return nil, fmt.Errorf("no configured challenge solvers can be used for this challenge")
-	}
-
-	// It should never be possible for this case to be hit as earlier in this
-	// method we already assert that the challenge type is one of 'http-01'
-	// or 'dns-01'.
-	chType, err := challengeType(selectedChallenge.Type)
-	if err != nil {
-		return nil, err
-	}
-
-	key, err := keyForChallenge(cl, selectedChallenge.Token, chType)
-	if err != nil {
-		return nil, err
-	}
-
-	// 4. handle overriding the HTTP01 ingress class and name fields using the
-	//    ACMECertificateHTTP01IngressNameOverride & Class annotations
-	if err := applyIngressParameterAnnotationOverrides(o, selectedSolver); err != nil {
-		return nil, err
-	}
-
-	// 5. construct Challenge resource with spec.solver field set
-	return &cmacme.ChallengeSpec{
-		AuthorizationURL: authz.URL,
-		Type:             chType,
-		URL:              selectedChallenge.URL,
-		DNSName:          authz.Identifier,
-		Token:            selectedChallenge.Token,
-		Key:              key,
-		// selectedSolver cannot be nil due to the check above.
-		Solver:    *selectedSolver,
-		Wildcard:  wc,
-		IssuerRef: o.Spec.IssuerRef,
-	}, nil
+	return selectedSolver, selectedChallenge
 }
 
 func challengeType(t string) (cmacme.ACMEChallengeType, error) {
@@ -296,7 +360,15 @@ func challengeType(t string) (cmacme.ACMEChallengeType, error) {
 }
 
 func applyIngressParameterAnnotationOverrides(o *cmacme.Order, s *cmacme.ACMEChallengeSolver) error {
-	if s.HTTP01 == nil || s.HTTP01.Ingress == nil || o.Annotations == nil {
+	if s.HTTP01 == nil || o.Annotations == nil {
+		return nil
+	}
+
+	if err := applyPortAnnotationOverride(o, s.HTTP01); err != nil {
+		return err
+	}
+
+	if s.HTTP01.Ingress == nil {
 		return nil
 	}
 
@@ -321,6 +393,27 @@ func applyIngressParameterAnnotationOverrides(o *cmacme.Order, s *cmacme.ACMECha
 	return nil
 }
 
+// applyPortAnnotationOverride sets the port of the HTTP01 ingress or
+// gatewayHTTPRoute solver to the value of the ACMECertificateHTTP01PortOverride
+// annotation, if it is set on the Order.
+func applyPortAnnotationOverride(o *cmacme.Order, http01 *cmacme.ACMEChallengeSolverHTTP01) error {
+	manualPort, hasManualPort := o.Annotations[cmacme.ACMECertificateHTTP01PortOverride]
+	if !hasManualPort || (http01.Ingress == nil && http01.GatewayHTTPRoute == nil) {
+		return nil
+	}
+	port, err := strconv.ParseInt(manualPort, 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("invalid value %q for annotation %q: must be a port number between 1 and 65535", manualPort, cmacme.ACMECertificateHTTP01PortOverride)
+	}
+	if http01.Ingress != nil {
+		http01.Ingress.Port = int32(port)
+	}
+	if http01.GatewayHTTPRoute != nil {
+		http01.GatewayHTTPRoute.Port = int32(port)
+	}
+	return nil
+}
+
 func keyForChallenge(cl acmecl.Interface, token string, chType cmacme.ACMEChallengeType) (string, error) {
 	switch chType {
 	case cmacme.ACMEChallengeTypeHTTP01:
@@ -176,6 +176,73 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				},
 			},
 		},
+		"should override the solver port if the port override annotation is specified": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverHTTP01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmacme.ACMECertificateHTTP01PortOverride: "8080",
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver: cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "empty-selector-solver",
+							Port: 8080,
+						},
+					},
+				},
+			},
+		},
+		"should return an error if the port override annotation is not a valid port": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverHTTP01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmacme.ACMECertificateHTTP01PortOverride: "http",
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedError: true,
+		},
 		"should return an error if both ingress class and name override annotations are set": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
@@ -1256,6 +1323,183 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  exampleComDNSNameSelectorSolver,
 			},
 		},
+		"uses a solver of the most preferred challenge type even if a less preferred one is more specific": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01, cmacme.ACMEChallengeTypeHTTP01},
+							Solvers: []cmacme.ACMEChallengeSolver{
+								exampleComDNSNameSelectorSolver,
+								emptySelectorSolverDNS01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01, *acmeChallengeDNS01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeDNS01,
+				DNSName: "example.com",
+				Token:   acmeChallengeDNS01.Token,
+				Key:     "dns01",
+				Solver:  emptySelectorSolverDNS01,
+			},
+		},
+		"falls back to the next preferred challenge type if the most preferred one has failed": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01, cmacme.ACMEChallengeTypeHTTP01},
+							Solvers: []cmacme.ACMEChallengeSolver{
+								exampleComDNSNameSelectorSolver,
+								emptySelectorSolverDNS01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier:           "example.com",
+				Challenges:           []cmacme.ACMEChallenge{*acmeChallengeHTTP01, *acmeChallengeDNS01},
+				FailedChallengeTypes: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver:  exampleComDNSNameSelectorSolver,
+			},
+		},
+		"uses the next preferred challenge type if the ACME server does not offer the most preferred one": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01, cmacme.ACMEChallengeTypeHTTP01},
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverDNS01,
+								emptySelectorSolverHTTP01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver:  emptySelectorSolverHTTP01,
+			},
+		},
+		"returns an error if every preferred challenge type has failed": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							ChallengeTypePreference: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01},
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverDNS01,
+								emptySelectorSolverHTTP01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier:           "example.com",
+				Challenges:           []cmacme.ACMEChallenge{*acmeChallengeHTTP01, *acmeChallengeDNS01},
+				FailedChallengeTypes: []cmacme.ACMEChallengeType{cmacme.ACMEChallengeTypeDNS01},
+			},
+			expectedError: true,
+		},
+		"uses an HTTP01 solver for an IP address identifier even if a DNS01 solver is listed first": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverDNS01,
+								emptySelectorSolverHTTP01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					IPAddresses: []string{"192.0.2.1"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "192.0.2.1",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01, *acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "192.0.2.1",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver:  emptySelectorSolverHTTP01,
+			},
+		},
+		"returns an error if only a DNS01 solver is configured for an IP address identifier": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverDNS01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					IPAddresses: []string{"2001:db8::1"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "2001:db8::1",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01},
+			},
+			expectedError: true,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -67,7 +67,9 @@ type InjectTarget interface {
 
 	// SetCA sets the CA of this target to the given certificate data (in the standard
 	// PEM format used across Kubernetes).  In cases where multiple CA fields exist per
-	// target (like admission webhook configs), all CAs are set to the given value.
+	// target (like admission webhook configs), all CAs are set to the given value,
+	// unless the target limits injection to some of them, as webhook configs do
+	// with the 'cert-manager.io/inject-ca-into-webhooks' annotation.
 	SetCA(data []byte)
 }
 
@@ -17,10 +17,14 @@ limitations under the License.
 package cainjector
 
 import (
+	"strings"
+
 	admissionreg "k8s.io/api/admissionregistration/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apireg "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 // this contains implementations of CertInjector (and dependents)
@@ -40,8 +44,8 @@ func (i mutatingWebhookInjector) NewTarget() InjectTarget {
 	return &mutatingWebhookTarget{}
 }
 
-// mutatingWebhookTarget knows how to set CA data for all the webhooks
-// in a mutatingWebhookConfiguration.
+// mutatingWebhookTarget knows how to set CA data for all the webhooks, or
+// the webhooks selected by name, in a mutatingWebhookConfiguration.
 type mutatingWebhookTarget struct {
 	obj admissionreg.MutatingWebhookConfiguration
 }
@@ -50,8 +54,11 @@ func (t *mutatingWebhookTarget) AsObject() client.Object {
 	return &t.obj
 }
 func (t *mutatingWebhookTarget) SetCA(data []byte) {
+	selected := webhookSelector(t.obj.Annotations)
 	for ind := range t.obj.Webhooks {
-		t.obj.Webhooks[ind].ClientConfig.CABundle = data
+		if selected(t.obj.Webhooks[ind].Name) {
+			t.obj.Webhooks[ind].ClientConfig.CABundle = data
+		}
 	}
 }
 
@@ -66,8 +73,8 @@ func (i validatingWebhookInjector) IsAlpha() bool {
 	return false
 }
 
-// validatingWebhookTarget knows how to set CA data for all the webhooks
-// in a validatingWebhookConfiguration.
+// validatingWebhookTarget knows how to set CA data for all the webhooks, or
+// the webhooks selected by name, in a validatingWebhookConfiguration.
 type validatingWebhookTarget struct {
 	obj admissionreg.ValidatingWebhookConfiguration
 }
@@ -77,9 +84,31 @@ func (t *validatingWebhookTarget) AsObject() client.Object {
 }
 
 func (t *validatingWebhookTarget) SetCA(data []byte) {
+	selected := webhookSelector(t.obj.Annotations)
 	for ind := range t.obj.Webhooks {
-		t.obj.Webhooks[ind].ClientConfig.CABundle = data
+		if selected(t.obj.Webhooks[ind].Name) {
+			t.obj.Webhooks[ind].ClientConfig.CABundle = data
+		}
+	}
+}
+
+// webhookSelector returns a function reporting whether the webhook with the
+// given name should have its CA set, according to the
+// 'cert-manager.io/inject-ca-into-webhooks' annotation. All webhooks are
+// selected if the annotation is not set.
+func webhookSelector(annotations map[string]string) func(name string) bool {
+	names, ok := annotations[cmapi.WantInjectWebhooksAnnotation]
+	if !ok {
+		return func(string) bool { return true }
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selected[name] = true
+		}
 	}
+	return func(name string) bool { return selected[name] }
 }
 
 // apiServiceInjector knows how to create an InjectTarget for APICAReferences
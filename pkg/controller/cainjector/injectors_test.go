@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cainjector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionreg "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var existingCAData = []byte("existing-ca-data")
+
+func webhookAnnotations(webhooks *string) map[string]string {
+	annotations := map[string]string{cmapi.WantInjectAnnotation: "ns/cert"}
+	if webhooks != nil {
+		annotations[cmapi.WantInjectWebhooksAnnotation] = *webhooks
+	}
+	return annotations
+}
+
+var webhookSelectionTests = map[string]struct {
+	webhooks *string
+	expFirst []byte
+	expOther []byte
+}{
+	"all webhooks are injected if no webhooks are named": {
+		webhooks: nil,
+		expFirst: testCAData,
+		expOther: testCAData,
+	},
+	"only the named webhook is injected": {
+		webhooks: pointer.StringPtr("first.example.com"),
+		expFirst: testCAData,
+		expOther: existingCAData,
+	},
+	"whitespace around webhook names is ignored": {
+		webhooks: pointer.StringPtr(" other.example.com , unknown.example.com"),
+		expFirst: existingCAData,
+		expOther: testCAData,
+	},
+	"no webhooks are injected if the annotation is empty": {
+		webhooks: pointer.StringPtr(""),
+		expFirst: existingCAData,
+		expOther: existingCAData,
+	},
+}
+
+func TestMutatingWebhookTargetSetCA(t *testing.T) {
+	for name, test := range webhookSelectionTests {
+		t.Run(name, func(t *testing.T) {
+			target := &mutatingWebhookTarget{obj: admissionreg.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhooks", Annotations: webhookAnnotations(test.webhooks)},
+				Webhooks: []admissionreg.MutatingWebhook{
+					{Name: "first.example.com", ClientConfig: admissionreg.WebhookClientConfig{CABundle: existingCAData}},
+					{Name: "other.example.com", ClientConfig: admissionreg.WebhookClientConfig{CABundle: existingCAData}},
+				},
+			}}
+
+			target.SetCA(testCAData)
+			assert.Equal(t, test.expFirst, target.obj.Webhooks[0].ClientConfig.CABundle)
+			assert.Equal(t, test.expOther, target.obj.Webhooks[1].ClientConfig.CABundle)
+		})
+	}
+}
+
+func TestValidatingWebhookTargetSetCA(t *testing.T) {
+	for name, test := range webhookSelectionTests {
+		t.Run(name, func(t *testing.T) {
+			target := &validatingWebhookTarget{obj: admissionreg.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhooks", Annotations: webhookAnnotations(test.webhooks)},
+				Webhooks: []admissionreg.ValidatingWebhook{
+					{Name: "first.example.com", ClientConfig: admissionreg.WebhookClientConfig{CABundle: existingCAData}},
+					{Name: "other.example.com", ClientConfig: admissionreg.WebhookClientConfig{CABundle: existingCAData}},
+				},
+			}}
+
+			target.SetCA(testCAData)
+			assert.Equal(t, test.expFirst, target.obj.Webhooks[0].ClientConfig.CABundle)
+			assert.Equal(t, test.expOther, target.obj.Webhooks[1].ClientConfig.CABundle)
+		})
+	}
+}
@@ -176,10 +176,13 @@ func dataFromSliceOrFile(data []byte, file string) ([]byte, error) {
 // RegisterCertificateBased registers all known injection controllers that
 // target Certificate resources with the  given manager, and adds relevant
 // indices.
+// If namespaces is not empty, only Certificates and Secrets in those
+// namespaces are watched, and only targets injecting a CA from them are
+// updated.
 // The registered controllers require the cert-manager API to be available
 // in order to run.
-func RegisterCertificateBased(ctx context.Context, mgr ctrl.Manager) error {
-	cache, client, err := newIndependentCacheAndDelegatingClient(mgr)
+func RegisterCertificateBased(ctx context.Context, mgr ctrl.Manager, namespaces []string) error {
+	cache, client, err := newIndependentCacheAndDelegatingClient(mgr, namespaces)
 	if err != nil {
 		return err
 	}
@@ -188,7 +191,7 @@ func RegisterCertificateBased(ctx context.Context, mgr ctrl.Manager) error {
 		"certificate",
 		mgr,
 		[]caDataSource{
-			&certificateDataSource{client: cache},
+			&certificateDataSource{client: cache, namespaces: namespaces},
 		},
 		client,
 		cache,
@@ -198,10 +201,12 @@ func RegisterCertificateBased(ctx context.Context, mgr ctrl.Manager) error {
 // RegisterSecretBased registers all known injection controllers that
 // target Secret resources with the  given manager, and adds relevant
 // indices.
+// If namespaces is not empty, only Secrets in those namespaces are watched,
+// and only targets injecting a CA from them are updated.
 // The registered controllers only require the corev1 APi to be available in
 // order to run.
-func RegisterSecretBased(ctx context.Context, mgr ctrl.Manager) error {
-	cache, client, err := newIndependentCacheAndDelegatingClient(mgr)
+func RegisterSecretBased(ctx context.Context, mgr ctrl.Manager, namespaces []string) error {
+	cache, client, err := newIndependentCacheAndDelegatingClient(mgr, namespaces)
 	if err != nil {
 		return err
 	}
@@ -210,7 +215,7 @@ func RegisterSecretBased(ctx context.Context, mgr ctrl.Manager) error {
 		"secret",
 		mgr,
 		[]caDataSource{
-			&secretDataSource{client: cache},
+			&secretDataSource{client: cache, namespaces: namespaces},
 			&kubeconfigDataSource{},
 		},
 		client,
@@ -224,12 +229,22 @@ func RegisterSecretBased(ctx context.Context, mgr ctrl.Manager) error {
 // cert-manager Certificates CRDs have been installed and before the CA bundles
 // have been injected into the cert-manager CRDs, by the secrets based injector,
 // which is running in a separate goroutine.
-func newIndependentCacheAndDelegatingClient(mgr ctrl.Manager) (cache.Cache, client.Client, error) {
+// If namespaces is not empty, namespaced resources are only cached from those
+// namespaces; cluster scoped injection targets are always cached.
+func newIndependentCacheAndDelegatingClient(mgr ctrl.Manager, namespaces []string) (cache.Cache, client.Client, error) {
 	cacheOptions := cache.Options{
 		Scheme: mgr.GetScheme(),
 		Mapper: mgr.GetRESTMapper(),
 	}
-	ca, err := cache.New(mgr.GetConfig(), cacheOptions)
+	newCache := cache.New
+	switch len(namespaces) {
+	case 0:
+	case 1:
+		cacheOptions.Namespace = namespaces[0]
+	default:
+		newCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+	ca, err := newCache(mgr.GetConfig(), cacheOptions)
 	if err != nil {
 		return nil, nil, err
 	}
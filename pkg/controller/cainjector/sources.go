@@ -88,6 +88,10 @@ func (c *kubeconfigDataSource) ApplyTo(ctx context.Context, mgr ctrl.Manager, se
 // 'namespace/name'.
 type certificateDataSource struct {
 	client client.Reader
+
+	// namespaces, if not empty, are the only namespaces from which
+	// Certificates will be read.
+	namespaces []string
 }
 
 func (c *certificateDataSource) Configured(log logr.Logger, metaObj metav1.Object) bool {
@@ -108,6 +112,10 @@ func (c *certificateDataSource) ReadCA(ctx context.Context, log logr.Logger, met
 		// don't return an error, requeuing won't help till this is changed
 		return nil, nil
 	}
+	if !namespaceWatched(c.namespaces, certName.Namespace) {
+		log.V(logf.WarnLevel).Info("refusing to inject CA from a certificate outside of the watched namespaces")
+		return nil, nil
+	}
 
 	var cert cmapi.Certificate
 	if err := c.client.Get(ctx, certName, &cert); err != nil {
@@ -174,6 +182,10 @@ func (c *certificateDataSource) ApplyTo(ctx context.Context, mgr ctrl.Manager, s
 // 'namespace/name'.
 type secretDataSource struct {
 	client client.Reader
+
+	// namespaces, if not empty, are the only namespaces from which Secrets
+	// will be read.
+	namespaces []string
 }
 
 func (c *secretDataSource) Configured(log logr.Logger, metaObj metav1.Object) bool {
@@ -194,6 +206,10 @@ func (c *secretDataSource) ReadCA(ctx context.Context, log logr.Logger, metaObj
 		// don't return an error, requeuing won't help till this is changed
 		return nil, nil
 	}
+	if !namespaceWatched(c.namespaces, secretName.Namespace) {
+		log.V(logf.WarnLevel).Info("refusing to inject CA from a secret outside of the watched namespaces")
+		return nil, nil
+	}
 
 	// grab the associated secret
 	var secret corev1.Secret
@@ -235,3 +251,18 @@ func (c *secretDataSource) ApplyTo(ctx context.Context, mgr ctrl.Manager, setup
 	}
 	return nil
 }
+
+// namespaceWatched returns true if the given namespace is one of the watched
+// namespaces, or if no namespaces are given and so all namespaces are
+// watched.
+func namespaceWatched(namespaces []string, namespace string) bool {
+	if len(namespaces) == 0 {
+		return true
+	}
+	for _, ns := range namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cainjector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionreg "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var testCAData = []byte("test-ca-data")
+
+func newFakeReader(t *testing.T, objs ...client.Object) client.Reader {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, cmapi.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func webhookAnnotatedWith(annotation, value string) metav1.Object {
+	return &admissionreg.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "webhook",
+			Annotations: map[string]string{annotation: value},
+		},
+	}
+}
+
+func TestCertificateDataSourceWatchedNamespaces(t *testing.T) {
+	objs := func(namespace string) []client.Object {
+		return []client.Object{
+			&cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "serving-cert"},
+				Spec:       cmapi.CertificateSpec{SecretName: "serving-cert"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   namespace,
+					Name:        "serving-cert",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "serving-cert"},
+				},
+				Data: map[string][]byte{cmmeta.TLSCAKey: testCAData},
+			},
+		}
+	}
+	reader := newFakeReader(t, append(objs("watched"), objs("unwatched")...)...)
+
+	tests := map[string]struct {
+		namespaces []string
+		injectFrom string
+		expCA      []byte
+	}{
+		"all namespaces are watched if none are given": {
+			injectFrom: "unwatched/serving-cert",
+			expCA:      testCAData,
+		},
+		"a certificate in a watched namespace is injected": {
+			namespaces: []string{"other", "watched"},
+			injectFrom: "watched/serving-cert",
+			expCA:      testCAData,
+		},
+		"a certificate outside of the watched namespaces is ignored": {
+			namespaces: []string{"other", "watched"},
+			injectFrom: "unwatched/serving-cert",
+			expCA:      nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &certificateDataSource{client: reader, namespaces: test.namespaces}
+			ca, err := source.ReadCA(context.Background(), logr.Discard(), webhookAnnotatedWith(cmapi.WantInjectAnnotation, test.injectFrom))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expCA, ca)
+		})
+	}
+}
+
+func TestSecretDataSourceWatchedNamespaces(t *testing.T) {
+	secret := func(namespace string) client.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        "ca",
+				Annotations: map[string]string{cmapi.AllowsInjectionFromSecretAnnotation: "true"},
+			},
+			Data: map[string][]byte{cmmeta.TLSCAKey: testCAData},
+		}
+	}
+	reader := newFakeReader(t, secret("watched"), secret("unwatched"))
+
+	tests := map[string]struct {
+		namespaces []string
+		injectFrom string
+		expCA      []byte
+	}{
+		"all namespaces are watched if none are given": {
+			injectFrom: "unwatched/ca",
+			expCA:      testCAData,
+		},
+		"a secret in a watched namespace is injected": {
+			namespaces: []string{"watched"},
+			injectFrom: "watched/ca",
+			expCA:      testCAData,
+		},
+		"a secret outside of the watched namespaces is ignored": {
+			namespaces: []string{"watched"},
+			injectFrom: "unwatched/ca",
+			expCA:      nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &secretDataSource{client: reader, namespaces: test.namespaces}
+			ca, err := source.ReadCA(context.Background(), logr.Discard(), webhookAnnotatedWith(cmapi.WantInjectFromSecretAnnotation, test.injectFrom))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expCA, ca)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"strings"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,6 +46,10 @@ const (
 	// this controller when enabling or disabling it from
 	// command line flags.
 	CRControllerName = "certificaterequests-issuer-acme"
+
+	// rateLimitedProblemType is the ACME problem type returned by ACME
+	// servers when they are rate limiting requests.
+	rateLimitedProblemType = "urn:ietf:params:acme:error:rateLimited"
 )
 
 // ACME is a controller that implements `certificaterequests.Issuer`.
@@ -178,6 +183,18 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	if acme.IsFailureState(order.Status.State) {
 		message := fmt.Sprintf("Failed to wait for order resource %q to become ready", expectedOrder.Name)
 		err := fmt.Errorf("order is in %q state: %s", order.Status.State, order.Status.Reason)
+
+		// If the order failed because the ACME server is rate limiting
+		// requests, delete it so that a new order is created once the
+		// CertificateRequest is retried after the rate limit backoff.
+		if strings.Contains(order.Status.Reason, rateLimitedProblemType) {
+			log.Error(err, message)
+			if err := a.acmeClientV.Orders(order.Namespace).Delete(ctx, order.Name, metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+				return nil, err
+			}
+			return nil, issuerpkg.NewRateLimitedError(err)
+		}
+
 		a.reporter.Failed(cr, err, "OrderFailed", message)
 		return nil, nil
 	}
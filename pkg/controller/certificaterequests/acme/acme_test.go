@@ -452,6 +452,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -507,6 +508,43 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if the order resource failed because of an ACME rate limit then we should delete it and report RateLimited": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					`Warning RateLimited Failed to sign certificate request, will retry: order is in "errored" state: Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates already issued`,
+				},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(),
+					gen.OrderFrom(baseOrder,
+						gen.SetOrderState(cmacme.Errored),
+						gen.SetOrderReason("Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates already issued"),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewDeleteAction(
+						cmacme.SchemeGroupVersion.WithResource("orders"),
+						gen.DefaultTestNamespace,
+						baseOrder.Name,
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Minute))),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonRateLimited,
+								Message:            `Failed to sign certificate request, will retry: order is in "errored" state: Failed to create Order: 429 urn:ietf:params:acme:error:rateLimited: too many certificates already issued`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
 		"if the order is in an unknown state, then report pending": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
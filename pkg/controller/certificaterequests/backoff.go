@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRetryBaseDelay is the delay before the first retry of a
+	// CertificateRequest whose Sign call failed.
+	defaultRetryBaseDelay = 5 * time.Second
+
+	// defaultRetryMaxDelay is used as the cap when no maximum retry backoff
+	// has been configured.
+	defaultRetryMaxDelay = 5 * time.Minute
+
+	// retryJitterFactor is the fraction of each delay that may be randomly
+	// removed so that requests failing together do not retry in lockstep.
+	retryJitterFactor = 0.2
+)
+
+// retryBackoff tracks the number of consecutive failed Sign calls for each
+// CertificateRequest and computes an exponentially growing delay before it
+// should be retried. The delay never exceeds maxDelay.
+// Failure counts are held in memory only and so restart from zero when the
+// controller restarts.
+type retryBackoff struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+
+	// rand returns a value in [0.0, 1.0) used to jitter delays. Overridden in
+	// tests.
+	rand func() float64
+
+	lock     sync.Mutex
+	failures map[string]int
+}
+
+func newRetryBackoff(baseDelay, maxDelay time.Duration) *retryBackoff {
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	if baseDelay > maxDelay {
+		baseDelay = maxDelay
+	}
+	return &retryBackoff{
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		rand:      rand.Float64,
+		failures:  make(map[string]int),
+	}
+}
+
+// Next records a failure for the given key and returns how long to wait
+// before retrying it.
+func (b *retryBackoff) Next(key string) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	exp := b.failures[key]
+	b.failures[key] = exp + 1
+
+	backoff := float64(b.baseDelay) * math.Pow(2, float64(exp))
+	if backoff > float64(b.maxDelay) {
+		backoff = float64(b.maxDelay)
+	}
+
+	// The first retry always waits for exactly the base delay, which is too
+	// short for jitter to be meaningful. Later retries are jittered, and jitter
+	// is subtracted rather than added so that the maximum is never exceeded.
+	if exp > 0 {
+		backoff -= backoff * retryJitterFactor * b.rand()
+	}
+
+	return time.Duration(backoff)
+}
+
+// Failures returns the number of consecutive failures recorded for the key.
+func (b *retryBackoff) Failures(key string) int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.failures[key]
+}
+
+// Reset forgets any failures recorded for the given key.
+func (b *retryBackoff) Reset(key string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.failures, key)
+}
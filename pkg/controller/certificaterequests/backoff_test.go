@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := map[string]struct {
+		baseDelay time.Duration
+		maxDelay  time.Duration
+		rand      float64
+		expected  []time.Duration
+	}{
+		"delay doubles on each failure until the cap is reached": {
+			baseDelay: time.Second,
+			maxDelay:  10 * time.Second,
+			expected: []time.Duration{
+				time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+				10 * time.Second, 10 * time.Second,
+			},
+		},
+		"jitter reduces the delay of retries after the first": {
+			baseDelay: time.Second,
+			maxDelay:  4 * time.Second,
+			rand:      0.5,
+			expected: []time.Duration{
+				time.Second, 1800 * time.Millisecond, 3600 * time.Millisecond, 3600 * time.Millisecond,
+			},
+		},
+		"an unset cap falls back to the default maximum": {
+			baseDelay: 4 * time.Minute,
+			expected:  []time.Duration{4 * time.Minute, defaultRetryMaxDelay, defaultRetryMaxDelay},
+		},
+		"a base delay larger than the cap is limited to the cap": {
+			baseDelay: time.Hour,
+			maxDelay:  time.Minute,
+			expected:  []time.Duration{time.Minute, time.Minute},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := newRetryBackoff(test.baseDelay, test.maxDelay)
+			b.rand = func() float64 { return test.rand }
+
+			for i, expected := range test.expected {
+				if got := b.Next("ns/name"); got != expected {
+					t.Errorf("unexpected delay for failure %d, exp=%s got=%s", i+1, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryBackoffReset(t *testing.T) {
+	b := newRetryBackoff(time.Second, time.Minute)
+	b.rand = func() float64 { return 0 }
+
+	b.Next("ns/a")
+	b.Next("ns/a")
+	b.Next("ns/b")
+
+	if got := b.Failures("ns/a"); got != 2 {
+		t.Errorf("expected 2 failures for ns/a, got %d", got)
+	}
+
+	b.Reset("ns/a")
+	if got := b.Failures("ns/a"); got != 0 {
+		t.Errorf("expected failures for ns/a to be reset, got %d", got)
+	}
+	if got := b.Next("ns/a"); got != time.Second {
+		t.Errorf("expected delay to restart from the base delay after reset, got %s", got)
+	}
+
+	// Other keys are not affected by a reset.
+	if got := b.Next("ns/b"); got != 2*time.Second {
+		t.Errorf("expected ns/b delay to keep growing, got %s", got)
+	}
+}
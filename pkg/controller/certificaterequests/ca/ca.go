@@ -27,10 +27,12 @@ import (
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -45,11 +47,16 @@ type templateGenerator func(*cmapi.CertificateRequest) (*x509.Certificate, error
 type signingFn func([]*x509.Certificate, crypto.Signer, *x509.Certificate) (pki.PEMBundle, error)
 
 type CA struct {
-	issuerOptions controllerpkg.IssuerOptions
-	secretsLister corelisters.SecretLister
+	issuerOptions     controllerpkg.IssuerOptions
+	secretsLister     corelisters.SecretLister
+	certificateLister cmlisters.CertificateLister
 
 	reporter *crutil.Reporter
 
+	// templatePolicy may modify the certificate template before it is
+	// signed. If nil, the template is signed as generated.
+	templatePolicy issuerpkg.TemplatePolicy
+
 	// Used for testing to get reproducible resulting certificates
 	templateGenerator templateGenerator
 	signingFn         signingFn
@@ -68,7 +75,9 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &CA{
 		issuerOptions:     ctx.IssuerOptions,
 		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
 		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		templatePolicy:    issuerpkg.RegisteredTemplatePolicy(),
 		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
 		signingFn:         pki.SignCSRTemplate,
 	}
@@ -80,11 +89,30 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
 	log := logf.FromContext(ctx, "sign")
 
-	secretName := issuerObj.GetSpec().CA.SecretName
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
+	// resolve the Secret from the Certificate named on the Issuer, if any. The
+	// Secret is looked up on every call so that a rotated CA is used for all
+	// subsequent requests.
+	secretName, err := caissuer.SigningSecretName(c.certificateLister, resourceNamespace, issuerObj.GetSpec().CA)
+	if k8sErrors.IsNotFound(err) {
+		message := fmt.Sprintf("Referenced Certificate %s/%s not found", resourceNamespace, issuerObj.GetSpec().CA.CertificateName)
+
+		c.reporter.Pending(cr, err, "CertificateMissing", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("Failed to get Certificate %s/%s", resourceNamespace, issuerObj.GetSpec().CA.CertificateName)
+		c.reporter.Pending(cr, err, "CertificateGetError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, secretName)
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 
@@ -120,6 +148,17 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
+	template.IssuingCertificateURL = issuerObj.GetSpec().CA.IssuingCertificateURLs
+	pki.SetCAPathLen(template, issuerObj.GetSpec().CA.PathLen)
+
+	if c.templatePolicy != nil {
+		if err := c.templatePolicy.MutateTemplate(ctx, cr, issuerObj, template); err != nil {
+			message := "Certificate template rejected by signing policy"
+			c.reporter.Failed(cr, err, "PolicyError", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
 
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
@@ -38,16 +38,20 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
@@ -283,6 +287,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -541,6 +546,68 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, true, got.IsCA)
 			},
 		},
+		"when the Issuer has a pathLen of 0 and the CertificateRequest has isCA set, the signed ca should only be able to sign end-entity certificates": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+				PathLen:    pointer.Int(0),
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.True(t, got.IsCA)
+				assert.Equal(t, 0, got.MaxPathLen)
+				assert.True(t, got.MaxPathLenZero)
+			},
+		},
+		"when the Issuer has a positive pathLen and the CertificateRequest has isCA set, it should appear on the signed ca": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+				PathLen:    pointer.Int(2),
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.True(t, got.IsCA)
+				assert.Equal(t, 2, got.MaxPathLen)
+				assert.False(t, got.MaxPathLenZero)
+			},
+		},
+		"when the Issuer has pathLen set but the CertificateRequest does not have isCA set, no path length constraint should be set": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+				PathLen:    pointer.Int(0),
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.False(t, got.IsCA)
+				assert.Equal(t, -1, got.MaxPathLen)
+				assert.False(t, got.MaxPathLenZero)
+			},
+		},
 		"when the Issuer has ocspServers set, it should appear on the signed ca": {
 			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
 			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
@@ -559,6 +626,33 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, []string{"http://ocsp-v3.example.org"}, got.OCSPServer)
 			},
 		},
+		"when the Issuer has ocspServers and issuingCertificateURLs set, they should appear in the AIA extension of the signed certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName:             "secret-1",
+				OCSPServers:            []string{"http://ocsp.example.com"},
+				IssuingCertificateURLs: []string{"http://ca.example.com/ca.crt", "http://ca-backup.example.com/ca.crt"},
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				aiaExtension := false
+				for _, ext := range got.Extensions {
+					if ext.Id.Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 1}) {
+						aiaExtension = true
+					}
+				}
+				assert.True(t, aiaExtension, "expected the signed certificate to have an authority information access extension")
+				assert.Equal(t, []string{"http://ocsp.example.com"}, got.OCSPServer)
+				assert.Equal(t, []string{"http://ca.example.com/ca.crt", "http://ca-backup.example.com/ca.crt"}, got.IssuingCertificateURL)
+			},
+		},
 		"when the Issuer has crlDistributionPoints set, it should appear on the signed ca ": {
 			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
 			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
@@ -578,6 +672,31 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, []string{"http://www.example.com/crl/test.crl"}, gotCA.CRLDistributionPoints)
 			},
 		},
+		"when the Issuer has multiple crlDistributionPoints set, they should all appear on the signed certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName:            "secret-1",
+				CRLDistributionPoints: []string{"http://crl-1.example.com/ca.crl", "http://crl-2.example.com/ca.crl"},
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				crlDPExtension := false
+				for _, ext := range got.Extensions {
+					if ext.Id.Equal(asn1.ObjectIdentifier{2, 5, 29, 31}) {
+						crlDPExtension = true
+					}
+				}
+				assert.True(t, crlDPExtension, "expected the signed certificate to have a CRL distribution points extension")
+				assert.Equal(t, []string{"http://crl-1.example.com/ca.crl", "http://crl-2.example.com/ca.crl"}, got.CRLDistributionPoints)
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -613,6 +732,179 @@ func TestCA_Sign(t *testing.T) {
 	}
 }
 
+func TestCA_SignWithRotatedCertificateSecret(t *testing.T) {
+	oldPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	oldCert, _ := generateSelfSignedCACert(t, oldPK, "root-1")
+
+	newPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	newCert, _ := generateSelfSignedCACert(t, newPK, "root-2")
+
+	testpk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	testCSR := generateCSR(t, testpk, x509.ECDSAWithSHA256)
+
+	certificateIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	require.NoError(t, certificateIndexer.Add(gen.Certificate("ca",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateSecretName("ca-tls"),
+	)))
+	require.NoError(t, secretIndexer.Add(gen.Secret("ca-tls",
+		gen.SetSecretNamespace("default"),
+		gen.SetSecretData(secretDataFor(t, oldPK, oldCert)),
+	)))
+
+	c := &CA{
+		reporter:          util.NewReporter(fixedClock, &testpkg.FakeRecorder{}),
+		secretsLister:     clientcorev1.NewSecretLister(secretIndexer),
+		certificateLister: cmlisters.NewCertificateLister(certificateIndexer),
+		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
+		signingFn:         pki.SignCSRTemplate,
+	}
+
+	issuer := gen.Issuer("issuer-1",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerCA(cmapi.CAIssuer{CertificateName: "ca"}),
+	)
+	cr := gen.CertificateRequest("cr-1",
+		gen.SetCertificateRequestNamespace("default"),
+		gen.SetCertificateRequestCSR(testCSR),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  "issuer-1",
+			Group: certmanager.GroupName,
+			Kind:  "Issuer",
+		}),
+	)
+
+	signAndVerify := func(t *testing.T, caCert *x509.Certificate) {
+		resp, err := c.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+
+		gotCert, err := pki.DecodeX509CertificateBytes(resp.Certificate)
+		require.NoError(t, err)
+		assert.NoError(t, gotCert.CheckSignatureFrom(caCert))
+		assert.Equal(t, caCert.Subject.String(), gotCert.Issuer.String())
+	}
+
+	signAndVerify(t, oldCert)
+
+	// Rotate the CA by updating the Secret referenced by the Certificate, as
+	// the certificates controller would when the CA is renewed.
+	require.NoError(t, secretIndexer.Update(gen.Secret("ca-tls",
+		gen.SetSecretNamespace("default"),
+		gen.SetSecretData(secretDataFor(t, newPK, newCert)),
+	)))
+
+	signAndVerify(t, newCert)
+}
+
+func TestCA_SignWithTemplatePolicy(t *testing.T) {
+	caPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	caCert, _ := generateSelfSignedCACert(t, caPK, "root")
+
+	testpk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	testCSR := generateCSR(t, testpk, x509.ECDSAWithSHA256)
+
+	certificateIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, secretIndexer.Add(gen.Secret("ca-tls",
+		gen.SetSecretNamespace("default"),
+		gen.SetSecretData(secretDataFor(t, caPK, caCert)),
+	)))
+
+	issuer := gen.Issuer("issuer-1",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-tls"}),
+	)
+	cr := gen.CertificateRequest("cr-1",
+		gen.SetCertificateRequestNamespace("default"),
+		gen.SetCertificateRequestCSR(testCSR),
+		gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageServerAuth),
+	)
+
+	tests := map[string]struct {
+		policy         issuerpkg.TemplatePolicy
+		expectedUsages []x509.ExtKeyUsage
+		expectedEvents []string
+	}{
+		"the default policy signs the template as generated": {
+			policy:         issuerpkg.NoopTemplatePolicy{},
+			expectedUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		},
+		"a policy can force a usage regardless of the CSR": {
+			policy: issuerpkg.TemplatePolicyFunc(func(_ context.Context, gotCR *cmapi.CertificateRequest, gotIssuer cmapi.GenericIssuer, template *x509.Certificate) error {
+				assert.Equal(t, cr, gotCR)
+				assert.Equal(t, issuer, gotIssuer)
+				template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+				return nil
+			}),
+			expectedUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+		"a policy error fails the request": {
+			policy: issuerpkg.TemplatePolicyFunc(func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer, *x509.Certificate) error {
+				return errors.New("server auth is not allowed")
+			}),
+			expectedEvents: []string{"Warning PolicyError Certificate template rejected by signing policy: server auth is not allowed"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := &testpkg.FakeRecorder{}
+			c := &CA{
+				reporter:          util.NewReporter(fixedClock, rec),
+				secretsLister:     clientcorev1.NewSecretLister(secretIndexer),
+				certificateLister: cmlisters.NewCertificateLister(certificateIndexer),
+				templatePolicy:    test.policy,
+				templateGenerator: pki.GenerateTemplateFromCertificateRequest,
+				signingFn:         pki.SignCSRTemplate,
+			}
+
+			resp, err := c.Sign(context.Background(), cr.DeepCopy(), issuer)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedEvents, rec.Events)
+			if test.expectedUsages == nil {
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NotNil(t, resp)
+			gotCert, err := pki.DecodeX509CertificateBytes(resp.Certificate)
+			require.NoError(t, err)
+			assert.NoError(t, gotCert.CheckSignatureFrom(caCert))
+			assert.Equal(t, test.expectedUsages, gotCert.ExtKeyUsage)
+		})
+	}
+}
+
+func TestCA_SignWithMissingCertificate(t *testing.T) {
+	rec := &testpkg.FakeRecorder{}
+	c := &CA{
+		reporter:          util.NewReporter(fixedClock, rec),
+		secretsLister:     clientcorev1.NewSecretLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		certificateLister: cmlisters.NewCertificateLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
+		signingFn:         pki.SignCSRTemplate,
+	}
+
+	issuer := gen.Issuer("issuer-1",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerCA(cmapi.CAIssuer{CertificateName: "ca"}),
+	)
+	cr := gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace("default"))
+
+	resp, err := c.Sign(context.Background(), cr, issuer)
+	require.NoError(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, []string{"Normal CertificateMissing Referenced Certificate default/ca not found: certificate.cert-manager.io \"ca\" not found"}, rec.Events)
+}
+
 // Returns a map that is meant to be used for creating a certificate Secret
 // that contains the fields "tls.crt" and "tls.key".
 func secretDataFor(t *testing.T, caKey *ecdsa.PrivateKey, caCrt *x509.Certificate) (secretData map[string][]byte) {
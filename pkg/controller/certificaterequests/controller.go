@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/sync/semaphore"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
@@ -42,6 +43,9 @@ var keyFunc = controllerpkg.KeyFunc
 // Issuer implements the functionality to sign a certificate request for a
 // particular issuer type.
 type Issuer interface {
+	// Sign signs the CertificateRequest. Errors may be classified using the
+	// error classes in the issuer package, e.g. issuer.NewRateLimitedError,
+	// to control how the failure is reported and whether it is retried.
 	Sign(context.Context, *v1.CertificateRequest, v1.GenericIssuer) (*issuer.IssueResponse, error)
 }
 
@@ -84,6 +88,14 @@ type Controller struct {
 	issuerConstructor IssuerConstructor
 	issuer            Issuer
 
+	// signingLimiter, if set, is shared with all other signing controllers to
+	// bound the number of concurrent Sign calls
+	signingLimiter *semaphore.Weighted
+
+	// backoff computes the delay before retrying CertificateRequests whose
+	// Sign call returned an error
+	backoff *retryBackoff
+
 	// used for testing
 	clock clock.Clock
 
@@ -184,6 +196,8 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// recorder records events about resources to the Kubernetes api
 	c.recorder = ctx.Recorder
 	c.reporter = util.NewReporter(c.clock, c.recorder)
+	c.signingLimiter = ctx.IssuerOptions.SigningLimiter
+	c.backoff = newRetryBackoff(defaultRetryBaseDelay, ctx.CertificateOptions.CertificateRequestMaxRetryBackoff)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
 
@@ -212,6 +226,7 @@ func (c *Controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			dbg.Info(fmt.Sprintf("certificate request in work queue no longer exists: %s", err))
+			c.backoff.Reset(key)
 			return nil
 		}
 
@@ -54,6 +54,10 @@ type SelfSigned struct {
 	reporter *crutil.Reporter
 	recorder record.EventRecorder
 
+	// templatePolicy may modify the certificate template before it is
+	// signed. If nil, the template is signed as generated.
+	templatePolicy issuer.TemplatePolicy
+
 	// Used for testing to get reproducible resulting certificates
 	signingFn signingFn
 }
@@ -69,11 +73,12 @@ func init() {
 
 func NewSelfSigned(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &SelfSigned{
-		issuerOptions: ctx.IssuerOptions,
-		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
-		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		recorder:      ctx.Recorder,
-		signingFn:     pki.SignCertificate,
+		issuerOptions:  ctx.IssuerOptions,
+		secretsLister:  ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		reporter:       crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		recorder:       ctx.Recorder,
+		templatePolicy: issuer.RegisteredTemplatePolicy(),
+		signingFn:      pki.SignCertificate,
 	}
 }
 
@@ -131,6 +136,16 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 	}
 
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
+	pki.SetCAPathLen(template, issuerObj.GetSpec().SelfSigned.PathLen)
+
+	if s.templatePolicy != nil {
+		if err := s.templatePolicy.MutateTemplate(ctx, cr, issuerObj, template); err != nil {
+			message := "Certificate template rejected by signing policy"
+			s.reporter.Failed(cr, err, "ErrorPolicy", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
 
 	if template.Subject.String() == "" {
 		// RFC 5280 (https://tools.ietf.org/html/rfc5280#section-4.1.2.4) says that:
@@ -26,6 +26,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -42,7 +44,9 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	listersfake "github.com/cert-manager/cert-manager/test/unit/listers"
@@ -401,6 +405,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -611,6 +616,91 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSelfSigned_SignWithTemplatePolicy(t *testing.T) {
+	sk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skPEM, err := pki.EncodeECPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := secretIndexer.Add(gen.Secret("test-key",
+		gen.SetSecretNamespace(gen.DefaultTestNamespace),
+		gen.SetSecretData(map[string][]byte{corev1.TLSPrivateKeyKey: skPEM}),
+	)); err != nil {
+		t.Fatal(err)
+	}
+
+	selfSignedIssuer := gen.Issuer("test-issuer", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(generateCSR(t, sk, x509.ECDSAWithSHA256, "test")),
+		gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageServerAuth),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestPrivateKeyAnnotationKey: "test-key",
+		}),
+	)
+
+	tests := map[string]struct {
+		policy         issuer.TemplatePolicy
+		expectedUsages []x509.ExtKeyUsage
+		expectedEvents []string
+	}{
+		"a policy can force a usage regardless of the CSR": {
+			policy: issuer.TemplatePolicyFunc(func(_ context.Context, _ *cmapi.CertificateRequest, _ cmapi.GenericIssuer, template *x509.Certificate) error {
+				template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageClientAuth)
+				return nil
+			}),
+			expectedUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		},
+		"a policy error fails the request": {
+			policy: issuer.TemplatePolicyFunc(func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer, *x509.Certificate) error {
+				return errors.New("server auth is not allowed")
+			}),
+			expectedEvents: []string{"Warning ErrorPolicy Certificate template rejected by signing policy: server auth is not allowed"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := &testpkg.FakeRecorder{}
+			s := &SelfSigned{
+				secretsLister:  clientcorev1.NewSecretLister(secretIndexer),
+				reporter:       crutil.NewReporter(fixedClock, rec),
+				recorder:       rec,
+				templatePolicy: test.policy,
+				signingFn:      pki.SignCertificate,
+			}
+
+			resp, err := s.Sign(context.Background(), cr.DeepCopy(), selfSignedIssuer)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(rec.Events, test.expectedEvents) {
+				t.Errorf("expected events %v, got %v", test.expectedEvents, rec.Events)
+			}
+			if test.expectedUsages == nil {
+				if resp != nil {
+					t.Errorf("expected the request not to be signed, got %v", resp)
+				}
+				return
+			}
+
+			if resp == nil {
+				t.Fatal("expected the request to be signed")
+			}
+			cert, err := pki.DecodeX509CertificateBytes(resp.Certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cert.ExtKeyUsage, test.expectedUsages) {
+				t.Errorf("expected extended key usages %v, got %v", test.expectedUsages, cert.ExtKeyUsage)
+			}
+		})
+	}
+}
+
 type testT struct {
 	builder            *testpkg.Builder
 	certificateRequest *cmapi.CertificateRequest
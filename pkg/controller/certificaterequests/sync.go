@@ -18,9 +18,11 @@ package certificaterequests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
+	"github.com/go-logr/logr"
 	"github.com/kr/pretty"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -33,6 +35,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -131,15 +134,34 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	key, err := keyFunc(cr)
+	if err != nil {
+		log.Error(err, "failed to construct key for certificate request")
+		return nil
+	}
+
+	// If a previous Sign call failed, wait until the backoff has elapsed
+	// before calling the issuer again.
+	if nextRetryTime := crCopy.Status.NextRetryTime; nextRetryTime != nil {
+		if wait := nextRetryTime.Time.Sub(c.clock.Now()); wait > 0 {
+			dbg.Info("backing off after a failed sign attempt", "next_retry_time", nextRetryTime.Time)
+			c.queue.AddAfter(key, wait)
+			return nil
+		}
+	}
+
 	dbg.Info("invoking sign function as existing certificate does not exist")
 
 	// Attempt to call the Sign function on our issuer
-	resp, err := c.issuer.Sign(ctx, crCopy, issuerObj)
+	resp, err := c.sign(ctx, crCopy, issuerObj)
 	if err != nil {
-		log.Error(err, "error issuing certificate request")
-		return err
+		c.handleSignError(log, crCopy, key, err)
+		return nil
 	}
 
+	c.backoff.Reset(key)
+	crCopy.Status.NextRetryTime = nil
+
 	// If the issuer has not returned any data we may be pending or failed. The
 	// underlying issuer will have set the condition of pending or failed and we
 	// should potentially wait for a re-sync.
@@ -164,6 +186,67 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	return nil
 }
 
+// handleSignError reports an error returned by the issuer's Sign function on
+// the CertificateRequest and schedules a retry, based on which of the issuer
+// error classes the error belongs to:
+//
+//   - issuer.ErrPermanent fails the request with the Failed reason, and the
+//     request is never retried.
+//   - issuer.ErrRateLimited sets the RateLimited reason, and the request is
+//     retried after the maximum backoff so as not to prolong the rate limit.
+//   - issuer.ErrAuthFailed sets the AuthFailed reason, and the request is
+//     retried with exponential backoff.
+//   - issuer.ErrTransient sets the Pending reason, and the request is
+//     retried with exponential backoff.
+//
+// The conditions of requests failing with any other error are left unchanged
+// and the request is retried with exponential backoff.
+//
+// Retries are scheduled on the queue after the delay, so Sync should return
+// nil rather than the error, which would cause the workqueue to retry the
+// request sooner.
+func (c *Controller) handleSignError(log logr.Logger, cr *cmapi.CertificateRequest, key string, err error) {
+	if errors.Is(err, issuer.ErrPermanent) {
+		log.Error(err, "error issuing certificate request, not retrying")
+		c.backoff.Reset(key)
+		cr.Status.NextRetryTime = nil
+		c.reporter.Failed(cr, err, "SigningError", "Failed to sign certificate request")
+		return
+	}
+
+	delay := c.backoff.Next(key)
+	if errors.Is(err, issuer.ErrRateLimited) {
+		delay = c.backoff.maxDelay
+	}
+	log.Error(err, "error issuing certificate request", "retry_after", delay)
+	cr.Status.NextRetryTime = &metav1.Time{Time: c.clock.Now().Add(delay)}
+	c.queue.AddAfter(key, delay)
+
+	const message = "Failed to sign certificate request, will retry"
+	switch {
+	case errors.Is(err, issuer.ErrRateLimited):
+		c.reporter.Retrying(cr, err, cmapi.CertificateRequestReasonRateLimited, message)
+	case errors.Is(err, issuer.ErrAuthFailed):
+		c.reporter.Retrying(cr, err, cmapi.CertificateRequestReasonAuthFailed, message)
+	case errors.Is(err, issuer.ErrTransient):
+		c.reporter.Pending(cr, err, "TransientError", message)
+	}
+}
+
+// sign calls the issuer's Sign function. If a signing limiter is configured, it
+// first waits for a free slot so that the number of in-flight signings across
+// all issuers stays within the configured limit.
+func (c *Controller) sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+	if c.signingLimiter != nil {
+		if err := c.signingLimiter.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		defer c.signingLimiter.Release(1)
+	}
+
+	return c.issuer.Sign(ctx, cr, issuerObj)
+}
+
 func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Context, old, new *cmapi.CertificateRequest) error {
 	log := logf.FromContext(ctx, "updateStatus")
 
@@ -26,11 +26,15 @@ import (
 	"encoding/asn1"
 	"encoding/pem"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"golang.org/x/sync/semaphore"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
@@ -534,19 +538,197 @@ func TestSync(t *testing.T) {
 				ExpectedActions:    []testpkg.Action{},
 			},
 		},
-		"if calling sign errors, we should not update condition and return error to retry": {
+		"if calling sign errors, we should not update condition, set the next retry time and not return an error": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR),
 			issuerImpl: &fake.Issuer{
 				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
 					return nil, errors.New("sign call returns error")
 				},
 			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR, baseIssuer},
+				ExpectedEvents:     []string{},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(defaultRetryBaseDelay))),
+						),
+					)),
+				},
+			},
+			expectedErr: false,
+		},
+		"if calling sign returns a permanent error, we should fail the request and not retry": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return nil, issuer.NewPermanentError(errors.New("csr rejected"))
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR, baseIssuer},
+				ExpectedEvents: []string{
+					"Warning SigningError Failed to sign certificate request: csr rejected",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Failed to sign certificate request: csr rejected",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					)),
+				},
+			},
+		},
+		"if calling sign returns a rate limited error, we should set the RateLimited reason and retry after the maximum backoff": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return nil, issuer.NewRateLimitedError(errors.New("too many requests"))
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR, baseIssuer},
+				ExpectedEvents: []string{
+					"Warning RateLimited Failed to sign certificate request, will retry: too many requests",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonRateLimited,
+								Message:            "Failed to sign certificate request, will retry: too many requests",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(defaultRetryMaxDelay))),
+						),
+					)),
+				},
+			},
+		},
+		"if calling sign returns an auth failed error, we should set the AuthFailed reason and retry with backoff": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return nil, issuer.NewAuthFailedError(errors.New("token expired"))
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR, baseIssuer},
+				ExpectedEvents: []string{
+					"Warning AuthFailed Failed to sign certificate request, will retry: token expired",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonAuthFailed,
+								Message:            "Failed to sign certificate request, will retry: token expired",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(defaultRetryBaseDelay))),
+						),
+					)),
+				},
+			},
+		},
+		"if calling sign returns a transient error, we should set the Pending reason and retry with backoff": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return nil, issuer.NewTransientError(errors.New("connection reset"))
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR, baseIssuer},
+				ExpectedEvents: []string{
+					"Normal TransientError Failed to sign certificate request, will retry: connection reset",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Failed to sign certificate request, will retry: connection reset",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(defaultRetryBaseDelay))),
+						),
+					)),
+				},
+			},
+		},
+		"if the next retry time has not been reached, we should not call sign": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(time.Minute))),
+			),
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{baseCR, baseIssuer},
 				ExpectedEvents:     []string{},
 				ExpectedActions:    []testpkg.Action{},
 			},
-			expectedErr: true,
+		},
+		"if the next retry time has passed and sign succeeds, we should clear the next retry time": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(-time.Minute))),
+			),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: certRSAPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
 		},
 		"if calling sign returns nil, nil then we should return nil with no-op since the underlying issuer has probably set the condition to failed": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR),
@@ -782,3 +964,88 @@ func runTest(t *testing.T, test testT) {
 	}
 	test.builder.CheckAndFinish(err)
 }
+
+func TestSignConcurrencyLimitSharedAcrossIssuers(t *testing.T) {
+	const limit = 2
+
+	var inFlight, maxInFlight int32
+	release := make(chan struct{})
+	fakeSign := func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			cur := atomic.LoadInt32(&maxInFlight)
+			if n <= cur || atomic.CompareAndSwapInt32(&maxInFlight, cur, n) {
+				break
+			}
+		}
+		<-release
+		return nil, nil
+	}
+
+	// Controllers for different issuer types share the same limiter, as they
+	// do when built from the same controller Context.
+	limiter := semaphore.NewWeighted(limit)
+	var controllers []*Controller
+	for _, issuerType := range []string{util.IssuerCA, util.IssuerSelfSigned, util.IssuerVault} {
+		c := New(issuerType, nil)
+		c.issuer = &fake.Issuer{FakeSign: fakeSign}
+		c.signingLimiter = limiter
+		controllers = append(controllers, c)
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range controllers {
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(c *Controller) {
+				defer wg.Done()
+				if _, err := c.sign(context.Background(), gen.CertificateRequest("test"), gen.Issuer("test")); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}(c)
+		}
+	}
+
+	// Wait for the limit to be reached, then give any other signings the
+	// chance to (incorrectly) start before checking.
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return atomic.LoadInt32(&inFlight) == limit, nil
+	}); err != nil {
+		t.Fatalf("expected %d signings to be in flight, got %d", limit, atomic.LoadInt32(&inFlight))
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&inFlight); got != limit {
+		t.Errorf("expected %d signings to be in flight, got %d", limit, got)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if maxInFlight != limit {
+		t.Errorf("expected at most %d concurrent signings, got %d", limit, maxInFlight)
+	}
+}
+
+func TestSignWaitingForLimiterRespectsContext(t *testing.T) {
+	limiter := semaphore.NewWeighted(1)
+	if !limiter.TryAcquire(1) {
+		t.Fatal("failed to acquire limiter")
+	}
+	defer limiter.Release(1)
+
+	c := New(util.IssuerCA, nil)
+	c.issuer = &fake.Issuer{
+		FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+			t.Error("unexpected sign call while the limiter is exhausted")
+			return nil, nil
+		},
+	}
+	c.signingLimiter = limiter
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.sign(ctx, gen.CertificateRequest("test"), gen.Issuer("test")); err == nil {
+		t.Error("expected an error when the context is cancelled while waiting for the limiter")
+	}
+}
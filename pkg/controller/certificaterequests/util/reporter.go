@@ -103,6 +103,23 @@ func (r *Reporter) Pending(cr *cmapi.CertificateRequest, err error, reason, mess
 		cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending, message)
 }
 
+// Retrying marks a CertificateRequest as not Ready, using the given Ready
+// condition reason, after a failure that will be retried. A warning event is
+// sent using the same reason.
+//
+// The event is only sent if the Ready condition does not already have the
+// given reason.
+func (r *Reporter) Retrying(cr *cmapi.CertificateRequest, err error, reason, message string) {
+	message = fmt.Sprintf("%s: %v", message, err)
+
+	if apiutil.CertificateRequestReadyReason(cr) != reason {
+		r.recorder.Event(cr, corev1.EventTypeWarning, reason, message)
+	}
+
+	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
+		cmmeta.ConditionFalse, reason, message)
+}
+
 // Ready marks a CertificateRequest as Ready and sends a corresponding event.
 func (r *Reporter) Ready(cr *cmapi.CertificateRequest) {
 	r.recorder.Event(cr, corev1.EventTypeNormal, "CertificateIssued", readyMessage)
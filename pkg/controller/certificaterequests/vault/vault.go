@@ -18,7 +18,10 @@ package vault
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
+	vault "github.com/hashicorp/vault/api"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
@@ -45,6 +48,9 @@ type Vault struct {
 	reporter      *crutil.Reporter
 
 	vaultClientBuilder vaultinternal.ClientBuilder
+
+	// userAgent is the User-Agent sent with requests to Vault.
+	userAgent string
 }
 
 func init() {
@@ -63,6 +69,7 @@ func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		secretsLister:      ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		vaultClientBuilder: vaultinternal.New,
+		userAgent:          ctx.UserAgent,
 	}
 }
 
@@ -74,7 +81,7 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister, issuerObj)
+	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister, issuerObj, v.userAgent)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
@@ -96,6 +103,11 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	if err != nil {
 		message := "Vault failed to sign certificate"
 
+		if classifiedErr := classifySignError(err); classifiedErr != nil {
+			log.Error(err, message)
+			return nil, classifiedErr
+		}
+
 		v.reporter.Failed(cr, err, "SigningError", message)
 		log.Error(err, message)
 
@@ -109,3 +121,22 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 		CA:          caPem,
 	}, nil
 }
+
+// classifySignError classifies errors returned by Vault when signing a
+// request which may succeed when retried, based on the HTTP status code of the
+// response. It returns nil if the error should fail the request.
+func classifySignError(err error) error {
+	var respErr *vault.ResponseError
+	if !errors.As(err, &respErr) {
+		return nil
+	}
+	switch code := respErr.StatusCode; {
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return issuer.NewAuthFailedError(err)
+	case code == http.StatusTooManyRequests:
+		return issuer.NewRateLimitedError(err)
+	case code >= http.StatusInternalServerError:
+		return issuer.NewTransientError(err)
+	}
+	return nil
+}
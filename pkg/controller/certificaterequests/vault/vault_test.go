@@ -30,6 +30,7 @@ import (
 	"testing"
 	"time"
 
+	vault "github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -97,6 +98,11 @@ func generateSelfSignedCertFromCR(cr *cmapi.CertificateRequest, key crypto.Signe
 }
 
 func TestSign(t *testing.T) {
+	forbiddenErr := fmt.Errorf("failed to sign certificate by vault: %w",
+		&vault.ResponseError{HTTPMethod: "POST", URL: "https://vault/v1/pki/sign/role", StatusCode: 403, Errors: []string{"permission denied"}})
+	rateLimitedErr := fmt.Errorf("failed to sign certificate by vault: %w",
+		&vault.ResponseError{HTTPMethod: "POST", URL: "https://vault/v1/pki/sign/role", StatusCode: 429, Errors: []string{"request path \"pki/sign/role\": rate limit quota exceeded"}})
+
 	metaFixedClockStart := metav1.NewTime(fixedClockStart)
 	baseIssuer := gen.Issuer("vault-issuer",
 		gen.SetIssuerVault(cmapi.VaultIssuer{}),
@@ -373,6 +379,84 @@ func TestSign(t *testing.T) {
 			},
 			fakeVault: fakevault.New().WithSign(nil, nil, errors.New("failed to sign")),
 		},
+		"a client which is forbidden from signing should report AuthFailed and retry with backoff": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								Key: "my-token-key",
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "token-secret",
+								},
+							},
+						},
+					}),
+				)},
+				ExpectedEvents: []string{
+					"Warning AuthFailed Failed to sign certificate request, will retry: " + forbiddenErr.Error(),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonAuthFailed,
+								Message:            "Failed to sign certificate request, will retry: " + forbiddenErr.Error(),
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
+						),
+					)),
+				},
+			},
+			fakeVault: fakevault.New().WithSign(nil, nil, forbiddenErr),
+		},
+		"a client which is rate limited by vault should report RateLimited and retry after the maximum backoff": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								Key: "my-token-key",
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "token-secret",
+								},
+							},
+						},
+					}),
+				)},
+				ExpectedEvents: []string{
+					"Warning RateLimited Failed to sign certificate request, will retry: " + rateLimitedErr.Error(),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonRateLimited,
+								Message:            "Failed to sign certificate request, will retry: " + rateLimitedErr.Error(),
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Minute))),
+						),
+					)),
+				},
+			},
+			fakeVault: fakevault.New().WithSign(nil, nil, rateLimitedErr),
+		},
 		"a client with a app role secret referenced with role but failed to sign should report fail": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -527,7 +611,7 @@ func runTest(t *testing.T, test testT) {
 
 	if test.fakeVault != nil {
 		vault.vaultClientBuilder = func(ns string, sl corelisters.SecretLister,
-			iss cmapi.GenericIssuer) (internalvault.Interface, error) {
+			iss cmapi.GenericIssuer, _ string) (internalvault.Interface, error) {
 			return test.fakeVault.New(ns, sl, iss)
 		}
 	}
@@ -19,6 +19,7 @@ package venafi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -26,6 +27,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"github.com/Venafi/vcert/v4/pkg/verror"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -54,6 +56,9 @@ type Venafi struct {
 	clientBuilder venaficlient.VenafiClientBuilder
 
 	metrics *metrics.Metrics
+
+	// userAgent is the User-Agent sent with requests to Venafi.
+	userAgent string
 }
 
 func init() {
@@ -73,6 +78,7 @@ func NewVenafi(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		clientBuilder: venaficlient.New,
 		metrics:       ctx.Metrics,
 		cmClient:      ctx.CMClient,
+		userAgent:     ctx.UserAgent,
 	}
 }
 
@@ -80,7 +86,7 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
-	client, err := v.clientBuilder(v.issuerOptions.ResourceNamespace(issuerObj), v.secretsLister, issuerObj, v.metrics, log)
+	client, err := v.clientBuilder(v.issuerOptions.ResourceNamespace(issuerObj), v.secretsLister, issuerObj, v.metrics, log, v.userAgent)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
@@ -131,6 +137,11 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 			default:
 				message := "Failed to request venafi certificate"
 
+				if classifiedErr := classifyVenafiError(err); classifiedErr != nil {
+					log.Error(err, message)
+					return nil, classifiedErr
+				}
+
 				v.reporter.Failed(cr, err, "RequestError", message)
 				log.Error(err, message)
 
@@ -158,6 +169,11 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		default:
 			message := "Failed to obtain venafi certificate"
 
+			if classifiedErr := classifyVenafiError(err); classifiedErr != nil {
+				log.Error(err, message)
+				return nil, classifiedErr
+			}
+
 			v.reporter.Failed(cr, err, "RetrieveError", message)
 			log.Error(err, message)
 
@@ -180,3 +196,15 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		CA:          bundle.CAPEM,
 	}, nil
 }
+
+// classifyVenafiError classifies errors returned by vcert which may succeed
+// when retried. It returns nil if the error should fail the request.
+func classifyVenafiError(err error) error {
+	switch {
+	case errors.Is(err, verror.AuthError):
+		return issuerpkg.NewAuthFailedError(err)
+	case errors.Is(err, verror.ServerUnavailableError):
+		return issuerpkg.NewTransientError(err)
+	}
+	return nil
+}
@@ -24,11 +24,13 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"github.com/Venafi/vcert/v4/pkg/verror"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -247,6 +249,16 @@ func TestSign(t *testing.T) {
 			return "", errors.New("this is an error")
 		},
 	}
+	clientReturnsAuthError := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
+			return "", fmt.Errorf("%w: token expired", verror.AuthError)
+		},
+	}
+	clientReturnsServerUnavailableError := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
+			return "", fmt.Errorf("%w: connection refused", verror.ServerTemporaryUnavailableError)
+		},
+	}
 	clientReturnsCert := &internalvenafifake.Venafi{
 		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
 			return "test", nil
@@ -345,6 +357,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -397,6 +410,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -470,6 +484,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -516,6 +531,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -546,6 +562,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -577,6 +594,7 @@ func TestSign(t *testing.T) {
 						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -594,6 +612,64 @@ func TestSign(t *testing.T) {
 			expectedErr:        true,
 			skipSecondSignCall: false,
 		},
+		"tpp: if sign returns an auth error then set AuthFailed and retry with backoff": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning AuthFailed Failed to sign certificate request, will retry: vcert error: your data contains problems: auth error: token expired",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonAuthFailed,
+								Message:            "Failed to sign certificate request, will retry: vcert error: your data contains problems: auth error: token expired",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsAuthError,
+		},
+		"cloud: if sign returns a server unavailable error then set pending and retry with backoff": {
+			certificateRequest: cloudCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{cloudSecret},
+				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), cloudIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal TransientError Failed to sign certificate request, will retry: vcert error: server error: server unavailable: temporary: connection refused",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestNextRetryTime(metav1.NewTime(fixedClockStart.Add(5*time.Second))),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Failed to sign certificate request, will retry: vcert error: server error: server unavailable: temporary: connection refused",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsServerUnavailableError,
+		},
 		"tpp: if sign returns cert then return cert and not failed": {
 			certificateRequest: tppCR.DeepCopy(),
 			builder: &controllertest.Builder{
@@ -831,7 +907,7 @@ func runTest(t *testing.T, test testT) {
 
 	if test.fakeClient != nil {
 		v.clientBuilder = func(namespace string, secretsLister corelisters.SecretLister,
-			issuer cmapi.GenericIssuer, _ *metrics.Metrics, _ logr.Logger) (client.Interface, error) {
+			issuer cmapi.GenericIssuer, _ *metrics.Metrics, _ logr.Logger, _ string) (client.Interface, error) {
 			return test.fakeClient, nil
 		}
 	}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// PrivateKeyIsUnmanaged returns true if cert-manager should neither generate
+// nor store a private key for the given Certificate, and should instead sign
+// the CSR referenced by `spec.csrSecretRef`.
+func PrivateKeyIsUnmanaged(crt *cmapi.Certificate) bool {
+	return crt.Spec.PrivateKey != nil && crt.Spec.PrivateKey.Managed != nil && !*crt.Spec.PrivateKey.Managed
+}
+
+// CSRSecretKey returns the data key of the Secret referenced by the
+// Certificate's `spec.csrSecretRef` that holds the CSR.
+func CSRSecretKey(crt *cmapi.Certificate) string {
+	if crt.Spec.CSRSecretRef == nil || len(crt.Spec.CSRSecretRef.Key) == 0 {
+		return cmapi.CertificateCSRSecretDefaultKey
+	}
+	return crt.Spec.CSRSecretRef.Key
+}
+
+// FetchCSRForCertificate returns the PEM encoded CSR referenced by the
+// Certificate's `spec.csrSecretRef`, along with its decoded form.
+func FetchCSRForCertificate(lister corelisters.SecretLister, crt *cmapi.Certificate) ([]byte, *x509.CertificateRequest, error) {
+	if crt.Spec.CSRSecretRef == nil {
+		return nil, nil, fmt.Errorf("spec.csrSecretRef must be set when spec.privateKey.managed is false")
+	}
+
+	secret, err := lister.Secrets(crt.Namespace).Get(crt.Spec.CSRSecretRef.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := CSRSecretKey(crt)
+	csrPEM := secret.Data[key]
+	if len(csrPEM) == 0 {
+		return nil, nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, secret.Namespace, secret.Name)
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSR in secret '%s/%s': %w", secret.Namespace, secret.Name, err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, nil, fmt.Errorf("CSR in secret '%s/%s' has an invalid signature: %w", secret.Namespace, secret.Name, err)
+	}
+
+	return csrPEM, csr, nil
+}
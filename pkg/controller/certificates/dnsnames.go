@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// DNSNamesConfigMapErrorReason is the reason used in the conditions and
+// events of a Certificate whose DNS names ConfigMap cannot be read.
+const DNSNamesConfigMapErrorReason = "DNSNamesConfigMapError"
+
+// DNSNamesConfigMapKey returns the data key of the ConfigMap referenced by the
+// Certificate's `spec.dnsNamesConfigMapRef` that holds the additional DNS
+// names.
+func DNSNamesConfigMapKey(crt *cmapi.Certificate) string {
+	if crt.Spec.DNSNamesConfigMapRef == nil || len(crt.Spec.DNSNamesConfigMapRef.Key) == 0 {
+		return cmapi.CertificateDNSNamesConfigMapDefaultKey
+	}
+	return crt.Spec.DNSNamesConfigMapRef.Key
+}
+
+// DNSNamesConfigMapsInformer returns the informer for the ConfigMaps that may
+// be referenced by `spec.dnsNamesConfigMapRef`. Only ConfigMaps in the given
+// namespace carrying the CertificateDNSNamesConfigMapLabelKey label are
+// watched, so that cert-manager does not cache every ConfigMap in the
+// cluster. The informer is shared by all controllers using the factory.
+func DNSNamesConfigMapsInformer(factory informers.SharedInformerFactory, namespace string) coreinformers.ConfigMapInformer {
+	return &dnsNamesConfigMapsInformer{factory: factory, namespace: namespace}
+}
+
+type dnsNamesConfigMapsInformer struct {
+	factory   informers.SharedInformerFactory
+	namespace string
+}
+
+func (f *dnsNamesConfigMapsInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&corev1.ConfigMap{}, f.newInformer)
+}
+
+func (f *dnsNamesConfigMapsInformer) Lister() corelisters.ConfigMapLister {
+	return corelisters.NewConfigMapLister(f.Informer().GetIndexer())
+}
+
+func (f *dnsNamesConfigMapsInformer) newInformer(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return coreinformers.NewFilteredConfigMapInformer(client, f.namespace, resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(opts *metav1.ListOptions) {
+			opts.LabelSelector = cmapi.CertificateDNSNamesConfigMapLabelKey + "=true"
+		})
+}
+
+// dnsNamesConfigMapError is returned when the DNS names referenced by a
+// Certificate's `spec.dnsNamesConfigMapRef` cannot be read.
+type dnsNamesConfigMapError struct {
+	err error
+}
+
+func (e *dnsNamesConfigMapError) Error() string {
+	return e.err.Error()
+}
+
+func (e *dnsNamesConfigMapError) Unwrap() error {
+	return e.err
+}
+
+// IsDNSNamesConfigMapError returns true if the error was returned because the
+// ConfigMap referenced by a Certificate's `spec.dnsNamesConfigMapRef` does not
+// exist or does not hold a valid list of DNS names. These errors can only be
+// resolved by changing the ConfigMap, which causes the Certificate to be
+// processed again, so they should not be retried.
+func IsDNSNamesConfigMapError(err error) bool {
+	var cmErr *dnsNamesConfigMapError
+	return errors.As(err, &cmErr)
+}
+
+// CertificateWithConfigMapDNSNames returns the Certificate with the DNS names
+// listed in the ConfigMap referenced by `spec.dnsNamesConfigMapRef` merged
+// into `spec.dnsNames`. The Certificate is returned unchanged if it does not
+// reference a ConfigMap, otherwise a copy is returned and the given
+// Certificate is not modified.
+// An error satisfying IsDNSNamesConfigMapError is returned if the ConfigMap
+// cannot be read or if any of the names it contains are not valid DNS names.
+func CertificateWithConfigMapDNSNames(lister corelisters.ConfigMapLister, crt *cmapi.Certificate) (*cmapi.Certificate, error) {
+	if crt.Spec.DNSNamesConfigMapRef == nil {
+		return crt, nil
+	}
+
+	cm, err := lister.ConfigMaps(crt.Namespace).Get(crt.Spec.DNSNamesConfigMapRef.Name)
+	if err != nil {
+		return nil, &dnsNamesConfigMapError{err: err}
+	}
+
+	key := DNSNamesConfigMapKey(crt)
+	data, ok := cm.Data[key]
+	if !ok {
+		return nil, &dnsNamesConfigMapError{err: fmt.Errorf("no data for %q in configmap '%s/%s'", key, cm.Namespace, cm.Name)}
+	}
+
+	names, err := ParseDNSNamesList(data)
+	if err != nil {
+		return nil, &dnsNamesConfigMapError{err: fmt.Errorf("invalid DNS names in configmap '%s/%s': %w", cm.Namespace, cm.Name, err)}
+	}
+
+	crt = crt.DeepCopy()
+	existing := make(map[string]struct{}, len(crt.Spec.DNSNames))
+	for _, name := range crt.Spec.DNSNames {
+		existing[name] = struct{}{}
+	}
+	for _, name := range names {
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		existing[name] = struct{}{}
+		crt.Spec.DNSNames = append(crt.Spec.DNSNames, name)
+	}
+
+	return crt, nil
+}
+
+// ParseDNSNamesList parses a list of DNS names separated by newlines or
+// commas. Surrounding whitespace and empty entries are ignored. An error is
+// returned if any of the names is not a valid DNS name, optionally prefixed
+// with a `*.` wildcard label.
+func ParseDNSNamesList(data string) ([]string, error) {
+	var names []string
+	for _, field := range strings.FieldsFunc(data, func(r rune) bool {
+		return r == '\n' || r == ','
+	}) {
+		name := strings.TrimSpace(field)
+		if len(name) == 0 {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(strings.ToLower(strings.TrimPrefix(name, "*."))); len(errs) > 0 {
+			return nil, fmt.Errorf("%q is not a valid DNS name: %s", name, strings.Join(errs, ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
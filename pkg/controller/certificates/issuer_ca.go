@@ -0,0 +1,371 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"crypto/x509"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+// IssuerCAResolver finds the CA certificate which the issuer of a Certificate
+// currently issues certificates with, so that Certificates whose ca.crt has
+// become stale after the issuer's CA was rotated can be detected.
+// The CA is only known for CA issuers, which hold their signing keypair in a
+// Secret.
+// The CA of Vault issuers is only known to Vault, and self signed
+// certificates are their own CA, so rotation cannot be detected for them.
+type IssuerCAResolver struct {
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	certificateLister   cmlisters.CertificateLister
+	secretLister        corelisters.SecretLister
+
+	// issuerIndexer and clusterIssuerIndexer index CA issuers by their
+	// signing Secret, see issuerCASecretIndex.
+	issuerIndexer        cache.Indexer
+	clusterIssuerIndexer cache.Indexer
+
+	// clusterResourceNamespace is the namespace that the signing Secrets of
+	// ClusterIssuers are read from.
+	clusterResourceNamespace string
+}
+
+// issuerCASecretIndex is the name of the index of CA issuers by the
+// namespaced name of their signing Secret, prefixed with "secret:", or by the
+// namespaced name of the Certificate issuing the signing Secret, prefixed with
+// "certificate:", if the issuer references one.
+const issuerCASecretIndex = "issuerCASecret"
+
+// NewIssuerCAResolver returns an IssuerCAResolver reading issuers and their
+// Secrets from the given informer factories, along with the InformerSynced
+// functions of the informers it uses. ClusterIssuers are only read if
+// cert-manager is not scoped to a single namespace.
+// The issuer informers must not have been started yet, as an index of the
+// issuers by their signing Secret is added to them.
+func NewIssuerCAResolver(factory informers.SharedInformerFactory, cmFactory cminformers.SharedInformerFactory, namespace, clusterResourceNamespace string) (*IssuerCAResolver, []cache.InformerSynced, error) {
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
+	indexers := cache.Indexers{issuerCASecretIndex: issuerCASecretIndexFunc(clusterResourceNamespace)}
+	if err := issuerInformer.Informer().AddIndexers(indexers); err != nil {
+		return nil, nil, err
+	}
+	r := &IssuerCAResolver{
+		issuerLister:             issuerInformer.Lister(),
+		certificateLister:        certificateInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		issuerIndexer:            issuerInformer.Informer().GetIndexer(),
+		clusterResourceNamespace: clusterResourceNamespace,
+	}
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		if err := clusterIssuerInformer.Informer().AddIndexers(indexers); err != nil {
+			return nil, nil, err
+		}
+		r.clusterIssuerLister = clusterIssuerInformer.Lister()
+		r.clusterIssuerIndexer = clusterIssuerInformer.Informer().GetIndexer()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return r, mustSync, nil
+}
+
+// issuerCASecretIndexFunc indexes CA issuers by their signing Secret. The
+// signing Secrets of ClusterIssuers are read from the given namespace.
+func issuerCASecretIndexFunc(clusterResourceNamespace string) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		var (
+			spec      *cmapi.IssuerSpec
+			namespace string
+		)
+		switch iss := obj.(type) {
+		case *cmapi.Issuer:
+			spec, namespace = &iss.Spec, iss.Namespace
+		case *cmapi.ClusterIssuer:
+			spec, namespace = &iss.Spec, clusterResourceNamespace
+		default:
+			return nil, nil
+		}
+
+		switch {
+		case spec.CA == nil:
+			return nil, nil
+		case len(spec.CA.CertificateName) > 0:
+			return []string{"certificate:" + namespace + "/" + spec.CA.CertificateName}, nil
+		default:
+			return []string{"secret:" + namespace + "/" + spec.CA.SecretName}, nil
+		}
+	}
+}
+
+// IssuerCA returns the CA certificate which the issuer referenced by the
+// Certificate's `spec.issuerRef` currently issues certificates with. As for
+// the ca.crt of the Secrets of the Certificates it issues, this is the
+// certificate at the top of the chain held in the issuer's signing Secret.
+// nil is returned if the CA of the issuer is not known, for example because
+// the issuer is not a CA issuer, or because it or its signing Secret cannot
+// be found. An error is only returned if the signing Secret cannot be parsed.
+func (r *IssuerCAResolver) IssuerCA(crt *cmapi.Certificate) (*x509.Certificate, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	ref := crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	var (
+		spec      *cmapi.IssuerSpec
+		namespace string
+	)
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		iss, err := r.issuerLister.Issuers(crt.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, nil
+		}
+		spec, namespace = &iss.Spec, crt.Namespace
+	case cmapi.ClusterIssuerKind:
+		if r.clusterIssuerLister == nil {
+			return nil, nil
+		}
+		iss, err := r.clusterIssuerLister.Get(ref.Name)
+		if err != nil {
+			return nil, nil
+		}
+		spec, namespace = &iss.Spec, r.clusterResourceNamespace
+	default:
+		return nil, nil
+	}
+
+	if spec.CA == nil {
+		return nil, nil
+	}
+
+	secretName, err := caissuer.SigningSecretName(r.certificateLister, namespace, spec.CA)
+	if err != nil {
+		return nil, nil
+	}
+	secret, err := r.secretLister.Secrets(namespace).Get(secretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return caForSigningSecret(secret)
+}
+
+// caForSigningSecret returns the certificate at the top of the chain formed
+// by the tls.crt and ca.crt of a CA issuer's signing Secret, in the same way
+// as the CA of the certificates signed by the issuer is chosen.
+func caForSigningSecret(secret *corev1.Secret) (*x509.Certificate, error) {
+	certs, err := pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+	if caBytes := secret.Data[cmmeta.TLSCAKey]; len(caBytes) > 0 {
+		ca, err := pki.DecodeX509CertificateBytes(caBytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, ca)
+	}
+
+	bundle, err := pki.ParseSingleCertificateChain(certs)
+	if err != nil {
+		return nil, err
+	}
+	// A single CA certificate which is not self signed is returned as the
+	// chain only.
+	caPEM := bundle.CAPEM
+	if len(caPEM) == 0 {
+		caPEM = bundle.ChainPEM
+	}
+
+	return pki.DecodeX509CertificateBytes(caPEM)
+}
+
+// IssuerCASecretEventHandler returns an event handler for Secrets which
+// enqueues the Certificates of the CA issuers signing with a Secret whenever
+// it is created, or its certificate data changes.
+func (r *IssuerCAResolver) IssuerCASecretEventHandler(log logr.Logger, queue workqueue.Interface) cache.ResourceEventHandler {
+	enqueue := r.EnqueueCertificatesForIssuerCASecret(log, queue)
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, oldOK := oldObj.(*corev1.Secret)
+			newSecret, newOK := newObj.(*corev1.Secret)
+			if !oldOK || !newOK {
+				log.V(logf.ErrorLevel).Info("Non-Secret type resource passed to IssuerCASecretEventHandler")
+				return
+			}
+			if bytes.Equal(oldSecret.Data[corev1.TLSCertKey], newSecret.Data[corev1.TLSCertKey]) &&
+				bytes.Equal(oldSecret.Data[cmmeta.TLSCAKey], newSecret.Data[cmmeta.TLSCAKey]) {
+				return
+			}
+			enqueue(newSecret)
+		},
+	}
+}
+
+// IssuerEventHandler returns an event handler for Issuers and ClusterIssuers
+// which enqueues the Certificates referencing an issuer whenever its spec
+// changes, as it may then sign with another CA or set other private key
+// defaults.
+func (r *IssuerCAResolver) IssuerEventHandler(log logr.Logger, queue workqueue.Interface) cache.ResourceEventHandler {
+	enqueue := r.EnqueueCertificatesForIssuer(log, queue)
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldIssuer, oldOK := oldObj.(cmapi.GenericIssuer)
+			newIssuer, newOK := newObj.(cmapi.GenericIssuer)
+			if !oldOK || !newOK {
+				log.V(logf.ErrorLevel).Info("Non-issuer type resource passed to IssuerEventHandler")
+				return
+			}
+			if apiequality.Semantic.DeepEqual(oldIssuer.GetSpec(), newIssuer.GetSpec()) {
+				return
+			}
+			enqueue(newObj)
+		},
+	}
+}
+
+// EnqueueCertificatesForIssuerCASecret returns a function which, given a
+// Secret, enqueues every Certificate referencing a CA issuer that signs with
+// that Secret, so that the Certificates are checked promptly after the
+// issuer's CA is rotated. The issuers are looked up by the name of the Secret
+// and, for issuers referencing the Certificate issuing their signing Secret,
+// by the Certificate named in the Secret's annotations.
+func (r *IssuerCAResolver) EnqueueCertificatesForIssuerCASecret(log logr.Logger, queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-Object type resource passed to EnqueueCertificatesForIssuerCASecret")
+			return
+		}
+
+		keys := []string{"secret:" + secret.GetNamespace() + "/" + secret.GetName()}
+		if crtName := secret.GetAnnotations()[cmapi.CertificateNameKey]; len(crtName) > 0 {
+			keys = append(keys, "certificate:"+secret.GetNamespace()+"/"+crtName)
+		}
+
+		for _, key := range keys {
+			issuers, err := r.issuerIndexer.ByIndex(issuerCASecretIndex, key)
+			if err != nil {
+				log.Error(err, "Failed listing Issuer resources")
+				return
+			}
+			for _, obj := range issuers {
+				iss := obj.(*cmapi.Issuer)
+				if r.signsWithSecret(&iss.Spec, iss.Namespace, secret.GetName()) {
+					r.enqueueCertificatesForIssuer(log, queue, iss.Namespace, cmapi.IssuerKind, iss.Name)
+				}
+			}
+
+			if r.clusterIssuerIndexer == nil || secret.GetNamespace() != r.clusterResourceNamespace {
+				continue
+			}
+			clusterIssuers, err := r.clusterIssuerIndexer.ByIndex(issuerCASecretIndex, key)
+			if err != nil {
+				log.Error(err, "Failed listing ClusterIssuer resources")
+				return
+			}
+			for _, obj := range clusterIssuers {
+				iss := obj.(*cmapi.ClusterIssuer)
+				if r.signsWithSecret(&iss.Spec, r.clusterResourceNamespace, secret.GetName()) {
+					r.enqueueCertificatesForIssuer(log, queue, metav1.NamespaceAll, cmapi.ClusterIssuerKind, iss.Name)
+				}
+			}
+		}
+	}
+}
+
+// EnqueueCertificatesForIssuer returns a function which, given an Issuer or
+// ClusterIssuer, enqueues every Certificate referencing it, so that the
+// Certificates are checked promptly after the issuer is changed to sign with
+// another CA.
+func (r *IssuerCAResolver) EnqueueCertificatesForIssuer(log logr.Logger, queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		switch iss := obj.(type) {
+		case *cmapi.Issuer:
+			r.enqueueCertificatesForIssuer(log, queue, iss.Namespace, cmapi.IssuerKind, iss.Name)
+		case *cmapi.ClusterIssuer:
+			r.enqueueCertificatesForIssuer(log, queue, metav1.NamespaceAll, cmapi.ClusterIssuerKind, iss.Name)
+		default:
+			log.V(logf.ErrorLevel).Info("Non-issuer type resource passed to EnqueueCertificatesForIssuer")
+		}
+	}
+}
+
+// signsWithSecret returns true if the issuer is a CA issuer whose signing
+// keypair is held in the named Secret.
+func (r *IssuerCAResolver) signsWithSecret(spec *cmapi.IssuerSpec, namespace, name string) bool {
+	if spec.CA == nil {
+		return false
+	}
+	secretName, err := caissuer.SigningSecretName(r.certificateLister, namespace, spec.CA)
+	return err == nil && secretName == name
+}
+
+func (r *IssuerCAResolver) enqueueCertificatesForIssuer(log logr.Logger, queue workqueue.Interface, namespace, kind, name string) {
+	certs, err := ListCertificatesMatchingPredicates(r.certificateLister.Certificates(namespace), labels.Everything(),
+		predicate.CertificateIssuerRef(kind, name))
+	if err != nil {
+		log.Error(err, "Failed listing Certificate resources")
+		return
+	}
+
+	for _, cert := range certs {
+		key, err := controllerpkg.KeyFunc(cert)
+		if err != nil {
+			log.Error(err, "Error determining 'key' for resource")
+			continue
+		}
+		queue.Add(key)
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto/x509"
+	"sort"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// mustCreateCA returns a CA certificate for the given common name, signed by
+// the given parent or self signed if parent is nil, along with its PEM
+// encoding and private key.
+func mustCreateCA(t *testing.T, cn string, parent *x509.Certificate, parentKey interface{}) (*x509.Certificate, []byte, interface{}) {
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	template, err := pki.GenerateTemplate(gen.Certificate(cn, gen.SetCertificateCommonName(cn), gen.SetCertificateIsCA(true)))
+	require.NoError(t, err)
+	template.PublicKey = pk.Public()
+
+	if parent == nil {
+		parent, parentKey = template, pk
+	}
+	certPEM, cert, err := pki.SignCertificate(template, parent, pk.Public(), parentKey)
+	require.NoError(t, err)
+	return cert, certPEM, pk
+}
+
+func caSecret(namespace, name string, tlsCrt, caCrt []byte) *corev1.Secret {
+	data := map[string][]byte{corev1.TLSCertKey: tlsCrt}
+	if caCrt != nil {
+		data[cmmeta.TLSCAKey] = caCrt
+	}
+	return gen.Secret(name, gen.SetSecretNamespace(namespace), gen.SetSecretData(data))
+}
+
+func newTestIssuerCAResolver(t *testing.T, objects ...runtime.Object) *IssuerCAResolver {
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	issuerIndexers := cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		issuerCASecretIndex:  issuerCASecretIndexFunc("cert-manager"),
+	}
+	issuers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, issuerIndexers)
+	clusterIssuers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, issuerIndexers)
+	certs := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	secrets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	for _, obj := range objects {
+		var err error
+		switch obj.(type) {
+		case *cmapi.Issuer:
+			err = issuers.Add(obj)
+		case *cmapi.ClusterIssuer:
+			err = clusterIssuers.Add(obj)
+		case *cmapi.Certificate:
+			err = certs.Add(obj)
+		case *corev1.Secret:
+			err = secrets.Add(obj)
+		default:
+			t.Fatalf("unexpected object type %T", obj)
+		}
+		require.NoError(t, err)
+	}
+
+	return &IssuerCAResolver{
+		issuerLister:             cmlisters.NewIssuerLister(issuers),
+		clusterIssuerLister:      cmlisters.NewClusterIssuerLister(clusterIssuers),
+		certificateLister:        cmlisters.NewCertificateLister(certs),
+		secretLister:             corelisters.NewSecretLister(secrets),
+		issuerIndexer:            issuers,
+		clusterIssuerIndexer:     clusterIssuers,
+		clusterResourceNamespace: "cert-manager",
+	}
+}
+
+func TestIssuerCAResolver_IssuerCA(t *testing.T) {
+	root, rootPEM, rootKey := mustCreateCA(t, "root", nil, nil)
+	rotatedRoot, rotatedRootPEM, _ := mustCreateCA(t, "rotated-root", nil, nil)
+	_, intermediatePEM, _ := mustCreateCA(t, "intermediate", root, rootKey)
+
+	caIssuer := func(name string, ca cmapi.CAIssuer) *cmapi.Issuer {
+		return gen.Issuer(name, gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(ca))
+	}
+	certificateFor := func(kind, name string) *cmapi.Certificate {
+		return gen.Certificate("leaf", gen.SetCertificateNamespace("ns"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: name, Kind: kind}))
+	}
+
+	tests := map[string]struct {
+		objects     []runtime.Object
+		certificate *cmapi.Certificate
+		expectedCA  *x509.Certificate
+	}{
+		"returns the CA of a CA Issuer": {
+			objects: []runtime.Object{
+				caIssuer("ca", cmapi.CAIssuer{SecretName: "ca-key-pair"}),
+				caSecret("ns", "ca-key-pair", rootPEM, nil),
+			},
+			certificate: certificateFor(cmapi.IssuerKind, "ca"),
+			expectedCA:  root,
+		},
+		"returns the rotated CA of a CA Issuer": {
+			objects: []runtime.Object{
+				caIssuer("ca", cmapi.CAIssuer{SecretName: "ca-key-pair"}),
+				caSecret("ns", "ca-key-pair", rotatedRootPEM, nil),
+			},
+			certificate: certificateFor(cmapi.IssuerKind, "ca"),
+			expectedCA:  rotatedRoot,
+		},
+		"returns the root of the chain of an intermediate CA": {
+			objects: []runtime.Object{
+				caIssuer("ca", cmapi.CAIssuer{SecretName: "ca-key-pair"}),
+				caSecret("ns", "ca-key-pair", intermediatePEM, rootPEM),
+			},
+			certificate: certificateFor("", "ca"),
+			expectedCA:  root,
+		},
+		"resolves the Secret of the Certificate named by the CA Issuer": {
+			objects: []runtime.Object{
+				caIssuer("ca", cmapi.CAIssuer{CertificateName: "ca-cert"}),
+				gen.Certificate("ca-cert", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("ca-cert-tls")),
+				caSecret("ns", "ca-cert-tls", rotatedRootPEM, nil),
+			},
+			certificate: certificateFor(cmapi.IssuerKind, "ca"),
+			expectedCA:  rotatedRoot,
+		},
+		"reads the Secret of a ClusterIssuer from the cluster resource namespace": {
+			objects: []runtime.Object{
+				gen.ClusterIssuer("ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"})),
+				caSecret("cert-manager", "ca-key-pair", rootPEM, nil),
+				caSecret("ns", "ca-key-pair", rotatedRootPEM, nil),
+			},
+			certificate: certificateFor(cmapi.ClusterIssuerKind, "ca"),
+			expectedCA:  root,
+		},
+		"returns nil for a SelfSigned Issuer": {
+			objects: []runtime.Object{
+				gen.Issuer("selfsigned", gen.SetIssuerNamespace("ns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+			},
+			certificate: certificateFor(cmapi.IssuerKind, "selfsigned"),
+		},
+		"returns nil for an external issuer": {
+			objects: []runtime.Object{
+				caIssuer("ca", cmapi.CAIssuer{SecretName: "ca-key-pair"}),
+				caSecret("ns", "ca-key-pair", rootPEM, nil),
+			},
+			certificate: gen.Certificate("leaf", gen.SetCertificateNamespace("ns"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "example.com"})),
+		},
+		"returns nil if the Issuer does not exist": {
+			certificate: certificateFor(cmapi.IssuerKind, "ca"),
+		},
+		"returns nil if the signing Secret does not exist": {
+			objects: []runtime.Object{
+				caIssuer("ca", cmapi.CAIssuer{SecretName: "ca-key-pair"}),
+			},
+			certificate: certificateFor(cmapi.IssuerKind, "ca"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newTestIssuerCAResolver(t, test.objects...)
+			ca, err := r.IssuerCA(test.certificate)
+			require.NoError(t, err)
+			if test.expectedCA == nil {
+				assert.Nil(t, ca)
+				return
+			}
+			require.NotNil(t, ca)
+			assert.True(t, test.expectedCA.Equal(ca), "unexpected CA %q", ca.Subject.CommonName)
+		})
+	}
+}
+
+func TestIssuerCAResolver_EnqueueCertificatesForIssuerCASecret(t *testing.T) {
+	_, rootPEM, _ := mustCreateCA(t, "root", nil, nil)
+
+	objects := []runtime.Object{
+		gen.Issuer("ca", gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"})),
+		gen.Issuer("other-ca", gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "other-key-pair"})),
+		gen.ClusterIssuer("cluster-ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"})),
+		gen.Issuer("certificate-ca", gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(cmapi.CAIssuer{CertificateName: "ca"})),
+		gen.Certificate("ca", gen.SetCertificateNamespace("ns"), gen.SetCertificateSecretName("issued-ca-key-pair")),
+		gen.Certificate("issued-by-certificate-ca", gen.SetCertificateNamespace("ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "certificate-ca"})),
+		gen.Certificate("issued-by-ca", gen.SetCertificateNamespace("ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"})),
+		gen.Certificate("issued-by-other-ca", gen.SetCertificateNamespace("ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "other-ca", Kind: cmapi.IssuerKind})),
+		gen.Certificate("issued-by-cluster-ca", gen.SetCertificateNamespace("other-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "cluster-ca", Kind: cmapi.ClusterIssuerKind})),
+	}
+
+	tests := map[string]struct {
+		secret       *corev1.Secret
+		expectedKeys []string
+	}{
+		"enqueues the Certificates of the Issuers signing with the Secret": {
+			secret:       caSecret("ns", "ca-key-pair", rootPEM, nil),
+			expectedKeys: []string{"ns/issued-by-ca"},
+		},
+		"enqueues the Certificates of the ClusterIssuers signing with a Secret in the cluster resource namespace": {
+			secret:       caSecret("cert-manager", "ca-key-pair", rootPEM, nil),
+			expectedKeys: []string{"other-ns/issued-by-cluster-ca"},
+		},
+		"enqueues the Certificates of the Issuers signing with the Secret of a Certificate": {
+			secret: gen.SecretFrom(caSecret("ns", "issued-ca-key-pair", rootPEM, nil),
+				gen.SetSecretAnnotations(map[string]string{cmapi.CertificateNameKey: "ca"})),
+			expectedKeys: []string{"ns/issued-by-certificate-ca"},
+		},
+		"enqueues nothing for a Secret which no issuer signs with": {
+			secret: caSecret("ns", "unrelated", rootPEM, nil),
+		},
+		"enqueues nothing for a Secret of a Certificate which is no longer used by the issuer": {
+			secret: gen.SecretFrom(caSecret("ns", "previous-ca-key-pair", rootPEM, nil),
+				gen.SetSecretAnnotations(map[string]string{cmapi.CertificateNameKey: "ca"})),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newTestIssuerCAResolver(t, objects...)
+			queue := workqueue.New()
+			defer queue.ShutDown()
+
+			r.EnqueueCertificatesForIssuerCASecret(logtesting.NewTestLogger(t), queue)(test.secret)
+
+			var gotKeys []string
+			for queue.Len() > 0 {
+				key, _ := queue.Get()
+				gotKeys = append(gotKeys, key.(string))
+				queue.Done(key)
+			}
+			sort.Strings(gotKeys)
+			assert.Equal(t, test.expectedKeys, gotKeys)
+		})
+	}
+}
+
+func TestIssuerCAResolver_EventHandlers(t *testing.T) {
+	_, rootPEM, _ := mustCreateCA(t, "root", nil, nil)
+	_, rotatedRootPEM, _ := mustCreateCA(t, "rotated-root", nil, nil)
+
+	issuer := gen.Issuer("ca", gen.SetIssuerNamespace("ns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+	objects := []runtime.Object{
+		issuer,
+		gen.Certificate("issued-by-ca", gen.SetCertificateNamespace("ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"})),
+	}
+	secret := caSecret("ns", "ca-key-pair", rootPEM, nil)
+
+	tests := map[string]struct {
+		handler      func(r *IssuerCAResolver, queue workqueue.Interface) cache.ResourceEventHandler
+		oldObj       interface{}
+		newObj       interface{}
+		expectedKeys []string
+	}{
+		"enqueues on a change to the certificate data of a CA Secret": {
+			handler: func(r *IssuerCAResolver, queue workqueue.Interface) cache.ResourceEventHandler {
+				return r.IssuerCASecretEventHandler(logtesting.NewTestLogger(t), queue)
+			},
+			oldObj:       secret,
+			newObj:       caSecret("ns", "ca-key-pair", rotatedRootPEM, nil),
+			expectedKeys: []string{"ns/issued-by-ca"},
+		},
+		"does not enqueue on a metadata change to a CA Secret": {
+			handler: func(r *IssuerCAResolver, queue workqueue.Interface) cache.ResourceEventHandler {
+				return r.IssuerCASecretEventHandler(logtesting.NewTestLogger(t), queue)
+			},
+			oldObj: secret,
+			newObj: gen.SecretFrom(secret, gen.SetSecretAnnotations(map[string]string{"foo": "bar"})),
+		},
+		"enqueues on a change to the spec of an issuer": {
+			handler: func(r *IssuerCAResolver, queue workqueue.Interface) cache.ResourceEventHandler {
+				return r.IssuerEventHandler(logtesting.NewTestLogger(t), queue)
+			},
+			oldObj:       issuer,
+			newObj:       gen.IssuerFrom(issuer, gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "rotated-key-pair"})),
+			expectedKeys: []string{"ns/issued-by-ca"},
+		},
+		"enqueues on a change to the default private key of an issuer": {
+			handler: func(r *IssuerCAResolver, queue workqueue.Interface) cache.ResourceEventHandler {
+				return r.IssuerEventHandler(logtesting.NewTestLogger(t), queue)
+			},
+			oldObj: issuer,
+			newObj: gen.IssuerFrom(issuer, func(iss cmapi.GenericIssuer) {
+				iss.GetSpec().DefaultPrivateKey = &cmapi.IssuerPrivateKeyDefaults{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}
+			}),
+			expectedKeys: []string{"ns/issued-by-ca"},
+		},
+		"does not enqueue on a change to the status of an issuer": {
+			handler: func(r *IssuerCAResolver, queue workqueue.Interface) cache.ResourceEventHandler {
+				return r.IssuerEventHandler(logtesting.NewTestLogger(t), queue)
+			},
+			oldObj: issuer,
+			newObj: gen.IssuerFrom(issuer, gen.AddIssuerCondition(*gen.IssuerCondition(cmapi.IssuerConditionReady,
+				gen.SetIssuerConditionStatus(cmmeta.ConditionTrue)))),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newTestIssuerCAResolver(t, objects...)
+			queue := workqueue.New()
+			defer queue.ShutDown()
+
+			test.handler(r, queue).OnUpdate(test.oldObj, test.newObj)
+
+			var gotKeys []string
+			for queue.Len() > 0 {
+				key, _ := queue.Get()
+				gotKeys = append(gotKeys, key.(string))
+				queue.Done(key)
+			}
+			assert.Equal(t, test.expectedKeys, gotKeys)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// defaultNotificationRetryBackoff is the time waited before the first
+	// retry of a failed notification. It is doubled for every subsequent retry.
+	defaultNotificationRetryBackoff = time.Second
+
+	// notificationQueueSize is the maximum number of notifications waiting to
+	// be sent. Notifications enqueued while the queue is full are dropped.
+	notificationQueueSize = 256
+)
+
+// IssuanceNotification is the JSON payload sent to the issuance webhook once
+// a certificate has been issued and written to its Secret.
+type IssuanceNotification struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	SecretName string `json:"secretName"`
+	// Revision is the revision of the Certificate that has been issued.
+	Revision int `json:"revision"`
+	// Renewal is true if the Certificate had been issued before.
+	Renewal bool `json:"renewal"`
+
+	SerialNumber string    `json:"serialNumber"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+	// Fingerprint is the SHA-256 fingerprint of the issued certificate.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// NewIssuanceNotification builds the notification for the given PEM encoded
+// certificate, issued as the given revision of the Certificate.
+func NewIssuanceNotification(crt *cmapi.Certificate, revision int, certPEM []byte) (*IssuanceNotification, error) {
+	cert, err := utilpki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode issued certificate: %w", err)
+	}
+
+	return &IssuanceNotification{
+		Name:         crt.Name,
+		Namespace:    crt.Namespace,
+		SecretName:   crt.Spec.SecretName,
+		Revision:     revision,
+		Renewal:      revision > 1,
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore.UTC(),
+		NotAfter:     cert.NotAfter.UTC(),
+		Fingerprint:  utilpki.SHA256Fingerprint(cert),
+	}, nil
+}
+
+// IssuanceNotifier posts IssuanceNotifications to an external webhook.
+type IssuanceNotifier struct {
+	url        string
+	client     *http.Client
+	maxRetries int
+
+	// retryBackoff is the time waited before the first retry of a failed
+	// notification.
+	retryBackoff time.Duration
+
+	// queue holds the notifications waiting to be sent by Run.
+	queue chan queuedNotification
+}
+
+// queuedNotification is a notification waiting to be sent, along with the
+// function called if it cannot be delivered.
+type queuedNotification struct {
+	notification *IssuanceNotification
+	onFailure    func(error)
+}
+
+// NewIssuanceNotifier returns a notifier which posts to the given URL. Each
+// attempt is limited to the given timeout, and failed attempts are retried
+// up to maxRetries times with an exponential backoff.
+func NewIssuanceNotifier(url string, timeout time.Duration, maxRetries int) *IssuanceNotifier {
+	return &IssuanceNotifier{
+		url:          url,
+		client:       &http.Client{Timeout: timeout},
+		maxRetries:   maxRetries,
+		retryBackoff: defaultNotificationRetryBackoff,
+		queue:        make(chan queuedNotification, notificationQueueSize),
+	}
+}
+
+// Enqueue queues the notification to be sent by Run, so that callers are not
+// blocked while the webhook is retried. onFailure is called with the error if
+// the notification cannot be delivered. Enqueue returns false without queuing
+// the notification if the queue is full.
+func (n *IssuanceNotifier) Enqueue(notification *IssuanceNotification, onFailure func(error)) bool {
+	select {
+	case n.queue <- queuedNotification{notification: notification, onFailure: onFailure}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run sends queued notifications one at a time until the context is
+// cancelled.
+func (n *IssuanceNotifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queued := <-n.queue:
+			if err := n.Notify(ctx, queued.notification); err != nil && queued.onFailure != nil {
+				queued.onFailure(err)
+			}
+		}
+	}
+}
+
+// Notify posts the notification to the webhook. Connection errors, timeouts
+// and responses with a 429 or 5xx status code are retried; other non-2xx
+// responses are returned as errors immediately.
+func (n *IssuanceNotifier) Notify(ctx context.Context, notification *IssuanceNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode issuance notification: %w", err)
+	}
+
+	backoff := n.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := n.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= n.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends a single request to the webhook, returning whether a failure
+// may be retried.
+func (n *IssuanceNotifier) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build issuance notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send issuance notification: %w", err)
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("issuance notification webhook returned status %d", resp.StatusCode)
+}
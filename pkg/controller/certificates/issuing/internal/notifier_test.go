@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// stubReceiver is a webhook which responds with the given status codes in
+// turn and records the notifications it receives.
+type stubReceiver struct {
+	lock          sync.Mutex
+	statusCodes   []int
+	notifications []IssuanceNotification
+}
+
+func (s *stubReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var notification IssuanceNotification
+	if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.notifications = append(s.notifications, notification)
+
+	statusCode := http.StatusOK
+	if len(s.statusCodes) > 0 {
+		statusCode = s.statusCodes[0]
+		s.statusCodes = s.statusCodes[1:]
+	}
+	w.WriteHeader(statusCode)
+}
+
+func TestNewIssuanceNotification(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fakeclock.NewFakeClock(time.Now()))
+
+	notification, err := NewIssuanceNotification(crt, 2, bundle.CertBytes)
+	require.NoError(t, err)
+	assert.Equal(t, &IssuanceNotification{
+		Name:         "test",
+		Namespace:    "testns",
+		SecretName:   "output",
+		Revision:     2,
+		Renewal:      true,
+		SerialNumber: bundle.Cert.SerialNumber.String(),
+		NotBefore:    bundle.Cert.NotBefore.UTC(),
+		NotAfter:     bundle.Cert.NotAfter.UTC(),
+		Fingerprint:  utilpki.SHA256Fingerprint(bundle.Cert),
+	}, notification)
+
+	notification, err = NewIssuanceNotification(crt, 1, bundle.CertBytes)
+	require.NoError(t, err)
+	assert.False(t, notification.Renewal, "expected the first revision to not be a renewal")
+
+	_, err = NewIssuanceNotification(crt, 1, []byte("not a certificate"))
+	assert.Error(t, err)
+}
+
+func TestIssuanceNotifierNotify(t *testing.T) {
+	notification := &IssuanceNotification{
+		Name:        "test",
+		Namespace:   "testns",
+		SecretName:  "output",
+		Revision:    3,
+		Renewal:     true,
+		NotAfter:    time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		Fingerprint: "AB:CD",
+	}
+
+	tests := map[string]struct {
+		statusCodes  []int
+		maxRetries   int
+		expErr       bool
+		expDelivered int
+	}{
+		"a successful notification is sent once": {
+			expDelivered: 1,
+		},
+		"server errors are retried": {
+			statusCodes:  []int{http.StatusInternalServerError, http.StatusTooManyRequests},
+			maxRetries:   2,
+			expDelivered: 3,
+		},
+		"an error is returned once the retries are exhausted": {
+			statusCodes:  []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			maxRetries:   2,
+			expErr:       true,
+			expDelivered: 3,
+		},
+		"client errors are not retried": {
+			statusCodes:  []int{http.StatusBadRequest},
+			maxRetries:   2,
+			expErr:       true,
+			expDelivered: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			receiver := &stubReceiver{statusCodes: test.statusCodes}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			notifier := NewIssuanceNotifier(server.URL, time.Second, test.maxRetries)
+			notifier.retryBackoff = time.Millisecond
+
+			err := notifier.Notify(context.Background(), notification)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.Len(t, receiver.notifications, test.expDelivered)
+			for _, got := range receiver.notifications {
+				assert.Equal(t, *notification, got)
+			}
+		})
+	}
+}
+
+func TestIssuanceNotifierTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	notifier := NewIssuanceNotifier(server.URL, 50*time.Millisecond, 0)
+	err := notifier.Notify(context.Background(), &IssuanceNotification{Name: "test"})
+	assert.Error(t, err, "expected a webhook which does not respond to time out")
+}
+
+func TestIssuanceNotifierRun(t *testing.T) {
+	receiver := &stubReceiver{statusCodes: []int{http.StatusOK, http.StatusBadRequest}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	notifier := NewIssuanceNotifier(server.URL, time.Second, 0)
+
+	failures := make(chan error, 2)
+	onFailure := func(err error) { failures <- err }
+	require.True(t, notifier.Enqueue(&IssuanceNotification{Name: "delivered"}, onFailure))
+	require.True(t, notifier.Enqueue(&IssuanceNotification{Name: "rejected"}, onFailure))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go notifier.Run(ctx)
+
+	select {
+	case err := <-failures:
+		assert.EqualError(t, err, "issuance notification webhook returned status 400")
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the rejected notification to be reported as failed")
+	}
+
+	receiver.lock.Lock()
+	defer receiver.lock.Unlock()
+	require.Len(t, receiver.notifications, 2)
+	assert.Equal(t, "delivered", receiver.notifications[0].Name)
+	assert.Equal(t, "rejected", receiver.notifications[1].Name)
+	assert.Empty(t, failures)
+}
+
+func TestIssuanceNotifierEnqueueFull(t *testing.T) {
+	notifier := NewIssuanceNotifier("http://localhost", time.Second, 0)
+	for i := 0; i < notificationQueueSize; i++ {
+		require.True(t, notifier.Enqueue(&IssuanceNotification{Name: "test"}, nil))
+	}
+	assert.False(t, notifier.Enqueue(&IssuanceNotification{Name: "test"}, nil), "expected a full queue to drop the notification")
+}
@@ -19,6 +19,7 @@ package internal
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,7 +33,6 @@ import (
 	"github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -42,6 +42,11 @@ var (
 	certificateGvk = cmapi.SchemeGroupVersion.WithKind("Certificate")
 )
 
+// ErrSecretNotOwned is returned by UpdateData if the Certificate's Secret
+// already holds data which was not written by cert-manager, and the
+// Certificate does not allow the Secret to be adopted.
+var ErrSecretNotOwned = errors.New("secret is not managed by cert-manager")
+
 // SecretsManager creates and updates secrets with certificate and key data.
 type SecretsManager struct {
 	secretClient coreclient.SecretsGetter
@@ -53,8 +58,13 @@ type SecretsManager struct {
 	// if true, Secret resources created by the controller will have an
 	// 'owner reference' set, meaning when the Certificate is deleted, the
 	// Secret resource will be automatically deleted.
-	// This option is disabled by default.
+	// This option is disabled by default, and can be overridden per
+	// Certificate using the SecretOwnerReferenceAnnotation.
 	enableSecretOwnerReferences bool
+
+	// if true, UpdateData will not apply a Secret whose content would not be
+	// changed by the Apply call.
+	skipUnchangedWrites bool
 }
 
 // SecretData is a structure wrapping private key, Certificate and CA data
@@ -64,24 +74,32 @@ type SecretData struct {
 
 // NewSecretsManager returns a new SecretsManager. Setting
 // enableSecretOwnerReferences to true will mean that secrets will be deleted
-// when the corresponding Certificate is deleted.
+// when the corresponding Certificate is deleted. Setting skipUnchangedWrites
+// to true will mean that secrets are not written if their content is
+// unchanged.
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
 	secretLister corelisters.SecretLister,
 	fieldManager string,
 	enableSecretOwnerReferences bool,
+	skipUnchangedWrites bool,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
 		secretLister:                secretLister,
 		fieldManager:                fieldManager,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
+		skipUnchangedWrites:         skipUnchangedWrites,
 	}
 }
 
 // UpdateData will ensure the Secret resource contains the given secret data as
 // well as appropriate metadata using an Apply call.
 // If the Secret resource does not exist, it will be created on Apply.
+// If the Secret resource exists with data that was not written by
+// cert-manager, an error wrapping ErrSecretNotOwned is returned rather than
+// overwriting the data, unless the Certificate allows the Secret to be
+// adopted.
 // UpdateData will also update deprecated annotations if they exist.
 func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate, data SecretData) error {
 	secret, err := s.getCertificateSecret(ctx, crt)
@@ -105,8 +123,10 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	// If Secret owner reference is enabled, set it on the Secret. This results
 	// in a no-op if the Secret already exists and has the owner reference set,
 	// and visa-versa.
-	if s.enableSecretOwnerReferences {
+	var ownerRefs []metav1.OwnerReference
+	if certificates.SecretOwnerReferenceEnabled(crt, s.enableSecretOwnerReferences) {
 		ref := *metav1.NewControllerRef(crt, certificateGvk)
+		ownerRefs = append(ownerRefs, ref)
 		applyCnf = applyCnf.WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
 			APIVersion: &ref.APIVersion, Kind: &ref.Kind,
 			Name: &ref.Name, UID: &ref.UID,
@@ -114,6 +134,24 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 		})
 	}
 
+	if s.skipUnchangedWrites {
+		desired := secretContent{
+			Annotations:     secret.Annotations,
+			Labels:          secret.Labels,
+			Data:            secret.Data,
+			Type:            secret.Type,
+			OwnerReferences: ownerRefs,
+		}
+		unchanged, err := s.secretContentUnchanged(secret.Namespace, secret.Name, desired)
+		if err != nil {
+			return err
+		}
+		if unchanged {
+			log.V(logf.DebugLevel).Info("secret content is unchanged, skipping apply")
+			return nil
+		}
+	}
+
 	log.V(logf.DebugLevel).Info("applying secret")
 
 	_, err = s.secretClient.Secrets(secret.Namespace).Apply(ctx, applyCnf, applyOpts)
@@ -124,6 +162,36 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	return nil
 }
 
+// secretContentUnchanged returns true if the content of the existing Secret
+// which is owned by the field manager has the same hash as the desired
+// content, in which case applying the desired content would be a no-op.
+// Keystores are encoded with a random salt, so Secrets containing them are
+// never considered unchanged.
+func (s *SecretsManager) secretContentUnchanged(namespace, name string, desired secretContent) (bool, error) {
+	existing, err := s.secretLister.Secrets(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	existingContent, err := managedSecretContent(existing, s.fieldManager)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode managed fields on Secret %s/%s: %w", namespace, name, err)
+	}
+
+	desiredHash, err := desired.hash()
+	if err != nil {
+		return false, err
+	}
+	existingHash, err := existingContent.hash()
+	if err != nil {
+		return false, err
+	}
+	return desiredHash == existingHash, nil
+}
+
 // setValues will update the Secret resource 'secret' with the data contained
 // in the given secretData.
 // It will update labels and annotations on the Secret resource appropriately.
@@ -144,10 +212,11 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		}
 	}
 
-	secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
-	secret.Data[corev1.TLSCertKey] = data.Certificate
+	keys := certificates.SecretKeysForCertificate(crt)
+	secret.Data[keys.PrivateKey] = data.PrivateKey
+	secret.Data[keys.Certificate] = data.Certificate
 	if len(data.CA) > 0 {
-		secret.Data[cmmeta.TLSCAKey] = data.CA
+		secret.Data[keys.CA] = data.CA
 	}
 
 	var certificate *x509.Certificate
@@ -179,6 +248,8 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 
 // getCertificateSecret will return a secret which is ready for fields to be
 // applied. Only the Secret Type will be persisted from the original Secret.
+// An error wrapping ErrSecretNotOwned is returned if the original Secret is
+// foreign and may not be adopted.
 func (s *SecretsManager) getCertificateSecret(ctx context.Context, crt *cmapi.Certificate) (*corev1.Secret, error) {
 	// Get existing secret if it exists.
 	existingSecret, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
@@ -192,7 +263,7 @@ func (s *SecretsManager) getCertificateSecret(ctx context.Context, crt *cmapi.Ce
 				Namespace: crt.Namespace,
 			},
 			Data: make(map[string][]byte),
-			Type: corev1.SecretTypeTLS,
+			Type: certificates.SecretTypeForCertificate(crt),
 		}, nil
 	}
 
@@ -201,6 +272,10 @@ func (s *SecretsManager) getCertificateSecret(ctx context.Context, crt *cmapi.Ce
 		return nil, err
 	}
 
+	if certificates.SecretIsForeign(crt, existingSecret, s.fieldManager) && !certificates.SecretAdoptionAllowed(crt) {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotOwned, certificates.SecretNotOwnedMessage(existingSecret.Name))
+	}
+
 	// Only copy Secret Type to not take ownership of annotations or labels on
 	// Apply.
 	return &corev1.Secret{
@@ -289,6 +364,13 @@ func setAdditionalOutputFormats(crt *cmapi.Certificate, secret *corev1.Secret, d
 		case cmapi.CertificateOutputFormatCombinedPEM:
 			// Combine tls.key and tls.crt
 			secret.Data[cmapi.CertificateOutputFormatCombinedPEMKey] = certificates.OutputFormatCombinedPEM(data.PrivateKey, data.Certificate)
+		case cmapi.CertificateOutputFormatAzureKeyVaultPEM:
+			// Certificate chain followed by the PKCS#8 private key
+			bundle, err := certificates.OutputFormatAzureKeyVaultPEM(data.PrivateKey, data.Certificate)
+			if err != nil {
+				return fmt.Errorf("error encoding Azure Key Vault PEM bundle: %w", err)
+			}
+			secret.Data[certificates.OutputFormatSecretKey(format)] = bundle
 		default:
 			return fmt.Errorf("unknown additional output format %s", format.Type)
 		}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// secretContent is the part of a Secret which is written by the
+// SecretsManager.
+type secretContent struct {
+	Annotations     map[string]string       `json:"annotations,omitempty"`
+	Labels          map[string]string       `json:"labels,omitempty"`
+	Data            map[string][]byte       `json:"data,omitempty"`
+	Type            corev1.SecretType       `json:"type,omitempty"`
+	OwnerReferences []metav1.OwnerReference `json:"ownerReferences,omitempty"`
+}
+
+// hash returns a SHA-256 digest of the content. Map keys are sorted when
+// encoded, so equal content always has the same hash.
+func (c secretContent) hash() (string, error) {
+	encoded, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// managedSecretContent returns the content of the Secret which is owned by
+// the given field manager, i.e. what an Apply by that field manager last set.
+// Fields which were since changed by another manager are not owned by the
+// field manager any more and so are not part of the content.
+func managedSecretContent(secret *corev1.Secret, fieldManager string) (secretContent, error) {
+	content := secretContent{Type: secret.Type}
+	for _, managedField := range secret.ManagedFields {
+		if managedField.Manager != fieldManager || managedField.FieldsV1 == nil {
+			continue
+		}
+
+		var fieldset fieldpath.Set
+		if err := fieldset.FromJSON(bytes.NewReader(managedField.FieldsV1.Raw)); err != nil {
+			return secretContent{}, err
+		}
+
+		metadata := fieldset.Children.Descend(fieldpath.PathElement{FieldName: pointer.String("metadata")})
+		for _, key := range fieldNames(metadata.Children.Descend(fieldpath.PathElement{FieldName: pointer.String("annotations")})) {
+			if v, ok := secret.Annotations[key]; ok {
+				if content.Annotations == nil {
+					content.Annotations = make(map[string]string)
+				}
+				content.Annotations[key] = v
+			}
+		}
+		for _, key := range fieldNames(metadata.Children.Descend(fieldpath.PathElement{FieldName: pointer.String("labels")})) {
+			if v, ok := secret.Labels[key]; ok {
+				if content.Labels == nil {
+					content.Labels = make(map[string]string)
+				}
+				content.Labels[key] = v
+			}
+		}
+		for _, key := range fieldNames(fieldset.Children.Descend(fieldpath.PathElement{FieldName: pointer.String("data")})) {
+			if v, ok := secret.Data[key]; ok {
+				if content.Data == nil {
+					content.Data = make(map[string][]byte)
+				}
+				content.Data[key] = v
+			}
+		}
+		for _, ref := range secret.OwnerReferences {
+			if fieldset.Has(fieldpath.Path{
+				{FieldName: pointer.String("metadata")},
+				{FieldName: pointer.String("ownerReferences")},
+				{Key: &value.FieldList{{Name: "uid", Value: value.NewValueInterface(string(ref.UID))}}},
+			}) {
+				content.OwnerReferences = append(content.OwnerReferences, ref)
+			}
+		}
+	}
+	return content, nil
+}
+
+// fieldNames returns the names of the fields which are direct members of the
+// given set.
+func fieldNames(set *fieldpath.Set) []string {
+	var names []string
+	set.Iterate(func(path fieldpath.Path) {
+		if len(path) == 1 && path[0].FieldName != nil {
+			names = append(names, *path[0].FieldName)
+		}
+	})
+	return names
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testcoreclients "github.com/cert-manager/cert-manager/test/unit/coreclients"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testcorelisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+// appliedSecret returns the Secret which results from applying the given
+// configuration as the given field manager, including its managed fields.
+func appliedSecret(t *testing.T, cnf *applycorev1.SecretApplyConfiguration, fieldManager string) *corev1.Secret {
+	encoded, err := json.Marshal(cnf)
+	require.NoError(t, err)
+	var secret corev1.Secret
+	require.NoError(t, json.Unmarshal(encoded, &secret))
+
+	metadata := fieldpath.PathElement{FieldName: pointer.String("metadata")}
+	var paths []fieldpath.Path
+	for key := range secret.Annotations {
+		paths = append(paths, fieldpath.MakePathOrDie("metadata", "annotations", key))
+	}
+	for key := range secret.Labels {
+		paths = append(paths, fieldpath.MakePathOrDie("metadata", "labels", key))
+	}
+	for key := range secret.Data {
+		paths = append(paths, fieldpath.MakePathOrDie("data", key))
+	}
+	for _, ref := range secret.OwnerReferences {
+		paths = append(paths, fieldpath.Path{
+			metadata,
+			{FieldName: pointer.String("ownerReferences")},
+			{Key: &value.FieldList{{Name: "uid", Value: value.NewValueInterface(string(ref.UID))}}},
+		})
+	}
+	fields, err := fieldpath.NewSet(paths...).ToJSON()
+	require.NoError(t, err)
+
+	secret.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "another-manager", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:other":{}}}`)}},
+		{Manager: fieldManager, Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: fields}},
+	}
+	return &secret
+}
+
+func Test_SecretsManagerSkipUnchangedWrites(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("test-namespace"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateUID(apitypes.UID("test-uid")),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	data := SecretData{Certificate: bundle.CertBytes, CA: []byte("test-ca"), PrivateKey: bundle.PrivateKeyBytes}
+
+	// Apply the Secret once, to find out what cert-manager would write.
+	var applied *applycorev1.SecretApplyConfiguration
+	secretClient := testcoreclients.NewFakeSecretsGetter(testcoreclients.SetFakeSecretsGetterApplyFn(
+		func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+			applied = cnf
+			return nil, nil
+		},
+	))
+	secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(nil, apierrors.NewNotFound(corev1.Resource("secret"), "not found")))
+	require.NoError(t, NewSecretsManager(secretClient, secretLister, "cert-manager-test", true, true).UpdateData(context.Background(), crt, data))
+	require.NotNil(t, applied, "expected the Secret to be applied if it does not exist")
+	existing := appliedSecret(t, applied, "cert-manager-test")
+	// Content written by other managers must not affect the comparison.
+	existing.Data["other"] = []byte("other")
+
+	tests := map[string]struct {
+		existing            *corev1.Secret
+		data                SecretData
+		skipUnchangedWrites bool
+		expApply            bool
+	}{
+		"if the content is unchanged, the Secret should not be written": {
+			existing:            existing,
+			data:                data,
+			skipUnchangedWrites: true,
+			expApply:            false,
+		},
+		"if the content is unchanged but skipping writes is disabled, the Secret should be written": {
+			existing:            existing,
+			data:                data,
+			skipUnchangedWrites: false,
+			expApply:            true,
+		},
+		"if the data has changed, the Secret should be written": {
+			existing:            existing,
+			data:                SecretData{Certificate: bundle.CertBytes, CA: []byte("new-ca"), PrivateKey: bundle.PrivateKeyBytes},
+			skipUnchangedWrites: true,
+			expApply:            true,
+		},
+		"if a managed annotation was changed by another manager, the Secret should be written": {
+			existing: func() *corev1.Secret {
+				secret := existing.DeepCopy()
+				secret.Annotations[cmapi.CommonNameAnnotationKey] = "changed"
+				return secret
+			}(),
+			data:                data,
+			skipUnchangedWrites: true,
+			expApply:            true,
+		},
+		"if the Secret was not written by the field manager, the Secret should be written": {
+			existing: func() *corev1.Secret {
+				secret := existing.DeepCopy()
+				secret.ManagedFields = secret.ManagedFields[:1]
+				return secret
+			}(),
+			data:                data,
+			skipUnchangedWrites: true,
+			expApply:            true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var applyCalled bool
+			secretClient := testcoreclients.NewFakeSecretsGetter(testcoreclients.SetFakeSecretsGetterApplyFn(
+				func(context.Context, *applycorev1.SecretApplyConfiguration, metav1.ApplyOptions) (*corev1.Secret, error) {
+					applyCalled = true
+					return nil, nil
+				},
+			))
+			secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(test.existing, nil))
+
+			testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", true, test.skipUnchangedWrites)
+			require.NoError(t, testManager.UpdateData(context.Background(), crt, test.data))
+			assert.Equal(t, test.expApply, applyCalled)
+		})
+	}
+}
+
+func Test_managedSecretContent(t *testing.T) {
+	fields := []byte(`{"f:data":{"f:tls.crt":{},"f:missing":{}},"f:metadata":{"f:annotations":{"f:owned":{}},"f:labels":{"f:owned":{}},"f:ownerReferences":{"k:{\"uid\":\"owned-uid\"}":{}}}}`)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"owned": "a", "other": "b"},
+			Labels:      map[string]string{"owned": "c", "other": "d"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Name: "owned", UID: "owned-uid"},
+				{Name: "other", UID: "other-uid"},
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "cert-manager-test", FieldsV1: &metav1.FieldsV1{Raw: fields}},
+			},
+		},
+		Data: map[string][]byte{"tls.crt": []byte("crt"), "other": []byte("other")},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	content, err := managedSecretContent(secret, "cert-manager-test")
+	require.NoError(t, err)
+	assert.Equal(t, secretContent{
+		Annotations:     map[string]string{"owned": "a"},
+		Labels:          map[string]string{"owned": "c"},
+		Data:            map[string][]byte{"tls.crt": []byte("crt")},
+		Type:            corev1.SecretTypeTLS,
+		OwnerReferences: []metav1.OwnerReference{{Name: "owned", UID: "owned-uid"}},
+	}, content)
+
+	content, err = managedSecretContent(secret, "another-manager")
+	require.NoError(t, err)
+	assert.Equal(t, secretContent{Type: corev1.SecretTypeTLS}, content)
+
+	secret.ManagedFields[0].FieldsV1.Raw = []byte("not json")
+	_, err = managedSecretContent(secret, "cert-manager-test")
+	assert.Error(t, err)
+}
+
+func Test_secretContentHash(t *testing.T) {
+	content := secretContent{
+		Annotations: map[string]string{"a": "1", "b": "2"},
+		Data:        map[string][]byte{"tls.crt": []byte("crt"), "tls.key": []byte("key")},
+		Type:        corev1.SecretTypeTLS,
+	}
+	hash, err := content.hash()
+	require.NoError(t, err)
+
+	// Reordering map keys, or using empty rather than nil maps, must not
+	// change the hash.
+	equal := secretContent{
+		Annotations: map[string]string{"b": "2", "a": "1"},
+		Labels:      map[string]string{},
+		Data:        map[string][]byte{"tls.key": []byte("key"), "tls.crt": []byte("crt")},
+		Type:        corev1.SecretTypeTLS,
+	}
+	equalHash, err := equal.hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, equalHash)
+
+	content.Data["tls.crt"] = []byte("other")
+	changedHash, err := content.hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changedHash)
+}
@@ -93,8 +93,19 @@ func Test_SecretsManager(t *testing.T) {
 			cmapi.CertificateAdditionalOutputFormat{Type: "CombinedPEM"},
 		),
 	)
+	baseCertWithAdditionalOutputFormatAzureKeyVaultPEM := gen.CertificateFrom(baseCertBundle.Certificate,
+		gen.SetCertificateAdditionalOutputFormats(cmapi.CertificateAdditionalOutputFormat{Type: "AzureKeyVaultPEM", Key: "keyvault.pem"}),
+	)
+	baseCertWithOpaqueSecretKeys := gen.CertificateFrom(baseCertBundle.Certificate,
+		gen.SetCertificateSecretType(cmapi.CertificateSecretTypeOpaque),
+		gen.SetCertificateSecretKeys(cmapi.CertificateSecretKeys{Certificate: "cert.pem", PrivateKey: "key.pem", CA: "chain.pem"}),
+	)
 	block, _ := pem.Decode(baseCertBundle.PrivateKeyBytes)
 	tlsDerContent := block.Bytes
+	pkcs8Key, err := utilpki.EncodePKCS8PrivateKey(baseCertBundle.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := map[string]struct {
 		certificateOptions controllerpkg.CertificateOptions
@@ -191,6 +202,43 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does not exist, create new Secret without owner, if owner enabled but disabled by annotation": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
+			certificate: gen.CertificateFrom(baseCertBundle.Certificate,
+				gen.SetCertificateAnnotations(map[string]string{cmapi.SecretOwnerReferenceAnnotation: "false"}),
+			),
+			existingSecret: nil,
+			secretData:     SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Empty(t, gotCnf.OwnerReferences)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
+		"if secret does not exist, create new Secret with owner, if owner disabled but enabled by annotation": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate: gen.CertificateFrom(baseCertBundle.Certificate,
+				gen.SetCertificateAnnotations(map[string]string{cmapi.SecretOwnerReferenceAnnotation: "true"}),
+			),
+			existingSecret: nil,
+			secretData:     SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expUID := apitypes.UID("test-uid")
+					assert.Equal(t, []applymetav1.OwnerReferenceApplyConfiguration{{
+						APIVersion: pointer.String("cert-manager.io/v1"), Kind: pointer.String("Certificate"),
+						Name: pointer.String("test"), UID: &expUID,
+						Controller: pointer.Bool(true), BlockOwnerDeletion: pointer.Bool(true),
+					}}, gotCnf.OwnerReferences)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does exist, update existing Secret and leave custom annotations, with owner disabled": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertBundle.Certificate,
@@ -198,7 +246,7 @@ func Test_SecretsManager(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   gen.DefaultTestNamespace,
 					Name:        "output",
-					Annotations: map[string]string{"my-custom": "annotation"},
+					Annotations: map[string]string{"my-custom": "annotation", cmapi.CertificateNameKey: "test"},
 					Labels:      map[string]string{},
 				},
 				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
@@ -242,7 +290,7 @@ func Test_SecretsManager(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   gen.DefaultTestNamespace,
 					Name:        "output",
-					Annotations: map[string]string{"my-custom": "annotation"},
+					Annotations: map[string]string{"my-custom": "annotation", cmapi.CertificateNameKey: "test"},
 					Labels:      map[string]string{},
 				},
 				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
@@ -286,6 +334,40 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does exist with labels, data and owner references added by other controllers, leave them out of the apply configuration": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   gen.DefaultTestNamespace,
+					Name:        "output",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "test"},
+					Labels:      map[string]string{"external.io/owner": "tool"},
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"},
+					},
+				},
+				Data: map[string][]byte{"extra": []byte("keep"), corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo")},
+				Type: corev1.SecretTypeTLS,
+			},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					// Fields which are not part of the apply configuration
+					// are not claimed by cert-manager, so are left in place
+					// by the server-side apply.
+					assert.NotContains(t, gotCnf.Labels, "external.io/owner")
+					assert.Empty(t, gotCnf.OwnerReferences)
+					assert.Equal(t, map[string][]byte{
+						corev1.TLSCertKey:       baseCertBundle.CertBytes,
+						corev1.TLSPrivateKeyKey: []byte("test-key"),
+						cmmeta.TLSCAKey:         []byte("test-ca"),
+					}, gotCnf.Data)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
 		"if secret does not exist, create new Secret using the secret template": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertWithSecretTemplate,
@@ -293,7 +375,7 @@ func Test_SecretsManager(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   gen.DefaultTestNamespace,
 					Name:        "output",
-					Annotations: map[string]string{"my-custom": "annotation"},
+					Annotations: map[string]string{"my-custom": "annotation", cmapi.CertificateNameKey: "test"},
 					Labels:      map[string]string{},
 				},
 				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
@@ -451,6 +533,43 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does not exist, create new Secret with additional output format AzureKeyVaultPEM": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertWithAdditionalOutputFormatAzureKeyVaultPEM,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: baseCertBundle.PrivateKeyBytes},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+							}).
+						WithLabels(make(map[string]string)).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:       baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey: baseCertBundle.PrivateKeyBytes,
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+							"keyvault.pem":          []byte(strings.Join([]string{string(baseCertBundle.CertBytes), string(pkcs8Key)}, "\n")),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					assert.Equal(t, expOpts, gotOpts)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does not exist, create new Secret with additional output format DER and CombinedPEM": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertWithAdditionalOutputFormats,
@@ -498,7 +617,8 @@ func Test_SecretsManager(t *testing.T) {
 					Namespace: gen.DefaultTestNamespace,
 					Name:      "output",
 					Annotations: map[string]string{
-						"my-custom": "annotation",
+						"my-custom":              "annotation",
+						cmapi.CertificateNameKey: "test",
 					},
 				},
 				Data: map[string][]byte{
@@ -551,7 +671,8 @@ func Test_SecretsManager(t *testing.T) {
 					Namespace: gen.DefaultTestNamespace,
 					Name:      "output",
 					Annotations: map[string]string{
-						"my-custom": "annotation",
+						"my-custom":              "annotation",
+						cmapi.CertificateNameKey: "test",
 					},
 				},
 				Data: map[string][]byte{
@@ -605,7 +726,8 @@ func Test_SecretsManager(t *testing.T) {
 					Namespace: gen.DefaultTestNamespace,
 					Name:      "output",
 					Annotations: map[string]string{
-						"my-custom": "annotation",
+						"my-custom":              "annotation",
+						cmapi.CertificateNameKey: "test",
 					},
 				},
 				Data: map[string][]byte{
@@ -649,6 +771,104 @@ func Test_SecretsManager(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if secret does not exist, create new Opaque Secret with the configured keys": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertWithOpaqueSecretKeys,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+							}).
+						WithLabels(make(map[string]string)).
+						WithData(map[string][]byte{
+							"cert.pem":  baseCertBundle.CertBytes,
+							"key.pem":   []byte("test-key"),
+							"chain.pem": []byte("test-ca"),
+						}).
+						WithType(corev1.SecretTypeOpaque)
+					assert.Equal(t, expCnf, gotCnf)
+
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					assert.Equal(t, expOpts, gotOpts)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if secret exists with the kubernetes.io/tls type, keep its type when Opaque is requested": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        gen.CertificateFrom(baseCertBundle.Certificate, gen.SetCertificateSecretType(cmapi.CertificateSecretTypeOpaque)),
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "output"},
+				Type:       corev1.SecretTypeTLS,
+			},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, corev1.SecretTypeTLS, *gotCnf.Type)
+					assert.Equal(t, map[string][]byte{
+						corev1.TLSCertKey:       baseCertBundle.CertBytes,
+						corev1.TLSPrivateKeyKey: []byte("test-key"),
+						cmmeta.TLSCAKey:         []byte("test-ca"),
+					}, gotCnf.Data)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if secret exists with data not written by cert-manager, refuse to overwrite it": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "output"},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+				Type:       corev1.SecretTypeOpaque,
+			},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(context.Context, *applycorev1.SecretApplyConfiguration, metav1.ApplyOptions) (*corev1.Secret, error) {
+					t.Error("unexpected apply call")
+					return nil, nil
+				}
+			},
+			expectedErr: true,
+		},
+		"if secret exists with data not written by cert-manager and the Certificate adopts it, overwrite it": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate: gen.CertificateFrom(baseCertBundle.Certificate,
+				gen.SetCertificateAnnotations(map[string]string{cmapi.AdoptSecretAnnotation: "true"}),
+			),
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "output"},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+				Type:       corev1.SecretTypeOpaque,
+			},
+			secretData: SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					assert.Equal(t, corev1.SecretTypeOpaque, *gotCnf.Type)
+					assert.Equal(t, "test", gotCnf.Annotations[cmapi.CertificateNameKey])
+					assert.Equal(t, map[string][]byte{
+						corev1.TLSCertKey:       baseCertBundle.CertBytes,
+						corev1.TLSPrivateKeyKey: []byte("test-key"),
+						cmmeta.TLSCAKey:         []byte("test-ca"),
+					}, gotCnf.Data)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
 		"if apply errors, expect error response": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertWithSecretTemplate,
@@ -681,6 +901,7 @@ func Test_SecretsManager(t *testing.T) {
 				secretClient, secretLister,
 				"cert-manager-test",
 				test.certificateOptions.EnableOwnerRef,
+				test.certificateOptions.SkipUnchangedSecretWrites,
 			)
 
 			err := testManager.UpdateData(context.Background(), test.certificate, test.secretData)
@@ -703,6 +924,7 @@ func Test_getCertificateSecret(t *testing.T) {
 	tests := map[string]struct {
 		existingSecret *corev1.Secret
 		expSecret      *corev1.Secret
+		expErr         error
 	}{
 		"if secret doesn't exist, expect empty secret": {
 			existingSecret: nil,
@@ -716,7 +938,7 @@ func Test_getCertificateSecret(t *testing.T) {
 			existingSecret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test-namespace", Name: "test-secret",
-					Annotations: map[string]string{"foo": "bar"}, Labels: map[string]string{"abc": "123"},
+					Annotations: map[string]string{"foo": "bar", cmapi.CertificateNameKey: "test-certificate"}, Labels: map[string]string{"abc": "123"},
 				},
 				Data: map[string][]byte{"abc": []byte("123"), "hello-world": []byte("bar"), "tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca")},
 				Type: corev1.SecretTypeTLS,
@@ -733,7 +955,7 @@ func Test_getCertificateSecret(t *testing.T) {
 			existingSecret: &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test-namespace", Name: "test-secret",
-					Annotations: map[string]string{"foo": "bar"}, Labels: map[string]string{"abc": "123"},
+					Annotations: map[string]string{"foo": "bar", cmapi.CertificateNameKey: "test-certificate"}, Labels: map[string]string{"abc": "123"},
 				},
 				Data: map[string][]byte{"abc": []byte("123"), "hello-world": []byte("bar"), "tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca")},
 				Type: corev1.SecretTypeOpaque,
@@ -746,6 +968,33 @@ func Test_getCertificateSecret(t *testing.T) {
 				Type: corev1.SecretTypeOpaque,
 			},
 		},
+		"if secret exists with data not written by cert-manager, expect an error": {
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Data: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+				Type: corev1.SecretTypeTLS,
+			},
+			expErr: ErrSecretNotOwned,
+		},
+		"if secret exists without data, expect it to be used": {
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-namespace", Name: "test-secret",
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Type: corev1.SecretTypeOpaque,
+			},
+			expSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-namespace", Name: "test-secret",
+				},
+				Data: make(map[string][]byte),
+				Type: corev1.SecretTypeOpaque,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -771,7 +1020,11 @@ func Test_getCertificateSecret(t *testing.T) {
 			defer builder.Stop()
 
 			gotSecret, err := s.getCertificateSecret(context.Background(), crt)
-			assert.NoError(t, err)
+			if test.expErr != nil {
+				assert.ErrorIs(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
 
 			assert.Equal(t, test.expSecret, gotSecret, "unexpected returned secret")
 		})
@@ -19,6 +19,7 @@ package issuing
 import (
 	"context"
 	"crypto"
+	"errors"
 	"fmt"
 	"time"
 
@@ -47,6 +48,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revisionmanager"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -56,6 +58,16 @@ import (
 
 const (
 	ControllerName = "certificates-issuing"
+
+	reasonAdditionalCAFailed = "AdditionalCAFailed"
+
+	reasonMaxIssuanceAttemptsExceeded = "MaxIssuanceAttemptsExceeded"
+
+	reasonIssuerFailover = "IssuerFailover"
+
+	reasonIssuanceNotificationFailed = "IssuanceNotificationFailed"
+
+	reasonSecretNotOwned = internalcertificates.ReasonSecretNotOwned
 )
 
 type localTemporarySignerFn func(crt *cmapi.Certificate, pk []byte) ([]byte, error)
@@ -67,6 +79,7 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             corelisters.SecretLister
+	configMapLister          corelisters.ConfigMapLister
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
 
@@ -88,6 +101,21 @@ type controller struct {
 
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
+
+	// disableTemporaryCertificates prevents temporary certificates from being
+	// issued, even if the Certificate requests one.
+	disableTemporaryCertificates bool
+
+	// maxIssuanceAttempts is the number of consecutive failed issuances after
+	// which the Certificate is marked as Failed. Zero means no limit.
+	maxIssuanceAttempts int
+
+	// issuanceNotifier, if set, is notified of every successful issuance.
+	issuanceNotifier *internal.IssuanceNotifier
+
+	// privateKeyDefaulter applies the default private key parameters of the
+	// Certificate's issuer.
+	privateKeyDefaulter *certificates.PrivateKeyDefaulter
 }
 
 func NewController(
@@ -96,6 +124,7 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	namespace string,
 	recorder record.EventRecorder,
 	clock clock.Clock,
 	certificateControllerOptions controllerpkg.CertificateOptions,
@@ -109,6 +138,7 @@ func NewController(
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := factory.Core().V1().Secrets()
+	configMapsInformer := certificates.DNSNamesConfigMapsInformer(factory, namespace)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
@@ -131,18 +161,30 @@ func NewController(
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
+		configMapsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
 	secretsManager := internal.NewSecretsManager(
 		kubeClient.CoreV1(), secretsInformer.Lister(),
 		fieldManager, certificateControllerOptions.EnableOwnerRef,
+		certificateControllerOptions.SkipUnchangedSecretWrites,
 	)
 
+	var issuanceNotifier *internal.IssuanceNotifier
+	if certificateControllerOptions.IssuedWebhookURL != "" {
+		issuanceNotifier = internal.NewIssuanceNotifier(
+			certificateControllerOptions.IssuedWebhookURL,
+			certificateControllerOptions.IssuedWebhookTimeout,
+			certificateControllerOptions.IssuedWebhookMaxRetries,
+		)
+	}
+
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
+		configMapLister:          configMapsInformer.Lister(),
 		client:                   client,
 		recorder:                 recorder,
 		clock:                    clock,
@@ -151,8 +193,11 @@ func NewController(
 			certificateControllerOptions.EnableOwnerRef,
 			fieldManager,
 		),
-		fieldManager:         fieldManager,
-		localTemporarySigner: certificates.GenerateLocallySignedTemporaryCertificate,
+		fieldManager:                 fieldManager,
+		localTemporarySigner:         certificates.GenerateLocallySignedTemporaryCertificate,
+		disableTemporaryCertificates: certificateControllerOptions.DisableTemporaryCertificates,
+		maxIssuanceAttempts:          certificateControllerOptions.MaxIssuanceAttempts,
+		issuanceNotifier:             issuanceNotifier,
 	}, queue, mustSync
 }
 
@@ -176,6 +221,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	crt = c.privateKeyDefaulter.WithDefaults(crt)
 
 	log = logf.WithResource(log, crt)
 	ctx = logf.NewContext(ctx, log)
@@ -190,39 +236,57 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.ensureSecretData(ctx, log, crt)
 	}
 
-	if crt.Status.NextPrivateKeySecretName == nil ||
-		len(*crt.Status.NextPrivateKeySecretName) == 0 {
-		// Do nothing if the next private key secret name is not set
-		return nil
-	}
+	// The public key that the CertificateRequest's CSR must contain. This is
+	// either the public key of the next private key, or when the private key
+	// is not managed by cert-manager, that of the user supplied CSR.
+	var (
+		pk        crypto.Signer
+		publicKey crypto.PublicKey
+	)
+	if certificates.PrivateKeyIsUnmanaged(crt) {
+		_, csr, err := certificates.FetchCSRForCertificate(c.secretLister, crt)
+		if err != nil {
+			// Problems with the CSR are reported by the requestmanager controller.
+			log.V(logf.DebugLevel).Info("Unable to read CSR from spec.csrSecretRef, waiting for requestmanager controller", "error", err.Error())
+			return nil
+		}
+		publicKey = csr.PublicKey
+	} else {
+		if crt.Status.NextPrivateKeySecretName == nil ||
+			len(*crt.Status.NextPrivateKeySecretName) == 0 {
+			// Do nothing if the next private key secret name is not set
+			return nil
+		}
 
-	// Fetch and parse the 'next private key secret'
-	nextPrivateKeySecret, err := c.secretLister.Secrets(crt.Namespace).Get(*crt.Status.NextPrivateKeySecretName)
-	if apierrors.IsNotFound(err) {
-		log.V(logf.DebugLevel).Info("Next private key secret does not exist, waiting for keymanager controller")
-		// If secret does not exist, do nothing (keymanager will handle this).
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	if nextPrivateKeySecret.Data == nil || len(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
-		logf.WithResource(log, nextPrivateKeySecret).Info("Next private key secret does not contain any private key data, waiting for keymanager controller")
-		return nil
-	}
-	pk, _, err := utilkube.ParseTLSKeyFromSecret(nextPrivateKeySecret, corev1.TLSPrivateKeyKey)
-	if err != nil {
-		// If the private key cannot be parsed here, do nothing as the key manager will handle this.
-		logf.WithResource(log, nextPrivateKeySecret).Error(err, "failed to parse next private key, waiting for keymanager controller")
-		return nil
-	}
-	pkViolations, err := certificates.PrivateKeyMatchesSpec(pk, crt.Spec)
-	if err != nil {
-		return err
-	}
-	if len(pkViolations) > 0 {
-		logf.WithResource(log, nextPrivateKeySecret).Info("stored next private key does not match requirements on Certificate resource, waiting for keymanager controller", "violations", pkViolations)
-		return nil
+		// Fetch and parse the 'next private key secret'
+		nextPrivateKeySecret, err := c.secretLister.Secrets(crt.Namespace).Get(*crt.Status.NextPrivateKeySecretName)
+		if apierrors.IsNotFound(err) {
+			log.V(logf.DebugLevel).Info("Next private key secret does not exist, waiting for keymanager controller")
+			// If secret does not exist, do nothing (keymanager will handle this).
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if nextPrivateKeySecret.Data == nil || len(nextPrivateKeySecret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+			logf.WithResource(log, nextPrivateKeySecret).Info("Next private key secret does not contain any private key data, waiting for keymanager controller")
+			return nil
+		}
+		pk, _, err = utilkube.ParseTLSKeyFromSecret(nextPrivateKeySecret, corev1.TLSPrivateKeyKey)
+		if err != nil {
+			// If the private key cannot be parsed here, do nothing as the key manager will handle this.
+			logf.WithResource(log, nextPrivateKeySecret).Error(err, "failed to parse next private key, waiting for keymanager controller")
+			return nil
+		}
+		pkViolations, err := certificates.PrivateKeyMatchesSpec(pk, crt.Spec)
+		if err != nil {
+			return err
+		}
+		if len(pkViolations) > 0 {
+			logf.WithResource(log, nextPrivateKeySecret).Info("stored next private key does not match requirements on Certificate resource, waiting for keymanager controller", "violations", pkViolations)
+			return nil
+		}
+		publicKey = pk.Public()
 	}
 
 	// CertificateRequest revisions begin from 1. If no revision is set on the
@@ -248,9 +312,17 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	req := reqs[0]
 	log = logf.WithResource(log, req)
 
-	// Verify the CSR options match what is requested in certificate.spec.
+	// Verify the CSR options match what is requested in certificate.spec,
+	// including any DNS names listed in the referenced ConfigMap.
 	// If there are violations in the spec, then the requestmanager will handle this.
-	requestViolations, err := certificates.RequestMatchesSpec(req, crt.Spec)
+	mergedCrt, err := certificates.CertificateWithConfigMapDNSNames(c.configMapLister, crt)
+	if err != nil {
+		// The Certificate is processed again once the ConfigMap is created or
+		// updated, so there is no need to retry.
+		log.V(logf.DebugLevel).Info("cannot read the DNS names referenced by spec.dnsNamesConfigMapRef", "error", err.Error())
+		return nil
+	}
+	requestViolations, err := certificates.RequestMatchesSpec(req, mergedCrt.Spec)
 	if err != nil {
 		return err
 	}
@@ -296,10 +368,14 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	// If the certificate request has failed, set the last failure time to
-	// now, bump the issuance attempts and set the Issuing status condition
-	// to False.
+	// If the certificate request has failed and the Certificate lists a
+	// fallback issuer after the one the request was sent to, fail over to it.
+	// Otherwise, set the last failure time to now, bump the issuance attempts
+	// and set the Issuing status condition to False.
 	if crReadyCond.Reason == cmapi.CertificateRequestReasonFailed {
+		if next, ok := certificates.NextIssuerRef(crt.Spec, req.Spec.IssuerRef); ok {
+			return c.failOverToIssuer(ctx, log, crt, req, crReadyCond, next)
+		}
 		return c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady))
 	}
 
@@ -308,12 +384,12 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
-	publicKeyMatchesCSR, err := utilpki.PublicKeyMatchesCSR(pk.Public(), csr)
+	publicKeyMatchesCSR, err := utilpki.PublicKeyMatchesCSR(publicKey, csr)
 	if err != nil {
 		return err
 	}
 	if !publicKeyMatchesCSR {
-		logf.WithResource(log, nextPrivateKeySecret).Info("next private key does not match CSR public key, waiting for requestmanager controller")
+		log.Info("next private key does not match CSR public key, waiting for requestmanager controller")
 		return nil
 	}
 
@@ -325,9 +401,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 
 	// Issue temporary certificate if needed. If a certificate was issued, then
 	// return early - we will sync again since the target Secret has been
-	// updated.
-	if issued, err := c.ensureTemporaryCertificate(ctx, crt, pk); err != nil || issued {
-		return err
+	// updated. A temporary certificate cannot be issued without a private key.
+	if pk != nil {
+		issued, err := c.ensureTemporaryCertificate(ctx, crt, pk)
+		// As when storing the issued certificate, fail the issuance rather
+		// than retrying until the Certificate adopts the Secret.
+		if errors.Is(err, internal.ErrSecretNotOwned) {
+			return c.failIssuance(ctx, log, crt, "The temporary certificate could not be stored", reasonSecretNotOwned, err.Error())
+		}
+		if err != nil || issued {
+			return err
+		}
+	}
+
+	// If the issuer failed to sign the CertificateRequest for a reason that
+	// will be retried, surface that reason on the Certificate while waiting.
+	switch crReadyCond.Reason {
+	case cmapi.CertificateRequestReasonRateLimited, cmapi.CertificateRequestReasonAuthFailed:
+		return c.reportRetryingIssuance(ctx, log, crt, crReadyCond)
 	}
 
 	// CertificateRequest is not in a final state so do nothing.
@@ -335,28 +426,72 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return nil
 }
 
+// reportRetryingIssuance copies the reason of a CertificateRequest Ready
+// condition, set after a failure that will be retried, onto the Certificate's
+// Issuing condition. The Issuing condition remains True as issuance is still
+// in progress.
+func (c *controller) reportRetryingIssuance(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, condition *cmapi.CertificateRequestCondition) error {
+	message := fmt.Sprintf("The certificate request has failed to complete and will be retried: %s", condition.Message)
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond.Reason == condition.Reason && cond.Message == message {
+		return nil
+	}
+
+	log.V(logf.DebugLevel).Info("CertificateRequest failed and will be retried, updating Issuing condition", "reason", condition.Reason)
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, condition.Reason, message)
+	return c.updateOrApplyStatus(ctx, crt, false)
+}
+
 // failIssueCertificate will mark the Issuing condition of this Certificate as
 // false, set the Certificate's last failure time and issuance attempts, and log
 // an appropriate event. The reason and message of the Issuing condition will be that of
 // the CertificateRequest condition passed.
+// If the maximum number of issuance attempts has been reached, the Failed
+// condition is also set so that issuance is not retried until the spec
+// changes.
 func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, condition *cmapi.CertificateRequestCondition) error {
+	return c.failIssuance(ctx, log, crt, "The certificate request has failed to complete", condition.Reason, condition.Message)
+}
+
+// failIssuance records a failed issuance of this Certificate as described by
+// failIssueCertificate. failure describes what failed, and the message of the
+// Issuing condition is built from it and the given message.
+func (c *controller) failIssuance(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, failure, reason, failureMessage string) error {
+	crt = crt.DeepCopy()
+
 	nowTime := metav1.NewTime(c.clock.Now())
 	crt.Status.LastFailureTime = &nowTime
 
+	// A Failed condition observed for an older generation means the spec
+	// has changed since issuance was given up on, so start counting the
+	// attempts afresh.
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionFailed); cond != nil && cond.ObservedGeneration != crt.Generation {
+		crt.Status.FailedIssuanceAttempts = nil
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionFailed)
+	}
+
 	failedIssuanceAttempts := 1
 	if crt.Status.FailedIssuanceAttempts != nil {
 		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts + 1
 	}
 	crt.Status.FailedIssuanceAttempts = &failedIssuanceAttempts
 
-	log.V(logf.DebugLevel).Info("CertificateRequest in failed state so retrying issuance later")
-
-	var reason, message string
-	reason = condition.Reason
-	message = fmt.Sprintf("The certificate request has failed to complete and will be retried: %s",
-		condition.Message)
+	// Every issuer has now been tried, so the next issuance starts again from
+	// spec.issuerRef.
+	crt.Status.FallbackIssuerRef = nil
+
+	var message string
+	if c.maxIssuanceAttempts > 0 && failedIssuanceAttempts >= c.maxIssuanceAttempts {
+		log.V(logf.DebugLevel).Info("Issuance failed and maximum issuance attempts reached so not retrying issuance",
+			"attempts", failedIssuanceAttempts)
+		message = fmt.Sprintf("%s and will not be retried until the Certificate is updated: %s", failure, failureMessage)
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionFailed, cmmeta.ConditionTrue, reasonMaxIssuanceAttemptsExceeded,
+			fmt.Sprintf("Issuance has failed %d consecutive times: %s", failedIssuanceAttempts, failureMessage))
+	} else {
+		log.V(logf.DebugLevel).Info("Issuance failed so retrying issuance later")
+		message = fmt.Sprintf("%s and will be retried: %s", failure, failureMessage)
+	}
 
-	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reason, message)
 
 	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
@@ -368,26 +503,70 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 	return nil
 }
 
+// failOverToIssuer records that CertificateRequests for the ongoing issuance
+// should be sent to the fallback issuer next, after the CertificateRequest
+// req failed. The Issuing condition is left True; the requestmanager
+// controller replaces req with a CertificateRequest for the next issuer.
+func (c *controller) failOverToIssuer(ctx context.Context, log logr.Logger, crt *cmapi.Certificate, req *cmapi.CertificateRequest, condition *cmapi.CertificateRequestCondition, next cmmeta.ObjectReference) error {
+	// A failed request for an issuer before the current one is left over from
+	// an earlier failover, and will be deleted by the requestmanager.
+	if !certificates.IssuerRefsEqual(certificates.CurrentIssuerRef(crt), req.Spec.IssuerRef) {
+		log.V(logf.DebugLevel).Info("Failed CertificateRequest was not sent to the current issuer, waiting for it to be replaced")
+		return nil
+	}
+
+	log.V(logf.DebugLevel).Info("CertificateRequest in failed state, failing over to the next issuer", "issuer", next.Name, "issuer_kind", next.Kind)
+	crt = crt.DeepCopy()
+	crt.Status.FallbackIssuerRef = &next
+	if err := c.updateOrApplyStatus(ctx, crt, false); err != nil {
+		return err
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonIssuerFailover,
+		"The certificate request failed on %s %q and will be retried on %s %q: %s",
+		apiutil.IssuerKind(req.Spec.IssuerRef), req.Spec.IssuerRef.Name, apiutil.IssuerKind(next), next.Name, condition.Message)
+
+	return nil
+}
+
 // issueCertificate will ensure the public key of the CSR matches the signed
 // certificate, and then store the certificate, CA and private key into the
 // Secret in the appropriate format type.
+// If pk is nil, as the private key is not managed by cert-manager, only the
+// certificate and CA are stored.
 func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer) error {
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
 	}
 
-	pkData, err := utilpki.EncodePrivateKey(pk, crt.Spec.PrivateKey.Encoding)
+	var pkData []byte
+	if pk != nil {
+		var err error
+		pkData, err = utilpki.EncodePrivateKey(pk, crt.Spec.PrivateKey.Encoding)
+		if err != nil {
+			return err
+		}
+	}
+	ca, err := c.appendAdditionalCACertificates(crt, req.Status.CA)
 	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonAdditionalCAFailed, "Failed to append additional CA certificates: %v", err)
 		return err
 	}
+
 	secretData := internal.SecretData{
 		PrivateKey:  pkData,
 		Certificate: req.Status.Certificate,
-		CA:          req.Status.CA,
+		CA:          ca,
 	}
 
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
+		// Overwriting data that cert-manager did not write could break
+		// whatever relies on it, so fail the issuance rather than retrying
+		// the update until the Certificate adopts the Secret.
+		if errors.Is(err, internal.ErrSecretNotOwned) {
+			return c.failIssuance(ctx, logf.FromContext(ctx), crt, "The certificate could not be stored", reasonSecretNotOwned, err.Error())
+		}
 		return err
 	}
 
@@ -405,6 +584,13 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	// Clear status.lastFailureTime (if set)
 	crt.Status.LastFailureTime = nil
 
+	// Clear status.fallbackIssuerRef (if set) so that the next issuance
+	// starts from spec.issuerRef again
+	crt.Status.FallbackIssuerRef = nil
+
+	// Remove Failed status condition (if set)
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionFailed)
+
 	if err := c.updateOrApplyStatus(ctx, crt, true); err != nil {
 		return err
 	}
@@ -412,10 +598,76 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	message := "The certificate has been successfully issued"
 	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
 
+	if c.issuanceNotifier != nil {
+		c.notifyIssuance(ctx, crt, nextRevision, req.Status.Certificate)
+	}
+
+	// Enforce the revision history limit now that a new revision exists,
+	// rather than waiting for the revision manager to observe the Certificate
+	// becoming Ready. Failures are not fatal as the revision manager will
+	// retry the garbage collection.
+	if err := revisionmanager.PruneRevisions(ctx, c.client, c.certificateRequestLister, crt); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to garbage collect old CertificateRequest revisions")
+	}
+
 	return nil
 
 }
 
+// notifyIssuance queues a notification to the issuance webhook that the given
+// revision of the Certificate has been issued. Notifications are sent by
+// runIssuanceNotifier so that retrying the webhook does not block a worker.
+// Failures are logged and recorded as an event, but do not fail the issuance
+// as the certificate has already been stored.
+func (c *controller) notifyIssuance(ctx context.Context, crt *cmapi.Certificate, revision int, certPEM []byte) {
+	log := logf.FromContext(ctx)
+
+	recordFailure := func(err error) {
+		log.Error(err, "failed to notify the issuance webhook", "revision", revision)
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonIssuanceNotificationFailed, "Failed to notify the issuance webhook of revision %d: %v", revision, err)
+	}
+
+	notification, err := internal.NewIssuanceNotification(crt, revision, certPEM)
+	if err != nil {
+		recordFailure(err)
+		return
+	}
+	if !c.issuanceNotifier.Enqueue(notification, recordFailure) {
+		recordFailure(errors.New("too many notifications are waiting to be sent"))
+	}
+}
+
+// appendAdditionalCACertificates returns the given PEM encoded CA bundle with
+// the certificates referenced by the Certificate's
+// 'spec.additionalCACertificates' appended to it.
+func (c *controller) appendAdditionalCACertificates(crt *cmapi.Certificate, ca []byte) ([]byte, error) {
+	ref := crt.Spec.AdditionalCACertificates
+	if ref == nil {
+		return ca, nil
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := ref.Key
+	if len(key) == 0 {
+		key = cmmeta.TLSCAKey
+	}
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, secret.Namespace, secret.Name)
+	}
+
+	bundle, err := utilpki.AppendCertificatesToPEMBundle(ca, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificates in secret '%s/%s': %w", secret.Namespace, secret.Name, err)
+	}
+
+	return bundle, nil
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -434,16 +686,20 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 		}
 
 		var conditions []cmapi.CertificateCondition
-		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+		for _, condType := range []cmapi.CertificateConditionType{cmapi.CertificateConditionIssuing, cmapi.CertificateConditionFailed} {
+			if cond := apiutil.GetCertificateCondition(crt, condType); cond != nil {
+				conditions = append(conditions, *cond)
+			}
 		}
 
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
 			Status: cmapi.CertificateStatus{
-				Revision:        crt.Status.Revision,
-				LastFailureTime: crt.Status.LastFailureTime,
-				Conditions:      conditions,
+				Revision:               crt.Status.Revision,
+				LastFailureTime:        crt.Status.LastFailureTime,
+				FailedIssuanceAttempts: crt.Status.FailedIssuanceAttempts,
+				FallbackIssuerRef:      crt.Status.FallbackIssuerRef,
+				Conditions:             conditions,
 			},
 		})
 	} else {
@@ -458,6 +714,15 @@ type controllerWrapper struct {
 	*controller
 }
 
+// runIssuanceNotifier sends the queued issuance webhook notifications until
+// the context is cancelled.
+func (c *controllerWrapper) runIssuanceNotifier(ctx context.Context) {
+	if c.controller == nil || c.issuanceNotifier == nil {
+		return
+	}
+	c.issuanceNotifier.Run(ctx)
+}
+
 func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
 	// construct a new named logger to be reused throughout the controller
 	log := logf.FromContext(ctx.RootContext, ControllerName)
@@ -467,11 +732,15 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Namespace,
 		ctx.Recorder,
 		ctx.Clock,
 		ctx.CertificateOptions,
 		ctx.FieldManager,
 	)
+	defaulter, defaulterMustSync := certificates.NewPrivateKeyDefaulter(ctx.SharedInformerFactory, ctx.Namespace)
+	ctrl.privateKeyDefaulter = defaulter
+	mustSync = append(mustSync, defaulterMustSync...)
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -479,8 +748,10 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 
 func init() {
 	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		c := &controllerWrapper{}
 		return controllerpkg.NewBuilder(ctx, ControllerName).
-			For(&controllerWrapper{}).
+			For(c).
+			With(c.runIssuanceNotifier, time.Second).
 			Complete()
 	})
 }
@@ -18,7 +18,12 @@ package issuing
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,15 +32,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -51,6 +60,21 @@ func testLocalTemporarySignerFn(b []byte) localTemporarySignerFn {
 	}
 }
 
+// previousRevisions returns copies of the given CertificateRequest for
+// revisions 1 to n, named after their revision.
+func previousRevisions(req *cmapi.CertificateRequest, n int) []runtime.Object {
+	var requests []runtime.Object
+	for i := 1; i <= n; i++ {
+		requests = append(requests, gen.CertificateRequestFrom(req,
+			gen.SetCertificateRequestName(fmt.Sprintf("test-revision-%d", i)),
+			gen.AddCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey: strconv.Itoa(i),
+			}),
+		))
+	}
+	return requests
+}
+
 func TestIssuingController(t *testing.T) {
 	type testT struct {
 		builder *testpkg.Builder
@@ -58,6 +82,9 @@ func TestIssuingController(t *testing.T) {
 		certificate             *cmapi.Certificate
 		expSecretUpdateDataCall *internal.SecretData
 
+		disableTemporaryCertificates bool
+		maxIssuanceAttempts          int
+
 		expectedErr bool
 	}
 
@@ -75,6 +102,9 @@ func TestIssuingController(t *testing.T) {
 	exampleBundle := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fixedClock)
 
 	exampleBundleAlt := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fixedClock)
+	issuerCA := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t), gen.Certificate("issuer-ca", gen.SetCertificateCommonName("issuer-ca"), gen.SetCertificateIsCA(true)))
+	additionalCA := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t), gen.Certificate("additional-ca", gen.SetCertificateCommonName("additional-ca"), gen.SetCertificateIsCA(true)))
+	additionalCASecretRef := cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "additional-ca"}}
 	metaFixedClockStart := metav1.NewTime(fixedClockStart)
 
 	issuingCert := gen.CertificateFrom(baseCert.DeepCopy(),
@@ -86,7 +116,151 @@ func TestIssuingController(t *testing.T) {
 		}),
 	)
 
+	fallbackIssuerRef := cmmeta.ObjectReference{Name: "fallback-issuer", Kind: "ClusterIssuer"}
+	issuingCertWithFallback := gen.CertificateFrom(issuingCert,
+		gen.SetCertificateFallbackIssuers(fallbackIssuerRef),
+	)
+	nextPrivateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nextPrivateKeySecretName,
+			Namespace: exampleBundle.Certificate.Namespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+		},
+	}
+	failedCondition := cmapi.CertificateRequestCondition{
+		Type:    cmapi.CertificateRequestConditionReady,
+		Status:  cmmeta.ConditionFalse,
+		Reason:  cmapi.CertificateRequestReasonFailed,
+		Message: "The certificate request failed because of reasons",
+	}
+
 	tests := map[string]testT{
+		"if certificate is in Issuing state, one CertificateRequest, and has failed, fail over to the next issuer if one is listed": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCertWithFallback),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestFailed,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestStatusCondition(failedCondition),
+					)},
+				KubeObjects: []runtime.Object{nextPrivateKeySecret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(issuingCertWithFallback,
+							gen.SetCertificateStatusFallbackIssuer(fallbackIssuerRef),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					`Warning IssuerFailover The certificate request failed on Issuer "ca-issuer" and will be retried on ClusterIssuer "fallback-issuer": The certificate request failed because of reasons`,
+				},
+			},
+			expectedErr: false,
+		},
+		"if certificate has failed over to the next issuer, but the failed CertificateRequest for the previous issuer still exists, do nothing": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCertWithFallback,
+						gen.SetCertificateStatusFallbackIssuer(fallbackIssuerRef),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestFailed,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestStatusCondition(failedCondition),
+					)},
+				KubeObjects:     []runtime.Object{nextPrivateKeySecret},
+				ExpectedActions: []testpkg.Action{},
+				ExpectedEvents:  []string{},
+			},
+			expectedErr: false,
+		},
+		"if certificate is in Issuing state, and the CertificateRequest for the last fallback issuer has failed, fail issuance and start from the first issuer next time": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCertWithFallback,
+						gen.SetCertificateStatusFallbackIssuer(fallbackIssuerRef),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestFailed,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestIssuer(fallbackIssuerRef),
+						gen.SetCertificateRequestStatusCondition(failedCondition),
+					)},
+				KubeObjects: []runtime.Object{nextPrivateKeySecret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateFallbackIssuers(fallbackIssuerRef),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
+				},
+			},
+			expectedErr: false,
+		},
+		"if certificate is in Issuing state, and the CertificateRequest for the fallback issuer is ready, store the signed certificate and clear the fallback issuer": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCertWithFallback,
+						gen.SetCertificateStatusFallbackIssuer(fallbackIssuerRef),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestIssuer(fallbackIssuerRef),
+					)},
+				KubeObjects: []runtime.Object{nextPrivateKeySecret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateFallbackIssuers(fallbackIssuerRef),
+							gen.SetCertificateRevision(2),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			expectedErr: false,
+		},
 		"if certificate is not in Issuing state, then do nothing": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -181,6 +355,97 @@ func TestIssuingController(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if certificate is in Issuing state, one CertificateRequest, and is rate limited, set the Issuing reason to RateLimited": {
+			certificate:                  exampleBundle.Certificate,
+			disableTemporaryCertificates: true,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequest,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:    cmapi.CertificateRequestConditionReady,
+							Status:  cmmeta.ConditionFalse,
+							Reason:  cmapi.CertificateRequestReasonRateLimited,
+							Message: "Failed to sign certificate request, will retry: too many requests",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonRateLimited,
+								Message:            "The certificate request has failed to complete and will be retried: Failed to sign certificate request, will retry: too many requests",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+						),
+					)),
+				},
+			},
+			expectedErr: false,
+		},
+
+		"if certificate is in Issuing state and already reports that its CertificateRequest failed authentication, do nothing": {
+			certificate:                  exampleBundle.Certificate,
+			disableTemporaryCertificates: true,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionIssuing,
+							Status:             cmmeta.ConditionTrue,
+							Reason:             cmapi.CertificateRequestReasonAuthFailed,
+							Message:            "The certificate request has failed to complete and will be retried: Failed to sign certificate request, will retry: token expired",
+							LastTransitionTime: &metaFixedClockStart,
+							ObservedGeneration: 3,
+						}),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequest,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:    cmapi.CertificateRequestConditionReady,
+							Status:  cmmeta.ConditionFalse,
+							Reason:  cmapi.CertificateRequestReasonAuthFailed,
+							Message: "Failed to sign certificate request, will retry: token expired",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{},
+			},
+			expectedErr: false,
+		},
+
 		"if certificate is in Issuing state, one CertificateRequest, but has failed and does not match the certificate spec, do nothing": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -391,12 +656,367 @@ func TestIssuingController(t *testing.T) {
 			},
 			expectedErr: false,
 		},
-		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the private key stored in the Secret cannot be parsed, do nothing": {
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the private key stored in the Secret cannot be parsed, do nothing": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: []byte("bad key"),
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{},
+				ExpectedEvents:  []string{},
+			},
+			expectedErr: false,
+		},
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the private key stored in the Secret does not match that creating the CSR, do nothing": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundleAlt.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{},
+				ExpectedEvents:  []string{},
+			},
+			expectedErr: false,
+		},
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the CertificateRequest contains a violation, do nothing": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestKeyUsages(cmapi.UsageCRLSign),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{},
+				ExpectedEvents:  []string{},
+			},
+			expectedErr: false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequest, and has failed for the maximum number of attempts, set the Failed condition and log event": {
+			certificate:         exampleBundle.Certificate,
+			maxIssuanceAttempts: 3,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert, gen.SetCertificateIssuanceAttempts(pointer.Int(2))),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestFailed,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:    cmapi.CertificateRequestConditionReady,
+							Status:  cmmeta.ConditionFalse,
+							Reason:  cmapi.CertificateRequestReasonFailed,
+							Message: "The certificate request failed because of reasons",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "The certificate request has failed to complete and will not be retried until the Certificate is updated: The certificate request failed because of reasons",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionFailed,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "MaxIssuanceAttemptsExceeded",
+								Message:            "Issuance has failed 3 consecutive times: The certificate request failed because of reasons",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed The certificate request has failed to complete and will not be retried until the Certificate is updated: The certificate request failed because of reasons",
+				},
+			},
+			expectedErr: false,
+		},
+		"if certificate is in Issuing state, one CertificateRequest, and has failed after the spec of a Failed certificate changed, reset the issuance attempts and remove the Failed condition": {
+			certificate:         exampleBundle.Certificate,
+			maxIssuanceAttempts: 3,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionFailed,
+							Status:             cmmeta.ConditionTrue,
+							Reason:             "MaxIssuanceAttemptsExceeded",
+							Message:            "Issuance has failed 3 consecutive times: The certificate request failed because of reasons",
+							LastTransitionTime: &metaFixedClockStart,
+							ObservedGeneration: 2,
+						}),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestFailed,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:    cmapi.CertificateRequestConditionReady,
+							Status:  cmmeta.ConditionFalse,
+							Reason:  cmapi.CertificateRequestReasonFailed,
+							Message: "The certificate request failed because of reasons",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed The certificate request has failed to complete and will be retried: The certificate request failed because of reasons",
+				},
+			},
+			expectedErr: false,
+		},
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, remove the Failed condition": {
+			certificate:         exampleBundle.Certificate,
+			maxIssuanceAttempts: 3,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionFailed,
+							Status:             cmmeta.ConditionTrue,
+							Reason:             "MaxIssuanceAttemptsExceeded",
+							Message:            "Issuance has failed 3 consecutive times: The certificate request failed because of reasons",
+							LastTransitionTime: &metaFixedClockStart,
+							ObservedGeneration: 2,
+						}),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateRevision(2),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to a new secret, and log an event": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateRevision(2),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, delete the oldest CertificateRequests over the revision history limit": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: append([]runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.SetCertificateRevision(5),
+						gen.SetCertificateRevisionHistoryLimit(2),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "6", // Current Certificate revision=5
+						}),
+					)},
+					previousRevisions(exampleBundle.CertificateRequestReady, 5)...,
+				),
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateRevision(6),
+							gen.SetCertificateRevisionHistoryLimit(2),
+						),
+					)),
+					testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), exampleBundle.Certificate.Namespace, "test-revision-1")),
+					testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), exampleBundle.Certificate.Namespace, "test-revision-2")),
+					testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), exampleBundle.Certificate.Namespace, "test-revision-3")),
+					testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), exampleBundle.Certificate.Namespace, "test-revision-4")),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			expectedErr: false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, append the additional CA certificates to the issued ca": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{
-					gen.CertificateFrom(issuingCert),
+					gen.CertificateFrom(issuingCert, gen.SetCertificateAdditionalCACertificates(additionalCASecretRef)),
 					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.SetCertificateRequestCA(issuerCA),
 						gen.AddCertificateRequestAnnotations(map[string]string{
 							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
 						}),
@@ -408,51 +1028,51 @@ func TestIssuingController(t *testing.T) {
 							Namespace: exampleBundle.Certificate.Namespace,
 						},
 						Data: map[string][]byte{
-							corev1.TLSPrivateKeyKey: []byte("bad key"),
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
 						},
 					},
-				},
-				ExpectedActions: []testpkg.Action{},
-				ExpectedEvents:  []string{},
-			},
-			expectedErr: false,
-		},
-		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the private key stored in the Secret does not match that creating the CSR, do nothing": {
-			certificate: exampleBundle.Certificate,
-			builder: &testpkg.Builder{
-				CertManagerObjects: []runtime.Object{
-					gen.CertificateFrom(issuingCert),
-					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
-						gen.AddCertificateRequestAnnotations(map[string]string{
-							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
-						}),
-					)},
-				KubeObjects: []runtime.Object{
 					&corev1.Secret{
 						ObjectMeta: metav1.ObjectMeta{
-							Name:      nextPrivateKeySecretName,
+							Name:      "additional-ca",
 							Namespace: exampleBundle.Certificate.Namespace,
 						},
 						Data: map[string][]byte{
-							corev1.TLSPrivateKeyKey: exampleBundleAlt.PrivateKeyBytes,
+							// The issuer's CA is already in the bundle and must not be duplicated.
+							cmmeta.TLSCAKey: append(append([]byte{}, additionalCA...), issuerCA...),
 						},
 					},
 				},
-				ExpectedActions: []testpkg.Action{},
-				ExpectedEvents:  []string{},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateAdditionalCACertificates(additionalCASecretRef),
+							gen.SetCertificateRevision(2),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          append(append([]byte{}, issuerCA...), additionalCA...),
 			},
 			expectedErr: false,
 		},
-		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the CertificateRequest contains a violation, do nothing": {
+		"if certificate is in Issuing state, one CertificateRequest, and is ready, but the additional CA certificates are invalid, log an event and return an error": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{
-					gen.CertificateFrom(issuingCert),
+					gen.CertificateFrom(issuingCert, gen.SetCertificateAdditionalCACertificates(additionalCASecretRef)),
 					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
 						gen.AddCertificateRequestAnnotations(map[string]string{
 							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
 						}),
-						gen.SetCertificateRequestKeyUsages(cmapi.UsageCRLSign),
 					)},
 				KubeObjects: []runtime.Object{
 					&corev1.Secret{
@@ -464,18 +1084,29 @@ func TestIssuingController(t *testing.T) {
 							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
 						},
 					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "additional-ca",
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							cmmeta.TLSCAKey: []byte("not a certificate"),
+						},
+					},
+				},
+				ExpectedEvents: []string{
+					"Warning AdditionalCAFailed Failed to append additional CA certificates: invalid CA certificates in secret 'default-unit-test-ns/additional-ca': error decoding certificate PEM block",
 				},
-				ExpectedActions: []testpkg.Action{},
-				ExpectedEvents:  []string{},
 			},
-			expectedErr: false,
+			expectedErr: true,
 		},
-
-		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to a new secret, and log an event": {
+		"if certificate does not manage its private key and is in Issuing state, one CertificateRequest, and is ready, store only the signed certificate and ca, and log an event": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{
-					gen.CertificateFrom(issuingCert),
+					gen.CertificateFrom(issuingCert,
+						gen.SetCertificateUnmanagedPrivateKey("csr"),
+					),
 					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
 						gen.AddCertificateRequestAnnotations(map[string]string{
 							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
@@ -484,11 +1115,11 @@ func TestIssuingController(t *testing.T) {
 				KubeObjects: []runtime.Object{
 					&corev1.Secret{
 						ObjectMeta: metav1.ObjectMeta{
-							Name:      nextPrivateKeySecretName,
+							Name:      "csr",
 							Namespace: exampleBundle.Certificate.Namespace,
 						},
 						Data: map[string][]byte{
-							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+							cmapi.CertificateCSRSecretDefaultKey: exampleBundle.CSRBytes,
 						},
 					},
 				},
@@ -498,6 +1129,7 @@ func TestIssuingController(t *testing.T) {
 						"status",
 						exampleBundle.Certificate.Namespace,
 						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateUnmanagedPrivateKey("csr"),
 							gen.SetCertificateRevision(2),
 						),
 					)),
@@ -508,11 +1140,37 @@ func TestIssuingController(t *testing.T) {
 			},
 			expSecretUpdateDataCall: &internal.SecretData{
 				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
-				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				PrivateKey:  nil,
 				CA:          nil,
 			},
 			expectedErr: false,
 		},
+		"if certificate does not manage its private key and is in Issuing state, one CertificateRequest, and is ready, but the CSR Secret contains a different public key, do nothing": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.SetCertificateUnmanagedPrivateKey("csr"),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "csr",
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							cmapi.CertificateCSRSecretDefaultKey: exampleBundleAlt.CSRBytes,
+						},
+					},
+				},
+			},
+			expectedErr: false,
+		},
 
 		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
@@ -951,6 +1609,86 @@ func TestIssuingController(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if certificate is in Issuing state with temp annotation, one CertificateRequest Pending, no target Secret, but temporary certificates are disabled, do nothing": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.AddCertificateAnnotations(map[string]string{
+							cmapi.IssueTemporaryCertificateAnnotation: "true",
+						}),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestPending,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{},
+			},
+			disableTemporaryCertificates: true,
+			expectedErr:                  false,
+		},
+		"if certificate is in Issuing state with temp annotation, one CertificateRequest Ready, a target Secret does not exist, and temporary certificates are disabled, issue Certificate from CertificateRequest": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert,
+						gen.AddCertificateAnnotations(map[string]string{
+							cmapi.IssueTemporaryCertificateAnnotation: "true",
+						}),
+					),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.AddCertificateAnnotations(map[string]string{
+								cmapi.IssueTemporaryCertificateAnnotation: "true",
+							}),
+							gen.SetCertificateRevision(2),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: exampleBundle.CertificateRequestReady.Status.Certificate,
+				PrivateKey:  exampleBundle.PrivateKeyBytes,
+				CA:          nil,
+			},
+			disableTemporaryCertificates: true,
+			expectedErr:                  false,
+		},
 		"if certificate is in Issuing state with temp annotation, one CertificateRequest Failed, a target Secret does not exist, mark the Certificate as failed": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -1071,6 +1809,8 @@ func TestIssuingController(t *testing.T) {
 			test.builder.T = t
 			test.builder.InitWithRESTConfig()
 			defer test.builder.Stop()
+			test.builder.Context.CertificateOptions.DisableTemporaryCertificates = test.disableTemporaryCertificates
+			test.builder.Context.CertificateOptions.MaxIssuanceAttempts = test.maxIssuanceAttempts
 
 			w := controllerWrapper{}
 			_, _, err := w.Register(test.builder.Context)
@@ -1107,3 +1847,313 @@ func TestIssuingController(t *testing.T) {
 		})
 	}
 }
+
+func TestFailIssueCertificateMaxIssuanceAttempts(t *testing.T) {
+	const maxIssuanceAttempts = 3
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateGeneration(1),
+		gen.SetCertificateSecretName("output"),
+	)
+	failedCond := &cmapi.CertificateRequestCondition{
+		Type:    cmapi.CertificateRequestConditionReady,
+		Status:  cmmeta.ConditionFalse,
+		Reason:  cmapi.CertificateRequestReasonFailed,
+		Message: "The certificate request failed because of reasons",
+	}
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fixedClock,
+		CertManagerObjects: []runtime.Object{crt},
+	}
+	builder.Init()
+	defer builder.Stop()
+	builder.Context.CertificateOptions.MaxIssuanceAttempts = maxIssuanceAttempts
+
+	w := controllerWrapper{}
+	_, _, err := w.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	ctx := context.Background()
+	getCertificate := func() *cmapi.Certificate {
+		crt, err := builder.CMClient.CertmanagerV1().Certificates(crt.Namespace).Get(ctx, crt.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return crt
+	}
+	isFailed := func(crt *cmapi.Certificate) bool {
+		return apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionFailed,
+			Status: cmmeta.ConditionTrue,
+		})
+	}
+
+	// Drive repeated failures until the Certificate reaches the terminal state.
+	for attempt := 1; attempt <= maxIssuanceAttempts; attempt++ {
+		require.NoError(t, w.controller.failIssueCertificate(ctx, logf.Log, getCertificate(), failedCond))
+
+		crt := getCertificate()
+		require.NotNil(t, crt.Status.FailedIssuanceAttempts)
+		assert.Equal(t, attempt, *crt.Status.FailedIssuanceAttempts)
+		assert.Equal(t, attempt == maxIssuanceAttempts, isFailed(crt), "unexpected Failed condition after %d attempts", attempt)
+	}
+
+	// Editing the spec bumps the generation, after which the attempts are
+	// counted afresh and the Failed condition is removed.
+	edited := getCertificate()
+	edited.Spec.DNSNames = []string{"example.com"}
+	edited.Generation++
+	_, err = builder.CMClient.CertmanagerV1().Certificates(edited.Namespace).Update(ctx, edited, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, w.controller.failIssueCertificate(ctx, logf.Log, getCertificate(), failedCond))
+
+	crt = getCertificate()
+	require.NotNil(t, crt.Status.FailedIssuanceAttempts)
+	assert.Equal(t, 1, *crt.Status.FailedIssuanceAttempts)
+	assert.False(t, isFailed(crt), "expected the Failed condition to be removed after the spec changed")
+}
+
+func TestIssueCertificateNotifiesWebhook(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateGeneration(1),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+
+	notifications := make(chan internal.IssuanceNotification, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification internal.IssuanceNotification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		notifications <- notification
+	}))
+	defer receiver.Close()
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fixedClock,
+		CertManagerObjects: []runtime.Object{crt},
+	}
+	builder.Init()
+	defer builder.Stop()
+	builder.Context.CertificateOptions.IssuedWebhookURL = receiver.URL
+	builder.Context.CertificateOptions.IssuedWebhookTimeout = time.Second
+
+	w := &controllerWrapper{}
+	_, _, err := w.Register(builder.Context)
+	require.NoError(t, err)
+	w.controller.secretsUpdateData = func(context.Context, *cmapi.Certificate, internal.SecretData) error {
+		return nil
+	}
+	builder.Start()
+
+	// Issuing the second revision is a renewal of the certificate.
+	err = w.controller.issueCertificate(context.Background(), 2, crt, bundle.CertificateRequestReady, bundle.PrivateKey)
+	require.NoError(t, err)
+
+	// The notification is only sent once the notifier is running, so issuing
+	// does not wait for the webhook.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.runIssuanceNotifier(ctx)
+
+	select {
+	case notification := <-notifications:
+		assert.Equal(t, internal.IssuanceNotification{
+			Name:         crt.Name,
+			Namespace:    crt.Namespace,
+			SecretName:   "output",
+			Revision:     2,
+			Renewal:      true,
+			SerialNumber: bundle.Cert.SerialNumber.String(),
+			NotBefore:    bundle.Cert.NotBefore.UTC(),
+			NotAfter:     bundle.Cert.NotAfter.UTC(),
+			Fingerprint:  utilpki.SHA256Fingerprint(bundle.Cert),
+		}, notification)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the issuance webhook to be notified")
+	}
+}
+
+func TestIssueCertificateSecretNotOwned(t *testing.T) {
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateGeneration(1),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCrt, fixedClock)
+
+	tests := map[string]struct {
+		annotations map[string]string
+
+		expIssued bool
+	}{
+		"if the Secret holds data not written by cert-manager, refuse to overwrite it": {
+			expIssued: false,
+		},
+		"if the Certificate adopts the Secret, overwrite it": {
+			annotations: map[string]string{cmapi.AdoptSecretAnnotation: "true"},
+			expIssued:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.CertificateFrom(baseCrt, gen.SetCertificateAnnotations(test.annotations))
+			foreignSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: "output"},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+				Type:       corev1.SecretTypeOpaque,
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{crt},
+				KubeObjects:        []runtime.Object{foreignSecret},
+			}
+			builder.Init()
+			defer builder.Stop()
+			appliedSecrets := recordAppliedSecrets(builder)
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+			builder.Start()
+
+			ctx := context.Background()
+			err = w.controller.issueCertificate(ctx, 2, crt, bundle.CertificateRequestReady, bundle.PrivateKey)
+			require.NoError(t, err)
+
+			gotCrt, err := builder.CMClient.CertmanagerV1().Certificates(crt.Namespace).Get(ctx, crt.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			if test.expIssued {
+				require.Len(t, appliedSecrets(), 1)
+				assert.Equal(t, bundle.CertBytes, appliedSecrets()[0].Data[corev1.TLSCertKey])
+				assert.Equal(t, 2, *gotCrt.Status.Revision)
+				assert.Nil(t, apiutil.GetCertificateCondition(gotCrt, cmapi.CertificateConditionIssuing))
+				return
+			}
+
+			assert.Empty(t, appliedSecrets(), "expected the Secret to be left alone")
+			assert.Equal(t, 1, *gotCrt.Status.Revision)
+			cond := apiutil.GetCertificateCondition(gotCrt, cmapi.CertificateConditionIssuing)
+			require.NotNil(t, cond)
+			assert.Equal(t, cmmeta.ConditionFalse, cond.Status)
+			assert.Equal(t, "SecretNotOwned", cond.Reason)
+			assert.Contains(t, cond.Message, cmapi.AdoptSecretAnnotation)
+			require.NotNil(t, gotCrt.Status.FailedIssuanceAttempts)
+			assert.Equal(t, 1, *gotCrt.Status.FailedIssuanceAttempts)
+			require.Len(t, builder.Events(), 1)
+			assert.Contains(t, builder.Events()[0], "Warning SecretNotOwned")
+		})
+	}
+}
+
+func TestTemporaryCertificateSecretNotOwned(t *testing.T) {
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateGeneration(1),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName("next-private-key"),
+		gen.AddCertificateAnnotations(map[string]string{cmapi.IssueTemporaryCertificateAnnotation: "true"}),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCrt, fixedClock)
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	crt := gen.CertificateFrom(baseCrt,
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 1,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	foreignSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: "output"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+		Type:       corev1.SecretTypeOpaque,
+	}
+
+	builder := &testpkg.Builder{
+		T:     t,
+		Clock: fixedClock,
+		CertManagerObjects: []runtime.Object{crt,
+			gen.CertificateRequestFrom(bundle.CertificateRequestPending,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmapi.CertificateRequestRevisionAnnotationKey: "2",
+				}),
+			),
+		},
+		KubeObjects: []runtime.Object{foreignSecret,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: "next-private-key"},
+				Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes},
+			},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+	appliedSecrets := recordAppliedSecrets(builder)
+
+	w := controllerWrapper{}
+	_, _, err := w.Register(builder.Context)
+	require.NoError(t, err)
+	w.controller.localTemporarySigner = testLocalTemporarySignerFn(bundle.LocalTemporaryCertificateBytes)
+	builder.Start()
+
+	ctx := context.Background()
+	key, err := cache.MetaNamespaceKeyFunc(crt)
+	require.NoError(t, err)
+	// The failure is recorded on the Certificate rather than returned, so
+	// that the Certificate is not requeued until it adopts the Secret.
+	require.NoError(t, w.controller.ProcessItem(ctx, key))
+
+	gotCrt, err := builder.CMClient.CertmanagerV1().Certificates(crt.Namespace).Get(ctx, crt.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.Empty(t, appliedSecrets(), "expected the Secret to be left alone")
+	cond := apiutil.GetCertificateCondition(gotCrt, cmapi.CertificateConditionIssuing)
+	require.NotNil(t, cond)
+	assert.Equal(t, cmmeta.ConditionFalse, cond.Status)
+	assert.Equal(t, "SecretNotOwned", cond.Reason)
+	assert.Contains(t, cond.Message, cmapi.AdoptSecretAnnotation)
+	require.Len(t, builder.Events(), 1)
+	assert.Contains(t, builder.Events()[0], "Warning SecretNotOwned")
+}
+
+// recordAppliedSecrets returns a func listing the Secrets written by Apply
+// calls, which the fake clientset does not support itself.
+func recordAppliedSecrets(builder *testpkg.Builder) func() []corev1.Secret {
+	var (
+		lock    sync.Mutex
+		applied []corev1.Secret
+	)
+	builder.FakeKubeClient().PrependReactor("patch", "secrets", func(action coretesting.Action) (bool, runtime.Object, error) {
+		patch := action.(coretesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		var secret corev1.Secret
+		if err := json.Unmarshal(patch.GetPatch(), &secret); err != nil {
+			return true, nil, err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		applied = append(applied, secret)
+		return true, &secret, nil
+	})
+	return func() []corev1.Secret {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]corev1.Secret(nil), applied...)
+	}
+}
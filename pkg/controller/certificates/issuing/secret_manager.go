@@ -21,12 +21,12 @@ import (
 	"errors"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
@@ -57,18 +57,20 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 	// If there is no certificate or private key data available at the target
 	// Secret then exit early. The absense of these keys should cause an issuance
 	// of the Certificate, so there is no need to run post issuance checks.
+	// Certificates whose private key is not managed never store a private key.
+	keys := internalcertificates.SecretKeysForCertificate(crt)
 	if secret.Data == nil ||
-		len(secret.Data[corev1.TLSCertKey]) == 0 ||
-		len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		len(secret.Data[keys.Certificate]) == 0 ||
+		(len(secret.Data[keys.PrivateKey]) == 0 && !certificates.PrivateKeyIsUnmanaged(crt)) {
 		log.V(logf.DebugLevel).Info("secret doesn't contain both certificate and private key data",
-			"cert_data_len", len(secret.Data[corev1.TLSCertKey]), "key_data_len", len(secret.Data[corev1.TLSPrivateKeyKey]))
+			"cert_data_len", len(secret.Data[keys.Certificate]), "key_data_len", len(secret.Data[keys.PrivateKey]))
 		return nil
 	}
 
 	data := internal.SecretData{
-		PrivateKey:  secret.Data[corev1.TLSPrivateKeyKey],
-		Certificate: secret.Data[corev1.TLSCertKey],
-		CA:          secret.Data[cmmeta.TLSCAKey],
+		PrivateKey:  secret.Data[keys.PrivateKey],
+		Certificate: secret.Data[keys.Certificate],
+		CA:          secret.Data[keys.CA],
 	}
 
 	// Check whether the Certificate's Secret has correct output format and
@@ -92,7 +94,14 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 
 			// Here the Certificate need to be re-reconciled.
 			log.Info("applying Secret data", "message", message)
-			return c.secretsUpdateData(ctx, crt, data)
+			err := c.secretsUpdateData(ctx, crt, data)
+			// A Secret whose data was not written by cert-manager is only
+			// overwritten on issuance, which reports why it is refused.
+			if errors.Is(err, internal.ErrSecretNotOwned) {
+				log.V(logf.DebugLevel).Info("not applying Secret data", "reason", err.Error())
+				return nil
+			}
+			return err
 		}
 	}
 
@@ -19,6 +19,7 @@ package issuing
 import (
 	"context"
 	"encoding/pem"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,6 +63,9 @@ func Test_ensureSecretData(t *testing.T) {
 
 		// enableOwnerRef is passed to the post issuance policy checks.
 		enableOwnerRef bool
+
+		// updateDataErr is returned when the controller reconciles the Secret.
+		updateDataErr error
 	}{
 		"if 'key' is empty, should do nothing and not error": {
 			expectedAction: false,
@@ -157,6 +161,28 @@ func Test_ensureSecretData(t *testing.T) {
 			secret:         nil,
 			expectedAction: false,
 		},
+		"if the Secret holds data not written by cert-manager, the refused reconcile should not error": {
+			key: "test-namespace/test-name",
+			cert: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+				Spec: cmapi.CertificateSpec{
+					SecretName:     "test-secret",
+					SecretTemplate: &cmapi.CertificateSecretTemplate{Labels: map[string]string{"abc": "123"}},
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse}},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret"},
+				Data: map[string][]byte{
+					"tls.crt": cert,
+					"tls.key": pk,
+				},
+			},
+			updateDataErr:  fmt.Errorf("%w: test", internal.ErrSecretNotOwned),
+			expectedAction: true,
+		},
 		"if Certificate exists in a false Issuing condition, Secret exists and matches the SecretTemplate but no managed fields, should reconcile Secret": {
 			key: "test-namespace/test-name",
 			cert: &cmapi.Certificate{
@@ -521,7 +547,7 @@ func Test_ensureSecretData(t *testing.T) {
 			var actionCalled bool
 			w.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, _ internal.SecretData) error {
 				actionCalled = true
-				return nil
+				return test.updateDataErr
 			}
 			w.postIssuancePolicyChain = policies.NewSecretPostIssuancePolicyChain(test.enableOwnerRef, fieldManager)
 
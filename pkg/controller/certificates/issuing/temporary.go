@@ -31,6 +31,7 @@ import (
 
 // ensureTemporaryCertificate will create a temporary certificate and store it
 // into the target Secret if:
+// - Temporary certificates have not been disabled
 // - The temporary certificate annotation is present
 // - The target Secret does not exist yet, or the certificate/key data there is not valid
 // - If the Certificate/Key pair does not match the 'NextPrivateKey'
@@ -41,8 +42,9 @@ func (c *controller) ensureTemporaryCertificate(ctx context.Context, crt *cmapi.
 		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
 	}
 
-	// If certificate does not have temporary certificate annotation, do nothing
-	if !certificateHasTemporaryCertificateAnnotation(crt) {
+	// If temporary certificates are disabled or the certificate does not have
+	// the temporary certificate annotation, do nothing
+	if c.disableTemporaryCertificates || !certificateHasTemporaryCertificateAnnotation(crt) {
 		return false, nil
 	}
 
@@ -51,7 +53,7 @@ func (c *controller) ensureTemporaryCertificate(ctx context.Context, crt *cmapi.
 	if err != nil && !apierrors.IsNotFound(err) {
 		return false, err
 	}
-	input := policies.Input{Secret: secret}
+	input := policies.Input{Certificate: crt, Secret: secret}
 	// If the target Secret exists with a signed certificate and matching private
 	// key, do not issue.
 	if _, _, invalid := policies.NewTemporaryCertificatePolicyChain().Evaluate(input); !invalid {
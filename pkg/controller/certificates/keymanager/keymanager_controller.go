@@ -68,6 +68,10 @@ type controller struct {
 	coreClient        kubernetes.Interface
 	recorder          record.EventRecorder
 
+	// privateKeyDefaulter applies the default private key parameters of the
+	// Certificate's issuer.
+	privateKeyDefaulter *certificates.PrivateKeyDefaulter
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -152,6 +156,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	crt = c.privateKeyDefaulter.WithDefaults(crt)
 
 	// Discover all 'owned' secrets that have the `next-private-key` label
 	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), isNextPrivateKeyLabelSelector, predicate.ResourceOwnedBy(crt))
@@ -170,6 +175,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.setNextPrivateKeySecretName(ctx, crt, nil)
 	}
 
+	// Certificates which do not manage their private key have their CSR
+	// supplied by the user, so a private key must never be generated.
+	if certificates.PrivateKeyIsUnmanaged(crt) {
+		log.V(logf.DebugLevel).Info("Cleaning up Secret resources and unsetting nextPrivateKeySecretName as the private key is not managed by cert-manager")
+		if err := c.deleteSecretResources(ctx, secrets); err != nil {
+			return err
+		}
+		return c.setNextPrivateKeySecretName(ctx, crt, nil)
+	}
+
 	// if there is no existing Secret resource, create a new one
 	if len(secrets) == 0 {
 		rotationPolicy := cmapi.RotationPolicyNever
@@ -243,11 +258,14 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 	if err != nil {
 		return err
 	}
-	if s.Data == nil || len(s.Data[corev1.TLSPrivateKeyKey]) == 0 {
+	// The private key is read from the same key of the Secret that the
+	// issuing controller stores it in.
+	pkKey := internalcertificates.SecretKeysForCertificate(crt).PrivateKey
+	if s.Data == nil || len(s.Data[pkKey]) == 0 {
 		log.V(logf.DebugLevel).Info("Creating new nextPrivateKeySecretName Secret because existing Secret contains empty data and rotation policy is Never")
 		return c.createAndSetNextPrivateKey(ctx, crt)
 	}
-	existingPKData := s.Data[corev1.TLSPrivateKeyKey]
+	existingPKData := s.Data[pkKey]
 	pk, err := pki.DecodePrivateKeyBytes(existingPKData)
 	if err != nil {
 		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonDecodeFailed, "Failed to decode private key stored in Secret %q - generating new key", crt.Spec.SecretName)
@@ -386,6 +404,9 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.Recorder,
 		ctx.FieldManager,
 	)
+	defaulter, defaulterMustSync := certificates.NewPrivateKeyDefaulter(ctx.SharedInformerFactory, ctx.Namespace)
+	ctrl.privateKeyDefaulter = defaulter
+	mustSync = append(mustSync, defaulterMustSync...)
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -77,6 +77,25 @@ func relaxedSecretMatcher(l coretesting.Action, r coretesting.Action) error {
 }
 
 func TestProcessItem(t *testing.T) {
+	// ecdsaDefaultIssuer is an Issuer whose Certificates default to ECDSA
+	// P-384 private keys.
+	ecdsaDefaultIssuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "ca-issuer"},
+		Spec: cmapi.IssuerSpec{
+			IssuerConfig: cmapi.IssuerConfig{
+				CA: &cmapi.CAIssuer{SecretName: "ca"},
+			},
+			DefaultPrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+				Algorithm: cmapi.ECDSAKeyAlgorithm,
+				Size:      384,
+			},
+		},
+	}
+
+	// existingKey is stored under a custom key name in the Secret of a
+	// Certificate which never rotates its private key.
+	existingKey := mustGenerateECDSA(t, pki.ECCurve256)
+
 	ownedSecretWithName := func(namespace, name, owner string, data map[string][]byte) *corev1.Secret {
 		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -105,6 +124,9 @@ func TestProcessItem(t *testing.T) {
 
 		secrets []runtime.Object
 
+		// issuers, if set, will exist in the apiserver before the test is run.
+		issuers []runtime.Object
+
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []*cmapi.CertificateRequest
 
@@ -281,6 +303,54 @@ func TestProcessItem(t *testing.T) {
 				)),
 			},
 		},
+		"delete owned secrets and unset status.nextPrivateKeySecretName if the private key is not managed": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Spec: cmapi.CertificateSpec{
+					PrivateKey:   &cmapi.CertificatePrivateKey{Managed: pointer.Bool(false)},
+					CSRSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"}},
+				},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: pointer.StringPtr("fixed-name"),
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", nil),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					"fixed-name",
+				)),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					&cmapi.Certificate{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+						Spec: cmapi.CertificateSpec{
+							PrivateKey:   &cmapi.CertificatePrivateKey{Managed: pointer.Bool(false)},
+							CSRSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "csr"}},
+						},
+						Status: cmapi.CertificateStatus{
+							Conditions: []cmapi.CertificateCondition{
+								{
+									Type:   cmapi.CertificateConditionIssuing,
+									Status: cmmeta.ConditionTrue,
+								},
+							},
+						},
+					},
+				)),
+			},
+		},
 		// TODO: change this behaviour to not delete the named nextPrivateKeySecretName
 		"if multiple owned secrets exist, delete them all even if one is the named Secret": {
 			certificate: &cmapi.Certificate{
@@ -461,6 +531,129 @@ func TestProcessItem(t *testing.T) {
 				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
 			},
 		},
+		"if an owned secret contains a key matching the issuer's default private key, do nothing": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Spec: cmapi.CertificateSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "ca-issuer"},
+				},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: pointer.StringPtr("fixed-name"),
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			issuers: []runtime.Object{ecdsaDefaultIssuer},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateECDSA(t, pki.ECCurve384)}),
+			},
+		},
+		"if the Certificate sets an algorithm other than the issuer's default, the Certificate's algorithm is used": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Spec: cmapi.CertificateSpec{
+					IssuerRef:  cmmeta.ObjectReference{Name: "ca-issuer"},
+					PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm},
+				},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: pointer.StringPtr("fixed-name"),
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			issuers: []runtime.Object{ecdsaDefaultIssuer},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateECDSA(t, pki.ECCurve384)}),
+			},
+			expectedEvents: []string{"Normal Deleted Regenerating private key due to change in fields: [spec.keyAlgorithm]"},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					"fixed-name",
+				)),
+			},
+		},
+		"reuse the existing private key stored under the Certificate's private key name if the rotation policy is Never": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "test-tls",
+					SecretKeys: &cmapi.CertificateSecretKeys{PrivateKey: "custom.key"},
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						Algorithm:      cmapi.ECDSAKeyAlgorithm,
+						Size:           256,
+						Encoding:       cmapi.PKCS8,
+						RotationPolicy: cmapi.RotationPolicyNever,
+					},
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+					Data:       map[string][]byte{"custom.key": existingKey},
+				},
+			},
+			expectedEvents: []string{`Normal Reused Reusing private key stored in existing Secret resource "test-tls"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
+						},
+						Data: map[string][]byte{"tls.key": existingKey},
+					},
+				)),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					&cmapi.Certificate{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+						Spec: cmapi.CertificateSpec{
+							SecretName: "test-tls",
+							SecretKeys: &cmapi.CertificateSecretKeys{PrivateKey: "custom.key"},
+							PrivateKey: &cmapi.CertificatePrivateKey{
+								Algorithm:      cmapi.ECDSAKeyAlgorithm,
+								Size:           256,
+								Encoding:       cmapi.PKCS8,
+								RotationPolicy: cmapi.RotationPolicyNever,
+							},
+						},
+						Status: cmapi.CertificateStatus{
+							NextPrivateKeySecretName: pointer.StringPtr("test-notrandom"),
+							Conditions: []cmapi.CertificateCondition{
+								{
+									Type:   cmapi.CertificateConditionIssuing,
+									Status: cmmeta.ConditionTrue,
+								},
+							},
+						},
+					},
+				)),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -480,6 +673,7 @@ func TestProcessItem(t *testing.T) {
 			for _, req := range test.requests {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, req)
 			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuers...)
 			builder.Init()
 
 			// Register informers used by the controller using the registration wrapper
@@ -18,17 +18,22 @@ package metrics
 
 import (
 	"context"
+	"crypto/x509"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 const (
@@ -47,6 +52,7 @@ type controllerWrapper struct {
 // 'delete' events which will update the metrics for that Certificate.
 type controller struct {
 	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
 
 	metrics *metrics.Metrics
 }
@@ -61,10 +67,13 @@ func NewController(
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
 
 	// Reconcile over all Certificate events. We do _not_ reconcile on Secret
 	// events that are related to Certificates. It is the responsibility of the
-	// Certificates controllers to update accordingly.
+	// Certificates controllers to update accordingly. Secrets are only read
+	// to expose metrics about the issued certificate chain, which changes
+	// together with the Certificate's status.
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
 	// build a list of InformerSynced functions that will be returned by the
@@ -72,10 +81,12 @@ func NewController(
 	// of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
 	}
 
 	return &controller{
 		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretsInformer.Lister(),
 		metrics:           metrics,
 	}, queue, mustSync
 }
@@ -103,6 +114,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	// Update that Certificates metrics
 	c.metrics.UpdateCertificate(ctx, crt)
 
+	// Update the metrics of the certificate chain stored in the Secret. A
+	// missing Secret or one that does not hold a valid chain yet means there
+	// is no issued certificate to report on.
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	var chain []*x509.Certificate
+	certKey := internalcertificates.SecretKeysForCertificate(crt).Certificate
+	if secret != nil && len(secret.Data[certKey]) > 0 {
+		chain, err = pki.DecodeX509CertificateChainBytes(secret.Data[certKey])
+		if err != nil {
+			log := logf.WithRelatedResource(logf.FromContext(ctx), secret)
+			log.V(logf.DebugLevel).Info("failed to decode certificate chain stored in Secret", "error", err)
+		}
+	}
+	c.metrics.UpdateCertificateChain(crt, chain)
+
 	return nil
 }
 
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+)
+
+// PrivateKeyDefaulter applies the default private key parameters declared by
+// the issuer of a Certificate to the Certificate.
+// It must be used by every controller which generates, checks or encodes a
+// Certificate's private key, so that they all agree on the key parameters.
+type PrivateKeyDefaulter struct {
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+}
+
+// NewPrivateKeyDefaulter returns a PrivateKeyDefaulter reading issuers from
+// the given informer factory, along with the InformerSynced functions of the
+// informers it uses. ClusterIssuers are only read if cert-manager is not
+// scoped to a single namespace.
+func NewPrivateKeyDefaulter(cmFactory cminformers.SharedInformerFactory, namespace string) (*PrivateKeyDefaulter, []cache.InformerSynced) {
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	d := &PrivateKeyDefaulter{
+		issuerLister: issuerInformer.Lister(),
+	}
+	mustSync := []cache.InformerSynced{issuerInformer.Informer().HasSynced}
+
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		d.clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return d, mustSync
+}
+
+// WithDefaults returns the Certificate with the default private key
+// parameters of the issuer referenced by its `spec.issuerRef` applied.
+// The defaults of fallback issuers are never used, so that failing over to
+// another issuer does not change the private key.
+// The Certificate is returned unchanged if its issuer is not a cert-manager
+// issuer, cannot be found or declares no defaults. The returned Certificate
+// must never be used to update the Certificate's spec.
+func (d *PrivateKeyDefaulter) WithDefaults(crt *cmapi.Certificate) *cmapi.Certificate {
+	if d == nil {
+		return crt
+	}
+
+	ref := crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return crt
+	}
+
+	var spec *cmapi.IssuerSpec
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		iss, err := d.issuerLister.Issuers(crt.Namespace).Get(ref.Name)
+		if err != nil {
+			return crt
+		}
+		spec = &iss.Spec
+	case cmapi.ClusterIssuerKind:
+		if d.clusterIssuerLister == nil {
+			return crt
+		}
+		iss, err := d.clusterIssuerLister.Get(ref.Name)
+		if err != nil {
+			return crt
+		}
+		spec = &iss.Spec
+	default:
+		return crt
+	}
+
+	return ApplyPrivateKeyDefaults(crt, spec.DefaultPrivateKey)
+}
+
+// ApplyPrivateKeyDefaults returns a copy of the Certificate with the given
+// default private key parameters applied to the ones it does not set.
+// The default algorithm and size are only applied together, to a
+// Certificate which sets neither an algorithm nor a size, or which sets the
+// same algorithm as the defaults but no size; otherwise they could describe
+// an invalid key, such as an RSA key of 256 bits.
+// If defaults is nil the Certificate itself is returned.
+func ApplyPrivateKeyDefaults(crt *cmapi.Certificate, defaults *cmapi.IssuerPrivateKeyDefaults) *cmapi.Certificate {
+	if defaults == nil {
+		return crt
+	}
+
+	crt = crt.DeepCopy()
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+	pk := crt.Spec.PrivateKey
+
+	if pk.Size == 0 {
+		switch pk.Algorithm {
+		case "":
+			pk.Algorithm = defaults.Algorithm
+			pk.Size = defaults.Size
+		case defaults.Algorithm:
+			pk.Size = defaults.Size
+		}
+	}
+
+	if pk.Encoding == "" {
+		pk.Encoding = defaults.Encoding
+	}
+
+	return crt
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestApplyPrivateKeyDefaults(t *testing.T) {
+	ecdsaDefaults := &cmapi.IssuerPrivateKeyDefaults{
+		Algorithm: cmapi.ECDSAKeyAlgorithm,
+		Size:      384,
+		Encoding:  cmapi.PKCS8,
+	}
+
+	tests := map[string]struct {
+		privateKey *cmapi.CertificatePrivateKey
+		defaults   *cmapi.IssuerPrivateKeyDefaults
+		expected   *cmapi.CertificatePrivateKey
+	}{
+		"no defaults leaves the private key unset": {
+			privateKey: nil,
+			defaults:   nil,
+			expected:   nil,
+		},
+		"defaults are used when the Certificate sets no private key": {
+			privateKey: nil,
+			defaults:   ecdsaDefaults,
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, Encoding: cmapi.PKCS8},
+		},
+		"defaults are used alongside other private key fields": {
+			privateKey: &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyAlways},
+			defaults:   ecdsaDefaults,
+			expected:   &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyAlways, Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, Encoding: cmapi.PKCS8},
+		},
+		"the default size is used when the Certificate sets the same algorithm": {
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			defaults:   ecdsaDefaults,
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, Encoding: cmapi.PKCS8},
+		},
+		"the default size is not used when the Certificate sets another algorithm": {
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm},
+			defaults:   ecdsaDefaults,
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Encoding: cmapi.PKCS8},
+		},
+		"the defaults are not used when the Certificate sets a size": {
+			privateKey: &cmapi.CertificatePrivateKey{Size: 4096},
+			defaults:   ecdsaDefaults,
+			expected:   &cmapi.CertificatePrivateKey{Size: 4096, Encoding: cmapi.PKCS8},
+		},
+		"the Certificate's encoding is kept": {
+			privateKey: &cmapi.CertificatePrivateKey{Encoding: cmapi.PKCS1},
+			defaults:   ecdsaDefaults,
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, Encoding: cmapi.PKCS1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test")
+			crt.Spec.PrivateKey = test.privateKey
+			original := crt.DeepCopy()
+
+			got := ApplyPrivateKeyDefaults(crt, test.defaults)
+			assert.Equal(t, test.expected, got.Spec.PrivateKey)
+			assert.Equal(t, original, crt, "the Certificate passed in must not be modified")
+		})
+	}
+}
@@ -18,10 +18,10 @@ package readiness
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +30,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
@@ -43,6 +44,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
@@ -53,6 +55,8 @@ const (
 	ControllerName = "certificates-readiness"
 	// ReadyReason is the 'Ready' reason of a Certificate.
 	ReadyReason = "Ready"
+	// RenewalFailingReason is the 'ExpirationImminent' reason of a Certificate.
+	RenewalFailingReason = "RenewalFailing"
 )
 
 type controller struct {
@@ -66,7 +70,18 @@ type controller struct {
 	// policyEvaluator builds Ready condition of a Certificate based on policy evaluation
 	policyEvaluator policyEvaluatorFunc
 	// renewalTimeCalculator calculates renewal time of a certificate
-	renewalTimeCalculator certificates.RenewalTimeFunc
+	renewalTimeCalculator certificates.CertificateRenewalTimeFunc
+
+	// used to re-evaluate Certificates once their certificate becomes valid
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+	clock              clock.Clock
+	// notBeforeTolerance is how far in the future the NotBefore time of a
+	// certificate may be before the NotYetValid condition is set
+	notBeforeTolerance time.Duration
+	// expirationImminentWindow is how close to its expiry a certificate whose
+	// renewal is failing must be for the ExpirationImminent condition to be
+	// set. Zero disables the condition.
+	expirationImminentWindow time.Duration
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -83,10 +98,14 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	namespace string,
 	chain policies.Chain,
-	renewalTimeCalculator certificates.RenewalTimeFunc,
+	renewalTimeCalculator certificates.CertificateRenewalTimeFunc,
 	policyEvaluator policyEvaluatorFunc,
 	fieldManager string,
+	clock clock.Clock,
+	notBeforeTolerance time.Duration,
+	expirationImminentWindow time.Duration,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
 	// create a queue used to queue up items to be processed
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
@@ -95,6 +114,7 @@ func NewController(
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := factory.Core().V1().Secrets()
+	configMapsInformer := certificates.DNSNamesConfigMapsInformer(factory, namespace)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
@@ -109,11 +129,18 @@ func NewController(
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
 	})
 
+	// When a ConfigMap resource changes, enqueue any Certificate resources that name it as spec.dnsNamesConfigMapRef.name.
+	configMapsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateDNSNamesConfigMapName)),
+	})
+
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
+		configMapsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
@@ -126,10 +153,15 @@ func NewController(
 		gatherer: &policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
+			ConfigMapLister:          configMapsInformer.Lister(),
 		},
-		policyEvaluator:       policyEvaluator,
-		renewalTimeCalculator: renewalTimeCalculator,
-		fieldManager:          fieldManager,
+		policyEvaluator:          policyEvaluator,
+		renewalTimeCalculator:    renewalTimeCalculator,
+		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		clock:                    clock,
+		notBeforeTolerance:       notBeforeTolerance,
+		expirationImminentWindow: expirationImminentWindow,
+		fieldManager:             fieldManager,
 	}, queue, mustSync
 }
 
@@ -156,6 +188,18 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	input, err := c.gatherer.DataForCertificate(ctx, crt)
+	if certificates.IsDNSNamesConfigMapError(err) {
+		// Readiness cannot be evaluated until the ConfigMap is fixed, which
+		// causes the Certificate to be processed again.
+		log.V(logf.DebugLevel).Info("cannot evaluate readiness", "error", err.Error())
+		oldCrt := crt
+		crt = crt.DeepCopy()
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionReady, cmmeta.ConditionFalse, certificates.DNSNamesConfigMapErrorReason, err.Error())
+		if !apiequality.Semantic.DeepEqual(oldCrt.Status, crt.Status) {
+			return c.updateOrApplyStatus(ctx, crt)
+		}
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -167,40 +211,157 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 
 	switch {
 	case input.Secret != nil && input.Secret.Data != nil:
-		x509cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
+		chain, err := pki.DecodeX509CertificateChainBytes(input.Secret.Data[internalcertificates.SecretKeysForCertificate(crt).Certificate])
 		if err != nil {
 			// clear status fields if we cannot decode the certificate bytes
 			crt.Status.NotAfter = nil
 			crt.Status.NotBefore = nil
 			crt.Status.RenewalTime = nil
+			crt.Status.EmbeddedSCTCount = nil
+			crt.Status.Fingerprint = ""
+			crt.Status.ChainFingerprints = nil
+			crt.Status.IssuerSubject = ""
+			crt.Status.IssuerSerialNumber = ""
+			apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionExpirationImminent)
+			apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionNotYetValid)
 			break
 		}
 
+		x509cert := chain[0]
 		notBefore := metav1.NewTime(x509cert.NotBefore)
 		notAfter := metav1.NewTime(x509cert.NotAfter)
-		renewBeforeHint := crt.Spec.RenewBefore
-		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, x509cert.NotAfter, renewBeforeHint)
+		renewalTime := c.renewalTimeCalculator(crt, x509cert.NotBefore, x509cert.NotAfter)
 
 		//update Certificate's Status
 		crt.Status.NotBefore = &notBefore
 		crt.Status.NotAfter = &notAfter
 		crt.Status.RenewalTime = renewalTime
 
+		// Report how many Signed Certificate Timestamps the certificate embeds.
+		// A malformed SCT list is not fatal as the field is informational only.
+		if sctCount, err := pki.EmbeddedSCTCount(x509cert); err != nil {
+			log.V(logf.WarnLevel).Info("failed to count embedded SCTs of certificate", "error", err.Error())
+			crt.Status.EmbeddedSCTCount = nil
+		} else {
+			crt.Status.EmbeddedSCTCount = &sctCount
+		}
+
+		crt.Status.Fingerprint = pki.SHA256Fingerprint(x509cert)
+		crt.Status.ChainFingerprints = nil
+		for _, cert := range chain[1:] {
+			crt.Status.ChainFingerprints = append(crt.Status.ChainFingerprints, pki.SHA256Fingerprint(cert))
+		}
+
+		// Report which CA signed the certificate. The CA certificate is
+		// usually included in the chain, but may only be stored as the CA of
+		// the Secret, and a self-signed certificate is its own CA.
+		candidates := chain[1:]
+		if caData := input.Secret.Data[internalcertificates.SecretKeysForCertificate(crt).CA]; len(caData) > 0 {
+			if cas, err := pki.DecodeX509CertificateChainBytes(caData); err == nil {
+				candidates = append(candidates, cas...)
+			}
+		}
+		if ca := pki.IssuingCertificate(x509cert, append(candidates, x509cert)...); ca != nil {
+			crt.Status.IssuerSubject = ca.Subject.String()
+			crt.Status.IssuerSerialNumber = pki.HexSerialNumber(ca.SerialNumber)
+		} else {
+			crt.Status.IssuerSubject = ""
+			crt.Status.IssuerSerialNumber = ""
+		}
+
+		imminentIn := c.updateExpirationImminentCondition(crt, x509cert.NotAfter)
+
+		validIn := c.updateNotYetValidCondition(crt, x509cert.NotBefore)
+
+		// If the certificate has more than the minimum remaining validity,
+		// re-evaluate readiness once it no longer does.
+		var tooShortIn time.Duration
+		if minRemainingValidity := crt.Spec.MinRemainingValidity; minRemainingValidity != nil {
+			tooShortIn = x509cert.NotAfter.Add(-minRemainingValidity.Duration).Sub(c.clock.Now())
+		}
+
+		c.scheduleReevaluation(key, imminentIn, validIn, tooShortIn)
+
 	default:
 		// clear status fields if the secret does not have any data
 		crt.Status.NotAfter = nil
 		crt.Status.NotBefore = nil
 		crt.Status.RenewalTime = nil
+		crt.Status.EmbeddedSCTCount = nil
+		crt.Status.Fingerprint = ""
+		crt.Status.ChainFingerprints = nil
+		crt.Status.IssuerSubject = ""
+		crt.Status.IssuerSerialNumber = ""
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionExpirationImminent)
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionNotYetValid)
 	}
 	if !apiequality.Semantic.DeepEqual(oldCrt.Status, crt.Status) {
 		log.V(logf.DebugLevel).Info("updating status fields", "notAfter",
 			crt.Status.NotAfter, "notBefore", crt.Status.NotBefore, "renewalTime",
-			crt.Status.RenewalTime)
+			crt.Status.RenewalTime, "embeddedSCTCount", crt.Status.EmbeddedSCTCount,
+			"fingerprint", crt.Status.Fingerprint)
 		return c.updateOrApplyStatus(ctx, crt)
 	}
 	return nil
 }
 
+// scheduleReevaluation schedules the Certificate to be re-evaluated after the
+// shortest of the given positive durations. Scheduling a Certificate replaces
+// any previous schedule, so only the earliest time is scheduled; the later
+// times are scheduled again when the Certificate is re-evaluated.
+func (c *controller) scheduleReevaluation(key string, durations ...time.Duration) {
+	var earliest time.Duration
+	for _, d := range durations {
+		if d > 0 && (earliest == 0 || d < earliest) {
+			earliest = d
+		}
+	}
+	if earliest > 0 {
+		c.scheduledWorkQueue.Add(key, earliest)
+	}
+}
+
+// updateNotYetValidCondition sets the NotYetValid condition if the NotBefore
+// time of the certificate is further in the future than the configured
+// tolerance, which allows for clock skew between cert-manager and the issuing
+// CA. Otherwise the condition is removed. The Ready condition is not affected.
+// If the condition is set, the time until it should be removed is returned.
+func (c *controller) updateNotYetValidCondition(crt *cmapi.Certificate, notBefore time.Time) time.Duration {
+	validIn := notBefore.Sub(c.clock.Now()) - c.notBeforeTolerance
+	if validIn <= 0 {
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionNotYetValid)
+		return 0
+	}
+
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionNotYetValid, cmmeta.ConditionTrue,
+		policies.NotYetValid, fmt.Sprintf("Certificate is not valid until %s", notBefore.Format(time.RFC1123)))
+	return validIn
+}
+
+// updateExpirationImminentCondition sets the ExpirationImminent condition if
+// the certificate expires within the configured window and the last attempt to
+// renew it failed. Otherwise the condition is removed.
+// If renewal is failing but the certificate has not entered the window yet,
+// the time until it does is returned so that the condition can be set then.
+func (c *controller) updateExpirationImminentCondition(crt *cmapi.Certificate, notAfter time.Time) time.Duration {
+	if c.expirationImminentWindow <= 0 || crt.Status.LastFailureTime == nil {
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionExpirationImminent)
+		return 0
+	}
+
+	// If renewal is still failing once the certificate enters the window, the
+	// condition must be set then even if nothing else about the Certificate
+	// has changed.
+	if imminentIn := notAfter.Add(-c.expirationImminentWindow).Sub(c.clock.Now()); imminentIn > 0 {
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionExpirationImminent)
+		return imminentIn
+	}
+
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionExpirationImminent, cmmeta.ConditionTrue,
+		RenewalFailingReason, fmt.Sprintf("Certificate expires at %s and the last attempt to renew it failed", notAfter.UTC().Format(time.RFC3339)))
+	return 0
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -208,15 +369,23 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		var conditions []cmapi.CertificateCondition
 		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
-			conditions = []cmapi.CertificateCondition{*cond}
+			conditions = append(conditions, *cond)
+		}
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionExpirationImminent); cond != nil {
+			conditions = append(conditions, *cond)
 		}
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
 			Status: cmapi.CertificateStatus{
-				NotAfter:    crt.Status.NotAfter,
-				NotBefore:   crt.Status.NotBefore,
-				RenewalTime: crt.Status.RenewalTime,
-				Conditions:  conditions,
+				NotAfter:           crt.Status.NotAfter,
+				NotBefore:          crt.Status.NotBefore,
+				RenewalTime:        crt.Status.RenewalTime,
+				EmbeddedSCTCount:   crt.Status.EmbeddedSCTCount,
+				Fingerprint:        crt.Status.Fingerprint,
+				ChainFingerprints:  crt.Status.ChainFingerprints,
+				IssuerSubject:      crt.Status.IssuerSubject,
+				IssuerSerialNumber: crt.Status.IssuerSerialNumber,
+				Conditions:         conditions,
 			},
 		})
 	} else {
@@ -258,10 +427,16 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Namespace,
 		policies.NewReadinessPolicyChain(ctx.Clock),
-		certificates.RenewalTime,
+		func(crt *cmapi.Certificate, notBefore, notAfter time.Time) *metav1.Time {
+			return certificates.RenewalTimeWithJitter(crt, notBefore, notAfter, ctx.CertificateOptions.RenewalJitterPercent)
+		},
 		policyEvaluator,
 		ctx.FieldManager,
+		ctx.Clock,
+		ctx.CertificateOptions.NotBeforeTolerance,
+		ctx.CertificateOptions.ExpirationImminentWindow,
 	)
 	c.controller = ctrl
 
@@ -18,6 +18,16 @@ package readiness
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,11 +37,14 @@ import (
 	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	schedulertest "github.com/cert-manager/cert-manager/pkg/scheduler/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
@@ -44,12 +57,105 @@ func policyEvaluatorBuilder(c cmapi.CertificateCondition) policyEvaluatorFunc {
 }
 
 // renewalTimeBuilder returns a fake renewalTimeFunc for ReadinessController.
-func renewalTimeBuilder(rt *metav1.Time) certificates.RenewalTimeFunc {
-	return func(notBefore, notAfter time.Time, cert *metav1.Duration) *metav1.Time {
+func renewalTimeBuilder(rt *metav1.Time) certificates.CertificateRenewalTimeFunc {
+	return func(crt *cmapi.Certificate, notBefore, notAfter time.Time) *metav1.Time {
 		return rt
 	}
 }
 
+// mustCreateCertWithSCTs returns a self-signed x509 certificate embedding an
+// SCT list extension which holds the given serialized SCTs.
+func mustCreateCertWithSCTs(t *testing.T, pkData []byte, spec *cmapi.Certificate, notBefore, notAfter time.Time, scts [][]byte) []byte {
+	pk, err := pki.DecodePrivateKeyBytes(pkData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := pki.GenerateTemplate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.NotBefore = notBefore
+	template.NotAfter = notAfter
+
+	var entries []byte
+	for _, sct := range scts {
+		entries = append(entries, 0, 0)
+		binary.BigEndian.PutUint16(entries[len(entries)-2:], uint16(len(sct)))
+		entries = append(entries, sct...)
+	}
+	list := make([]byte, 2, 2+len(entries))
+	binary.BigEndian.PutUint16(list, uint16(len(entries)))
+	list = append(list, entries...)
+	value, err := asn1.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: pki.OIDExtensionCTSCTList, Value: value})
+
+	certData, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certData
+}
+
+// manualFingerprint returns the SHA-256 fingerprint of the PEM encoded
+// certificate, formatted like the output of `openssl x509 -fingerprint`.
+func manualFingerprint(t *testing.T, certPEM []byte) string {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatal("failed to decode certificate PEM")
+	}
+	sum := sha256.Sum256(block.Bytes)
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	var pairs []string
+	for i := 0; i < len(digest); i += 2 {
+		pairs = append(pairs, digest[i:i+2])
+	}
+	return strings.Join(pairs, ":")
+}
+
+// mustCreateCASignedCert returns an x509 cert for the Certificate with the
+// provided NotBefore, NotAfter values, signed by a CA which is also returned.
+// The CA's subject is "CN=test-issuing-ca,O=cert-manager" and its serial
+// number is 0x1a2b3c.
+func mustCreateCASignedCert(t *testing.T, pkData []byte, spec *cmapi.Certificate, notBefore, notAfter time.Time) ([]byte, []byte) {
+	caKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(0x1a2b3c),
+		Subject:               pkix.Name{CommonName: "test-issuing-ca", Organization: []string{"cert-manager"}},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caData, caCert, err := pki.SignCertificate(caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, err := pki.DecodePrivateKeyBytes(pkData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.NotBefore = notBefore
+	template.NotAfter = notAfter
+	certData, _, err := pki.SignCertificate(template, caCert, pk.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certData, caData
+}
+
 func TestProcessItem(t *testing.T) {
 	// now time is the current UTC time at the start of the test
 	now := time.Now().UTC()
@@ -101,6 +207,36 @@ func TestProcessItem(t *testing.T) {
 		// renewalTime will be the updated Certificate's status.renewalTime
 		renewalTime *metav1.Time
 
+		// scts will be embedded in the X509 cert if set
+		scts [][]byte
+
+		// embeddedSCTCount will be the updated Certificate's status.embeddedSCTCount
+		embeddedSCTCount *int
+
+		// withChain appends an issuing certificate to the X509 cert if set
+		withChain bool
+
+		// issuingCA, if set, is where the CA which signs the X509 cert is
+		// stored in the secret, either "chain" or "ca.crt". Otherwise the
+		// X509 cert is self-signed.
+		issuingCA string
+
+		// expirationImminentWindow configures the controller's window for
+		// the ExpirationImminent condition
+		expirationImminentWindow time.Duration
+
+		// Certificate's ExpirationImminent condition expected after the
+		// update. If nil, the condition is expected to be absent.
+		expirationImminentCondition *cmapi.CertificateCondition
+
+		// notBeforeTolerance configures the controller's tolerance for the
+		// NotYetValid condition
+		notBeforeTolerance time.Duration
+
+		// Certificate's NotYetValid condition expected after the update. If
+		// nil, the condition is expected to be absent.
+		notYetValidCondition *cmapi.CertificateCondition
+
 		wantsErr bool
 	}{
 		"do nothing if an empty 'key' is used": {},
@@ -124,6 +260,7 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:  func(i int) *int { return &i }(0),
 		},
 		"update status for a Certificate that is evaluated as not Ready and whose spec.secretName secret contains a valid X509 cert": {
 			condition: cmapi.CertificateCondition{
@@ -139,6 +276,186 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:  func(i int) *int { return &i }(0),
+		},
+		"update status with the number of SCTs embedded in the X509 cert": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			scts:              [][]byte{[]byte("sct-1"), []byte("sct-2")},
+			embeddedSCTCount:  func(i int) *int { return &i }(2),
+		},
+		"update status with the fingerprints of the X509 cert and its chain": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:  func(i int) *int { return &i }(0),
+			withChain:         true,
+		},
+		"update status with the issuing CA of the X509 cert found in its chain": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:  func(i int) *int { return &i }(0),
+			issuingCA:         "chain",
+		},
+		"update status with the issuing CA of the X509 cert found in the CA data": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:  func(i int) *int { return &i }(0),
+			issuingCA:         "ca.crt",
+		},
+		"set ExpirationImminent for a Certificate within the window of expiry whose renewal is failing": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:                     gen.CertificateFrom(cert, gen.SetCertificateLastFailureTime(metav1.NewTime(now.Add(-time.Minute)))),
+			certShouldUpdate:         true,
+			secretShouldExist:        true,
+			notAfter:                 func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:                func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:              func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:         func(i int) *int { return &i }(0),
+			expirationImminentWindow: time.Hour * 24,
+			expirationImminentCondition: &cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionExpirationImminent,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             RenewalFailingReason,
+				Message:            "Certificate expires at " + now.Add(time.Hour*2).Truncate(time.Second).Format(time.RFC3339) + " and the last attempt to renew it failed",
+				LastTransitionTime: &metaNow,
+			},
+		},
+		"do not set ExpirationImminent for a Certificate whose renewal is failing but is outside the window of expiry": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:                     gen.CertificateFrom(cert, gen.SetCertificateLastFailureTime(metav1.NewTime(now.Add(-time.Minute)))),
+			certShouldUpdate:         true,
+			secretShouldExist:        true,
+			notAfter:                 func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:                func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:              func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:         func(i int) *int { return &i }(0),
+			expirationImminentWindow: time.Hour,
+		},
+		"set NotYetValid for a Certificate whose NotBefore is further in the future than the tolerance": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:               gen.CertificateFrom(cert),
+			certShouldUpdate:   true,
+			secretShouldExist:  true,
+			notAfter:           func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 3).Truncate(time.Second))),
+			notBefore:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour).Truncate(time.Second))),
+			renewalTime:        func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2))),
+			embeddedSCTCount:   func(i int) *int { return &i }(0),
+			notBeforeTolerance: time.Minute,
+			notYetValidCondition: &cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionNotYetValid,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             policies.NotYetValid,
+				Message:            "Certificate is not valid until " + now.Add(time.Hour).Truncate(time.Second).Format(time.RFC1123),
+				LastTransitionTime: &metaNow,
+			},
+		},
+		"remove NotYetValid from a Certificate whose NotBefore is a few seconds in the future, within the tolerance": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert: gen.CertificateFrom(cert, gen.SetCertificateStatusCondition(
+				cmapi.CertificateCondition{
+					Type:    cmapi.CertificateConditionNotYetValid,
+					Status:  cmmeta.ConditionTrue,
+					Reason:  policies.NotYetValid,
+					Message: "some message",
+				})),
+			certShouldUpdate:   true,
+			secretShouldExist:  true,
+			notAfter:           func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(5 * time.Second).Truncate(time.Second))),
+			renewalTime:        func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:   func(i int) *int { return &i }(0),
+			notBeforeTolerance: time.Minute,
+		},
+		"remove ExpirationImminent from a Certificate once its renewal has succeeded": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert: gen.CertificateFrom(cert, gen.SetCertificateStatusCondition(
+				cmapi.CertificateCondition{
+					Type:    cmapi.CertificateConditionExpirationImminent,
+					Status:  cmmeta.ConditionTrue,
+					Reason:  RenewalFailingReason,
+					Message: "some message",
+				})),
+			certShouldUpdate:         true,
+			secretShouldExist:        true,
+			notAfter:                 func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:                func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:              func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTCount:         func(i int) *int { return &i }(0),
+			expirationImminentWindow: time.Hour * 24,
 		},
 		"update status for a Certificate whose spec.secretName secret does not exist": {
 			condition: cmapi.CertificateCondition{
@@ -235,6 +552,19 @@ func TestProcessItem(t *testing.T) {
 			secretShouldExist: true,
 			certShouldUpdate:  false,
 		},
+		"update status for a Certificate whose DNS names ConfigMap does not exist- should be not Ready without retrying": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             certificates.DNSNamesConfigMapErrorReason,
+				Message:            `failed to read the DNS names referenced by spec.dnsNamesConfigMapRef: configmap "dns-names" not found`,
+				LastTransitionTime: &metaNow,
+			},
+			cert: gen.CertificateFrom(cert, gen.SetCertificateDNSNamesConfigMapRef(cmmeta.ConfigMapKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"},
+			})),
+			certShouldUpdate: true,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -249,15 +579,46 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.cert)
 			}
 
+			var fingerprint string
+			var chainFingerprints []string
+			var issuerSubject, issuerSerialNumber string
 			if test.secretShouldExist {
 				mods := make([]gen.SecretModifier, 0)
 				// If the test scenario needs a secret with a valid X509 cert.
 				if test.notBefore != nil && test.notAfter != nil {
 					x509Bytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
-					mods = append(mods,
-						gen.SetSecretData(map[string][]byte{
-							"tls.crt": x509Bytes,
-						}))
+					if len(test.scts) > 0 {
+						x509Bytes = mustCreateCertWithSCTs(t, privKey, cert, test.notBefore.Time, test.notAfter.Time, test.scts)
+					}
+					var caBytes []byte
+					if test.issuingCA != "" {
+						x509Bytes, caBytes = mustCreateCASignedCert(t, privKey, cert, test.notBefore.Time, test.notAfter.Time)
+						issuerSubject, issuerSerialNumber = "CN=test-issuing-ca,O=cert-manager", "1A:2B:3C"
+					} else {
+						// the X509 cert is self-signed, so is its own issuing CA
+						x509Cert, err := pki.DecodeX509CertificateBytes(x509Bytes)
+						if err != nil {
+							t.Fatal(err)
+						}
+						issuerSubject, issuerSerialNumber = x509Cert.Subject.String(), pki.HexSerialNumber(x509Cert.SerialNumber)
+					}
+					fingerprint = manualFingerprint(t, x509Bytes)
+					if test.withChain {
+						issuerBytes := testcrypto.MustCreateCertWithNotBeforeAfter(t, testcrypto.MustCreatePEMPrivateKey(t),
+							gen.Certificate("issuer", gen.SetCertificateCommonName("issuer")), test.notBefore.Time, test.notAfter.Time)
+						chainFingerprints = []string{manualFingerprint(t, issuerBytes)}
+						x509Bytes = append(x509Bytes, issuerBytes...)
+					}
+					data := map[string][]byte{}
+					switch test.issuingCA {
+					case "chain":
+						chainFingerprints = append(chainFingerprints, manualFingerprint(t, caBytes))
+						x509Bytes = append(x509Bytes, caBytes...)
+					case "ca.crt":
+						data["ca.crt"] = caBytes
+					}
+					data["tls.crt"] = x509Bytes
+					mods = append(mods, gen.SetSecretData(data))
 				}
 				// Ensure secret is loaded into the builder's fake clientset.
 				builder.KubeObjects = append(builder.KubeObjects,
@@ -279,6 +640,9 @@ func TestProcessItem(t *testing.T) {
 			// Override controller's renewalTime func with a fake that returns test.renewalTime.
 			w.controller.renewalTimeCalculator = renewalTimeBuilder(test.renewalTime)
 
+			w.controller.expirationImminentWindow = test.expirationImminentWindow
+			w.controller.notBeforeTolerance = test.notBeforeTolerance
+
 			// If Certificate's status should be updated,
 			// build the expected Certificate and use it to set the expected update action on builder.
 			if test.certShouldUpdate {
@@ -289,6 +653,22 @@ func TestProcessItem(t *testing.T) {
 				c.Status.NotAfter = test.notAfter
 				c.Status.NotBefore = test.notBefore
 				c.Status.RenewalTime = test.renewalTime
+				c.Status.EmbeddedSCTCount = test.embeddedSCTCount
+				c.Status.Fingerprint = fingerprint
+				c.Status.ChainFingerprints = chainFingerprints
+				c.Status.IssuerSubject = issuerSubject
+				c.Status.IssuerSerialNumber = issuerSerialNumber
+
+				if test.expirationImminentCondition != nil {
+					c = gen.CertificateFrom(c, gen.SetCertificateStatusCondition(*test.expirationImminentCondition))
+				} else {
+					apiutil.RemoveCertificateCondition(c, cmapi.CertificateConditionExpirationImminent)
+				}
+				if test.notYetValidCondition != nil {
+					c = gen.CertificateFrom(c, gen.SetCertificateStatusCondition(*test.notYetValidCondition))
+				} else {
+					apiutil.RemoveCertificateCondition(c, cmapi.CertificateConditionNotYetValid)
+				}
 
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -472,6 +852,124 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 			message:        "Certificate expired on Sun, 31 Dec 0000 23:00:00 UTC",
 			violationFound: true,
 		},
+		"Certificate is Ready when its NotBefore is in the future": {
+			cert: gen.Certificate("something",
+				gen.SetCertificateCommonName("new.example.com"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				})),
+			secret: gen.Secret("something",
+				gen.SetSecretAnnotations(map[string]string{
+					cmapi.IssuerNameAnnotationKey:  "testissuer",
+					cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+					cmapi.IssuerGroupAnnotationKey: "group.example.com",
+				}),
+				gen.SetSecretData(
+					map[string][]byte{
+						corev1.TLSPrivateKeyKey: privKey,
+						corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey,
+							gen.Certificate("something", gen.SetCertificateCommonName("new.example.com")),
+							clock.Now().Add(time.Hour), clock.Now().Add(3*time.Hour),
+						),
+					},
+				)),
+			cr: gen.CertificateRequest("something",
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				}),
+				gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, privKey,
+					gen.Certificate("something",
+						gen.SetCertificateCommonName("new.example.com")))),
+			),
+			reason:  "",
+			message: "",
+		},
+		"Certificate not Ready as the short-lived certificate expires sooner than its minimum remaining validity": {
+			cert: gen.Certificate("something",
+				gen.SetCertificateCommonName("new.example.com"),
+				gen.SetCertificateMinRemainingValidity(2*time.Hour),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				})),
+			secret: gen.Secret("something",
+				gen.SetSecretAnnotations(
+					map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					}),
+				gen.SetSecretData(
+					map[string][]byte{
+						corev1.TLSPrivateKeyKey: privKey,
+						corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey,
+							&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "new.example.com"}},
+							clock.Now(), clock.Now().Add(time.Hour),
+						),
+					},
+				)),
+			cr: gen.CertificateRequest("something",
+				gen.SetCertificateRequestIssuer(
+					cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+				),
+				gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, privKey,
+					gen.Certificate("something",
+						gen.SetCertificateCommonName("new.example.com")))),
+			),
+			reason:         policies.InsufficientValidity,
+			message:        fmt.Sprintf("Certificate expires on %s, which is sooner than its minimum remaining validity of 2h0m0s", clock.Now().Add(time.Hour).Format(time.RFC1123)),
+			violationFound: true,
+		},
+		"Certificate is Ready as the short-lived certificate has more than its minimum remaining validity": {
+			cert: gen.Certificate("something",
+				gen.SetCertificateCommonName("new.example.com"),
+				gen.SetCertificateMinRemainingValidity(30*time.Minute),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				})),
+			secret: gen.Secret("something",
+				gen.SetSecretAnnotations(
+					map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					}),
+				gen.SetSecretData(
+					map[string][]byte{
+						corev1.TLSPrivateKeyKey: privKey,
+						corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey,
+							&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "new.example.com"}},
+							clock.Now(), clock.Now().Add(time.Hour),
+						),
+					},
+				)),
+			cr: gen.CertificateRequest("something",
+				gen.SetCertificateRequestIssuer(
+					cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+				),
+				gen.SetCertificateRequestCSR(testcrypto.MustGenerateCSRImpl(t, privKey,
+					gen.Certificate("something",
+						gen.SetCertificateCommonName("new.example.com")))),
+			),
+			reason:         "",
+			message:        "",
+			violationFound: false,
+		},
 		"Certificate is Ready, no policy violations found": {
 			cert: gen.Certificate("something",
 				gen.SetCertificateCommonName("new.example.com"),
@@ -532,3 +1030,44 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleReevaluation(t *testing.T) {
+	tests := map[string]struct {
+		durations   []time.Duration
+		expAdded    bool
+		expSchedule time.Duration
+	}{
+		"nothing is scheduled if no durations are positive": {
+			durations: []time.Duration{0, -time.Hour},
+		},
+		"the shortest positive duration is scheduled": {
+			durations:   []time.Duration{3 * time.Hour, 0, time.Hour, -time.Minute, 2 * time.Hour},
+			expAdded:    true,
+			expSchedule: time.Hour,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var added bool
+			var scheduled time.Duration
+			c := &controller{
+				scheduledWorkQueue: &schedulertest.FakeScheduler{
+					AddFunc: func(_ interface{}, d time.Duration) {
+						added = true
+						scheduled = d
+					},
+				},
+			}
+
+			c.scheduleReevaluation("namespace/name", test.durations...)
+
+			if added != test.expAdded {
+				t.Errorf("expected scheduled=%t, got=%t", test.expAdded, added)
+			}
+			if scheduled != test.expSchedule {
+				t.Errorf("unexpected scheduled duration exp=%s, got=%s", test.expSchedule, scheduled)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"strconv"
+	"text/template"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -47,6 +48,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
@@ -65,11 +67,20 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             corelisters.SecretLister
+	configMapLister          corelisters.ConfigMapLister
 	client                   cmclient.Interface
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
 	copiedAnnotationPrefixes []string
 
+	// nameTemplate is used to name the CertificateRequests created for each
+	// revision. If nil, a random suffix is appended to the Certificate's name.
+	nameTemplate *template.Template
+
+	// privateKeyDefaulter applies the default private key parameters of the
+	// Certificate's issuer.
+	privateKeyDefaulter *certificates.PrivateKeyDefaulter
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Create or Apply API calls.
@@ -81,6 +92,7 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	namespace string,
 	recorder record.EventRecorder,
 	clock clock.Clock,
 	certificateControllerOptions controllerpkg.CertificateOptions,
@@ -93,6 +105,7 @@ func NewController(
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := factory.Core().V1().Secrets()
+	configMapsInformer := certificates.DNSNamesConfigMapsInformer(factory, namespace)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
@@ -107,11 +120,26 @@ func NewController(
 			predicate.ResourceOwnerOf,
 		),
 	})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to the Secret containing the CSR of
+		// Certificates which do not manage their own private key
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateCSRSecretName),
+		),
+	})
+	configMapsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to the ConfigMap listing additional
+		// DNS names for the Certificate
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateDNSNamesConfigMapName),
+		),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		secretsInformer.Informer().HasSynced,
+		configMapsInformer.Informer().HasSynced,
 		certificateRequestInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
@@ -120,10 +148,12 @@ func NewController(
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
+		configMapLister:          configMapsInformer.Lister(),
 		client:                   client,
 		recorder:                 recorder,
 		clock:                    clock,
 		copiedAnnotationPrefixes: certificateControllerOptions.CopiedAnnotationPrefixes,
+		nameTemplate:             certificateControllerOptions.CertificateRequestNameTemplate,
 		fieldManager:             fieldManager,
 	}, queue, mustSync
 }
@@ -154,6 +184,21 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	if certificates.PrivateKeyIsUnmanaged(crt) {
+		return c.processUnmanagedPrivateKey(ctx, crt)
+	}
+
+	// Requests are generated for, and compared against, the full set of DNS
+	// names including any listed in the referenced ConfigMap.
+	mergedCrt, err := certificates.CertificateWithConfigMapDNSNames(c.configMapLister, crt)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to read the DNS names referenced by spec.dnsNamesConfigMapRef: %v", err)
+		// The Certificate is processed again once the ConfigMap is created
+		// or updated, so there is no need to retry.
+		return nil
+	}
+	crt = c.privateKeyDefaulter.WithDefaults(mergedCrt)
+
 	// Check for and fetch the 'status.nextPrivateKeySecretName' secret
 	if crt.Status.NextPrivateKeySecretName == nil {
 		log.V(logf.DebugLevel).Info("status.nextPrivateKeySecretName not yet set, waiting for keymanager before processing certificate")
@@ -177,16 +222,101 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	requests, nextRevision, err := c.currentRequestsForNextRevision(ctx, crt, pk.Public())
+	if err != nil {
+		return err
+	}
+
+	if len(requests) > 1 {
+		// TODO: we should handle this case better, but for now do nothing to
+		//  avoid getting into loops where we keep creating multiple requests
+		//  and deleting them again.
+		log.V(logf.ErrorLevel).Info("Multiple matching CertificateRequest resources exist, delete one of them. This is likely an error and should be reported on the issue tracker!")
+		return nil
+	}
+
+	if len(requests) == 1 {
+		// Nothing to do as we've already verified that the CertificateRequest
+		// is up to date above.
+		return nil
+	}
+
+	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name)
+}
+
+// processUnmanagedPrivateKey ensures a CertificateRequest exists for the next
+// revision of a Certificate whose private key is not managed by cert-manager.
+// The CSR supplied in 'spec.csrSecretRef' is used as-is, so that every renewal
+// signs the same request again and no private key is ever generated or stored.
+func (c *controller) processUnmanagedPrivateKey(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	csrPEM, csr, err := certificates.FetchCSRForCertificate(c.secretLister, crt)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("spec.csrSecretRef Secret resource does not exist, waiting for it to be created before continuing")
+		return nil
+	}
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to read the certificate signing request: %v", err)
+		return nil
+	}
+
+	requests, nextRevision, err := c.currentRequestsForNextRevision(ctx, crt, csr.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if len(requests) > 1 {
+		log.V(logf.ErrorLevel).Info("Multiple matching CertificateRequest resources exist, delete one of them. This is likely an error and should be reported on the issue tracker!")
+		return nil
+	}
+
+	if len(requests) == 1 {
+		// The CertificateRequest for this revision already exists and matches
+		// the CSR's public key, so there is nothing more to do.
+		return nil
+	}
+
+	// Check the supplied CSR before creating a request from it, otherwise a
+	// CSR that does not match the spec would be deleted and recreated in a
+	// loop. The Certificate is resynced when the CSR Secret changes.
+	violations, err := certificates.RequestMatchesSpec(&cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace},
+		Spec: cmapi.CertificateRequestSpec{
+			Duration:  crt.Spec.Duration,
+			IssuerRef: certificates.CurrentIssuerRef(crt),
+			Request:   csrPEM,
+			IsCA:      crt.Spec.IsCA,
+			Usages:    crt.Spec.Usages,
+		},
+	}, currentIssuerSpec(crt))
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to check the certificate signing request: %v", err)
+		return nil
+	}
+	if len(violations) > 0 {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "The certificate signing request does not match the Certificate spec: %v", violations)
+		return nil
+	}
+
+	return c.submitCertificateRequest(ctx, crt, csrPEM, nextRevision, "")
+}
+
+// currentRequestsForNextRevision returns the 'owned' CertificateRequests for
+// the next revision of the Certificate which are still up to date, deleting
+// any requests which are invalid, out of date, or failed during a previous
+// issuance.
+func (c *controller) currentRequestsForNextRevision(ctx context.Context, crt *cmapi.Certificate, publicKey crypto.PublicKey) ([]*cmapi.CertificateRequest, int, error) {
 	// Discover all 'owned' CertificateRequests
 	requests, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	// delete any existing CertificateRequest resources that do not have a
 	// revision annotation
 	if requests, err = c.deleteRequestsWithoutRevision(ctx, requests...); err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	currentCertificateRevision := 0
@@ -197,34 +327,20 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 
 	requests, err = requestsWithRevision(requests, nextRevision)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
-	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, pk.Public(), requests...)
+	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, publicKey, requests...)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	requests, err = c.deleteCurrentFailedRequests(ctx, crt, requests...)
 	if err != nil {
-		return err
-	}
-
-	if len(requests) > 1 {
-		// TODO: we should handle this case better, but for now do nothing to
-		//  avoid getting into loops where we keep creating multiple requests
-		//  and deleting them again.
-		log.V(logf.ErrorLevel).Info("Multiple matching CertificateRequest resources exist, delete one of them. This is likely an error and should be reported on the issue tracker!")
-		return nil
-	}
-
-	if len(requests) == 1 {
-		// Nothing to do as we've already verified that the CertificateRequest
-		// is up to date above.
-		return nil
+		return nil, 0, err
 	}
 
-	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name)
+	return requests, nextRevision, nil
 }
 
 func (c *controller) deleteCurrentFailedRequests(ctx context.Context, crt *cmapi.Certificate, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
@@ -314,12 +430,22 @@ func requestsWithRevision(reqs []*cmapi.CertificateRequest, revision int) ([]*cm
 	return remaining, nil
 }
 
+// currentIssuerSpec returns the Certificate's spec with `issuerRef` set to
+// the issuer that CertificateRequests should currently be sent to and no
+// fallback issuers, so that requests sent to any other issuer do not match.
+func currentIssuerSpec(crt *cmapi.Certificate) cmapi.CertificateSpec {
+	spec := crt.Spec
+	spec.IssuerRef = certificates.CurrentIssuerRef(crt)
+	spec.FallbackIssuerRefs = nil
+	return spec
+}
+
 func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cmapi.Certificate, publicKey crypto.PublicKey, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
 	log := logf.FromContext(ctx)
 	var remaining []*cmapi.CertificateRequest
 	for _, req := range reqs {
 		log := logf.WithRelatedResource(log, req)
-		violations, err := certificates.RequestMatchesSpec(req, crt.Spec)
+		violations, err := certificates.RequestMatchesSpec(req, currentIssuerSpec(crt))
 		if err != nil {
 			log.Error(err, "Failed to check if CertificateRequest matches spec, deleting CertificateRequest")
 			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
@@ -373,9 +499,18 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 		return err
 	}
 
+	return c.submitCertificateRequest(ctx, crt, csrPEM.Bytes(), nextRevision, nextPrivateKeySecretName)
+}
+
+// submitCertificateRequest creates a CertificateRequest for the given revision
+// of the Certificate containing the PEM encoded CSR. The private key
+// annotation is only set if nextPrivateKeySecretName is not empty.
+func (c *controller) submitCertificateRequest(ctx context.Context, crt *cmapi.Certificate, csrPEM []byte, nextRevision int, nextPrivateKeySecretName string) error {
 	annotations := controllerpkg.BuildAnnotationsToCopy(crt.Annotations, c.copiedAnnotationPrefixes)
 	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = strconv.Itoa(nextRevision)
-	annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
+	if len(nextPrivateKeySecretName) > 0 {
+		annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
+	}
 	annotations[cmapi.CertificateNameKey] = crt.Name
 
 	cr := &cmapi.CertificateRequest{
@@ -388,21 +523,57 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 		},
 		Spec: cmapi.CertificateRequestSpec{
 			Duration:  crt.Spec.Duration,
-			IssuerRef: crt.Spec.IssuerRef,
-			Request:   csrPEM.Bytes(),
+			IssuerRef: certificates.CurrentIssuerRef(crt),
+			Request:   csrPEM,
 			IsCA:      crt.Spec.IsCA,
 			Usages:    crt.Spec.Usages,
 		},
 	}
 
-	cr, err = c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
+	if c.nameTemplate != nil {
+		name, err := apiutil.ComputeCertificateRequestName(c.nameTemplate, apiutil.CertificateRequestNameData{
+			Name:      crt.Name,
+			Namespace: crt.Namespace,
+		}, nextRevision)
+		if err != nil {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to compute CertificateRequest name: "+err.Error())
+			return err
+		}
+		cr.Name = name
+		cr.GenerateName = ""
+	}
+
+	created, err := c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
+	if apierrors.IsAlreadyExists(err) && len(cr.Name) > 0 {
+		// The templated name may already be used by the request for this
+		// revision, created by an earlier sync that the lister has not yet
+		// observed. Creating another request would leave two for the same
+		// revision, so wait for the existing one instead.
+		existing, getErr := c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Get(ctx, cr.Name, metav1.GetOptions{})
+		if getErr != nil {
+			return getErr
+		}
+		if metav1.IsControlledBy(existing, crt) && existing.Annotations[cmapi.CertificateRequestRevisionAnnotationKey] == strconv.Itoa(nextRevision) {
+			logf.FromContext(ctx).V(logf.DebugLevel).Info("CertificateRequest for the next revision already exists", "name", existing.Name)
+			return nil
+		}
+
+		// Otherwise the name collides with a request that has not yet been
+		// deleted, or that belongs to another Certificate. Fall back to a
+		// generated name using the templated name as a prefix so that the
+		// new request is still unique.
+		cr = cr.DeepCopy()
+		cr.GenerateName = cr.Name + "-"
+		cr.Name = ""
+		created, err = c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
+	}
 	if err != nil {
-		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to create CertificateRequest: "+err.Error())
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonRequestFailed, cmerrors.WithRemediationHint("Failed to create CertificateRequest: "+err.Error(), withCreateErrorHint(err)))
 		return err
 	}
 
-	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource %q", cr.Name)
-	if err := c.waitForCertificateRequestToExist(cr.Namespace, cr.Name); err != nil {
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRequested, "Created new CertificateRequest resource %q", created.Name)
+	if err := c.waitForCertificateRequestToExist(created.Namespace, created.Name); err != nil {
 		return fmt.Errorf("failed whilst waiting for CertificateRequest to exist - this may indicate an apiserver running slowly. Request will be retried")
 	}
 	return nil
@@ -435,11 +606,15 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Namespace,
 		ctx.Recorder,
 		ctx.Clock,
 		ctx.CertificateOptions,
 		ctx.FieldManager,
 	)
+	defaulter, defaulterMustSync := certificates.NewPrivateKeyDefaulter(ctx.SharedInformerFactory, ctx.Namespace)
+	ctrl.privateKeyDefaulter = defaulter
+	mustSync = append(mustSync, defaulterMustSync...)
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -452,3 +627,13 @@ func init() {
 			Complete()
 	})
 }
+
+// withCreateErrorHint adds a remediation hint to an error creating a
+// CertificateRequest if it was refused by the API server, as retrying will not
+// succeed until the cause has been fixed.
+func withCreateErrorHint(err error) error {
+	if !apierrors.IsForbidden(err) {
+		return err
+	}
+	return cmerrors.NewWithRemediationHint(err, "check that no ResourceQuota or admission policy in the namespace prevents cert-manager from creating CertificateRequests")
+}
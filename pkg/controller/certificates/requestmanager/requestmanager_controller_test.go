@@ -18,6 +18,7 @@ package requestmanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -25,11 +26,14 @@ import (
 
 	"github.com/kr/pretty"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -80,6 +84,7 @@ func TestProcessItem(t *testing.T) {
 	)
 	fixedNow := metav1.NewTime(time.Now())
 	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+	fallbackIssuerRef := cmmeta.ObjectReference{Name: "fallback-issuer", Kind: "ClusterIssuer"}
 	failedCRConditionPreviousIssuance := cmapi.CertificateRequestCondition{
 		Type:               cmapi.CertificateRequestConditionReady,
 		Status:             cmmeta.ConditionFalse,
@@ -588,6 +593,67 @@ func TestProcessItem(t *testing.T) {
 				),
 			},
 		},
+		"should delete the CertificateRequest that failed during this issuance cycle and create one for the fallback issuer the Certificate failed over to": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateFallbackIssuers(fallbackIssuerRef),
+				gen.SetCertificateStatusFallbackIssuer(fallbackIssuerRef),
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue, LastTransitionTime: &fixedNow}),
+				gen.SetCertificateRevision(5),
+			),
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(bundle1.certificateRequest,
+					gen.SetCertificateRequestAnnotations(map[string]string{
+						cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+						cmapi.CertificateRequestRevisionAnnotationKey:   "6",
+					}),
+					gen.AddCertificateRequestStatusCondition(failedCRConditionThisIssuance),
+					gen.SetCertificateRequestFailureTime(metav1.Time{Time: fixedNow.Time.Add(1 * time.Minute)}),
+				),
+			},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test")),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle1.certificateRequest,
+						gen.SetCertificateRequestIssuer(fallbackIssuerRef),
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "6",
+						}),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"should do nothing if the CertificateRequest for the fallback issuer the Certificate failed over to is valid": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateFallbackIssuers(fallbackIssuerRef),
+				gen.SetCertificateStatusFallbackIssuer(fallbackIssuerRef),
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue, LastTransitionTime: &fixedNow}),
+				gen.SetCertificateRevision(5),
+			),
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(bundle1.certificateRequest,
+					gen.SetCertificateRequestIssuer(fallbackIssuerRef),
+					gen.SetCertificateRequestAnnotations(map[string]string{
+						cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+						cmapi.CertificateRequestRevisionAnnotationKey:   "6",
+					}),
+				),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -651,3 +717,361 @@ func TestProcessItem(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessItemUnmanagedPrivateKey(t *testing.T) {
+	baseCert := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      "test",
+			UID:       "test",
+		},
+		Spec: cmapi.CertificateSpec{
+			CommonName:   "test-unmanaged",
+			PrivateKey:   &cmapi.CertificatePrivateKey{Managed: pointer.Bool(false)},
+			CSRSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "test-csr"}},
+		},
+	}
+	bundle := mustCreateCryptoBundle(t, baseCert)
+	otherKeyBundle := mustCreateCryptoBundle(t, baseCert)
+	mismatchedBundle := mustCreateCryptoBundle(t, gen.CertificateFrom(baseCert, gen.SetCertificateCommonName("not-test-unmanaged")))
+	csrSecret := func(csrPEM []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-csr"},
+			Data:       map[string][]byte{cmapi.CertificateCSRSecretDefaultKey: csrPEM},
+		}
+	}
+	issuing := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue})
+	requestForRevision := func(revision string) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(bundle.certificateRequest,
+			gen.DeleteCertificateRequestAnnotation(cmapi.CertificateRequestPrivateKeyAnnotationKey),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateRequestRevisionAnnotationKey: revision,
+			}),
+		)
+	}
+
+	tests := map[string]struct {
+		certificate     *cmapi.Certificate
+		secrets         []runtime.Object
+		requests        []runtime.Object
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"do nothing if the CSR Secret does not exist": {
+			certificate: gen.CertificateFrom(bundle.certificate, issuing),
+		},
+		"emit an event and do nothing if the CSR Secret does not contain a CSR": {
+			certificate:    gen.CertificateFrom(bundle.certificate, issuing),
+			secrets:        []runtime.Object{csrSecret(nil)},
+			expectedEvents: []string{`Warning RequestFailed Failed to read the certificate signing request: no data for "tls.csr" in secret 'testns/test-csr'`},
+		},
+		"emit an event and do nothing if the CSR does not match the spec": {
+			certificate:    gen.CertificateFrom(bundle.certificate, issuing),
+			secrets:        []runtime.Object{csrSecret(mismatchedBundle.certificateRequest.Spec.Request)},
+			expectedEvents: []string{`Warning RequestFailed The certificate signing request does not match the Certificate spec: [spec.commonName]`},
+		},
+		"create a CertificateRequest containing the supplied CSR without referencing a private key": {
+			certificate:    gen.CertificateFrom(bundle.certificate, issuing),
+			secrets:        []runtime.Object{csrSecret(bundle.certificateRequest.Spec.Request)},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					requestForRevision("1"))),
+			},
+		},
+		"re-sign the same CSR when the Certificate is renewed": {
+			certificate: gen.CertificateFrom(bundle.certificate, issuing, gen.SetCertificateRevision(1)),
+			secrets:     []runtime.Object{csrSecret(bundle.certificateRequest.Spec.Request)},
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(requestForRevision("1"),
+					gen.SetCertificateRequestName("test-revision-1"),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionTrue,
+						Reason: cmapi.CertificateRequestReasonIssued,
+					}),
+				),
+			},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					requestForRevision("2"))),
+			},
+		},
+		"do nothing if a CertificateRequest for the next revision already contains the CSR": {
+			certificate: gen.CertificateFrom(bundle.certificate, issuing),
+			secrets:     []runtime.Object{csrSecret(bundle.certificateRequest.Spec.Request)},
+			requests:    []runtime.Object{requestForRevision("1")},
+		},
+		"replace a CertificateRequest for the next revision if the CSR has changed": {
+			certificate: gen.CertificateFrom(bundle.certificate, issuing),
+			secrets:     []runtime.Object{csrSecret(bundle.certificateRequest.Spec.Request)},
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(requestForRevision("1"),
+					gen.SetCertificateRequestName("test-stale"),
+					gen.SetCertificateRequestCSR(otherKeyBundle.csrBytes),
+				),
+			},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "test-stale")),
+				testpkg.NewAction(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					requestForRevision("1"))),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:               t,
+				ExpectedEvents:  test.expectedEvents,
+				ExpectedActions: test.expectedActions,
+				StringGenerator: func(i int) string { return "notrandom" },
+				Clock:           fakeclock.NewFakeClock(time.Now()),
+			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.certificate)
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
+			builder.KubeObjects = append(builder.KubeObjects, test.secrets...)
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := builder.AllEventsCalled(); err != nil {
+				builder.T.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
+
+func TestProcessItemNameTemplate(t *testing.T) {
+	bundle := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      "test",
+			UID:       "test",
+		},
+		Spec: cmapi.CertificateSpec{CommonName: "test-bundle-1"}},
+	)
+	issuing := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle.privateKeyBytes},
+	}
+	requestNamed := func(name, revision string) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(bundle.certificateRequest,
+			gen.SetCertificateRequestName(name),
+			gen.SetCertificateRequestGenerateName(""),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+				cmapi.CertificateRequestRevisionAnnotationKey:   revision,
+			}),
+		)
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		requests    []runtime.Object
+		// createdConcurrently is a request which is created, and so returns
+		// AlreadyExists, when the controller creates a request but is not
+		// observed by the lister beforehand.
+		createdConcurrently *cmapi.CertificateRequest
+		expectedActions     []testpkg.Action
+		expectedEvents      []string
+	}{
+		"name the CertificateRequest for the first revision using the template": {
+			certificate:    gen.CertificateFrom(bundle.certificate, issuing, gen.SetCertificateNextPrivateKeySecretName("exists")),
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "testns-test-1"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					requestNamed("testns-test-1", "1")), relaxedCertificateRequestMatcher),
+			},
+		},
+		"use a different name for the next revision": {
+			certificate: gen.CertificateFrom(bundle.certificate, issuing, gen.SetCertificateNextPrivateKeySecretName("exists"), gen.SetCertificateRevision(1)),
+			requests: []runtime.Object{
+				gen.CertificateRequestFrom(requestNamed("testns-test-1", "1"),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionTrue,
+						Reason: cmapi.CertificateRequestReasonIssued,
+					}),
+				),
+			},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "testns-test-2"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					requestNamed("testns-test-2", "2")), relaxedCertificateRequestMatcher),
+			},
+		},
+		"fall back to a generated name if the templated name is already taken": {
+			certificate: gen.CertificateFrom(bundle.certificate, issuing, gen.SetCertificateNextPrivateKeySecretName("exists")),
+			requests: []runtime.Object{
+				gen.CertificateRequest("testns-test-1", gen.SetCertificateRequestNamespace("testns")),
+			},
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "testns-test-1-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					requestNamed("testns-test-1", "1")), relaxedCertificateRequestMatcher),
+				testpkg.NewAction(coretesting.NewGetAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "testns-test-1")),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(requestNamed("", "1"),
+						gen.SetCertificateRequestGenerateName("testns-test-1-"),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"do not create a second request if the request for this revision already exists": {
+			certificate:         gen.CertificateFrom(bundle.certificate, issuing, gen.SetCertificateNextPrivateKeySecretName("exists")),
+			createdConcurrently: requestNamed("testns-test-1", "1"),
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					requestNamed("testns-test-1", "1")), relaxedCertificateRequestMatcher),
+				testpkg.NewAction(coretesting.NewGetAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "testns-test-1")),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:               t,
+				ExpectedEvents:  test.expectedEvents,
+				ExpectedActions: test.expectedActions,
+				StringGenerator: func(i int) string { return "notrandom" },
+				Clock:           fakeclock.NewFakeClock(time.Now()),
+			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.certificate)
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
+			builder.KubeObjects = append(builder.KubeObjects, secret)
+			builder.Init()
+
+			if test.createdConcurrently != nil {
+				created := false
+				builder.FakeCMClient().PrependReactor("create", "certificaterequests", func(action coretesting.Action) (bool, runtime.Object, error) {
+					if created {
+						return false, nil, nil
+					}
+					created = true
+					if err := builder.FakeCMClient().Tracker().Add(test.createdConcurrently); err != nil {
+						return true, nil, err
+					}
+					return true, nil, apierrors.NewAlreadyExists(cmapi.Resource("certificaterequests"), test.createdConcurrently.Name)
+				})
+			}
+
+			tmpl, err := apiutil.ParseCertificateRequestNameTemplate("{{ .Namespace }}-{{ .Name }}")
+			if err != nil {
+				t.Fatal(err)
+			}
+			builder.Context.CertificateOptions.CertificateRequestNameTemplate = tmpl
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := builder.AllEventsCalled(); err != nil {
+				builder.T.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
+
+func TestProcessItemCreateForbidden(t *testing.T) {
+	bundle := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      "test",
+			UID:       "test",
+		},
+		Spec: cmapi.CertificateSpec{CommonName: "test-bundle-1"}},
+	)
+	crt := gen.CertificateFrom(bundle.certificate,
+		gen.SetCertificateNextPrivateKeySecretName("exists"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+	)
+	forbiddenErr := apierrors.NewForbidden(cmapi.Resource("certificaterequests"), "", errors.New("exceeded quota: certificaterequests"))
+
+	builder := &testpkg.Builder{
+		T: t,
+		ExpectedEvents: []string{
+			fmt.Sprintf("Warning RequestFailed Failed to create CertificateRequest: %s"+
+				" (hint: check that no ResourceQuota or admission policy in the namespace prevents cert-manager from creating CertificateRequests)", forbiddenErr),
+		},
+		ExpectedActions: []testpkg.Action{
+			testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+				gen.CertificateRequestFrom(bundle.certificateRequest,
+					gen.SetCertificateRequestAnnotations(map[string]string{
+						cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+						cmapi.CertificateRequestRevisionAnnotationKey:   "1",
+					}),
+				)), relaxedCertificateRequestMatcher),
+		},
+		StringGenerator: func(i int) string { return "notrandom" },
+		Clock:           fakeclock.NewFakeClock(time.Now()),
+	}
+	builder.CertManagerObjects = append(builder.CertManagerObjects, crt)
+	builder.KubeObjects = append(builder.KubeObjects, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle.privateKeyBytes},
+	})
+	builder.Init()
+	builder.FakeCMClient().PrependReactor("create", "certificaterequests", builder.EnsureReactorCalled("forbidden",
+		func(coretesting.Action) (bool, runtime.Object, error) {
+			return true, nil, forbiddenErr
+		}))
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	key, err := controllerpkg.KeyFunc(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.controller.ProcessItem(context.Background(), key); !apierrors.IsForbidden(err) {
+		t.Errorf("expected a forbidden error, got: %v", err)
+	}
+
+	if err := builder.AllEventsCalled(); err != nil {
+		builder.T.Error(err)
+	}
+	if err := builder.AllActionsExecuted(); err != nil {
+		builder.T.Error(err)
+	}
+	if err := builder.AllReactorsCalled(); err != nil {
+		builder.T.Error(err)
+	}
+}
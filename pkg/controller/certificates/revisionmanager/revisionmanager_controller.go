@@ -90,7 +90,10 @@ func NewController(log logr.Logger, client cmclient.Interface, cmFactory cminfor
 
 // ProcessItem will attempt to garbage collect old CertificateRequests based
 // upon `spec.revisionHistoryLimit`. This controller will only act on
-// Certificates which are in a Ready state and this value is set.
+// Certificates which are in a Ready state and this value is set. Old
+// revisions are also pruned by the issuing controller as soon as a new
+// revision has been issued; this controller catches up on any that it
+// missed.
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx).WithValues("key", key)
 
@@ -126,9 +129,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	return PruneRevisions(logf.NewContext(ctx, log), c.client, c.certificateRequestLister, crt)
+}
+
+// PruneRevisions deletes the oldest CertificateRequests owned by the
+// Certificate so that no more than `spec.revisionHistoryLimit` remain. Nothing
+// is deleted if the limit is not set. It is also called by the issuing
+// controller so that old revisions are pruned as soon as a new revision has
+// been issued.
+func PruneRevisions(ctx context.Context, client cmclient.Interface, lister cmlisters.CertificateRequestLister, crt *cmapi.Certificate) error {
+	if crt.Spec.RevisionHistoryLimit == nil {
+		return nil
+	}
+
+	log := logf.FromContext(ctx)
+
 	// Get all CertificateRequests that are owned by this Certificate
 	requests, err := certificates.ListCertificateRequestsMatchingPredicates(
-		c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
+		lister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
 	if err != nil {
 		return err
 	}
@@ -140,7 +158,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	for _, req := range toDelete {
 		logf.WithRelatedResourceName(log, req.Name, req.Namespace, cmapi.CertificateRequestKind).
 			WithValues("revision", req.rev).Info("garbage collecting old certificate request revsion")
-		err = c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
+		err = client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
 		if apierrors.IsNotFound(err) {
 			continue
 		}
@@ -18,7 +18,10 @@ package revisionmanager
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"testing"
 
 	logtesting "github.com/go-logr/logr/testing"
@@ -26,9 +29,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -418,3 +424,62 @@ func TestCertificateRequestsToDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestPruneRevisions(t *testing.T) {
+	const (
+		revisions = 20
+		limit     = 3
+	)
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateUID("test"),
+		gen.SetCertificateRevisionHistoryLimit(limit),
+	)
+	owner := *metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind("Certificate"))
+
+	var objects []runtime.Object
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := 1; i <= revisions; i++ {
+		req := gen.CertificateRequest(fmt.Sprintf("test-%d", i),
+			gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+			gen.SetCertificateRequestRevision(strconv.Itoa(i)),
+			gen.AddCertificateRequestOwnerReferences(owner),
+		)
+		if err := indexer.Add(req); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, req)
+	}
+	// requests owned by other Certificates must not be deleted
+	other := gen.CertificateRequest("other-1",
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateRequestRevision("1"),
+	)
+	if err := indexer.Add(other); err != nil {
+		t.Fatal(err)
+	}
+	objects = append(objects, other)
+
+	client := fake.NewSimpleClientset(objects...)
+	lister := cmlisters.NewCertificateRequestLister(indexer)
+
+	if err := PruneRevisions(context.Background(), client, lister, crt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining, err := client.CertmanagerV1().CertificateRequests(gen.DefaultTestNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, req := range remaining.Items {
+		names = append(names, req.Name)
+	}
+	sort.Strings(names)
+
+	exp := []string{"other-1", "test-18", "test-19", "test-20"}
+	if !reflect.DeepEqual(exp, names) {
+		t.Errorf("unexpected remaining requests, exp=%v got=%v", exp, names)
+	}
+}
@@ -72,6 +72,18 @@ type controller struct {
 	recorder                 record.EventRecorder
 	scheduledWorkQueue       scheduler.ScheduledWorkQueue
 
+	// privateKeyDefaulter applies the default private key parameters of the
+	// Certificate's issuer.
+	privateKeyDefaulter *certificates.PrivateKeyDefaulter
+
+	// expirationImminentWindow is how close to its expiry the certificate of
+	// a permanently failed Certificate must be for issuance to be retried
+	// regardless. Zero disables the retries.
+	expirationImminentWindow time.Duration
+
+	// gatherer gathers the state of a Certificate for the policy checks.
+	gatherer *policies.Gatherer
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -88,6 +100,7 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	namespace string,
 	recorder record.EventRecorder,
 	clock clock.Clock,
 	shouldReissue policies.Func,
@@ -100,6 +113,7 @@ func NewController(
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := factory.Core().V1().Secrets()
+	configMapsInformer := certificates.DNSNamesConfigMapsInformer(factory, namespace)
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
 
@@ -114,14 +128,27 @@ func NewController(
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
 	})
 
+	// When a ConfigMap resource changes, enqueue any Certificate resources that name it as spec.dnsNamesConfigMapRef.name.
+	configMapsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateDNSNamesConfigMapName)),
+	})
+
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
+		configMapsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
 	}
 
+	gatherer := &policies.Gatherer{
+		CertificateRequestLister: certificateRequestInformer.Lister(),
+		SecretLister:             secretsInformer.Lister(),
+		ConfigMapLister:          configMapsInformer.Lister(),
+	}
+
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
@@ -129,15 +156,13 @@ func NewController(
 		client:                   client,
 		recorder:                 recorder,
 		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		gatherer:                 gatherer,
 		fieldManager:             fieldManager,
 
 		// The following are used for testing purposes.
-		clock:         clock,
-		shouldReissue: shouldReissue,
-		dataForCertificate: (&policies.Gatherer{
-			CertificateRequestLister: certificateRequestInformer.Lister(),
-			SecretLister:             secretsInformer.Lister(),
-		}).DataForCertificate,
+		clock:              clock,
+		shouldReissue:      shouldReissue,
+		dataForCertificate: gatherer.DataForCertificate,
 	}, queue, mustSync
 }
 
@@ -158,6 +183,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	crt = c.privateKeyDefaulter.WithDefaults(crt)
 	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -166,14 +192,39 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	// A Certificate which failed as its Secret was not owned by cert-manager
+	// is retried as soon as it is allowed to adopt the Secret, without
+	// waiting for the maximum issuance attempts or failure back off.
+	adoptionPending := internalcertificates.SecretAdoptionPending(crt)
+
+	// Don't trigger issuance if the maximum number of issuance attempts has
+	// been reached and the Certificate's spec has not changed since, unless
+	// the current certificate is about to expire, in which case issuance is
+	// retried subject to the usual failure back off.
+	failedCond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionFailed)
+	permanentlyFailed := failedCond != nil && failedCond.Status == cmmeta.ConditionTrue && failedCond.ObservedGeneration == crt.Generation
+	expirationImminent := permanentlyFailed && c.expirationImminent(crt)
+	if !adoptionPending && permanentlyFailed && !expirationImminent {
+		log.V(logf.InfoLevel).Info("Not issuing as the Certificate has permanently failed; update the Certificate to retry", "message", failedCond.Message)
+		return nil
+	}
+
 	input, err := c.dataForCertificate(ctx, crt)
+	if certificates.IsDNSNamesConfigMapError(err) {
+		// The Certificate is processed again once the ConfigMap is created or
+		// updated, so there is no need to retry.
+		c.recorder.Event(crt, corev1.EventTypeWarning, certificates.DNSNamesConfigMapErrorReason, err.Error())
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
 	// Don't trigger issuance if we need to back off due to previous failures and Certificate's spec has not changed.
+	// A Failed condition left over from an older generation means the spec
+	// has changed since issuance was given up on, so no back off is needed.
 	backoff, delay := shouldBackoffReissuingOnFailure(log, c.clock, input.Certificate, input.NextRevisionRequest)
-	if backoff {
+	if backoff && (failedCond == nil || expirationImminent) && !adoptionPending {
 		nextIssuanceRetry := c.clock.Now().Add(delay)
 		message := fmt.Sprintf("Backing off from issuance due to previously failed issuance(s). Issuance will next be attempted at %v", nextIssuanceRetry)
 		log.V(logf.InfoLevel).Info(message)
@@ -181,10 +232,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
-	if crt.Status.RenewalTime != nil {
-		// ensure a resync is scheduled in the future so that we re-check
-		// Certificate resources and trigger them near expiry time
-		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
+	// ensure a resync is scheduled in the future so that we re-check
+	// Certificate resources and trigger them near expiry time, or at the time
+	// requested in the renew-at annotation if that comes first
+	if recheckAt := nextRecheckTime(log, c.clock, crt); recheckAt != nil {
+		c.scheduleRecheckOfCertificateIfRequired(log, key, recheckAt.Sub(c.clock.Now()))
 	}
 
 	reason, message, reissue := c.shouldReissue(input)
@@ -193,6 +245,14 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	// Don't trigger issuance into a Secret holding data which was not written
+	// by cert-manager, since no CertificateRequest should be created for a
+	// certificate which could not be stored. The issuing controller checks
+	// this again before writing the Secret.
+	if input.Secret != nil && internalcertificates.SecretIsForeign(crt, input.Secret, c.fieldManager) && !internalcertificates.SecretAdoptionAllowed(crt) {
+		return c.refuseForeignSecret(ctx, log, crt)
+	}
+
 	// Although the below recorder.Event already logs the event, the log
 	// line is quite unreadable (very long). Since this information is very
 	// important for the user and the operator, we log the following
@@ -209,6 +269,29 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return nil
 }
 
+// refuseForeignSecret sets the Issuing condition of the Certificate to False
+// with the SecretNotOwned reason, so that issuance is retried without back off
+// once the Certificate is allowed to adopt its Secret. The condition is only
+// updated if it has changed, to avoid recording an event on every resync.
+func (c *controller) refuseForeignSecret(ctx context.Context, log logr.Logger, crt *cmapi.Certificate) error {
+	message := internalcertificates.SecretNotOwnedMessage(crt.Spec.SecretName)
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil &&
+		cond.Status == cmmeta.ConditionFalse && cond.Reason == internalcertificates.ReasonSecretNotOwned && cond.Message == message {
+		return nil
+	}
+
+	log.V(logf.InfoLevel).Info("Not issuing as the Secret contains data which was not written by cert-manager", "secret", crt.Spec.SecretName)
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, internalcertificates.ReasonSecretNotOwned, message)
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+	c.recorder.Event(crt, corev1.EventTypeWarning, internalcertificates.ReasonSecretNotOwned, message)
+
+	return nil
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -228,6 +311,27 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 	}
 }
 
+// nextRecheckTime returns the earliest of the Certificate's renewal time and
+// the time requested in its renew-at annotation. A renew-at time that has
+// already passed is ignored. Nil is returned if neither time is set.
+func nextRecheckTime(log logr.Logger, c clock.Clock, crt *cmapi.Certificate) *time.Time {
+	var recheckAt *time.Time
+	if crt.Status.RenewalTime != nil {
+		recheckAt = &crt.Status.RenewalTime.Time
+	}
+
+	renewAt, err := certificates.RenewAtTime(crt)
+	if err != nil {
+		log.V(logf.WarnLevel).Info("ignoring invalid renew-at annotation", "error", err.Error())
+		return recheckAt
+	}
+	if renewAt != nil && renewAt.After(c.Now()) && (recheckAt == nil || renewAt.Before(*recheckAt)) {
+		recheckAt = renewAt
+	}
+
+	return recheckAt
+}
+
 // shouldBackOffReissuingOnFailure returns true if an issuance needs to be
 // delayed and the required delay after calculating the exponential backoff.
 // The backoff periods are 1h, 2h, 4h, 8h, 16h and 32h counting from when the last
@@ -307,6 +411,15 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	return true, delay - durationSinceFailure
 }
 
+// expirationImminent returns true if the Certificate's current certificate
+// expires within the expiration imminent window.
+func (c *controller) expirationImminent(crt *cmapi.Certificate) bool {
+	if c.expirationImminentWindow <= 0 || crt.Status.NotAfter == nil {
+		return false
+	}
+	return crt.Status.NotAfter.Sub(c.clock.Now()) < c.expirationImminentWindow
+}
+
 // scheduleRecheckOfCertificateIfRequired will schedule the resource with the
 // given key to be re-queued for processing after the given amount of time
 // has elapsed.
@@ -342,11 +455,34 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Namespace,
 		ctx.Recorder,
 		ctx.Clock,
-		policies.NewTriggerPolicyChain(ctx.Clock).Evaluate,
+		policies.NewTriggerPolicyChain(ctx.Clock, ctx.CertificateOptions.RenewalJitterPercent).Evaluate,
 		ctx.FieldManager,
 	)
+	defaulter, defaulterMustSync := certificates.NewPrivateKeyDefaulter(ctx.SharedInformerFactory, ctx.Namespace)
+	ctrl.privateKeyDefaulter = defaulter
+	ctrl.expirationImminentWindow = ctx.CertificateOptions.ExpirationImminentWindow
+	mustSync = append(mustSync, defaulterMustSync...)
+
+	// Reissue Certificates whose ca.crt is stale as soon as the CA of their
+	// issuer is rotated, either by updating the issuer's signing Secret or by
+	// pointing the issuer at another Secret.
+	// Watching the issuers also re-evaluates their Certificates as soon as
+	// the issuers' private key defaults change, as those are applied to the
+	// Certificates before the trigger policies are evaluated.
+	resolver, resolverMustSync, err := certificates.NewIssuerCAResolver(ctx.KubeSharedInformerFactory, ctx.SharedInformerFactory, ctx.Namespace, ctx.IssuerOptions.ClusterResourceNamespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctrl.gatherer.IssuerCAResolver = resolver
+	mustSync = append(mustSync, resolverMustSync...)
+	ctx.KubeSharedInformerFactory.Core().V1().Secrets().Informer().AddEventHandler(resolver.IssuerCASecretEventHandler(log, queue))
+	ctx.SharedInformerFactory.Certmanager().V1().Issuers().Informer().AddEventHandler(resolver.IssuerEventHandler(log, queue))
+	if ctx.Namespace == "" {
+		ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Informer().AddEventHandler(resolver.IssuerEventHandler(log, queue))
+	}
 	c.controller = ctrl
 
 	return queue, mustSync, nil
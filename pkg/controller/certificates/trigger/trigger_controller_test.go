@@ -19,19 +19,24 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	logtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -63,6 +68,10 @@ func Test_controller_ProcessItem(t *testing.T) {
 		mockShouldReissue       func(t *testing.T) policies.Func
 		wantShouldReissueCalled bool
 
+		// expirationImminentWindow is the window in which a permanently
+		// failed Certificate is retried.
+		expirationImminentWindow time.Duration
+
 		// wantEvent, if set, is an 'event string' that is expected to be fired.
 		// For example, "Normal Issuing Re-issuance forced by unit test case"
 		// where 'Normal' is the event severity, 'Issuing' is the reason and the
@@ -159,6 +168,36 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should set Issuing=True if the certificate in the Secret does not match its private key": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				Secret: gen.Secret("secret-1", gen.SetSecretNamespace("testns"),
+					gen.SetSecretAnnotations(map[string]string{cmapi.CertificateNameKey: "cert-1"}), gen.SetSecretData(map[string][]byte{
+						corev1.TLSPrivateKeyKey: testcrypto.MustCreatePEMPrivateKey(t),
+						// The certificate is signed for a different private key,
+						// as if the Secret had been edited by hand.
+						corev1.TLSCertKey: testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+							gen.Certificate("cert-1", gen.SetCertificateCommonName("example.com"))),
+					})),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return policies.NewTriggerPolicyChain(fixedClock, 0).Evaluate
+			},
+			wantEvent: "Normal Issuing Issuing certificate as Secret contains an invalid key-pair: tls: private key does not match public key",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             policies.InvalidKeyPair,
+				Message:            "Issuing certificate as Secret contains an invalid key-pair: tls: private key does not match public key",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
 		// The combinations of number of failed issuances and last
 		// failed issuance time that do or do not result in re-issuance
 		// are tested in Test_shouldBackoffReissuingOnFailure below
@@ -238,6 +277,256 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should not set Issuing=True when the Certificate has permanently failed and its spec has not changed": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-61*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: false,
+			wantShouldReissueCalled:      false,
+		},
+		"should set Issuing=True when the Certificate has permanently failed but its certificate is about to expire": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateNotAfter(metav1.NewTime(fixedNow.Add(24*time.Hour))),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-48*time.Hour))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			expirationImminentWindow:     7 * 24 * time.Hour,
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				)),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "ForceTriggered", "Re-issuance forced by unit test case", true
+				}
+			},
+			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantConditions: []cmapi.CertificateCondition{
+				{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+				{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "ForceTriggered",
+					Message:            "Re-issuance forced by unit test case",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+			},
+		},
+		"should back off when the Certificate has permanently failed and its certificate is about to expire but it failed recently": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateNotAfter(metav1.NewTime(fixedNow.Add(24*time.Hour))),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-1*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			expirationImminentWindow:     7 * 24 * time.Hour,
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				)),
+			},
+			wantShouldReissueCalled: false,
+		},
+		"should set Issuing=True without backing off when the spec of a permanently failed Certificate has changed": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(43),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-1*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				)),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "ForceTriggered", "Re-issuance forced by unit test case", true
+				}
+			},
+			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantConditions: []cmapi.CertificateCondition{
+				{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+				{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "ForceTriggered",
+					Message:            "Re-issuance forced by unit test case",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 43,
+				},
+			},
+		},
+		"should set Issuing=True without backing off when a Certificate which failed as its Secret was not owned adopts the Secret": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.AddCertificateAnnotations(map[string]string{cmapi.AdoptSecretAnnotation: "true"}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-1*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(3)),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "False",
+					Reason:             "SecretNotOwned",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				)),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "ForceTriggered", "Re-issuance forced by unit test case", true
+				}
+			},
+			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantConditions: []cmapi.CertificateCondition{
+				{
+					Type:               "Failed",
+					Status:             "True",
+					Reason:             "MaxIssuanceAttemptsExceeded",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+				{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "ForceTriggered",
+					Message:            "Re-issuance forced by unit test case",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				},
+			},
+		},
+		"should set Issuing=False rather than trigger issuance into a Secret which is not owned by cert-manager": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				Secret: gen.Secret("secret-1", gen.SetSecretNamespace("testns"),
+					gen.SetSecretData(map[string][]byte{"password": []byte("hunter2")}),
+				),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return policies.NewTriggerPolicyChain(fixedClock, 0).Evaluate
+			},
+			wantEvent: "Warning SecretNotOwned Secret \"secret-1\" already exists and contains data which was not written by cert-manager, set the \"cert-manager.io/adopt-secret\" annotation on the Certificate to \"true\" to allow it to be overwritten",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "False",
+				Reason:             "SecretNotOwned",
+				Message:            "Secret \"secret-1\" already exists and contains data which was not written by cert-manager, set the \"cert-manager.io/adopt-secret\" annotation on the Certificate to \"true\" to allow it to be overwritten",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should not update a Certificate which already records that its Secret is not owned by cert-manager": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:               "Issuing",
+					Status:             "False",
+					Reason:             "SecretNotOwned",
+					Message:            "Secret \"secret-1\" already exists and contains data which was not written by cert-manager, set the \"cert-manager.io/adopt-secret\" annotation on the Certificate to \"true\" to allow it to be overwritten",
+					LastTransitionTime: &fixedNow,
+					ObservedGeneration: 42,
+				}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				Secret: gen.Secret("secret-1", gen.SetSecretNamespace("testns"),
+					gen.SetSecretData(map[string][]byte{"password": []byte("hunter2")}),
+				),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return policies.NewTriggerPolicyChain(fixedClock, 0).Evaluate
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -256,6 +545,8 @@ func Test_controller_ProcessItem(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			w.expirationImminentWindow = test.expirationImminentWindow
+
 			gotShouldReissueCalled := false
 			w.shouldReissue = func(i policies.Input) (string, string, bool) {
 				gotShouldReissueCalled = true
@@ -679,3 +970,438 @@ func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 
 	}
 }
+
+func Test_nextRecheckTime(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC))
+	renewalTime := clock.Now().Add(time.Hour)
+	renewAt := func(t time.Time) gen.CertificateModifier {
+		return gen.AddCertificateAnnotations(map[string]string{cmapi.RenewAtAnnotation: t.Format(time.RFC3339)})
+	}
+
+	tests := map[string]struct {
+		givenCert *cmapi.Certificate
+		want      *time.Time
+	}{
+		"nothing to schedule if neither the renewal time nor renew-at are set": {
+			givenCert: gen.Certificate("test"),
+		},
+		"schedules the renewal time if renew-at is not set": {
+			givenCert: gen.Certificate("test", gen.SetCertificateRenewalTime(metav1.NewTime(renewalTime))),
+			want:      &renewalTime,
+		},
+		"schedules renew-at if it is before the renewal time": {
+			givenCert: gen.Certificate("test",
+				gen.SetCertificateRenewalTime(metav1.NewTime(renewalTime)),
+				renewAt(clock.Now().Add(time.Minute)),
+			),
+			want: func() *time.Time { t := clock.Now().Add(time.Minute); return &t }(),
+		},
+		"schedules renew-at if the renewal time is not set": {
+			givenCert: gen.Certificate("test", renewAt(clock.Now().Add(time.Minute))),
+			want:      func() *time.Time { t := clock.Now().Add(time.Minute); return &t }(),
+		},
+		"schedules the renewal time if it is before renew-at": {
+			givenCert: gen.Certificate("test",
+				gen.SetCertificateRenewalTime(metav1.NewTime(renewalTime)),
+				renewAt(renewalTime.Add(time.Minute)),
+			),
+			want: &renewalTime,
+		},
+		"ignores renew-at if it has already passed": {
+			givenCert: gen.Certificate("test",
+				gen.SetCertificateRenewalTime(metav1.NewTime(renewalTime)),
+				renewAt(clock.Now().Add(-time.Minute)),
+			),
+			want: &renewalTime,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := nextRecheckTime(logtesting.NewTestLogger(t), clock, test.givenCert)
+			if test.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NotNil(t, got) {
+				assert.True(t, test.want.Equal(*got), "expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_controller_ProcessItem_DNSNamesConfigMap(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateGeneration(42),
+		gen.SetCertificateUID("cert-1-uid"),
+		gen.SetCertificateSecretName("cert-1-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateDNSNamesConfigMapRef(cmmeta.ConfigMapKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: "dns-names"},
+		}),
+		gen.SetCertificateRevision(1),
+	)
+
+	// The issued certificate contains both the names from the spec and
+	// the names that were listed in the ConfigMap at the time of issuance.
+	bundle := testcrypto.MustCreateCryptoBundle(t, gen.CertificateFrom(crt,
+		gen.SetCertificateDNSNames("example.com", "foo.example.com"),
+	), fixedClock)
+	secret := gen.Secret("cert-1-tls",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretAnnotations(map[string]string{
+			cmapi.CertificateNameKey:      "cert-1",
+			cmapi.IssuerNameAnnotationKey: "ca-issuer",
+			cmapi.IssuerKindAnnotationKey: "Issuer",
+		}),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       bundle.CertBytes,
+			corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+		}),
+	)
+
+	tests := map[string]struct {
+		configMapData map[string]string
+		// configMapLabels are the labels of the ConfigMap. If nil, the
+		// ConfigMap is labelled to be watched by cert-manager.
+		configMapLabels map[string]string
+
+		// wantConditions is the expected set of conditions on the Certificate
+		// resource if an Update is made.
+		// If nil, no update is expected.
+		wantConditions []cmapi.CertificateCondition
+		wantEvent      string
+		wantErr        string
+	}{
+		"should not reissue when the ConfigMap lists the names already issued": {
+			configMapData: map[string]string{"dnsNames": "foo.example.com\n"},
+		},
+		"should reissue when a name is added to the ConfigMap": {
+			configMapData: map[string]string{"dnsNames": "foo.example.com\nbar.example.com\n"},
+			wantEvent:     "Normal Issuing Existing issued Secret is not up to date for spec: [spec.dnsNames]",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "SecretMismatch",
+				Message:            "Existing issued Secret is not up to date for spec: [spec.dnsNames]",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should reissue when a name is removed from the ConfigMap": {
+			configMapData: map[string]string{"dnsNames": ""},
+			wantEvent:     "Normal Issuing Existing issued Secret is not up to date for spec: [spec.dnsNames]",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "SecretMismatch",
+				Message:            "Existing issued Secret is not up to date for spec: [spec.dnsNames]",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should not reissue and should not retry when the ConfigMap contains an invalid name": {
+			configMapData: map[string]string{"dnsNames": "foo.example.com,bar_example.com"},
+			wantEvent:     `Warning DNSNamesConfigMapError failed to read the DNS names referenced by spec.dnsNamesConfigMapRef: invalid DNS names in configmap 'testns/dns-names': "bar_example.com" is not a valid DNS name: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+		},
+		"should not reissue and should not retry when the ConfigMap is not labelled to be watched": {
+			configMapData:   map[string]string{"dnsNames": "foo.example.com\nbar.example.com\n"},
+			configMapLabels: map[string]string{},
+			wantEvent:       `Warning DNSNamesConfigMapError failed to read the DNS names referenced by spec.dnsNamesConfigMapRef: configmap "dns-names" not found`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			configMapLabels := test.configMapLabels
+			if configMapLabels == nil {
+				configMapLabels = map[string]string{cmapi.CertificateDNSNamesConfigMapLabelKey: "true"}
+			}
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: []runtime.Object{crt},
+				KubeObjects: []runtime.Object{
+					secret,
+					&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: "dns-names", Namespace: "testns", Labels: configMapLabels},
+						Data:       test.configMapData,
+					},
+				},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.wantConditions != nil {
+				expectedCert := crt.DeepCopy()
+				expectedCert.Status.Conditions = test.wantConditions
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						expectedCert,
+					)),
+				)
+			}
+			if test.wantEvent != "" {
+				builder.ExpectedEvents = []string{test.wantEvent}
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotErr := w.controller.ProcessItem(context.Background(), key)
+			switch {
+			case gotErr != nil:
+				if !strings.HasPrefix(gotErr.Error(), test.wantErr) || test.wantErr == "" {
+					t.Errorf("error text did not match, got=%s, exp=%s", gotErr.Error(), test.wantErr)
+				}
+			default:
+				if test.wantErr != "" {
+					t.Errorf("got no error but expected: %s", test.wantErr)
+				}
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+// Test_controller_ProcessItem_MissedRenewal simulates the controller not
+// running past the renewal time of a Certificate, and checks that the
+// Certificate is re-issued as soon as it is processed again after startup or
+// a resync rather than waiting for a scheduled recheck.
+func Test_controller_ProcessItem_MissedRenewal(t *testing.T) {
+	issuedAt := time.Now()
+
+	crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateGeneration(42),
+		gen.SetCertificateUID("cert-1-uid"),
+		gen.SetCertificateSecretName("cert-1-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateDuration(time.Hour),
+		gen.SetCertificateRevision(1),
+	)
+
+	// The issued certificate is valid for an hour, so it is due for renewal
+	// 40 minutes after it was issued.
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fakeclock.NewFakeClock(issuedAt))
+	renewalTime := *certificates.RenewalTime(bundle.Cert.NotBefore, bundle.Cert.NotAfter, nil)
+	secret := gen.Secret("cert-1-tls",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretAnnotations(map[string]string{
+			cmapi.CertificateNameKey:      "cert-1",
+			cmapi.IssuerNameAnnotationKey: "ca-issuer",
+			cmapi.IssuerKindAnnotationKey: "Issuer",
+		}),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       bundle.CertBytes,
+			corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes,
+		}),
+	)
+
+	tests := map[string]struct {
+		// downtime is how long after issuedAt the controller processes the
+		// Certificate again.
+		downtime    time.Duration
+		certificate *cmapi.Certificate
+		wantReissue bool
+	}{
+		"should not reissue if the controller starts before the renewal time": {
+			downtime:    10 * time.Minute,
+			certificate: gen.CertificateFrom(crt, gen.SetCertificateRenewalTime(renewalTime)),
+		},
+		"should reissue immediately if the renewal time passed while the controller was not running": {
+			downtime:    50 * time.Minute,
+			certificate: gen.CertificateFrom(crt, gen.SetCertificateRenewalTime(renewalTime)),
+			wantReissue: true,
+		},
+		"should reissue immediately if the certificate expired while the controller was not running": {
+			downtime:    48 * time.Hour,
+			certificate: gen.CertificateFrom(crt, gen.SetCertificateRenewalTime(renewalTime)),
+			wantReissue: true,
+		},
+		"should reissue immediately if the back off after a failure ended while the controller was not running": {
+			downtime: 3 * time.Hour,
+			certificate: gen.CertificateFrom(crt,
+				gen.SetCertificateRenewalTime(renewalTime),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(issuedAt.Add(45*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			wantReissue: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := metav1.NewTime(issuedAt.Add(test.downtime))
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now.Time),
+				CertManagerObjects: []runtime.Object{test.certificate},
+				KubeObjects:        []runtime.Object{secret},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.wantReissue {
+				message := fmt.Sprintf("Renewing certificate as renewal was scheduled at %s", renewalTime)
+				expectedCert := test.certificate.DeepCopy()
+				expectedCert.Status.Conditions = []cmapi.CertificateCondition{{
+					Type:               "Issuing",
+					Status:             "True",
+					Reason:             "Renewing",
+					Message:            message,
+					LastTransitionTime: &now,
+					ObservedGeneration: 42,
+				}}
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						expectedCert,
+					)),
+				)
+				builder.ExpectedEvents = []string{"Normal Issuing " + message}
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+// Test_controller_ProcessItem_IssuerCARotated ensures that a Certificate is
+// reissued once the CA of its CA issuer has been rotated, so that the stale
+// ca.crt in its Secret is updated.
+func Test_controller_ProcessItem_IssuerCARotated(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now())
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	createCA := func(cn string) []byte {
+		return testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+			gen.Certificate(cn, gen.SetCertificateCommonName(cn), gen.SetCertificateIsCA(true)))
+	}
+	caPEM := createCA("ca")
+	rotatedCAPEM := createCA("rotated-ca")
+
+	crt := gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("cert-1-tls"),
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: cmapi.IssuerKind}),
+		gen.SetCertificateGeneration(42),
+	)
+	leafKey := testcrypto.MustCreatePEMPrivateKey(t)
+	leafSecret := gen.Secret("cert-1-tls", gen.SetSecretNamespace("testns"),
+		gen.SetSecretAnnotations(map[string]string{
+			cmapi.CertificateNameKey:      "cert-1",
+			cmapi.IssuerNameAnnotationKey: "ca-issuer",
+			cmapi.IssuerKindAnnotationKey: cmapi.IssuerKind,
+		}),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSPrivateKeyKey: leafKey,
+			corev1.TLSCertKey:       testcrypto.MustCreateCert(t, leafKey, crt),
+			cmmeta.TLSCAKey:         caPEM,
+		}),
+	)
+	issuer := gen.Issuer("ca-issuer", gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+
+	tests := map[string]struct {
+		signingCA []byte
+
+		wantEvent      string
+		wantConditions []cmapi.CertificateCondition
+	}{
+		"should not set Issuing=True if the CA of the issuer has not changed": {
+			signingCA: caPEM,
+		},
+		"should set Issuing=True if the CA of the issuer has been rotated": {
+			signingCA: rotatedCAPEM,
+			wantEvent: "Normal Issuing Issuing certificate as the CA certificate of its issuer has changed",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             policies.IssuerCAChanged,
+				Message:            "Issuing certificate as the CA certificate of its issuer has changed",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signingSecret := gen.Secret("ca-key-pair", gen.SetSecretNamespace("testns"),
+				gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: test.signingCA}))
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				KubeObjects:        []runtime.Object{leafSecret, signingSecret},
+				CertManagerObjects: []runtime.Object{crt, issuer},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.wantConditions != nil {
+				expectedCert := crt.DeepCopy()
+				expectedCert.Status.Conditions = test.wantConditions
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						crt.Namespace,
+						expectedCert,
+					)),
+				)
+			}
+			if test.wantEvent != "" {
+				builder.ExpectedEvents = []string{test.wantEvent}
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -21,15 +21,20 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
-	"reflect"
+	"math"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -108,6 +113,8 @@ func ed25519PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSp
 // RequestMatchesSpec compares a CertificateRequest with a CertificateSpec
 // and returns a list of field names on the Certificate that do not match their
 // counterpart fields on the CertificateRequest.
+// The request's issuerRef matches if it refers to any of the issuers returned
+// by IssuerRefs.
 // If decoding the x509 certificate request fails, an error will be returned.
 func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpec) ([]string, error) {
 	x509req, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
@@ -131,7 +138,7 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	if !util.EqualUnsorted(pki.IPAddressesToString(x509req.IPAddresses), spec.IPAddresses) {
 		violations = append(violations, "spec.ipAddresses")
 	}
-	if !util.EqualUnsorted(pki.URLsToString(x509req.URIs), spec.URIs) {
+	if !util.EqualURIsUnsorted(pki.URLsToString(x509req.URIs), pki.URISANsForCertificateSpec(req.Namespace, spec)) {
 		violations = append(violations, "spec.uris")
 	}
 	if !util.EqualUnsorted(x509req.EmailAddresses, spec.EmailAddresses) {
@@ -171,20 +178,76 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		spec.Duration.Duration != req.Spec.Duration.Duration {
 		violations = append(violations, "spec.duration")
 	}
-	if !reflect.DeepEqual(spec.IssuerRef, req.Spec.IssuerRef) {
+	if _, ok := issuerRefIndex(spec, req.Spec.IssuerRef); !ok {
 		violations = append(violations, "spec.issuerRef")
 	}
 
 	return violations, nil
 }
 
+// IssuerRefs returns the issuers that may issue a Certificate, in the order
+// they are tried: `spec.issuerRef` followed by `spec.fallbackIssuerRefs`.
+func IssuerRefs(spec cmapi.CertificateSpec) []cmmeta.ObjectReference {
+	return append([]cmmeta.ObjectReference{spec.IssuerRef}, spec.FallbackIssuerRefs...)
+}
+
+// CurrentIssuerRef returns the issuer that CertificateRequests for the
+// Certificate's ongoing issuance should be sent to. This is the fallback
+// issuer recorded in `status.fallbackIssuerRef` if it is still listed in
+// `spec.fallbackIssuerRefs`, and `spec.issuerRef` otherwise.
+func CurrentIssuerRef(crt *cmapi.Certificate) cmmeta.ObjectReference {
+	if ref := crt.Status.FallbackIssuerRef; ref != nil {
+		if _, ok := issuerRefIndex(crt.Spec, *ref); ok {
+			return *ref
+		}
+	}
+	return crt.Spec.IssuerRef
+}
+
+// NextIssuerRef returns the issuer to fail over to once the issuer referenced
+// by ref has failed to issue the Certificate. It returns false if ref is the
+// last issuer, or is not one of the Certificate's issuers.
+func NextIssuerRef(spec cmapi.CertificateSpec, ref cmmeta.ObjectReference) (cmmeta.ObjectReference, bool) {
+	i, ok := issuerRefIndex(spec, ref)
+	if !ok || i+1 >= len(IssuerRefs(spec)) {
+		return cmmeta.ObjectReference{}, false
+	}
+	return IssuerRefs(spec)[i+1], true
+}
+
+// issuerRefIndex returns the position of ref in IssuerRefs(spec).
+func issuerRefIndex(spec cmapi.CertificateSpec, ref cmmeta.ObjectReference) (int, bool) {
+	for i, r := range IssuerRefs(spec) {
+		if IssuerRefsEqual(r, ref) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// IssuerRefsEqual compares two issuer references, treating an empty kind or
+// group as equal to its defaulted value so that explicitly setting the default
+// does not count as a change.
+func IssuerRefsEqual(l, r cmmeta.ObjectReference) bool {
+	defaultRef := func(ref cmmeta.ObjectReference) cmmeta.ObjectReference {
+		if ref.Kind == "" {
+			ref.Kind = cmapi.IssuerKind
+		}
+		if ref.Group == "" {
+			ref.Group = certmanager.GroupName
+		}
+		return ref
+	}
+	return defaultRef(l) == defaultRef(r)
+}
+
 // SecretDataAltNamesMatchSpec will compare a Secret resource containing certificate
 // data to a CertificateSpec and return a list of 'violations' for any fields that
 // do not match their counterparts.
 // This is a purposely less comprehensive check than RequestMatchesSpec as some
 // issuers override/force certain fields.
 func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSpec) ([]string, error) {
-	x509cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	x509cert, err := pki.DecodeX509CertificateBytes(secret.Data[internalcertificates.SecretKeysForCertificate(&cmapi.Certificate{Spec: spec}).Certificate])
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +281,7 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 	if !util.EqualUnsorted(pki.IPAddressesToString(x509cert.IPAddresses), spec.IPAddresses) {
 		violations = append(violations, "spec.ipAddresses")
 	}
-	if !util.EqualUnsorted(pki.URLsToString(x509cert.URIs), spec.URIs) {
+	if !util.EqualURIsUnsorted(pki.URLsToString(x509cert.URIs), pki.URISANsForCertificateSpec(secret.Namespace, spec)) {
 		violations = append(violations, "spec.uris")
 	}
 	if !util.EqualUnsorted(x509cert.EmailAddresses, spec.EmailAddresses) {
@@ -280,6 +343,11 @@ func GenerateLocallySignedTemporaryCertificate(crt *cmapi.Certificate, pkData []
 //RenewalTimeFunc is a custom function type for calculating renewal time of a certificate.
 type RenewalTimeFunc func(time.Time, time.Time, *metav1.Duration) *metav1.Time
 
+// CertificateRenewalTimeFunc is a custom function type for calculating the
+// renewal time of a certificate which depends on the Certificate itself, such
+// as one with renewal jitter.
+type CertificateRenewalTimeFunc func(*cmapi.Certificate, time.Time, time.Time) *metav1.Time
+
 // RenewalTime calculates renewal time for a certificate. Default renewal time
 // is 2/3 through certificate's lifetime. If user has configured
 // spec.renewBefore, renewal time will be renewBefore period before expiry
@@ -288,16 +356,7 @@ func RenewalTime(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Dura
 
 	// 1. Calculate how long before expiry a cert should be renewed
 
-	actualDuration := notAfter.Sub(notBefore)
-
-	renewBefore := actualDuration / 3
-
-	// If spec.renewBefore was set (and is less than duration)
-	// respect that. We don't want to prevent users from renewing
-	// longer lived certs more frequently.
-	if renewBeforeOverride != nil && renewBeforeOverride.Duration < actualDuration {
-		renewBefore = renewBeforeOverride.Duration
-	}
+	renewBefore := renewBeforeDuration(notBefore, notAfter, renewBeforeOverride)
 
 	// 2. Calculate when a cert should be renewed
 
@@ -314,3 +373,58 @@ func RenewalTime(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Dura
 	rt := metav1.NewTime(notAfter.Add(-1 * renewBefore).Truncate(time.Second))
 	return &rt
 }
+
+// renewBeforeDuration returns how long before its expiry a certificate should
+// be renewed.
+func renewBeforeDuration(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Duration) time.Duration {
+	actualDuration := notAfter.Sub(notBefore)
+
+	renewBefore := actualDuration / 3
+
+	// If spec.renewBefore was set (and is less than duration)
+	// respect that. We don't want to prevent users from renewing
+	// longer lived certs more frequently.
+	if renewBeforeOverride != nil && renewBeforeOverride.Duration < actualDuration {
+		renewBefore = renewBeforeOverride.Duration
+	}
+	return renewBefore
+}
+
+// RenewalTimeWithJitter calculates the renewal time of a certificate like
+// RenewalTime, and then brings it forward by up to jitterPercent percent of
+// the renew before period.
+// The jitter is derived from a hash of the Certificate's namespace and name,
+// so that the renewal time of a Certificate is stable across reconciles while
+// the renewals of Certificates with identical lifetimes are spread out.
+func RenewalTimeWithJitter(crt *cmapi.Certificate, notBefore, notAfter time.Time, jitterPercent int) *metav1.Time {
+	rt := RenewalTime(notBefore, notAfter, crt.Spec.RenewBefore)
+	if jitterPercent <= 0 {
+		return rt
+	}
+
+	maxJitter := renewBeforeDuration(notBefore, notAfter, crt.Spec.RenewBefore) * time.Duration(jitterPercent) / 100
+
+	sum := sha256.Sum256([]byte(crt.Namespace + "/" + crt.Name))
+	// scale the hash to a fraction of maxJitter, using floating point to
+	// avoid overflowing for long renew before periods
+	fraction := float64(binary.BigEndian.Uint64(sum[:8])) / float64(math.MaxUint64)
+	jitter := time.Duration(float64(maxJitter) * fraction)
+
+	// the jittered time is truncated for the same reason as in RenewalTime
+	jittered := metav1.NewTime(rt.Add(-jitter).Truncate(time.Second))
+	return &jittered
+}
+
+// RenewAtTime returns the time at which a renewal was requested using the
+// Certificate's RenewAtAnnotation, or nil if the annotation is not set.
+func RenewAtTime(crt *cmapi.Certificate) (*time.Time, error) {
+	renewAt, ok := crt.Annotations[cmapi.RenewAtAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, renewAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q annotation %q: %w", cmapi.RenewAtAnnotation, renewAt, err)
+	}
+	return &t, nil
+}
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -245,6 +246,40 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 			}),
 			violations: []string{"spec.ipAddresses"},
 		},
+		"should match if emailAddresses are equal": {
+			spec: cmapi.CertificateSpec{
+				EmailAddresses: []string{"alice@example.com", "bob@example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				EmailAddresses: []string{"bob@example.com", "alice@example.com"},
+			}),
+		},
+		"should not match if emailAddresses are not equal": {
+			spec: cmapi.CertificateSpec{
+				EmailAddresses: []string{"alice@example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				EmailAddresses: []string{"bob@example.com"},
+			}),
+			violations: []string{"spec.emailAddresses"},
+		},
+		"should match if the certificate contains the SPIFFE ID derived from the spec": {
+			spec: cmapi.CertificateSpec{
+				SPIFFE: &cmapi.SPIFFEID{TrustDomain: "cluster.local", ServiceAccountName: "default"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				URIs: []string{"spiffe://cluster.local/ns/sandbox/sa/default"},
+			}),
+		},
+		"should not match if the certificate contains a different SPIFFE ID": {
+			spec: cmapi.CertificateSpec{
+				SPIFFE: &cmapi.SPIFFEID{TrustDomain: "cluster.local", ServiceAccountName: "other"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				URIs: []string{"spiffe://cluster.local/ns/sandbox/sa/default"},
+			}),
+			violations: []string{"spec.uris"},
+		},
 		"should not match if ipAddresses has been made the commonName": {
 			spec: cmapi.CertificateSpec{
 				IPAddresses: []string{"127.0.0.1"},
@@ -258,7 +293,10 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			violations, err := SecretDataAltNamesMatchSpec(&corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: test.data}}, test.spec)
+			violations, err := SecretDataAltNamesMatchSpec(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "sandbox"},
+				Data:       map[string][]byte{corev1.TLSCertKey: test.data},
+			}, test.spec)
 			switch {
 			case err != nil:
 				if test.err != err.Error() {
@@ -361,3 +399,89 @@ func TestRenewalTime(t *testing.T) {
 		})
 	}
 }
+
+func TestRenewalTimeWithJitter(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	notBefore, notAfter := now, now.Add(time.Hour*24*90)
+	renewBefore := 30 * 24 * time.Hour
+	renewalTime := RenewalTime(notBefore, notAfter, nil)
+
+	crt := func(name string) *cmapi.Certificate {
+		return &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name}}
+	}
+
+	t.Run("no jitter is applied if the jitter percentage is zero", func(t *testing.T) {
+		assert.Equal(t, renewalTime, RenewalTimeWithJitter(crt("cert"), notBefore, notAfter, 0))
+	})
+
+	t.Run("jitter is within the configured percentage of the renew before period", func(t *testing.T) {
+		earliest := renewalTime.Add(-renewBefore / 10)
+		spread := make(map[time.Time]bool)
+		for i := 0; i < 100; i++ {
+			jittered := RenewalTimeWithJitter(crt(fmt.Sprintf("cert-%d", i)), notBefore, notAfter, 10)
+			if jittered.After(renewalTime.Time) || jittered.Time.Before(earliest) {
+				t.Errorf("expected renewal time between %v and %v, got %v", earliest, renewalTime, jittered)
+			}
+			if !jittered.Time.Equal(jittered.Truncate(time.Second)) {
+				t.Errorf("expected renewal time to be truncated to the second, got %v", jittered)
+			}
+			spread[jittered.Time] = true
+		}
+		if len(spread) < 90 {
+			t.Errorf("expected renewal times of certificates with identical lifetimes to be spread out, got only %d distinct times", len(spread))
+		}
+	})
+
+	t.Run("jitter is stable for a certificate", func(t *testing.T) {
+		first := RenewalTimeWithJitter(crt("cert"), notBefore, notAfter, 10)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, first, RenewalTimeWithJitter(crt("cert"), notBefore, notAfter, 10))
+		}
+	})
+
+	t.Run("jitter respects spec.renewBefore", func(t *testing.T) {
+		c := crt("cert")
+		c.Spec.RenewBefore = &metav1.Duration{Duration: time.Hour}
+		renewalTime := RenewalTime(notBefore, notAfter, c.Spec.RenewBefore)
+		jittered := RenewalTimeWithJitter(c, notBefore, notAfter, 100)
+		if jittered.After(renewalTime.Time) || jittered.Time.Before(renewalTime.Add(-time.Hour)) {
+			t.Errorf("expected renewal time between %v and %v, got %v", renewalTime.Add(-time.Hour), renewalTime, jittered)
+		}
+	})
+}
+
+func TestFallbackIssuerRefs(t *testing.T) {
+	primary := cmmeta.ObjectReference{Name: "internal", Kind: "ClusterIssuer"}
+	first := cmmeta.ObjectReference{Name: "external", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+	second := cmmeta.ObjectReference{Name: "external", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"}
+	spec := cmapi.CertificateSpec{IssuerRef: primary, FallbackIssuerRefs: []cmmeta.ObjectReference{first, second}}
+
+	t.Run("issuers are tried in order", func(t *testing.T) {
+		assert.Equal(t, []cmmeta.ObjectReference{primary, first, second}, IssuerRefs(spec))
+
+		next, ok := NextIssuerRef(spec, primary)
+		assert.True(t, ok)
+		assert.Equal(t, first, next)
+
+		// The defaulted group of the first fallback issuer is not significant.
+		next, ok = NextIssuerRef(spec, cmmeta.ObjectReference{Name: "external", Kind: "ClusterIssuer"})
+		assert.True(t, ok)
+		assert.Equal(t, second, next)
+
+		_, ok = NextIssuerRef(spec, second)
+		assert.False(t, ok)
+		_, ok = NextIssuerRef(spec, cmmeta.ObjectReference{Name: "unknown"})
+		assert.False(t, ok)
+	})
+
+	t.Run("the current issuer is the recorded fallback issuer if it is still listed", func(t *testing.T) {
+		crt := &cmapi.Certificate{Spec: spec}
+		assert.Equal(t, primary, CurrentIssuerRef(crt))
+
+		crt.Status.FallbackIssuerRef = &second
+		assert.Equal(t, second, CurrentIssuerRef(crt))
+
+		crt.Spec.FallbackIssuerRefs = []cmmeta.ObjectReference{first}
+		assert.Equal(t, primary, CurrentIssuerRef(crt))
+	})
+}
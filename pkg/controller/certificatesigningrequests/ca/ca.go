@@ -31,9 +31,11 @@ import (
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -51,8 +53,9 @@ type signingFn func([]*x509.Certificate, crypto.Signer, *x509.Certificate) (pki.
 // signing CertificateSigningRequests that reference a cert-manager CA Issuer
 // or ClusterIssuer
 type CA struct {
-	issuerOptions controllerpkg.IssuerOptions
-	secretsLister corelisters.SecretLister
+	issuerOptions     controllerpkg.IssuerOptions
+	secretsLister     corelisters.SecretLister
+	certificateLister cmlisters.CertificateLister
 
 	certClient certificatesclient.CertificateSigningRequestInterface
 
@@ -79,6 +82,7 @@ func NewCA(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 	return &CA{
 		issuerOptions:     ctx.IssuerOptions,
 		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
 		certClient:        ctx.Client.CertificatesV1().CertificateSigningRequests(),
 		fieldManager:      ctx.FieldManager,
 		recorder:          ctx.Recorder,
@@ -94,11 +98,23 @@ func NewCA(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, issuerObj cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx, "sign")
 
-	secretName := issuerObj.GetSpec().CA.SecretName
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
+	secretName, err := caissuer.SigningSecretName(c.certificateLister, resourceNamespace, issuerObj.GetSpec().CA)
+	if apierrors.IsNotFound(err) {
+		message := fmt.Sprintf("Referenced Certificate %s/%s not found", resourceNamespace, issuerObj.GetSpec().CA.CertificateName)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "CertificateMissing", message)
+		return nil
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("Failed to get Certificate %s/%s", resourceNamespace, issuerObj.GetSpec().CA.CertificateName)
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "CertificateGetError", "%s: %s", message, err)
+		return err
+	}
+
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, secretName)
 	if apierrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "SecretMissing", message)
@@ -129,6 +145,8 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
+	template.IssuingCertificateURL = issuerObj.GetSpec().CA.IssuingCertificateURLs
+	pki.SetCAPathLen(template, issuerObj.GetSpec().CA.PathLen)
 
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/sync/semaphore"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -78,6 +79,10 @@ type Controller struct {
 	signerConstructor SignerConstructor
 	signer            Signer
 
+	// signingLimiter, if set, is shared with all other signing controllers to
+	// bound the number of concurrent Sign calls
+	signingLimiter *semaphore.Weighted
+
 	// the signer kind to react to when a certificate signing request is synced
 	signerType string
 
@@ -185,6 +190,8 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.certClient = kubeClient.CertificatesV1().CertificateSigningRequests()
 	c.fieldManager = ctx.FieldManager
 
+	c.signingLimiter = ctx.IssuerOptions.SigningLimiter
+
 	// Construct the signer implementation with the built component context.
 	c.signer = c.signerConstructor(ctx)
 
@@ -37,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
@@ -781,6 +782,66 @@ func TestSign(t *testing.T) {
 				assert.Equal(t, true, got.IsCA)
 			},
 		},
+		"when the Issuer has a pathLen of 0 and the CertificateSigningRequest has the isCA field set, the signed certificate should only be able to sign end-entity certificates": {
+			csr: gen.CertificateSigningRequest("csr-1",
+				gen.AddCertificateSigningRequestAnnotations(map[string]string{
+					"experimental.cert-manager.io/private-key-secret-name": "test-secret",
+				}),
+				gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/default-unit-test-ns.issuer-1"),
+				gen.SetCertificateSigningRequestRequest(csrBundle.csrPEM),
+				gen.SetCertificateSigningRequestIsCA(true),
+			),
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{
+					PathLen: pointer.Int(0),
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.True(t, got.IsCA)
+				assert.Equal(t, 0, got.MaxPathLen)
+				assert.True(t, got.MaxPathLenZero)
+			},
+		},
+		"when the Issuer has a positive pathLen and the CertificateSigningRequest has the isCA field set, it should appear on the signed certificate": {
+			csr: gen.CertificateSigningRequest("csr-1",
+				gen.AddCertificateSigningRequestAnnotations(map[string]string{
+					"experimental.cert-manager.io/private-key-secret-name": "test-secret",
+				}),
+				gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/default-unit-test-ns.issuer-1"),
+				gen.SetCertificateSigningRequestRequest(csrBundle.csrPEM),
+				gen.SetCertificateSigningRequestIsCA(true),
+			),
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{
+					PathLen: pointer.Int(2),
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.True(t, got.IsCA)
+				assert.Equal(t, 2, got.MaxPathLen)
+				assert.False(t, got.MaxPathLenZero)
+			},
+		},
+		"when the Issuer has pathLen set but the CertificateSigningRequest does not have the isCA field set, no path length constraint should be set": {
+			csr: gen.CertificateSigningRequest("csr-1",
+				gen.AddCertificateSigningRequestAnnotations(map[string]string{
+					"experimental.cert-manager.io/private-key-secret-name": "test-secret",
+				}),
+				gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/default-unit-test-ns.issuer-1"),
+				gen.SetCertificateSigningRequestRequest(csrBundle.csrPEM),
+				gen.SetCertificateSigningRequestIsCA(false),
+			),
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{
+					PathLen: pointer.Int(0),
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.False(t, got.IsCA)
+				assert.Equal(t, -1, got.MaxPathLen)
+				assert.False(t, got.MaxPathLenZero)
+			},
+		},
 		"when the Issuer has crlDistributionPoints set, it should appear on the signed ca ": {
 			csr: gen.CertificateSigningRequest("cr-1",
 				gen.AddCertificateSigningRequestAnnotations(map[string]string{
@@ -160,6 +160,13 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 
 	dbg.Info("invoking sign function as existing certificate does not exist")
 
+	if c.signingLimiter != nil {
+		if err := c.signingLimiter.Acquire(ctx, 1); err != nil {
+			return err
+		}
+		defer c.signingLimiter.Release(1)
+	}
+
 	return c.signer.Sign(ctx, csr, issuerObj)
 }
 
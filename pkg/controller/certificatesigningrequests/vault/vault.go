@@ -58,6 +58,9 @@ type Vault struct {
 
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
+
+	// userAgent is the User-Agent sent with requests to Vault.
+	userAgent string
 }
 
 func init() {
@@ -76,6 +79,7 @@ func NewVault(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 		certClient:    ctx.Client.CertificatesV1().CertificateSigningRequests(),
 		clientBuilder: internalvault.New,
 		fieldManager:  ctx.FieldManager,
+		userAgent:     ctx.UserAgent,
 	}
 }
 
@@ -89,7 +93,7 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.clientBuilder(resourceNamespace, v.secretsLister, issuerObj)
+	client, err := v.clientBuilder(resourceNamespace, v.secretsLister, issuerObj, v.userAgent)
 	if apierrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 		log.Error(err, message)
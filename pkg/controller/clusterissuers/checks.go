@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -49,7 +50,13 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 				}
 			}
 		case iss.Spec.CA != nil:
-			if iss.Spec.CA.SecretName == secret.Name {
+			secretName, err := caissuer.SigningSecretName(c.certificateLister, secret.Namespace, iss.Spec.CA)
+			if err != nil {
+				// the referenced Certificate does not exist (yet), so it
+				// cannot be affected by this secret
+				continue
+			}
+			if secretName == secret.Name {
 				affected = append(affected, iss)
 				continue
 			}
@@ -59,6 +66,10 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					affected = append(affected, iss)
 					continue
 				}
+				if ref := iss.Spec.Venafi.TPP.CABundleSecretRef; ref != nil && ref.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
 			}
 			if iss.Spec.Venafi.Cloud != nil {
 				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.Name {
@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"text/template"
 	"time"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/sync/semaphore"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -46,6 +48,7 @@ import (
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -131,6 +134,12 @@ type ContextOptions struct {
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
+	// UserAgent is the User-Agent sent by the HTTP clients of issuers and
+	// DNS01 providers, for example to ACME servers, Vault, Venafi and DNS
+	// provider APIs. If empty, the User Agent of the component's Kubernetes
+	// clients is used.
+	UserAgent string
+
 	IssuerOptions
 	ACMEOptions
 	IngressShimOptions
@@ -151,6 +160,11 @@ type IssuerOptions struct {
 	// IssuerAmbientCredentials controls whether an issuer should pick up ambient
 	// credentials, such as those from metadata services, to construct clients.
 	IssuerAmbientCredentials bool
+
+	// SigningLimiter, if set, is shared by all CertificateRequest and
+	// CertificateSigningRequest controllers to bound the total number of
+	// in-flight calls to an issuer's Sign function.
+	SigningLimiter *semaphore.Weighted
 }
 
 type ACMEOptions struct {
@@ -178,6 +192,10 @@ type ACMEOptions struct {
 	// for checking propagation of an RR. This is the ideal scenario
 	DNS01CheckAuthoritative bool
 
+	// DNS01NameserverStrategy determines how the answers of the recursive
+	// nameservers are combined when DNS01CheckAuthoritative is false.
+	DNS01NameserverStrategy dnsutil.NameserverStrategy
+
 	// DNS01Nameservers is a list of nameservers to use when performing self-checks
 	// for ACME DNS01 validations.
 	DNS01Nameservers []string
@@ -188,6 +206,25 @@ type ACMEOptions struct {
 
 	// DNS01CheckRetryPeriod is the time the controller should wait between checking if a ACME dns entry exists.
 	DNS01CheckRetryPeriod time.Duration
+
+	// MaxConcurrentDNS01SelfChecks is the maximum number of DNS01 propagation
+	// self checks that may run at once. Zero means self checks are run
+	// synchronously by the challenges controller's workers.
+	MaxConcurrentDNS01SelfChecks int
+
+	// EABKeyDir is the directory from which external account binding keys
+	// referenced using keyFile are read. If empty, keys can only be read
+	// from Secrets.
+	EABKeyDir string
+
+	// ChallengeForceCleanUpOnIssuerDeletion causes the resources presented
+	// for ACME challenges to be cleaned up if their issuer has been deleted,
+	// using the solver configuration copied to the challenge.
+	ChallengeForceCleanUpOnIssuerDeletion bool
+
+	// OrdersListInterval is the minimum time between listings of the orders
+	// of an ACME issuer's account. Zero disables listing orders.
+	OrdersListInterval time.Duration
 }
 
 // IngressShimOptions contain default Issuer GVK config for the certificate-shim controllers.
@@ -207,6 +244,46 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+	// NotBeforeTolerance is how far in the future the NotBefore time of an
+	// issued certificate may be before the NotYetValid condition is set on the
+	// Certificate.
+	NotBeforeTolerance time.Duration
+	// ExpirationImminentWindow is how close to its expiry a certificate whose
+	// renewal is failing must be for it to be reported as about to expire,
+	// and for issuance of a Failed Certificate to be retried. Zero disables
+	// both.
+	ExpirationImminentWindow time.Duration
+	// CertificateRequestMaxRetryBackoff is the maximum time to wait before
+	// retrying a CertificateRequest whose signing attempt failed.
+	CertificateRequestMaxRetryBackoff time.Duration
+	// DisableTemporaryCertificates prevents temporary self-signed
+	// certificates from being written to Secrets during issuance, regardless
+	// of the issue-temporary-certificate annotation.
+	DisableTemporaryCertificates bool
+	// CertificateRequestNameTemplate is used to name the CertificateRequests
+	// created for each revision of a Certificate. If nil, names are
+	// generated from the Certificate's name.
+	CertificateRequestNameTemplate *template.Template
+	// MaxIssuanceAttempts is the number of consecutive failed issuances
+	// after which a Certificate is marked as Failed. Issuance of a Failed
+	// Certificate is only retried once its spec changes or its certificate is
+	// within ExpirationImminentWindow of expiring. Zero means no limit.
+	MaxIssuanceAttempts int
+	// SkipUnchangedSecretWrites prevents Certificate Secrets from being
+	// written if the write would not change their content.
+	SkipUnchangedSecretWrites bool
+	// RenewalJitterPercent is the maximum percentage of a certificate's renew
+	// before period by which its renewal is brought forward. Zero disables
+	// jitter.
+	RenewalJitterPercent int
+	// IssuedWebhookURL is the URL of a webhook notified whenever a
+	// certificate is issued. If empty, no notifications are sent.
+	IssuedWebhookURL string
+	// IssuedWebhookTimeout is the timeout of each notification attempt.
+	IssuedWebhookTimeout time.Duration
+	// IssuedWebhookMaxRetries is the number of times a failed notification
+	// is retried.
+	IssuedWebhookMaxRetries int
 }
 
 type SchedulerOptions struct {
@@ -302,6 +379,9 @@ func (c *ContextFactory) Build(component ...string) (*Context, error) {
 
 	ctx := *c.ctx
 	ctx.FieldManager = util.PrefixFromUserAgent(restConfig.UserAgent)
+	if ctx.UserAgent == "" {
+		ctx.UserAgent = restConfig.UserAgent
+	}
 	ctx.RESTConfig = restConfig
 	ctx.Client = clients.kubeClient
 	ctx.CMClient = clients.cmClient
@@ -35,8 +35,9 @@ import (
 )
 
 type controller struct {
-	issuerLister cmlisters.IssuerLister
-	secretLister corelisters.SecretLister
+	issuerLister      cmlisters.IssuerLister
+	secretLister      corelisters.SecretLister
+	certificateLister cmlisters.CertificateLister
 
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
@@ -72,16 +73,21 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// obtain references to all the informers used by this controller
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
 	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	// certificates are watched so that CA issuers referencing a Certificate
+	// can resolve the Secret holding the signing keypair
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		issuerInformer.Informer().HasSynced,
 		secretInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
 	}
 
 	// set all the references to the listers for used by the Sync function
 	c.issuerLister = issuerInformer.Lister()
 	c.secretLister = secretInformer.Lister()
+	c.certificateLister = certificateInformer.Lister()
 
 	// register handler functions
 	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
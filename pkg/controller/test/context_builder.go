@@ -163,6 +163,9 @@ func (b *Builder) Init() {
 func (b *Builder) InitWithRESTConfig() {
 	b.Init()
 	b.RESTConfig = util.RestConfigWithUserAgent(new(rest.Config), "unit-testing")
+	if b.UserAgent == "" {
+		b.UserAgent = b.RESTConfig.UserAgent
+	}
 }
 
 func (b *Builder) FakeKubeClient() *kubefake.Clientset {
@@ -20,16 +20,20 @@ import (
 	"context"
 	"crypto"
 	"fmt"
+	"time"
 
+	"k8s.io/client-go/discovery"
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
 )
@@ -41,8 +45,13 @@ type Acme struct {
 	issuer v1.GenericIssuer
 
 	secretsClient core.SecretsGetter
+	secretsLister corelisters.SecretLister
 	recorder      record.EventRecorder
 
+	// discoveryClient is used to check that DNS01 webhook solvers are served
+	// by the apiserver.
+	discoveryClient discovery.DiscoveryInterface
+
 	// keyFromSecret returns a decoded account key from a Kubernetes secret.
 	// It can be stubbed in unit tests.
 	keyFromSecret keyFromSecretFunc
@@ -50,6 +59,17 @@ type Acme struct {
 	// clientBuilder builds a new ACME client.
 	clientBuilder accounts.NewClientFunc
 
+	// validateDNS01 performs a dry run of a DNS01 solver's provider.
+	// It can be stubbed in unit tests.
+	validateDNS01 validateDNS01Func
+
+	// listOrders lists the orders of the ACME account.
+	// It can be stubbed in unit tests.
+	listOrders listOrdersFunc
+	// ordersListInterval is the minimum time between listings of the
+	// account's orders. Zero disables listing orders.
+	ordersListInterval time.Duration
+
 	// namespace of referenced resources when the given issuer is a ClusterIssuer
 	clusterResourceNamespace string
 	// used as a cache for ACME clients
@@ -60,6 +80,10 @@ type Acme struct {
 
 	// userAgent is the string used as the UserAgent when making HTTP calls.
 	userAgent string
+
+	// eabKeyDir is the directory from which external account binding keys
+	// referenced using keyFile are read.
+	eabKeyDir string
 }
 
 // New returns a new ACME issuer interface for the given issuer.
@@ -74,12 +98,18 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		issuer:                   issuer,
 		keyFromSecret:            newKeyFromSecret(secretsLister),
 		clientBuilder:            accounts.NewClient,
+		validateDNS01:            dns.NewValidator(ctx).Validate,
+		listOrders:               listOrders,
+		ordersListInterval:       ctx.ACMEOptions.OrdersListInterval,
 		secretsClient:            ctx.Client.CoreV1(),
+		secretsLister:            secretsLister,
+		discoveryClient:          ctx.DiscoveryClient,
 		recorder:                 ctx.Recorder,
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
 		metrics:                  ctx.Metrics,
-		userAgent:                ctx.RESTConfig.UserAgent,
+		userAgent:                ctx.UserAgent,
+		eabKeyDir:                ctx.ACMEOptions.EABKeyDir,
 	}
 
 	return a, nil
@@ -96,6 +126,10 @@ func newKeyFromSecret(secretLister corelisters.SecretLister) keyFromSecretFunc {
 	}
 }
 
+// validateDNS01Func validates the credentials and zone access of the DNS01
+// provider configured on the given solver, without presenting a record.
+type validateDNS01Func func(ctx context.Context, issuer v1.GenericIssuer, solver cmacme.ACMEChallengeSolver) error
+
 // Register this Issuer with the issuer factory
 func init() {
 	issuer.RegisterIssuer(apiutil.IssuerACME, New)
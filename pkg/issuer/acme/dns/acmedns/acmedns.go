@@ -22,17 +22,35 @@ limitations under the License.
 package acmedns
 
 import (
+	"bytes"
+	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/cpu/goacmedns"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
 
+// updateTimeout is the timeout of requests to update a TXT record, which is
+// the same as that used by goacmedns.
+const updateTimeout = 30 * time.Second
+
+// txtRecordUpdater updates the TXT record of an ACME-DNS account. It is
+// implemented by goacmedns.Client.
+type txtRecordUpdater interface {
+	UpdateTXTRecord(account goacmedns.Account, value string) error
+}
+
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers []string
-	client           goacmedns.Client
+	client           txtRecordUpdater
 	accounts         map[string]goacmedns.Account
 }
 
@@ -41,14 +59,21 @@ type DNSProvider struct {
 func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
 	host := os.Getenv("ACME_DNS_HOST")
 	accountJSON := os.Getenv("ACME_DNS_ACCOUNT_JSON")
-	return NewDNSProviderHostBytes(host, []byte(accountJSON), dns01Nameservers)
+	return NewDNSProviderHostBytes(host, []byte(accountJSON), dns01Nameservers, nil)
 }
 
 // NewDNSProviderHostBytes returns a DNSProvider instance configured for ACME DNS
 // acme-dns server host is given in a string
 // credentials are stored in json in the given string
-func NewDNSProviderHostBytes(host string, accountJSON []byte, dns01Nameservers []string) (*DNSProvider, error) {
-	client := goacmedns.NewClient(host)
+// If rootCAs is not nil, it replaces the system roots when verifying the TLS
+// certificate of the acme-dns server.
+func NewDNSProviderHostBytes(host string, accountJSON []byte, dns01Nameservers []string, rootCAs *x509.CertPool) (*DNSProvider, error) {
+	var client txtRecordUpdater = goacmedns.NewClient(host)
+	if httpClient := util.HTTPClientWithRootCAs(rootCAs); httpClient != nil {
+		// goacmedns always uses its own HTTP client, so the update request
+		// is made directly instead.
+		client = &httpUpdater{baseURL: host, httpClient: httpClient}
+	}
 
 	var accounts map[string]goacmedns.Account
 	if err := json.Unmarshal(accountJSON, &accounts); err != nil {
@@ -79,3 +104,53 @@ func (c *DNSProvider) CleanUp(_, _, _ string) error {
 	// ACME-DNS it is expected the stale records remain in-place.
 	return nil
 }
+
+// httpUpdater updates TXT records with the same request as goacmedns.Client
+// using the given HTTP client.
+type httpUpdater struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func (u *httpUpdater) UpdateTXTRecord(account goacmedns.Account, value string) error {
+	body, err := json.Marshal(struct {
+		SubDomain string
+		Txt       string
+	}{
+		SubDomain: account.SubDomain,
+		Txt:       value,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal update: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.baseURL+"/update", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-User", account.Username)
+	req.Header.Set("X-Api-Key", account.Password)
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return goacmedns.ClientError{
+			Message:    "failed to update txt record",
+			HTTPStatus: resp.StatusCode,
+			Body:       respBody,
+		}
+	}
+	return nil
+}
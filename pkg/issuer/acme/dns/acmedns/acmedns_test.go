@@ -17,6 +17,10 @@ limitations under the License.
 package acmedns
 
 import (
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -49,20 +53,20 @@ func TestValidJsonAccount(t *testing.T) {
             "username": "usernom"
         }
     }`)
-	provider, err := NewDNSProviderHostBytes("http://localhost/", accountJSON, util.RecursiveNameservers)
+	provider, err := NewDNSProviderHostBytes("http://localhost/", accountJSON, util.RecursiveNameservers, nil)
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 	assert.Equal(t, provider.accounts["domain"].FullDomain, "fooldom")
 }
 
 func TestNoValidJsonAccount(t *testing.T) {
 	accountJson := []byte(`{"duck": "quack"}`)
-	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers)
+	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers, nil)
 	assert.Error(t, err, "Expected error constructing DNSProvider from invalid accountJson")
 }
 
 func TestNoValidJson(t *testing.T) {
 	accountJson := []byte("b00m")
-	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers)
+	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers, nil)
 	assert.Error(t, err, "Expected error constructing DNSProvider from invalid JSON")
 }
 
@@ -70,10 +74,54 @@ func TestLiveAcmeDnsPresent(t *testing.T) {
 	if !acmednsLiveTest {
 		t.Skip("skipping live test")
 	}
-	provider, err := NewDNSProviderHostBytes(acmednsHost, acmednsAccountJSON, util.RecursiveNameservers)
+	provider, err := NewDNSProviderHostBytes(acmednsHost, acmednsAccountJSON, util.RecursiveNameservers, nil)
 	assert.NoError(t, err)
 
 	// ACME-DNS requires 43 character keys or it throws a bad TXT error
 	err = provider.Present(acmednsDomain, "", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
 	assert.NoError(t, err)
 }
+
+func TestPresentWithRootCAs(t *testing.T) {
+	var gotUser, gotKey string
+	var gotUpdate struct {
+		SubDomain string
+		Txt       string
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/update" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotUser, gotKey = r.Header.Get("X-Api-User"), r.Header.Get("X-Api-Key")
+		if err := json.NewDecoder(r.Body).Decode(&gotUpdate); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}))
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	accountJSON := []byte(`{
+        "domain": {
+            "fulldomain": "fooldom",
+            "password": "secret",
+            "subdomain": "subdoom",
+            "username": "usernom"
+        }
+    }`)
+	provider, err := NewDNSProviderHostBytes(server.URL, accountJSON, util.RecursiveNameservers, rootCAs)
+	assert.NoError(t, err)
+
+	err = provider.Present("domain", "", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
+	assert.NoError(t, err)
+	assert.Equal(t, "usernom", gotUser)
+	assert.Equal(t, "secret", gotKey)
+	assert.Equal(t, "subdoom", gotUpdate.SubDomain)
+	assert.Equal(t, "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE", gotUpdate.Txt)
+
+	err = provider.Present("unknown", "", "LG3tptA6W7T1vw4ujbmDxH2lLu6r8TUIqLZD3pzPmgE")
+	assert.Error(t, err, "Expected error for a domain without account credentials")
+}
@@ -131,7 +131,7 @@ func (a *DNSProvider) Present(domain, fqdn, value string) error {
 			return nil
 		}
 
-		record.Target = append(record.Target, `"`+value+`"`)
+		record.Target = append(record.Target, util.QuoteTXTValue(value))
 		record.TTL = a.TTL
 
 		err = a.dnsclient.RecordUpdate(record, hostedDomain)
@@ -146,7 +146,7 @@ func (a *DNSProvider) Present(domain, fqdn, value string) error {
 		Name:       recordName,
 		RecordType: "TXT",
 		TTL:        a.TTL,
-		Target:     []string{`"` + value + `"`},
+		Target:     []string{util.QuoteTXTValue(value)},
 	}
 
 	err = a.dnsclient.RecordSave(record, hostedDomain)
@@ -197,8 +197,7 @@ func (a *DNSProvider) CleanUp(domain, fqdn, value string) error {
 
 	var newRData []string
 	for _, val := range existingRec.Target {
-		tval := strings.Trim(val, `"`)
-		if tval == value {
+		if util.UnquoteTXTValue(val) == value {
 			continue
 		}
 		newRData = append(newRData, val)
@@ -226,7 +225,7 @@ func (a *DNSProvider) CleanUp(domain, fqdn, value string) error {
 
 func containsValue(values []string, value string) bool {
 	for _, val := range values {
-		if strings.Trim(val, `"`) == value {
+		if util.UnquoteTXTValue(val) == value {
 			return true
 		}
 	}
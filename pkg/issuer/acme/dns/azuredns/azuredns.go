@@ -12,12 +12,16 @@ package azuredns
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/go-logr/logr"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2017-10-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -28,6 +32,19 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// privateRecordSetsClient is the subset of privatedns.RecordSetsClient used
+// to manage the TXT records of Azure Private DNS zones.
+type privateRecordSetsClient interface {
+	CreateOrUpdate(ctx context.Context, resourceGroupName string, privateZoneName string, recordType privatedns.RecordType, relativeRecordSetName string, parameters privatedns.RecordSet, ifMatch string, ifNoneMatch string) (privatedns.RecordSet, error)
+	Delete(ctx context.Context, resourceGroupName string, privateZoneName string, recordType privatedns.RecordType, relativeRecordSetName string, ifMatch string) (autorest.Response, error)
+}
+
+// privateZonesClient is the subset of privatedns.PrivateZonesClient used to
+// look up Azure Private DNS zones.
+type privateZonesClient interface {
+	Get(ctx context.Context, resourceGroupName string, privateZoneName string) (privatedns.PrivateZone, error)
+}
+
 // DNSProvider implements the util.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers  []string
@@ -36,39 +53,129 @@ type DNSProvider struct {
 	resourceGroupName string
 	zoneName          string
 	log               logr.Logger
+
+	// privateZone is true if the records are created in Azure Private DNS
+	// zones, using the private clients rather than the public ones.
+	privateZone         bool
+	privateRecordClient privateRecordSetsClient
+	privateZoneClient   privateZonesClient
+}
+
+// Options configures a DNSProvider created with NewDNSProviderWithOptions.
+type Options struct {
+	// Environment is the name of the Azure cloud environment. If empty, the
+	// Azure public cloud is used.
+	Environment string
+
+	// ClientID, ClientSecret and TenantID are the credentials of a service
+	// principal. If ClientID is empty and Ambient is true, a managed identity
+	// is used instead, optionally the one selected by ManagedIdentity.
+	ClientID        string
+	ClientSecret    string
+	TenantID        string
+	Ambient         bool
+	ManagedIdentity *cmacme.AzureManagedIdentity
+
+	SubscriptionID    string
+	ResourceGroupName string
+	ZoneName          string
+
+	// PrivateZone is true if the zone is an Azure Private DNS zone.
+	PrivateZone bool
+
+	DNS01Nameservers []string
+	UserAgent        string
+
+	// RootCAs, if not nil, replaces the system roots when verifying the TLS
+	// certificates of the Azure APIs.
+	RootCAs *x509.CertPool
 }
 
 // NewDNSProviderCredentials returns a DNSProvider instance configured for the Azure
-// DNS service using static credentials from its parameters
+// DNS service using static credentials from its parameters.
+// Use NewDNSProviderWithOptions to configure any further options.
 func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*DNSProvider, error) {
+	return NewDNSProviderWithOptions(Options{
+		Environment:       environment,
+		ClientID:          clientID,
+		ClientSecret:      clientSecret,
+		TenantID:          tenantID,
+		Ambient:           ambient,
+		ManagedIdentity:   managedIdentity,
+		SubscriptionID:    subscriptionID,
+		ResourceGroupName: resourceGroupName,
+		ZoneName:          zoneName,
+		DNS01Nameservers:  dns01Nameservers,
+	})
+}
+
+// NewDNSProviderWithOptions returns a DNSProvider instance configured for the
+// Azure DNS service with the given options.
+func NewDNSProviderWithOptions(opts Options) (*DNSProvider, error) {
 	env := azure.PublicCloud
-	if environment != "" {
+	if opts.Environment != "" {
 		var err error
-		env, err = azure.EnvironmentFromName(environment)
+		env, err = azure.EnvironmentFromName(opts.Environment)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	spt, err := getAuthorization(env, clientID, clientSecret, subscriptionID, tenantID, ambient, managedIdentity)
+	spt, err := getAuthorization(env, opts.ClientID, opts.ClientSecret, opts.SubscriptionID, opts.TenantID, opts.Ambient, opts.ManagedIdentity)
 	if err != nil {
 		return nil, err
 	}
 
-	rc := dns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	// A nil sender leaves the default senders of the Azure clients in place.
+	var sender autorest.Sender
+	if httpClient := util.HTTPClientWithRootCAs(opts.RootCAs); httpClient != nil {
+		sender = httpClient
+		spt.SetSender(httpClient)
+	}
+
+	rc := dns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, opts.SubscriptionID)
 	rc.Authorizer = autorest.NewBearerAuthorizer(spt)
+	rc.Sender = sender
 
-	zc := dns.NewZonesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	zc := dns.NewZonesClientWithBaseURI(env.ResourceManagerEndpoint, opts.SubscriptionID)
 	zc.Authorizer = autorest.NewBearerAuthorizer(spt)
+	zc.Sender = sender
 
-	return &DNSProvider{
-		dns01Nameservers:  dns01Nameservers,
+	if opts.UserAgent != "" {
+		// AddToUserAgent only fails if the extension is empty.
+		_ = rc.AddToUserAgent(opts.UserAgent)
+		_ = zc.AddToUserAgent(opts.UserAgent)
+	}
+
+	provider := &DNSProvider{
+		dns01Nameservers:  opts.DNS01Nameservers,
 		recordClient:      rc,
 		zoneClient:        zc,
-		resourceGroupName: resourceGroupName,
-		zoneName:          zoneName,
+		resourceGroupName: opts.ResourceGroupName,
+		zoneName:          opts.ZoneName,
 		log:               logf.Log.WithName("azure-dns"),
-	}, nil
+	}
+
+	if opts.PrivateZone {
+		prc := privatedns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, opts.SubscriptionID)
+		prc.Authorizer = autorest.NewBearerAuthorizer(spt)
+		prc.Sender = sender
+
+		pzc := privatedns.NewPrivateZonesClientWithBaseURI(env.ResourceManagerEndpoint, opts.SubscriptionID)
+		pzc.Authorizer = autorest.NewBearerAuthorizer(spt)
+		pzc.Sender = sender
+
+		if opts.UserAgent != "" {
+			_ = prc.AddToUserAgent(opts.UserAgent)
+			_ = pzc.AddToUserAgent(opts.UserAgent)
+		}
+
+		provider.privateZone = true
+		provider.privateRecordClient = prc
+		provider.privateZoneClient = pzc
+	}
+
+	return provider, nil
 }
 
 func getAuthorization(env azure.Environment, clientID, clientSecret, subscriptionID, tenantID string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*adal.ServicePrincipalToken, error) {
@@ -116,6 +223,16 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 		return err
 	}
 
+	if c.privateZone {
+		_, err = c.privateRecordClient.Delete(
+			context.TODO(),
+			c.resourceGroupName,
+			z,
+			privatedns.TXT,
+			c.trimFqdn(fqdn, z), "")
+		return err
+	}
+
 	_, err = c.recordClient.Delete(
 		context.TODO(),
 		c.resourceGroupName,
@@ -130,11 +247,15 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 }
 
 func (c *DNSProvider) createRecord(fqdn, value string, ttl int) error {
+	if c.privateZone {
+		return c.createPrivateRecord(fqdn, value, ttl)
+	}
+
 	rparams := &dns.RecordSet{
 		RecordSetProperties: &dns.RecordSetProperties{
 			TTL: to.Int64Ptr(int64(ttl)),
 			TxtRecords: &[]dns.TxtRecord{
-				{Value: &[]string{value}},
+				{Value: to.StringSlicePtr(util.SplitTXTValue(value))},
 			},
 		},
 	}
@@ -160,7 +281,41 @@ func (c *DNSProvider) createRecord(fqdn, value string, ttl int) error {
 	return nil
 }
 
+func (c *DNSProvider) createPrivateRecord(fqdn, value string, ttl int) error {
+	rparams := privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			TTL: to.Int64Ptr(int64(ttl)),
+			TxtRecords: &[]privatedns.TxtRecord{
+				{Value: to.StringSlicePtr(util.SplitTXTValue(value))},
+			},
+		},
+	}
+
+	z, err := c.getHostedZoneName(fqdn)
+	if err != nil {
+		c.log.Error(err, "Error getting hosted zone name for:", fqdn)
+		return err
+	}
+
+	_, err = c.privateRecordClient.CreateOrUpdate(
+		context.TODO(),
+		c.resourceGroupName,
+		z,
+		privatedns.TXT,
+		c.trimFqdn(fqdn, z),
+		rparams, "", "")
+
+	if err != nil {
+		c.log.Error(err, "Error creating TXT:", z)
+		return err
+	}
+	return nil
+}
+
 func (c *DNSProvider) getHostedZoneName(fqdn string) (string, error) {
+	if c.privateZone {
+		return c.getPrivateZoneName(fqdn)
+	}
 	if c.zoneName != "" {
 		return c.zoneName, nil
 	}
@@ -182,6 +337,39 @@ func (c *DNSProvider) getHostedZoneName(fqdn string) (string, error) {
 	return util.UnFqdn(z), nil
 }
 
+// getPrivateZoneName returns the name of the Azure Private DNS zone that the
+// fqdn belongs to. Private zones cannot be found by querying public
+// nameservers for the SOA record of the fqdn, so unless the zone name is
+// configured, the resource group is searched for the private zone with the
+// longest name that the fqdn is part of.
+func (c *DNSProvider) getPrivateZoneName(fqdn string) (string, error) {
+	domain := strings.ToLower(util.UnFqdn(fqdn))
+	if c.zoneName != "" {
+		zone := strings.ToLower(util.UnFqdn(c.zoneName))
+		if !strings.HasSuffix(domain, "."+zone) {
+			return "", fmt.Errorf("Domain %s is not part of the private zone %s", fqdn, c.zoneName)
+		}
+		return c.zoneName, nil
+	}
+
+	// The first label of the fqdn is the name of the challenge record, and
+	// private zone names have at least two labels.
+	labels := strings.Split(domain, ".")
+	for i := 1; i < len(labels)-1; i++ {
+		candidate := strings.Join(labels[i:], ".")
+		_, err := c.privateZoneClient.Get(context.TODO(), c.resourceGroupName, candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		var detailedErr autorest.DetailedError
+		if !errors.As(err, &detailedErr) || detailedErr.StatusCode != http.StatusNotFound {
+			return "", fmt.Errorf("Error looking up private zone %s in AzureDNS for domain %s. Err: %v", candidate, fqdn, err)
+		}
+	}
+
+	return "", fmt.Errorf("No private zone found in AzureDNS resource group %s for domain %s", c.resourceGroupName, fqdn)
+}
+
 // Trims DNS zone from the fqdn. Defaults to DNSProvider.zoneName if it is specified.
 func (c *DNSProvider) trimFqdn(fqdn string, zone string) string {
 	z := zone
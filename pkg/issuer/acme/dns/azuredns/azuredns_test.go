@@ -9,10 +9,17 @@ this directory.
 package azuredns
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/go-logr/logr"
+
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/stretchr/testify/assert"
@@ -77,3 +84,171 @@ func TestInvalidAzureDns(t *testing.T) {
 	_, err := NewDNSProviderCredentials("invalid env", "cid", "secret", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
 	assert.Error(t, err)
 }
+
+// fakePrivateDNS is a fake Azure Private DNS API, implementing the private
+// record sets and zones clients.
+type fakePrivateDNS struct {
+	// zones are the names of the private zones in the resource group
+	zones map[string]bool
+	// getErr, if set, is returned when looking up any zone
+	getErr error
+
+	// records are the values of the TXT records, keyed by zone and then by
+	// relative record set name
+	records map[string]map[string][]string
+	ttls    map[string]int64
+	gets    []string
+}
+
+func newFakePrivateDNS(zones ...string) *fakePrivateDNS {
+	f := &fakePrivateDNS{zones: map[string]bool{}, records: map[string]map[string][]string{}, ttls: map[string]int64{}}
+	for _, zone := range zones {
+		f.zones[zone] = true
+	}
+	return f
+}
+
+func (f *fakePrivateDNS) Get(_ context.Context, resourceGroupName string, privateZoneName string) (privatedns.PrivateZone, error) {
+	f.gets = append(f.gets, privateZoneName)
+	if f.getErr != nil {
+		return privatedns.PrivateZone{}, f.getErr
+	}
+	if resourceGroupName != "test-rg" || !f.zones[privateZoneName] {
+		return privatedns.PrivateZone{}, autorest.DetailedError{StatusCode: http.StatusNotFound, Message: "zone not found"}
+	}
+	return privatedns.PrivateZone{Name: &privateZoneName}, nil
+}
+
+func (f *fakePrivateDNS) CreateOrUpdate(_ context.Context, resourceGroupName string, privateZoneName string, recordType privatedns.RecordType, relativeRecordSetName string, parameters privatedns.RecordSet, _ string, _ string) (privatedns.RecordSet, error) {
+	if resourceGroupName != "test-rg" || !f.zones[privateZoneName] || recordType != privatedns.TXT {
+		return privatedns.RecordSet{}, errors.New("unexpected record set")
+	}
+	if f.records[privateZoneName] == nil {
+		f.records[privateZoneName] = map[string][]string{}
+	}
+	var values []string
+	for _, record := range *parameters.TxtRecords {
+		values = append(values, *record.Value...)
+	}
+	f.records[privateZoneName][relativeRecordSetName] = values
+	f.ttls[privateZoneName+"/"+relativeRecordSetName] = *parameters.TTL
+	return parameters, nil
+}
+
+func (f *fakePrivateDNS) Delete(_ context.Context, resourceGroupName string, privateZoneName string, recordType privatedns.RecordType, relativeRecordSetName string, _ string) (autorest.Response, error) {
+	if resourceGroupName != "test-rg" || !f.zones[privateZoneName] || recordType != privatedns.TXT {
+		return autorest.Response{}, errors.New("unexpected record set")
+	}
+	delete(f.records[privateZoneName], relativeRecordSetName)
+	return autorest.Response{}, nil
+}
+
+func newPrivateZoneProvider(fake *fakePrivateDNS, zoneName string) *DNSProvider {
+	return &DNSProvider{
+		resourceGroupName:   "test-rg",
+		zoneName:            zoneName,
+		log:                 logr.Discard(),
+		privateZone:         true,
+		privateRecordClient: fake,
+		privateZoneClient:   fake,
+	}
+}
+
+func TestPrivateZonePresentAndCleanUp(t *testing.T) {
+	fake := newFakePrivateDNS("example.com", "internal.example.com")
+	provider := newPrivateZoneProvider(fake, "")
+
+	err := provider.Present("app.internal.example.com", "_acme-challenge.app.internal.example.com.", "123d==")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"_acme-challenge.app": {"123d=="}}, fake.records["internal.example.com"])
+	assert.Equal(t, int64(60), fake.ttls["internal.example.com/_acme-challenge.app"])
+	assert.Empty(t, fake.records["example.com"])
+
+	err = provider.CleanUp("app.internal.example.com", "_acme-challenge.app.internal.example.com.", "123d==")
+	assert.NoError(t, err)
+	assert.Empty(t, fake.records["internal.example.com"])
+}
+
+func TestPrivateZoneName(t *testing.T) {
+	tests := map[string]struct {
+		zones    []string
+		zoneName string
+		getErr   error
+		fqdn     string
+
+		expZone string
+		expGets []string
+		expErr  bool
+	}{
+		"uses the configured zone the fqdn is part of without looking it up": {
+			zoneName: "internal.example.com",
+			fqdn:     "_acme-challenge.app.internal.example.com.",
+			expZone:  "internal.example.com",
+		},
+		"refuses a configured zone the fqdn is not part of": {
+			zoneName: "internal.example.com",
+			fqdn:     "_acme-challenge.app.example.org.",
+			expErr:   true,
+		},
+		"refuses a configured zone which only shares a suffix with the fqdn": {
+			zoneName: "example.com",
+			fqdn:     "_acme-challenge.app.myexample.com.",
+			expErr:   true,
+		},
+		"finds the private zone with the longest name the fqdn is part of": {
+			zones:   []string{"example.com", "internal.example.com"},
+			fqdn:    "_acme-challenge.app.internal.example.com.",
+			expZone: "internal.example.com",
+			expGets: []string{"app.internal.example.com", "internal.example.com"},
+		},
+		"does not look up the challenge record or a single label as a zone": {
+			fqdn:    "_acme-challenge.app.example.",
+			expGets: []string{"app.example"},
+			expErr:  true,
+		},
+		"returns an error if no private zone is found": {
+			zones:   []string{"example.org"},
+			fqdn:    "_acme-challenge.app.example.com.",
+			expGets: []string{"app.example.com", "example.com"},
+			expErr:  true,
+		},
+		"stops looking up zones on errors other than not found": {
+			zones:   []string{"example.com"},
+			getErr:  autorest.DetailedError{StatusCode: http.StatusForbidden, Message: "forbidden"},
+			fqdn:    "_acme-challenge.app.example.com.",
+			expGets: []string{"app.example.com"},
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakePrivateDNS(test.zones...)
+			fake.getErr = test.getErr
+			provider := newPrivateZoneProvider(fake, test.zoneName)
+
+			zone, err := provider.getHostedZoneName(test.fqdn)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expZone, zone)
+			assert.Equal(t, test.expGets, fake.gets)
+		})
+	}
+}
+
+func TestNewPrivateZoneProvider(t *testing.T) {
+	provider, err := NewDNSProviderWithOptions(Options{
+		ClientID:          "cid",
+		ClientSecret:      "secret",
+		ResourceGroupName: "test-rg",
+		PrivateZone:       true,
+		DNS01Nameservers:  util.RecursiveNameservers,
+	})
+	assert.NoError(t, err)
+	assert.True(t, provider.privateZone)
+	assert.NotNil(t, provider.privateRecordClient)
+	assert.NotNil(t, provider.privateZoneClient)
+}
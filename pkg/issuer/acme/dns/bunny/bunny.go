@@ -0,0 +1,251 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bunny implements a DNS provider for solving the DNS-01 challenge
+// using Bunny.net DNS.
+package bunny
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// BunnyAPIURL is the Bunny.net API endpoint.
+const BunnyAPIURL = "https://api.bunny.net"
+
+const (
+	// txtRecordType is the value Bunny.net uses to identify TXT records.
+	txtRecordType = 3
+
+	// zonesPerPage is the number of DNS zones requested per page when
+	// listing zones.
+	zonesPerPage = 1000
+)
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	dns01Nameservers []string
+	accessKey        string
+	userAgent        string
+
+	// baseURL is the Bunny.net API endpoint requests are made to.
+	baseURL string
+	client  *http.Client
+}
+
+// dnsZone is a DNS zone as returned by the Bunny.net API. Fields we do not
+// need are ignored.
+type dnsZone struct {
+	ID      int64       `json:"Id"`
+	Domain  string      `json:"Domain"`
+	Records []dnsRecord `json:"Records"`
+}
+
+// dnsRecord is a DNS record as returned by the Bunny.net API.
+type dnsRecord struct {
+	ID    int64  `json:"Id,omitempty"`
+	Type  int    `json:"Type"`
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+	TTL   int    `json:"Ttl,omitempty"`
+}
+
+// dnsZoneList is a page of DNS zones as returned by the Bunny.net API.
+type dnsZoneList struct {
+	Items        []dnsZone `json:"Items"`
+	HasMoreItems bool      `json:"HasMoreItems"`
+}
+
+// apiError is the body of an error returned by the Bunny.net API.
+type apiError struct {
+	ErrorKey string `json:"ErrorKey"`
+	Message  string `json:"Message"`
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Bunny.net.
+// The API access key must be passed in the environment variable
+// BUNNY_ACCESS_KEY.
+func NewDNSProvider(dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
+	accessKey := os.Getenv("BUNNY_ACCESS_KEY")
+	return NewDNSProviderCredentials(accessKey, dns01Nameservers, userAgent, nil)
+}
+
+// NewDNSProviderCredentials uses the supplied API access key to return a
+// DNSProvider instance configured for Bunny.net. If rootCAs is not nil, it
+// is used instead of the system roots to verify the Bunny.net API.
+func NewDNSProviderCredentials(accessKey string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*DNSProvider, error) {
+	accessKey = strings.TrimSpace(accessKey)
+	if accessKey == "" {
+		return nil, fmt.Errorf("Bunny.net API access key missing")
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if rootCAs != nil {
+		client.Transport = util.TransportWithRootCAs(rootCAs)
+	}
+
+	return &DNSProvider{
+		dns01Nameservers: dns01Nameservers,
+		accessKey:        accessKey,
+		userAgent:        userAgent,
+		baseURL:          BunnyAPIURL,
+		client:           client,
+	}, nil
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(domain, fqdn, value string) error {
+	zone, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	name := recordName(fqdn, zone.Domain)
+	for _, record := range zone.Records {
+		if record.Type == txtRecordType && record.Name == name && record.Value == value {
+			// the record is already set to the desired value
+			return nil
+		}
+	}
+
+	return c.makeRequest(http.MethodPut, fmt.Sprintf("/dnszone/%d/records", zone.ID), &dnsRecord{
+		Type:  txtRecordType,
+		Name:  name,
+		Value: value,
+		TTL:   120,
+	}, nil)
+}
+
+// CleanUp removes the TXT record matching the specified parameters
+func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
+	zone, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	name := recordName(fqdn, zone.Domain)
+	for _, record := range zone.Records {
+		// Only delete the record with this challenge's value, as the same name
+		// may be used by other challenges at the same time.
+		if record.Type != txtRecordType || record.Name != name || record.Value != value {
+			continue
+		}
+		if err := c.makeRequest(http.MethodDelete, fmt.Sprintf("/dnszone/%d/records/%d", zone.ID, record.ID), nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findZone returns the most specific DNS zone managed in Bunny.net that
+// contains the given fqdn.
+func (c *DNSProvider) findZone(fqdn string) (*dnsZone, error) {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+
+	var zone *dnsZone
+	for page := 1; ; page++ {
+		var list dnsZoneList
+		if err := c.makeRequest(http.MethodGet, fmt.Sprintf("/dnszone?page=%d&perPage=%d", page, zonesPerPage), nil, &list); err != nil {
+			return nil, err
+		}
+
+		for i := range list.Items {
+			domain := strings.ToLower(util.UnFqdn(list.Items[i].Domain))
+			if name != domain && !strings.HasSuffix(name, "."+domain) {
+				continue
+			}
+			if zone == nil || len(domain) > len(zone.Domain) {
+				zone = &list.Items[i]
+				zone.Domain = domain
+			}
+		}
+
+		if !list.HasMoreItems || len(list.Items) == 0 {
+			break
+		}
+	}
+
+	if zone == nil {
+		return nil, fmt.Errorf("found no Bunny.net DNS zone for %s, please make sure the zone exists and the API access key can read it", fqdn)
+	}
+
+	return zone, nil
+}
+
+// recordName returns the name of the record for fqdn relative to its zone.
+// An empty name refers to the zone apex.
+func recordName(fqdn, zone string) string {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+	if name == zone {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+zone)
+}
+
+func (c *DNSProvider) makeRequest(method, uri string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+uri, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("AccessKey", c.accessKey)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while querying the Bunny.net API for %s %q: %v", method, uri, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Message != "" {
+			return fmt.Errorf("while querying the Bunny.net API for %s %q: %s: %s", method, uri, resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("while querying the Bunny.net API for %s %q: %s", method, uri, resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
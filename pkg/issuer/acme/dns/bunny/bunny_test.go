@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bunny
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+const testAccessKey = "access-key"
+
+// fakeBunnyAPI is an in-memory implementation of the parts of the Bunny.net
+// DNS API used by the provider.
+type fakeBunnyAPI struct {
+	lock         sync.Mutex
+	zones        []dnsZone
+	perPage      int
+	nextRecordID int64
+}
+
+func newFakeBunnyAPI(t *testing.T, perPage int, domains ...string) (*fakeBunnyAPI, *DNSProvider) {
+	api := &fakeBunnyAPI{perPage: perPage, nextRecordID: 100}
+	for i, domain := range domains {
+		api.zones = append(api.zones, dnsZone{ID: int64(i + 1), Domain: domain})
+	}
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials(testAccessKey, util.RecursiveNameservers, "cert-manager-test", nil)
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	return api, provider
+}
+
+func (f *fakeBunnyAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("AccessKey") != testAccessKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"ErrorKey":"unauthorized","Message":"The request authorization failed"}`))
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1 && parts[0] == "dnszone":
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := (page - 1) * f.perPage
+		end := start + f.perPage
+		if end > len(f.zones) {
+			end = len(f.zones)
+		}
+		var items []dnsZone
+		if start < end {
+			items = f.zones[start:end]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"Items":        items,
+			"CurrentPage":  page,
+			"TotalItems":   len(f.zones),
+			"HasMoreItems": end < len(f.zones),
+		})
+	case r.Method == http.MethodPut && len(parts) == 3 && parts[2] == "records":
+		zone := f.zone(parts[1])
+		if zone == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var record dnsRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		record.ID = f.nextRecordID
+		f.nextRecordID++
+		zone.Records = append(zone.Records, record)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(record)
+	case r.Method == http.MethodDelete && len(parts) == 4 && parts[2] == "records":
+		zone := f.zone(parts[1])
+		if zone == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for i, record := range zone.Records {
+			if strconv.FormatInt(record.ID, 10) == parts[3] {
+				zone.Records = append(zone.Records[:i], zone.Records[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeBunnyAPI) zone(id string) *dnsZone {
+	for i := range f.zones {
+		if strconv.FormatInt(f.zones[i].ID, 10) == id {
+			return &f.zones[i]
+		}
+	}
+	return nil
+}
+
+func (f *fakeBunnyAPI) records(domain string) []dnsRecord {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, zone := range f.zones {
+		if zone.Domain == domain {
+			return append([]dnsRecord(nil), zone.Records...)
+		}
+	}
+	return nil
+}
+
+func TestNewDNSProviderCredentials(t *testing.T) {
+	_, err := NewDNSProviderCredentials("", util.RecursiveNameservers, "cert-manager-test", nil)
+	assert.Error(t, err)
+
+	provider, err := NewDNSProviderCredentials(" access-key\n", util.RecursiveNameservers, "cert-manager-test", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "access-key", provider.accessKey)
+}
+
+func TestFindZone(t *testing.T) {
+	tests := map[string]struct {
+		domains []string
+		perPage int
+		fqdn    string
+		expZone string
+		expErr  bool
+	}{
+		"finds the zone of a sub-domain": {
+			domains: []string{"example.org", "example.com"},
+			fqdn:    "_acme-challenge.www.example.com.",
+			expZone: "example.com",
+		},
+		"finds the zone of its apex": {
+			domains: []string{"example.com"},
+			fqdn:    "example.com.",
+			expZone: "example.com",
+		},
+		"prefers the most specific zone": {
+			domains: []string{"example.com", "sub.example.com"},
+			fqdn:    "_acme-challenge.sub.example.com.",
+			expZone: "sub.example.com",
+		},
+		"does not match zones that only share a suffix": {
+			domains: []string{"ample.com"},
+			fqdn:    "_acme-challenge.example.com.",
+			expErr:  true,
+		},
+		"finds zones on later pages": {
+			domains: []string{"a.com", "b.com", "c.com", "example.com", "d.com"},
+			perPage: 2,
+			fqdn:    "_acme-challenge.example.com.",
+			expZone: "example.com",
+		},
+		"returns an error if there is no zone": {
+			domains: []string{"example.org"},
+			fqdn:    "_acme-challenge.example.com.",
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			perPage := test.perPage
+			if perPage == 0 {
+				perPage = zonesPerPage
+			}
+			_, provider := newFakeBunnyAPI(t, perPage, test.domains...)
+
+			zone, err := provider.findZone(test.fqdn)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expZone, zone.Domain)
+		})
+	}
+}
+
+func TestBunnyPresentAndCleanUp(t *testing.T) {
+	api, provider := newFakeBunnyAPI(t, zonesPerPage, "example.com")
+	fqdn := "_acme-challenge.example.com."
+
+	// Two challenges for the same name, e.g. for example.com and
+	// *.example.com, must be able to exist at the same time.
+	require.NoError(t, provider.Present("example.com", fqdn, "value-1"))
+	require.NoError(t, provider.Present("*.example.com", fqdn, "value-2"))
+	assert.Equal(t, []dnsRecord{
+		{ID: 100, Type: txtRecordType, Name: "_acme-challenge", Value: "value-1", TTL: 120},
+		{ID: 101, Type: txtRecordType, Name: "_acme-challenge", Value: "value-2", TTL: 120},
+	}, api.records("example.com"))
+
+	// Presenting a record again does not create a duplicate.
+	require.NoError(t, provider.Present("example.com", fqdn, "value-1"))
+	assert.Len(t, api.records("example.com"), 2)
+
+	// Cleaning up only removes the record with the challenge's value.
+	require.NoError(t, provider.CleanUp("example.com", fqdn, "value-1"))
+	assert.Equal(t, []dnsRecord{
+		{ID: 101, Type: txtRecordType, Name: "_acme-challenge", Value: "value-2", TTL: 120},
+	}, api.records("example.com"))
+
+	// Cleaning up a record that does not exist is not an error.
+	require.NoError(t, provider.CleanUp("example.com", fqdn, "value-1"))
+
+	require.NoError(t, provider.CleanUp("*.example.com", fqdn, "value-2"))
+	assert.Empty(t, api.records("example.com"))
+}
+
+func TestBunnyAPIError(t *testing.T) {
+	_, provider := newFakeBunnyAPI(t, zonesPerPage, "example.com")
+	provider.accessKey = "wrong"
+
+	err := provider.Present("example.com", "_acme-challenge.example.com.", "value")
+	require.Error(t, err)
+	assert.Equal(t, fmt.Sprintf("while querying the Bunny.net API for GET %q: 401 Unauthorized: The request authorization failed", "/dnszone?page=1&perPage=1000"), err.Error())
+}
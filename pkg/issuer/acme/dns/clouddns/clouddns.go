@@ -11,6 +11,7 @@ this directory.
 package clouddns
 
 import (
+	"crypto/x509"
 	"fmt"
 	"os"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/go-logr/logr"
 
 	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
 	"google.golang.org/api/option"
@@ -36,8 +38,10 @@ type DNSProvider struct {
 	log              logr.Logger
 }
 
-// NewDNSProvider returns a new DNSProvider Instance with configuration
-func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*DNSProvider, error) {
+// NewDNSProvider returns a new DNSProvider Instance with configuration.
+// If rootCAs is not nil, it replaces the system roots when verifying the TLS
+// certificates of the Google APIs.
+func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName, userAgent string, rootCAs *x509.CertPool) (*DNSProvider, error) {
 	// project is a required field
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
@@ -48,11 +52,11 @@ func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, a
 		if !ambient {
 			return nil, fmt.Errorf("unable to construct clouddns provider: empty credentials; perhaps you meant to enable ambient credentials?")
 		}
-		return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName, userAgent, rootCAs)
 	}
 	// if service account data is provided, we instantiate using that
 	if len(saBytes) != 0 {
-		return NewDNSProviderServiceAccountBytes(project, saBytes, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderServiceAccountBytes(project, saBytes, dns01Nameservers, hostedZoneName, userAgent, rootCAs)
 	}
 	return nil, fmt.Errorf("missing Google Cloud DNS provider credentials")
 }
@@ -61,27 +65,27 @@ func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, a
 // DNS. Project name must be passed in the environment variable: GCE_PROJECT.
 // A Service Account file can be passed in the environment variable:
 // GCE_SERVICE_ACCOUNT_FILE
-func NewDNSProviderEnvironment(dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderEnvironment(dns01Nameservers []string, hostedZoneName, userAgent string) (*DNSProvider, error) {
 	project := os.Getenv("GCE_PROJECT")
 	if saFile, ok := os.LookupEnv("GCE_SERVICE_ACCOUNT_FILE"); ok {
-		return NewDNSProviderServiceAccount(project, saFile, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderServiceAccount(project, saFile, dns01Nameservers, hostedZoneName, userAgent)
 	}
-	return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName)
+	return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName, userAgent, nil)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
 // DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderCredentials(project string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderCredentials(project string, dns01Nameservers []string, hostedZoneName, userAgent string, rootCAs *x509.CertPool) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
 
-	ctx := context.Background()
+	ctx := contextWithRootCAs(context.Background(), rootCAs)
 	client, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to get Google Cloud client: %v", err)
 	}
-	svc, err := dns.NewService(ctx, option.WithHTTPClient(client))
+	svc, err := dns.NewService(ctx, option.WithHTTPClient(client), option.WithUserAgent(userAgent))
 	if err != nil {
 		return nil, fmt.Errorf("Unable to create Google Cloud DNS service: %v", err)
 	}
@@ -96,7 +100,7 @@ func NewDNSProviderCredentials(project string, dns01Nameservers []string, hosted
 
 // NewDNSProviderServiceAccount uses the supplied service account JSON file to
 // return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameservers []string, hostedZoneName, userAgent string) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
@@ -108,12 +112,12 @@ func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameserver
 	if err != nil {
 		return nil, fmt.Errorf("Unable to read Service Account file: %v", err)
 	}
-	return NewDNSProviderServiceAccountBytes(project, dat, dns01Nameservers, hostedZoneName)
+	return NewDNSProviderServiceAccountBytes(project, dat, dns01Nameservers, hostedZoneName, userAgent, nil)
 }
 
 // NewDNSProviderServiceAccountBytes uses the supplied service account JSON
 // file data to return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Nameservers []string, hostedZoneName, userAgent string, rootCAs *x509.CertPool) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
@@ -126,10 +130,10 @@ func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Name
 		return nil, fmt.Errorf("Unable to acquire config: %v", err)
 	}
 
-	ctx := context.Background()
+	ctx := contextWithRootCAs(context.Background(), rootCAs)
 	client := conf.Client(ctx)
 
-	svc, err := dns.NewService(ctx, option.WithHTTPClient(client))
+	svc, err := dns.NewService(ctx, option.WithHTTPClient(client), option.WithUserAgent(userAgent))
 	if err != nil {
 		return nil, fmt.Errorf("Unable to create Google Cloud DNS service: %v", err)
 	}
@@ -142,6 +146,16 @@ func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Name
 	}, nil
 }
 
+// contextWithRootCAs returns a context which makes the oauth2 clients created
+// with it, and the token requests they make, verify TLS certificates using
+// the given CA certificates. If rootCAs is nil, ctx is returned unchanged.
+func contextWithRootCAs(ctx context.Context, rootCAs *x509.CertPool) context.Context {
+	if httpClient := util.HTTPClientWithRootCAs(rootCAs); httpClient != nil {
+		return context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	}
+	return ctx
+}
+
 // Present creates a TXT record to fulfil the dns-01 challenge.
 func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	zone, err := c.getHostedZone(fqdn)
@@ -12,16 +12,19 @@ package cloudflare
 
 import (
 	"bytes"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 // CloudFlareAPIURL represents the API endpoint to call.
@@ -41,6 +44,15 @@ type DNSProvider struct {
 	authToken        string
 
 	userAgent string
+
+	// baseURL is the Cloudflare API endpoint requests are made to.
+	baseURL string
+
+	// metrics counts the errors returned by the Cloudflare API, if set.
+	metrics *metrics.Metrics
+
+	// transport is used to make requests to the Cloudflare API, if set.
+	transport http.RoundTripper
 }
 
 // DNSZone is the Zone-Record returned from Cloudflare (we`ll ignore everything we don't need)
@@ -88,9 +100,16 @@ func NewDNSProviderCredentials(email, key, token string, dns01Nameservers []stri
 		authToken:        token,
 		dns01Nameservers: dns01Nameservers,
 		userAgent:        userAgent,
+		baseURL:          CloudFlareAPIURL,
 	}, nil
 }
 
+// SetMetrics sets the metrics that errors returned by the Cloudflare API are
+// counted in.
+func (c *DNSProvider) SetMetrics(m *metrics.Metrics) {
+	c.metrics = m
+}
+
 // FindNearestZoneForFQDN will try to traverse the official Cloudflare API to find the nearest valid Zone.
 // It's a replacement for /pkg/issuer/acme/dns/util/wait.go#FindZoneByFqdn
 //  example.com.                                   ← Zone-Record found for the SLD (in most cases)
@@ -132,7 +151,7 @@ func FindNearestZoneForFQDN(c DNSProviderType, fqdn string) (DNSZone, error) {
 		nextName = string([]rune(nextName)[from:to])
 	}
 	if lastErr != nil {
-		return DNSZone{}, fmt.Errorf("while attempting to find Zones for domain %s\n%s", fqdn, lastErr)
+		return DNSZone{}, fmt.Errorf("while attempting to find Zones for domain %s\n%w", fqdn, lastErr)
 	}
 	return DNSZone{}, fmt.Errorf("Found no Zones for domain %s (neither in the sub-domain nor in the SLD) please make sure your domain-entries in the config are correct and the API key is correctly setup with Zone.read rights.", fqdn)
 }
@@ -200,6 +219,29 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return nil
 }
 
+// Validate checks that the credentials are accepted by Cloudflare and that a
+// zone can be found for each of the given domains, without changing any
+// records.
+func (c *DNSProvider) Validate(domains []string) error {
+	// API tokens have a dedicated endpoint to verify them, whereas API keys
+	// are verified by reading the user they belong to.
+	uri := "/user"
+	if c.authToken != "" {
+		uri = "/user/tokens/verify"
+	}
+	if _, err := c.makeRequest("GET", uri, nil); err != nil {
+		return err
+	}
+
+	for _, domain := range domains {
+		if _, err := FindNearestZoneForFQDN(c, util.ToFqdn(domain)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 	hostedZone, err := FindNearestZoneForFQDN(c, fqdn)
 	if err != nil {
@@ -255,7 +297,7 @@ func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 		Result  json.RawMessage `json:"result"`
 	}
 
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", CloudFlareAPIURL, uri), body)
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", c.baseURL, uri), body)
 	if err != nil {
 		return nil, err
 	}
@@ -271,7 +313,8 @@ func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 	req.Header.Set("User-Agent", c.userAgent)
 
 	client := http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: c.transport,
 	}
 	resp, err := client.Do(req)
 	if err != nil {
@@ -283,6 +326,14 @@ func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 	var r APIResponse
 	err = json.NewDecoder(resp.Body).Decode(&r)
 	if err != nil {
+		c.countAPIError(otherErrorCode)
+		if credentialsRejectedStatus(resp.StatusCode) {
+			// Rate limited responses may not have a JSON body.
+			return nil, &credentialsRejectedError{
+				err:        fmt.Errorf("while querying the Cloudflare API for %s %q: %s", method, uri, resp.Status),
+				statusCode: resp.StatusCode,
+			}
+		}
 		return nil, err
 	}
 
@@ -290,19 +341,124 @@ func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 		if len(r.Errors) > 0 {
 			errStr := ""
 			for _, apiErr := range r.Errors {
+				c.countAPIError(errorCodeLabel(apiErr.Code))
 				errStr += fmt.Sprintf("\t Error: %d: %s", apiErr.Code, apiErr.Message)
 				for _, chainErr := range apiErr.ErrorChain {
 					errStr += fmt.Sprintf("<- %d: %s", chainErr.Code, chainErr.Message)
 				}
 			}
-			return nil, fmt.Errorf("while querying the Cloudflare API for %s %q \n%s", method, uri, errStr)
+			err = fmt.Errorf("while querying the Cloudflare API for %s %q \n%s", method, uri, errStr)
+		} else {
+			c.countAPIError(otherErrorCode)
+			err = fmt.Errorf("while querying the Cloudflare API for %s %q", method, uri)
+		}
+		if credentialsRejectedStatus(resp.StatusCode) {
+			return nil, &credentialsRejectedError{err: err, statusCode: resp.StatusCode}
 		}
-		return nil, fmt.Errorf("while querying the Cloudflare API for %s %q", method, uri)
+		return nil, err
 	}
 
 	return r.Result, nil
 }
 
+// otherErrorCode is the code that errors are counted with if Cloudflare did
+// not return an error code, or returned one that is not in knownErrorCodes.
+const otherErrorCode = "other"
+
+// knownErrorCodes are the Cloudflare API error codes which are counted by
+// their code. Other codes are counted as otherErrorCode to keep the number of
+// metric series bounded.
+// See https://api.cloudflare.com/#getting-started-responses
+var knownErrorCodes = map[int]bool{
+	971:   true, // rate limited
+	1004:  true, // DNS validation error
+	6003:  true, // invalid request headers
+	6111:  true, // invalid format for Authorization header
+	7000:  true, // no route for that URI
+	7003:  true, // could not route, invalid object identifier
+	9103:  true, // unknown X-Auth-Key or X-Auth-Email
+	9106:  true, // missing X-Auth-Key, X-Auth-Email or Authorization headers
+	9109:  true, // invalid access token
+	10000: true, // authentication error
+	81044: true, // record does not exist
+	81053: true, // an A, AAAA or CNAME record with that host already exists
+	81057: true, // record already exists
+}
+
+// errorCodeLabel returns the metric label for a Cloudflare API error code.
+func errorCodeLabel(code int) string {
+	if !knownErrorCodes[code] {
+		return otherErrorCode
+	}
+	return strconv.Itoa(code)
+}
+
+// countAPIError increments the counter of Cloudflare API errors with the
+// given code, if metrics have been set.
+func (c *DNSProvider) countAPIError(code string) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.IncrementDNSProviderAPIErrorCount("cloudflare", code)
+}
+
+// SetRootCAs sets the CA certificates used to verify the TLS certificate of
+// the Cloudflare API. If pool is nil, the system roots are used.
+func (c *DNSProvider) SetRootCAs(pool *x509.CertPool) {
+	if pool == nil {
+		c.transport = nil
+		return
+	}
+	c.transport = util.TransportWithRootCAs(pool)
+}
+
+// credentialsRejectedError is returned when the Cloudflare API rejects a
+// request because of the credentials it was made with.
+type credentialsRejectedError struct {
+	err        error
+	statusCode int
+}
+
+func (e *credentialsRejectedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *credentialsRejectedError) Unwrap() error {
+	return e.err
+}
+
+// RemediationHint suggests how the credentials may be fixed, based on the
+// status code of the response.
+func (e *credentialsRejectedError) RemediationHint() string {
+	switch e.statusCode {
+	case http.StatusUnauthorized:
+		return "check that the Cloudflare API token or API key referenced by the issuer is valid and has not expired"
+	case http.StatusForbidden:
+		return "check that the Cloudflare API token has the Zone:Zone:Read and Zone:DNS:Edit permissions for the zone"
+	case http.StatusTooManyRequests:
+		return "Cloudflare is rate limiting requests made with these credentials, consider configuring additional API tokens"
+	}
+	return ""
+}
+
+// credentialsRejectedStatus returns true if the HTTP status code means that the
+// credentials used are not authorized, or that requests made with them are
+// being rate limited.
+func credentialsRejectedStatus(code int) bool {
+	return code == http.StatusUnauthorized ||
+		code == http.StatusForbidden ||
+		code == http.StatusTooManyRequests
+}
+
+// IsCredentialsRejected returns true if the error was caused by the Cloudflare
+// API rejecting the credentials used, either because they are not authorized
+// or because requests made with them are being rate limited. Trying again with
+// different credentials may succeed.
+func IsCredentialsRejected(err error) bool {
+	var rejected *credentialsRejectedError
+	return errors.As(err, &rejected)
+}
+
 // cloudFlareRecord represents a CloudFlare DNS record
 type cloudFlareRecord struct {
 	Name    string `json:"name"`
@@ -12,14 +12,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	logtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 )
 
 var (
@@ -156,3 +165,284 @@ func TestCloudFlareCleanUp(t *testing.T) {
 	err = provider.CleanUp(cflareDomain, "_acme-challenge."+cflareDomain+".", "123d==")
 	assert.NoError(t, err)
 }
+
+func TestMakeRequestCredentialsRejected(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		body     string
+		rejected bool
+		hint     string
+	}{
+		"rate limited without a JSON body": {
+			status:   http.StatusTooManyRequests,
+			body:     "slow down",
+			rejected: true,
+			hint:     "Cloudflare is rate limiting requests made with these credentials, consider configuring additional API tokens",
+		},
+		"rate limited with a JSON body": {
+			status:   http.StatusTooManyRequests,
+			body:     `{"success":false,"errors":[{"code":971,"message":"Please wait and consider throttling your request speed"}]}`,
+			rejected: true,
+			hint:     "Cloudflare is rate limiting requests made with these credentials, consider configuring additional API tokens",
+		},
+		"invalid token": {
+			status:   http.StatusForbidden,
+			body:     `{"success":false,"errors":[{"code":9109,"message":"Invalid access token"}]}`,
+			rejected: true,
+			hint:     "check that the Cloudflare API token has the Zone:Zone:Read and Zone:DNS:Edit permissions for the zone",
+		},
+		"unauthorized": {
+			status:   http.StatusUnauthorized,
+			body:     `{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`,
+			rejected: true,
+			hint:     "check that the Cloudflare API token or API key referenced by the issuer is valid and has not expired",
+		},
+		"bad request": {
+			status:   http.StatusBadRequest,
+			body:     `{"success":false,"errors":[{"code":1004,"message":"DNS Validation Error"}]}`,
+			rejected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			provider, err := NewDNSProviderCredentials("", "", "token", util.RecursiveNameservers, "cert-manager-test")
+			assert.NoError(t, err)
+			provider.baseURL = server.URL
+
+			err = provider.Present("example.com", "_acme-challenge.example.com.", "123d==")
+			assert.Error(t, err)
+			assert.Equal(t, test.rejected, IsCredentialsRejected(err), "unexpected IsCredentialsRejected result for error: %v", err)
+			assert.Equal(t, test.hint, cmerrors.RemediationHint(err))
+		})
+	}
+}
+
+func TestMakeRequestErrorMetrics(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		body     string
+		expected []string
+	}{
+		"known error code": {
+			status:   http.StatusForbidden,
+			body:     `{"success":false,"errors":[{"code":9109,"message":"Invalid access token"}]}`,
+			expected: []string{`certmanager_dns_provider_api_error_count{code="9109",provider="cloudflare"} 1`},
+		},
+		"unknown error code": {
+			status:   http.StatusBadRequest,
+			body:     `{"success":false,"errors":[{"code":12345,"message":"Something went wrong"}]}`,
+			expected: []string{`certmanager_dns_provider_api_error_count{code="other",provider="cloudflare"} 1`},
+		},
+		"multiple error codes": {
+			status: http.StatusBadRequest,
+			body:   `{"success":false,"errors":[{"code":6003,"message":"Invalid request headers"},{"code":10000,"message":"Authentication error"}]}`,
+			expected: []string{
+				`certmanager_dns_provider_api_error_count{code="10000",provider="cloudflare"} 1`,
+				`certmanager_dns_provider_api_error_count{code="6003",provider="cloudflare"} 1`,
+			},
+		},
+		"error without a code": {
+			status:   http.StatusInternalServerError,
+			body:     `{"success":false,"errors":[]}`,
+			expected: []string{`certmanager_dns_provider_api_error_count{code="other",provider="cloudflare"} 1`},
+		},
+		"error without a JSON body": {
+			status:   http.StatusTooManyRequests,
+			body:     "slow down",
+			expected: []string{`certmanager_dns_provider_api_error_count{code="other",provider="cloudflare"} 1`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			provider, err := NewDNSProviderCredentials("", "", "token", util.RecursiveNameservers, "cert-manager-test")
+			require.NoError(t, err)
+			provider.baseURL = server.URL
+			m := metrics.New(logtesting.NewTestLogger(t), clock.RealClock{})
+			provider.SetMetrics(m)
+
+			_, err = provider.makeRequest(http.MethodGet, "/zones", nil)
+			assert.Error(t, err)
+
+			body := scrapeMetrics(t, m)
+			var got []string
+			for _, line := range strings.Split(body, "\n") {
+				if strings.HasPrefix(line, "certmanager_dns_provider_api_error_count{") {
+					got = append(got, line)
+				}
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+// scrapeMetrics returns the text exposition of all metrics registered by m.
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	rec := httptest.NewRecorder()
+	m.NewServer(ln).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Result().Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCloudFlarePresentFakeAPI(t *testing.T) {
+	var created []cloudFlareRecord
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":9109,"message":"Invalid access token"}]}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/zones":
+			result := "[]"
+			if r.URL.Query().Get("name") == "example.com" {
+				result = `[{"id":"zone-id","name":"example.com"}]`
+			}
+			_, _ = w.Write([]byte(`{"success":true,"result":` + result + `}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/zones/zone-id/dns_records":
+			_, _ = w.Write([]byte(`{"success":true,"result":[]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/zones/zone-id/dns_records":
+			var rec cloudFlareRecord
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&rec))
+			created = append(created, rec)
+			_, _ = w.Write([]byte(`{"success":true,"result":{}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewDNSProviderCredentials("", "", "token", util.RecursiveNameservers, "cert-manager-test")
+	assert.NoError(t, err)
+	provider.baseURL = server.URL
+
+	err = provider.Present("example.com", "_acme-challenge.example.com.", "123d==")
+	assert.NoError(t, err)
+	assert.Equal(t, []cloudFlareRecord{{Type: "TXT", Name: "_acme-challenge.example.com", Content: "123d==", TTL: 120}}, created)
+
+	provider, err = NewDNSProviderCredentials("", "", "wrong-token", util.RecursiveNameservers, "cert-manager-test")
+	assert.NoError(t, err)
+	provider.baseURL = server.URL
+
+	err = provider.Present("example.com", "_acme-challenge.example.com.", "123d==")
+	assert.True(t, IsCredentialsRejected(err), "expected credentials to be rejected, got: %v", err)
+}
+
+func TestCloudFlareUserAgent(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/zones":
+			_, _ = w.Write([]byte(`{"success":true,"result":[{"id":"zone-id","name":"example.com"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"success":true,"result":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewDNSProviderCredentials("", "", "token", util.RecursiveNameservers, "cert-manager-test/v1.2.3")
+	assert.NoError(t, err)
+	provider.baseURL = server.URL
+
+	err = provider.Present("example.com", "_acme-challenge.example.com.", "123d==")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, userAgents)
+	for _, ua := range userAgents {
+		assert.Equal(t, "cert-manager-test/v1.2.3", ua)
+	}
+}
+
+func TestCloudFlareValidate(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.Header.Get("Authorization") == "Bearer token":
+		case r.Header.Get("X-Auth-Email") == "test@example.com" && r.Header.Get("X-Auth-Key") == "key":
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":9109,"message":"Invalid access token"}]}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && (r.URL.Path == "/user/tokens/verify" || r.URL.Path == "/user"):
+			_, _ = w.Write([]byte(`{"success":true,"result":{}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/zones":
+			result := "[]"
+			if r.URL.Query().Get("name") == "example.com" {
+				result = `[{"id":"zone-id","name":"example.com"}]`
+			}
+			_, _ = w.Write([]byte(`{"success":true,"result":` + result + `}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		email, key, token string
+		domains           []string
+		expErr            bool
+		expRejected       bool
+		expRequests       []string
+	}{
+		"valid API token and zone": {
+			token:       "token",
+			domains:     []string{"www.example.com"},
+			expRequests: []string{"GET /user/tokens/verify", "GET /zones?name=www.example.com", "GET /zones?name=example.com"},
+		},
+		"valid API key without zones": {
+			email:       "test@example.com",
+			key:         "key",
+			expRequests: []string{"GET /user"},
+		},
+		"invalid API token": {
+			token:       "wrong-token",
+			domains:     []string{"example.com"},
+			expErr:      true,
+			expRejected: true,
+			expRequests: []string{"GET /user/tokens/verify"},
+		},
+		"zone not found": {
+			token:       "token",
+			domains:     []string{"example.org"},
+			expErr:      true,
+			expRequests: []string{"GET /user/tokens/verify", "GET /zones?name=example.org"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests = nil
+			provider, err := NewDNSProviderCredentials(test.email, test.key, test.token, util.RecursiveNameservers, "cert-manager-test")
+			assert.NoError(t, err)
+			provider.baseURL = server.URL
+
+			err = provider.Validate(test.domains)
+			assert.Equal(t, test.expErr, err != nil, "unexpected error: %v", err)
+			assert.Equal(t, test.expRejected, IsCredentialsRejected(err))
+			assert.Equal(t, test.expRequests, requests)
+		})
+	}
+}
@@ -20,6 +20,7 @@ package digitalocean
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"strings"
@@ -38,26 +39,41 @@ type DNSProvider struct {
 
 // NewDNSProvider returns a DNSProvider instance configured for digitalocean.
 // The access token must be passed in the environment variable DIGITALOCEAN_TOKEN
-func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProvider(dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
 	token := os.Getenv("DIGITALOCEAN_TOKEN")
-	return NewDNSProviderCredentials(token, dns01Nameservers)
+	return NewDNSProviderCredentials(token, dns01Nameservers, userAgent, nil)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
-// DNSProvider instance configured for digitalocean.
-func NewDNSProviderCredentials(token string, dns01Nameservers []string) (*DNSProvider, error) {
+// DNSProvider instance configured for digitalocean. If rootCAs is not nil, it
+// replaces the system roots when verifying the TLS certificate of the
+// DigitalOcean API.
+func NewDNSProviderCredentials(token string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*DNSProvider, error) {
 	if token == "" {
 		return nil, fmt.Errorf("DigitalOcean token missing")
 	}
 
+	ctx := context.Background()
+	if httpClient := util.HTTPClientWithRootCAs(rootCAs); httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	}
 	c := oauth2.NewClient(
-		context.Background(),
+		ctx,
 		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
 	)
 
+	var opts []godo.ClientOpt
+	if userAgent != "" {
+		opts = append(opts, godo.SetUserAgent(userAgent))
+	}
+	client, err := godo.New(c, opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DNSProvider{
 		dns01Nameservers: dns01Nameservers,
-		client:           godo.NewClient(c),
+		client:           client,
 	}, nil
 }
 
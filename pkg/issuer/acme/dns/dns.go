@@ -18,15 +18,19 @@ package dns
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook"
 	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
@@ -36,14 +40,19 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/akamai"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/azuredns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/bunny"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/gandi"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/rfc2136"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	webhookslv "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/webhook"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // solver is the old solver type interface.
@@ -57,12 +66,14 @@ type solver interface {
 // It is useful for mocking out a given provider since an alternate set of
 // constructors may be set.
 type dnsProviderConstructors struct {
-	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error)
+	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName, userAgent string, rootCAs *x509.CertPool) (*clouddns.DNSProvider, error)
 	cloudFlare   func(email, apikey, apiToken string, dns01Nameservers []string, userAgent string) (*cloudflare.DNSProvider, error)
-	route53      func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error)
-	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error)
-	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
-	digitalOcean func(token string, dns01Nameservers []string) (*digitalocean.DNSProvider, error)
+	route53      func(opts route53.Options) (*route53.DNSProvider, error)
+	azureDNS     func(opts azuredns.Options) (*azuredns.DNSProvider, error)
+	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string, rootCAs *x509.CertPool) (*acmedns.DNSProvider, error)
+	digitalOcean func(token string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*digitalocean.DNSProvider, error)
+	bunny        func(accessKey string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*bunny.DNSProvider, error)
+	gandi        func(token string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*gandi.DNSProvider, error)
 }
 
 // Solver is a solver for the acme dns01 challenge.
@@ -73,6 +84,10 @@ type Solver struct {
 	secretLister            corev1listers.SecretLister
 	dnsProviderConstructors dnsProviderConstructors
 	webhookSolvers          map[string]webhook.Solver
+
+	// cloudflareTokensUsed records which of a list of Cloudflare API tokens
+	// presented each DNS01 record.
+	cloudflareTokensUsed sync.Map
 }
 
 // Present performs the work to configure DNS to resolve a DNS01 challenge.
@@ -86,7 +101,11 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	}
 	if err == nil {
 		log.V(logf.InfoLevel).Info("presenting DNS01 challenge for domain")
-		return webhookSolver.Present(req)
+		if err := webhookSolver.Present(req); err != nil {
+			return err
+		}
+		ch.Status.Presentation = s.challengePresentation(ch, req.ResolvedFQDN, req.ResolvedZone)
+		return nil
 	}
 
 	slv, providerConfig, err := s.solverForChallenge(ctx, issuer, ch)
@@ -94,29 +113,94 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(ch.Spec.DNSName, providerConfig, true)
 	if err != nil {
 		return err
 	}
 
 	log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain")
 
-	return slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	if err := slv.Present(ch.Spec.DNSName, fqdn, challengeKey(ch)); err != nil {
+		return err
+	}
+
+	// the zone is only used to report how the challenge was presented, so
+	// failing to determine it does not fail the challenge
+	zone, err := util.FindZoneByFqdn(fqdn, s.DNS01Nameservers)
+	if err != nil {
+		log.Error(err, "failed to determine the zone of the presented DNS01 record", "fqdn", fqdn)
+	}
+	ch.Status.Presentation = s.challengePresentation(ch, fqdn, zone)
+
+	return nil
+}
+
+// challengePresentation describes the DNS01 record for the challenge that was
+// presented at fqdn in zone, including the target of any CNAME records that
+// were followed from the challenge's '_acme-challenge' record.
+func (s *Solver) challengePresentation(ch *cmacme.Challenge, fqdn, zone string) *cmacme.ACMEChallengePresentation {
+	cfg := ch.Spec.Solver.DNS01
+	presentation := &cmacme.ACMEChallengePresentation{
+		Solver:       dns01ProviderName(cfg),
+		ResolvedFQDN: fqdn,
+		ResolvedZone: zone,
+	}
+	// the FQDN without following CNAMEs is computed locally, so this cannot
+	// fail or make any DNS queries
+	if unfollowed, err := s.challengeFQDN(ch.Spec.DNSName, cfg, false); err == nil && unfollowed != fqdn {
+		presentation.CNAMETarget = fqdn
+	}
+	return presentation
+}
+
+// dns01ProviderName returns the name of the DNS01 provider configured in cfg.
+func dns01ProviderName(cfg *cmacme.ACMEChallengeSolverDNS01) string {
+	switch {
+	case cfg.Akamai != nil:
+		return "akamai"
+	case cfg.CloudDNS != nil:
+		return "clouddns"
+	case cfg.Cloudflare != nil:
+		return "cloudflare"
+	case cfg.DigitalOcean != nil:
+		return "digitalocean"
+	case cfg.Bunny != nil:
+		return "bunny"
+	case cfg.Gandi != nil:
+		return "gandi"
+	case cfg.Route53 != nil:
+		return "route53"
+	case cfg.AzureDNS != nil:
+		return "azuredns"
+	case cfg.AcmeDNS != nil:
+		return "acmedns"
+	case cfg.RFC2136 != nil:
+		return "rfc2136"
+	case cfg.Webhook != nil:
+		return fmt.Sprintf("webhook/%s/%s", cfg.Webhook.GroupName, cfg.Webhook.SolverName)
+	}
+	return ""
 }
 
 // Check verifies that the DNS records for the ACME challenge have propagated.
 func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	log := logf.WithResource(logf.FromContext(ctx, "Check"), ch).WithValues("domain", ch.Spec.DNSName)
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, false, s.DNS01Nameservers...)
+	providerConfig, err := extractChallengeSolverConfig(ch)
+	if err != nil {
+		return err
+	}
+
+	// CNAMEs are resolved by the recursive nameservers during the check
+	fqdn, err := s.challengeFQDN(ch.Spec.DNSName, providerConfig, false)
 	if err != nil {
 		return err
 	}
 
 	log.V(logf.DebugLevel).Info("checking DNS propagation", "nameservers", s.Context.DNS01Nameservers)
 
-	ok, err := util.PreCheckDNS(fqdn, ch.Spec.Key, s.Context.DNS01Nameservers,
-		s.Context.DNS01CheckAuthoritative)
+	ok, err := util.PreCheckDNS(fqdn, challengeKey(ch), s.Context.DNS01Nameservers,
+		s.Context.DNS01CheckAuthoritative, s.Context.DNS01NameserverStrategy)
 	if err != nil {
 		return err
 	}
@@ -152,39 +236,121 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(ch.Spec.DNSName, providerConfig, true)
 	if err != nil {
 		return err
 	}
 
-	return slv.CleanUp(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	return slv.CleanUp(ch.Spec.DNSName, fqdn, challengeKey(ch))
+}
+
+// ErrValidationNotSupported is returned by Validate if the configured DNS
+// provider cannot be validated without presenting a record.
+var ErrValidationNotSupported = errors.New("the DNS01 provider does not support validation")
+
+// validator is implemented by DNS providers which can check that their
+// credentials are valid and that the zones of the given domains can be
+// managed, without changing any records.
+type validator interface {
+	Validate(domains []string) error
+}
+
+// Validate performs a dry run of the DNS01 provider configured on a solver
+// of the given issuer. The provider's credentials are checked, along with
+// access to the zones of any DNS names or zones the solver is selected for.
+// ErrValidationNotSupported is returned if the provider does not support
+// validation.
+func (s *Solver) Validate(ctx context.Context, issuer v1.GenericIssuer, cfg cmacme.ACMEChallengeSolver) error {
+	if cfg.DNS01 == nil {
+		return fmt.Errorf("no dns01 challenge solver configuration found")
+	}
+
+	// The webhook based solvers, including rfc2136, do not provide a way to
+	// check their configuration without presenting a record.
+	if _, _, err := s.dns01SolverForConfig(cfg.DNS01); err != errNotFound {
+		return ErrValidationNotSupported
+	}
+
+	slv, err := s.solverForConfig(ctx, issuer, nil, cfg.DNS01)
+	if err != nil {
+		return err
+	}
+
+	v, ok := slv.(validator)
+	if !ok {
+		return ErrValidationNotSupported
+	}
+
+	var domains []string
+	if cfg.Selector != nil {
+		for _, name := range cfg.Selector.DNSNames {
+			domains = append(domains, strings.TrimPrefix(name, "*."))
+		}
+		domains = append(domains, cfg.Selector.DNSZones...)
+	}
+
+	return v.Validate(domains)
 }
 
 func followCNAME(strategy cmacme.CNAMEStrategy) bool {
 	return strategy == cmacme.FollowStrategy
 }
 
+// challengeFQDN returns the FQDN of the TXT record used to solve a DNS01
+// challenge for the given domain. If the solver delegates challenges to a
+// challenge domain the record is always created in that domain. Otherwise
+// CNAMEs are followed if allowed by the solver's CNAME strategy and
+// followCNAMEs is true.
+func (s *Solver) challengeFQDN(domain string, cfg *cmacme.ACMEChallengeSolverDNS01, followCNAMEs bool) (string, error) {
+	if cfg.ChallengeDomain != "" {
+		return util.DelegatedDNS01FQDN(domain, cfg.ChallengeDomain), nil
+	}
+	return util.DNS01LookupFQDN(domain, followCNAMEs && followCNAME(cfg.CNAMEStrategy), s.DNS01Nameservers...)
+}
+
 func extractChallengeSolverConfig(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverDNS01, error) {
 	if ch.Spec.Solver.DNS01 == nil {
-		return nil, fmt.Errorf("no dns01 challenge solver configuration found")
+		return nil, cmerrors.NewInvalidData("no dns01 challenge solver configuration found")
 	}
 
 	return ch.Spec.Solver.DNS01, nil
 }
 
-// solverForChallenge returns a Solver for the given providerName.
-// The providerName is the name of an ACME DNS-01 challenge provider as
-// specified on the Issuer resource for the Solver.
+// solverForChallenge returns a Solver for the DNS01 provider configured on
+// the given challenge.
 func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) (solver, *cmacme.ACMEChallengeSolverDNS01, error) {
-	log := logf.FromContext(ctx, "solverForChallenge")
+	providerConfig, err := extractChallengeSolverConfig(ch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	impl, err := s.solverForConfig(ctx, issuer, ch, providerConfig)
+	if err != nil {
+		return nil, providerConfig, err
+	}
+
+	return impl, providerConfig, nil
+}
+
+// solverForConfig returns a Solver for the given DNS01 provider configuration,
+// as specified on the Issuer resource for the Solver.
+// A new provider is constructed for every call and any credentials are read
+// from the Secret lister at that time, so rotated credentials are used for
+// the next Present or CleanUp without needing to restart or edit the Issuer.
+// Implementations must not cache providers across calls.
+// The Challenge being solved is nil when the provider is only being validated.
+func (s *Solver) solverForConfig(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge, providerConfig *cmacme.ACMEChallengeSolverDNS01) (solver, error) {
+	log := logf.FromContext(ctx, "solverForConfig")
 	dbg := log.V(logf.DebugLevel)
 
 	resourceNamespace := s.ResourceNamespace(issuer)
 	canUseAmbientCredentials := s.CanUseAmbientCredentials(issuer)
 
-	providerConfig, err := extractChallengeSolverConfig(ch)
+	// The issuer's trust bundle is used by every provider which talks to its
+	// DNS API over TLS, other than Akamai whose client cannot be configured.
+	rootCAs, err := pki.AddTrustBundle(nil, issuer.GetSpec().TrustBundle)
 	if err != nil {
-		return nil, nil, err
+		return nil, cmerrors.NewInvalidData("error loading issuer trust bundle: %v", err)
 	}
 
 	var impl solver
@@ -193,17 +359,17 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		dbg.Info("preparing to create Akamai provider")
 		clientToken, err := s.loadSecretData(&providerConfig.Akamai.ClientToken, resourceNamespace)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error getting akamai client token")
+			return nil, errors.Wrap(err, "error getting akamai client token")
 		}
 
 		clientSecret, err := s.loadSecretData(&providerConfig.Akamai.ClientSecret, resourceNamespace)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error getting akamai client secret")
+			return nil, errors.Wrap(err, "error getting akamai client secret")
 		}
 
 		accessToken, err := s.loadSecretData(&providerConfig.Akamai.AccessToken, resourceNamespace)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error getting akamai access token")
+			return nil, errors.Wrap(err, "error getting akamai access token")
 		}
 
 		impl, err = akamai.NewDNSProvider(
@@ -213,7 +379,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			string(accessToken),
 			s.DNS01Nameservers)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error instantiating akamai challenge solver")
+			return nil, errors.Wrap(err, "error instantiating akamai challenge solver")
 		}
 	case providerConfig.CloudDNS != nil:
 		dbg.Info("preparing to create CloudDNS provider")
@@ -225,25 +391,33 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		if providerConfig.CloudDNS.ServiceAccount != nil {
 			saSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.CloudDNS.ServiceAccount.Name)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error getting clouddns service account: %s", err)
+				return nil, fmt.Errorf("error getting clouddns service account: %s", err)
 			}
 
 			saKey := providerConfig.CloudDNS.ServiceAccount.Key
 			keyData = saSecret.Data[saKey]
 			if len(keyData) == 0 {
-				return nil, nil, fmt.Errorf("specified key %q not found in secret %s/%s", saKey, saSecret.Namespace, saSecret.Name)
+				return nil, fmt.Errorf("specified key %q not found in secret %s/%s", saKey, saSecret.Namespace, saSecret.Name)
 			}
 		}
 
 		// attempt to construct the cloud dns provider
-		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName)
+		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName, s.UserAgent, rootCAs)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
 		}
 	case providerConfig.Cloudflare != nil:
 		dbg.Info("preparing to create Cloudflare provider")
 		if providerConfig.Cloudflare.APIKey != nil && providerConfig.Cloudflare.APIToken != nil {
-			return nil, nil, fmt.Errorf("API key and API token secret references are both present")
+			return nil, fmt.Errorf("API key and API token secret references are both present")
+		}
+
+		if len(providerConfig.Cloudflare.APITokens) > 0 {
+			impl, err = s.cloudflareFailoverSolver(providerConfig.Cloudflare, resourceNamespace, rootCAs)
+			if err != nil {
+				return nil, err
+			}
+			break
 		}
 
 		var saSecretName, saSecretKey string
@@ -257,12 +431,12 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 
 		saSecret, err := s.secretLister.Secrets(resourceNamespace).Get(saSecretName)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error getting cloudflare secret: %s", err)
+			return nil, fmt.Errorf("error getting cloudflare secret: %s", err)
 		}
 
 		keyData, ok := saSecret.Data[saSecretKey]
 		if !ok {
-			return nil, nil, fmt.Errorf("specified key %q not found in secret %s/%s", saSecretKey, saSecret.Namespace, saSecret.Name)
+			return nil, fmt.Errorf("specified key %q not found in secret %s/%s", saSecretKey, saSecret.Namespace, saSecret.Name)
 		}
 
 		var apiKey, apiToken string
@@ -273,22 +447,57 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		email := providerConfig.Cloudflare.Email
-		impl, err = s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, s.DNS01Nameservers, s.RESTConfig.UserAgent)
+		cf, err := s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, s.DNS01Nameservers, s.UserAgent)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
 		}
+		cf.SetMetrics(s.Metrics)
+		cf.SetRootCAs(rootCAs)
+		impl = cf
 	case providerConfig.DigitalOcean != nil:
 		dbg.Info("preparing to create DigitalOcean provider")
 		apiTokenSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.DigitalOcean.Token.Name)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error getting digitalocean token: %s", err)
+			return nil, fmt.Errorf("error getting digitalocean token: %s", err)
 		}
 
 		apiToken := string(apiTokenSecret.Data[providerConfig.DigitalOcean.Token.Key])
 
-		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), s.DNS01Nameservers)
+		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), s.DNS01Nameservers, s.UserAgent, rootCAs)
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
+		}
+	case providerConfig.Bunny != nil:
+		dbg.Info("preparing to create Bunny.net provider")
+		accessKeySecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Bunny.AccessKey.Name)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
+			return nil, fmt.Errorf("error getting bunny access key: %s", err)
+		}
+
+		accessKey, ok := accessKeySecret.Data[providerConfig.Bunny.AccessKey.Key]
+		if !ok {
+			return nil, fmt.Errorf("error getting bunny access key: key '%s' not found in secret", providerConfig.Bunny.AccessKey.Key)
+		}
+
+		impl, err = s.dnsProviderConstructors.bunny(strings.TrimSpace(string(accessKey)), s.DNS01Nameservers, s.UserAgent, rootCAs)
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating bunny challenge solver: %s", err)
+		}
+	case providerConfig.Gandi != nil:
+		dbg.Info("preparing to create Gandi provider")
+		tokenSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Gandi.Token.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error getting gandi token: %s", err)
+		}
+
+		token, ok := tokenSecret.Data[providerConfig.Gandi.Token.Key]
+		if !ok {
+			return nil, fmt.Errorf("error getting gandi token: key '%s' not found in secret", providerConfig.Gandi.Token.Key)
+		}
+
+		impl, err = s.dnsProviderConstructors.gandi(strings.TrimSpace(string(token)), s.DNS01Nameservers, s.UserAgent, rootCAs)
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating gandi challenge solver: %s", err)
 		}
 	case providerConfig.Route53 != nil:
 		dbg.Info("preparing to create Route53 provider")
@@ -296,28 +505,32 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		if providerConfig.Route53.SecretAccessKey.Name != "" {
 			secretAccessKeySecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Route53.SecretAccessKey.Name)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error getting route53 secret access key: %s", err)
+				return nil, fmt.Errorf("error getting route53 secret access key: %s", err)
 			}
 
 			secretAccessKeyBytes, ok := secretAccessKeySecret.Data[providerConfig.Route53.SecretAccessKey.Key]
 			if !ok {
-				return nil, nil, fmt.Errorf("error getting route53 secret access key: key '%s' not found in secret", providerConfig.Route53.SecretAccessKey.Key)
+				return nil, fmt.Errorf("error getting route53 secret access key: key '%s' not found in secret", providerConfig.Route53.SecretAccessKey.Key)
 			}
 			secretAccessKey = string(secretAccessKeyBytes)
 		}
 
-		impl, err = s.dnsProviderConstructors.route53(
-			strings.TrimSpace(providerConfig.Route53.AccessKeyID),
-			strings.TrimSpace(secretAccessKey),
-			providerConfig.Route53.HostedZoneID,
-			providerConfig.Route53.Region,
-			providerConfig.Route53.Role,
-			canUseAmbientCredentials,
-			s.DNS01Nameservers,
-			s.RESTConfig.UserAgent,
-		)
+		impl, err = s.dnsProviderConstructors.route53(route53.Options{
+			AccessKeyID:      strings.TrimSpace(providerConfig.Route53.AccessKeyID),
+			SecretAccessKey:  strings.TrimSpace(secretAccessKey),
+			Ambient:          canUseAmbientCredentials,
+			HostedZoneID:     providerConfig.Route53.HostedZoneID,
+			Region:           providerConfig.Route53.Region,
+			Role:             providerConfig.Route53.Role,
+			RoleSessionName:  providerConfig.Route53.RoleSessionName,
+			SessionTags:      providerConfig.Route53.SessionTags,
+			Comment:          route53ChangeComment(providerConfig.Route53.Comment, ch),
+			DNS01Nameservers: s.DNS01Nameservers,
+			UserAgent:        s.UserAgent,
+			RootCAs:          rootCAs,
+		})
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err)
 		}
 	case providerConfig.AzureDNS != nil:
 		dbg.Info("preparing to create AzureDNS provider")
@@ -327,55 +540,59 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		if providerConfig.AzureDNS.ClientID != "" {
 			clientSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.AzureDNS.ClientSecret.Name)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error getting azuredns client secret: %s", err)
+				return nil, fmt.Errorf("error getting azuredns client secret: %s", err)
 			}
 
 			clientSecretBytes, ok := clientSecret.Data[providerConfig.AzureDNS.ClientSecret.Key]
 			if !ok {
-				return nil, nil, fmt.Errorf("error getting azure dns client secret: key '%s' not found in secret", providerConfig.AzureDNS.ClientSecret.Key)
+				return nil, fmt.Errorf("error getting azure dns client secret: key '%s' not found in secret", providerConfig.AzureDNS.ClientSecret.Key)
 			}
 			secret = string(clientSecretBytes)
 		}
-		impl, err = s.dnsProviderConstructors.azureDNS(
-			string(providerConfig.AzureDNS.Environment),
-			providerConfig.AzureDNS.ClientID,
-			secret,
-			providerConfig.AzureDNS.SubscriptionID,
-			providerConfig.AzureDNS.TenantID,
-			providerConfig.AzureDNS.ResourceGroupName,
-			providerConfig.AzureDNS.HostedZoneName,
-			s.DNS01Nameservers,
-			canUseAmbientCredentials,
-			providerConfig.AzureDNS.ManagedIdentity,
-		)
+		impl, err = s.dnsProviderConstructors.azureDNS(azuredns.Options{
+			Environment:       string(providerConfig.AzureDNS.Environment),
+			ClientID:          providerConfig.AzureDNS.ClientID,
+			ClientSecret:      secret,
+			TenantID:          providerConfig.AzureDNS.TenantID,
+			Ambient:           canUseAmbientCredentials,
+			ManagedIdentity:   providerConfig.AzureDNS.ManagedIdentity,
+			SubscriptionID:    providerConfig.AzureDNS.SubscriptionID,
+			ResourceGroupName: providerConfig.AzureDNS.ResourceGroupName,
+			ZoneName:          providerConfig.AzureDNS.HostedZoneName,
+			PrivateZone:       providerConfig.AzureDNS.PrivateZone,
+			DNS01Nameservers:  s.DNS01Nameservers,
+			UserAgent:         s.UserAgent,
+			RootCAs:           rootCAs,
+		})
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating azuredns challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating azuredns challenge solver: %s", err)
 		}
 	case providerConfig.AcmeDNS != nil:
 		dbg.Info("preparing to create ACMEDNS provider")
 		accountSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.AcmeDNS.AccountSecret.Name)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: %s", err)
+			return nil, fmt.Errorf("error getting acmedns accounts secret: %s", err)
 		}
 
 		accountSecretBytes, ok := accountSecret.Data[providerConfig.AcmeDNS.AccountSecret.Key]
 		if !ok {
-			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", providerConfig.AcmeDNS.AccountSecret.Key)
+			return nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", providerConfig.AcmeDNS.AccountSecret.Key)
 		}
 
 		impl, err = s.dnsProviderConstructors.acmeDNS(
 			providerConfig.AcmeDNS.Host,
 			accountSecretBytes,
 			s.DNS01Nameservers,
+			rootCAs,
 		)
 		if err != nil {
-			return nil, providerConfig, fmt.Errorf("error instantiating acmedns challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating acmedns challenge solver: %s", err)
 		}
 	default:
-		return nil, providerConfig, fmt.Errorf("no dns provider config specified for challenge")
+		return nil, cmerrors.NewInvalidData("no dns provider config specified for challenge")
 	}
 
-	return impl, providerConfig, nil
+	return impl, nil
 }
 
 func (s *Solver) prepareChallengeRequest(issuer v1.GenericIssuer, ch *cmacme.Challenge) (webhook.Solver, *whapi.ChallengeRequest, error) {
@@ -389,7 +606,7 @@ func (s *Solver) prepareChallengeRequest(issuer v1.GenericIssuer, ch *cmacme.Cha
 		return nil, nil, err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(dns01Config.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := s.challengeFQDN(ch.Spec.DNSName, dns01Config, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -416,7 +633,7 @@ func (s *Solver) prepareChallengeRequest(issuer v1.GenericIssuer, ch *cmacme.Cha
 		ResolvedZone:            zone,
 		AllowAmbientCredentials: canUseAmbientCredentials,
 		ResourceNamespace:       resourceNamespace,
-		Key:                     ch.Spec.Key,
+		Key:                     challengeKey(ch),
 		DNSName:                 ch.Spec.DNSName,
 		Config:                  &apiextensionsv1.JSON{Raw: b},
 	}
@@ -426,6 +643,20 @@ func (s *Solver) prepareChallengeRequest(issuer v1.GenericIssuer, ch *cmacme.Cha
 
 var errNotFound = fmt.Errorf("failed to determine DNS01 solver type")
 
+// challengeKey returns the value to present in the DNS01 record for the
+// challenge. This is the key authorization of the challenge, unless it is
+// overridden for testing using the DNS01KeyOverrideAnnotationKey annotation
+// and the ExperimentalDNS01KeyOverride feature gate is enabled.
+func challengeKey(ch *cmacme.Challenge) string {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalDNS01KeyOverride) {
+		return ch.Spec.Key
+	}
+	if key, ok := ch.Annotations[cmacme.DNS01KeyOverrideAnnotationKey]; ok {
+		return key
+	}
+	return ch.Spec.Key
+}
+
 func (s *Solver) dns01SolverForConfig(config *cmacme.ACMEChallengeSolverDNS01) (webhook.Solver, interface{}, error) {
 	solverName := ""
 	var c interface{}
@@ -442,7 +673,7 @@ func (s *Solver) dns01SolverForConfig(config *cmacme.ACMEChallengeSolverDNS01) (
 	}
 	p := s.webhookSolvers[solverName]
 	if p == nil {
-		return nil, c, fmt.Errorf("no solver provider configured for %q", solverName)
+		return nil, c, cmerrors.NewInvalidData("no solver provider configured for %q", solverName)
 	}
 	return p, c, nil
 }
@@ -470,18 +701,74 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 		}
 	}
 
+	s := NewValidator(ctx)
+	s.webhookSolvers = initialized
+	return s, nil
+}
+
+// NewValidator creates a Solver which can only be used to Validate DNS
+// providers. Webhook based solvers are not initialized, as they do not
+// support validation.
+func NewValidator(ctx *controller.Context) *Solver {
 	return &Solver{
 		Context:      ctx,
 		secretLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		dnsProviderConstructors: dnsProviderConstructors{
 			clouddns.NewDNSProvider,
 			cloudflare.NewDNSProviderCredentials,
-			route53.NewDNSProvider,
-			azuredns.NewDNSProviderCredentials,
+			route53.NewDNSProviderWithOptions,
+			azuredns.NewDNSProviderWithOptions,
 			acmedns.NewDNSProviderHostBytes,
 			digitalocean.NewDNSProviderCredentials,
+			bunny.NewDNSProviderCredentials,
+			gandi.NewDNSProviderCredentials,
 		},
-		webhookSolvers: initialized,
+	}
+}
+
+// route53ChangeComment returns the comment to set on Route 53 change batches
+// for the given Challenge, identifying cert-manager (or the configured
+// comment) and the Order the Challenge belongs to.
+func route53ChangeComment(comment string, ch *cmacme.Challenge) string {
+	if comment == "" {
+		comment = route53.DefaultChangeComment
+	}
+	if ch == nil {
+		return comment
+	}
+
+	owner := metav1.GetControllerOf(ch)
+	if owner == nil || owner.Kind != cmacme.OrderKind {
+		return fmt.Sprintf("%s (challenge %s/%s)", comment, ch.Namespace, ch.Name)
+	}
+
+	return fmt.Sprintf("%s (order %s/%s)", comment, ch.Namespace, owner.Name)
+}
+
+// cloudflareFailoverSolver returns a solver which uses each of the configured
+// Cloudflare API tokens in turn, moving on to the next token if Cloudflare
+// rejects or rate limits the previous one.
+func (s *Solver) cloudflareFailoverSolver(cfg *cmacme.ACMEIssuerDNS01ProviderCloudflare, ns string, rootCAs *x509.CertPool) (solver, error) {
+	solvers := make([]solver, 0, len(cfg.APITokens))
+	for i := range cfg.APITokens {
+		apiToken, err := s.loadSecretData(&cfg.APITokens[i], ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting cloudflare api token")
+		}
+
+		slv, err := s.dnsProviderConstructors.cloudFlare(cfg.Email, "", string(apiToken), s.DNS01Nameservers, s.UserAgent)
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
+		}
+		slv.SetMetrics(s.Metrics)
+		slv.SetRootCAs(rootCAs)
+		solvers = append(solvers, slv)
+	}
+
+	return &failoverSolver{
+		solvers:  solvers,
+		failover: cloudflare.IsCredentialsRejected,
+		used:     &s.cloudflareTokensUsed,
 	}, nil
 }
 
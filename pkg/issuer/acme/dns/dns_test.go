@@ -18,14 +18,22 @@ package dns
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook"
+	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -34,6 +42,10 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	testserver "github.com/cert-manager/cert-manager/test/acme/dns/server"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 )
 
 func newIssuer(name, namespace string) *v1.Issuer {
@@ -352,6 +364,234 @@ func TestSolveForDigitalOcean(t *testing.T) {
 
 }
 
+func TestSolveForBunny(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("bunny", "default", map[string][]byte{
+					"access-key": []byte("FAKE-ACCESS-KEY\n"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{
+							AccessKey: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "bunny",
+								},
+								Key: "access-key",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedCall := []fakeDNSProviderCall{
+		{
+			name: "bunny",
+			args: []interface{}{"FAKE-ACCESS-KEY", util.RecursiveNameservers},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedCall, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedCall, f.dnsProviders.calls)
+	}
+}
+
+func TestSolveForGandi(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("gandi", "default", map[string][]byte{
+					"token": []byte("FAKE-TOKEN\n"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Gandi: &cmacme.ACMEIssuerDNS01ProviderGandi{
+							Token: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "gandi",
+								},
+								Key: "token",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedCall := []fakeDNSProviderCall{
+		{
+			name: "gandi",
+			args: []interface{}{"FAKE-TOKEN", util.RecursiveNameservers},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedCall, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedCall, f.dnsProviders.calls)
+	}
+}
+
+func TestSolveForCloudflareRotatedToken(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("cloudflare-token", "default", map[string][]byte{
+					"api-token": []byte("old-token"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+							Email: "test",
+							APIToken: &cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "cloudflare-token",
+								},
+								Key: "api-token",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	if _, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge); err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	// Rotate the token without touching the Issuer. The next challenge must
+	// pick up the new token without the solver being rebuilt.
+	_, err := f.Builder.Client.CoreV1().Secrets("default").Update(context.Background(), newSecret("cloudflare-token", "default", map[string][]byte{
+		"api-token": []byte("new-token"),
+	}), metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("error updating secret: %s", err)
+	}
+	f.Builder.Sync()
+
+	if _, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge); err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedCalls := []fakeDNSProviderCall{
+		{
+			name: "cloudflare",
+			args: []interface{}{"test", "", "old-token", util.RecursiveNameservers},
+		},
+		{
+			name: "cloudflare",
+			args: []interface{}{"test", "", "new-token", util.RecursiveNameservers},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedCalls, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedCalls, f.dnsProviders.calls)
+	}
+}
+
+func TestSolverForChallengeTrustBundle(t *testing.T) {
+	trustBundle := testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+		&v1.Certificate{Spec: v1.CertificateSpec{CommonName: "proxy-ca", IsCA: true}})
+	secretRef := func(name string) cmmeta.SecretKeySelector {
+		return cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}, Key: "key"}
+	}
+
+	tests := map[string]*cmacme.ACMEChallengeSolverDNS01{
+		"clouddns":     {CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{Project: "test"}},
+		"route53":      {Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{Region: "us-west-2"}},
+		"azuredns":     {AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{SubscriptionID: "test"}},
+		"acmedns":      {AcmeDNS: &cmacme.ACMEIssuerDNS01ProviderAcmeDNS{Host: "https://acme-dns.example.com", AccountSecret: secretRef("acmedns")}},
+		"digitalocean": {DigitalOcean: &cmacme.ACMEIssuerDNS01ProviderDigitalOcean{Token: secretRef("digitalocean")}},
+		"bunny":        {Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{AccessKey: secretRef("bunny")}},
+		"gandi":        {Gandi: &cmacme.ACMEIssuerDNS01ProviderGandi{Token: secretRef("gandi")}},
+	}
+	for name, dns01 := range tests {
+		for _, withTrustBundle := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s with trust bundle %t", name, withTrustBundle), func(t *testing.T) {
+				issuer := newIssuer("test", "default")
+				if withTrustBundle {
+					issuer.Spec.TrustBundle = trustBundle
+				}
+				f := &solverFixture{
+					Builder: &test.Builder{
+						KubeObjects: []runtime.Object{
+							newSecret("acmedns", "default", map[string][]byte{"key": []byte("{}")}),
+							newSecret("digitalocean", "default", map[string][]byte{"key": []byte("token")}),
+							newSecret("bunny", "default", map[string][]byte{"key": []byte("access-key")}),
+							newSecret("gandi", "default", map[string][]byte{"key": []byte("token")}),
+						},
+					},
+					Issuer: issuer,
+					Challenge: &cmacme.Challenge{
+						Spec: cmacme.ChallengeSpec{
+							Solver: cmacme.ACMEChallengeSolver{DNS01: dns01},
+						},
+					},
+					dnsProviders: newFakeDNSProviders(),
+				}
+
+				f.Setup(t)
+				defer f.Finish(t)
+
+				if _, _, err := f.Solver.solverForChallenge(context.Background(), f.Issuer, f.Challenge); err != nil {
+					t.Fatalf("expected solverFor to not error, but got: %s", err)
+				}
+
+				rootCAs, ok := f.dnsProviders.rootCAs[name]
+				if !ok {
+					t.Fatalf("expected the %s provider to be constructed", name)
+				}
+				if withTrustBundle != (rootCAs != nil) {
+					t.Errorf("expected root CAs to be set: %t, got: %v", withTrustBundle, rootCAs)
+				}
+			})
+		}
+	}
+}
+
 func TestRoute53TrimCreds(t *testing.T) {
 	f := &solverFixture{
 		Builder: &test.Builder{
@@ -395,7 +635,7 @@ func TestRoute53TrimCreds(t *testing.T) {
 	expectedR53Call := []fakeDNSProviderCall{
 		{
 			name: "route53",
-			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", "", false, util.RecursiveNameservers},
+			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", "", "", "Managed by cert-manager (challenge /)", map[string]string(nil), false, util.RecursiveNameservers},
 		},
 	}
 
@@ -443,7 +683,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "", "", "Managed by cert-manager (challenge /)", map[string]string(nil), true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -476,7 +716,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "", "", "Managed by cert-manager (challenge /)", map[string]string(nil), false, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -540,7 +780,7 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-role", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "my-role", "", "Managed by cert-manager (challenge /)", map[string]string(nil), true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -574,7 +814,43 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-other-role", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "my-other-role", "", "Managed by cert-manager (challenge /)", map[string]string(nil), false, util.RecursiveNameservers},
+				},
+			},
+		},
+		{
+			solverFixture{
+				Builder: &test.Builder{
+					Context: &controller.Context{
+						RESTConfig: new(rest.Config),
+						ContextOptions: controller.ContextOptions{
+							IssuerOptions: controller.IssuerOptions{
+								IssuerAmbientCredentials: true,
+							},
+						},
+					},
+				},
+				Issuer:       newIssuer("test", "default"),
+				dnsProviders: newFakeDNSProviders(),
+				Challenge: &cmacme.Challenge{
+					Spec: cmacme.ChallengeSpec{
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: &cmacme.ACMEChallengeSolverDNS01{
+								Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+									Region:          "us-west-2",
+									Role:            "my-role",
+									RoleSessionName: "my-session",
+									SessionTags:     map[string]string{"team": "a"},
+								},
+							},
+						},
+					},
+				},
+			},
+			result{
+				expectedCall: &fakeDNSProviderCall{
+					name: "route53",
+					args: []interface{}{"", "", "", "us-west-2", "my-role", "my-session", "Managed by cert-manager (challenge /)", map[string]string{"team": "a"}, true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -597,3 +873,512 @@ func TestRoute53AssumeRole(t *testing.T) {
 		}
 	}
 }
+
+func TestRoute53ChangeComment(t *testing.T) {
+	order := &cmacme.Order{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-order", Namespace: "default", UID: "uid"},
+	}
+	orderChallenge := &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-challenge",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(order, cmacme.SchemeGroupVersion.WithKind(cmacme.OrderKind)),
+			},
+		},
+	}
+	orphanChallenge := &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-challenge", Namespace: "default"},
+	}
+
+	tests := map[string]struct {
+		comment  string
+		ch       *cmacme.Challenge
+		expected string
+	}{
+		"no challenge uses the default comment": {
+			expected: "Managed by cert-manager",
+		},
+		"no challenge uses the configured comment": {
+			comment:  "owner=team-a",
+			expected: "owner=team-a",
+		},
+		"a challenge owned by an order identifies the order": {
+			ch:       orderChallenge,
+			expected: "Managed by cert-manager (order default/test-order)",
+		},
+		"the order is appended to the configured comment": {
+			comment:  "owner=team-a",
+			ch:       orderChallenge,
+			expected: "owner=team-a (order default/test-order)",
+		},
+		"a challenge without an order identifies the challenge": {
+			ch:       orphanChallenge,
+			expected: "Managed by cert-manager (challenge default/test-challenge)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, route53ChangeComment(test.comment, test.ch))
+		})
+	}
+}
+
+func TestRoute53CommentFromConfig(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{},
+		Issuer:  newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-challenge",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(&cmacme.Order{ObjectMeta: metav1.ObjectMeta{Name: "test-order"}},
+						cmacme.SchemeGroupVersion.WithKind(cmacme.OrderKind)),
+				},
+			},
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+							Region:  "us-west-2",
+							Comment: "owner=team-a",
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	_, _, err := f.Solver.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedR53Call := []fakeDNSProviderCall{
+		{
+			name: "route53",
+			args: []interface{}{"", "", "", "us-west-2", "", "", "owner=team-a (order default/test-order)", map[string]string(nil), false, util.RecursiveNameservers},
+		},
+	}
+	if !reflect.DeepEqual(expectedR53Call, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedR53Call, f.dnsProviders.calls)
+	}
+}
+
+func TestSolveForCloudflareMultipleTokens(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("cloudflare-token-primary", "default", map[string][]byte{
+					"api-token": []byte("primary-token"),
+				}),
+				newSecret("cloudflare-token-secondary", "default", map[string][]byte{
+					"api-token": []byte("secondary-token"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+							Email: "test",
+							APITokens: []cmmeta.SecretKeySelector{
+								{
+									LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloudflare-token-primary"},
+									Key:                  "api-token",
+								},
+								{
+									LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloudflare-token-secondary"},
+									Key:                  "api-token",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	slv, _, err := f.Solver.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+	failover, ok := slv.(*failoverSolver)
+	if !ok {
+		t.Fatalf("expected a failover solver, but got %T", slv)
+	}
+	if len(failover.solvers) != 2 {
+		t.Fatalf("expected a solver for each token, but got %d", len(failover.solvers))
+	}
+
+	expectedCalls := []fakeDNSProviderCall{
+		{
+			name: "cloudflare",
+			args: []interface{}{"test", "", "primary-token", util.RecursiveNameservers},
+		},
+		{
+			name: "cloudflare",
+			args: []interface{}{"test", "", "secondary-token", util.RecursiveNameservers},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedCalls, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedCalls, f.dnsProviders.calls)
+	}
+}
+
+func TestValidateNotSupported(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("digitalocean", "default", map[string][]byte{
+					"token": []byte("FAKE-TOKEN"),
+				}),
+			},
+		},
+		Issuer:       newIssuer("test", "default"),
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	err := f.Solver.Validate(context.Background(), f.Issuer, cmacme.ACMEChallengeSolver{
+		DNS01: &cmacme.ACMEChallengeSolverDNS01{
+			DigitalOcean: &cmacme.ACMEIssuerDNS01ProviderDigitalOcean{
+				Token: cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "digitalocean",
+					},
+					Key: "token",
+				},
+			},
+		},
+	})
+	if err != ErrValidationNotSupported {
+		t.Fatalf("expected %v, but got: %v", ErrValidationNotSupported, err)
+	}
+
+	err = f.Solver.Validate(context.Background(), f.Issuer, cmacme.ACMEChallengeSolver{
+		DNS01: &cmacme.ACMEChallengeSolverDNS01{
+			DigitalOcean: &cmacme.ACMEIssuerDNS01ProviderDigitalOcean{
+				Token: cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "does-not-exist",
+					},
+					Key: "token",
+				},
+			},
+		},
+	})
+	if err == nil || err == ErrValidationNotSupported {
+		t.Fatalf("expected an error getting the token, but got: %v", err)
+	}
+}
+
+func TestValidateNotSupportedForWebhookSolvers(t *testing.T) {
+	f := &solverFixture{
+		Builder:      &test.Builder{},
+		Issuer:       newIssuer("test", "default"),
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	tests := map[string]*cmacme.ACMEChallengeSolverDNS01{
+		"webhook": {
+			Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{
+				GroupName:  "acme.example.com",
+				SolverName: "example",
+			},
+		},
+		"rfc2136": {
+			RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+				Nameserver: "127.0.0.1:53",
+			},
+		},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := f.Solver.Validate(context.Background(), f.Issuer, cmacme.ACMEChallengeSolver{DNS01: cfg})
+			if err != ErrValidationNotSupported {
+				t.Fatalf("expected %v, but got: %v", ErrValidationNotSupported, err)
+			}
+		})
+	}
+}
+
+// recordingWebhookSolver is a webhook.Solver that records the challenge
+// requests it is asked to present and clean up.
+type recordingWebhookSolver struct {
+	presented, cleanedUp []*whapi.ChallengeRequest
+}
+
+func (r *recordingWebhookSolver) Name() string { return "rfc2136" }
+
+func (r *recordingWebhookSolver) Present(ch *whapi.ChallengeRequest) error {
+	r.presented = append(r.presented, ch)
+	return nil
+}
+
+func (r *recordingWebhookSolver) CleanUp(ch *whapi.ChallengeRequest) error {
+	r.cleanedUp = append(r.cleanedUp, ch)
+	return nil
+}
+
+func (r *recordingWebhookSolver) Initialize(*rest.Config, <-chan struct{}) error { return nil }
+
+func TestDelegatedChallengeDomain(t *testing.T) {
+	ctx := logf.NewContext(context.TODO(), logtesting.NewTestLogger(t), t.Name())
+	server := &testserver.BasicServer{Zones: []string{"delegated.net."}}
+	if err := server.Run(ctx); err != nil {
+		t.Fatalf("failed to start test DNS server: %v", err)
+	}
+	defer server.Shutdown()
+
+	f := &solverFixture{
+		Builder: &test.Builder{},
+		Issuer:  newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				DNSName: "example.com",
+				Key:     "token",
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						ChallengeDomain: "acme.delegated.net",
+						RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+							Nameserver: server.ListenAddr(),
+						},
+					},
+				},
+			},
+		},
+	}
+	f.Setup(t)
+	defer f.Finish(t)
+
+	webhookSolver := &recordingWebhookSolver{}
+	s := f.Solver
+	s.DNS01Nameservers = []string{server.ListenAddr()}
+	s.webhookSolvers = map[string]webhook.Solver{"rfc2136": webhookSolver}
+
+	const expectedFQDN = "_acme-challenge.example.com.acme.delegated.net."
+
+	if err := s.Present(ctx, f.Issuer, f.Challenge); err != nil {
+		t.Fatalf("unexpected error presenting challenge: %v", err)
+	}
+	if assert.Len(t, webhookSolver.presented, 1) {
+		assert.Equal(t, expectedFQDN, webhookSolver.presented[0].ResolvedFQDN)
+		assert.Equal(t, "delegated.net.", webhookSolver.presented[0].ResolvedZone)
+	}
+
+	var checkedFQDN string
+	defer func(preCheckDNS func(string, string, []string, bool, util.NameserverStrategy) (bool, error)) {
+		util.PreCheckDNS = preCheckDNS
+	}(util.PreCheckDNS)
+	util.PreCheckDNS = func(fqdn, _ string, _ []string, _ bool, _ util.NameserverStrategy) (bool, error) {
+		checkedFQDN = fqdn
+		return false, nil
+	}
+	assert.EqualError(t, s.Check(ctx, f.Issuer, f.Challenge), `DNS record for "example.com" not yet propagated`)
+	assert.Equal(t, expectedFQDN, checkedFQDN)
+
+	if err := s.CleanUp(ctx, f.Issuer, f.Challenge); err != nil {
+		t.Fatalf("unexpected error cleaning up challenge: %v", err)
+	}
+	if assert.Len(t, webhookSolver.cleanedUp, 1) {
+		assert.Equal(t, expectedFQDN, webhookSolver.cleanedUp[0].ResolvedFQDN)
+	}
+}
+
+func TestDNS01KeyOverride(t *testing.T) {
+	tests := map[string]struct {
+		featureEnabled bool
+		annotations    map[string]string
+		expectedKey    string
+	}{
+		"the key of the challenge is presented if the feature is disabled": {
+			annotations: map[string]string{cmacme.DNS01KeyOverrideAnnotationKey: "override"},
+			expectedKey: "token",
+		},
+		"the key of the challenge is presented if the annotation is not set": {
+			featureEnabled: true,
+			expectedKey:    "token",
+		},
+		"the value of the annotation is presented if the feature is enabled": {
+			featureEnabled: true,
+			annotations:    map[string]string{cmacme.DNS01KeyOverrideAnnotationKey: "override"},
+			expectedKey:    "override",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExperimentalDNS01KeyOverride, tc.featureEnabled)()
+
+			ctx := logf.NewContext(context.TODO(), logtesting.NewTestLogger(t), t.Name())
+			server := &testserver.BasicServer{Zones: []string{"example.com."}}
+			if err := server.Run(ctx); err != nil {
+				t.Fatalf("failed to start test DNS server: %v", err)
+			}
+			defer server.Shutdown()
+
+			f := &solverFixture{
+				Builder: &test.Builder{},
+				Issuer:  newIssuer("test", "default"),
+				Challenge: &cmacme.Challenge{
+					ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+					Spec: cmacme.ChallengeSpec{
+						DNSName: "example.com",
+						Key:     "token",
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: &cmacme.ACMEChallengeSolverDNS01{
+								RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+									Nameserver: server.ListenAddr(),
+								},
+							},
+						},
+					},
+				},
+			}
+			f.Setup(t)
+			defer f.Finish(t)
+
+			webhookSolver := &recordingWebhookSolver{}
+			s := f.Solver
+			s.DNS01Nameservers = []string{server.ListenAddr()}
+			s.webhookSolvers = map[string]webhook.Solver{"rfc2136": webhookSolver}
+
+			if err := s.Present(ctx, f.Issuer, f.Challenge); err != nil {
+				t.Fatalf("unexpected error presenting challenge: %v", err)
+			}
+			if assert.Len(t, webhookSolver.presented, 1) {
+				assert.Equal(t, tc.expectedKey, webhookSolver.presented[0].Key)
+			}
+
+			var checkedKey string
+			defer func(preCheckDNS func(string, string, []string, bool, util.NameserverStrategy) (bool, error)) {
+				util.PreCheckDNS = preCheckDNS
+			}(util.PreCheckDNS)
+			util.PreCheckDNS = func(_, value string, _ []string, _ bool, _ util.NameserverStrategy) (bool, error) {
+				checkedKey = value
+				return false, nil
+			}
+			assert.Error(t, s.Check(ctx, f.Issuer, f.Challenge))
+			assert.Equal(t, tc.expectedKey, checkedKey)
+
+			if err := s.CleanUp(ctx, f.Issuer, f.Challenge); err != nil {
+				t.Fatalf("unexpected error cleaning up challenge: %v", err)
+			}
+			if assert.Len(t, webhookSolver.cleanedUp, 1) {
+				assert.Equal(t, tc.expectedKey, webhookSolver.cleanedUp[0].Key)
+			}
+		})
+	}
+}
+
+func TestPresentReportsCNAMEResolution(t *testing.T) {
+	ctx := logf.NewContext(context.TODO(), logtesting.NewTestLogger(t), t.Name())
+
+	// the challenge record of example.com is a CNAME to a record in the
+	// delegated.net zone
+	server := &testserver.BasicServer{
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(req)
+			defer w.WriteMsg(m)
+
+			q := req.Question[0]
+			switch {
+			case q.Qtype == dns.TypeCNAME && q.Name == "_acme-challenge.example.com.":
+				rr, _ := dns.NewRR("_acme-challenge.example.com. 60 IN CNAME _acme-challenge.example.com.delegated.net.")
+				m.Answer = []dns.RR{rr}
+			case q.Qtype == dns.TypeSOA && dns.IsSubDomain("delegated.net.", q.Name):
+				rr, _ := dns.NewRR("delegated.net. 60 IN SOA ns1.delegated.net. admin.delegated.net. 2016022801 28800 7200 2419200 1200")
+				m.Answer = []dns.RR{rr}
+			}
+		}),
+	}
+	if err := server.Run(ctx); err != nil {
+		t.Fatalf("failed to start test DNS server: %v", err)
+	}
+	defer server.Shutdown()
+
+	f := &solverFixture{
+		Builder: &test.Builder{},
+		Issuer:  newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				DNSName: "example.com",
+				Key:     "token",
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						CNAMEStrategy: cmacme.FollowStrategy,
+						RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+							Nameserver: server.ListenAddr(),
+						},
+					},
+				},
+			},
+		},
+	}
+	f.Setup(t)
+	defer f.Finish(t)
+
+	webhookSolver := &recordingWebhookSolver{}
+	s := f.Solver
+	s.DNS01Nameservers = []string{server.ListenAddr()}
+	s.webhookSolvers = map[string]webhook.Solver{"rfc2136": webhookSolver}
+
+	if err := s.Present(ctx, f.Issuer, f.Challenge); err != nil {
+		t.Fatalf("unexpected error presenting challenge: %v", err)
+	}
+
+	assert.Equal(t, &cmacme.ACMEChallengePresentation{
+		Solver:       "rfc2136",
+		ResolvedFQDN: "_acme-challenge.example.com.delegated.net.",
+		ResolvedZone: "delegated.net.",
+		CNAMETarget:  "_acme-challenge.example.com.delegated.net.",
+	}, f.Challenge.Status.Presentation)
+}
+
+func TestDNS01ProviderName(t *testing.T) {
+	tests := map[string]struct {
+		cfg          *cmacme.ACMEChallengeSolverDNS01
+		expectedName string
+	}{
+		"cloudflare": {
+			cfg:          &cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{}},
+			expectedName: "cloudflare",
+		},
+		"webhook solvers are named by their group and solver name": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{
+				GroupName:  "acme.example.com",
+				SolverName: "example",
+			}},
+			expectedName: "webhook/acme.example.com/example",
+		},
+		"no provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, dns01ProviderName(tc.cfg))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"fmt"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// failoverSolver presents and cleans up DNS01 records using an ordered list
+// of solvers, each configured with different credentials. The next solver is
+// only tried if the previous one failed with an error for which failover
+// returns true, such as its credentials being rejected or rate limited.
+type failoverSolver struct {
+	solvers  []solver
+	failover func(error) bool
+
+	// used records the index of the solver that presented each record, so
+	// that the same credentials are tried first when the record is cleaned
+	// up. It is shared between failoverSolvers as a new solver is built for
+	// every Present and CleanUp call.
+	used *sync.Map
+}
+
+var _ solver = &failoverSolver{}
+
+func (f *failoverSolver) Present(domain, fqdn, value string) error {
+	i, err := f.try(fqdn, value, func(s solver) error {
+		return s.Present(domain, fqdn, value)
+	})
+	if err != nil {
+		return err
+	}
+	f.used.Store(recordKey(fqdn, value), i)
+	return nil
+}
+
+func (f *failoverSolver) CleanUp(domain, fqdn, value string) error {
+	if _, err := f.try(fqdn, value, func(s solver) error {
+		return s.CleanUp(domain, fqdn, value)
+	}); err != nil {
+		return err
+	}
+	f.used.Delete(recordKey(fqdn, value))
+	return nil
+}
+
+// Validate validates each of the solvers, so that credentials which would
+// be rejected are reported before they are needed.
+func (f *failoverSolver) Validate(domains []string) error {
+	var errs []error
+	for i, s := range f.solvers {
+		v, ok := s.(validator)
+		if !ok {
+			return ErrValidationNotSupported
+		}
+		if err := v.Validate(domains); err != nil {
+			errs = append(errs, fmt.Errorf("credentials %d: %w", i, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// try calls fn with each solver in turn until one succeeds, starting with the
+// solver that last presented the record. It returns the index of the solver
+// that succeeded.
+func (f *failoverSolver) try(fqdn, value string, fn func(solver) error) (int, error) {
+	first := 0
+	if i, ok := f.used.Load(recordKey(fqdn, value)); ok && i.(int) < len(f.solvers) {
+		first = i.(int)
+	}
+
+	var errs []error
+	for n := range f.solvers {
+		i := (first + n) % len(f.solvers)
+		err := fn(f.solvers[i])
+		if err == nil {
+			return i, nil
+		}
+		if !f.failover(err) {
+			return -1, err
+		}
+		errs = append(errs, fmt.Errorf("credentials %d: %w", i, err))
+	}
+
+	return -1, utilerrors.NewAggregate(errs)
+}
+
+func recordKey(fqdn, value string) string {
+	return fqdn + "/" + value
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errRateLimited = errors.New("429 Too Many Requests")
+	errInvalidName = errors.New("invalid record name")
+)
+
+type fakeSolver struct {
+	name       string
+	presentErr error
+	cleanUpErr error
+	calls      *[]string
+}
+
+func (f *fakeSolver) Present(domain, fqdn, value string) error {
+	*f.calls = append(*f.calls, "present:"+f.name)
+	return f.presentErr
+}
+
+func (f *fakeSolver) CleanUp(domain, fqdn, value string) error {
+	*f.calls = append(*f.calls, "cleanup:"+f.name)
+	return f.cleanUpErr
+}
+
+func isRateLimited(err error) bool {
+	return errors.Is(err, errRateLimited)
+}
+
+func TestFailoverSolver(t *testing.T) {
+	tests := map[string]struct {
+		primary, secondary fakeSolver
+
+		expPresentErr bool
+		expCleanUpErr bool
+		expCalls      []string
+	}{
+		"uses the primary if it succeeds": {
+			expCalls: []string{"present:primary", "cleanup:primary"},
+		},
+		"fails over to the secondary if the primary is rate limited, and cleans up with the secondary": {
+			primary:  fakeSolver{presentErr: errRateLimited, cleanUpErr: errRateLimited},
+			expCalls: []string{"present:primary", "present:secondary", "cleanup:secondary"},
+		},
+		"falls back to the primary during clean up if the secondary is rate limited": {
+			primary:   fakeSolver{presentErr: errRateLimited},
+			secondary: fakeSolver{cleanUpErr: errRateLimited},
+			expCalls:  []string{"present:primary", "present:secondary", "cleanup:secondary", "cleanup:primary"},
+		},
+		"does not fail over on other errors": {
+			primary:       fakeSolver{presentErr: errInvalidName},
+			expPresentErr: true,
+			expCalls:      []string{"present:primary"},
+		},
+		"returns an error if all credentials are rate limited": {
+			primary:       fakeSolver{presentErr: errRateLimited},
+			secondary:     fakeSolver{presentErr: errRateLimited},
+			expPresentErr: true,
+			expCalls:      []string{"present:primary", "present:secondary"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			test.primary.name, test.primary.calls = "primary", &calls
+			test.secondary.name, test.secondary.calls = "secondary", &calls
+
+			// A new failoverSolver is built for each call, as done by
+			// solverForChallenge, sharing only the record of which
+			// credentials were used.
+			used := &sync.Map{}
+			newSolver := func() *failoverSolver {
+				return &failoverSolver{
+					solvers:  []solver{&test.primary, &test.secondary},
+					failover: isRateLimited,
+					used:     used,
+				}
+			}
+
+			err := newSolver().Present("example.com", "_acme-challenge.example.com.", "value")
+			assert.Equal(t, test.expPresentErr, err != nil, "unexpected Present error: %v", err)
+			if err == nil {
+				err = newSolver().CleanUp("example.com", "_acme-challenge.example.com.", "value")
+				assert.Equal(t, test.expCleanUpErr, err != nil, "unexpected CleanUp error: %v", err)
+			}
+
+			assert.Equal(t, test.expCalls, calls)
+			if err == nil {
+				_, ok := used.Load(recordKey("_acme-challenge.example.com.", "value"))
+				assert.False(t, ok, "expected the used credentials to be forgotten after clean up")
+			}
+		})
+	}
+}
+
+type fakeValidatingSolver struct {
+	fakeSolver
+	validateErr error
+}
+
+func (f *fakeValidatingSolver) Validate(domains []string) error {
+	return f.validateErr
+}
+
+func TestFailoverSolverValidate(t *testing.T) {
+	var calls []string
+	valid := &fakeValidatingSolver{fakeSolver: fakeSolver{calls: &calls}}
+	rejected := &fakeValidatingSolver{fakeSolver: fakeSolver{calls: &calls}, validateErr: errRateLimited}
+
+	f := &failoverSolver{solvers: []solver{valid, valid}, failover: isRateLimited, used: &sync.Map{}}
+	assert.NoError(t, f.Validate([]string{"example.com"}))
+
+	// Every set of credentials is validated, not only the first that works.
+	f = &failoverSolver{solvers: []solver{valid, rejected}, failover: isRateLimited, used: &sync.Map{}}
+	err := f.Validate([]string{"example.com"})
+	assert.ErrorIs(t, err, errRateLimited)
+	assert.Contains(t, err.Error(), "credentials 1")
+
+	f = &failoverSolver{solvers: []solver{valid, &fakeSolver{calls: &calls}}, failover: isRateLimited, used: &sync.Map{}}
+	assert.Equal(t, ErrValidationNotSupported, f.Validate([]string{"example.com"}))
+}
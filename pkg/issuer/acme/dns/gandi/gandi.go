@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gandi implements a DNS provider for solving the DNS-01 challenge
+// using Gandi LiveDNS.
+package gandi
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// GandiLiveDNSAPIURL is the Gandi LiveDNS v5 API endpoint.
+const GandiLiveDNSAPIURL = "https://api.gandi.net/v5/livedns"
+
+// minTTL is the smallest TTL accepted by Gandi LiveDNS.
+const minTTL = 300
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	dns01Nameservers []string
+	token            string
+	userAgent        string
+
+	// baseURL is the Gandi LiveDNS API endpoint requests are made to.
+	baseURL string
+	client  *http.Client
+}
+
+// rrset is a resource record set as used by the Gandi LiveDNS API. All the
+// records of a given name and type are managed together as a single rrset.
+type rrset struct {
+	TTL    int      `json:"rrset_ttl,omitempty"`
+	Values []string `json:"rrset_values"`
+}
+
+// apiError is the body of an error returned by the Gandi API.
+type apiError struct {
+	Message string `json:"message"`
+}
+
+// errNotFound is returned when the Gandi API responds with 404 Not Found.
+var errNotFound = errors.New("not found")
+
+// NewDNSProvider returns a DNSProvider instance configured for Gandi
+// LiveDNS. The personal access token must be passed in the environment
+// variable GANDI_PAT.
+func NewDNSProvider(dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
+	token := os.Getenv("GANDI_PAT")
+	return NewDNSProviderCredentials(token, dns01Nameservers, userAgent, nil)
+}
+
+// NewDNSProviderCredentials uses the supplied personal access token to
+// return a DNSProvider instance configured for Gandi LiveDNS. If rootCAs is
+// not nil, it is used instead of the system roots to verify the Gandi API.
+func NewDNSProviderCredentials(token string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*DNSProvider, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("Gandi personal access token missing")
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if rootCAs != nil {
+		client.Transport = util.TransportWithRootCAs(rootCAs)
+	}
+
+	return &DNSProvider{
+		dns01Nameservers: dns01Nameservers,
+		token:            token,
+		userAgent:        userAgent,
+		baseURL:          GandiLiveDNSAPIURL,
+		client:           client,
+	}, nil
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge. As Gandi
+// replaces whole rrsets, the value is added to any existing TXT values for
+// the name.
+func (c *DNSProvider) Present(domain, fqdn, value string) error {
+	zone, name, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.getTXTRRSet(zone, name)
+	if err != nil {
+		return err
+	}
+
+	values := existing.Values
+	for _, v := range values {
+		if unquote(v) == value {
+			// the record is already set to the desired value
+			return nil
+		}
+	}
+	values = append(values, quote(value))
+
+	return c.putTXTRRSet(zone, name, values, existing.TTL)
+}
+
+// CleanUp removes the TXT record matching the specified parameters. Other
+// values in the same rrset are kept, and the rrset is only deleted once it
+// has no values left.
+func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
+	zone, name, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.getTXTRRSet(zone, name)
+	if err != nil {
+		return err
+	}
+
+	var values []string
+	for _, v := range existing.Values {
+		if unquote(v) != value {
+			values = append(values, v)
+		}
+	}
+	if len(values) == len(existing.Values) {
+		// Nothing to cleanup
+		return nil
+	}
+
+	if len(values) == 0 {
+		err := c.makeRequest(http.MethodDelete, txtRRSetPath(zone, name), nil, nil)
+		if err == errNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return c.putTXTRRSet(zone, name, values, existing.TTL)
+}
+
+// findDomain returns the Gandi LiveDNS domain that contains the fqdn, along
+// with the name of the fqdn relative to that domain. The most specific domain
+// is found by looking up each parent of the fqdn in turn.
+func (c *DNSProvider) findDomain(fqdn string) (string, string, error) {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+	labels := strings.Split(name, ".")
+
+	// don't look up the top level domain on its own
+	for i := 0; i < len(labels)-1; i++ {
+		candidate := strings.Join(labels[i:], ".")
+		err := c.makeRequest(http.MethodGet, "/domains/"+candidate, nil, nil)
+		if err == errNotFound {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+
+		if i == 0 {
+			return candidate, "@", nil
+		}
+		return candidate, strings.Join(labels[:i], "."), nil
+	}
+
+	return "", "", fmt.Errorf("found no Gandi LiveDNS domain for %s, please make sure the domain uses LiveDNS and the personal access token can manage it", fqdn)
+}
+
+// getTXTRRSet returns the TXT rrset with the given name. An empty rrset is
+// returned if it does not exist.
+func (c *DNSProvider) getTXTRRSet(zone, name string) (*rrset, error) {
+	var existing rrset
+	err := c.makeRequest(http.MethodGet, txtRRSetPath(zone, name), nil, &existing)
+	if err == errNotFound {
+		return &rrset{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// putTXTRRSet creates or replaces the TXT rrset with the given name.
+func (c *DNSProvider) putTXTRRSet(zone, name string, values []string, ttl int) error {
+	if ttl < minTTL {
+		ttl = minTTL
+	}
+	return c.makeRequest(http.MethodPut, txtRRSetPath(zone, name), &rrset{
+		TTL:    ttl,
+		Values: values,
+	}, nil)
+}
+
+func txtRRSetPath(zone, name string) string {
+	return fmt.Sprintf("/domains/%s/records/%s/TXT", zone, name)
+}
+
+// quote returns the value as a quoted TXT record value, as returned by
+// Gandi.
+func quote(value string) string {
+	return `"` + value + `"`
+}
+
+func unquote(value string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+}
+
+func (c *DNSProvider) makeRequest(method, uri string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+uri, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while querying the Gandi LiveDNS API for %s %q: %v", method, uri, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Message != "" {
+			return fmt.Errorf("while querying the Gandi LiveDNS API for %s %q: %s: %s", method, uri, resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("while querying the Gandi LiveDNS API for %s %q: %s", method, uri, resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gandi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+const testToken = "pat"
+
+// fakeGandiAPI is an in-memory implementation of the parts of the Gandi
+// LiveDNS API used by the provider.
+type fakeGandiAPI struct {
+	lock sync.Mutex
+	// rrsets holds the TXT rrsets of each domain, keyed by domain and then
+	// by rrset name.
+	rrsets map[string]map[string]rrset
+	// requests records the method and path of each request that modified
+	// an rrset.
+	requests []string
+}
+
+func newFakeGandiAPI(t *testing.T, domains ...string) (*fakeGandiAPI, *DNSProvider) {
+	api := &fakeGandiAPI{rrsets: make(map[string]map[string]rrset)}
+	for _, domain := range domains {
+		api.rrsets[domain] = make(map[string]rrset)
+	}
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials(testToken, util.RecursiveNameservers, "cert-manager-test", nil)
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	return api, provider
+}
+
+func (f *fakeGandiAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code":403,"message":"Access was denied to this resource.","object":"HTTPForbidden","cause":"Forbidden"}`))
+		return
+	}
+
+	// /domains/{domain} or /domains/{domain}/records/{name}/TXT
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "domains" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	domain, ok := f.rrsets[parts[1]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"message":"The resource could not be found.","object":"HTTPNotFound","cause":"Not Found"}`))
+		return
+	}
+
+	if len(parts) == 2 && r.Method == http.MethodGet {
+		_ = json.NewEncoder(w).Encode(map[string]string{"fqdn": parts[1]})
+		return
+	}
+	if len(parts) != 5 || parts[2] != "records" || parts[4] != "TXT" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	name := parts[3]
+	switch r.Method {
+	case http.MethodGet:
+		set, ok := domain[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"rrset_name":   name,
+			"rrset_type":   "TXT",
+			"rrset_ttl":    set.TTL,
+			"rrset_values": set.Values,
+		})
+	case http.MethodPut:
+		var set rrset
+		if err := json.NewDecoder(r.Body).Decode(&set); err != nil || set.TTL < minTTL || len(set.Values) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		domain[name] = set
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"message":"DNS Record Created"}`))
+	case http.MethodDelete:
+		if _, ok := domain[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(domain, name)
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeGandiAPI) rrset(domain, name string) (rrset, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	set, ok := f.rrsets[domain][name]
+	return set, ok
+}
+
+func TestNewDNSProviderCredentials(t *testing.T) {
+	_, err := NewDNSProviderCredentials("", util.RecursiveNameservers, "cert-manager-test", nil)
+	assert.Error(t, err)
+
+	provider, err := NewDNSProviderCredentials(" pat\n", util.RecursiveNameservers, "cert-manager-test", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "pat", provider.token)
+}
+
+func TestFindDomain(t *testing.T) {
+	tests := map[string]struct {
+		domains []string
+		fqdn    string
+		expZone string
+		expName string
+		expErr  bool
+	}{
+		"finds the domain of a sub-domain": {
+			domains: []string{"example.com"},
+			fqdn:    "_acme-challenge.www.example.com.",
+			expZone: "example.com",
+			expName: "_acme-challenge.www",
+		},
+		"finds the domain of its apex": {
+			domains: []string{"example.com"},
+			fqdn:    "example.com.",
+			expZone: "example.com",
+			expName: "@",
+		},
+		"prefers the most specific domain": {
+			domains: []string{"example.com", "sub.example.com"},
+			fqdn:    "_acme-challenge.sub.example.com.",
+			expZone: "sub.example.com",
+			expName: "_acme-challenge",
+		},
+		"returns an error if there is no domain": {
+			domains: []string{"example.org"},
+			fqdn:    "_acme-challenge.example.com.",
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, provider := newFakeGandiAPI(t, test.domains...)
+
+			zone, recordName, err := provider.findDomain(test.fqdn)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expZone, zone)
+			assert.Equal(t, test.expName, recordName)
+		})
+	}
+}
+
+func TestGandiRRSetLifecycle(t *testing.T) {
+	api, provider := newFakeGandiAPI(t, "example.com")
+	fqdn := "_acme-challenge.example.com."
+	path := "/domains/example.com/records/_acme-challenge/TXT"
+
+	// Create the rrset.
+	require.NoError(t, provider.Present("example.com", fqdn, "value-1"))
+	set, ok := api.rrset("example.com", "_acme-challenge")
+	require.True(t, ok)
+	assert.Equal(t, rrset{TTL: minTTL, Values: []string{`"value-1"`}}, set)
+
+	// Replace the rrset, keeping the existing value, e.g. when solving
+	// challenges for example.com and *.example.com at the same time.
+	require.NoError(t, provider.Present("*.example.com", fqdn, "value-2"))
+	set, _ = api.rrset("example.com", "_acme-challenge")
+	assert.Equal(t, rrset{TTL: minTTL, Values: []string{`"value-1"`, `"value-2"`}}, set)
+
+	// Presenting an existing value does not modify the rrset.
+	require.NoError(t, provider.Present("example.com", fqdn, "value-1"))
+
+	// Cleaning up one value replaces the rrset with the remaining values.
+	require.NoError(t, provider.CleanUp("example.com", fqdn, "value-1"))
+	set, _ = api.rrset("example.com", "_acme-challenge")
+	assert.Equal(t, rrset{TTL: minTTL, Values: []string{`"value-2"`}}, set)
+
+	// Cleaning up a value that does not exist does not modify the rrset.
+	require.NoError(t, provider.CleanUp("example.com", fqdn, "value-1"))
+
+	// Cleaning up the last value deletes the rrset.
+	require.NoError(t, provider.CleanUp("*.example.com", fqdn, "value-2"))
+	_, ok = api.rrset("example.com", "_acme-challenge")
+	assert.False(t, ok)
+
+	// Cleaning up a rrset that does not exist is not an error.
+	require.NoError(t, provider.CleanUp("example.com", fqdn, "value-2"))
+
+	assert.Equal(t, []string{
+		"PUT " + path,
+		"PUT " + path,
+		"PUT " + path,
+		"DELETE " + path,
+	}, api.requests)
+}
+
+func TestGandiPreservesExistingTTL(t *testing.T) {
+	api, provider := newFakeGandiAPI(t, "example.com")
+	api.rrsets["example.com"]["_acme-challenge"] = rrset{TTL: 3600, Values: []string{`"other"`}}
+
+	require.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "value"))
+	set, _ := api.rrset("example.com", "_acme-challenge")
+	assert.Equal(t, rrset{TTL: 3600, Values: []string{`"other"`, `"value"`}}, set)
+}
+
+func TestGandiAPIError(t *testing.T) {
+	_, provider := newFakeGandiAPI(t, "example.com")
+	provider.token = "wrong"
+
+	err := provider.Present("example.com", "_acme-challenge.example.com.", "value")
+	require.Error(t, err)
+	assert.Equal(t, `while querying the Gandi LiveDNS API for GET "/domains/_acme-challenge.example.com": 403 Forbidden: Access was denied to this resource.`, err.Error())
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// keyFileAlgorithms maps the TSIG algorithm names used in BIND keyfiles to
+// the algorithm names accepted by NewDNSProviderCredentials.
+var keyFileAlgorithms = map[string]string{
+	"hmac-md5":                 "HMACMD5",
+	"hmac-md5.sig-alg.reg.int": "HMACMD5",
+	"hmac-sha1":                "HMACSHA1",
+	"hmac-sha256":              "HMACSHA256",
+	"hmac-sha512":              "HMACSHA512",
+}
+
+// TSIGKey is a TSIG key parsed from a BIND keyfile.
+type TSIGKey struct {
+	// Name is the name of the key.
+	Name string
+
+	// Algorithm is the TSIG algorithm of the key, in the form accepted by
+	// NewDNSProviderCredentials, e.g. HMACSHA256.
+	Algorithm string
+
+	// Secret is the base64 encoded secret of the key.
+	Secret string
+}
+
+// ParseTSIGKeyFile parses a BIND style (named.conf) keyfile, such as one
+// generated by `tsig-keygen`, containing a single key statement:
+//
+//	key "example-key" {
+//		algorithm hmac-sha256;
+//		secret "c2VjcmV0";
+//	};
+//
+// The key must specify both an algorithm and a secret.
+func ParseTSIGKeyFile(data []byte) (*TSIGKey, error) {
+	tokens, err := tokenizeKeyFile(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &keyFileParser{tokens: tokens}
+	key, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, errors.New("keyfile must contain exactly one key statement")
+	}
+
+	return key, nil
+}
+
+type keyFileParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *keyFileParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *keyFileParser) next() (string, error) {
+	if p.done() {
+		return "", errors.New("unexpected end of keyfile")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *keyFileParser) expect(want string) error {
+	t, err := p.next()
+	if err != nil {
+		return fmt.Errorf("expected %q: %w", want, err)
+	}
+	if t != want {
+		return fmt.Errorf("expected %q but got %q", want, t)
+	}
+	return nil
+}
+
+// value returns the next token, which must not be one of the structural
+// tokens of a keyfile.
+func (p *keyFileParser) value(field string) (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", fmt.Errorf("expected %s: %w", field, err)
+	}
+	switch t {
+	case "{", "}", ";":
+		return "", fmt.Errorf("expected %s but got %q", field, t)
+	}
+	return t, nil
+}
+
+func (p *keyFileParser) parseKey() (*TSIGKey, error) {
+	if err := p.expect("key"); err != nil {
+		return nil, err
+	}
+	name, err := p.value("key name")
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, errors.New("key name must not be empty")
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	key := &TSIGKey{Name: name}
+	var algorithm string
+	for {
+		t, err := p.next()
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not terminated: %w", name, err)
+		}
+		if t == "}" {
+			break
+		}
+
+		switch t {
+		case "algorithm":
+			if algorithm != "" {
+				return nil, fmt.Errorf("key %q specifies algorithm more than once", name)
+			}
+			if algorithm, err = p.value("algorithm"); err != nil {
+				return nil, err
+			}
+		case "secret":
+			if key.Secret != "" {
+				return nil, fmt.Errorf("key %q specifies secret more than once", name)
+			}
+			if key.Secret, err = p.value("secret"); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q in key %q", t, name)
+		}
+		if err := p.expect(";"); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+
+	if algorithm == "" {
+		return nil, fmt.Errorf("key %q does not specify an algorithm", name)
+	}
+	alg, ok := keyFileAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return nil, fmt.Errorf("key %q uses unsupported algorithm %q", name, algorithm)
+	}
+	key.Algorithm = alg
+
+	if key.Secret == "" {
+		return nil, fmt.Errorf("key %q does not specify a secret", name)
+	}
+	if _, err := base64.StdEncoding.DecodeString(key.Secret); err != nil {
+		return nil, fmt.Errorf("key %q has a secret which is not valid base64: %v", name, err)
+	}
+
+	return key, nil
+}
+
+// tokenizeKeyFile splits a keyfile into words, quoted strings and the
+// structural tokens '{', '}' and ';', dropping comments.
+func tokenizeKeyFile(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '#' || strings.HasPrefix(s[i:], "//"):
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.New("unterminated comment in keyfile")
+			}
+			i += end + 4
+		case c == '{' || c == '}' || c == ';':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, errors.New("unterminated quoted string in keyfile")
+			}
+			tokens = append(tokens, s[i+1:i+1+end])
+			i += end + 2
+		default:
+			start := i
+			for i < len(s) && !strings.ContainsRune(" \t\r\n{};\"#", rune(s[i])) {
+				i++
+			}
+			tokens = append(tokens, s[start:i])
+		}
+	}
+	return tokens, nil
+}
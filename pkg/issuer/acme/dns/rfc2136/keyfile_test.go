@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientcorev1 "k8s.io/client-go/listers/core/v1"
+
+	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+// sampleKeyFile is a keyfile in the format generated by `tsig-keygen`.
+const sampleKeyFile = `# generated by tsig-keygen
+key "cert-manager-key" {
+	algorithm hmac-sha256;
+	secret "QmFzZTY0IGVuY29kZWQgc2VjcmV0IGtleQ==";
+};
+`
+
+func TestParseTSIGKeyFile(t *testing.T) {
+	tests := map[string]struct {
+		keyFile string
+		expKey  *TSIGKey
+		expErr  string
+	}{
+		"sample keyfile": {
+			keyFile: sampleKeyFile,
+			expKey:  &TSIGKey{Name: "cert-manager-key", Algorithm: "HMACSHA256", Secret: "QmFzZTY0IGVuY29kZWQgc2VjcmV0IGtleQ=="},
+		},
+		"single line with unquoted name, comments and legacy md5 algorithm name": {
+			keyFile: `/* legacy key */ key legacy. { secret "c2VjcmV0"; // the secret
+algorithm HMAC-MD5.SIG-ALG.REG.INT; };`,
+			expKey: &TSIGKey{Name: "legacy.", Algorithm: "HMACMD5", Secret: "c2VjcmV0"},
+		},
+		"empty keyfile": {
+			keyFile: "",
+			expErr:  `expected "key": unexpected end of keyfile`,
+		},
+		"missing terminating semicolon": {
+			keyFile: `key "k" { algorithm hmac-sha1; secret "c2VjcmV0"; }`,
+			expErr:  `expected ";": unexpected end of keyfile`,
+		},
+		"unterminated key": {
+			keyFile: `key "k" { algorithm hmac-sha1;`,
+			expErr:  `key "k" is not terminated: unexpected end of keyfile`,
+		},
+		"unterminated quoted string": {
+			keyFile: `key "k { algorithm hmac-sha1; };`,
+			expErr:  "unterminated quoted string in keyfile",
+		},
+		"missing algorithm": {
+			keyFile: `key "k" { secret "c2VjcmV0"; };`,
+			expErr:  `key "k" does not specify an algorithm`,
+		},
+		"missing secret": {
+			keyFile: `key "k" { algorithm hmac-sha512; };`,
+			expErr:  `key "k" does not specify a secret`,
+		},
+		"unsupported algorithm": {
+			keyFile: `key "k" { algorithm hmac-sha224; secret "c2VjcmV0"; };`,
+			expErr:  `key "k" uses unsupported algorithm "hmac-sha224"`,
+		},
+		"secret is not base64": {
+			keyFile: `key "k" { algorithm hmac-sha256; secret "not base64!"; };`,
+			expErr:  `key "k" has a secret which is not valid base64: illegal base64 data at input byte 3`,
+		},
+		"duplicate secret": {
+			keyFile: `key "k" { algorithm hmac-sha256; secret "c2VjcmV0"; secret "c2VjcmV0"; };`,
+			expErr:  `key "k" specifies secret more than once`,
+		},
+		"unknown statement": {
+			keyFile: `key "k" { algorithm hmac-sha256; secret "c2VjcmV0"; owner "me"; };`,
+			expErr:  `unexpected "owner" in key "k"`,
+		},
+		"empty key name": {
+			keyFile: `key "" { algorithm hmac-sha256; secret "c2VjcmV0"; };`,
+			expErr:  "key name must not be empty",
+		},
+		"multiple keys": {
+			keyFile: sampleKeyFile + sampleKeyFile,
+			expErr:  "keyfile must contain exactly one key statement",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, err := ParseTSIGKeyFile([]byte(test.keyFile))
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expKey, key)
+		})
+	}
+}
+
+func TestBuildDNSProviderWithTSIGKeyFile(t *testing.T) {
+	secrets := map[string]*corev1.Secret{
+		"keyfile": {Data: map[string][]byte{"tsig.key": []byte(sampleKeyFile)}},
+		"broken":  {Data: map[string][]byte{"tsig.key": []byte(`key "k" { algorithm hmac-sha256; };`)}},
+	}
+	s := New()
+	s.secretLister = testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+		testlisters.SetFakeSecretListerSecret(func(string) clientcorev1.SecretNamespaceLister {
+			return testlisters.FakeSecretNamespaceListerFrom(testlisters.NewFakeSecretNamespaceLister(),
+				func(l *testlisters.FakeSecretNamespaceLister) {
+					l.GetFn = func(name string) (*corev1.Secret, error) {
+						if secret, ok := secrets[name]; ok {
+							return secret, nil
+						}
+						return nil, errors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+					}
+				},
+			)
+		}),
+	)
+
+	challengeRequest := func(t *testing.T, secretName string) *whapi.ChallengeRequest {
+		cfg, err := json.Marshal(cmacme.ACMEIssuerDNS01ProviderRFC2136{
+			Nameserver: "127.0.0.1:53",
+			TSIGKeyFile: &cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: secretName},
+				Key:                  "tsig.key",
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &whapi.ChallengeRequest{ResourceNamespace: "default", Config: &apiextensionsv1.JSON{Raw: cfg}}
+	}
+
+	t.Run("configures the provider from the keyfile", func(t *testing.T) {
+		p, err := s.buildDNSProvider(challengeRequest(t, "keyfile"))
+		assert.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:53", p.Nameserver())
+		assert.Equal(t, "hmac-sha256.", p.TSIGAlgorithm())
+		assert.Equal(t, "cert-manager-key", p.tsigKeyName)
+		assert.Equal(t, "QmFzZTY0IGVuY29kZWQgc2VjcmV0IGtleQ==", p.tsigSecret)
+	})
+
+	t.Run("rejects a malformed keyfile", func(t *testing.T) {
+		_, err := s.buildDNSProvider(challengeRequest(t, "broken"))
+		assert.EqualError(t, err, `error parsing TSIG keyfile from secret "broken": key "k" does not specify a secret`)
+	})
+
+	t.Run("returns an error if the keyfile Secret does not exist", func(t *testing.T) {
+		_, err := s.buildDNSProvider(challengeRequest(t, "missing"))
+		assert.EqualError(t, err, `secrets "missing" not found`)
+	})
+}
@@ -19,6 +19,7 @@ package rfc2136
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -40,6 +41,12 @@ type Solver struct {
 	// scope of the lister/watcher to a single namespace, to allow for
 	// namespace restricted instances of cert-manager.
 	namespace string
+
+	// presentedLock guards presentedOn.
+	presentedLock sync.Mutex
+	// presentedOn records the nameserver that accepted the update for each
+	// presented record, so that CleanUp removes it from the same nameserver.
+	presentedOn map[string]string
 }
 
 type Option func(*Solver)
@@ -68,11 +75,18 @@ func (s *Solver) Present(ch *whapi.ChallengeRequest) error {
 		return err
 	}
 
-	err = p.Present(ch.DNSName, ch.ResolvedFQDN, ch.ResolvedZone, ch.Key)
+	nameserver, err := p.present(ch.ResolvedFQDN, ch.ResolvedZone, ch.Key)
 	if err != nil {
 		return err
 	}
 
+	s.presentedLock.Lock()
+	defer s.presentedLock.Unlock()
+	if s.presentedOn == nil {
+		s.presentedOn = make(map[string]string)
+	}
+	s.presentedOn[recordKey(ch)] = nameserver
+
 	return nil
 }
 
@@ -82,14 +96,27 @@ func (s *Solver) CleanUp(ch *whapi.ChallengeRequest) error {
 		return err
 	}
 
-	err = p.CleanUp(ch.DNSName, ch.ResolvedFQDN, ch.ResolvedZone, ch.Key)
+	s.presentedLock.Lock()
+	nameserver := s.presentedOn[recordKey(ch)]
+	s.presentedLock.Unlock()
+
+	err = p.cleanUp(ch.ResolvedFQDN, ch.ResolvedZone, ch.Key, nameserver)
 	if err != nil {
 		return err
 	}
 
+	s.presentedLock.Lock()
+	defer s.presentedLock.Unlock()
+	delete(s.presentedOn, recordKey(ch))
+
 	return nil
 }
 
+// recordKey identifies the record presented for a challenge request.
+func recordKey(ch *whapi.ChallengeRequest) string {
+	return ch.ResourceNamespace + "/" + ch.ResolvedFQDN + "/" + ch.Key
+}
+
 func (s *Solver) Initialize(kubeClientConfig *restclient.Config, stopCh <-chan struct{}) error {
 	cl, err := kubernetes.NewForConfig(kubeClientConfig)
 	if err != nil {
@@ -148,6 +175,18 @@ func (s *Solver) buildDNSProvider(ch *whapi.ChallengeRequest) (*DNSProvider, err
 	}
 
 	l := s.secretLister.Secrets(ch.ResourceNamespace)
+	if cfg.TSIGKeyFile != nil {
+		keyFile, err := loadSecretKeySelector(l, *cfg.TSIGKeyFile, "")
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseTSIGKeyFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing TSIG keyfile from secret %q: %v", cfg.TSIGKeyFile.Name, err)
+		}
+		return NewDNSProviderCredentials(cfg.Nameserver, key.Algorithm, key.Name, key.Secret, cfg.AdditionalNameservers...)
+	}
+
 	secret, err := loadSecretKeySelector(l, cfg.TSIGSecret, "")
 	if err != nil {
 		return nil, err
@@ -157,5 +196,5 @@ func (s *Solver) buildDNSProvider(ch *whapi.ChallengeRequest) (*DNSProvider, err
 		key = string(secret)
 	}
 
-	return NewDNSProviderCredentials(cfg.Nameserver, cfg.TSIGAlgorithm, cfg.TSIGKeyName, key)
+	return NewDNSProviderCredentials(cfg.Nameserver, cfg.TSIGAlgorithm, cfg.TSIGKeyName, key, cfg.AdditionalNameservers...)
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/miekg/dns"
 
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -43,7 +44,9 @@ var supportedAlgorithms = map[string]string{
 // DNSProvider is an implementation of the acme.ChallengeProvider interface that
 // uses dynamic DNS updates (RFC 2136) to create TXT records on a nameserver.
 type DNSProvider struct {
-	nameserver    string
+	// nameservers are tried in order when sending updates, starting with the
+	// primary nameserver.
+	nameservers   []string
 	tsigAlgorithm string
 	tsigKeyName   string
 	tsigSecret    string
@@ -53,15 +56,19 @@ type DNSProvider struct {
 // DNSProvider instance configured for rfc2136 dynamic update. To disable TSIG
 // authentication, leave the TSIG parameters as empty strings.
 // nameserver must be a network address in the form "IP" or "IP:port".
-func NewDNSProviderCredentials(nameserver, tsigAlgorithm, tsigKeyName, tsigSecret string) (*DNSProvider, error) {
+// If any additionalNameservers are given, updates that are not accepted by
+// nameserver are sent to each of them in turn.
+func NewDNSProviderCredentials(nameserver, tsigAlgorithm, tsigKeyName, tsigSecret string, additionalNameservers ...string) (*DNSProvider, error) {
 	logf.Log.V(logf.DebugLevel).Info("Creating RFC2136 Provider")
 
 	d := &DNSProvider{}
 
-	if validNameserver, err := util.ValidNameserver(nameserver); err != nil {
-		return nil, err
-	} else {
-		d.nameserver = validNameserver
+	for _, ns := range append([]string{nameserver}, additionalNameservers...) {
+		validNameserver, err := util.ValidNameserver(ns)
+		if err != nil {
+			return nil, err
+		}
+		d.nameservers = append(d.nameservers, validNameserver)
 	}
 
 	if len(tsigKeyName) > 0 && len(tsigSecret) > 0 {
@@ -81,7 +88,7 @@ func NewDNSProviderCredentials(nameserver, tsigAlgorithm, tsigKeyName, tsigSecre
 	}
 	d.tsigAlgorithm = tsigAlgorithm
 
-	logf.V(logf.DebugLevel).Infof("DNSProvider nameserver:       %s\n", d.nameserver)
+	logf.V(logf.DebugLevel).Infof("DNSProvider nameservers:      %s\n", strings.Join(d.nameservers, ", "))
 	logf.V(logf.DebugLevel).Infof("            tsigAlgorithm:    %s\n", d.tsigAlgorithm)
 	logf.V(logf.DebugLevel).Infof("            tsigKeyName:      %s\n", d.tsigKeyName)
 	keyLen := len(d.tsigSecret)
@@ -97,19 +104,62 @@ func NewDNSProviderCredentials(nameserver, tsigAlgorithm, tsigKeyName, tsigSecre
 
 // Present creates a TXT record using the specified parameters
 func (r *DNSProvider) Present(_, fqdn, zone, value string) error {
-	return r.changeRecord("INSERT", fqdn, zone, value, 60)
+	_, err := r.present(fqdn, zone, value)
+	return err
 }
 
 // CleanUp removes the TXT record matching the specified parameters
 func (r *DNSProvider) CleanUp(_, fqdn, zone, value string) error {
-	return r.changeRecord("REMOVE", fqdn, zone, value, 60)
+	return r.cleanUp(fqdn, zone, value, "")
 }
 
-func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) error {
+// present creates a TXT record and returns the nameserver that accepted the
+// update.
+func (r *DNSProvider) present(fqdn, zone, value string) (string, error) {
+	return r.changeRecordOnAny("INSERT", fqdn, zone, value, 60, "")
+}
+
+// cleanUp removes a TXT record, sending the update to the preferred
+// nameserver first if it is one of the configured nameservers.
+func (r *DNSProvider) cleanUp(fqdn, zone, value, preferred string) error {
+	_, err := r.changeRecordOnAny("REMOVE", fqdn, zone, value, 60, preferred)
+	return err
+}
+
+// changeRecordOnAny sends the update to each of the configured nameservers in
+// turn, starting with the preferred nameserver if set, until one of them
+// accepts it. It returns the nameserver that accepted the update.
+func (r *DNSProvider) changeRecordOnAny(action, fqdn, zone, value string, ttl int, preferred string) (string, error) {
+	nameservers := r.nameservers
+	for i, ns := range r.nameservers {
+		if ns == preferred {
+			nameservers = append([]string{ns}, r.nameservers[:i]...)
+			nameservers = append(nameservers, r.nameservers[i+1:]...)
+			break
+		}
+	}
+
+	var errs []string
+	for _, ns := range nameservers {
+		err := r.changeRecord(ns, action, fqdn, zone, value, ttl)
+		if err == nil {
+			return ns, nil
+		}
+		if len(nameservers) == 1 {
+			return "", err
+		}
+		logf.V(logf.DebugLevel).Infof("rfc2136: update of %s on nameserver %s failed, trying next nameserver: %v", fqdn, ns, err)
+		errs = append(errs, fmt.Sprintf("%s: %v", ns, err))
+	}
+
+	return "", fmt.Errorf("DNS update failed on all nameservers: %s", strings.Join(errs, "; "))
+}
+
+func (r *DNSProvider) changeRecord(nameserver, action, fqdn, zone, value string, ttl int) error {
 	// Create RR
 	rr := new(dns.TXT)
 	rr.Hdr = dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: uint32(ttl)}
-	rr.Txt = []string{value}
+	rr.Txt = dnsutil.SplitTXTValue(value)
 	rrs := []dns.RR{rr}
 
 	// Create dynamic update packet
@@ -135,7 +185,7 @@ func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) er
 	}
 
 	// Send the query
-	reply, _, err := c.Exchange(m, r.nameserver)
+	reply, _, err := c.Exchange(m, nameserver)
 	if err != nil {
 		return fmt.Errorf("DNS update failed: %v", err)
 	}
@@ -148,7 +198,13 @@ func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) er
 
 // Nameserver returns the nameserver configured for this provider when it was created
 func (r *DNSProvider) Nameserver() string {
-	return r.nameserver
+	return r.nameservers[0]
+}
+
+// Nameservers returns all nameservers configured for this provider when it was
+// created, in the order in which they are tried.
+func (r *DNSProvider) Nameservers() []string {
+	return r.nameservers
 }
 
 // TSIGAlgorithm returns the TSIG algorithm configured for this provider when it was created
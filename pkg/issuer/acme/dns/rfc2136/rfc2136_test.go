@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	testserver "github.com/cert-manager/cert-manager/test/acme/dns/server"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+const (
+	testFQDN  = "_acme-challenge.www.example.com."
+	testZone  = "example.com."
+	testValue = "123d=="
+)
+
+// updateHandler is a DNS handler that records the dynamic updates it
+// receives, and refuses those for which refuse returns true.
+type updateHandler struct {
+	refuse func(action string) bool
+
+	lock    sync.Mutex
+	updates []string
+}
+
+func (h *updateHandler) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(req)
+
+	action := "INSERT"
+	if len(req.Ns) > 0 && req.Ns[0].Header().Class == dns.ClassNONE {
+		action = "REMOVE"
+	}
+	if h.refuse != nil && h.refuse(action) {
+		m.Rcode = dns.RcodeRefused
+	} else {
+		h.lock.Lock()
+		h.updates = append(h.updates, action)
+		h.lock.Unlock()
+	}
+
+	w.WriteMsg(m)
+}
+
+func (h *updateHandler) received() []string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return append([]string(nil), h.updates...)
+}
+
+func runServer(t *testing.T, h *updateHandler) string {
+	ctx := logf.NewContext(context.TODO(), logtesting.NewTestLogger(t), t.Name())
+	server := &testserver.BasicServer{Zones: []string{testZone}, Handler: h}
+	require.NoError(t, server.Run(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, server.Shutdown())
+	})
+	return server.ListenAddr()
+}
+
+func refuseAll(string) bool { return true }
+
+func TestPresentTriesAdditionalNameservers(t *testing.T) {
+	failing := &updateHandler{refuse: refuseAll}
+	succeeding := &updateHandler{}
+	failingAddr := runServer(t, failing)
+	succeedingAddr := runServer(t, succeeding)
+
+	p, err := NewDNSProviderCredentials(failingAddr, "", "", "", succeedingAddr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{failingAddr, succeedingAddr}, p.Nameservers())
+
+	nameserver, err := p.present(testFQDN, testZone, testValue)
+	assert.NoError(t, err)
+	assert.Equal(t, succeedingAddr, nameserver)
+	assert.Empty(t, failing.received())
+	assert.Equal(t, []string{"INSERT"}, succeeding.received())
+}
+
+func TestPresentFailsIfNoNameserverAcceptsUpdate(t *testing.T) {
+	first := runServer(t, &updateHandler{refuse: refuseAll})
+	second := runServer(t, &updateHandler{refuse: refuseAll})
+
+	p, err := NewDNSProviderCredentials(first, "", "", "", second)
+	require.NoError(t, err)
+
+	err = p.Present("www.example.com", testFQDN, testZone, testValue)
+	assert.EqualError(t, err, "DNS update failed on all nameservers: "+
+		first+": DNS update failed. Server replied: REFUSED; "+
+		second+": DNS update failed. Server replied: REFUSED")
+}
+
+func TestSolverCleansUpOnNameserverThatAcceptedPresent(t *testing.T) {
+	// The first nameserver refuses inserts but would accept removals, so the
+	// record must only be removed from the nameserver it was presented on.
+	first := &updateHandler{refuse: func(action string) bool { return action == "INSERT" }}
+	second := &updateHandler{}
+	firstAddr := runServer(t, first)
+	secondAddr := runServer(t, second)
+
+	cfg, err := json.Marshal(cmacme.ACMEIssuerDNS01ProviderRFC2136{
+		Nameserver:            firstAddr,
+		AdditionalNameservers: []string{secondAddr},
+	})
+	require.NoError(t, err)
+	ch := &whapi.ChallengeRequest{
+		ResourceNamespace: "default",
+		ResolvedFQDN:      testFQDN,
+		ResolvedZone:      testZone,
+		Key:               testValue,
+		Config:            &apiextensionsv1.JSON{Raw: cfg},
+	}
+
+	s := New()
+	s.secretLister = testlisters.NewFakeSecretLister()
+
+	require.NoError(t, s.Present(ch))
+	require.NoError(t, s.CleanUp(ch))
+
+	assert.Empty(t, first.received())
+	assert.Equal(t, []string{"INSERT", "REMOVE"}, second.received())
+	assert.Empty(t, s.presentedOn)
+}
@@ -75,3 +75,47 @@ var ChangeResourceRecordSets403Response = `<?xml version="1.0"?>
   </Error>
   <RequestId>SOMEREQUESTID</RequestId>
 </ErrorResponse>`
+
+var GetHostedZoneResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<GetHostedZoneResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <HostedZone>
+      <Id>/hostedzone/ABCDEFG</Id>
+      <Name>example.com.</Name>
+      <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+      <Config>
+         <Comment>Test comment</Comment>
+         <PrivateZone>false</PrivateZone>
+      </Config>
+      <ResourceRecordSetCount>10</ResourceRecordSetCount>
+   </HostedZone>
+</GetHostedZoneResponse>`
+
+var ListHostedZonesResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<ListHostedZonesResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <HostedZones>
+      <HostedZone>
+         <Id>/hostedzone/ABCDEFG</Id>
+         <Name>example.com.</Name>
+         <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+         <Config>
+            <Comment>Test comment</Comment>
+            <PrivateZone>false</PrivateZone>
+         </Config>
+         <ResourceRecordSetCount>10</ResourceRecordSetCount>
+      </HostedZone>
+   </HostedZones>
+   <IsTruncated>true</IsTruncated>
+   <Marker></Marker>
+   <NextMarker>HIJKLMN</NextMarker>
+   <MaxItems>1</MaxItems>
+</ListHostedZonesResponse>`
+
+var GetHostedZone403Response = `<?xml version="1.0"?>
+<ErrorResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>AccessDenied</Code>
+    <Message>User: arn:aws:iam::0123456789:user/test-cert-manager is not authorized to perform: route53:GetHostedZone on resource: arn:aws:route53:::hostedzone/ABCDEFG</Message>
+  </Error>
+  <RequestId>SOMEREQUESTID</RequestId>
+</ErrorResponse>`
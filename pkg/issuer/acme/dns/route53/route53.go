@@ -11,9 +11,12 @@ this directory.
 package route53
 
 import (
+	"crypto/x509"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 
@@ -28,10 +31,23 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 )
 
 const (
 	route53TTL = 10
+
+	// DefaultChangeComment is the comment set on change batches when no
+	// comment has been configured.
+	DefaultChangeComment = "Managed by cert-manager"
+
+	// maxChangeCommentLength is the maximum length of a change batch
+	// comment accepted by the Route 53 API.
+	maxChangeCommentLength = 256
+
+	// DefaultRoleSessionName is the name of the session created when assuming
+	// a role if no name has been configured.
+	DefaultRoleSessionName = "cert-manager"
 )
 
 // DNSProvider implements the util.ChallengeProvider interface
@@ -39,6 +55,7 @@ type DNSProvider struct {
 	dns01Nameservers []string
 	client           *route53.Route53
 	hostedZoneID     string
+	comment          string
 	log              logr.Logger
 
 	userAgent string
@@ -50,7 +67,10 @@ type sessionProvider struct {
 	Ambient         bool
 	Region          string
 	Role            string
+	RoleSessionName string
+	SessionTags     map[string]string
 	StsProvider     func(*session.Session) stsiface.STSAPI
+	RootCAs         *x509.CertPool
 	log             logr.Logger
 	userAgent       string
 }
@@ -68,6 +88,9 @@ func (d *sessionProvider) GetSession() (*session.Session, error) {
 	useAmbientCredentials := d.Ambient && (d.AccessKeyID == "" && d.SecretAccessKey == "")
 
 	config := aws.NewConfig()
+	if httpClient := util.HTTPClientWithRootCAs(d.RootCAs); httpClient != nil {
+		config = config.WithHTTPClient(httpClient)
+	}
 	sessionOpts := session.Options{
 		Config: *config,
 	}
@@ -92,10 +115,7 @@ func (d *sessionProvider) GetSession() (*session.Session, error) {
 	if d.Role != "" {
 		d.log.V(logf.DebugLevel).WithValues("role", d.Role).Info("assuming role")
 		stsSvc := d.StsProvider(sess)
-		result, err := stsSvc.AssumeRole(&sts.AssumeRoleInput{
-			RoleArn:         aws.String(d.Role),
-			RoleSessionName: aws.String("cert-manager"),
-		})
+		result, err := stsSvc.AssumeRole(d.assumeRoleInput())
 		if err != nil {
 			return nil, fmt.Errorf("unable to assume role: %s", err)
 		}
@@ -124,16 +144,47 @@ func (d *sessionProvider) GetSession() (*session.Session, error) {
 	return sess, nil
 }
 
-func newSessionProvider(accessKeyID, secretAccessKey, region, role string, ambient bool, userAgent string) (*sessionProvider, error) {
+// assumeRoleInput returns the input of the STS AssumeRole call made to assume
+// the configured role, with the configured session name and session tags.
+func (d *sessionProvider) assumeRoleInput() *sts.AssumeRoleInput {
+	sessionName := d.RoleSessionName
+	if sessionName == "" {
+		sessionName = DefaultRoleSessionName
+	}
+	input := &sts.AssumeRoleInput{
+		RoleArn:         aws.String(d.Role),
+		RoleSessionName: aws.String(sessionName),
+	}
+
+	// sort the tags so that the same input is produced for the same tags
+	keys := make([]string, 0, len(d.SessionTags))
+	for k := range d.SessionTags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		input.Tags = append(input.Tags, &sts.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(d.SessionTags[k]),
+		})
+	}
+
+	return input
+}
+
+func newSessionProvider(opts Options) (*sessionProvider, error) {
 	return &sessionProvider{
-		AccessKeyID:     accessKeyID,
-		SecretAccessKey: secretAccessKey,
-		Ambient:         ambient,
-		Region:          region,
-		Role:            role,
+		AccessKeyID:     opts.AccessKeyID,
+		SecretAccessKey: opts.SecretAccessKey,
+		Ambient:         opts.Ambient,
+		Region:          opts.Region,
+		Role:            opts.Role,
+		RoleSessionName: opts.RoleSessionName,
+		SessionTags:     opts.SessionTags,
 		StsProvider:     defaultSTSProvider,
+		RootCAs:         opts.RootCAs,
 		log:             logf.Log.WithName("route53-session-provider"),
-		userAgent:       userAgent,
+		userAgent:       opts.UserAgent,
 	}, nil
 }
 
@@ -141,15 +192,64 @@ func defaultSTSProvider(sess *session.Session) stsiface.STSAPI {
 	return sts.New(sess)
 }
 
+// Options configures a DNSProvider created with NewDNSProviderWithOptions.
+type Options struct {
+	// AccessKeyID and SecretAccessKey are static credentials used to
+	// authenticate to AWS. If they are unset and Ambient is true, credentials
+	// are read from the environment.
+	AccessKeyID     string
+	SecretAccessKey string
+	Ambient         bool
+
+	// HostedZoneID is the hosted zone to manage records in. If unset, the
+	// hosted zone is found by name for each record.
+	HostedZoneID string
+	Region       string
+
+	// Role is the ARN of a role to assume. It is assumed with the session
+	// name RoleSessionName, DefaultRoleSessionName if empty, and the session
+	// tags SessionTags.
+	Role            string
+	RoleSessionName string
+	SessionTags     map[string]string
+
+	// Comment is set on every change batch submitted to Route 53. If it is
+	// empty DefaultChangeComment is used.
+	Comment string
+
+	DNS01Nameservers []string
+	UserAgent        string
+
+	// RootCAs, if not nil, replaces the system roots when verifying the TLS
+	// certificates of the Route 53 and STS APIs.
+	RootCAs *x509.CertPool
+}
+
 // NewDNSProvider returns a DNSProvider instance configured for the AWS
 // Route 53 service using static credentials from its parameters or, if they're
 // unset and the 'ambient' option is set, credentials from the environment.
+// Use NewDNSProviderWithOptions to configure any further options.
 func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role string,
 	ambient bool,
 	dns01Nameservers []string,
 	userAgent string,
 ) (*DNSProvider, error) {
-	provider, err := newSessionProvider(accessKeyID, secretAccessKey, region, role, ambient, userAgent)
+	return NewDNSProviderWithOptions(Options{
+		AccessKeyID:      accessKeyID,
+		SecretAccessKey:  secretAccessKey,
+		Ambient:          ambient,
+		HostedZoneID:     hostedZoneID,
+		Region:           region,
+		Role:             role,
+		DNS01Nameservers: dns01Nameservers,
+		UserAgent:        userAgent,
+	})
+}
+
+// NewDNSProviderWithOptions returns a DNSProvider instance configured for the
+// AWS Route 53 service with the given options.
+func NewDNSProviderWithOptions(opts Options) (*DNSProvider, error) {
+	provider, err := newSessionProvider(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -163,36 +263,70 @@ func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role str
 
 	return &DNSProvider{
 		client:           client,
-		hostedZoneID:     hostedZoneID,
-		dns01Nameservers: dns01Nameservers,
+		hostedZoneID:     opts.HostedZoneID,
+		comment:          opts.Comment,
+		dns01Nameservers: opts.DNS01Nameservers,
 		log:              logf.Log.WithName("route53"),
-		userAgent:        userAgent,
+		userAgent:        opts.UserAgent,
 	}, nil
 }
 
 // Present creates a TXT record using the specified parameters
 func (r *DNSProvider) Present(domain, fqdn, value string) error {
-	value = `"` + value + `"`
+	value = util.QuoteTXTValue(value)
 	return r.changeRecord(route53.ChangeActionUpsert, fqdn, value, route53TTL)
 }
 
 // CleanUp removes the TXT record matching the specified parameters
 func (r *DNSProvider) CleanUp(domain, fqdn, value string) error {
-	value = `"` + value + `"`
+	value = util.QuoteTXTValue(value)
 	return r.changeRecord(route53.ChangeActionDelete, fqdn, value, route53TTL)
 }
 
+// Validate checks that the credentials are accepted by Route 53 and that a
+// hosted zone can be found for each of the given domains, without changing
+// any records.
+func (r *DNSProvider) Validate(domains []string) error {
+	if r.hostedZoneID != "" {
+		_, err := r.client.GetHostedZone(&route53.GetHostedZoneInput{
+			Id: aws.String(r.hostedZoneID),
+		})
+		if err != nil {
+			return withPermissionHint(fmt.Errorf("failed to get Route 53 hosted zone %s: %v", r.hostedZoneID, removeReqID(err)), err, "route53:GetHostedZone")
+		}
+		return nil
+	}
+
+	if len(domains) == 0 {
+		_, err := r.client.ListHostedZones(&route53.ListHostedZonesInput{
+			MaxItems: aws.String("1"),
+		})
+		if err != nil {
+			return withPermissionHint(fmt.Errorf("failed to list Route 53 hosted zones: %v", removeReqID(err)), err, "route53:ListHostedZones")
+		}
+		return nil
+	}
+
+	for _, domain := range domains {
+		if _, err := r.getHostedZoneID(util.ToFqdn(domain)); err != nil {
+			return fmt.Errorf("failed to determine Route 53 hosted zone ID: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 	hostedZoneID, err := r.getHostedZoneID(fqdn)
 	if err != nil {
-		return fmt.Errorf("failed to determine Route 53 hosted zone ID: %v", err)
+		return fmt.Errorf("failed to determine Route 53 hosted zone ID: %w", err)
 	}
 
 	recordSet := newTXTRecordSet(fqdn, value, ttl)
 	reqParams := &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneID),
 		ChangeBatch: &route53.ChangeBatch{
-			Comment: aws.String("Managed by cert-manager"),
+			Comment: aws.String(r.changeComment()),
 			Changes: []*route53.Change{
 				{
 					Action:            &action,
@@ -212,7 +346,7 @@ func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 				return nil
 			}
 		}
-		return fmt.Errorf("failed to change Route 53 record set: %v", removeReqID(err))
+		return withPermissionHint(fmt.Errorf("failed to change Route 53 record set: %v", removeReqID(err)), err, "route53:ChangeResourceRecordSets")
 
 	}
 
@@ -224,7 +358,7 @@ func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 		}
 		resp, err := r.client.GetChange(reqParams)
 		if err != nil {
-			return false, fmt.Errorf("failed to query Route 53 change status: %v", removeReqID(err))
+			return false, withPermissionHint(fmt.Errorf("failed to query Route 53 change status: %v", removeReqID(err)), err, "route53:GetChange")
 		}
 		if *resp.ChangeInfo.Status == route53.ChangeStatusInsync {
 			return true, nil
@@ -233,6 +367,24 @@ func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 	})
 }
 
+// changeComment returns the comment to set on change batches, truncated to
+// the maximum length accepted by Route 53 without splitting a multi-byte
+// character.
+func (r *DNSProvider) changeComment() string {
+	comment := r.comment
+	if comment == "" {
+		comment = DefaultChangeComment
+	}
+	if len(comment) > maxChangeCommentLength {
+		end := maxChangeCommentLength
+		for end > 0 && !utf8.RuneStart(comment[end]) {
+			end--
+		}
+		comment = comment[:end]
+	}
+	return comment
+}
+
 func (r *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 	if r.hostedZoneID != "" {
 		return r.hostedZoneID, nil
@@ -249,7 +401,7 @@ func (r *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 	}
 	resp, err := r.client.ListHostedZonesByName(reqParams)
 	if err != nil {
-		return "", removeReqID(err)
+		return "", withPermissionHint(removeReqID(err), err, "route53:ListHostedZonesByName")
 	}
 
 	zoneToID := make(map[string]string)
@@ -292,6 +444,15 @@ func newTXTRecordSet(fqdn, value string, ttl int) *route53.ResourceRecordSet {
 	}
 }
 
+// withPermissionHint adds a remediation hint naming the IAM action that the
+// request needs to err, if awsErr shows that AWS denied the request.
+func withPermissionHint(err, awsErr error, action string) error {
+	if e, ok := awsErr.(awserr.Error); !ok || e.Code() != "AccessDenied" {
+		return err
+	}
+	return cmerrors.NewWithRemediationHint(err, fmt.Sprintf("check that the IAM policy of the credentials used by the issuer allows the %s action", action))
+}
+
 // The aws-sdk-go library appends a request id to its error messages. We
 // want our error messages to be the same when the cause is the same to
 // avoid spurious challenge updates.
@@ -9,10 +9,13 @@ this directory.
 package route53
 
 import (
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -28,6 +31,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 )
 
 var (
@@ -149,6 +153,117 @@ func TestRoute53Present(t *testing.T) {
 	assert.Equal(t, `failed to change Route 53 record set: AccessDenied: User: arn:aws:iam::0123456789:user/test-cert-manager is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/OPQRSTU`, err.Error())
 }
 
+func TestRoute53ChangeBatchComment(t *testing.T) {
+	longComment := strings.Repeat("a", maxChangeCommentLength+10)
+	// each "é" is two bytes long, so the limit falls within the last one
+	longMultiByteComment := "a" + strings.Repeat("é", maxChangeCommentLength/2)
+
+	tests := map[string]struct {
+		comment    string
+		expComment string
+	}{
+		"the default comment is used if none is configured": {
+			expComment: DefaultChangeComment,
+		},
+		"the configured comment is set on the change batch": {
+			comment:    "owner=team-a (order default/test-order)",
+			expComment: "owner=team-a (order default/test-order)",
+		},
+		"a comment longer than Route 53 accepts is truncated": {
+			comment:    longComment,
+			expComment: longComment[:maxChangeCommentLength],
+		},
+		"a comment is not truncated within a multi-byte character": {
+			comment:    longMultiByteComment,
+			expComment: longMultiByteComment[:maxChangeCommentLength-1],
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var comments []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/xml")
+				switch r.URL.Path {
+				case "/2013-04-01/hostedzone/ABCDEFG/rrset/":
+					var req struct {
+						ChangeBatch struct {
+							Comment string
+						}
+					}
+					require.NoError(t, xml.NewDecoder(r.Body).Decode(&req))
+					comments = append(comments, req.ChangeBatch.Comment)
+					_, _ = w.Write([]byte(ChangeResourceRecordSetsResponse))
+				case "/2013-04-01/change/123456":
+					_, _ = w.Write([]byte(GetChangeResponse))
+				default:
+					require.FailNow(t, "unexpected request path "+r.URL.Path)
+				}
+			}))
+			defer ts.Close()
+
+			provider, err := makeRoute53Provider(ts)
+			require.NoError(t, err, "Expected to make a Route 53 provider without error")
+			provider.hostedZoneID = "ABCDEFG"
+			provider.comment = test.comment
+
+			require.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "123456d=="))
+			require.NoError(t, provider.CleanUp("example.com", "_acme-challenge.example.com.", "123456d=="))
+
+			assert.Equal(t, []string{test.expComment, test.expComment}, comments)
+		})
+	}
+}
+
+func TestRoute53Validate(t *testing.T) {
+	tests := map[string]struct {
+		hostedZoneID  string
+		mockResponses MockResponseMap
+		expErr        string
+		expHint       string
+	}{
+		"credentials can list hosted zones": {
+			mockResponses: MockResponseMap{
+				"/2013-04-01/hostedzone": MockResponse{StatusCode: 200, Body: ListHostedZonesResponse},
+			},
+		},
+		"credentials can read the configured hosted zone": {
+			hostedZoneID: "ABCDEFG",
+			mockResponses: MockResponseMap{
+				"/2013-04-01/hostedzone/ABCDEFG": MockResponse{StatusCode: 200, Body: GetHostedZoneResponse},
+			},
+		},
+		"credentials cannot read the configured hosted zone": {
+			hostedZoneID: "ABCDEFG",
+			mockResponses: MockResponseMap{
+				"/2013-04-01/hostedzone/ABCDEFG": MockResponse{StatusCode: 403, Body: GetHostedZone403Response},
+			},
+			expErr:  `failed to get Route 53 hosted zone ABCDEFG: AccessDenied: User: arn:aws:iam::0123456789:user/test-cert-manager is not authorized to perform: route53:GetHostedZone on resource: arn:aws:route53:::hostedzone/ABCDEFG`,
+			expHint: "check that the IAM policy of the credentials used by the issuer allows the route53:GetHostedZone action",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := newMockServer(t, test.mockResponses)
+			defer ts.Close()
+
+			provider, err := makeRoute53Provider(ts)
+			require.NoError(t, err, "Expected to make a Route 53 provider without error")
+			provider.hostedZoneID = test.hostedZoneID
+
+			err = provider.Validate(nil)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, test.expErr, err.Error())
+			assert.Equal(t, test.expHint, cmerrors.RemediationHint(err))
+		})
+	}
+}
+
 func TestAssumeRole(t *testing.T) {
 	creds := &sts.Credentials{
 		AccessKeyId:     aws.String("foo"),
@@ -260,15 +375,72 @@ func TestAssumeRole(t *testing.T) {
 	}
 }
 
+func TestAssumeRoleSessionNameAndTags(t *testing.T) {
+	creds := &sts.Credentials{
+		AccessKeyId:     aws.String("foo"),
+		SecretAccessKey: aws.String("bar"),
+		SessionToken:    aws.String("my-token"),
+	}
+	tests := map[string]struct {
+		roleSessionName     string
+		sessionTags         map[string]string
+		expectedSessionName string
+		expectedTags        []*sts.Tag
+	}{
+		"the default session name is used and no tags are passed if none are configured": {
+			expectedSessionName: DefaultRoleSessionName,
+		},
+		"the configured session name and tags are passed in a stable order": {
+			roleSessionName:     "cert-manager-team-a",
+			sessionTags:         map[string]string{"team": "a", "environment": "production"},
+			expectedSessionName: "cert-manager-team-a",
+			expectedTags: []*sts.Tag{
+				{Key: aws.String("environment"), Value: aws.String("production")},
+				{Key: aws.String("team"), Value: aws.String("a")},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			stsMock := &mockSTS{
+				AssumeRoleFn: func(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+					return &sts.AssumeRoleOutput{Credentials: creds}, nil
+				},
+			}
+			provider, err := newSessionProvider(Options{
+				Ambient:         true,
+				Region:          "us-east-1",
+				Role:            "my-role",
+				RoleSessionName: test.roleSessionName,
+				SessionTags:     test.sessionTags,
+			})
+			require.NoError(t, err)
+			provider.StsProvider = func(sess *session.Session) stsiface.STSAPI {
+				return stsMock
+			}
+
+			_, err = provider.GetSession()
+			require.NoError(t, err)
+			require.NotNil(t, stsMock.assumeRoleInput)
+			assert.Equal(t, "my-role", *stsMock.assumeRoleInput.RoleArn)
+			assert.Equal(t, test.expectedSessionName, *stsMock.assumeRoleInput.RoleSessionName)
+			assert.Equal(t, test.expectedTags, stsMock.assumeRoleInput.Tags)
+		})
+	}
+}
+
 type mockSTS struct {
 	*sts.STS
-	AssumeRoleFn func(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error)
-	assumedRole  string
+	AssumeRoleFn    func(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error)
+	assumedRole     string
+	assumeRoleInput *sts.AssumeRoleInput
 }
 
 func (m *mockSTS) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
 	if m.AssumeRoleFn != nil {
 		m.assumedRole = *input.RoleArn
+		m.assumeRoleInput = input
 		return m.AssumeRoleFn(input)
 	}
 
@@ -10,6 +10,7 @@ package util
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/miekg/dns"
 )
@@ -32,6 +33,13 @@ func DNS01LookupFQDN(domain string, followCNAME bool, nameservers ...string) (st
 	return fqdn, nil
 }
 
+// DelegatedDNS01FQDN returns the FQDN of the TXT record for a DNS01 challenge
+// for domain that is solved in the delegated challengeDomain, i.e.
+// `_acme-challenge.<domain>.<challengeDomain>.`.
+func DelegatedDNS01FQDN(domain, challengeDomain string) string {
+	return fmt.Sprintf("_acme-challenge.%s.%s", strings.TrimSuffix(domain, "."), dns.Fqdn(challengeDomain))
+}
+
 // FindBestMatch returns the longest match for a given domain within a list of domains
 func FindBestMatch(query string, domains ...string) (string, error) {
 	var maxSoFar int
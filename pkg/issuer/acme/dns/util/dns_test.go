@@ -96,3 +96,8 @@ func TestLongestMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestDelegatedDNS01FQDN(t *testing.T) {
+	assert.Equal(t, "_acme-challenge.example.com.acme.delegated.net.", DelegatedDNS01FQDN("example.com", "acme.delegated.net"))
+	assert.Equal(t, "_acme-challenge.example.com.acme.delegated.net.", DelegatedDNS01FQDN("example.com.", "acme.delegated.net."))
+}
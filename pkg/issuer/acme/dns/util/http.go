@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+)
+
+// TransportWithRootCAs returns a copy of http.DefaultTransport which verifies
+// TLS certificates using the given CA certificates instead of the system
+// roots. It is used by the DNS01 providers to trust the issuer's
+// spec.trustBundle.
+func TransportWithRootCAs(pool *x509.CertPool) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return transport
+}
+
+// HTTPClientWithRootCAs returns an HTTP client which verifies TLS
+// certificates using the given CA certificates instead of the system roots.
+// If pool is nil, nil is returned so that providers keep using the default
+// client of the library they are built on.
+func HTTPClientWithRootCAs(pool *x509.CertPool) *http.Client {
+	if pool == nil {
+		return nil
+	}
+	return &http.Client{Transport: TransportWithRootCAs(pool)}
+}
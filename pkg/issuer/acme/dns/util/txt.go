@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+)
+
+// TXTMaxStringLength is the maximum number of bytes that a single
+// character-string within a TXT record may hold, as defined in RFC 1035
+// section 3.3.
+const TXTMaxStringLength = 255
+
+// SplitTXTValue splits a TXT record value into character-strings of at most
+// TXTMaxStringLength bytes each. A value that already fits is returned as a
+// single element. An empty value yields a single empty string so that the
+// result can always be used as TXT record data.
+func SplitTXTValue(value string) []string {
+	if len(value) <= TXTMaxStringLength {
+		return []string{value}
+	}
+
+	chunks := make([]string, 0, (len(value)+TXTMaxStringLength-1)/TXTMaxStringLength)
+	for len(value) > TXTMaxStringLength {
+		chunks = append(chunks, value[:TXTMaxStringLength])
+		value = value[TXTMaxStringLength:]
+	}
+	if len(value) > 0 {
+		chunks = append(chunks, value)
+	}
+	return chunks
+}
+
+// JoinTXTValue reassembles a TXT record value from its character-strings.
+// It is the inverse of SplitTXTValue.
+func JoinTXTValue(chunks []string) string {
+	return strings.Join(chunks, "")
+}
+
+// QuoteTXTValue returns the zone file presentation format of a TXT record
+// value, with each character-string quoted and separated by a single space.
+// This is the format expected by providers such as Route53 and Akamai.
+func QuoteTXTValue(value string) string {
+	chunks := SplitTXTValue(value)
+	for i, chunk := range chunks {
+		chunks[i] = `"` + chunk + `"`
+	}
+	return strings.Join(chunks, " ")
+}
+
+// UnquoteTXTValue reassembles a TXT record value from its zone file
+// presentation format. It is the inverse of QuoteTXTValue, and also accepts
+// values which were never quoted.
+func UnquoteTXTValue(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, `"`)
+	value = strings.TrimSuffix(value, `"`)
+	return strings.Join(strings.Split(value, `" "`), "")
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTXTValue(t *testing.T) {
+	tests := map[string]struct {
+		value      string
+		wantChunks []int
+	}{
+		"empty value": {
+			value:      "",
+			wantChunks: []int{0},
+		},
+		"short value": {
+			value:      "LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM",
+			wantChunks: []int{43},
+		},
+		"value one byte below the limit": {
+			value:      strings.Repeat("a", 254),
+			wantChunks: []int{254},
+		},
+		"value exactly at the limit": {
+			value:      strings.Repeat("a", 255),
+			wantChunks: []int{255},
+		},
+		"value one byte above the limit": {
+			value:      strings.Repeat("a", 256),
+			wantChunks: []int{255, 1},
+		},
+		"value exactly twice the limit": {
+			value:      strings.Repeat("a", 510),
+			wantChunks: []int{255, 255},
+		},
+		"value spanning three chunks": {
+			value:      strings.Repeat("a", 600),
+			wantChunks: []int{255, 255, 90},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chunks := SplitTXTValue(test.value)
+
+			var lengths []int
+			for _, chunk := range chunks {
+				lengths = append(lengths, len(chunk))
+			}
+			assert.Equal(t, test.wantChunks, lengths)
+			assert.Equal(t, test.value, JoinTXTValue(chunks))
+		})
+	}
+}
+
+func TestQuoteTXTValue(t *testing.T) {
+	long := strings.Repeat("a", 255) + "bc"
+
+	tests := map[string]struct {
+		value  string
+		quoted string
+	}{
+		"short value": {
+			value:  "abc",
+			quoted: `"abc"`,
+		},
+		"value exactly at the limit": {
+			value:  strings.Repeat("a", 255),
+			quoted: `"` + strings.Repeat("a", 255) + `"`,
+		},
+		"value above the limit": {
+			value:  long,
+			quoted: `"` + strings.Repeat("a", 255) + `" "bc"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			quoted := QuoteTXTValue(test.value)
+			assert.Equal(t, test.quoted, quoted)
+			assert.Equal(t, test.value, UnquoteTXTValue(quoted))
+		})
+	}
+}
+
+func TestUnquoteTXTValueUnquoted(t *testing.T) {
+	assert.Equal(t, "abc", UnquoteTXTValue("abc"))
+}
@@ -21,7 +21,7 @@ import (
 )
 
 type preCheckDNSFunc func(fqdn, value string, nameservers []string,
-	useAuthoritative bool) (bool, error)
+	useAuthoritative bool, strategy NameserverStrategy) (bool, error)
 type dnsQueryFunc func(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error)
 
 var (
@@ -38,6 +38,24 @@ var (
 
 const defaultResolvConf = "/etc/resolv.conf"
 
+// NameserverStrategy determines how the answers of the configured recursive
+// nameservers are combined when checking whether a TXT record has propagated.
+type NameserverStrategy string
+
+const (
+	// NameserverStrategyFirst uses the answer of the first nameserver that
+	// responds, falling back to the next nameserver on error.
+	NameserverStrategyFirst NameserverStrategy = "first"
+	// NameserverStrategyAll requires every nameserver to return the record.
+	NameserverStrategyAll NameserverStrategy = "all"
+	// NameserverStrategyMajority requires more than half of the nameservers
+	// to return the record.
+	NameserverStrategyMajority NameserverStrategy = "majority"
+)
+
+// NameserverStrategies lists the supported NameserverStrategy values.
+var NameserverStrategies = []NameserverStrategy{NameserverStrategyFirst, NameserverStrategyAll, NameserverStrategyMajority}
+
 const issueTag = "issue"
 const issuewildTag = "issuewild"
 
@@ -101,8 +119,10 @@ func followCNAMEs(fqdn string, nameservers []string, fqdnChain ...string) (strin
 }
 
 // checkDNSPropagation checks if the expected TXT record has been propagated to all authoritative nameservers.
+// If useAuthoritative is false, the given recursive nameservers are queried instead and their answers
+// are combined according to strategy.
 func checkDNSPropagation(fqdn, value string, nameservers []string,
-	useAuthoritative bool) (bool, error) {
+	useAuthoritative bool, strategy NameserverStrategy) (bool, error) {
 
 	var err error
 	fqdn, err = followCNAMEs(fqdn, nameservers)
@@ -111,7 +131,7 @@ func checkDNSPropagation(fqdn, value string, nameservers []string,
 	}
 
 	if !useAuthoritative {
-		return checkAuthoritativeNss(fqdn, value, nameservers)
+		return checkRecursiveNss(fqdn, value, nameservers, strategy)
 	}
 
 	authoritativeNss, err := lookupNameservers(fqdn, nameservers)
@@ -128,33 +148,89 @@ func checkDNSPropagation(fqdn, value string, nameservers []string,
 // checkAuthoritativeNss queries each of the given nameservers for the expected TXT record.
 func checkAuthoritativeNss(fqdn, value string, nameservers []string) (bool, error) {
 	for _, ns := range nameservers {
-		r, err := DNSQuery(fqdn, dns.TypeTXT, []string{ns}, true)
+		found, err := nameserverHasTXT(fqdn, value, ns)
 		if err != nil {
 			return false, err
 		}
 
-		// NXDomain response is not really an error, just waiting for propagation to happen
-		if !(r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError) {
-			return false, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
+		if !found {
+			return false, nil
 		}
+	}
+
+	return true, nil
+}
 
-		logf.V(logf.DebugLevel).Infof("Looking up TXT records for %q", fqdn)
-		var found bool
-		for _, rr := range r.Answer {
-			if txt, ok := rr.(*dns.TXT); ok {
-				if strings.Join(txt.Txt, "") == value {
-					found = true
-					break
-				}
+// checkRecursiveNss queries the given recursive nameservers for the expected
+// TXT record, combining their answers according to strategy.
+func checkRecursiveNss(fqdn, value string, nameservers []string, strategy NameserverStrategy) (bool, error) {
+	switch strategy {
+	case NameserverStrategyFirst:
+		var lastErr error
+		for _, ns := range nameservers {
+			found, err := nameserverHasTXT(fqdn, value, ns)
+			if err != nil {
+				logf.V(logf.DebugLevel).Infof("Nameserver %s failed to answer TXT query for %q, trying the next nameserver: %v", ns, fqdn, err)
+				lastErr = err
+				continue
 			}
+			return found, nil
 		}
+		return false, lastErr
 
-		if !found {
-			return false, nil
+	case NameserverStrategyMajority:
+		var found, answered int
+		var lastErr error
+		for _, ns := range nameservers {
+			ok, err := nameserverHasTXT(fqdn, value, ns)
+			if err != nil {
+				logf.V(logf.DebugLevel).Infof("Nameserver %s failed to answer TXT query for %q: %v", ns, fqdn, err)
+				lastErr = err
+				continue
+			}
+			answered++
+			if ok {
+				found++
+			}
 		}
+		if found*2 > len(nameservers) {
+			return true, nil
+		}
+		// A failing nameserver counts against the majority, but an error is
+		// only returned if none of the nameservers answered.
+		if answered == 0 {
+			return false, lastErr
+		}
+		return false, nil
+
+	default:
+		return checkAuthoritativeNss(fqdn, value, nameservers)
 	}
+}
 
-	return true, nil
+// nameserverHasTXT returns true if the given nameserver returns a TXT record
+// for fqdn with the expected value.
+func nameserverHasTXT(fqdn, value, ns string) (bool, error) {
+	r, err := dnsQuery(fqdn, dns.TypeTXT, []string{ns}, true)
+	if err != nil {
+		return false, err
+	}
+
+	// NXDomain response is not really an error, just waiting for propagation to happen
+	if !(r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError) {
+		return false, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
+	}
+
+	logf.V(logf.DebugLevel).Infof("Looking up TXT records for %q", fqdn)
+	for _, rr := range r.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			if JoinTXTValue(txt.Txt) == value {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
 }
 
 // DNSQuery will query a nameserver, iterating through the supplied servers as it retries
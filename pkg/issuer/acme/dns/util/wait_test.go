@@ -167,7 +167,7 @@ func TestMatchCAA(t *testing.T) {
 
 func TestPreCheckDNS(t *testing.T) {
 	// TODO: find a better TXT record to use in tests
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"8.8.8.8:53"}, true)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"8.8.8.8:53"}, true, NameserverStrategyAll)
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
@@ -175,7 +175,7 @@ func TestPreCheckDNS(t *testing.T) {
 
 func TestPreCheckDNSNonAuthoritative(t *testing.T) {
 	// TODO: find a better TXT record to use in tests
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"1.1.1.1:53"}, false)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"1.1.1.1:53"}, false, NameserverStrategyAll)
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
@@ -391,3 +391,111 @@ func Test_followCNAMEs(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckRecursiveNss(t *testing.T) {
+	const (
+		fqdn  = "_acme-challenge.example.com."
+		value = "token"
+	)
+	// each fake nameserver either returns the expected record, returns no
+	// record, or fails to answer
+	dnsQuery = func(fqdn string, rtype uint16, nameservers []string, recursive bool) (*dns.Msg, error) {
+		msg := &dns.Msg{}
+		msg.Rcode = dns.RcodeSuccess
+		switch nameservers[0] {
+		case "found:53":
+			msg.Answer = []dns.RR{&dns.TXT{Txt: []string{value}}}
+		case "notfound:53":
+			msg.Rcode = dns.RcodeNameError
+		case "servfail:53":
+			msg.Rcode = dns.RcodeServerFailure
+		case "error:53":
+			return nil, fmt.Errorf("mock error querying %s", nameservers[0])
+		}
+		return msg, nil
+	}
+	defer func() {
+		// restore the mock
+		dnsQuery = DNSQuery
+	}()
+
+	tests := map[string]struct {
+		nameservers []string
+		strategy    NameserverStrategy
+		ok          bool
+		wantErr     bool
+	}{
+		"first: passes if the first nameserver has the record": {
+			nameservers: []string{"found:53", "notfound:53"},
+			strategy:    NameserverStrategyFirst,
+			ok:          true,
+		},
+		"first: fails if the first nameserver does not have the record": {
+			nameservers: []string{"notfound:53", "found:53"},
+			strategy:    NameserverStrategyFirst,
+			ok:          false,
+		},
+		"first: falls back to the next nameserver if the first fails to answer": {
+			nameservers: []string{"error:53", "servfail:53", "found:53"},
+			strategy:    NameserverStrategyFirst,
+			ok:          true,
+		},
+		"first: returns an error if no nameserver answers": {
+			nameservers: []string{"error:53", "servfail:53"},
+			strategy:    NameserverStrategyFirst,
+			wantErr:     true,
+		},
+		"all: passes if every nameserver has the record": {
+			nameservers: []string{"found:53", "found:53"},
+			strategy:    NameserverStrategyAll,
+			ok:          true,
+		},
+		"all: fails if any nameserver does not have the record": {
+			nameservers: []string{"found:53", "notfound:53"},
+			strategy:    NameserverStrategyAll,
+			ok:          false,
+		},
+		"all: returns an error if any nameserver fails to answer": {
+			nameservers: []string{"found:53", "error:53"},
+			strategy:    NameserverStrategyAll,
+			wantErr:     true,
+		},
+		"majority: passes if more than half of the nameservers have the record": {
+			nameservers: []string{"found:53", "notfound:53", "found:53"},
+			strategy:    NameserverStrategyMajority,
+			ok:          true,
+		},
+		"majority: passes if a minority of nameservers fail to answer": {
+			nameservers: []string{"found:53", "error:53", "found:53"},
+			strategy:    NameserverStrategyMajority,
+			ok:          true,
+		},
+		"majority: fails if only half of the nameservers have the record": {
+			nameservers: []string{"found:53", "notfound:53"},
+			strategy:    NameserverStrategyMajority,
+			ok:          false,
+		},
+		"majority: failing nameservers count against the majority": {
+			nameservers: []string{"found:53", "error:53", "servfail:53"},
+			strategy:    NameserverStrategyMajority,
+			ok:          false,
+		},
+		"majority: returns an error if no nameserver answers": {
+			nameservers: []string{"error:53", "servfail:53"},
+			strategy:    NameserverStrategyMajority,
+			wantErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ok, err := checkRecursiveNss(fqdn, value, test.nameservers, test.strategy)
+			if test.wantErr != (err != nil) {
+				t.Errorf("expected error: %t, got: %v", test.wantErr, err)
+			}
+			if ok != test.ok {
+				t.Errorf("expected ok to be %t, got %t", test.ok, ok)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package dns
 
 import (
+	"crypto/x509"
 	"errors"
 	"testing"
 
@@ -25,9 +26,11 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/azuredns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/bunny"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/gandi"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -117,6 +120,9 @@ type fakeDNSProviderCall struct {
 type fakeDNSProviders struct {
 	constructors dnsProviderConstructors
 	calls        []fakeDNSProviderCall
+	// rootCAs records the CA certificates each provider was constructed
+	// with, by provider name.
+	rootCAs map[string]*x509.CertPool
 }
 
 func (f *fakeDNSProviders) call(name string, args ...interface{}) {
@@ -125,10 +131,12 @@ func (f *fakeDNSProviders) call(name string, args ...interface{}) {
 
 func newFakeDNSProviders() *fakeDNSProviders {
 	f := &fakeDNSProviders{
-		calls: []fakeDNSProviderCall{},
+		calls:   []fakeDNSProviderCall{},
+		rootCAs: map[string]*x509.CertPool{},
 	}
 	f.constructors = dnsProviderConstructors{
-		cloudDNS: func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error) {
+		cloudDNS: func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName, userAgent string, rootCAs *x509.CertPool) (*clouddns.DNSProvider, error) {
+			f.rootCAs["clouddns"] = rootCAs
 			f.call("clouddns", project, serviceAccount, util.RecursiveNameservers, ambient, hostedZoneName)
 			return nil, nil
 		},
@@ -137,24 +145,38 @@ func newFakeDNSProviders() *fakeDNSProviders {
 			if email == "" || (apikey == "" && apiToken == "") {
 				return nil, errors.New("invalid email or apikey or apitoken")
 			}
-			return nil, nil
+			return &cloudflare.DNSProvider{}, nil
 		},
-		route53: func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error) {
-			f.call("route53", accessKey, secretKey, hostedZoneID, region, role, ambient, util.RecursiveNameservers)
+		route53: func(opts route53.Options) (*route53.DNSProvider, error) {
+			f.rootCAs["route53"] = opts.RootCAs
+			f.call("route53", opts.AccessKeyID, opts.SecretAccessKey, opts.HostedZoneID, opts.Region, opts.Role, opts.RoleSessionName, opts.Comment, opts.SessionTags, opts.Ambient, util.RecursiveNameservers)
 			return nil, nil
 		},
-		azureDNS: func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error) {
-			f.call("azuredns", clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName, util.RecursiveNameservers, ambient, managedIdentity)
+		azureDNS: func(opts azuredns.Options) (*azuredns.DNSProvider, error) {
+			f.rootCAs["azuredns"] = opts.RootCAs
+			f.call("azuredns", opts.ClientID, opts.ClientSecret, opts.SubscriptionID, opts.TenantID, opts.ResourceGroupName, opts.ZoneName, opts.PrivateZone, util.RecursiveNameservers, opts.Ambient, opts.ManagedIdentity)
 			return nil, nil
 		},
-		acmeDNS: func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error) {
+		acmeDNS: func(host string, accountJson []byte, dns01Nameservers []string, rootCAs *x509.CertPool) (*acmedns.DNSProvider, error) {
+			f.rootCAs["acmedns"] = rootCAs
 			f.call("acmedns", host, accountJson, dns01Nameservers)
 			return nil, nil
 		},
-		digitalOcean: func(token string, dns01Nameservers []string) (*digitalocean.DNSProvider, error) {
+		digitalOcean: func(token string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*digitalocean.DNSProvider, error) {
+			f.rootCAs["digitalocean"] = rootCAs
 			f.call("digitalocean", token, util.RecursiveNameservers)
 			return nil, nil
 		},
+		bunny: func(accessKey string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*bunny.DNSProvider, error) {
+			f.rootCAs["bunny"] = rootCAs
+			f.call("bunny", accessKey, util.RecursiveNameservers)
+			return nil, nil
+		},
+		gandi: func(token string, dns01Nameservers []string, userAgent string, rootCAs *x509.CertPool) (*gandi.DNSProvider, error) {
+			f.rootCAs["gandi"] = rootCAs
+			f.call("gandi", token, util.RecursiveNameservers)
+			return nil, nil
+		},
 	}
 	return f
 }
@@ -33,12 +33,15 @@ import (
 	k8snet "k8s.io/utils/net"
 	gwapilisters "sigs.k8s.io/gateway-api/pkg/client/listers/gateway/apis/v1alpha2"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/internal/ingress"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 const (
@@ -102,6 +105,10 @@ func http01IngressCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSo
 }
 
 func getServiceType(ch *cmacme.Challenge) (corev1.ServiceType, error) {
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.HostNetwork != nil {
+		// the Service is headless, see buildService
+		return corev1.ServiceTypeClusterIP, nil
+	}
 	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.Ingress != nil {
 		return ch.Spec.Solver.HTTP01.Ingress.ServiceType, nil
 	}
@@ -111,6 +118,22 @@ func getServiceType(ch *cmacme.Challenge) (corev1.ServiceType, error) {
 	return "", fmt.Errorf("neither HTTP01 Ingress nor Gateway solvers were found")
 }
 
+// solverListenPort returns the port the challenge solver pod for the given
+// challenge should listen on.
+func solverListenPort(ch *cmacme.Challenge) int32 {
+	http01 := ch.Spec.Solver.HTTP01
+	switch {
+	case http01 == nil:
+	case http01.HostNetwork != nil:
+		return http01.HostNetwork.Port
+	case http01.Ingress != nil && http01.Ingress.Port != 0:
+		return http01.Ingress.Port
+	case http01.GatewayHTTPRoute != nil && http01.GatewayHTTPRoute.Port != 0:
+		return http01.GatewayHTTPRoute.Port
+	}
+	return acmeSolverListenPort
+}
+
 // Present will realise the resources required to solve the given HTTP01
 // challenge validation in the apiserver. If those resources already exist, it
 // will return nil (i.e. this function is idempotent).
@@ -118,7 +141,13 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.FromContext(ctx).WithName(loggerName)
 	ctx = logf.NewContext(ctx, log)
 
-	_, podErr := s.ensurePod(ctx, ch)
+	hostNetwork := ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.HostNetwork != nil
+	if hostNetwork && !utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalHTTP01HostNetworkSolver) {
+		return cmerrors.NewInvalidData("couldn't Present challenge %s/%s: the hostNetwork HTTP01 solver requires the %s feature gate to be enabled",
+			ch.Namespace, ch.Name, feature.ExperimentalHTTP01HostNetworkSolver)
+	}
+
+	pod, podErr := s.ensurePod(ctx, ch)
 	svc, svcErr := s.ensureService(ctx, ch)
 	if svcErr != nil {
 		return utilerrors.NewAggregate([]error{podErr, svcErr})
@@ -133,6 +162,14 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 			_, gatewayErr = s.ensureGatewayHTTPRoute(ctx, ch, svc.Name)
 			return utilerrors.NewAggregate([]error{podErr, svcErr, gatewayErr})
 		}
+		if hostNetwork {
+			// requests are routed to the nodes running the solver pod by
+			// infrastructure outside of the cluster
+			if podErr == nil {
+				podErr = hostPortConflict(pod, ch.Spec.Solver.HTTP01.HostNetwork.Port)
+			}
+			return utilerrors.NewAggregate([]error{podErr, svcErr})
+		}
 	}
 	return utilerrors.NewAggregate(
 		[]error{
@@ -140,7 +177,7 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 			svcErr,
 			ingressErr,
 			gatewayErr,
-			fmt.Errorf("couldn't Present challenge %s/%s: no Ingress nor Gateway HTTP01 solvers were specified", ch.Namespace, ch.Name),
+			fmt.Errorf("couldn't Present challenge %s/%s: no Ingress, Gateway nor HostNetwork HTTP01 solvers were specified", ch.Namespace, ch.Name),
 		},
 	)
 }
@@ -171,7 +208,7 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 
 	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
 	for i := 0; i < s.requiredPasses; i++ {
-		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.RESTConfig.UserAgent)
+		err := s.testReachability(ctx, url, ch.Spec.Key, s.HTTP01SolverNameservers, s.Context.UserAgent)
 		if err != nil {
 			return err
 		}
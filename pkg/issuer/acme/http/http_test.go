@@ -26,10 +26,15 @@ import (
 	"testing"
 
 	"github.com/miekg/dns"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 // countReachabilityTestCalls is a wrapper function that allows us to count the number
@@ -190,3 +195,96 @@ func TestReachabilityCustomDnsServers(t *testing.T) {
 		}
 	}
 }
+
+func TestSolverListenPort(t *testing.T) {
+	tests := map[string]struct {
+		http01 *cmacme.ACMEChallengeSolverHTTP01
+		port   int32
+	}{
+		"ingress solver without a port uses the default port": {
+			http01: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			},
+			port: acmeSolverListenPort,
+		},
+		"ingress solver with a port uses the configured port": {
+			http01: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Port: 8080},
+			},
+			port: 8080,
+		},
+		"gateway solver with a port uses the configured port": {
+			http01: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{Port: 8080},
+			},
+			port: 8080,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Token:   "token",
+					Solver:  cmacme.ACMEChallengeSolver{HTTP01: test.http01},
+				},
+			}
+
+			s := &Solver{Context: &controller.Context{}}
+			container := s.buildPod(ch).Spec.Containers[0]
+			if container.Ports[0].ContainerPort != test.port {
+				t.Errorf("expected container port %d, but got %d", test.port, container.Ports[0].ContainerPort)
+			}
+			if expectedArg := fmt.Sprintf("--listen-port=%d", test.port); container.Args[0] != expectedArg {
+				t.Errorf("expected container arg %q, but got %q", expectedArg, container.Args[0])
+			}
+
+			svc, err := buildService(ch)
+			if err != nil {
+				t.Fatalf("unexpected error building service: %v", err)
+			}
+			if svc.Spec.Ports[0].Port != test.port || svc.Spec.Ports[0].TargetPort.IntVal != test.port {
+				t.Errorf("expected service port and target port %d, but got %+v", test.port, svc.Spec.Ports[0])
+			}
+
+			if test.http01.Ingress != nil {
+				ing, err := buildIngressResource(ch, "solver-service")
+				if err != nil {
+					t.Fatalf("unexpected error building ingress: %v", err)
+				}
+				backend := ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+				if backend.Port.Number != test.port {
+					t.Errorf("expected ingress backend port %d, but got %d", test.port, backend.Port.Number)
+				}
+			}
+			if test.http01.GatewayHTTPRoute != nil {
+				backend := generateHTTPRouteSpec(ch, "solver-service").Rules[0].BackendRefs[0]
+				if backend.Port == nil || int32(*backend.Port) != test.port {
+					t.Errorf("expected HTTPRoute backend port %d, but got %v", test.port, backend.Port)
+				}
+			}
+		})
+	}
+}
+
+func TestPresentHostNetworkRequiresFeatureGate(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.ExperimentalHTTP01HostNetworkSolver, false)()
+
+	ch := &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					HostNetwork: &cmacme.ACMEChallengeSolverHTTP01HostNetwork{Port: 8080},
+				},
+			},
+		},
+	}
+
+	s := &Solver{Context: &controller.Context{}}
+	err := s.Present(context.Background(), nil, ch)
+	if !cmerrors.IsInvalidData(err) {
+		t.Errorf("expected an invalid data error, but got %v", err)
+	}
+}
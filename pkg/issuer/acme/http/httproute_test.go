@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"reflect"
+	"testing"
+
+	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestGenerateHTTPRouteSpecHostnames(t *testing.T) {
+	tests := map[string]struct {
+		dnsName           string
+		expectedHostnames []gwapi.Hostname
+	}{
+		"a DNS name is used as the hostname": {
+			dnsName:           "example.com",
+			expectedHostnames: []gwapi.Hostname{"example.com"},
+		},
+		"an IPv4 address matches all hostnames": {
+			dnsName: "192.0.2.1",
+		},
+		"an IPv6 address matches all hostnames": {
+			dnsName: "2001:db8::1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: test.dnsName,
+					Token:   "token",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{},
+						},
+					},
+				},
+			}
+
+			spec := generateHTTPRouteSpec(ch, "fakeservice")
+			if !reflect.DeepEqual(spec.Hostnames, test.expectedHostnames) {
+				t.Errorf("expected hostnames %v, got %v", test.expectedHostnames, spec.Hostnames)
+			}
+		})
+	}
+}
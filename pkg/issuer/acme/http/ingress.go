@@ -163,13 +163,9 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*networkingv1.I
 		ingAnnotations[annotationIngressClass] = *http01IngressCfg.Class
 	}
 
-	ingPathToAdd := ingressPath(ch.Spec.Token, svcName)
+	ingPathToAdd := ingressPath(ch.Spec.Token, svcName, solverListenPort(ch))
 
-	httpHost := ch.Spec.DNSName
-	// if we need to verify ownership of an IP the challenge should propagate on all hosts
-	if net.ParseIP(httpHost) != nil {
-		httpHost = ""
-	}
+	httpHost := ingressRuleHost(ch)
 	return &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName:    "cm-acme-http-solver-",
@@ -197,6 +193,17 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*networkingv1.I
 	}, nil
 }
 
+// ingressRuleHost returns the host of the Ingress rule which routes the
+// challenge to the solver. Ingress rules cannot match IP addresses, so if we
+// need to verify ownership of an IP the challenge should propagate on all
+// hosts.
+func ingressRuleHost(ch *cmacme.Challenge) string {
+	if net.ParseIP(ch.Spec.DNSName) != nil {
+		return ""
+	}
+	return ch.Spec.DNSName
+}
+
 // Merge object meta from the ingress template. Fall back to default values.
 func (s *Solver) mergeIngressObjectMetaWithIngressResourceTemplate(ingress *networkingv1.Ingress, ingressTempl *cmacme.ACMEChallengeSolverHTTP01IngressTemplate) *networkingv1.Ingress {
 	if ingressTempl == nil {
@@ -239,10 +246,10 @@ func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Chall
 		return nil, err
 	}
 
-	ingPathToAdd := ingressPath(ch.Spec.Token, svcName)
+	ingPathToAdd := ingressPath(ch.Spec.Token, svcName, solverListenPort(ch))
 	// check for an existing Rule for the given domain on the ingress resource
 	for _, rule := range ing.Spec.Rules {
-		if rule.Host == ch.Spec.DNSName {
+		if rule.Host == ingressRuleHost(ch) {
 			if rule.HTTP == nil {
 				rule.HTTP = &networkingv1.HTTPIngressRuleValue{}
 			}
@@ -266,7 +273,7 @@ func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Chall
 
 	// if one doesn't exist, create a new IngressRule
 	ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
-		Host: ch.Spec.DNSName,
+		Host: ingressRuleHost(ch),
 		IngressRuleValue: networkingv1.IngressRuleValue{
 			HTTP: &networkingv1.HTTPIngressRuleValue{
 				Paths: []networkingv1.HTTPIngressPath{ingPathToAdd},
@@ -331,7 +338,7 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 	var ingRules []networkingv1.IngressRule
 	for _, rule := range ing.Spec.Rules {
 		// always retain rules that are not for the same DNSName
-		if rule.Host != ch.Spec.DNSName {
+		if rule.Host != ingressRuleHost(ch) {
 			ingRules = append(ingRules, rule)
 			continue
 		}
@@ -370,8 +377,8 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 }
 
 // ingressPath returns the ingress HTTPIngressPath object needed to solve this
-// challenge.
-func ingressPath(token, serviceName string) networkingv1.HTTPIngressPath {
+// challenge, routing to the given port of the solver Service.
+func ingressPath(token, serviceName string, port int32) networkingv1.HTTPIngressPath {
 	return networkingv1.HTTPIngressPath{
 		Path:     solverPathFn(token),
 		PathType: func() *networkingv1.PathType { s := networkingv1.PathTypeImplementationSpecific; return &s }(),
@@ -379,7 +386,7 @@ func ingressPath(token, serviceName string) networkingv1.HTTPIngressPath {
 			Service: &networkingv1.IngressServiceBackend{
 				Name: serviceName,
 				Port: networkingv1.ServiceBackendPort{
-					Number: acmeSolverListenPort,
+					Number: port,
 				},
 			},
 		},
@@ -644,3 +644,58 @@ func TestOverrideNginxIngressWhitelistAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestAddChallengePathToIngressForIPAddress(t *testing.T) {
+	test := solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				&networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testingress",
+						Namespace: defaultTestNamespace,
+					},
+					Spec: networkingv1.IngressSpec{
+						Rules: []networkingv1.IngressRule{{Host: "example.com"}},
+					},
+				},
+			},
+		},
+		Challenge: &cmacme.Challenge{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testchal",
+				Namespace: defaultTestNamespace,
+			},
+			Spec: cmacme.ChallengeSpec{
+				DNSName: "192.0.2.1",
+				Token:   "abcd",
+				Solver: cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "testingress",
+						},
+					},
+				},
+			},
+		},
+	}
+	test.Setup(t)
+
+	ing, err := test.Solver.addChallengePathToIngress(context.TODO(), test.Challenge, "fakeservice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Ingress rules cannot match IP addresses, so the challenge path is
+	// added to a rule matching all hosts.
+	if len(ing.Spec.Rules) != 2 {
+		t.Fatalf("expected a rule to be added to the ingress, got rules %+v", ing.Spec.Rules)
+	}
+	rule := ing.Spec.Rules[1]
+	if rule.Host != "" {
+		t.Errorf("expected the rule for an IP address to match all hosts, got host %q", rule.Host)
+	}
+	if rule.HTTP == nil || len(rule.HTTP.Paths) != 1 || rule.HTTP.Paths[0].Path != "/.well-known/acme-challenge/abcd" {
+		t.Errorf("expected the rule to route the challenge path, got %+v", rule.HTTP)
+	}
+	test.Finish(t)
+}
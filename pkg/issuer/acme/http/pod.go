@@ -73,6 +73,26 @@ func (s *Solver) ensurePod(ctx context.Context, ch *cmacme.Challenge) (*corev1.P
 	return s.createPod(ctx, ch)
 }
 
+// hostPortConflict returns an error if the given hostNetwork solver pod is
+// unable to listen on its host port. This is the case if the scheduler cannot
+// find a node where the port is free, or if the solver has exited because
+// another process on its node is already listening on the port.
+func hostPortConflict(pod *corev1.Pod, port int32) error {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
+			return fmt.Errorf("challenge solver pod %s/%s cannot be scheduled, host port %d may be in use on all eligible nodes: %s",
+				pod.Namespace, pod.Name, port, cond.Message)
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if terminated := status.LastTerminationState.Terminated; terminated != nil {
+			return fmt.Errorf("challenge solver pod %s/%s exited on node %s, host port %d may be in use by another process on the node: %s",
+				pod.Namespace, pod.Name, pod.Spec.NodeName, port, terminated.Message)
+		}
+	}
+	return nil
+}
+
 // getPodsForChallenge returns a list of pods that were created to solve
 // the given challenge
 func (s *Solver) getPodsForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*corev1.Pod, error) {
@@ -150,6 +170,15 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 			pod = s.mergePodObjectMetaWithPodTemplate(pod,
 				ch.Spec.Solver.HTTP01.Ingress.PodTemplate)
 		}
+		if ch.Spec.Solver.HTTP01.HostNetwork != nil {
+			pod = s.mergePodObjectMetaWithPodTemplate(pod,
+				ch.Spec.Solver.HTTP01.HostNetwork.PodTemplate)
+			pod.Spec.HostNetwork = true
+			pod.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+			// declaring the host port ensures that the scheduler does not
+			// place two solver pods on the same node
+			pod.Spec.Containers[0].Ports[0].HostPort = ch.Spec.Solver.HTTP01.HostNetwork.Port
+		}
 	}
 
 	return pod
@@ -157,6 +186,7 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 
 func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 	podLabels := podLabels(ch)
+	listenPort := solverListenPort(ch)
 
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -184,7 +214,7 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					// TODO: replace this with some kind of cmdline generator
 					Args: []string{
-						fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+						fmt.Sprintf("--listen-port=%d", listenPort),
 						fmt.Sprintf("--domain=%s", ch.Spec.DNSName),
 						fmt.Sprintf("--token=%s", ch.Spec.Token),
 						fmt.Sprintf("--key=%s", ch.Spec.Key),
@@ -202,7 +232,7 @@ func (s *Solver) buildDefaultPod(ch *cmacme.Challenge) *corev1.Pod {
 					Ports: []corev1.ContainerPort{
 						{
 							Name:          "http",
-							ContainerPort: acmeSolverListenPort,
+							ContainerPort: listenPort,
 						},
 					},
 					SecurityContext: &corev1.SecurityContext{
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
@@ -413,3 +414,99 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildPodHostNetwork(t *testing.T) {
+	test := solverFixture{
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				DNSName: "example.com",
+				Solver: cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						HostNetwork: &cmacme.ACMEChallengeSolverHTTP01HostNetwork{
+							Port: 8080,
+							PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+								Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+									NodeSelector: map[string]string{
+										"edge": "true",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	test.Setup(t)
+	pod := test.Solver.buildPod(test.Challenge)
+
+	if !pod.Spec.HostNetwork {
+		t.Errorf("expected pod to use the host network")
+	}
+	if pod.Spec.DNSPolicy != corev1.DNSClusterFirstWithHostNet {
+		t.Errorf("expected DNS policy %q, but got %q", corev1.DNSClusterFirstWithHostNet, pod.Spec.DNSPolicy)
+	}
+	if pod.Spec.NodeSelector["edge"] != "true" {
+		t.Errorf("expected node selector from the pod template to be used, but got %v", pod.Spec.NodeSelector)
+	}
+	container := pod.Spec.Containers[0]
+	expectedPort := corev1.ContainerPort{Name: "http", ContainerPort: 8080, HostPort: 8080}
+	if len(container.Ports) != 1 || container.Ports[0] != expectedPort {
+		t.Errorf("expected container ports %v, but got %v", []corev1.ContainerPort{expectedPort}, container.Ports)
+	}
+	if container.Args[0] != "--listen-port=8080" {
+		t.Errorf("expected solver to listen on the configured port, but got args %v", container.Args)
+	}
+	test.Finish(t)
+}
+
+func TestHostPortConflict(t *testing.T) {
+	tests := map[string]struct {
+		status      corev1.PodStatus
+		expectedErr string
+	}{
+		"no conflict for a running pod": {
+			status: corev1.PodStatus{
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "acmesolver", Ready: true}},
+			},
+		},
+		"conflict if the pod cannot be scheduled": {
+			status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 node(s) didn't have free ports for the requested pod ports.",
+				}},
+			},
+			expectedErr: "challenge solver pod default/solver cannot be scheduled, host port 8080 may be in use on all eligible nodes: 0/3 nodes are available: 3 node(s) didn't have free ports for the requested pod ports.",
+		},
+		"conflict if the solver has exited": {
+			status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:                 "acmesolver",
+					RestartCount:         1,
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "address already in use"}},
+				}},
+			},
+			expectedErr: "challenge solver pod default/solver exited on node node-1, host port 8080 may be in use by another process on the node: address already in use",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "solver"},
+				Spec:       corev1.PodSpec{NodeName: "node-1"},
+				Status:     test.status,
+			}
+			err := hostPortConflict(pod, 8080)
+			if test.expectedErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if test.expectedErr != "" && (err == nil || err.Error() != test.expectedErr) {
+				t.Errorf("expected error %q, but got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -101,13 +101,14 @@ func (s *Solver) createService(ctx context.Context, ch *cmacme.Challenge) (*core
 
 func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 	podLabels := podLabels(ch)
+	listenPort := solverListenPort(ch)
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "cm-acme-http-solver-",
 			Namespace:    ch.Namespace,
 			Labels:       podLabels,
 			Annotations: map[string]string{
-				"auth.istio.io/8089": "NONE",
+				fmt.Sprintf("auth.istio.io/%d", listenPort): "NONE",
 			},
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
 		},
@@ -116,8 +117,8 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 			Ports: []corev1.ServicePort{
 				{
 					Name:       "http",
-					Port:       acmeSolverListenPort,
-					TargetPort: intstr.FromInt(acmeSolverListenPort),
+					Port:       listenPort,
+					TargetPort: intstr.FromInt(int(listenPort)),
 				},
 			},
 			Selector: podLabels,
@@ -132,6 +133,11 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 	if serviceType != "" {
 		service.Spec.Type = serviceType
 	}
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.HostNetwork != nil {
+		// the solver pods are reached on the host network, so the Service
+		// only provides DNS records for them
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+	}
 
 	return service, nil
 }
@@ -394,6 +394,41 @@ func TestEnsureService(t *testing.T) {
 			},
 			Err: false,
 		},
+		"http-01 hostNetwork challenge should create a headless service on the configured port": {
+			Challenge: &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "test.com",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							HostNetwork: &cmacme.ACMEChallengeSolverHTTP01HostNetwork{
+								Port: 8080,
+							},
+						},
+					},
+				},
+			},
+			PreFn: func(t *testing.T, s *solverFixture) {
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				resp := args[0].(*v1.Service)
+				if resp == nil {
+					t.Errorf("unexpected service = nil")
+					t.Fail()
+					return
+				}
+				if resp.Spec.Type != v1.ServiceTypeClusterIP {
+					t.Errorf("expected service type %q, but was %q", v1.ServiceTypeClusterIP, resp.Spec.Type)
+				}
+				if resp.Spec.ClusterIP != v1.ClusterIPNone {
+					t.Errorf("expected a headless service, but clusterIP was %q", resp.Spec.ClusterIP)
+				}
+				if len(resp.Spec.Ports) != 1 || resp.Spec.Ports[0].Port != 8080 || resp.Spec.Ports[0].TargetPort.IntValue() != 8080 {
+					t.Errorf("expected a single service port 8080, but got %+v", resp.Spec.Ports)
+				}
+			},
+			Err: false,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
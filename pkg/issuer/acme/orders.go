@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+
+	acmeapi "golang.org/x/crypto/acme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// maxOrderListPages is the maximum number of pages of an account's orders
+// list that are fetched, so that listing the orders of an account with a
+// runaway number of orders does not make an unbounded number of requests.
+const maxOrderListPages = 10
+
+// listOrdersFunc lists the orders at the given orders URL with the given
+// lister. complete is false if not all the pages of the list were fetched.
+type listOrdersFunc func(ctx context.Context, lister *client.OrderLister, ordersURL string) (orders []string, complete bool, err error)
+
+func listOrders(ctx context.Context, lister *client.OrderLister, ordersURL string) ([]string, bool, error) {
+	return lister.ListOrders(ctx, ordersURL, maxOrderListPages)
+}
+
+// updateAccountOrders lists the orders of the ACME account, if enabled and
+// not done within the orders list interval, and records their number in the
+// issuer's status. Orders cannot be deleted, so they are only logged.
+// Failures are only logged too, as ACME servers are not required to support
+// listing orders, and the issuer can be used regardless. If account is nil,
+// it is looked up with the given client.
+func (a *Acme) updateAccountOrders(ctx context.Context, cl client.Interface, httpClient *http.Client, key crypto.Signer, account *acmeapi.Account) {
+	status := a.issuer.GetStatus().ACMEStatus()
+	if a.ordersListInterval <= 0 {
+		status.Orders = nil
+		return
+	}
+
+	// Updating the status causes the issuer to be synced again, so the
+	// interval also prevents the orders from being listed in a loop.
+	now := apiutil.Clock.Now()
+	if status.Orders != nil && status.Orders.LastListedTime != nil &&
+		now.Before(status.Orders.LastListedTime.Add(a.ordersListInterval)) {
+		return
+	}
+
+	log := logf.FromContext(ctx)
+	if account == nil {
+		var err error
+		account, err = cl.GetReg(ctx, "")
+		if err != nil {
+			log.V(logf.InfoLevel).Info("failed to look up the ACME account to list its orders", "error", err)
+			return
+		}
+	}
+	if account.OrdersURL == "" {
+		log.V(logf.DebugLevel).Info("not listing orders as the ACME server does not return an orders URL for the account")
+		status.Orders = nil
+		return
+	}
+
+	dir, err := cl.Discover(ctx)
+	if err != nil {
+		log.V(logf.InfoLevel).Info("failed to fetch the ACME directory to list the account's orders", "error", err)
+		return
+	}
+
+	lister := &client.OrderLister{
+		HTTPClient: httpClient,
+		Key:        key,
+		UserAgent:  a.userAgent,
+		NonceURL:   dir.NonceURL,
+		AccountURL: account.URI,
+	}
+	orders, complete, err := a.listOrders(ctx, lister, account.OrdersURL)
+	if err != nil {
+		log.V(logf.InfoLevel).Info("failed to list the orders of the ACME account", "error", err)
+		return
+	}
+
+	log.V(logf.InfoLevel).Info("listed the orders of the ACME account", "count", len(orders), "complete", complete)
+	log.V(logf.DebugLevel).Info("orders of the ACME account", "orders", orders)
+	lastListedTime := metav1.NewTime(now)
+	status.Orders = &cmacme.ACMEAccountOrdersStatus{
+		Count:          len(orders),
+		Truncated:      !complete,
+		LastListedTime: &lastListedTime,
+	}
+}
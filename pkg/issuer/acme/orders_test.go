@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestAcme_updateAccountOrders(t *testing.T) {
+	var (
+		fixedClockStart = time.Now()
+		nowMetaTime     = metav1.NewTime(fixedClockStart)
+		recentMetaTime  = metav1.NewTime(fixedClockStart.Add(-30 * time.Minute))
+		oldMetaTime     = metav1.NewTime(fixedClockStart.Add(-2 * time.Hour))
+
+		account = &acmeapi.Account{
+			URI:       "https://acme.example.com/acme/acct/1",
+			OrdersURL: "https://acme.example.com/acme/acct/1/orders",
+		}
+		someOrders = []string{
+			"https://acme.example.com/acme/order/1",
+			"https://acme.example.com/acme/order/2",
+		}
+	)
+
+	tests := map[string]struct {
+		interval       time.Duration
+		existingStatus *cmacme.ACMEAccountOrdersStatus
+		// account is passed to updateAccountOrders, if not nil it is not
+		// looked up
+		account       *acmeapi.Account
+		getRegAccount *acmeapi.Account
+		orders        []string
+		complete      bool
+		listErr       error
+
+		expectListed   bool
+		expectedStatus *cmacme.ACMEAccountOrdersStatus
+	}{
+		"clears the status if listing orders is disabled": {
+			existingStatus: &cmacme.ACMEAccountOrdersStatus{Count: 2, LastListedTime: &oldMetaTime},
+			account:        account,
+		},
+		"records the number of orders": {
+			interval:       time.Hour,
+			account:        account,
+			orders:         someOrders,
+			complete:       true,
+			expectListed:   true,
+			expectedStatus: &cmacme.ACMEAccountOrdersStatus{Count: 2, LastListedTime: &nowMetaTime},
+		},
+		"looks up the account if it was not verified": {
+			interval:       time.Hour,
+			getRegAccount:  account,
+			orders:         someOrders,
+			complete:       true,
+			expectListed:   true,
+			expectedStatus: &cmacme.ACMEAccountOrdersStatus{Count: 2, LastListedTime: &nowMetaTime},
+		},
+		"records that the list was truncated": {
+			interval:       time.Hour,
+			account:        account,
+			orders:         someOrders,
+			expectListed:   true,
+			expectedStatus: &cmacme.ACMEAccountOrdersStatus{Count: 2, Truncated: true, LastListedTime: &nowMetaTime},
+		},
+		"does not list the orders again within the interval": {
+			interval:       time.Hour,
+			existingStatus: &cmacme.ACMEAccountOrdersStatus{Count: 1, LastListedTime: &recentMetaTime},
+			account:        account,
+			expectedStatus: &cmacme.ACMEAccountOrdersStatus{Count: 1, LastListedTime: &recentMetaTime},
+		},
+		"lists the orders again once the interval has passed": {
+			interval:       time.Hour,
+			existingStatus: &cmacme.ACMEAccountOrdersStatus{Count: 1, LastListedTime: &oldMetaTime},
+			account:        account,
+			complete:       true,
+			expectListed:   true,
+			expectedStatus: &cmacme.ACMEAccountOrdersStatus{Count: 0, LastListedTime: &nowMetaTime},
+		},
+		"clears the status if the ACME server does not support listing orders": {
+			interval:       time.Hour,
+			existingStatus: &cmacme.ACMEAccountOrdersStatus{Count: 1, LastListedTime: &oldMetaTime},
+			account:        &acmeapi.Account{URI: account.URI},
+		},
+		"keeps the last result if listing the orders fails": {
+			interval:       time.Hour,
+			existingStatus: &cmacme.ACMEAccountOrdersStatus{Count: 1, LastListedTime: &oldMetaTime},
+			account:        account,
+			listErr:        errors.New("connection refused"),
+			expectListed:   true,
+			expectedStatus: &cmacme.ACMEAccountOrdersStatus{Count: 1, LastListedTime: &oldMetaTime},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerACMEURL(acmev2Prod))
+			issuer.Status.ACME = &cmacme.ACMEIssuerStatus{Orders: test.existingStatus}
+
+			cl := &acmecl.FakeACME{
+				FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+					if test.getRegAccount == nil {
+						t.Error("unexpected call to look up the account")
+						return nil, errors.New("unexpected call")
+					}
+					return test.getRegAccount, nil
+				},
+				FakeDiscover: func(context.Context) (acmeapi.Directory, error) {
+					return acmeapi.Directory{NonceURL: "https://acme.example.com/acme/new-nonce"}, nil
+				},
+			}
+			key := mustGenerateRSAKey(t)
+
+			listed := false
+			a := Acme{
+				issuer:             issuer,
+				ordersListInterval: test.interval,
+				listOrders: func(_ context.Context, lister *acmecl.OrderLister, ordersURL string) ([]string, bool, error) {
+					listed = true
+					if ordersURL != account.OrdersURL {
+						t.Errorf("expected orders to be listed at %q, got %q", account.OrdersURL, ordersURL)
+					}
+					if lister.AccountURL != account.URI || lister.NonceURL != "https://acme.example.com/acme/new-nonce" || lister.Key != key {
+						t.Errorf("unexpected order lister: %+v", lister)
+					}
+					return test.orders, test.complete, test.listErr
+				},
+			}
+
+			// Stub the clock to get consistent last listed times.
+			apiutil.Clock = fakeclock.NewFakeClock(fixedClockStart)
+
+			a.updateAccountOrders(context.Background(), cl, nil, key, test.account)
+
+			if listed != test.expectListed {
+				t.Errorf("expected orders to be listed: %t, got: %t", test.expectListed, listed)
+			}
+			if !reflect.DeepEqual(issuer.Status.ACME.Orders, test.expectedStatus) {
+				t.Errorf("Expected orders status: %#+v\ngot: %#+v", test.expectedStatus, issuer.Status.ACME.Orders)
+			}
+		})
+	}
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package acme
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	acmeapi "golang.org/x/crypto/acme"
@@ -35,6 +38,7 @@ import (
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -46,9 +50,11 @@ const (
 	errorAccountUpdateFailed       = "ErrUpdateACMEAccount"
 	errorInvalidConfig             = "InvalidConfig"
 	errorInvalidURL                = "InvalidURL"
+	errorDNS01ValidationFailed     = "ErrValidateDNS01"
 
 	successAccountRegistered = "ACMEAccountRegistered"
 	successAccountVerified   = "ACMEAccountVerified"
+	successDNS01Validated    = "DNS01Validated"
 
 	messageAccountRegistrationFailed     = "Failed to register ACME account: "
 	messageAccountVerificationFailed     = "Failed to verify ACME account: "
@@ -57,12 +63,16 @@ const (
 	messageAccountVerified               = "The ACME account was verified with the ACME server"
 	messageNoSecretKeyGenerationDisabled = "the ACME issuer config has 'disableAccountKeyGeneration' set to true, but the secret was not found: "
 	messageInvalidPrivateKey             = "Account private key is invalid: "
+	messageDNS01ValidationFailed         = "Failed to validate DNS01 solvers: "
+	messageDNS01Validated                = "The credentials and zones of the DNS01 solvers were validated"
 
 	messageTemplateUpdateToV2              = "Your ACME server URL is set to a v1 endpoint (%s). You should update the spec.acme.server field to %q"
 	messageTemplateNotRSA                  = "ACME private key in %q is not of type RSA"
 	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
 	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
 	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+	messageTemplateFailedToReadEABKeyFile  = "failed to read External Account Binding key from file %q: %v"
+	messageTemplateInvalidTrustBundle      = "Failed to load spec.trustBundle: %v"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -98,6 +108,10 @@ func (a *Acme) Setup(ctx context.Context) error {
 		ns = a.clusterResourceNamespace
 	}
 
+	// misconfigured solvers are only surfaced as warnings, and do not prevent
+	// the ACME account from being registered
+	a.checkSolvers(ctx, ns)
+
 	log = logf.WithRelatedResourceName(log, a.issuer.GetSpec().ACME.PrivateKey.Name, ns, "Secret")
 
 	// attempt to obtain the existing private key from the apiserver.
@@ -155,7 +169,13 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// We could therefore move the removing of the client up to the start of
 	// this function.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
-	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify)
+	httpClient, err := accounts.BuildHTTPClientWithTrustBundle(a.metrics, a.issuer, a.issuer.GetSpec().ACME.SkipTLSVerify)
+	if err != nil {
+		reason = errorInvalidConfig
+		msg = fmt.Sprintf(messageTemplateInvalidTrustBundle, err)
+		// Return nil, because we do not want to re-queue an Issuer with an invalid spec.
+		return nil
+	}
 	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
 	// TODO: perform a complex check to determine whether we need to verify
@@ -212,6 +232,8 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 		// ensure the cached client in the account registry is up to date
 		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+		a.validateDNS01Solvers(ctx)
+		a.updateAccountOrders(ctx, cl, httpClient, rsaPk, nil)
 		return nil
 	}
 
@@ -314,10 +336,66 @@ func (a *Acme) Setup(ctx context.Context) error {
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
 	// ensure the cached client in the account registry is up to date
 	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+	a.validateDNS01Solvers(ctx)
+	a.updateAccountOrders(ctx, cl, httpClient, rsaPk, account)
 
 	return nil
 }
 
+// validateDNS01Solvers performs a dry run of each of the issuer's DNS01
+// solvers whose provider supports it, and records the result in the
+// issuer's DNS01Validated condition. Failures do not affect the issuer's
+// Ready condition, as the credentials may only be needed by some
+// Certificates.
+// As validation calls the DNS providers' APIs, it is only performed when the
+// issuer's spec has changed since the condition was last set.
+func (a *Acme) validateDNS01Solvers(ctx context.Context) {
+	log := logf.FromContext(ctx)
+
+	for _, cond := range a.issuer.GetStatus().Conditions {
+		if cond.Type == v1.IssuerConditionDNS01Validated && cond.ObservedGeneration == a.issuer.GetGeneration() {
+			return
+		}
+	}
+
+	validated := false
+	var errs []string
+	for i, solver := range a.issuer.GetSpec().ACME.Solvers {
+		if solver.DNS01 == nil {
+			continue
+		}
+
+		err := a.validateDNS01(ctx, a.issuer, solver)
+		if err == dns.ErrValidationNotSupported {
+			continue
+		}
+		validated = true
+		if err != nil {
+			log.V(logf.InfoLevel).Info("failed to validate DNS01 solver", "solver", i, "error", err)
+			errs = append(errs, fmt.Sprintf("solver %d: %v", i, err))
+		}
+	}
+
+	switch {
+	case !validated:
+		apiutil.RemoveIssuerCondition(a.issuer, v1.IssuerConditionDNS01Validated)
+	case len(errs) > 0:
+		apiutil.SetIssuerCondition(a.issuer,
+			a.issuer.GetGeneration(),
+			v1.IssuerConditionDNS01Validated,
+			cmmeta.ConditionFalse,
+			errorDNS01ValidationFailed,
+			messageDNS01ValidationFailed+strings.Join(errs, "; "))
+	default:
+		apiutil.SetIssuerCondition(a.issuer,
+			a.issuer.GetGeneration(),
+			v1.IssuerConditionDNS01Validated,
+			cmmeta.ConditionTrue,
+			successDNS01Validated,
+			messageDNS01Validated)
+	}
+}
+
 func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, specEmail string) (*acmeapi.Account, string, error) {
 	log := logf.FromContext(ctx)
 
@@ -382,6 +460,10 @@ func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAcco
 }
 
 func (a *Acme) getEABKey(ctx context.Context, ns string) ([]byte, error) {
+	if keyFile := a.issuer.GetSpec().ACME.ExternalAccountBinding.KeyFile; keyFile != "" {
+		return a.getEABKeyFromFile(keyFile)
+	}
+
 	eab := a.issuer.GetSpec().ACME.ExternalAccountBinding.Key
 	sec, err := a.secretsClient.Secrets(ns).Get(ctx, eab.Name, metav1.GetOptions{})
 	// Surface IsNotFound API error to not cause re-sync
@@ -398,10 +480,54 @@ func (a *Acme) getEABKey(ctx context.Context, ns string) ([]byte, error) {
 		return nil, errors.NewInvalidData("failed to find external account binding key data in Secret %q at index %q", eab.Name, eab.Key)
 	}
 
-	// decode the base64 encoded secret key data.
-	// we include this step to make it easier for end-users to encode secret
-	// keys in case the CA provides a key that is not in standard, padded
-	// base64 encoding.
+	return decodeEABKey(encodedKeyData)
+}
+
+// getEABKeyFromFile reads the external account binding key from the named
+// file in the directory configured with --acme-eab-key-dir. ClusterIssuers
+// read files from the directory itself, whereas Issuers can only read files
+// from the subdirectory named after their namespace, so that an Issuer cannot
+// use the keys intended for another namespace.
+func (a *Acme) getEABKeyFromFile(keyFile string) ([]byte, error) {
+	if a.eabKeyDir == "" {
+		return nil, errors.NewInvalidData("external account binding key file %q cannot be read as no key directory is configured for the controller", keyFile)
+	}
+	// Validation should prevent this, but never read files outside of the
+	// configured directory.
+	if filepath.IsAbs(keyFile) || filepath.Base(keyFile) != keyFile || keyFile == "." || keyFile == ".." {
+		return nil, errors.NewInvalidData("external account binding key file %q must be a file name without a directory", keyFile)
+	}
+
+	keyDir := a.eabKeyDir
+	if _, isClusterIssuer := a.issuer.(*v1.ClusterIssuer); !isClusterIssuer {
+		ns := a.issuer.GetObjectMeta().Namespace
+		if ns == "" || filepath.Base(ns) != ns || ns == "." || ns == ".." {
+			return nil, errors.NewInvalidData("external account binding key file %q cannot be read for an Issuer without a valid namespace", keyFile)
+		}
+		keyDir = filepath.Join(keyDir, ns)
+	}
+
+	// Errors reading the file are retried, as the volume it is mounted from
+	// may not have been populated yet.
+	encodedKeyData, err := os.ReadFile(filepath.Join(keyDir, keyFile))
+	if err != nil {
+		// The directory is an implementation detail of the deployment, so
+		// only report the file name.
+		if pathErr, ok := err.(*os.PathError); ok {
+			err = pathErr.Err
+		}
+		return nil, fmt.Errorf(messageTemplateFailedToReadEABKeyFile, keyFile, err)
+	}
+
+	// Files commonly end with a newline which is not part of the key.
+	return decodeEABKey(bytes.TrimSpace(encodedKeyData))
+}
+
+// decodeEABKey decodes the base64 encoded external account binding key data.
+// We include this step to make it easier for end-users to encode secret keys
+// in case the CA provides a key that is not in standard, padded base64
+// encoding.
+func decodeEABKey(encodedKeyData []byte) ([]byte, error) {
 	keyData := make([]byte, base64.RawURLEncoding.DecodedLen(len(encodedKeyData)))
 	if _, err := base64.RawURLEncoding.Decode(keyData, encodedKeyData); err != nil {
 		return nil, errors.NewInvalidData("failed to decode external account binding key data: %v", err)
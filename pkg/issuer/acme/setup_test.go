@@ -23,7 +23,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
+	"syscall"
 	"testing"
 	"time"
 
@@ -41,6 +44,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -129,6 +133,9 @@ func TestAcme_Setup(t *testing.T) {
 
 		eabSecret       *corev1.Secret
 		eabSecretGetErr error
+		// Files written to the EAB key directory. If nil, no EAB key
+		// directory is configured.
+		eabKeyFiles map[string]string
 
 		// expected ACME account passed to cl.Register
 		expectedRegisteredAcc *acmeapi.Account
@@ -291,6 +298,65 @@ func TestAcme_Setup(t *testing.T) {
 			},
 			wantsErr: true,
 		},
+		"EAB key file specified, but no EAB key directory is configured": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerACMEEABKeyFile(someString, "eab.key")),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+`external account binding key file "eab.key" cannot be read as no key directory is configured for the controller`)),
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountRegistrationFailed, messageAccountRegistrationFailed+`external account binding key file "eab.key" cannot be read as no key directory is configured for the controller`),
+			},
+		},
+		"EAB key file specified, but it does not contain a valid key": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerACMEEABKeyFile(someString, "eab.key")),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			eabKeyFiles:                map[string]string{"test-ns/eab.key": "not base64!"},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+"failed to decode external account binding key data: illegal base64 data at input byte 3")),
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorAccountRegistrationFailed, messageAccountRegistrationFailed+"failed to decode external account binding key data: illegal base64 data at input byte 3"),
+			},
+		},
+		"EAB key file specified, but it has not been mounted yet": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerACMEEABKeyFile(someString, "eab.key")),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			eabKeyFiles:                map[string]string{},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+fmt.Sprintf(messageTemplateFailedToReadEABKeyFile, "eab.key", syscall.ENOENT))),
+			},
+			wantsErr: true,
+		},
+		"EAB key file specified for an Issuer, but it only exists for ClusterIssuers": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerACMEEABKeyFile(someString, "eab.key")),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			eabKeyFiles:                map[string]string{"eab.key": "ZEdWemRBbz0K", "other-ns/eab.key": "ZEdWemRBbz0K"},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+fmt.Sprintf(messageTemplateFailedToReadEABKeyFile, "eab.key", syscall.ENOENT))),
+			},
+			wantsErr: true,
+		},
 		"Attempt to register ACME account returns unknown error": {
 			issuer:                     gen.IssuerFrom(baseIssuer),
 			kfsKey:                     rsaPrivKey,
@@ -361,6 +427,44 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionMessage(messageAccountRegistered)),
 			},
 		},
+		"ACME account with EAB key read from a file registered successfully": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerACMEEABKeyFile(someString, "eab.key")),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			eabKeyFiles:                map[string]string{"test-ns/eab.key": "ZEdWemRBbz0K\n"},
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionReason(successAccountRegistered),
+					gen.SetIssuerConditionMessage(messageAccountRegistered)),
+			},
+		},
+		"ACME account of a ClusterIssuer with EAB key read from a file registered successfully": {
+			issuer: gen.ClusterIssuer("test-issuer",
+				gen.SetIssuerACMEURL(acmev2Prod),
+				gen.SetIssuerACMEEABKeyFile(someString, "eab.key")),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			eabKeyFiles:                map[string]string{"eab.key": "ZEdWemRBbz0K"},
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionReason(successAccountRegistered),
+					gen.SetIssuerConditionMessage(messageAccountRegistered)),
+			},
+		},
 		"ACME account with legacy EAB key algorithm set and with an email is registered successfully": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEEmail(someEmail),
@@ -428,6 +532,21 @@ func TestAcme_Setup(t *testing.T) {
 				},
 			}
 
+			// Write the EAB key files to a temporary directory.
+			var eabKeyDir string
+			if test.eabKeyFiles != nil {
+				eabKeyDir = t.TempDir()
+				for name, data := range test.eabKeyFiles {
+					path := filepath.Join(eabKeyDir, name)
+					if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
 			// Mock events recorder.
 			recorder := new(controllertest.FakeRecorder)
 			a := Acme{
@@ -437,6 +556,7 @@ func TestAcme_Setup(t *testing.T) {
 				keyFromSecret:   kfs,
 				clientBuilder:   clientBuilderMock(&cl),
 				recorder:        recorder,
+				eabKeyDir:       eabKeyDir,
 			}
 
 			// Stub the clock to get consistent last transition times on conditions.
@@ -489,6 +609,132 @@ func TestAcme_Setup(t *testing.T) {
 	}
 }
 
+func TestAcme_validateDNS01Solvers(t *testing.T) {
+	var (
+		fixedClockStart = time.Now()
+		nowMetaTime     = metav1.NewTime(fixedClockStart)
+
+		cloudflareSolver = cmacme.ACMEChallengeSolver{
+			DNS01: &cmacme.ACMEChallengeSolverDNS01{
+				Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{},
+			},
+		}
+		route53Solver = cmacme.ACMEChallengeSolver{
+			DNS01: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{},
+			},
+		}
+		webhookSolver = cmacme.ACMEChallengeSolver{
+			DNS01: &cmacme.ACMEChallengeSolverDNS01{
+				Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{},
+			},
+		}
+		http01Solver = cmacme.ACMEChallengeSolver{
+			HTTP01: &cmacme.ACMEChallengeSolverHTTP01{},
+		}
+
+		validatedTrueCondition = gen.IssuerCondition(cmapi.IssuerConditionDNS01Validated,
+			gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+			gen.SetIssuerConditionReason(successDNS01Validated),
+			gen.SetIssuerConditionMessage(messageDNS01Validated),
+			gen.SetIssuerConditionLastTransitionTime(&nowMetaTime),
+			gen.SetIssuerConditionObservedGeneration(2))
+		// a condition set before the issuer's spec was last changed
+		staleValidatedTrueCondition = gen.IssuerConditionFrom(validatedTrueCondition,
+			gen.SetIssuerConditionObservedGeneration(1))
+	)
+
+	tests := map[string]struct {
+		solvers            []cmacme.ACMEChallengeSolver
+		existingConditions []cmapi.IssuerCondition
+		// errors returned when validating each solver, by provider name
+		validateErrs       map[string]error
+		expectedConditions []cmapi.IssuerCondition
+		expectedValidated  []string
+	}{
+		"does not set a condition if there are no DNS01 solvers": {
+			solvers: []cmacme.ACMEChallengeSolver{http01Solver},
+		},
+		"does not set a condition if no DNS01 provider supports validation": {
+			solvers:           []cmacme.ACMEChallengeSolver{http01Solver, webhookSolver},
+			expectedValidated: []string{"webhook"},
+		},
+		"sets the condition to true if the credentials are valid": {
+			solvers:            []cmacme.ACMEChallengeSolver{cloudflareSolver, route53Solver, webhookSolver},
+			expectedConditions: []cmapi.IssuerCondition{*validatedTrueCondition},
+			expectedValidated:  []string{"cloudflare", "route53", "webhook"},
+		},
+		"sets the condition to false if any credentials are invalid": {
+			solvers: []cmacme.ACMEChallengeSolver{cloudflareSolver, route53Solver},
+			validateErrs: map[string]error{
+				"route53": fmt.Errorf("AccessDenied"),
+			},
+			existingConditions: []cmapi.IssuerCondition{*staleValidatedTrueCondition},
+			expectedConditions: []cmapi.IssuerCondition{*gen.IssuerCondition(cmapi.IssuerConditionDNS01Validated,
+				gen.SetIssuerConditionStatus(cmmeta.ConditionFalse),
+				gen.SetIssuerConditionReason(errorDNS01ValidationFailed),
+				gen.SetIssuerConditionMessage(messageDNS01ValidationFailed+"solver 1: AccessDenied"),
+				gen.SetIssuerConditionLastTransitionTime(&nowMetaTime),
+				gen.SetIssuerConditionObservedGeneration(2))},
+			expectedValidated: []string{"cloudflare", "route53"},
+		},
+		"removes the condition if DNS01 solvers are no longer configured": {
+			solvers:            []cmacme.ACMEChallengeSolver{http01Solver},
+			existingConditions: []cmapi.IssuerCondition{*staleValidatedTrueCondition},
+		},
+		"does not validate again if the issuer has not changed since the last validation": {
+			solvers: []cmacme.ACMEChallengeSolver{cloudflareSolver, route53Solver},
+			validateErrs: map[string]error{
+				"route53": fmt.Errorf("AccessDenied"),
+			},
+			existingConditions: []cmapi.IssuerCondition{*validatedTrueCondition},
+			expectedConditions: []cmapi.IssuerCondition{*validatedTrueCondition},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer",
+				gen.SetIssuerACMEURL(acmev2Prod),
+				gen.SetIssuerACMESolvers(test.solvers))
+			issuer.Generation = 2
+			issuer.Status.Conditions = test.existingConditions
+
+			var validated []string
+			a := Acme{
+				issuer: issuer,
+				validateDNS01: func(_ context.Context, _ cmapi.GenericIssuer, solver cmacme.ACMEChallengeSolver) error {
+					var provider string
+					switch {
+					case solver.DNS01.Cloudflare != nil:
+						provider = "cloudflare"
+					case solver.DNS01.Route53 != nil:
+						provider = "route53"
+					case solver.DNS01.Webhook != nil:
+						validated = append(validated, "webhook")
+						return dns.ErrValidationNotSupported
+					}
+					validated = append(validated, provider)
+					return test.validateErrs[provider]
+				},
+			}
+
+			// Stub the clock to get consistent last transition times on conditions.
+			apiutil.Clock = fakeclock.NewFakeClock(fixedClockStart)
+
+			a.validateDNS01Solvers(context.Background())
+
+			if !reflect.DeepEqual(validated, test.expectedValidated) {
+				t.Errorf("Expected solvers to be validated: %v, got: %v", test.expectedValidated, validated)
+			}
+			if !reflect.DeepEqual(issuer.Status.Conditions, test.expectedConditions) {
+				t.Errorf("Expected issuer's conditions: %#+v\ngot: %#+v",
+					test.expectedConditions, issuer.Status.Conditions)
+			}
+		})
+	}
+}
+
 // keyFromSecretMockBuilder returns a mock implementation of keyFromSecretFunc.
 func keyFromSecretMockBuilder(wasCalled *bool, key crypto.Signer, err error) keyFromSecretFunc {
 	return func(context.Context, string, string, string) (crypto.Signer, error) {
@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	errorInvalidSolverConfig = "InvalidSolverConfig"
+	successSolversConfigured = "SolversConfigured"
+
+	messageInvalidSolverConfig = "Some solvers are misconfigured and will fail challenges: "
+	messageSolversConfigured   = "The resources referenced by the solvers are available"
+)
+
+// namedSecretRef is a reference to a Secret key used by a solver, along with
+// the name of the field it was referenced by.
+type namedSecretRef struct {
+	field string
+	ref   cmmeta.SecretKeySelector
+}
+
+// checkSolvers checks that the Secrets referenced by the issuer's solvers
+// exist and that any DNS01 webhooks are served by the apiserver, and records
+// the result in the issuer's SolversConfigured condition. Problems are only
+// surfaced as warnings, as they do not prevent the ACME account from being
+// registered and may only affect some Certificates. A Warning event is only
+// recorded when the problems found change, rather than on every sync.
+func (a *Acme) checkSolvers(ctx context.Context, ns string) {
+	log := logf.FromContext(ctx)
+
+	solvers := a.issuer.GetSpec().ACME.Solvers
+	if len(solvers) == 0 {
+		apiutil.RemoveIssuerCondition(a.issuer, v1.IssuerConditionSolversConfigured)
+		return
+	}
+
+	var problems []string
+	for i, solver := range solvers {
+		for _, problem := range a.checkSolver(solver, ns) {
+			problems = append(problems, fmt.Sprintf("solver %d: %s", i, problem))
+		}
+	}
+
+	if len(problems) == 0 {
+		apiutil.SetIssuerCondition(a.issuer,
+			a.issuer.GetGeneration(),
+			v1.IssuerConditionSolversConfigured,
+			cmmeta.ConditionTrue,
+			successSolversConfigured,
+			messageSolversConfigured)
+		return
+	}
+
+	msg := messageInvalidSolverConfig + strings.Join(problems, "; ")
+	log.V(logf.InfoLevel).Info("issuer has misconfigured solvers", "problems", problems)
+	if !hasSolversConfiguredMessage(a.issuer, msg) {
+		a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorInvalidSolverConfig, msg)
+	}
+	apiutil.SetIssuerCondition(a.issuer,
+		a.issuer.GetGeneration(),
+		v1.IssuerConditionSolversConfigured,
+		cmmeta.ConditionFalse,
+		errorInvalidSolverConfig,
+		msg)
+}
+
+// hasSolversConfiguredMessage returns true if the issuer's SolversConfigured
+// condition already reports the given message.
+func hasSolversConfiguredMessage(issuer v1.GenericIssuer, msg string) bool {
+	for _, cond := range issuer.GetStatus().Conditions {
+		if cond.Type == v1.IssuerConditionSolversConfigured {
+			return cond.Message == msg
+		}
+	}
+	return false
+}
+
+// checkSolver returns a description of each problem found with the
+// configuration of the given solver.
+func (a *Acme) checkSolver(solver cmacme.ACMEChallengeSolver, ns string) []string {
+	var problems []string
+	for _, s := range solverSecretRefs(solver) {
+		if problem := a.checkSecretRef(s, ns); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	if solver.DNS01 != nil && solver.DNS01.Webhook != nil {
+		if problem := a.checkWebhook(solver.DNS01.Webhook); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	return problems
+}
+
+// checkSecretRef returns a description of the problem if the referenced
+// Secret or key does not exist.
+func (a *Acme) checkSecretRef(s namedSecretRef, ns string) string {
+	secret, err := a.secretsLister.Secrets(ns).Get(s.ref.Name)
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("%s: secret %q not found", s.field, ns+"/"+s.ref.Name)
+	}
+	if err != nil {
+		return fmt.Sprintf("%s: failed to get secret %q: %v", s.field, ns+"/"+s.ref.Name, err)
+	}
+
+	if s.ref.Key == "" {
+		return ""
+	}
+	if _, ok := secret.Data[s.ref.Key]; !ok {
+		return fmt.Sprintf("%s: key %q not found in secret %q", s.field, s.ref.Key, ns+"/"+s.ref.Name)
+	}
+
+	return ""
+}
+
+// checkWebhook returns a description of the problem if the apiserver does not
+// serve the webhook's solver.
+func (a *Acme) checkWebhook(webhook *cmacme.ACMEIssuerDNS01ProviderWebhook) string {
+	groupVersion := webhook.GroupName + "/" + v1alpha1.SchemeGroupVersion.Version
+	resources, err := a.discoveryClient.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return fmt.Sprintf("dns01.webhook: API group %q is not reachable: %v", groupVersion, err)
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == webhook.SolverName {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("dns01.webhook: API group %q does not serve solver %q", groupVersion, webhook.SolverName)
+}
+
+// solverSecretRefs returns the Secret keys referenced by the given solver.
+func solverSecretRefs(solver cmacme.ACMEChallengeSolver) []namedSecretRef {
+	if solver.DNS01 == nil {
+		return nil
+	}
+
+	var refs []namedSecretRef
+	add := func(field string, ref *cmmeta.SecretKeySelector) {
+		// optional references are left empty when they are not used
+		if ref == nil || ref.Name == "" {
+			return
+		}
+		refs = append(refs, namedSecretRef{field: field, ref: *ref})
+	}
+
+	dns01 := solver.DNS01
+	switch {
+	case dns01.Akamai != nil:
+		add("dns01.akamai.clientTokenSecretRef", &dns01.Akamai.ClientToken)
+		add("dns01.akamai.clientSecretSecretRef", &dns01.Akamai.ClientSecret)
+		add("dns01.akamai.accessTokenSecretRef", &dns01.Akamai.AccessToken)
+	case dns01.CloudDNS != nil:
+		add("dns01.cloudDNS.serviceAccountSecretRef", dns01.CloudDNS.ServiceAccount)
+	case dns01.Cloudflare != nil:
+		add("dns01.cloudflare.apiKeySecretRef", dns01.Cloudflare.APIKey)
+		add("dns01.cloudflare.apiTokenSecretRef", dns01.Cloudflare.APIToken)
+		for i := range dns01.Cloudflare.APITokens {
+			add(fmt.Sprintf("dns01.cloudflare.apiTokenSecretRefs[%d]", i), &dns01.Cloudflare.APITokens[i])
+		}
+	case dns01.Route53 != nil:
+		add("dns01.route53.secretAccessKeySecretRef", &dns01.Route53.SecretAccessKey)
+	case dns01.AzureDNS != nil:
+		add("dns01.azureDNS.clientSecretSecretRef", dns01.AzureDNS.ClientSecret)
+	case dns01.DigitalOcean != nil:
+		add("dns01.digitalocean.tokenSecretRef", &dns01.DigitalOcean.Token)
+	case dns01.Bunny != nil:
+		add("dns01.bunny.accessKeySecretRef", &dns01.Bunny.AccessKey)
+	case dns01.Gandi != nil:
+		add("dns01.gandi.tokenSecretRef", &dns01.Gandi.Token)
+	case dns01.AcmeDNS != nil:
+		add("dns01.acmeDNS.accountSecretRef", &dns01.AcmeDNS.AccountSecret)
+	case dns01.RFC2136 != nil:
+		add("dns01.rfc2136.tsigSecretSecretRef", &dns01.RFC2136.TSIGSecret)
+		add("dns01.rfc2136.tsigKeyFileSecretRef", dns01.RFC2136.TSIGKeyFile)
+	}
+
+	return refs
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcorev1 "k8s.io/client-go/listers/core/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	discoveryfake "github.com/cert-manager/cert-manager/test/unit/discovery"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	listersfake "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func TestAcme_checkSolvers(t *testing.T) {
+	var (
+		fixedClockStart = time.Now()
+		nowMetaTime     = metav1.NewTime(fixedClockStart)
+
+		secretRef = func(name, key string) cmmeta.SecretKeySelector {
+			return cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: name},
+				Key:                  key,
+			}
+		}
+		cloudflareSolver = func(name, key string) cmacme.ACMEChallengeSolver {
+			ref := secretRef(name, key)
+			return cmacme.ACMEChallengeSolver{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{APIToken: &ref},
+				},
+			}
+		}
+		webhookSolver = func(groupName, solverName string) cmacme.ACMEChallengeSolver {
+			return cmacme.ACMEChallengeSolver{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{GroupName: groupName, SolverName: solverName},
+				},
+			}
+		}
+		http01Solver = cmacme.ACMEChallengeSolver{
+			HTTP01: &cmacme.ACMEChallengeSolverHTTP01{},
+		}
+
+		configuredCondition = gen.IssuerCondition(cmapi.IssuerConditionSolversConfigured,
+			gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+			gen.SetIssuerConditionReason(successSolversConfigured),
+			gen.SetIssuerConditionMessage(messageSolversConfigured),
+			gen.SetIssuerConditionLastTransitionTime(&nowMetaTime))
+		misconfiguredCondition = func(msg string) []cmapi.IssuerCondition {
+			return []cmapi.IssuerCondition{*gen.IssuerCondition(cmapi.IssuerConditionSolversConfigured,
+				gen.SetIssuerConditionStatus(cmmeta.ConditionFalse),
+				gen.SetIssuerConditionReason(errorInvalidSolverConfig),
+				gen.SetIssuerConditionMessage(messageInvalidSolverConfig+msg),
+				gen.SetIssuerConditionLastTransitionTime(&nowMetaTime))}
+		}
+	)
+
+	// secrets in the "default" namespace, by name
+	secrets := map[string]*corev1.Secret{
+		"cloudflare": gen.Secret("cloudflare",
+			gen.SetSecretNamespace("default"),
+			gen.SetSecretData(map[string][]byte{"api-token": []byte("token")})),
+	}
+	// resources served by webhook API groups, by group version
+	webhookResources := map[string][]metav1.APIResource{
+		"acme.example.com/v1alpha1": {{Name: "example"}},
+	}
+
+	tests := map[string]struct {
+		solvers            []cmacme.ACMEChallengeSolver
+		existingConditions []cmapi.IssuerCondition
+		expectedConditions []cmapi.IssuerCondition
+		expectedEvents     []string
+	}{
+		"does not set a condition if there are no solvers": {},
+		"removes the condition if there are no longer any solvers": {
+			existingConditions: []cmapi.IssuerCondition{*configuredCondition},
+		},
+		"sets the condition to true if all referenced resources exist": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				http01Solver,
+				cloudflareSolver("cloudflare", "api-token"),
+				webhookSolver("acme.example.com", "example"),
+			},
+			expectedConditions: []cmapi.IssuerCondition{*configuredCondition},
+		},
+		"surfaces a missing solver secret": {
+			solvers:            []cmacme.ACMEChallengeSolver{http01Solver, cloudflareSolver("does-not-exist", "api-token")},
+			existingConditions: []cmapi.IssuerCondition{*configuredCondition},
+			expectedConditions: misconfiguredCondition(`solver 1: dns01.cloudflare.apiTokenSecretRef: secret "default/does-not-exist" not found`),
+			expectedEvents: []string{
+				`Warning InvalidSolverConfig ` + messageInvalidSolverConfig + `solver 1: dns01.cloudflare.apiTokenSecretRef: secret "default/does-not-exist" not found`,
+			},
+		},
+		"surfaces a missing key in a solver secret": {
+			solvers:            []cmacme.ACMEChallengeSolver{cloudflareSolver("cloudflare", "api-key")},
+			expectedConditions: misconfiguredCondition(`solver 0: dns01.cloudflare.apiTokenSecretRef: key "api-key" not found in secret "default/cloudflare"`),
+			expectedEvents: []string{
+				`Warning InvalidSolverConfig ` + messageInvalidSolverConfig + `solver 0: dns01.cloudflare.apiTokenSecretRef: key "api-key" not found in secret "default/cloudflare"`,
+			},
+		},
+		"does not record another event if the same problems were already surfaced": {
+			solvers:            []cmacme.ACMEChallengeSolver{cloudflareSolver("cloudflare", "api-key")},
+			existingConditions: misconfiguredCondition(`solver 0: dns01.cloudflare.apiTokenSecretRef: key "api-key" not found in secret "default/cloudflare"`),
+			expectedConditions: misconfiguredCondition(`solver 0: dns01.cloudflare.apiTokenSecretRef: key "api-key" not found in secret "default/cloudflare"`),
+		},
+		"surfaces unreachable and unknown webhooks": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				webhookSolver("acme.example.org", "example"),
+				webhookSolver("acme.example.com", "other"),
+			},
+			expectedConditions: misconfiguredCondition(`solver 0: dns01.webhook: API group "acme.example.org/v1alpha1" is not reachable: the server could not find the requested resource; ` +
+				`solver 1: dns01.webhook: API group "acme.example.com/v1alpha1" does not serve solver "other"`),
+			expectedEvents: []string{
+				`Warning InvalidSolverConfig ` + messageInvalidSolverConfig + `solver 0: dns01.webhook: API group "acme.example.org/v1alpha1" is not reachable: the server could not find the requested resource; ` +
+					`solver 1: dns01.webhook: API group "acme.example.com/v1alpha1" does not serve solver "other"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("default"),
+				gen.SetIssuerACMEURL(acmev2Prod),
+				gen.SetIssuerACMESolvers(test.solvers))
+			issuer.Status.Conditions = test.existingConditions
+
+			secretsLister := listersfake.NewFakeSecretLister(listersfake.SetFakeSecretListerSecret(func(namespace string) clientcorev1.SecretNamespaceLister {
+				return listersfake.NewFakeSecretNamespaceLister(func(l *listersfake.FakeSecretNamespaceLister) {
+					l.GetFn = func(name string) (*corev1.Secret, error) {
+						if secret, ok := secrets[name]; ok && namespace == "default" {
+							return secret, nil
+						}
+						return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+					}
+				})
+			}))
+			discoveryClient := discoveryfake.NewDiscovery().WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
+				resources, ok := webhookResources[groupVersion]
+				if !ok {
+					return nil, errors.New("the server could not find the requested resource")
+				}
+				return &metav1.APIResourceList{GroupVersion: groupVersion, APIResources: resources}, nil
+			})
+
+			recorder := new(controllertest.FakeRecorder)
+			a := Acme{
+				issuer:          issuer,
+				secretsLister:   secretsLister,
+				discoveryClient: discoveryClient,
+				recorder:        recorder,
+			}
+
+			// Stub the clock to get consistent last transition times on conditions.
+			apiutil.Clock = fakeclock.NewFakeClock(fixedClockStart)
+
+			a.checkSolvers(context.Background(), "default")
+
+			if !reflect.DeepEqual(issuer.Status.Conditions, test.expectedConditions) {
+				t.Errorf("Expected issuer's conditions: %#+v\ngot: %#+v",
+					test.expectedConditions, issuer.Status.Conditions)
+			}
+			if !reflect.DeepEqual(recorder.Events, test.expectedEvents) {
+				t.Errorf("Expected events:\n%s\ngot:\n%s",
+					fmt.Sprint(test.expectedEvents), fmt.Sprint(recorder.Events))
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 )
@@ -30,8 +31,9 @@ import (
 // used to sign certificates.
 type CA struct {
 	*controller.Context
-	issuer        v1.GenericIssuer
-	secretsLister corelisters.SecretLister
+	issuer            v1.GenericIssuer
+	secretsLister     corelisters.SecretLister
+	certificateLister cmlisters.CertificateLister
 
 	// Namespace in which to read resources related to this Issuer from.
 	// For Issuers, this will be the namespace of the Issuer.
@@ -41,15 +43,34 @@ type CA struct {
 
 func NewCA(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
 	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
+	certificateLister := ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister()
 
 	return &CA{
 		Context:           ctx,
 		issuer:            issuer,
 		secretsLister:     secretsLister,
+		certificateLister: certificateLister,
 		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
 	}, nil
 }
 
+// SigningSecretName returns the name of the Secret holding the signing CA
+// keypair for the given CA issuer configuration. If the issuer references a
+// Certificate, the Secret is resolved from that Certificate's spec.secretName
+// so that a renewed CA is used as soon as the Secret is updated.
+func SigningSecretName(certificateLister cmlisters.CertificateLister, namespace string, cfg *v1.CAIssuer) (string, error) {
+	if len(cfg.CertificateName) == 0 {
+		return cfg.SecretName, nil
+	}
+
+	crt, err := certificateLister.Certificates(namespace).Get(cfg.CertificateName)
+	if err != nil {
+		return "", err
+	}
+
+	return crt.Spec.SecretName, nil
+}
+
 func init() {
 	issuer.RegisterIssuer(apiutil.IssuerCA, NewCA)
 }
@@ -43,7 +43,16 @@ const (
 func (c *CA) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx, "setup")
 
-	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	secretName, err := SigningSecretName(c.certificateLister, c.resourceNamespace, c.issuer.GetSpec().CA)
+	if err != nil {
+		log.Error(err, "error getting signing CA Certificate")
+		s := messageErrorGetKeyPair + err.Error()
+		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
+		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
+		return err
+	}
+
+	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, secretName)
 	if err != nil {
 		log.Error(err, "error getting signing CA TLS certificate")
 		s := messageErrorGetKeyPair + err.Error()
@@ -52,7 +61,7 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, secretName)
 	if err != nil {
 		log.Error(err, "error getting signing CA private key")
 		s := messageErrorGetKeyPair + err.Error()
@@ -61,7 +70,7 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	log = logf.WithRelatedResourceName(log, c.issuer.GetSpec().CA.SecretName, c.resourceNamespace, "Secret")
+	log = logf.WithRelatedResourceName(log, secretName, c.resourceNamespace, "Secret")
 	if !cert.IsCA {
 		s := messageErrorGetKeyPair + "certificate is not a CA"
 		log.Error(nil, "signing certificate is not a CA")
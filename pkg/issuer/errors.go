@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"errors"
+)
+
+// The following errors classify why an issuer failed to sign a request.
+// Issuers should not return them directly, but wrap the underlying error
+// using NewRateLimitedError, NewAuthFailedError, NewTransientError or
+// NewPermanentError so that callers can use errors.Is to decide how the
+// failure is reported and whether it should be retried.
+var (
+	// ErrRateLimited means the signing service rejected the request because
+	// too many requests have been made. The request may be retried later.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrAuthFailed means the issuer could not authenticate or was not
+	// authorized to sign the request, usually because of invalid or expired
+	// credentials. The request may succeed once the credentials are fixed.
+	ErrAuthFailed = errors.New("authentication failed")
+
+	// ErrTransient means the failure is expected to resolve itself, for
+	// example a network error or a temporarily unavailable service.
+	ErrTransient = errors.New("transient error")
+
+	// ErrPermanent means the request can never be signed as it is, for
+	// example because the service rejected the CSR. It must not be retried.
+	ErrPermanent = errors.New("permanent error")
+)
+
+// classifiedError associates an error returned by an issuer with one of the
+// error classes above.
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e *classifiedError) Is(target error) bool {
+	return target == e.class
+}
+
+func classify(class, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// NewRateLimitedError wraps err so that it matches ErrRateLimited.
+func NewRateLimitedError(err error) error {
+	return classify(ErrRateLimited, err)
+}
+
+// NewAuthFailedError wraps err so that it matches ErrAuthFailed.
+func NewAuthFailedError(err error) error {
+	return classify(ErrAuthFailed, err)
+}
+
+// NewTransientError wraps err so that it matches ErrTransient.
+func NewTransientError(err error) error {
+	return classify(ErrTransient, err)
+}
+
+// NewPermanentError wraps err so that it matches ErrPermanent.
+func NewPermanentError(err error) error {
+	return classify(ErrPermanent, err)
+}
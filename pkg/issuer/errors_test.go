@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifiedErrors(t *testing.T) {
+	classes := []error{ErrRateLimited, ErrAuthFailed, ErrTransient, ErrPermanent}
+	underlying := errors.New("underlying error")
+
+	tests := map[string]struct {
+		err      error
+		expClass error
+	}{
+		"rate limited":          {err: NewRateLimitedError(underlying), expClass: ErrRateLimited},
+		"auth failed":           {err: NewAuthFailedError(underlying), expClass: ErrAuthFailed},
+		"transient":             {err: NewTransientError(underlying), expClass: ErrTransient},
+		"permanent":             {err: NewPermanentError(underlying), expClass: ErrPermanent},
+		"wrapped again":         {err: fmt.Errorf("signing: %w", NewPermanentError(underlying)), expClass: ErrPermanent},
+		"unclassified is plain": {err: underlying},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, class := range classes {
+				if got, exp := errors.Is(test.err, class), class == test.expClass; got != exp {
+					t.Errorf("unexpected errors.Is(err, %q), exp=%t got=%t", class, exp, got)
+				}
+			}
+			if !errors.Is(test.err, underlying) {
+				t.Errorf("expected error to wrap the underlying error")
+			}
+		})
+	}
+}
+
+func TestClassifiedErrorMessage(t *testing.T) {
+	if msg := NewRateLimitedError(errors.New("too many requests")).Error(); msg != "too many requests" {
+		t.Errorf("expected the message of the underlying error, got %q", msg)
+	}
+}
+
+func TestClassifiedErrorsNil(t *testing.T) {
+	for _, fn := range []func(error) error{NewRateLimitedError, NewAuthFailedError, NewTransientError, NewPermanentError} {
+		if err := fn(nil); err != nil {
+			t.Errorf("expected classifying a nil error to return nil, got %v", err)
+		}
+	}
+}
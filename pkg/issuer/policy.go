@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"crypto/x509"
+	"sync"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// TemplatePolicy is an in-process hook which can inspect and modify the
+// template of a certificate before it is signed by the CA or SelfSigned
+// issuers, for example to remove requested extensions or to force key usages
+// regardless of the CSR.
+type TemplatePolicy interface {
+	// MutateTemplate is called with the template generated from the given
+	// CertificateRequest, after the issuer has applied its own
+	// configuration to it. The template may be modified in place, but its
+	// public key must not be changed. If an error is returned, the
+	// CertificateRequest is failed and not signed.
+	MutateTemplate(ctx context.Context, cr *v1.CertificateRequest, issuer v1.GenericIssuer, template *x509.Certificate) error
+}
+
+// TemplatePolicyFunc is a function which implements TemplatePolicy.
+type TemplatePolicyFunc func(ctx context.Context, cr *v1.CertificateRequest, issuer v1.GenericIssuer, template *x509.Certificate) error
+
+// MutateTemplate calls f.
+func (f TemplatePolicyFunc) MutateTemplate(ctx context.Context, cr *v1.CertificateRequest, issuer v1.GenericIssuer, template *x509.Certificate) error {
+	return f(ctx, cr, issuer, template)
+}
+
+// NoopTemplatePolicy is the default TemplatePolicy, which leaves templates
+// unchanged.
+type NoopTemplatePolicy struct{}
+
+// MutateTemplate does nothing.
+func (NoopTemplatePolicy) MutateTemplate(context.Context, *v1.CertificateRequest, v1.GenericIssuer, *x509.Certificate) error {
+	return nil
+}
+
+var (
+	templatePolicy     TemplatePolicy = NoopTemplatePolicy{}
+	templatePolicyLock sync.RWMutex
+)
+
+// RegisterTemplatePolicy sets the TemplatePolicy used by the CA and
+// SelfSigned issuers. It is intended to be called from an init function of a
+// custom build of the controller, as the policy is read when the issuers'
+// controllers are constructed.
+func RegisterTemplatePolicy(p TemplatePolicy) {
+	templatePolicyLock.Lock()
+	defer templatePolicyLock.Unlock()
+	if p == nil {
+		p = NoopTemplatePolicy{}
+	}
+	templatePolicy = p
+}
+
+// RegisteredTemplatePolicy returns the TemplatePolicy set with
+// RegisterTemplatePolicy, or a NoopTemplatePolicy if none has been set.
+func RegisteredTemplatePolicy() TemplatePolicy {
+	templatePolicyLock.RLock()
+	defer templatePolicyLock.RUnlock()
+	return templatePolicy
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestRegisterTemplatePolicy(t *testing.T) {
+	defer RegisterTemplatePolicy(nil)
+
+	if _, ok := RegisteredTemplatePolicy().(NoopTemplatePolicy); !ok {
+		t.Fatalf("expected the default policy to be a no-op, got %T", RegisteredTemplatePolicy())
+	}
+
+	called := false
+	RegisterTemplatePolicy(TemplatePolicyFunc(func(context.Context, *v1.CertificateRequest, v1.GenericIssuer, *x509.Certificate) error {
+		called = true
+		return nil
+	}))
+	if err := RegisteredTemplatePolicy().MutateTemplate(context.Background(), nil, nil, &x509.Certificate{}); err != nil || !called {
+		t.Errorf("expected the registered policy to be called, got called=%t err=%v", called, err)
+	}
+
+	RegisterTemplatePolicy(nil)
+	if _, ok := RegisteredTemplatePolicy().(NoopTemplatePolicy); !ok {
+		t.Errorf("expected registering a nil policy to restore the no-op policy, got %T", RegisteredTemplatePolicy())
+	}
+}
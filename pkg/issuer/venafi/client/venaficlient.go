@@ -17,7 +17,10 @@ limitations under the License.
 package client
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/http"
 	"time"
 
 	vcert "github.com/Venafi/vcert/v4"
@@ -25,10 +28,13 @@ import (
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
 	"github.com/go-logr/logr"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/transport"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 const (
@@ -40,7 +46,7 @@ const (
 )
 
 type VenafiClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
-	issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger) (Interface, error)
+	issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error)
 
 // Interface implements a Venafi client
 type Interface interface {
@@ -75,12 +81,17 @@ type connector interface {
 
 // New constructs a Venafi client Interface. Errors may be network errors and
 // should be considered for retrying.
-func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger) (Interface, error) {
+func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error) {
 	cfg, err := configForIssuer(issuer, secretsLister, namespace)
 	if err != nil {
 		return nil, err
 	}
 
+	cfg.Client, err = httpClientForConfig(cfg, issuer.GetSpec().TrustBundle, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
 	vcertClient, err := vcert.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Venafi client: %s", err.Error())
@@ -110,7 +121,10 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 		username := string(tppSecret.Data[tppUsernameKey])
 		password := string(tppSecret.Data[tppPasswordKey])
 		accessToken := string(tppSecret.Data[tppAccessTokenKey])
-		caBundle := string(tpp.CABundle)
+		caBundle, err := caBundleForTPP(tpp, secretsLister, namespace)
+		if err != nil {
+			return nil, err
+		}
 
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeTPP,
@@ -118,7 +132,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			Zone:          venCfg.Zone,
 			// always enable verbose logging for now
 			LogVerbose:      true,
-			ConnectionTrust: caBundle,
+			ConnectionTrust: string(caBundle),
 			Credentials: &endpoint.Authentication{
 				User:        username,
 				Password:    password,
@@ -154,6 +168,32 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 	return nil, fmt.Errorf("neither Venafi Cloud or TPP configuration found")
 }
 
+// caBundleForTPP returns the PEM encoded CA bundle used to verify connections
+// to the TPP instance, read either inline from the issuer or from the
+// referenced Secret. An empty bundle means the system roots are used.
+func caBundleForTPP(tpp *cmapi.VenafiTPP, secretsLister corelisters.SecretLister, namespace string) ([]byte, error) {
+	ref := tpp.CABundleSecretRef
+	if ref == nil {
+		return tpp.CABundle, nil
+	}
+
+	caSecret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cmmeta.TLSCAKey
+	if ref.Key != "" {
+		key = ref.Key
+	}
+	caBundle, ok := caSecret.Data[key]
+	if !ok || len(caBundle) == 0 {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, namespace, ref.Name)
+	}
+
+	return caBundle, nil
+}
+
 func (v *Venafi) Ping() error {
 	return v.vcertClient.Ping()
 }
@@ -165,3 +205,37 @@ func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
 func (v *Venafi) SetClient(client endpoint.Connector) {
 	v.vcertClient = client
 }
+
+// httpClientForConfig returns the HTTP client passed to vcert through its
+// Client option, which sets the User-Agent of requests as vcert does not.
+// vcert uses this client in place of the one it would build for the config,
+// and so no longer applies its ConnectionTrust. The ConnectionTrust is
+// therefore trusted by the returned client along with the issuer's trust
+// bundle.
+func httpClientForConfig(cfg *vcert.Config, trustBundle []byte, userAgent string) (*http.Client, error) {
+	var pool *x509.CertPool
+	if cfg.ConnectionTrust != "" {
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.ConnectionTrust)) {
+			return nil, fmt.Errorf("failed to parse PEM trust bundle")
+		}
+	}
+	pool, err := pki.AddTrustBundle(pool, trustBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issuer trust bundle: %v", err)
+	}
+
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	if pool != nil {
+		httpTransport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	var rt http.RoundTripper = httpTransport
+	if userAgent != "" {
+		rt = transport.NewUserAgentRoundTripper(userAgent, rt)
+	}
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: rt,
+	}, nil
+}
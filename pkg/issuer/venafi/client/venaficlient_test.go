@@ -17,15 +17,28 @@ limitations under the License.
 package client
 
 import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	vcert "github.com/Venafi/vcert/v4"
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
 )
@@ -61,6 +74,7 @@ func generateSecretLister(s *corev1.Secret, err error) corelisters.SecretLister
 
 func TestConfigForIssuerT(t *testing.T) {
 	zone := "test-zone"
+	cloudZone := "test-application\\test-template"
 	username := "test-username"
 	password := "test-password"
 	accessToken := "KT2EEVTIjWM/37L78dqJAg=="
@@ -80,14 +94,14 @@ func TestConfigForIssuerT(t *testing.T) {
 
 	cloudIssuer := gen.IssuerFrom(baseIssuer,
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
-			Zone:  zone,
+			Zone:  cloudZone,
 			Cloud: &cmapi.VenafiCloud{},
 		}),
 	)
 
 	cloudWithKeyIssuer := gen.IssuerFrom(cloudIssuer,
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
-			Zone: zone,
+			Zone: cloudZone,
 			Cloud: &cmapi.VenafiCloud{
 				APITokenSecretRef: cmmeta.SecretKeySelector{
 					Key: customKey,
@@ -159,7 +173,7 @@ func TestConfigForIssuerT(t *testing.T) {
 				if key := cnf.Credentials.APIKey; key != apiKey {
 					t.Errorf("got unexpected API key: %s", key)
 				}
-				checkZone(t, zone, cnf)
+				checkZone(t, cloudZone, cnf)
 			},
 			expectedErr: false,
 		},
@@ -174,10 +188,56 @@ func TestConfigForIssuerT(t *testing.T) {
 				if key := cnf.Credentials.APIKey; key != apiKey {
 					t.Errorf("got unexpected API key: %s", key)
 				}
+				checkZone(t, cloudZone, cnf)
+			},
+			expectedErr: false,
+		},
+		"if TPP with a CA bundle secret ref, should use the bundle at the default key": {
+			iss: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+					Zone: zone,
+					TPP: &cmapi.VenafiTPP{
+						CABundleSecretRef: &cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "tpp-ca"},
+						},
+					},
+				}),
+			),
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppAccessTokenKey: []byte(accessToken),
+					cmmeta.TLSCAKey:   []byte("test-ca-bundle"),
+				},
+			}, nil),
+			CheckFn: func(t *testing.T, cnf *vcert.Config) {
+				if trust := cnf.ConnectionTrust; trust != "test-ca-bundle" {
+					t.Errorf("got unexpected connection trust: %s", trust)
+				}
 				checkZone(t, zone, cnf)
 			},
 			expectedErr: false,
 		},
+		"if TPP with a CA bundle secret ref but the key is missing, should error": {
+			iss: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+					Zone: zone,
+					TPP: &cmapi.VenafiTPP{
+						CABundleSecretRef: &cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "tpp-ca"},
+							Key:                  customKey,
+						},
+					},
+				}),
+			),
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppAccessTokenKey: []byte(accessToken),
+					cmmeta.TLSCAKey:   []byte("test-ca-bundle"),
+				},
+			}, nil),
+			CheckFn:     checkNoConfigReturned,
+			expectedErr: true,
+		},
 		"if TPP and Cloud, should chose TPP": {
 			iss: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
@@ -234,3 +294,112 @@ func (c *testConfigForIssuerT) runTest(t *testing.T) {
 		c.CheckFn(t, resp)
 	}
 }
+
+func TestNewTPPVerifiesTLSWithCustomCA(t *testing.T) {
+	var gotUserAgent atomic.Value
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent.Store(r.UserAgent())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// A CA which did not issue the server's certificate.
+	otherCA := generateSelfSignedCA(t)
+
+	tests := map[string]struct {
+		caBundle       []byte
+		caSecretData   []byte
+		useSecretRef   bool
+		expectPingFail bool
+	}{
+		"inline CA bundle trusts the server": {
+			caBundle: serverCA,
+		},
+		"CA bundle from a secret trusts the server": {
+			caSecretData: serverCA,
+			useSecretRef: true,
+		},
+		"CA bundle from a secret for a different CA does not trust the server": {
+			caSecretData:   otherCA,
+			useSecretRef:   true,
+			expectPingFail: true,
+		},
+		"no CA bundle falls back to system roots which do not trust the server": {
+			expectPingFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpp := &cmapi.VenafiTPP{
+				URL:            server.URL + "/vedsdk",
+				CredentialsRef: cmmeta.LocalObjectReference{Name: "tpp-credentials"},
+				CABundle:       test.caBundle,
+			}
+			if test.useSecretRef {
+				tpp.CABundleSecretRef = &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "tpp-ca"},
+				}
+			}
+			iss := gen.Issuer("venafi-tpp",
+				gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone", TPP: tpp}),
+			)
+
+			secrets := map[string]*corev1.Secret{
+				"tpp-credentials": {Data: map[string][]byte{tppAccessTokenKey: []byte("test-token")}},
+				"tpp-ca":          {Data: map[string][]byte{cmmeta.TLSCAKey: test.caSecretData}},
+			}
+			secretsLister := &testlisters.FakeSecretLister{
+				SecretsFn: func(string) corelisters.SecretNamespaceLister {
+					return &testlisters.FakeSecretNamespaceLister{
+						GetFn: func(name string) (*corev1.Secret, error) {
+							return secrets[name], nil
+						},
+					}
+				},
+			}
+
+			c, err := New("test-namespace", secretsLister, iss, metrics.New(logr.Discard(), clock.RealClock{}), logr.Discard(), "cert-manager-test")
+			if err != nil {
+				t.Fatalf("unexpected error constructing client: %v", err)
+			}
+
+			err = c.Ping()
+			if test.expectPingFail && err == nil {
+				t.Errorf("expected TLS verification to fail but it did not")
+			}
+			if !test.expectPingFail && err != nil {
+				t.Errorf("expected TLS verification to succeed, but got: %v", err)
+			}
+			if !test.expectPingFail && gotUserAgent.Load() != "cert-manager-test" {
+				t.Errorf("unexpected User-Agent: %v", gotUserAgent.Load())
+			}
+		})
+	}
+}
+
+func generateSelfSignedCA(t *testing.T) []byte {
+	t.Helper()
+
+	sk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "other-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
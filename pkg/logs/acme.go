@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Keys used to correlate the log lines of an ACME Order with those of the
+// Challenges created for it, so that the lifecycle of a single challenge can
+// be followed from the order through to the solver.
+const (
+	OrderNameKey     = "order_name"
+	ChallengeNameKey = "challenge_name"
+	DNSNameKey       = "dns_name"
+	IssuerNameKey    = "issuer_name"
+	IssuerKindKey    = "issuer_kind"
+)
+
+// WithOrder returns a logger annotated with the correlation keys of the given
+// ACME Order.
+func WithOrder(l logr.Logger, o *cmacme.Order) logr.Logger {
+	return withIssuerRef(l, o.Spec.IssuerRef).WithValues(
+		OrderNameKey, o.Name,
+	)
+}
+
+// WithChallenge returns a logger annotated with the correlation keys of the
+// given ACME Challenge. The name of the owning Order is included if the
+// Challenge has a controller reference to one.
+// The challenge token and key are deliberately never logged.
+func WithChallenge(l logr.Logger, ch *cmacme.Challenge) logr.Logger {
+	l = withIssuerRef(l, ch.Spec.IssuerRef)
+	if ref := metav1.GetControllerOf(ch); ref != nil && ref.Kind == cmacme.OrderKind {
+		l = l.WithValues(OrderNameKey, ref.Name)
+	}
+	return l.WithValues(
+		ChallengeNameKey, ch.Name,
+		DNSNameKey, ch.Spec.DNSName,
+	)
+}
+
+func withIssuerRef(l logr.Logger, ref cmmeta.ObjectReference) logr.Logger {
+	return l.WithValues(
+		IssuerNameKey, ref.Name,
+		IssuerKindKey, ref.Kind,
+	)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// recordingSink is a logr.LogSink which records the key/value pairs of every
+// log entry written to it.
+type recordingSink struct {
+	values  []interface{}
+	entries *[]map[string]interface{}
+}
+
+func (s *recordingSink) Init(logr.RuntimeInfo)  {}
+func (s *recordingSink) Enabled(level int) bool { return true }
+
+func (s *recordingSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.record(keysAndValues)
+}
+
+func (s *recordingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.record(keysAndValues)
+}
+
+func (s *recordingSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	values := append(append([]interface{}{}, s.values...), keysAndValues...)
+	return &recordingSink{values: values, entries: s.entries}
+}
+
+func (s *recordingSink) WithName(name string) logr.LogSink {
+	return s
+}
+
+func (s *recordingSink) record(keysAndValues []interface{}) {
+	entry := make(map[string]interface{})
+	all := append(append([]interface{}{}, s.values...), keysAndValues...)
+	for i := 0; i+1 < len(all); i += 2 {
+		entry[all[i].(string)] = all[i+1]
+	}
+	*s.entries = append(*s.entries, entry)
+}
+
+func newRecordingLogger() (logr.Logger, *[]map[string]interface{}) {
+	entries := &[]map[string]interface{}{}
+	return logr.New(&recordingSink{entries: entries}), entries
+}
+
+var testIssuerRef = cmmeta.ObjectReference{
+	Name: "letsencrypt",
+	Kind: "ClusterIssuer",
+}
+
+func TestWithOrder(t *testing.T) {
+	log, entries := newRecordingLogger()
+
+	order := &cmacme.Order{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-order", Namespace: "default"},
+		Spec:       cmacme.OrderSpec{IssuerRef: testIssuerRef},
+	}
+	WithOrder(log, order).Info("test")
+
+	expected := []map[string]interface{}{{
+		OrderNameKey:  "test-order",
+		IssuerNameKey: "letsencrypt",
+		IssuerKindKey: "ClusterIssuer",
+	}}
+	if !reflect.DeepEqual(expected, *entries) {
+		t.Errorf("unexpected log entries, exp=%v got=%v", expected, *entries)
+	}
+}
+
+func TestWithChallenge(t *testing.T) {
+	isController := true
+	tests := map[string]struct {
+		ownerReferences []metav1.OwnerReference
+		expected        map[string]interface{}
+	}{
+		"challenge owned by an order includes the order name": {
+			ownerReferences: []metav1.OwnerReference{{
+				APIVersion: cmacme.SchemeGroupVersion.String(),
+				Kind:       cmacme.OrderKind,
+				Name:       "test-order",
+				Controller: &isController,
+			}},
+			expected: map[string]interface{}{
+				OrderNameKey:     "test-order",
+				ChallengeNameKey: "test-challenge",
+				DNSNameKey:       "example.com",
+				IssuerNameKey:    "letsencrypt",
+				IssuerKindKey:    "ClusterIssuer",
+			},
+		},
+		"challenge without an owner omits the order name": {
+			expected: map[string]interface{}{
+				ChallengeNameKey: "test-challenge",
+				DNSNameKey:       "example.com",
+				IssuerNameKey:    "letsencrypt",
+				IssuerKindKey:    "ClusterIssuer",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			log, entries := newRecordingLogger()
+
+			ch := &cmacme.Challenge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-challenge",
+					Namespace:       "default",
+					OwnerReferences: test.ownerReferences,
+				},
+				Spec: cmacme.ChallengeSpec{
+					DNSName:   "example.com",
+					IssuerRef: testIssuerRef,
+					Token:     "secret-token",
+					Key:       "secret-key",
+				},
+			}
+			WithChallenge(log, ch).Info("test")
+
+			if len(*entries) != 1 {
+				t.Fatalf("expected exactly one log entry, got %d", len(*entries))
+			}
+			entry := (*entries)[0]
+			if !reflect.DeepEqual(test.expected, entry) {
+				t.Errorf("unexpected log entry, exp=%v got=%v", test.expected, entry)
+			}
+			for _, v := range entry {
+				if v == ch.Spec.Token || v == ch.Spec.Key {
+					t.Errorf("log entry must not contain the challenge token or key: %v", entry)
+				}
+			}
+		})
+	}
+}
@@ -20,6 +20,13 @@ import (
 	"time"
 )
 
+// IssuerLabels returns true if the ACME client request metrics have the
+// issuer_name, issuer_namespace and issuer_kind labels, which must then be
+// given after the other labels.
+func (m *Metrics) IssuerLabels() bool {
+	return m.issuerLabels
+}
+
 // ObserveACMERequestDuration increases bucket counters for that ACME client duration.
 func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...string) {
 	m.acmeClientRequestDurationSeconds.WithLabelValues(labels...).Observe(duration.Seconds())
@@ -29,3 +36,9 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// IncrementACMERateLimitedCount increases the acme client rate limited
+// request counter.
+func (m *Metrics) IncrementACMERateLimitedCount(labels ...string) {
+	m.acmeClientRateLimitedCount.WithLabelValues(labels...).Inc()
+}
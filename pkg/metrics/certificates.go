@@ -18,15 +18,89 @@ package metrics
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// certificateIssuerLabelNames are the names of the labels identifying the
+// issuer of a Certificate, which are added to the certificate metrics by the
+// WithIssuerLabels option.
+var certificateIssuerLabelNames = []string{"issuer_name", "issuer_kind", "issuer_group"}
+
+// certificateIssuer identifies the issuer referenced by a Certificate. The
+// kind and group are defaulted, so that a Certificate with an empty kind or
+// group has the same labels as one naming the default explicitly.
+type certificateIssuer struct {
+	name, kind, group string
+}
+
+func issuerOfCertificate(crt *cmapi.Certificate) certificateIssuer {
+	issuer := certificateIssuer{
+		name:  crt.Spec.IssuerRef.Name,
+		kind:  crt.Spec.IssuerRef.Kind,
+		group: crt.Spec.IssuerRef.Group,
+	}
+	if issuer.kind == "" {
+		issuer.kind = cmapi.IssuerKind
+	}
+	if issuer.group == "" {
+		issuer.group = certmanager.GroupName
+	}
+	return issuer
+}
+
+// labels returns the given certificate metric labels with the labels of the
+// issuer added, unless the issuer is nil because the metrics do not have
+// issuer labels.
+func (i *certificateIssuer) labels(labels prometheus.Labels) prometheus.Labels {
+	if i != nil {
+		labels["issuer_name"] = i.name
+		labels["issuer_kind"] = i.kind
+		labels["issuer_group"] = i.group
+	}
+	return labels
+}
+
+// labelValues is like labels, for a list of label values.
+func (i *certificateIssuer) labelValues(values ...string) []string {
+	if i != nil {
+		values = append(values, i.name, i.kind, i.group)
+	}
+	return values
+}
+
+// observeCertificateIssuer returns the issuer to label the metrics of the
+// given Certificate with, or nil if the metrics do not have issuer labels.
+// If the Certificate's metrics were exported with a different issuer, they
+// are removed so that a Certificate only ever has one series per metric.
+func (m *Metrics) observeCertificateIssuer(key string, crt *cmapi.Certificate) *certificateIssuer {
+	if !m.issuerLabels {
+		return nil
+	}
+
+	issuer := issuerOfCertificate(crt)
+
+	m.certificateIssuersLock.Lock()
+	previous, ok := m.certificateIssuers[key]
+	m.certificateIssuers[key] = issuer
+	m.certificateIssuersLock.Unlock()
+
+	if ok && previous != issuer {
+		m.removeCertificate(crt.Name, crt.Namespace, &previous)
+	}
+	return &issuer
+}
+
 // UpdateCertificate will update the given Certificate's metrics for its expiry, renewal, and status
 // condition.
 func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate) {
@@ -37,52 +111,72 @@ func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate)
 		return
 	}
 
-	m.updateCertificateStatus(key, crt)
-	m.updateCertificateExpiry(ctx, key, crt)
-	m.updateCertificateRenewalTime(crt)
+	issuer := m.observeCertificateIssuer(key, crt)
+	m.updateCertificateStatus(crt, issuer)
+	m.updateCertificateExpiry(ctx, crt, issuer)
+	m.updateCertificateRenewalTime(crt, issuer)
+	m.updateCertificateExpirationImminent(crt, issuer)
 }
 
 // updateCertificateExpiry updates the expiry time of a certificate
-func (m *Metrics) updateCertificateExpiry(ctx context.Context, key string, crt *cmapi.Certificate) {
+func (m *Metrics) updateCertificateExpiry(ctx context.Context, crt *cmapi.Certificate, issuer *certificateIssuer) {
 	expiryTime := 0.0
 
 	if crt.Status.NotAfter != nil {
 		expiryTime = float64(crt.Status.NotAfter.Unix())
 	}
 
-	m.certificateExpiryTimeSeconds.With(prometheus.Labels{
+	m.certificateExpiryTimeSeconds.With(issuer.labels(prometheus.Labels{
 		"name":      crt.Name,
-		"namespace": crt.Namespace}).Set(expiryTime)
+		"namespace": crt.Namespace})).Set(expiryTime)
 }
 
 // updateCertificateRenewalTime updates the renew before duration of a certificate
-func (m *Metrics) updateCertificateRenewalTime(crt *cmapi.Certificate) {
+func (m *Metrics) updateCertificateRenewalTime(crt *cmapi.Certificate, issuer *certificateIssuer) {
 	renewalTime := 0.0
 
 	if crt.Status.RenewalTime != nil {
 		renewalTime = float64(crt.Status.RenewalTime.Unix())
 	}
 
-	m.certificateRenewalTimeSeconds.With(prometheus.Labels{
+	m.certificateRenewalTimeSeconds.With(issuer.labels(prometheus.Labels{
 		"name":      crt.Name,
-		"namespace": crt.Namespace}).Set(renewalTime)
+		"namespace": crt.Namespace})).Set(renewalTime)
 
 }
 
+// updateCertificateExpirationImminent sets whether the certificate is about to
+// expire while its renewal is failing, as reported by its ExpirationImminent
+// condition.
+func (m *Metrics) updateCertificateExpirationImminent(crt *cmapi.Certificate, issuer *certificateIssuer) {
+	imminent := 0.0
+
+	for _, c := range crt.Status.Conditions {
+		if c.Type == cmapi.CertificateConditionExpirationImminent && c.Status == cmmeta.ConditionTrue {
+			imminent = 1.0
+			break
+		}
+	}
+
+	m.certificateExpirationImminent.With(issuer.labels(prometheus.Labels{
+		"name":      crt.Name,
+		"namespace": crt.Namespace})).Set(imminent)
+}
+
 // updateCertificateStatus will update the metric for that Certificate
-func (m *Metrics) updateCertificateStatus(key string, crt *cmapi.Certificate) {
+func (m *Metrics) updateCertificateStatus(crt *cmapi.Certificate, issuer *certificateIssuer) {
 	for _, c := range crt.Status.Conditions {
 		if c.Type == cmapi.CertificateConditionReady {
-			m.updateCertificateReadyStatus(crt, c.Status)
+			m.updateCertificateReadyStatus(crt, issuer, c.Status)
 			return
 		}
 	}
 
 	// If no status condition set yet, set to Unknown
-	m.updateCertificateReadyStatus(crt, cmmeta.ConditionUnknown)
+	m.updateCertificateReadyStatus(crt, issuer, cmmeta.ConditionUnknown)
 }
 
-func (m *Metrics) updateCertificateReadyStatus(crt *cmapi.Certificate, current cmmeta.ConditionStatus) {
+func (m *Metrics) updateCertificateReadyStatus(crt *cmapi.Certificate, issuer *certificateIssuer, current cmmeta.ConditionStatus) {
 	for _, condition := range readyConditionStatuses {
 		value := 0.0
 
@@ -90,12 +184,68 @@ func (m *Metrics) updateCertificateReadyStatus(crt *cmapi.Certificate, current c
 			value = 1.0
 		}
 
-		m.certificateReadyStatus.With(prometheus.Labels{
+		m.certificateReadyStatus.With(issuer.labels(prometheus.Labels{
 			"name":      crt.Name,
 			"namespace": crt.Namespace,
 			"condition": string(condition),
-		}).Set(value)
+		})).Set(value)
+	}
+}
+
+// UpdateCertificateChain will update the given Certificate's metrics for the
+// key size and algorithm of its leaf certificate and the length of its
+// certificate chain. The chain is expected to start with the leaf certificate.
+// If the chain is empty, the metrics are removed.
+func (m *Metrics) UpdateCertificateChain(crt *cmapi.Certificate, chain []*x509.Certificate) {
+	key, err := cache.MetaNamespaceKeyFunc(crt)
+	if err != nil {
+		log := logf.WithRelatedResource(m.log, crt)
+		log.Error(err, "failed to get key from certificate object")
+		return
+	}
+
+	issuer := m.observeCertificateIssuer(key, crt)
+	m.removeCertificateChain(crt.Name, crt.Namespace, issuer)
+	if len(chain) == 0 {
+		return
+	}
+
+	algorithm, size, ok := publicKeyAlgorithmAndSize(chain[0])
+	if ok {
+		m.certificateKeySize.With(issuer.labels(prometheus.Labels{
+			"name":          crt.Name,
+			"namespace":     crt.Namespace,
+			"key_algorithm": string(algorithm),
+		})).Set(float64(size))
 	}
+
+	m.certificateChainLength.With(issuer.labels(prometheus.Labels{
+		"name":      crt.Name,
+		"namespace": crt.Namespace,
+	})).Set(float64(len(chain)))
+}
+
+// publicKeyAlgorithmAndSize returns the algorithm and size in bits of the
+// public key of the given certificate. ok is false if the key type is not
+// supported by cert-manager.
+func publicKeyAlgorithmAndSize(cert *x509.Certificate) (algorithm cmapi.PrivateKeyAlgorithm, size int, ok bool) {
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return cmapi.RSAKeyAlgorithm, pub.N.BitLen(), true
+	case *ecdsa.PublicKey:
+		return cmapi.ECDSAKeyAlgorithm, pub.Curve.Params().BitSize, true
+	case ed25519.PublicKey:
+		return cmapi.Ed25519KeyAlgorithm, ed25519.PublicKeySize * 8, true
+	default:
+		return "", 0, false
+	}
+}
+
+func (m *Metrics) removeCertificateChain(name, namespace string, issuer *certificateIssuer) {
+	for _, algorithm := range keyAlgorithms {
+		m.certificateKeySize.DeleteLabelValues(issuer.labelValues(name, namespace, string(algorithm))...)
+	}
+	m.certificateChainLength.DeleteLabelValues(issuer.labelValues(name, namespace)...)
 }
 
 // RemoveCertificate will delete the Certificate metrics from continuing to be
@@ -107,9 +257,28 @@ func (m *Metrics) RemoveCertificate(key string) {
 		return
 	}
 
-	m.certificateExpiryTimeSeconds.DeleteLabelValues(name, namespace)
-	m.certificateRenewalTimeSeconds.DeleteLabelValues(name, namespace)
+	if !m.issuerLabels {
+		m.removeCertificate(name, namespace, nil)
+		return
+	}
+
+	m.certificateIssuersLock.Lock()
+	issuer, ok := m.certificateIssuers[key]
+	delete(m.certificateIssuers, key)
+	m.certificateIssuersLock.Unlock()
+	if ok {
+		m.removeCertificate(name, namespace, &issuer)
+	}
+}
+
+// removeCertificate deletes the metrics of the Certificate with the given
+// name and namespace that were exported with the given issuer.
+func (m *Metrics) removeCertificate(name, namespace string, issuer *certificateIssuer) {
+	m.certificateExpiryTimeSeconds.DeleteLabelValues(issuer.labelValues(name, namespace)...)
+	m.certificateRenewalTimeSeconds.DeleteLabelValues(issuer.labelValues(name, namespace)...)
+	m.certificateExpirationImminent.DeleteLabelValues(issuer.labelValues(name, namespace)...)
 	for _, condition := range readyConditionStatuses {
-		m.certificateReadyStatus.DeleteLabelValues(name, namespace, string(condition))
+		m.certificateReadyStatus.DeleteLabelValues(issuer.labelValues(name, namespace, string(condition))...)
 	}
+	m.removeCertificateChain(name, namespace, issuer)
 }
@@ -18,6 +18,11 @@ package metrics
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
@@ -42,6 +47,11 @@ const renewalTimeMetadata = `
 	# TYPE certmanager_certificate_renewal_timestamp_seconds gauge
 `
 
+const expirationImminentMetadata = `
+	# HELP certmanager_certificate_expiration_imminent Whether the certificate is close to its expiry and the last attempt to renew it failed.
+	# TYPE certmanager_certificate_expiration_imminent gauge
+`
+
 const readyMetadata = `
   # HELP certmanager_certificate_ready_status The ready status of the certificate.
   # TYPE certmanager_certificate_ready_status gauge
@@ -51,6 +61,7 @@ func TestCertificateMetrics(t *testing.T) {
 	type testT struct {
 		crt                                                *cmapi.Certificate
 		expectedExpiry, expectedReady, expectedRenewalTime string
+		expectedExpirationImminent                         string
 	}
 	tests := map[string]testT{
 		"certificate with expiry and ready status": {
@@ -74,6 +85,9 @@ func TestCertificateMetrics(t *testing.T) {
 `,
 			expectedRenewalTime: `
 		certmanager_certificate_renewal_timestamp_seconds{name="test-certificate",namespace="test-ns"} 0
+`,
+			expectedExpirationImminent: `
+		certmanager_certificate_expiration_imminent{name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 
@@ -91,6 +105,9 @@ func TestCertificateMetrics(t *testing.T) {
 `,
 			expectedRenewalTime: `
 		certmanager_certificate_renewal_timestamp_seconds{name="test-certificate",namespace="test-ns"} 0
+`,
+			expectedExpirationImminent: `
+		certmanager_certificate_expiration_imminent{name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 
@@ -115,6 +132,9 @@ func TestCertificateMetrics(t *testing.T) {
 `,
 			expectedRenewalTime: `
 		certmanager_certificate_renewal_timestamp_seconds{name="test-certificate",namespace="test-ns"} 0
+`,
+			expectedExpirationImminent: `
+		certmanager_certificate_expiration_imminent{name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 		"certificate with expiry and status Unknown should give an expiry and Unknown status": {
@@ -138,6 +158,9 @@ func TestCertificateMetrics(t *testing.T) {
 `,
 			expectedRenewalTime: `
 		certmanager_certificate_renewal_timestamp_seconds{name="test-certificate",namespace="test-ns"} 0
+`,
+			expectedExpirationImminent: `
+		certmanager_certificate_expiration_imminent{name="test-certificate",namespace="test-ns"} 0
 `,
 		},
 		"certificate with expiry and ready status and renew before": {
@@ -164,6 +187,39 @@ func TestCertificateMetrics(t *testing.T) {
 `,
 			expectedRenewalTime: `
 		certmanager_certificate_renewal_timestamp_seconds{name="test-certificate",namespace="test-ns"} 2.208988804e+09
+`,
+			expectedExpirationImminent: `
+		certmanager_certificate_expiration_imminent{name="test-certificate",namespace="test-ns"} 0
+`,
+		},
+		"certificate about to expire with failing renewal": {
+			crt: gen.Certificate("test-certificate",
+				gen.SetCertificateNamespace("test-ns"),
+				gen.SetCertificateNotAfter(metav1.Time{
+					Time: time.Unix(100, 0),
+				}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionReady,
+					Status: cmmeta.ConditionTrue,
+				}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionExpirationImminent,
+					Status: cmmeta.ConditionTrue,
+				}),
+			),
+			expectedExpiry: `
+	certmanager_certificate_expiration_timestamp_seconds{name="test-certificate",namespace="test-ns"} 100
+`,
+			expectedReady: `
+        certmanager_certificate_ready_status{condition="False",name="test-certificate",namespace="test-ns"} 0
+        certmanager_certificate_ready_status{condition="True",name="test-certificate",namespace="test-ns"} 1
+        certmanager_certificate_ready_status{condition="Unknown",name="test-certificate",namespace="test-ns"} 0
+`,
+			expectedRenewalTime: `
+		certmanager_certificate_renewal_timestamp_seconds{name="test-certificate",namespace="test-ns"} 0
+`,
+			expectedExpirationImminent: `
+		certmanager_certificate_expiration_imminent{name="test-certificate",namespace="test-ns"} 1
 `,
 		},
 	}
@@ -192,6 +248,13 @@ func TestCertificateMetrics(t *testing.T) {
 			); err != nil {
 				t.Errorf("unexpected collecting result:\n%s", err)
 			}
+
+			if err := testutil.CollectAndCompare(m.certificateExpirationImminent,
+				strings.NewReader(expirationImminentMetadata+test.expectedExpirationImminent),
+				"certmanager_certificate_expiration_imminent",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
 		})
 	}
 }
@@ -324,3 +387,173 @@ func TestCertificateCache(t *testing.T) {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
+
+const keySizeMetadata = `
+	# HELP certmanager_certificate_key_size The size in bits of the public key of the issued certificate.
+	# TYPE certmanager_certificate_key_size gauge
+`
+
+const chainLengthMetadata = `
+	# HELP certmanager_certificate_chain_length The number of certificates in the issued certificate chain, including the leaf certificate.
+	# TYPE certmanager_certificate_chain_length gauge
+`
+
+func TestCertificateChainMetrics(t *testing.T) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caCert := signCertificate(t, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test-leaf"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafCert := signCertificate(t, leafTmpl, caCert, &leafKey.PublicKey, caKey)
+
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	crt := gen.Certificate("test-certificate", gen.SetCertificateNamespace("test-ns"))
+
+	m.UpdateCertificateChain(crt, []*x509.Certificate{leafCert, caCert})
+
+	if err := testutil.CollectAndCompare(m.certificateKeySize,
+		strings.NewReader(keySizeMetadata+`
+	certmanager_certificate_key_size{key_algorithm="RSA",name="test-certificate",namespace="test-ns"} 2048
+`),
+		"certmanager_certificate_key_size",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateChainLength,
+		strings.NewReader(chainLengthMetadata+`
+	certmanager_certificate_chain_length{name="test-certificate",namespace="test-ns"} 2
+`),
+		"certmanager_certificate_chain_length",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Removing the Certificate should remove its chain metrics
+	m.RemoveCertificate("test-ns/test-certificate")
+	if err := testutil.CollectAndCompare(m.certificateKeySize,
+		strings.NewReader(keySizeMetadata),
+		"certmanager_certificate_key_size",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateChainLength,
+		strings.NewReader(chainLengthMetadata),
+		"certmanager_certificate_chain_length",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestCertificateIssuerLabels(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert := signCertificate(t, tmpl, tmpl, &key.PublicKey, key)
+
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{}, WithIssuerLabels())
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+		gen.SetCertificateNotAfter(metav1.Time{Time: time.Unix(100, 0)}),
+	)
+
+	m.UpdateCertificate(context.TODO(), crt)
+	m.UpdateCertificateChain(crt, []*x509.Certificate{cert})
+
+	// The kind and group of the issuerRef are defaulted.
+	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
+		strings.NewReader(expiryMetadata+`
+	certmanager_certificate_expiration_timestamp_seconds{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca-issuer",name="test-certificate",namespace="test-ns"} 100
+`),
+		"certmanager_certificate_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateReadyStatus,
+		strings.NewReader(readyMetadata+`
+	certmanager_certificate_ready_status{condition="False",issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca-issuer",name="test-certificate",namespace="test-ns"} 0
+	certmanager_certificate_ready_status{condition="True",issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca-issuer",name="test-certificate",namespace="test-ns"} 0
+	certmanager_certificate_ready_status{condition="Unknown",issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca-issuer",name="test-certificate",namespace="test-ns"} 1
+`),
+		"certmanager_certificate_ready_status",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateKeySize,
+		strings.NewReader(keySizeMetadata+`
+	certmanager_certificate_key_size{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="ca-issuer",key_algorithm="RSA",name="test-certificate",namespace="test-ns"} 2048
+`),
+		"certmanager_certificate_key_size",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Changing the issuerRef replaces the series rather than adding new ones.
+	crt.Spec.IssuerRef = cmmeta.ObjectReference{Name: "acme", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+	m.UpdateCertificate(context.TODO(), crt)
+	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
+		strings.NewReader(expiryMetadata+`
+	certmanager_certificate_expiration_timestamp_seconds{issuer_group="cert-manager.io",issuer_kind="ClusterIssuer",issuer_name="acme",name="test-certificate",namespace="test-ns"} 100
+`),
+		"certmanager_certificate_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateKeySize,
+		strings.NewReader(keySizeMetadata),
+		"certmanager_certificate_key_size",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.RemoveCertificate("test-ns/test-certificate")
+	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
+		strings.NewReader(expiryMetadata),
+		"certmanager_certificate_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateReadyStatus,
+		strings.NewReader(readyMetadata),
+		"certmanager_certificate_ready_status",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func signCertificate(t *testing.T, tmpl, parent *x509.Certificate, pub, priv interface{}) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
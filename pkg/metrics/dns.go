@@ -0,0 +1,23 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+// IncrementDNSProviderAPIErrorCount increases the counter of errors returned
+// by the API of the named DNS provider with the given error code.
+func (m *Metrics) IncrementDNSProviderAPIErrorCount(provider, code string) {
+	m.dnsProviderAPIErrorCount.WithLabelValues(provider, code).Inc()
+}
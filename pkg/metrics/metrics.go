@@ -19,8 +19,16 @@ limitations under the License.
 // certificate_expiration_timestamp_seconds{name, namespace}
 // certificate_renewal_timestamp_seconds{name, namespace}
 // certificate_ready_status{name, namespace, condition}
+// certificate_key_size{name, namespace, key_algorithm}
+// certificate_chain_length{name, namespace}
+// certificate_expiration_imminent{name, namespace}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// acme_client_rate_limited_count{"issuer_name", "issuer_namespace", "issuer_kind", "host"}
+// When the WithIssuerLabels option is given, the certificate metrics also have
+// the issuer_name, issuer_kind and issuer_group labels of the Certificate's
+// issuerRef, and the acme_client_request_* metrics also have the issuer_name,
+// issuer_namespace and issuer_kind labels of the issuer making the request.
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
 package metrics
@@ -28,6 +36,7 @@ package metrics
 import (
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -35,6 +44,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/utils/clock"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
@@ -52,22 +62,78 @@ type Metrics struct {
 	log      logr.Logger
 	registry *prometheus.Registry
 
+	// issuerLabels is true if the certificate and ACME client request
+	// metrics have labels identifying the issuer.
+	issuerLabels bool
+	// certificateIssuersLock protects certificateIssuers.
+	certificateIssuersLock sync.Mutex
+	// certificateIssuers is the issuer that the metrics of each Certificate
+	// were last exported with, by the Certificate's key, so that they can be
+	// removed when the Certificate or its issuerRef changes.
+	certificateIssuers map[string]certificateIssuer
+
 	clockTimeSeconds                   prometheus.CounterFunc
 	clockTimeSecondsGauge              prometheus.GaugeFunc
 	certificateExpiryTimeSeconds       *prometheus.GaugeVec
 	certificateRenewalTimeSeconds      *prometheus.GaugeVec
 	certificateReadyStatus             *prometheus.GaugeVec
+	certificateKeySize                 *prometheus.GaugeVec
+	certificateChainLength             *prometheus.GaugeVec
+	certificateExpirationImminent      *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
+	acmeClientRateLimitedCount         *prometheus.CounterVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
+	dnsProviderAPIErrorCount           *prometheus.CounterVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
 
+var keyAlgorithms = [...]cmapi.PrivateKeyAlgorithm{cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm, cmapi.Ed25519KeyAlgorithm}
+
+// Option configures the metrics exposed by a Metrics struct.
+type Option func(*options)
+
+type options struct {
+	issuerLabels bool
+}
+
+// WithIssuerLabels adds labels identifying the issuer to the certificate
+// metrics and the ACME client request metrics.
+// This is opt-in because it increases the number of series exposed: a
+// Certificate only has one issuer at a time, but every ACME issuer has its own
+// series of the ACME client request metrics.
+func WithIssuerLabels() Option {
+	return func(o *options) {
+		o.issuerLabels = true
+	}
+}
+
 // New creates a Metrics struct and populates it with prometheus metric types.
-func New(log logr.Logger, c clock.Clock) *Metrics {
+func New(log logr.Logger, c clock.Clock, opts ...Option) *Metrics {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// certificateLabels and acmeRequestLabels add the issuer labels, if
+	// enabled, to the labels of the certificate and ACME client request
+	// metrics.
+	certificateLabels := func(labels ...string) []string {
+		if o.issuerLabels {
+			labels = append(labels, certificateIssuerLabelNames...)
+		}
+		return labels
+	}
+	acmeRequestLabels := func(labels ...string) []string {
+		if o.issuerLabels {
+			labels = append(labels, "issuer_name", "issuer_namespace", "issuer_kind")
+		}
+		return labels
+	}
+
 	var (
 		// Deprecated in favour of clock_time_seconds_gauge.
 		clockTimeSeconds = prometheus.NewCounterFunc(
@@ -106,7 +172,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Name:      "certificate_expiration_timestamp_seconds",
 				Help:      "The date after which the certificate expires. Expressed as a Unix Epoch Time.",
 			},
-			[]string{"name", "namespace"},
+			certificateLabels("name", "namespace"),
 		)
 
 		certificateRenewalTimeSeconds = prometheus.NewGaugeVec(
@@ -115,7 +181,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Name:      "certificate_renewal_timestamp_seconds",
 				Help:      "The number of seconds before expiration time the certificate should renew.",
 			},
-			[]string{"name", "namespace"},
+			certificateLabels("name", "namespace"),
 		)
 
 		certificateReadyStatus = prometheus.NewGaugeVec(
@@ -124,7 +190,34 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Name:      "certificate_ready_status",
 				Help:      "The ready status of the certificate.",
 			},
-			[]string{"name", "namespace", "condition"},
+			certificateLabels("name", "namespace", "condition"),
+		)
+
+		certificateKeySize = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_key_size",
+				Help:      "The size in bits of the public key of the issued certificate.",
+			},
+			certificateLabels("name", "namespace", "key_algorithm"),
+		)
+
+		certificateChainLength = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_chain_length",
+				Help:      "The number of certificates in the issued certificate chain, including the leaf certificate.",
+			},
+			certificateLabels("name", "namespace"),
+		)
+
+		certificateExpirationImminent = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_expiration_imminent",
+				Help:      "Whether the certificate is close to its expiry and the last attempt to renew it failed.",
+			},
+			certificateLabels("name", "namespace"),
 		)
 
 		// acmeClientRequestCount is a Prometheus summary to collect the number of
@@ -136,7 +229,20 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Help:      "The number of requests made by the ACME client.",
 				Subsystem: "http",
 			},
-			[]string{"scheme", "host", "path", "method", "status"},
+			acmeRequestLabels("scheme", "host", "path", "method", "status"),
+		)
+
+		// acmeClientRateLimitedCount is a Prometheus counter to collect the
+		// number of requests made by the ACME client of each issuer that were
+		// rejected by the ACME server because of rate limiting.
+		acmeClientRateLimitedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_client_rate_limited_count",
+				Help:      "The number of requests made by the ACME client that were rate limited by the ACME server.",
+				Subsystem: "http",
+			},
+			[]string{"issuer_name", "issuer_namespace", "issuer_kind", "host"},
 		)
 
 		// acmeClientRequestDurationSeconds is a Prometheus summary to collect request
@@ -149,7 +255,7 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 				Subsystem:  "http",
 				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 			},
-			[]string{"scheme", "host", "path", "method", "status"},
+			acmeRequestLabels("scheme", "host", "path", "method", "status"),
 		)
 
 		// venafiClientRequestDurationSeconds is a Prometheus summary to
@@ -167,6 +273,18 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"api_call"},
 		)
 
+		// dnsProviderAPIErrorCount is a Prometheus counter to collect the
+		// number of errors returned by the API of each DNS provider, by the
+		// error code given in the provider's response.
+		dnsProviderAPIErrorCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "dns_provider_api_error_count",
+				Help:      "The number of errors returned by the API of a DNS provider used to solve ACME DNS01 challenges, by the error code in the provider's response.",
+			},
+			[]string{"provider", "code"},
+		)
+
 		controllerSyncCallCount = prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -191,14 +309,22 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		log:      log.WithName("metrics"),
 		registry: prometheus.NewRegistry(),
 
+		issuerLabels:       o.issuerLabels,
+		certificateIssuers: make(map[string]certificateIssuer),
+
 		clockTimeSeconds:                   clockTimeSeconds,
 		clockTimeSecondsGauge:              clockTimeSecondsGauge,
 		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
+		certificateKeySize:                 certificateKeySize,
+		certificateChainLength:             certificateChainLength,
+		certificateExpirationImminent:      certificateExpirationImminent,
 		acmeClientRequestCount:             acmeClientRequestCount,
+		acmeClientRateLimitedCount:         acmeClientRateLimitedCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
+		dnsProviderAPIErrorCount:           dnsProviderAPIErrorCount,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
 	}
@@ -213,9 +339,14 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
 	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
 	m.registry.MustRegister(m.certificateReadyStatus)
+	m.registry.MustRegister(m.certificateKeySize)
+	m.registry.MustRegister(m.certificateChainLength)
+	m.registry.MustRegister(m.certificateExpirationImminent)
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
+	m.registry.MustRegister(m.acmeClientRateLimitedCount)
+	m.registry.MustRegister(m.dnsProviderAPIErrorCount)
 	m.registry.MustRegister(m.controllerSyncCallCount)
 	m.registry.MustRegister(m.controllerSyncErrorCount)
 
@@ -16,7 +16,10 @@ limitations under the License.
 
 package errors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type invalidDataError struct{ error }
 
@@ -30,3 +33,52 @@ func IsInvalidData(err error) bool {
 	}
 	return true
 }
+
+// remediationHinter is implemented by errors which can suggest how the
+// problem that caused them may be resolved.
+type remediationHinter interface {
+	RemediationHint() string
+}
+
+type remediationHintError struct {
+	error
+	hint string
+}
+
+func (e *remediationHintError) Unwrap() error {
+	return e.error
+}
+
+func (e *remediationHintError) RemediationHint() string {
+	return e.hint
+}
+
+// NewWithRemediationHint wraps err with a hint, for users, on how the problem
+// that caused it may be resolved. The hint is not included in the error
+// message. If hint is empty, err is returned unchanged.
+func NewWithRemediationHint(err error, hint string) error {
+	if err == nil || len(hint) == 0 {
+		return err
+	}
+	return &remediationHintError{error: err, hint: hint}
+}
+
+// RemediationHint returns the remediation hint of the first error in err's
+// chain which has one, or an empty string.
+func RemediationHint(err error) string {
+	var hinter remediationHinter
+	if !errors.As(err, &hinter) {
+		return ""
+	}
+	return hinter.RemediationHint()
+}
+
+// WithRemediationHint appends the remediation hint of err, if it has one, to
+// the message, for use in Events and status conditions.
+func WithRemediationHint(message string, err error) string {
+	hint := RemediationHint(err)
+	if len(hint) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (hint: %s)", message, hint)
+}
@@ -19,7 +19,10 @@ package pki
 import (
 	"bytes"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -54,6 +57,10 @@ func URIsForCertificate(crt *v1.Certificate) ([]*url.URL, error) {
 		return nil, fmt.Errorf("failed to parse URIs: %s", err)
 	}
 
+	if id := SPIFFEIDForCertificateSpec(crt.Namespace, crt.Spec); id != nil {
+		uris = append(uris, id)
+	}
+
 	return uris, nil
 }
 
@@ -177,6 +184,8 @@ func BuildCertManagerKeyUsages(ku x509.KeyUsage, eku []x509.ExtKeyUsage) []v1.Ke
 // by issuers that utilise CSRs to obtain Certificates.
 // The CSR will not be signed, and should be passed to either EncodeCSR or
 // to the x509.CreateCertificateRequest function.
+// The common name is not added to the DNS names of the CSR; it is only
+// included as a SAN if it is also listed in the Certificate's dnsNames.
 func GenerateCSR(crt *v1.Certificate) (*x509.CertificateRequest, error) {
 	commonName := crt.Spec.CommonName
 	iPAddresses := IPAddressesForCertificate(crt)
@@ -278,7 +287,7 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 	ipAddresses := IPAddressesForCertificate(crt)
 	organization := OrganizationForCertificate(crt)
 	subject := SubjectForCertificate(crt)
-	uris, err := URLsFromStrings(crt.Spec.URIs)
+	uris, err := URIsForCertificate(crt)
 	if err != nil {
 		return nil, err
 	}
@@ -399,6 +408,20 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 	}, nil
 }
 
+// SetCAPathLen sets the path length constraint of the basic constraints
+// extension of a CA certificate template. It does nothing if the template is
+// not for a CA or if pathLen is nil, in which case the certificate is issued
+// without a path length constraint.
+// A pathLen of 0 is encoded explicitly by setting MaxPathLenZero, since
+// x509.CreateCertificate otherwise treats a MaxPathLen of 0 as unset.
+func SetCAPathLen(template *x509.Certificate, pathLen *int) {
+	if !template.IsCA || pathLen == nil {
+		return
+	}
+	template.MaxPathLen = *pathLen
+	template.MaxPathLenZero = *pathLen == 0
+}
+
 // SignCertificate returns a signed *x509.Certificate given a template
 // *x509.Certificate crt and an issuer.
 // publicKey is the public key of the signee, and signerKey is the private
@@ -406,6 +429,17 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 // It returns a PEM encoded copy of the Certificate as well as a *x509.Certificate
 // which can be used for reading the encoded values.
 func SignCertificate(template *x509.Certificate, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
+	if signer, ok := signerKey.(crypto.Signer); ok {
+		sigAlgo, err := signatureAlgorithmForSigner(template.SignatureAlgorithm, signer.Public())
+		if err != nil {
+			return nil, nil, err
+		}
+		// Copy the template so that the caller's template is not modified.
+		tmpl := *template
+		tmpl.SignatureAlgorithm = sigAlgo
+		template = &tmpl
+	}
+
 	derBytes, err := x509.CreateCertificate(rand.Reader, template, issuerCert, publicKey, signerKey)
 
 	if err != nil {
@@ -453,7 +487,15 @@ func SignCSRTemplate(caCerts []*x509.Certificate, caKey crypto.Signer, template
 // EncodeCSR calls x509.CreateCertificateRequest to sign the given CSR template.
 // It returns a DER encoded signed CSR.
 func EncodeCSR(template *x509.CertificateRequest, key crypto.Signer) ([]byte, error) {
-	derBytes, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	sigAlgo, err := signatureAlgorithmForSigner(template.SignatureAlgorithm, key.Public())
+	if err != nil {
+		return nil, err
+	}
+	// Copy the template so that the caller's template is not modified.
+	tmpl := *template
+	tmpl.SignatureAlgorithm = sigAlgo
+
+	derBytes, err := x509.CreateCertificateRequest(rand.Reader, &tmpl, key)
 	if err != nil {
 		return nil, fmt.Errorf("error creating x509 certificate: %s", err.Error())
 	}
@@ -551,3 +593,49 @@ func SignatureAlgorithm(crt *v1.Certificate) (x509.PublicKeyAlgorithm, x509.Sign
 	}
 	return pubKeyAlgo, sigAlgo, nil
 }
+
+// ValidateSignatureAlgorithm returns an error if the given signature algorithm
+// cannot be used to sign with the private key belonging to the given public
+// key.
+func ValidateSignatureAlgorithm(sigAlgo x509.SignatureAlgorithm, publicKey crypto.PublicKey) error {
+	var compatible bool
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		switch sigAlgo {
+		case x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+			x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS:
+			compatible = true
+		}
+	case *ecdsa.PublicKey:
+		switch sigAlgo {
+		case x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512:
+			compatible = true
+		}
+	case ed25519.PublicKey:
+		compatible = sigAlgo == x509.PureEd25519
+	default:
+		return fmt.Errorf("unsupported public key type: %T", publicKey)
+	}
+
+	if !compatible {
+		return fmt.Errorf("signature algorithm %s is not compatible with a %T signing key", sigAlgo, publicKey)
+	}
+
+	return nil
+}
+
+// signatureAlgorithmForSigner returns the signature algorithm to sign with
+// using the private key belonging to the given public key. An explicitly
+// requested algorithm is validated against the key. Otherwise,
+// UnknownSignatureAlgorithm is returned so that crypto/x509 picks its default,
+// which for ECDSA keys is the hash matching their curve.
+func signatureAlgorithmForSigner(requested x509.SignatureAlgorithm, publicKey crypto.PublicKey) (x509.SignatureAlgorithm, error) {
+	if requested != x509.UnknownSignatureAlgorithm {
+		if err := ValidateSignatureAlgorithm(requested, publicKey); err != nil {
+			return x509.UnknownSignatureAlgorithm, err
+		}
+		return requested, nil
+	}
+
+	return x509.UnknownSignatureAlgorithm, nil
+}
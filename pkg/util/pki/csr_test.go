@@ -19,9 +19,13 @@ package pki
 import (
 	"bytes"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -463,6 +467,28 @@ func TestGenerateCSR(t *testing.T) {
 				ExtraExtensions:    defaultExtraExtensions,
 			},
 		},
+		{
+			name: "Generate CSR from certificate with only email addresses",
+			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{EmailAddresses: []string{"alice@example.com", "bob@example.com"}}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				EmailAddresses:     []string{"alice@example.com", "bob@example.com"},
+				ExtraExtensions:    defaultExtraExtensions,
+			},
+		},
+		{
+			name: "Generate CSR from certificate with subject serialNumber",
+			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.org", Subject: &cmapi.X509Subject{SerialNumber: "DEVICE-1234"}}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				Subject:            pkix.Name{CommonName: "example.org", SerialNumber: "DEVICE-1234"},
+				ExtraExtensions:    defaultExtraExtensions,
+			},
+		},
 		{
 			name:    "Error on generating CSR from certificate with no subject",
 			crt:     &cmapi.Certificate{Spec: cmapi.CertificateSpec{}},
@@ -483,6 +509,83 @@ func TestGenerateCSR(t *testing.T) {
 	}
 }
 
+func TestGenerateCSRSubjectSerialNumber(t *testing.T) {
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		CommonName: "example.org",
+		Subject:    &cmapi.X509Subject{SerialNumber: "DEVICE-1234"},
+	}}
+
+	template, err := GenerateCSR(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := EncodeCSR(template, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2.5.4.5 is the OID of the serialNumber attribute type
+	oidSerialNumber := asn1.ObjectIdentifier{2, 5, 4, 5}
+	found := false
+	for _, atv := range csr.Subject.Names {
+		if atv.Type.Equal(oidSerialNumber) {
+			found = true
+			if atv.Value != "DEVICE-1234" {
+				t.Errorf("unexpected serialNumber attribute value, exp=%q got=%q", "DEVICE-1234", atv.Value)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected CSR subject %q to contain the serialNumber attribute", csr.Subject)
+	}
+}
+
+// The common name is never added to the SANs of a generated CSR, so that
+// CAs which reject duplicate entries are not sent one.
+func TestGenerateCSRDoesNotAddCommonNameToSANs(t *testing.T) {
+	tests := map[string]struct {
+		crt         *cmapi.Certificate
+		expDNSNames []string
+	}{
+		"common name only": {
+			crt:         buildCertificate("example.com"),
+			expDNSNames: nil,
+		},
+		"common name not listed in the DNS names": {
+			crt:         buildCertificate("example.com", "www.example.com"),
+			expDNSNames: []string{"www.example.com"},
+		},
+		"common name listed in the DNS names is not duplicated": {
+			crt:         buildCertificate("example.com", "example.com", "www.example.com"),
+			expDNSNames: []string{"example.com", "www.example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			template, err := GenerateCSR(test.crt)
+			require.NoError(t, err)
+			pk, err := GenerateRSAPrivateKey(MinRSAKeySize)
+			require.NoError(t, err)
+			csrDER, err := EncodeCSR(template, pk)
+			require.NoError(t, err)
+			csr, err := x509.ParseCertificateRequest(csrDER)
+			require.NoError(t, err)
+
+			assert.Equal(t, "example.com", csr.Subject.CommonName)
+			assert.Equal(t, test.expDNSNames, csr.DNSNames)
+		})
+	}
+}
+
 func Test_buildKeyUsagesExtensionsForCertificate(t *testing.T) {
 	// 0xa0 = DigitalSignature and Encipherment usage
 	asn1DefaultKeyUsage, err := asn1.Marshal(asn1.BitString{Bytes: []byte{0xa0}, BitLength: asn1BitLength([]byte{0xa0})})
@@ -762,3 +865,198 @@ func TestEncodeX509Chain(t *testing.T) {
 		})
 	}
 }
+
+func TestSignatureAlgorithmMatchesECDSAKeySize(t *testing.T) {
+	tests := map[int]x509.SignatureAlgorithm{
+		256: x509.ECDSAWithSHA256,
+		384: x509.ECDSAWithSHA384,
+		521: x509.ECDSAWithSHA512,
+	}
+
+	for keySize, expectedSigAlgo := range tests {
+		t.Run(fmt.Sprintf("P-%d", keySize), func(t *testing.T) {
+			crt := buildCertificateWithKeyParams(cmapi.ECDSAKeyAlgorithm, keySize)
+			crt.Spec.IsCA = true
+			pk, err := GenerateECPrivateKey(keySize)
+			require.NoError(t, err)
+
+			csrTmpl, err := GenerateCSR(crt)
+			require.NoError(t, err)
+			csrDER, err := EncodeCSR(csrTmpl, pk)
+			require.NoError(t, err)
+			csr, err := x509.ParseCertificateRequest(csrDER)
+			require.NoError(t, err)
+			assert.Equal(t, expectedSigAlgo, csr.SignatureAlgorithm, "unexpected CSR signature algorithm")
+
+			// Self-signed, as done by the SelfSigned issuer.
+			tmpl, err := GenerateTemplate(crt)
+			require.NoError(t, err)
+			tmpl.PublicKey = pk.Public()
+			_, selfSigned, err := SignCertificate(tmpl, tmpl, pk.Public(), pk)
+			require.NoError(t, err)
+			assert.Equal(t, expectedSigAlgo, selfSigned.SignatureAlgorithm, "unexpected self-signed certificate signature algorithm")
+
+			// Signed by a CA using the same key size, as done by the CA
+			// issuer. The signature algorithm follows the CA's key rather
+			// than the leaf's.
+			leafPK, err := GenerateECPrivateKey(256)
+			require.NoError(t, err)
+			leafTmpl, err := GenerateTemplate(buildCertificate("leaf.example.com"))
+			require.NoError(t, err)
+			leafTmpl.PublicKey = leafPK.Public()
+			bundle, err := SignCSRTemplate([]*x509.Certificate{selfSigned}, pk, leafTmpl)
+			require.NoError(t, err)
+			leaf, err := DecodeX509CertificateBytes(bundle.ChainPEM)
+			require.NoError(t, err)
+			assert.Equal(t, expectedSigAlgo, leaf.SignatureAlgorithm, "unexpected CA signed certificate signature algorithm")
+		})
+	}
+}
+
+func TestSignCertificateECDSACurveSignatureAlgorithm(t *testing.T) {
+	tests := map[string]struct {
+		curve           elliptic.Curve
+		expectedSigAlgo x509.SignatureAlgorithm
+	}{
+		"P-224 falls back to SHA-256": {curve: elliptic.P224(), expectedSigAlgo: x509.ECDSAWithSHA256},
+		"P-256":                       {curve: elliptic.P256(), expectedSigAlgo: x509.ECDSAWithSHA256},
+		"P-384":                       {curve: elliptic.P384(), expectedSigAlgo: x509.ECDSAWithSHA384},
+		"P-521":                       {curve: elliptic.P521(), expectedSigAlgo: x509.ECDSAWithSHA512},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// A CA using a key on the curve, which may have been created
+			// outside of cert-manager, can still sign certificates.
+			pk, err := ecdsa.GenerateKey(test.curve, rand.Reader)
+			require.NoError(t, err)
+			tmpl, err := GenerateTemplate(buildCertificate("ca.example.com"))
+			require.NoError(t, err)
+			tmpl.IsCA = true
+			tmpl.PublicKey = pk.Public()
+			_, ca, err := SignCertificate(tmpl, tmpl, pk.Public(), pk)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSigAlgo, ca.SignatureAlgorithm)
+		})
+	}
+}
+
+func TestSignCertificateWithSelectedSignatureAlgorithm(t *testing.T) {
+	pk, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		sigAlgo         x509.SignatureAlgorithm
+		expectedSigAlgo x509.SignatureAlgorithm
+		wantErr         bool
+	}{
+		"defaults to the hash matching the curve": {
+			expectedSigAlgo: x509.ECDSAWithSHA256,
+		},
+		"uses a selected ECDSA signature algorithm": {
+			sigAlgo:         x509.ECDSAWithSHA512,
+			expectedSigAlgo: x509.ECDSAWithSHA512,
+		},
+		"rejects a signature algorithm which is incompatible with the key": {
+			sigAlgo: x509.SHA512WithRSA,
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := GenerateTemplate(buildCertificate("example.com"))
+			require.NoError(t, err)
+			tmpl.PublicKey = pk.Public()
+			tmpl.SignatureAlgorithm = test.sigAlgo
+
+			_, cert, err := SignCertificate(tmpl, tmpl, pk.Public(), pk)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSigAlgo, cert.SignatureAlgorithm)
+			assert.Equal(t, test.sigAlgo, tmpl.SignatureAlgorithm, "template should not be modified")
+
+			csrTmpl, err := GenerateCSR(buildCertificate("example.com"))
+			require.NoError(t, err)
+			csrTmpl.SignatureAlgorithm = test.sigAlgo
+			csrDER, err := EncodeCSR(csrTmpl, pk)
+			require.NoError(t, err)
+			csr, err := x509.ParseCertificateRequest(csrDER)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSigAlgo, csr.SignatureAlgorithm)
+		})
+	}
+}
+
+func TestSetCAPathLen(t *testing.T) {
+	pk, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	intPtr := func(i int) *int { return &i }
+
+	tests := map[string]struct {
+		isCA    bool
+		pathLen *int
+
+		// expectedPathLen is the pathLenConstraint encoded in the basic
+		// constraints extension, or -1 if it must be absent.
+		expectedPathLen int
+	}{
+		"no path length constraint if pathLen is not set": {
+			isCA:            true,
+			expectedPathLen: -1,
+		},
+		"encodes a path length constraint of 0": {
+			isCA:            true,
+			pathLen:         intPtr(0),
+			expectedPathLen: 0,
+		},
+		"encodes a positive path length constraint": {
+			isCA:            true,
+			pathLen:         intPtr(2),
+			expectedPathLen: 2,
+		},
+		"ignores pathLen for certificates which are not CAs": {
+			isCA:            false,
+			pathLen:         intPtr(1),
+			expectedPathLen: -1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := GenerateTemplate(buildCertificate("example.com"))
+			require.NoError(t, err)
+			tmpl.IsCA = test.isCA
+			tmpl.PublicKey = pk.Public()
+
+			SetCAPathLen(tmpl, test.pathLen)
+
+			_, cert, err := SignCertificate(tmpl, tmpl, pk.Public(), pk)
+			require.NoError(t, err)
+
+			var constraints struct {
+				IsCA       bool `asn1:"optional"`
+				MaxPathLen int  `asn1:"optional,default:-1"`
+			}
+			var found bool
+			for _, ext := range cert.Extensions {
+				if !ext.Id.Equal(asn1.ObjectIdentifier{2, 5, 29, 19}) {
+					continue
+				}
+				found = true
+				_, err := asn1.Unmarshal(ext.Value, &constraints)
+				require.NoError(t, err)
+			}
+			require.True(t, found, "basic constraints extension not found")
+
+			assert.Equal(t, test.isCA, constraints.IsCA)
+			assert.Equal(t, test.expectedPathLen, constraints.MaxPathLen)
+			assert.Equal(t, test.expectedPathLen, cert.MaxPathLen)
+			assert.Equal(t, test.expectedPathLen == 0, cert.MaxPathLenZero)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// IssuingCertificate returns the first of the candidate certificates which
+// signed the given certificate, or nil if none did. A candidate signed the
+// certificate if its subject is the certificate's issuer and its public key
+// verifies the certificate's signature. Whether the candidate is allowed to
+// act as a CA is not checked, as only the signer is of interest.
+func IssuingCertificate(cert *x509.Certificate, candidates ...*x509.Certificate) *x509.Certificate {
+	for _, candidate := range candidates {
+		if !bytes.Equal(candidate.RawSubject, cert.RawIssuer) {
+			continue
+		}
+		if err := candidate.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err == nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// HexSerialNumber returns the given serial number formatted as colon
+// separated pairs of upper case hexadecimal digits, as printed by
+// `openssl x509 -text`.
+func HexSerialNumber(serial *big.Int) string {
+	b := serial.Bytes()
+	if len(b) == 0 {
+		return "00"
+	}
+	pairs := make([]string, len(b))
+	for i, octet := range b {
+		pairs[i] = fmt.Sprintf("%02X", octet)
+	}
+	return strings.Join(pairs, ":")
+}
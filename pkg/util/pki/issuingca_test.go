@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func mustSignTestCertificate(t *testing.T, commonName string, isCA bool, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	key, err := GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(int64(len(commonName))),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	_, cert, err := SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestIssuingCertificate(t *testing.T) {
+	root, rootKey := mustSignTestCertificate(t, "root", true, nil, nil)
+	intermediate, intermediateKey := mustSignTestCertificate(t, "intermediate", true, root, rootKey)
+	leaf, _ := mustSignTestCertificate(t, "leaf", false, intermediate, intermediateKey)
+	selfSigned, _ := mustSignTestCertificate(t, "self-signed", false, nil, nil)
+	// an impostor has the same subject as the intermediate, but a different key
+	impostor, _ := mustSignTestCertificate(t, "intermediate", true, root, rootKey)
+
+	tests := map[string]struct {
+		cert       *x509.Certificate
+		candidates []*x509.Certificate
+		exp        *x509.Certificate
+	}{
+		"no candidates": {
+			cert: leaf,
+		},
+		"finds the intermediate which signed the leaf": {
+			cert:       leaf,
+			candidates: []*x509.Certificate{root, intermediate},
+			exp:        intermediate,
+		},
+		"finds the root which signed the intermediate": {
+			cert:       intermediate,
+			candidates: []*x509.Certificate{intermediate, root},
+			exp:        root,
+		},
+		"ignores a certificate with the issuer's subject but another key": {
+			cert:       leaf,
+			candidates: []*x509.Certificate{impostor, intermediate},
+			exp:        intermediate,
+		},
+		"returns nil if no candidate signed the certificate": {
+			cert:       leaf,
+			candidates: []*x509.Certificate{impostor, root},
+		},
+		"a self-signed certificate signed itself": {
+			cert:       selfSigned,
+			candidates: []*x509.Certificate{root, selfSigned},
+			exp:        selfSigned,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IssuingCertificate(test.cert, test.candidates...); got != test.exp {
+				t.Errorf("unexpected issuing certificate, exp=%v, got=%v", subjectOf(test.exp), subjectOf(got))
+			}
+		})
+	}
+}
+
+func subjectOf(cert *x509.Certificate) string {
+	if cert == nil {
+		return "<nil>"
+	}
+	return cert.Subject.String()
+}
+
+func TestHexSerialNumber(t *testing.T) {
+	tests := map[string]struct {
+		serial *big.Int
+		exp    string
+	}{
+		"zero":           {serial: big.NewInt(0), exp: "00"},
+		"single octet":   {serial: big.NewInt(10), exp: "0A"},
+		"several octets": {serial: big.NewInt(0x1a2b3c), exp: "1A:2B:3C"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := HexSerialNumber(test.serial); got != test.exp {
+				t.Errorf("unexpected serial number, exp=%s, got=%s", test.exp, got)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package pki
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
@@ -116,6 +117,58 @@ func DecodeX509CertificateChainBytes(certBytes []byte) ([]*x509.Certificate, err
 	return certs, nil
 }
 
+// AppendCertificatesToPEMBundle appends the PEM encoded certificates in
+// additional to the PEM encoded bundle, skipping any certificate that is
+// already present. An error is returned if additional does not contain at
+// least one certificate, or contains a PEM block which is not a valid
+// certificate.
+func AppendCertificatesToPEMBundle(bundle, additional []byte) ([]byte, error) {
+	seen := make(map[string]struct{})
+	for rest := bundle; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		seen[string(block.Bytes)] = struct{}{}
+	}
+
+	out := bytes.NewBuffer(append([]byte{}, bundle...))
+	found := false
+	for rest := additional; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.NewInvalidData("unexpected PEM block of type %q, only certificates are supported", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, errors.NewInvalidData("error parsing certificate: %s", err.Error())
+		}
+		found = true
+
+		if _, ok := seen[string(block.Bytes)]; ok {
+			continue
+		}
+		seen[string(block.Bytes)] = struct{}{}
+
+		if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteByte('\n')
+		}
+		if err := pem.Encode(out, &pem.Block{Type: "CERTIFICATE", Bytes: block.Bytes}); err != nil {
+			return nil, err
+		}
+	}
+
+	if !found {
+		return nil, errors.NewInvalidData("error decoding certificate PEM block")
+	}
+
+	return out.Bytes(), nil
+}
+
 // DecodeX509CertificateBytes will decode a PEM encoded x509 Certificate.
 func DecodeX509CertificateBytes(certBytes []byte) (*x509.Certificate, error) {
 	certs, err := DecodeX509CertificateChainBytes(certBytes)
@@ -17,6 +17,7 @@ limitations under the License.
 package pki
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/rand"
@@ -361,3 +362,77 @@ func TestParseSingleCertificateChain(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendCertificatesToPEMBundle(t *testing.T) {
+	root := mustCreateBundle(t, nil, "root")
+	intA1 := mustCreateBundle(t, root, "intA-1")
+	extraRoot := mustCreateBundle(t, nil, "extra-root")
+	otherRoot := mustCreateBundle(t, nil, "other-root")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("not-a-cert")})
+
+	tests := map[string]struct {
+		bundle     []byte
+		additional []byte
+		expBundle  []byte
+		expErr     bool
+	}{
+		"appends additional certificates to an existing bundle": {
+			bundle:     joinPEM(nil, root.pem),
+			additional: joinPEM(nil, extraRoot.pem, otherRoot.pem),
+			expBundle:  joinPEM(nil, root.pem, extraRoot.pem, otherRoot.pem),
+		},
+		"appends additional certificates to an empty bundle": {
+			additional: joinPEM(nil, extraRoot.pem),
+			expBundle:  joinPEM(nil, extraRoot.pem),
+		},
+		"does not append certificates already in the bundle": {
+			bundle:     joinPEM(nil, intA1.pem, root.pem),
+			additional: joinPEM(nil, root.pem, extraRoot.pem),
+			expBundle:  joinPEM(nil, intA1.pem, root.pem, extraRoot.pem),
+		},
+		"does not append duplicate additional certificates twice": {
+			bundle:     joinPEM(nil, root.pem),
+			additional: joinPEM(nil, extraRoot.pem, extraRoot.pem),
+			expBundle:  joinPEM(nil, root.pem, extraRoot.pem),
+		},
+		"adds a new line if the bundle does not end with one": {
+			bundle:     bytes.TrimSpace(root.pem),
+			additional: joinPEM(nil, extraRoot.pem),
+			expBundle:  joinPEM(nil, root.pem, extraRoot.pem),
+		},
+		"errors if additional contains no certificates": {
+			bundle:     joinPEM(nil, root.pem),
+			additional: []byte("not PEM"),
+			expErr:     true,
+		},
+		"errors if additional contains a PEM block which is not a certificate": {
+			bundle:     joinPEM(nil, root.pem),
+			additional: joinPEM(nil, extraRoot.pem, keyPEM),
+			expErr:     true,
+		},
+		"errors if additional contains a certificate which cannot be parsed": {
+			bundle:     joinPEM(nil, root.pem),
+			additional: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")}),
+			expErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := AppendCertificatesToPEMBundle(test.bundle, test.additional)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+
+			if !bytes.Equal(out, test.expBundle) {
+				t.Errorf("unexpected bundle, exp=%s got=%s", test.expBundle, out)
+			}
+			if _, err := DecodeX509CertificateChainBytes(out); err != nil {
+				t.Errorf("resulting bundle could not be parsed: %v", err)
+			}
+		})
+	}
+}
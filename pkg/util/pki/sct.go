@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// OIDExtensionCTSCTList is the OID of the X.509v3 extension used to embed a
+// list of Signed Certificate Timestamps in a certificate, as defined in RFC
+// 6962 section 3.3.
+var OIDExtensionCTSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// EmbeddedSCTCount returns the number of Signed Certificate Timestamps
+// embedded in the given certificate. A certificate without the SCT list
+// extension has no embedded SCTs.
+// An error is returned if the extension is present but malformed.
+func EmbeddedSCTCount(cert *x509.Certificate) (int, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(OIDExtensionCTSCTList) {
+			continue
+		}
+
+		// The extension value is a DER OCTET STRING wrapping the TLS encoded
+		// SignedCertificateTimestampList.
+		var list []byte
+		rest, err := asn1.Unmarshal(ext.Value, &list)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode SCT list extension: %w", err)
+		}
+		if len(rest) > 0 {
+			return 0, errors.New("failed to decode SCT list extension: trailing data")
+		}
+		return countSCTs(list)
+	}
+
+	return 0, nil
+}
+
+// countSCTs counts the SerializedSCT entries of a TLS encoded
+// SignedCertificateTimestampList, in which the list and each of its entries
+// are prefixed with their length as a uint16.
+func countSCTs(list []byte) (int, error) {
+	if len(list) < 2 {
+		return 0, errors.New("SCT list is too short")
+	}
+	length := int(binary.BigEndian.Uint16(list))
+	list = list[2:]
+	if length != len(list) {
+		return 0, fmt.Errorf("SCT list length %d does not match the %d bytes of data", length, len(list))
+	}
+
+	count := 0
+	for len(list) > 0 {
+		if len(list) < 2 {
+			return 0, errors.New("SCT entry is too short")
+		}
+		sctLength := int(binary.BigEndian.Uint16(list))
+		list = list[2:]
+		if sctLength == 0 || sctLength > len(list) {
+			return 0, fmt.Errorf("invalid SCT entry length %d", sctLength)
+		}
+		list = list[sctLength:]
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// sctListExtension builds an SCT list extension holding the given serialized
+// SCTs. The SCTs are not required to be valid as only their framing is
+// inspected.
+func sctListExtension(t *testing.T, scts ...[]byte) pkix.Extension {
+	var entries []byte
+	for _, sct := range scts {
+		entries = append(entries, uint16Bytes(len(sct))...)
+		entries = append(entries, sct...)
+	}
+	list := append(uint16Bytes(len(entries)), entries...)
+
+	value, err := asn1.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkix.Extension{Id: OIDExtensionCTSCTList, Value: value}
+}
+
+func uint16Bytes(n int) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, uint16(n))
+	return b
+}
+
+func TestEmbeddedSCTCount(t *testing.T) {
+	malformedValue, err := asn1.Marshal([]byte{0x00, 0x05, 0x00, 0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		extensions []pkix.Extension
+		expected   int
+		expectErr  bool
+	}{
+		"certificate without an SCT list extension has no SCTs": {
+			expected: 0,
+		},
+		"certificate with a single embedded SCT": {
+			extensions: []pkix.Extension{sctListExtension(t, []byte("sct-1"))},
+			expected:   1,
+		},
+		"certificate with multiple embedded SCTs": {
+			extensions: []pkix.Extension{sctListExtension(t, []byte("sct-1"), []byte("sct-2"), []byte("sct-3"))},
+			expected:   3,
+		},
+		"certificate with a malformed SCT list extension": {
+			extensions: []pkix.Extension{{Id: OIDExtensionCTSCTList, Value: malformedValue}},
+			expectErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pk, err := GenerateECPrivateKey(256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			template := &x509.Certificate{
+				SerialNumber:    big.NewInt(1),
+				Subject:         pkix.Name{CommonName: "example.com"},
+				NotBefore:       time.Now(),
+				NotAfter:        time.Now().Add(time.Hour),
+				ExtraExtensions: test.extensions,
+			}
+			_, cert, err := SignCertificate(template, template, pk.Public(), pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			count, err := EmbeddedSCTCount(cert)
+			if test.expectErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectErr, err)
+			}
+			if count != test.expected {
+				t.Errorf("unexpected SCT count, exp=%d got=%d", test.expected, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// SPIFFEScheme is the URI scheme of SPIFFE IDs.
+const SPIFFEScheme = "spiffe"
+
+// SPIFFEIDForCertificateSpec returns the SPIFFE ID configured by the given
+// CertificateSpec for a Certificate in the given namespace, or nil if the
+// spec does not configure a SPIFFE ID.
+// The SPIFFE ID follows the convention used by Istio and SPIRE for
+// Kubernetes workloads: spiffe://<trust domain>/ns/<namespace>/sa/<name>.
+func SPIFFEIDForCertificateSpec(namespace string, spec v1.CertificateSpec) *url.URL {
+	if spec.SPIFFE == nil {
+		return nil
+	}
+	return &url.URL{
+		Scheme: SPIFFEScheme,
+		Host:   spec.SPIFFE.TrustDomain,
+		Path:   "/ns/" + namespace + "/sa/" + spec.SPIFFE.ServiceAccountName,
+	}
+}
+
+// URISANsForCertificateSpec returns the URI subjectAltNames to be set on a
+// certificate for the given CertificateSpec of a Certificate in the given
+// namespace, including the derived SPIFFE ID if one is configured.
+func URISANsForCertificateSpec(namespace string, spec v1.CertificateSpec) []string {
+	id := SPIFFEIDForCertificateSpec(namespace, spec)
+	if id == nil {
+		return spec.URIs
+	}
+	return append(append([]string{}, spec.URIs...), id.String())
+}
+
+// ValidateSPIFFETrustDomain returns an error if the given trust domain is not
+// a valid SPIFFE trust domain. A trust domain may only contain lowercase
+// letters, digits, dots, dashes and underscores.
+func ValidateSPIFFETrustDomain(trustDomain string) error {
+	if len(trustDomain) == 0 {
+		return errors.New("trust domain must not be empty")
+	}
+	if len(trustDomain) > 255 {
+		return errors.New("trust domain must be no more than 255 characters")
+	}
+	for _, r := range trustDomain {
+		if !isSPIFFECharacter(r) {
+			return fmt.Errorf("trust domain contains invalid character %q: only lowercase letters, digits, '.', '-' and '_' are allowed", r)
+		}
+	}
+	return nil
+}
+
+// ValidateSPIFFEID returns an error if the given URI is not a valid SPIFFE ID
+// identifying a workload: it must have a valid trust domain, no port,
+// userinfo, query or fragment, and a non-empty path whose segments only
+// contain letters, digits, dots, dashes and underscores.
+func ValidateSPIFFEID(u *url.URL) error {
+	if u.Scheme != SPIFFEScheme {
+		return fmt.Errorf("scheme must be %q", SPIFFEScheme)
+	}
+	if len(u.Opaque) > 0 {
+		return fmt.Errorf("must be of the form %s://<trust domain>/<path>", SPIFFEScheme)
+	}
+	if u.User != nil {
+		return errors.New("must not contain userinfo")
+	}
+	if len(u.Port()) > 0 {
+		return errors.New("trust domain must not contain a port")
+	}
+	if err := ValidateSPIFFETrustDomain(u.Host); err != nil {
+		return err
+	}
+	if len(u.RawQuery) > 0 || u.ForceQuery {
+		return errors.New("must not contain a query")
+	}
+	if len(u.Fragment) > 0 {
+		return errors.New("must not contain a fragment")
+	}
+	if len(u.Path) == 0 || u.Path == "/" {
+		return errors.New("path must not be empty")
+	}
+	if len(u.RawPath) > 0 {
+		return errors.New("path must not contain percent-encoded characters")
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(u.Path, "/"), "/") {
+		switch segment {
+		case "":
+			return errors.New("path must not contain empty segments or a trailing slash")
+		case ".", "..":
+			return errors.New("path must not contain relative segments")
+		}
+		for _, r := range segment {
+			if !isSPIFFECharacter(r) && !('A' <= r && r <= 'Z') {
+				return fmt.Errorf("path contains invalid character %q: only letters, digits, '.', '-' and '_' are allowed", r)
+			}
+		}
+	}
+	return nil
+}
+
+func isSPIFFECharacter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r == '.' || r == '-' || r == '_'
+}
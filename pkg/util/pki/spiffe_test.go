@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"net/url"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestGenerateCSRWithSPIFFEID(t *testing.T) {
+	crt := &v1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "sandbox", Name: "test"},
+		Spec: v1.CertificateSpec{
+			URIs: []string{"https://example.com/workload"},
+			SPIFFE: &v1.SPIFFEID{
+				TrustDomain:        "cluster.local",
+				ServiceAccountName: "default",
+			},
+		},
+	}
+
+	csr, err := GenerateCSR(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"https://example.com/workload", "spiffe://cluster.local/ns/sandbox/sa/default"}
+	got := URLsToString(csr.URIs)
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("unexpected URI SANs, exp=%v got=%v", expected, got)
+	}
+	if sans := URISANsForCertificateSpec(crt.Namespace, crt.Spec); len(sans) != 2 || sans[1] != expected[1] {
+		t.Errorf("unexpected URI SANs for spec, exp=%v got=%v", expected, sans)
+	}
+
+	template, err := GenerateTemplate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := URLsToString(template.URIs); len(got) != 2 || got[1] != expected[1] {
+		t.Errorf("unexpected URI SANs in template, exp=%v got=%v", expected, got)
+	}
+}
+
+func TestSPIFFEIDForCertificateSpecUnset(t *testing.T) {
+	if id := SPIFFEIDForCertificateSpec("sandbox", v1.CertificateSpec{}); id != nil {
+		t.Errorf("expected no SPIFFE ID, got %s", id)
+	}
+}
+
+func TestValidateSPIFFEID(t *testing.T) {
+	tests := map[string]struct {
+		uri       string
+		expectErr bool
+	}{
+		"valid workload SPIFFE ID": {
+			uri: "spiffe://cluster.local/ns/sandbox/sa/default",
+		},
+		"valid SPIFFE ID with upper case path": {
+			uri: "spiffe://example.org/Billing/Payments_v2",
+		},
+		"trust domain only": {
+			uri:       "spiffe://cluster.local",
+			expectErr: true,
+		},
+		"trust domain with trailing slash": {
+			uri:       "spiffe://cluster.local/",
+			expectErr: true,
+		},
+		"upper case trust domain": {
+			uri:       "spiffe://Cluster.Local/ns/sandbox",
+			expectErr: true,
+		},
+		"trust domain with port": {
+			uri:       "spiffe://cluster.local:8443/ns/sandbox",
+			expectErr: true,
+		},
+		"userinfo": {
+			uri:       "spiffe://user@cluster.local/ns/sandbox",
+			expectErr: true,
+		},
+		"query": {
+			uri:       "spiffe://cluster.local/ns/sandbox?a=b",
+			expectErr: true,
+		},
+		"fragment": {
+			uri:       "spiffe://cluster.local/ns/sandbox#a",
+			expectErr: true,
+		},
+		"empty path segment": {
+			uri:       "spiffe://cluster.local/ns//sandbox",
+			expectErr: true,
+		},
+		"relative path segment": {
+			uri:       "spiffe://cluster.local/ns/../sandbox",
+			expectErr: true,
+		},
+		"percent-encoded path": {
+			uri:       "spiffe://cluster.local/ns/sand%20box",
+			expectErr: true,
+		},
+		"opaque URI": {
+			uri:       "spiffe:cluster.local/ns/sandbox",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(test.uri)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ValidateSPIFFEID(u)
+			if test.expectErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectErr, err)
+			}
+		})
+	}
+}
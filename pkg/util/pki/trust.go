@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+// AddTrustBundle adds the PEM encoded CA certificates in trustBundle to pool,
+// or to the system roots if pool is nil, and returns the resulting pool.
+// If trustBundle is empty, pool is returned unchanged, so that a nil pool
+// continues to mean the system roots when used in a tls.Config.
+func AddTrustBundle(pool *x509.CertPool, trustBundle []byte) (*x509.CertPool, error) {
+	if len(trustBundle) == 0 {
+		return pool, nil
+	}
+
+	if pool == nil {
+		var err error
+		pool, err = x509.SystemCertPool()
+		if err != nil {
+			// the system roots are not available on all platforms
+			pool = x509.NewCertPool()
+		}
+	}
+
+	if !pool.AppendCertsFromPEM(trustBundle) {
+		return nil, errors.New("no valid PEM encoded CA certificates found in the trust bundle")
+	}
+	return pool, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"testing"
+)
+
+func TestAddTrustBundle(t *testing.T) {
+	root := mustCreateBundle(t, nil, "root")
+	leaf := mustCreateBundle(t, root, "leaf")
+
+	tests := map[string]struct {
+		pool        *x509.CertPool
+		trustBundle []byte
+		expectErr   bool
+		expectTrust bool
+	}{
+		"an empty trust bundle leaves the pool unchanged": {
+			pool: x509.NewCertPool(),
+		},
+		"a trust bundle is added to the given pool": {
+			pool:        x509.NewCertPool(),
+			trustBundle: root.pem,
+			expectTrust: true,
+		},
+		"a trust bundle is added to the system pool if no pool is given": {
+			trustBundle: root.pem,
+			expectTrust: true,
+		},
+		"a trust bundle without any certificates is rejected": {
+			pool:        x509.NewCertPool(),
+			trustBundle: []byte("not a certificate"),
+			expectErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pool, err := AddTrustBundle(test.pool, test.trustBundle)
+			if test.expectErr != (err != nil) {
+				t.Fatalf("expected error=%t, got: %v", test.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			_, err = leaf.cert.Verify(x509.VerifyOptions{Roots: pool})
+			if test.expectTrust != (err == nil) {
+				t.Errorf("expected leaf to be trusted=%t, got verification error: %v", test.expectTrust, err)
+			}
+		})
+	}
+}
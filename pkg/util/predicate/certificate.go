@@ -19,6 +19,7 @@ package predicate
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
@@ -44,3 +45,50 @@ func CertificateNextPrivateKeySecretName(name string) Func {
 		return *crt.Status.NextPrivateKeySecretName == name
 	}
 }
+
+// CertificateCSRSecretName returns a predicate that used to filter Certificates
+// to only those with the given 'spec.csrSecretRef.name'.
+// It is not possible to select Certificates with a 'nil' CSR secret reference
+// using this predicate function.
+func CertificateCSRSecretName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		if crt.Spec.CSRSecretRef == nil {
+			return false
+		}
+		return crt.Spec.CSRSecretRef.Name == name
+	}
+}
+
+// CertificateDNSNamesConfigMapName returns a predicate that used to filter
+// Certificates to only those with the given 'spec.dnsNamesConfigMapRef.name'.
+// It is not possible to select Certificates with a 'nil' ConfigMap reference
+// using this predicate function.
+func CertificateDNSNamesConfigMapName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		if crt.Spec.DNSNamesConfigMapRef == nil {
+			return false
+		}
+		return crt.Spec.DNSNamesConfigMapRef.Name == name
+	}
+}
+
+// CertificateIssuerRef returns a predicate that used to filter Certificates
+// to only those whose 'spec.issuerRef' references the cert-manager issuer
+// with the given kind and name. An empty kind on the Certificate is treated
+// as an Issuer.
+func CertificateIssuerRef(kind, name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != certmanager.GroupName {
+			return false
+		}
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = cmapi.IssuerKind
+		}
+		return refKind == kind && ref.Name == name
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 func TestCertificateSecretName(t *testing.T) {
@@ -92,3 +93,135 @@ func TestCertificateNextPrivateKeySecretName(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateCSRSecretName(t *testing.T) {
+	certWithCSRSecretRef := func(ref *cmmeta.SecretKeySelector) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{CSRSecretRef: ref},
+		}
+	}
+	tests := map[string]struct {
+		secretName string
+		cert       *cmapi.Certificate
+		expected   bool
+	}{
+		"returns true if secret name matches": {
+			secretName: "abc",
+			cert:       certWithCSRSecretRef(&cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "abc"}}),
+			expected:   true,
+		},
+		"returns false if secret name does not match": {
+			secretName: "abc",
+			cert:       certWithCSRSecretRef(&cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "abcd"}}),
+			expected:   false,
+		},
+		"returns false if csrSecretRef is nil": {
+			secretName: "",
+			cert:       certWithCSRSecretRef(nil),
+			expected:   false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateCSRSecretName(test.secretName)(test.cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestCertificateDNSNamesConfigMapName(t *testing.T) {
+	certWithConfigMapRef := func(ref *cmmeta.ConfigMapKeySelector) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{DNSNamesConfigMapRef: ref},
+		}
+	}
+	tests := map[string]struct {
+		configMapName string
+		cert          *cmapi.Certificate
+		expected      bool
+	}{
+		"returns true if configmap name matches": {
+			configMapName: "abc",
+			cert:          certWithConfigMapRef(&cmmeta.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "abc"}}),
+			expected:      true,
+		},
+		"returns false if configmap name does not match": {
+			configMapName: "abc",
+			cert:          certWithConfigMapRef(&cmmeta.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "abcd"}}),
+			expected:      false,
+		},
+		"returns false if dnsNamesConfigMapRef is nil": {
+			configMapName: "",
+			cert:          certWithConfigMapRef(nil),
+			expected:      false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateDNSNamesConfigMapName(test.configMapName)(test.cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestCertificateIssuerRef(t *testing.T) {
+	certWithIssuerRef := func(ref cmmeta.ObjectReference) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{IssuerRef: ref},
+		}
+	}
+	tests := map[string]struct {
+		kind, name string
+		cert       *cmapi.Certificate
+		expected   bool
+	}{
+		"returns true if the Issuer matches": {
+			kind:     cmapi.IssuerKind,
+			name:     "abc",
+			cert:     certWithIssuerRef(cmmeta.ObjectReference{Name: "abc", Kind: "Issuer", Group: "cert-manager.io"}),
+			expected: true,
+		},
+		"returns true if the Certificate does not set the kind and group of an Issuer": {
+			kind:     cmapi.IssuerKind,
+			name:     "abc",
+			cert:     certWithIssuerRef(cmmeta.ObjectReference{Name: "abc"}),
+			expected: true,
+		},
+		"returns true if the ClusterIssuer matches": {
+			kind:     cmapi.ClusterIssuerKind,
+			name:     "abc",
+			cert:     certWithIssuerRef(cmmeta.ObjectReference{Name: "abc", Kind: "ClusterIssuer"}),
+			expected: true,
+		},
+		"returns false if the kind does not match": {
+			kind:     cmapi.ClusterIssuerKind,
+			name:     "abc",
+			cert:     certWithIssuerRef(cmmeta.ObjectReference{Name: "abc"}),
+			expected: false,
+		},
+		"returns false if the name does not match": {
+			kind:     cmapi.IssuerKind,
+			name:     "abc",
+			cert:     certWithIssuerRef(cmmeta.ObjectReference{Name: "abcd", Kind: "Issuer"}),
+			expected: false,
+		},
+		"returns false for an external issuer": {
+			kind:     cmapi.IssuerKind,
+			name:     "abc",
+			cert:     certWithIssuerRef(cmmeta.ObjectReference{Name: "abc", Kind: "Issuer", Group: "example.com"}),
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateIssuerRef(test.kind, test.name)(test.cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
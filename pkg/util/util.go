@@ -21,6 +21,7 @@ import (
 	"net"
 	"net/url"
 	"sort"
+	"strings"
 	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -182,3 +183,48 @@ func Subset(set, subset []string) bool {
 
 	return true
 }
+
+// NormalizeURI returns the given absolute URI with its scheme and host lower
+// cased, as they are case-insensitive (RFC 3986 section 6.2.2.1). The rest of
+// the URI is returned unchanged. Normalizing an already normalized URI
+// returns it unchanged.
+func NormalizeURI(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" {
+		return uri, nil
+	}
+
+	// url.Parse has already lower cased the scheme.
+	rest := uri[len(u.Scheme):]
+	if u.Host != "" && strings.HasPrefix(rest, "://") {
+		authority := rest[len("://"):]
+		if i := strings.IndexAny(authority, "/?#"); i >= 0 {
+			authority = authority[:i]
+		}
+		// The userinfo, if any, is case-sensitive.
+		hostStart := strings.LastIndex(authority, "@") + 1
+		rest = "://" + authority[:hostStart] + strings.ToLower(authority[hostStart:]) + rest[len("://")+len(authority):]
+	}
+	return u.Scheme + rest, nil
+}
+
+// EqualURIsUnsorted returns whether the two slices hold the same URIs, in any
+// order, comparing their schemes and hosts case-insensitively. URIs which
+// cannot be parsed are compared as they are.
+func EqualURIsUnsorted(s1, s2 []string) bool {
+	normalize := func(uris []string) []string {
+		normalized := make([]string, len(uris))
+		for i, uri := range uris {
+			if n, err := NormalizeURI(uri); err == nil {
+				normalized[i] = n
+			} else {
+				normalized[i] = uri
+			}
+		}
+		return normalized
+	}
+	return EqualUnsorted(normalize(s1), normalize(s2))
+}
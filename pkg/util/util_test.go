@@ -163,3 +163,93 @@ func parseIPs(t *testing.T, ipStrs []string) []net.IP {
 
 	return ips
 }
+
+func TestNormalizeURI(t *testing.T) {
+	tests := map[string]struct {
+		uri      string
+		expected string
+		err      bool
+	}{
+		"already normalized URI is unchanged": {
+			uri:      "spiffe://cluster.local/ns/sandbox/sa/default",
+			expected: "spiffe://cluster.local/ns/sandbox/sa/default",
+		},
+		"scheme and host are lower cased": {
+			uri:      "HTTPS://Example.COM/Path",
+			expected: "https://example.com/Path",
+		},
+		"opaque URI is unchanged": {
+			uri:      "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+			expected: "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		},
+		"path, query and fragment are left unchanged": {
+			uri:      "HTTPS://Example.COM/A b?Q=1#F",
+			expected: "https://example.com/A b?Q=1#F",
+		},
+		"userinfo is left unchanged": {
+			uri:      "https://User@Example.COM:8443/Path",
+			expected: "https://User@example.com:8443/Path",
+		},
+		"invalid URI returns an error": {
+			uri: "https://example.com/%zz",
+			err: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			normalized, err := NormalizeURI(test.uri)
+			if test.err != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.err, err)
+			}
+			if normalized != test.expected {
+				t.Errorf("unexpected normalized URI, exp=%q got=%q", test.expected, normalized)
+			}
+			if err != nil {
+				return
+			}
+			// Normalization must be stable.
+			again, err := NormalizeURI(normalized)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again != normalized {
+				t.Errorf("normalization is not stable, first=%q second=%q", normalized, again)
+			}
+		})
+	}
+}
+
+func TestEqualURIsUnsorted(t *testing.T) {
+	tests := map[string]struct {
+		s1, s2   []string
+		expected bool
+	}{
+		"equal in any order": {
+			s1:       []string{"spiffe://cluster.local/ns/a", "urn:uuid:1234"},
+			s2:       []string{"urn:uuid:1234", "spiffe://cluster.local/ns/a"},
+			expected: true,
+		},
+		"scheme and host are compared case-insensitively": {
+			s1:       []string{"HTTPS://Example.COM/path"},
+			s2:       []string{"https://example.com/path"},
+			expected: true,
+		},
+		"path is compared case-sensitively": {
+			s1:       []string{"https://example.com/Path"},
+			s2:       []string{"https://example.com/path"},
+			expected: false,
+		},
+		"different lengths": {
+			s1:       []string{"https://example.com"},
+			s2:       []string{"https://example.com", "https://example.org"},
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := EqualURIsUnsorted(test.s1, test.s2); got != test.expected {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expected, got)
+			}
+		})
+	}
+}
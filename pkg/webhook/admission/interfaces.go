@@ -34,6 +34,16 @@ type PluginInitializer interface {
 	Initialize(plugin Interface)
 }
 
+// PluginInitializers is a list of PluginInitializers which are all used to
+// initialize each admission plugin, in order.
+type PluginInitializers []PluginInitializer
+
+func (pp PluginInitializers) Initialize(plugin Interface) {
+	for _, p := range pp {
+		p.Initialize(plugin)
+	}
+}
+
 // InitializationValidator holds ValidateInitialization functions, which are responsible for validation of initialized
 // shared resources and should be implemented on admission plugins
 type InitializationValidator interface {
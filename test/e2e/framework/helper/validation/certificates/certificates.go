@@ -29,6 +29,7 @@ import (
 	"github.com/kr/pretty"
 	corev1 "k8s.io/api/core/v1"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -403,6 +404,19 @@ func ExpectValidAdditionalOutputFormats(certificate *cmapi.Certificate, secret *
 				} else {
 					return fmt.Errorf("expected additional output format CombinedPEM key %s to be present in secret", cmapi.CertificateOutputFormatCombinedPEMKey)
 				}
+			case cmapi.CertificateOutputFormatAzureKeyVaultPEM:
+				key := internalcertificates.OutputFormatSecretKey(f)
+				if keyVaultPem, ok := secret.Data[key]; ok {
+					expectedKeyVaultPem, err := internalcertificates.OutputFormatAzureKeyVaultPEM(secret.Data[corev1.TLSPrivateKeyKey], secret.Data[corev1.TLSCertKey])
+					if err != nil {
+						return err
+					}
+					if !bytes.Equal(keyVaultPem, expectedKeyVaultPem) {
+						return fmt.Errorf("expected additional output format AzureKeyVaultPEM %s to contain the certificate followed by the PKCS#8 private key", key)
+					}
+				} else {
+					return fmt.Errorf("expected additional output format AzureKeyVaultPEM key %s to be present in secret", key)
+				}
 
 			default:
 				return fmt.Errorf("unknown additional output format %s", f.Type)
@@ -106,7 +106,10 @@ func TestAcmeOrdersController(t *testing.T) {
 			// A hack to ensure the status of the _ACME_ order gets set to valid
 			// when we're finalizing the order.
 			acmeOrder.Status = acmeapi.StatusValid
-			return [][]byte{}, "", nil
+			return nil, "", nil
+		},
+		FakeFetchCert: func(_ context.Context, _ string, _ bool) ([][]byte, error) {
+			return [][]byte{}, nil
 		},
 	}
 
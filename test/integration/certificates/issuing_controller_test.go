@@ -71,7 +71,7 @@ func TestIssuingController(t *testing.T) {
 	}
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
-		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
+		cmCl, factory, cmFactory, metav1.NamespaceAll, framework.NewEventRecorder(t), clock.RealClock{},
 		controllerOptions, "cert-manage-certificates-issuing-test")
 	c := controllerpkg.NewController(
 		ctx,
@@ -277,7 +277,7 @@ func TestIssuingController_PKCS8_PrivateKey(t *testing.T) {
 	}
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
-		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
+		cmCl, factory, cmFactory, metav1.NamespaceAll, framework.NewEventRecorder(t), clock.RealClock{},
 		controllerOptions, "cert-manage-certificates-issuing-test")
 	c := controllerpkg.NewController(
 		ctx,
@@ -492,7 +492,7 @@ func Test_IssuingController_SecretTemplate(t *testing.T) {
 	}
 
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
-		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
+		cmCl, factory, cmFactory, metav1.NamespaceAll, framework.NewEventRecorder(t), clock.RealClock{},
 		controllerOptions, "cert-manage-certificates-issuing-test")
 	c := controllerpkg.NewController(
 		ctx,
@@ -726,7 +726,7 @@ func Test_IssuingController_AdditionalOutputFormats(t *testing.T) {
 		EnableOwnerRef: true,
 	}
 
-	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{}, controllerOptions, "cert-manager-issuing-test")
+	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmCl, factory, cmFactory, metav1.NamespaceAll, framework.NewEventRecorder(t), clock.RealClock{}, controllerOptions, "cert-manager-issuing-test")
 	c := controllerpkg.NewController(
 		ctx,
 		"issuing_test",
@@ -950,7 +950,7 @@ func Test_IssuingController_OwnerRefernece(t *testing.T) {
 		EnableOwnerRef: false,
 	}
 	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient, cmClient,
-		factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
+		factory, cmFactory, metav1.NamespaceAll, framework.NewEventRecorder(t), clock.RealClock{},
 		controllerOptions, fieldManager,
 	)
 	c := controllerpkg.NewController(ctx, fieldManager, metrics.New(logf.Log, clock.RealClock{}), ctrl.ProcessItem, mustSync, nil, queue)
@@ -1037,7 +1037,7 @@ func Test_IssuingController_OwnerRefernece(t *testing.T) {
 	stopControllerNoOwnerRef = nil
 	controllerOptions.EnableOwnerRef = true
 	ctrl, queue, mustSync = issuing.NewController(logf.Log, kubeClient, cmClient,
-		factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
+		factory, cmFactory, metav1.NamespaceAll, framework.NewEventRecorder(t), clock.RealClock{},
 		controllerOptions, fieldManager,
 	)
 	c = controllerpkg.NewController(ctx, fieldManager, metrics.New(logf.Log, clock.RealClock{}), ctrl.ProcessItem, mustSync, nil, queue)
@@ -56,6 +56,12 @@ func SetCertificateIssuer(o cmmeta.ObjectReference) CertificateModifier {
 	}
 }
 
+func SetCertificateFallbackIssuers(refs ...cmmeta.ObjectReference) CertificateModifier {
+	return func(c *v1.Certificate) {
+		c.Spec.FallbackIssuerRefs = refs
+	}
+}
+
 func SetCertificateDNSNames(dnsNames ...string) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.DNSNames = dnsNames
@@ -110,6 +116,33 @@ func SetCertificateKeyEncoding(keyEncoding v1.PrivateKeyEncoding) CertificateMod
 	}
 }
 
+// SetCertificateUnmanagedPrivateKey configures the Certificate to not manage
+// its private key, and to instead sign the CSR stored in the named Secret.
+func SetCertificateUnmanagedPrivateKey(csrSecretName string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		if crt.Spec.PrivateKey == nil {
+			crt.Spec.PrivateKey = &v1.CertificatePrivateKey{}
+		}
+		managed := false
+		crt.Spec.PrivateKey.Managed = &managed
+		crt.Spec.CSRSecretRef = &cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: csrSecretName},
+		}
+	}
+}
+
+func SetCertificateAdditionalCACertificates(ref cmmeta.SecretKeySelector) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.AdditionalCACertificates = &ref
+	}
+}
+
+func SetCertificateDNSNamesConfigMapRef(ref cmmeta.ConfigMapKeySelector) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.DNSNamesConfigMapRef = &ref
+	}
+}
+
 func SetCertificateSecretName(secretName string) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.SecretName = secretName
@@ -138,6 +171,12 @@ func SetCertificateRenewBefore(renewBefore time.Duration) CertificateModifier {
 	}
 }
 
+func SetCertificateMinRemainingValidity(minRemainingValidity time.Duration) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.MinRemainingValidity = &metav1.Duration{Duration: minRemainingValidity}
+	}
+}
+
 func SetCertificateNextPrivateKeySecretName(name string) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Status.NextPrivateKeySecretName = &name
@@ -171,6 +210,12 @@ func SetCertificateIssuanceAttempts(ia *int) CertificateModifier {
 	}
 }
 
+func SetCertificateStatusFallbackIssuer(ref cmmeta.ObjectReference) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.FallbackIssuerRef = &ref
+	}
+}
+
 func SetCertificateNotAfter(p metav1.Time) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Status.NotAfter = &p
@@ -219,6 +264,17 @@ func SetCertificateUID(uid types.UID) CertificateModifier {
 	}
 }
 
+func SetCertificateAnnotations(annotations map[string]string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		if crt.Annotations == nil {
+			crt.Annotations = make(map[string]string)
+		}
+		for k, v := range annotations {
+			crt.Annotations[k] = v
+		}
+	}
+}
+
 func SetCertificateGeneration(gen int64) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Generation = gen
@@ -273,6 +329,18 @@ func SetCertificateRevisionHistoryLimit(limit int32) CertificateModifier {
 	}
 }
 
+func SetCertificateSecretType(secretType v1.CertificateSecretType) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.SecretType = secretType
+	}
+}
+
+func SetCertificateSecretKeys(keys v1.CertificateSecretKeys) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.SecretKeys = &keys
+	}
+}
+
 func SetCertificateAdditionalOutputFormats(additionalOutputFormats ...v1.CertificateAdditionalOutputFormat) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.AdditionalOutputFormats = additionalOutputFormats
@@ -114,6 +114,12 @@ func SetCertificateRequestName(name string) CertificateRequestModifier {
 	}
 }
 
+func SetCertificateRequestGenerateName(generateName string) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.ObjectMeta.GenerateName = generateName
+	}
+}
+
 func SetCertificateRequestKeyUsages(usages ...v1.KeyUsage) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.Spec.Usages = usages
@@ -166,6 +172,12 @@ func SetCertificateRequestFailureTime(p metav1.Time) CertificateRequestModifier
 	}
 }
 
+func SetCertificateRequestNextRetryTime(p metav1.Time) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Status.NextRetryTime = &p
+	}
+}
+
 func SetCertificateRequestTypeMeta(tm metav1.TypeMeta) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.TypeMeta = tm
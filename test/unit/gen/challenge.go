@@ -17,6 +17,8 @@ limitations under the License.
 package gen
 
 import (
+	"time"
+
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -79,6 +81,18 @@ func SetChallengePresented(p bool) ChallengeModifier {
 	}
 }
 
+func SetChallengePresentedTime(t metav1.Time) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Status.PresentedTime = &t
+	}
+}
+
+func SetChallengePreCheckDelay(d time.Duration) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Spec.Solver.PreCheckDelay = &metav1.Duration{Duration: d}
+	}
+}
+
 func SetChallengeWildcard(p bool) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Spec.Wildcard = p
@@ -109,6 +123,12 @@ func SetChallengeProcessing(b bool) ChallengeModifier {
 	}
 }
 
+func SetChallengeFallback(b bool) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Status.Fallback = b
+	}
+}
+
 func SetChallengeFinalizers(finalizers []string) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Finalizers = finalizers
@@ -66,3 +66,9 @@ func SetIssuerConditionMessage(s string) IssuerConditionModifier {
 		c.Message = s
 	}
 }
+
+func SetIssuerConditionObservedGeneration(g int64) IssuerConditionModifier {
+	return func(c *v1.IssuerCondition) {
+		c.ObservedGeneration = g
+	}
+}
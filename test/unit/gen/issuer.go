@@ -185,6 +185,21 @@ func SetIssuerACMEEAB(keyID, secretName string) IssuerModifier {
 	}
 }
 
+// SetIssuerACMEEABKeyFile returns an ACME Issuer modifier that sets ACME
+// External Account Binding with the key read from the named file.
+func SetIssuerACMEEABKeyFile(keyID, keyFile string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.ACME == nil {
+			spec.ACME = &cmacme.ACMEIssuer{}
+		}
+		spec.ACME.ExternalAccountBinding = &cmacme.ACMEExternalAccountBinding{
+			KeyID:   keyID,
+			KeyFile: keyFile,
+		}
+	}
+}
+
 // SetIssuerACMEEABWithKeyAlgorithm returns an ACME Issuer modifier that sets
 // ACME External Account Binding with the legacy keyAlgorithm field set.
 func SetIssuerACMEEABWithKeyAlgorithm(keyID, secretName string, keyAlgorithm cmacme.HMACKeyAlgorithm) IssuerModifier {
@@ -340,6 +355,12 @@ func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerTrustBundle(trustBundle []byte) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().TrustBundle = trustBundle
+	}
+}
+
 func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)